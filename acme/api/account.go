@@ -1,7 +1,10 @@
 package api
 
 import (
+	"context"
+	"crypto"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -14,7 +17,7 @@ type AccountService service
 func (a *AccountService) New(req acme.Account) (acme.ExtendedAccount, error) {
 	var account acme.Account
 
-	resp, err := a.core.post(a.core.GetDirectory().NewAccountURL, req, &account)
+	resp, err := a.core.post(context.Background(), a.core.GetDirectory().NewAccountURL, req, &account)
 	location := getLocation(resp)
 
 	if location != "" {
@@ -53,7 +56,7 @@ func (a *AccountService) Get(accountURL string) (acme.Account, error) {
 
 	var account acme.Account
 
-	_, err := a.core.postAsGet(accountURL, &account)
+	_, err := a.core.postAsGet(context.Background(), accountURL, &account)
 	if err != nil {
 		return acme.Account{}, err
 	}
@@ -69,7 +72,7 @@ func (a *AccountService) Update(accountURL string, req acme.Account) (acme.Accou
 
 	var account acme.Account
 
-	_, err := a.core.post(accountURL, req, &account)
+	_, err := a.core.post(context.Background(), accountURL, req, &account)
 	if err != nil {
 		return acme.Account{}, err
 	}
@@ -84,11 +87,35 @@ func (a *AccountService) Deactivate(accountURL string) error {
 	}
 
 	req := acme.Account{Status: acme.StatusDeactivated}
-	_, err := a.core.post(accountURL, req, nil)
+	_, err := a.core.post(context.Background(), accountURL, req, nil)
 
 	return err
 }
 
+// KeyChange Replaces the key of the account by the new key.
+// The requests are signed with the new key after the change.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.3.5
+func (a *AccountService) KeyChange(newKey crypto.PrivateKey) error {
+	keyChangeURL := a.core.GetDirectory().KeyChangeURL
+	if keyChangeURL == "" {
+		return errors.New("account[keyChange]: the server doesn't support the key change")
+	}
+
+	inner, err := a.core.jws.SignKeyChange(keyChangeURL, newKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.core.post(context.Background(), keyChangeURL, json.RawMessage(inner.FullSerialize()), nil)
+	if err != nil {
+		return err
+	}
+
+	a.core.jws.SetPrivateKey(newKey)
+
+	return nil
+}
+
 func decodeEABHmac(hmacEncoded string) ([]byte, error) {
 	hmac, errRaw := base64.RawURLEncoding.DecodeString(hmacEncoded)
 	if errRaw == nil {
@@ -1,8 +1,19 @@
 package api
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
 	"testing"
 
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-jose/go-jose/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -33,3 +44,93 @@ func Test_decodeEABHmac(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountService_KeyChange(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	newKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	var accountURL string
+
+	server := tester.MockACMEServer().
+		Route("POST /keyChange", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			reqBody, err := io.ReadAll(req.Body)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			// The outer JWS is signed with the old key of the account (kid).
+			outer, err := jose.ParseSigned(string(reqBody), []jose.SignatureAlgorithm{jose.RS256})
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if outer.Signatures[0].Protected.KeyID != accountURL {
+				http.Error(rw, "the outer JWS must be signed with the account", http.StatusBadRequest)
+				return
+			}
+
+			outerBody, err := outer.Verify(oldKey.Public())
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			// The inner JWS is signed with the new key (jwk), without nonce.
+			inner, err := jose.ParseSigned(string(outerBody), []jose.SignatureAlgorithm{jose.ES384})
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			header := inner.Signatures[0].Protected
+			if header.JSONWebKey == nil || header.Nonce != "" || header.ExtraHeaders["url"] != outer.Signatures[0].Protected.ExtraHeaders["url"] {
+				http.Error(rw, "invalid inner JWS header", http.StatusBadRequest)
+				return
+			}
+
+			innerBody, err := inner.Verify(newKey.Public())
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			var msg struct {
+				Account string          `json:"account"`
+				OldKey  jose.JSONWebKey `json:"oldKey"`
+			}
+
+			err = json.Unmarshal(innerBody, &msg)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if msg.Account != accountURL || !oldKey.PublicKey.Equal(msg.OldKey.Key) {
+				http.Error(rw, "invalid key change", http.StatusBadRequest)
+				return
+			}
+		})).
+		BuildHTTPS(t)
+
+	accountURL = server.URL + "/account/1"
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", accountURL, oldKey)
+	require.NoError(t, err)
+
+	err = core.Accounts.KeyChange(newKey)
+	require.NoError(t, err)
+
+	// The next requests are signed with the new key.
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	thumbprint, err := (&jose.JSONWebKey{Key: newKey.Public()}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	assert.Equal(t, "token."+base64.RawURLEncoding.EncodeToString(thumbprint), keyAuth)
+}
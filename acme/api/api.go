@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v5"
@@ -18,13 +20,18 @@ import (
 	"github.com/go-acme/lego/v4/log"
 )
 
+// DefaultBadNonceRetries the default maximum number of retries of a request rejected by the server with a badNonce error.
+const DefaultBadNonceRetries = 10
+
 // Core ACME/LE core API.
 type Core struct {
-	doer         *sender.Doer
-	nonceManager *nonces.Manager
-	jws          *secure.JWS
-	directory    acme.Directory
-	HTTPClient   *http.Client
+	doer            *sender.Doer
+	nonceManager    *nonces.Manager
+	jws             *secure.JWS
+	directory       acme.Directory
+	badNonceRetries int
+	logger          log.LeveledLogger
+	HTTPClient      *http.Client
 
 	common         service // Reuse a single struct instead of allocating one for each service on the heap.
 	Accounts       *AccountService
@@ -47,7 +54,14 @@ func New(httpClient *http.Client, userAgent, caDirURL, kid string, privateKey cr
 
 	jws := secure.NewJWS(privateKey, kid, nonceManager)
 
-	c := &Core{doer: doer, nonceManager: nonceManager, jws: jws, directory: dir, HTTPClient: httpClient}
+	c := &Core{
+		doer:            doer,
+		nonceManager:    nonceManager,
+		jws:             jws,
+		directory:       dir,
+		badNonceRetries: DefaultBadNonceRetries,
+		HTTPClient:      httpClient,
+	}
 
 	c.common.core = c
 	c.Accounts = (*AccountService)(&c.common)
@@ -61,31 +75,64 @@ func New(httpClient *http.Client, userAgent, caDirURL, kid string, privateKey cr
 
 // post performs an HTTP POST request and parses the response body as JSON,
 // into the provided respBody object.
-func (a *Core) post(uri string, reqBody, response any) (*http.Response, error) {
+func (a *Core) post(ctx context.Context, uri string, reqBody, response any) (*http.Response, error) {
+	return a.postWithJWS(ctx, a.jws, uri, reqBody, response)
+}
+
+// postWithJWS performs an HTTP POST request signed by the JWS, instead of the account key.
+func (a *Core) postWithJWS(ctx context.Context, jws *secure.JWS, uri string, reqBody, response any) (*http.Response, error) {
 	content, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, errors.New("failed to marshal message")
 	}
 
-	return a.retrievablePost(uri, content, response)
+	return a.retrievablePost(ctx, jws, uri, content, response)
 }
 
 // postAsGet performs an HTTP POST ("POST-as-GET") request.
 // https://www.rfc-editor.org/rfc/rfc8555.html#section-6.3
-func (a *Core) postAsGet(uri string, response any) (*http.Response, error) {
-	return a.retrievablePost(uri, []byte{}, response)
+func (a *Core) postAsGet(ctx context.Context, uri string, response any) (*http.Response, error) {
+	return a.retrievablePost(ctx, a.jws, uri, []byte{}, response)
+}
+
+// SetBadNonceRetries sets the maximum number of retries of a request rejected by the server with a badNonce error.
+// A negative value disables the retries.
+func (a *Core) SetBadNonceRetries(retries int) {
+	a.badNonceRetries = max(retries, 0)
+}
+
+// SetLogger sets the logger of the client, the default logger is used if nil.
+func (a *Core) SetLogger(logger log.LeveledLogger) {
+	a.logger = logger
 }
 
-func (a *Core) retrievablePost(uri string, content []byte, response any) (*http.Response, error) {
-	ctx := context.Background()
+// Logger returns the printer of the logger of the client.
+// The default logger is used if no logger is defined (or if the Core is nil).
+func (a *Core) Logger() log.Printer {
+	if a == nil {
+		return log.Printer{}
+	}
+
+	return log.NewPrinter(a.logger)
+}
 
+// retrievablePost performs a signed POST request.
+// All the signed requests go through this method:
+// a request rejected with a badNonce error is signed again, with a fresh nonce, and retried.
+// The request, and the retries, are canceled when the context is done.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-6.5
+func (a *Core) retrievablePost(ctx context.Context, jws *secure.JWS, uri string, content []byte, response any) (*http.Response, error) {
 	// during tests, allow to support ~90% of bad nonce with a minimum of attempts.
 	bo := backoff.NewExponentialBackOff()
 	bo.InitialInterval = 200 * time.Millisecond
 	bo.MaxInterval = 5 * time.Second
 
+	var attempts int
+
 	operation := func() (*http.Response, error) {
-		resp, err := a.signedPost(uri, content, response)
+		attempts++
+
+		resp, err := a.signedPost(ctx, jws, uri, content, response)
 		if err != nil {
 			// Retry if the nonce was invalidated
 			var e *acme.NonceError
@@ -100,24 +147,32 @@ func (a *Core) retrievablePost(uri string, content []byte, response any) (*http.
 	}
 
 	notify := func(err error, duration time.Duration) {
-		log.Infof("retry due to: %v", err)
+		a.Logger().Warnf("badNonce: retry %d/%d of %s in %s: %v", attempts, a.badNonceRetries, uri, duration, err)
 	}
 
-	return backoff.Retry(ctx, operation,
+	resp, err := backoff.Retry(ctx, operation,
 		backoff.WithBackOff(bo),
+		backoff.WithMaxTries(uint(a.badNonceRetries)+1),
 		backoff.WithMaxElapsedTime(20*time.Second),
 		backoff.WithNotify(notify))
+	if err != nil && attempts > 1 {
+		return resp, fmt.Errorf("after %d attempts: %w", attempts, err)
+	}
+
+	return resp, err
 }
 
-func (a *Core) signedPost(uri string, content []byte, response any) (*http.Response, error) {
-	signedContent, err := a.jws.SignContent(uri, content)
+func (a *Core) signedPost(ctx context.Context, jws *secure.JWS, uri string, content []byte, response any) (*http.Response, error) {
+	signedContent, err := jws.SignContent(uri, content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to post JWS message: failed to sign content: %w", err)
 	}
 
 	signedBody := bytes.NewBufferString(signedContent.FullSerialize())
 
-	resp, err := a.doer.Post(uri, signedBody, "application/jose+json", response)
+	resp, err := a.doer.PostWithContext(ctx, uri, signedBody, "application/jose+json", response)
+
+	a.logSchedulingHeaders(uri, resp)
 
 	// nonceErr is ignored to keep the root error.
 	nonce, nonceErr := nonces.GetFromResponse(resp)
@@ -128,6 +183,36 @@ func (a *Core) signedPost(uri string, content []byte, response any) (*http.Respo
 	return resp, err
 }
 
+// logSchedulingHeaders logs (debug level) the headers of the response used to schedule the next requests:
+// Retry-After, Link, and the rate-limit headers.
+func (a *Core) logSchedulingHeaders(uri string, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	var headers []string
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		headers = append(headers, "Retry-After="+retryAfter)
+	}
+
+	for _, link := range resp.Header.Values("Link") {
+		headers = append(headers, "Link="+link)
+	}
+
+	for name, values := range sender.RateLimitHeaders(resp.Header) {
+		headers = append(headers, name+"="+strings.Join(values, ","))
+	}
+
+	if len(headers) == 0 {
+		return
+	}
+
+	slices.Sort(headers)
+
+	a.Logger().Debugf("acme: response headers of %s [status=%d]: %s", uri, resp.StatusCode, strings.Join(headers, " "))
+}
+
 func (a *Core) signEABContent(newAccountURL, kid string, hmac []byte) ([]byte, error) {
 	eabJWS, err := a.jws.SignEABContent(newAccountURL, kid, hmac)
 	if err != nil {
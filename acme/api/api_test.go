@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBadNonceServer(t *testing.T, badNonces int32) (*Core, string, *atomic.Int32) {
+	t.Helper()
+
+	// small value keeps test fast
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Could not generate test key")
+
+	var attempts atomic.Int32
+
+	server := tester.MockACMEServer().
+		Route("POST /authz",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if attempts.Add(1) <= badNonces {
+					rw.Header().Set("Content-Type", "application/problem+json")
+					rw.WriteHeader(http.StatusBadRequest)
+
+					servermock.JSONEncode(acme.ProblemDetails{
+						Type:       acme.BadNonceErr,
+						Detail:     "JWS has an invalid anti-replay nonce",
+						HTTPStatus: http.StatusBadRequest,
+					}).ServeHTTP(rw, req)
+
+					return
+				}
+
+				servermock.JSONEncode(acme.Authorization{Status: acme.StatusValid}).ServeHTTP(rw, req)
+			})).
+		BuildHTTPS(t)
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	return core, server.URL + "/authz", &attempts
+}
+
+func TestCore_retrievablePost_badNonce(t *testing.T) {
+	core, authzURL, attempts := newBadNonceServer(t, 2)
+
+	authz, err := core.Authorizations.Get(authzURL)
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusValid, authz.Status)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestCore_retrievablePost_badNonce_limit(t *testing.T) {
+	core, authzURL, attempts := newBadNonceServer(t, 5)
+
+	core.SetBadNonceRetries(2)
+
+	_, err := core.Authorizations.Get(authzURL)
+
+	var nonceErr *acme.NonceError
+	require.ErrorAs(t, err, &nonceErr)
+	require.ErrorContains(t, err, "after 3 attempts")
+
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestCore_retrievablePost_badNonce_disabled(t *testing.T) {
+	core, authzURL, attempts := newBadNonceServer(t, 5)
+
+	core.SetBadNonceRetries(-1)
+
+	_, err := core.Authorizations.Get(authzURL)
+
+	var nonceErr *acme.NonceError
+	require.ErrorAs(t, err, &nonceErr)
+
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestCore_rateLimited(t *testing.T) {
+	// small value keeps test fast
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Could not generate test key")
+
+	server := tester.MockACMEServer().
+		Route("POST /newOrder",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Set("Retry-After", "120")
+				rw.Header().Set("Ratelimit-Policy", "300;w=10800")
+				rw.Header().Set("X-Ratelimit-Remaining", "0")
+
+				servermock.JSONEncode(acme.ProblemDetails{
+					Type:       acme.RateLimitedErr,
+					Detail:     "too many new orders",
+					HTTPStatus: http.StatusTooManyRequests,
+				}).WithStatusCode(http.StatusTooManyRequests).ServeHTTP(rw, req)
+			})).
+		BuildHTTPS(t)
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+
+	core.SetLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	_, err = core.Orders.New([]string{"example.com"})
+
+	var problem *acme.ProblemDetails
+	require.ErrorAs(t, err, &problem)
+
+	assert.Equal(t, "120", problem.RetryAfter)
+
+	expected := http.Header{
+		"Ratelimit-Policy":      []string{"300;w=10800"},
+		"X-Ratelimit-Remaining": []string{"0"},
+	}
+
+	assert.Equal(t, expected, problem.RateLimit)
+
+	var rateLimited *acme.RateLimitedError
+	require.ErrorAs(t, err, &rateLimited)
+
+	assert.Equal(t, "120", rateLimited.RetryAfter)
+
+	assert.Contains(t, buf.String(), "level=DEBUG")
+	assert.Contains(t, buf.String(), "Ratelimit-Policy=300;w=10800 Retry-After=120 X-Ratelimit-Remaining=0")
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 
 	"github.com/go-acme/lego/v4/acme"
@@ -10,13 +11,18 @@ type AuthorizationService service
 
 // Get Gets an authorization.
 func (c *AuthorizationService) Get(authzURL string) (acme.Authorization, error) {
+	return c.GetWithContext(context.Background(), authzURL)
+}
+
+// GetWithContext is like Get, but the request is canceled when the context is done.
+func (c *AuthorizationService) GetWithContext(ctx context.Context, authzURL string) (acme.Authorization, error) {
 	if authzURL == "" {
 		return acme.Authorization{}, errors.New("authorization[get]: empty URL")
 	}
 
 	var authz acme.Authorization
 
-	_, err := c.core.postAsGet(authzURL, &authz)
+	_, err := c.core.postAsGet(ctx, authzURL, &authz)
 	if err != nil {
 		return acme.Authorization{}, err
 	}
@@ -32,7 +38,7 @@ func (c *AuthorizationService) Deactivate(authzURL string) error {
 
 	var disabledAuth acme.Authorization
 
-	_, err := c.core.post(authzURL, acme.Authorization{Status: acme.StatusDeactivated}, &disabledAuth)
+	_, err := c.core.post(context.Background(), authzURL, acme.Authorization{Status: acme.StatusDeactivated}, &disabledAuth)
 
 	return err
 }
@@ -2,12 +2,15 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto"
 	"encoding/pem"
 	"errors"
 	"io"
 	"net/http"
 
 	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api/internal/secure"
 )
 
 // maxBodySize is the maximum size of body that we will read.
@@ -18,7 +21,7 @@ type CertificateService service
 // Get Returns the certificate and the issuer certificate.
 // 'bundle' is only applied if the issuer is provided by the 'up' link.
 func (c *CertificateService) Get(certURL string, bundle bool) ([]byte, []byte, error) {
-	cert, _, err := c.get(certURL, bundle)
+	cert, _, err := c.get(context.Background(), certURL, bundle)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -29,7 +32,12 @@ func (c *CertificateService) Get(certURL string, bundle bool) ([]byte, []byte, e
 // GetAll the certificates and the alternate certificates.
 // bundle' is only applied if the issuer is provided by the 'up' link.
 func (c *CertificateService) GetAll(certURL string, bundle bool) (map[string]*acme.RawCertificate, error) {
-	cert, headers, err := c.get(certURL, bundle)
+	return c.GetAllWithContext(context.Background(), certURL, bundle)
+}
+
+// GetAllWithContext is like GetAll, but the requests are canceled when the context is done.
+func (c *CertificateService) GetAllWithContext(ctx context.Context, certURL string, bundle bool) (map[string]*acme.RawCertificate, error) {
+	cert, headers, err := c.get(ctx, certURL, bundle)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +49,7 @@ func (c *CertificateService) GetAll(certURL string, bundle bool) (map[string]*ac
 	alts := getLinks(headers, "alternate")
 
 	for _, alt := range alts {
-		altCert, _, err := c.get(alt, bundle)
+		altCert, _, err := c.get(ctx, alt, bundle)
 		if err != nil {
 			return nil, err
 		}
@@ -54,17 +62,27 @@ func (c *CertificateService) GetAll(certURL string, bundle bool) (map[string]*ac
 
 // Revoke Revokes a certificate.
 func (c *CertificateService) Revoke(req acme.RevokeCertMessage) error {
-	_, err := c.core.post(c.core.GetDirectory().RevokeCertURL, req, nil)
+	_, err := c.core.post(context.Background(), c.core.GetDirectory().RevokeCertURL, req, nil)
+	return err
+}
+
+// RevokeWithKey Revokes a certificate, the request is signed with the private key of the certificate instead of the account key.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.6
+func (c *CertificateService) RevokeWithKey(req acme.RevokeCertMessage, privateKey crypto.PrivateKey) error {
+	jws := secure.NewJWS(privateKey, "", c.core.nonceManager)
+
+	_, err := c.core.postWithJWS(context.Background(), jws, c.core.GetDirectory().RevokeCertURL, req, nil)
+
 	return err
 }
 
 // get Returns the certificate and the "up" link.
-func (c *CertificateService) get(certURL string, bundle bool) (*acme.RawCertificate, http.Header, error) {
+func (c *CertificateService) get(ctx context.Context, certURL string, bundle bool) (*acme.RawCertificate, http.Header, error) {
 	if certURL == "" {
 		return nil, nil, errors.New("certificate[get]: empty URL")
 	}
 
-	resp, err := c.core.postAsGet(certURL, nil)
+	resp, err := c.core.postAsGet(ctx, certURL, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1,12 +1,19 @@
 package api
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/json"
+	"io"
+	"net/http"
 	"testing"
 
+	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-jose/go-jose/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -105,3 +112,54 @@ func TestCertificateService_Get_embeddedIssuer(t *testing.T) {
 	assert.Equal(t, certResponseMock, string(cert), "Certificate")
 	assert.Equal(t, issuerMock, string(issuer), "IssuerCertificate")
 }
+
+func TestCertificateService_RevokeWithKey(t *testing.T) {
+	accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	server := tester.MockACMEServer().
+		Route("POST /revokeCert", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			reqBody, err := io.ReadAll(req.Body)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			jws, err := jose.ParseSigned(string(reqBody), []jose.SignatureAlgorithm{jose.ES256})
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			// The key of the certificate is embedded (jwk), there is no account (kid).
+			header := jws.Signatures[0].Protected
+			if header.KeyID != "" || header.JSONWebKey == nil {
+				http.Error(rw, "the request must be signed with the embedded key of the certificate", http.StatusBadRequest)
+				return
+			}
+
+			body, err := jws.Verify(certKey.Public())
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			var msg acme.RevokeCertMessage
+
+			err = json.Unmarshal(body, &msg)
+			if err != nil || msg.Certificate != "cert" {
+				http.Error(rw, "invalid message", http.StatusBadRequest)
+				return
+			}
+		})).
+		BuildHTTPS(t)
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", server.URL+"/account/1", accountKey)
+	require.NoError(t, err)
+
+	err = core.Certificates.RevokeWithKey(acme.RevokeCertMessage{Certificate: "cert"}, certKey)
+	require.NoError(t, err)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 
 	"github.com/go-acme/lego/v4/acme"
@@ -10,6 +11,11 @@ type ChallengeService service
 
 // New Creates a challenge.
 func (c *ChallengeService) New(chlgURL string) (acme.ExtendedChallenge, error) {
+	return c.NewWithContext(context.Background(), chlgURL)
+}
+
+// NewWithContext is like New, but the request is canceled when the context is done.
+func (c *ChallengeService) NewWithContext(ctx context.Context, chlgURL string) (acme.ExtendedChallenge, error) {
 	if chlgURL == "" {
 		return acme.ExtendedChallenge{}, errors.New("challenge[new]: empty URL")
 	}
@@ -18,7 +24,7 @@ func (c *ChallengeService) New(chlgURL string) (acme.ExtendedChallenge, error) {
 	// We use an empty struct instance as the postJSON payload here to achieve this result.
 	var chlng acme.ExtendedChallenge
 
-	resp, err := c.core.post(chlgURL, struct{}{}, &chlng)
+	resp, err := c.core.post(ctx, chlgURL, struct{}{}, &chlng)
 	if err != nil {
 		return acme.ExtendedChallenge{}, err
 	}
@@ -37,7 +43,7 @@ func (c *ChallengeService) Get(chlgURL string) (acme.ExtendedChallenge, error) {
 
 	var chlng acme.ExtendedChallenge
 
-	resp, err := c.core.postAsGet(chlgURL, &chlng)
+	resp, err := c.core.postAsGet(context.Background(), chlgURL, &chlng)
 	if err != nil {
 		return acme.ExtendedChallenge{}, err
 	}
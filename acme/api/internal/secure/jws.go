@@ -6,9 +6,11 @@ import (
 	"crypto/elliptic"
 	"crypto/rsa"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 
 	"github.com/go-acme/lego/v4/acme/api/internal/nonces"
+	"github.com/go-acme/lego/v4/certcrypto"
 	jose "github.com/go-jose/go-jose/v4"
 )
 
@@ -35,21 +37,13 @@ func (j *JWS) SetKid(kid string) {
 
 // SignContent Signs a content with the JWS.
 func (j *JWS) SignContent(url string, content []byte) (*jose.JSONWebSignature, error) {
-	var alg jose.SignatureAlgorithm
-
-	switch k := j.privKey.(type) {
-	case *rsa.PrivateKey:
-		alg = jose.RS256
-	case *ecdsa.PrivateKey:
-		if k.Curve == elliptic.P256() {
-			alg = jose.ES256
-		} else if k.Curve == elliptic.P384() {
-			alg = jose.ES384
-		}
+	err := certcrypto.CheckFIPSPrivateKey(j.privKey)
+	if err != nil {
+		return nil, fmt.Errorf("account key: %w", err)
 	}
 
 	signKey := jose.SigningKey{
-		Algorithm: alg,
+		Algorithm: signatureAlgorithm(j.privKey),
 		Key:       jose.JSONWebKey{Key: j.privKey, KeyID: j.kid},
 	}
 
@@ -77,6 +71,54 @@ func (j *JWS) SignContent(url string, content []byte) (*jose.JSONWebSignature, e
 	return signed, nil
 }
 
+// SetPrivateKey replaces the private key of the JWS (e.g. after a key change).
+func (j *JWS) SetPrivateKey(privateKey crypto.PrivateKey) {
+	j.privKey = privateKey
+}
+
+// SignKeyChange Signs the content of a key change request (the inner JWS) with the new key.
+// The inner JWS has no nonce, and contains the new key (jwk).
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.3.5
+func (j *JWS) SignKeyChange(url string, newKey crypto.PrivateKey) (*jose.JSONWebSignature, error) {
+	err := certcrypto.CheckFIPSPrivateKey(newKey)
+	if err != nil {
+		return nil, fmt.Errorf("new account key: %w", err)
+	}
+
+	oldKey := jose.JSONWebKey{Key: j.privKey}
+
+	content, err := json.Marshal(keyChange{Account: j.kid, OldKey: oldKey.Public()})
+	if err != nil {
+		return nil, fmt.Errorf("acme: error encoding key change: %w", err)
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: signatureAlgorithm(newKey), Key: newKey},
+		&jose.SignerOptions{
+			EmbedJWK: true,
+			ExtraHeaders: map[jose.HeaderKey]any{
+				"url": url,
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key change jose signer: %w", err)
+	}
+
+	signed, err := signer.Sign(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign key change content: %w", err)
+	}
+
+	return signed, nil
+}
+
+// keyChange the payload of the inner JWS of a key change request.
+type keyChange struct {
+	Account string          `json:"account"`
+	OldKey  jose.JSONWebKey `json:"oldKey"`
+}
+
 // SignEABContent Signs an external account binding content with the JWS.
 func (j *JWS) SignEABContent(url, kid string, hmac []byte) (*jose.JSONWebSignature, error) {
 	jwk := jose.JSONWebKey{Key: j.privKey}
@@ -132,3 +174,18 @@ func (j *JWS) GetKeyAuthorization(token string) (string, error) {
 
 	return token + "." + keyThumb, nil
 }
+
+func signatureAlgorithm(privateKey crypto.PrivateKey) jose.SignatureAlgorithm {
+	switch k := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return jose.RS256
+	case *ecdsa.PrivateKey:
+		if k.Curve == elliptic.P256() {
+			return jose.ES256
+		} else if k.Curve == elliptic.P384() {
+			return jose.ES384
+		}
+	}
+
+	return ""
+}
@@ -1,6 +1,7 @@
 package sender
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -38,7 +39,7 @@ func NewDoer(client *http.Client, userAgent string) *Doer {
 // Get performs a GET request with a proper User-Agent string.
 // If "response" is not provided, callers should close resp.Body when done reading from it.
 func (d *Doer) Get(url string, response any) (*http.Response, error) {
-	req, err := d.newRequest(http.MethodGet, url, nil)
+	req, err := d.newRequest(context.Background(), http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +50,7 @@ func (d *Doer) Get(url string, response any) (*http.Response, error) {
 // Head performs a HEAD request with a proper User-Agent string.
 // The response body (resp.Body) is already closed when this function returns.
 func (d *Doer) Head(url string) (*http.Response, error) {
-	req, err := d.newRequest(http.MethodHead, url, nil)
+	req, err := d.newRequest(context.Background(), http.MethodHead, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +61,12 @@ func (d *Doer) Head(url string) (*http.Response, error) {
 // Post performs a POST request with a proper User-Agent string.
 // If "response" is not provided, callers should close resp.Body when done reading from it.
 func (d *Doer) Post(url string, body io.Reader, bodyType string, response any) (*http.Response, error) {
-	req, err := d.newRequest(http.MethodPost, url, body, contentType(bodyType))
+	return d.PostWithContext(context.Background(), url, body, bodyType, response)
+}
+
+// PostWithContext is like Post, but the request is canceled when the context is done.
+func (d *Doer) PostWithContext(ctx context.Context, url string, body io.Reader, bodyType string, response any) (*http.Response, error) {
+	req, err := d.newRequest(ctx, http.MethodPost, url, body, contentType(bodyType))
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +74,8 @@ func (d *Doer) Post(url string, body io.Reader, bodyType string, response any) (
 	return d.do(req, response)
 }
 
-func (d *Doer) newRequest(method, uri string, body io.Reader, opts ...RequestOption) (*http.Request, error) {
-	req, err := http.NewRequest(method, uri, body)
+func (d *Doer) newRequest(ctx context.Context, method, uri string, body io.Reader, opts ...RequestOption) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, uri, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -143,6 +149,10 @@ func checkError(req *http.Request, resp *http.Response) error {
 		errorDetails.HTTPStatus = resp.StatusCode
 	}
 
+	errorDetails.RetryAfter = resp.Header.Get("Retry-After")
+	errorDetails.Links = resp.Header.Values("Link")
+	errorDetails.RateLimit = RateLimitHeaders(resp.Header)
+
 	// Check for errors we handle specifically
 	switch {
 	case errorDetails.HTTPStatus == http.StatusBadRequest && errorDetails.Type == acme.BadNonceErr:
@@ -154,7 +164,7 @@ func checkError(req *http.Request, resp *http.Response) error {
 	case errorDetails.HTTPStatus == http.StatusTooManyRequests && errorDetails.Type == acme.RateLimitedErr:
 		return &acme.RateLimitedError{
 			ProblemDetails: errorDetails,
-			RetryAfter:     resp.Header.Get("Retry-After"),
+			RetryAfter:     errorDetails.RetryAfter,
 		}
 
 	default:
@@ -162,6 +172,27 @@ func checkError(req *http.Request, resp *http.Response) error {
 	}
 }
 
+// RateLimitHeaders returns the rate-limit headers (`RateLimit`, `RateLimit-*`, `X-RateLimit-*`), or nil if there is none.
+func RateLimitHeaders(header http.Header) http.Header {
+	var headers http.Header
+
+	for name, values := range header {
+		lower := strings.ToLower(name)
+
+		if lower != "ratelimit" && !strings.HasPrefix(lower, "ratelimit-") && !strings.HasPrefix(lower, "x-ratelimit-") {
+			continue
+		}
+
+		if headers == nil {
+			headers = make(http.Header)
+		}
+
+		headers[name] = values
+	}
+
+	return headers
+}
+
 type httpsOnly struct {
 	rt http.RoundTripper
 }
@@ -148,3 +148,40 @@ func errorAs[T error](t *testing.T, err error) {
 	var zero T
 	assert.ErrorAs(t, err, &zero)
 }
+
+func Test_checkError_headers(t *testing.T) {
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "https://example.com", nil)
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"Retry-After":           []string{"120"},
+			"Link":                  []string{`<https://example.com/docs/rate-limits>;rel="help"`},
+			"Ratelimit-Policy":      []string{"300;w=10800"},
+			"X-Ratelimit-Remaining": []string{"0"},
+			"Content-Type":          []string{"application/problem+json"},
+		},
+		Body: io.NopCloser(bytes.NewBufferString(`{"type":"urn:ietf:params:acme:error:rateLimited","detail":"message","status":429}`)),
+	}
+
+	err := checkError(req, resp)
+	require.Error(t, err)
+
+	var problem *acme.ProblemDetails
+	require.ErrorAs(t, err, &problem)
+
+	assert.Equal(t, "120", problem.RetryAfter)
+	assert.Equal(t, []string{`<https://example.com/docs/rate-limits>;rel="help"`}, problem.Links)
+
+	expected := http.Header{
+		"Ratelimit-Policy":      []string{"300;w=10800"},
+		"X-Ratelimit-Remaining": []string{"0"},
+	}
+
+	assert.Equal(t, expected, problem.RateLimit)
+
+	var rateLimited *acme.RateLimitedError
+	require.ErrorAs(t, err, &rateLimited)
+
+	assert.Equal(t, "120", rateLimited.RetryAfter)
+}
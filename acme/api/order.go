@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -35,6 +36,11 @@ func (o *OrderService) New(domains []string) (acme.ExtendedOrder, error) {
 
 // NewWithOptions Creates a new order.
 func (o *OrderService) NewWithOptions(domains []string, opts *OrderOptions) (acme.ExtendedOrder, error) {
+	return o.NewWithContext(context.Background(), domains, opts)
+}
+
+// NewWithContext is like NewWithOptions, but the requests are canceled when the context is done.
+func (o *OrderService) NewWithContext(ctx context.Context, domains []string, opts *OrderOptions) (acme.ExtendedOrder, error) {
 	orderReq := acme.Order{Identifiers: createIdentifiers(domains)}
 
 	if opts != nil {
@@ -57,7 +63,7 @@ func (o *OrderService) NewWithOptions(domains []string, opts *OrderOptions) (acm
 
 	var order acme.Order
 
-	resp, err := o.core.post(o.core.GetDirectory().NewOrderURL, orderReq, &order)
+	resp, err := o.core.post(ctx, o.core.GetDirectory().NewOrderURL, orderReq, &order)
 	if err != nil {
 		are := &acme.AlreadyReplacedError{}
 		if !errors.As(err, &are) {
@@ -69,7 +75,7 @@ func (o *OrderService) NewWithOptions(domains []string, opts *OrderOptions) (acm
 		// https://www.rfc-editor.org/rfc/rfc9773.html#section-5
 		orderReq.Replaces = ""
 
-		resp, err = o.core.post(o.core.GetDirectory().NewOrderURL, orderReq, &order)
+		resp, err = o.core.post(ctx, o.core.GetDirectory().NewOrderURL, orderReq, &order)
 		if err != nil {
 			return acme.ExtendedOrder{}, err
 		}
@@ -103,29 +109,77 @@ func (o *OrderService) NewWithOptions(domains []string, opts *OrderOptions) (acm
 
 // Get Gets an order.
 func (o *OrderService) Get(orderURL string) (acme.ExtendedOrder, error) {
+	return o.GetWithContext(context.Background(), orderURL)
+}
+
+// GetWithContext is like Get, but the request is canceled when the context is done.
+func (o *OrderService) GetWithContext(ctx context.Context, orderURL string) (acme.ExtendedOrder, error) {
 	if orderURL == "" {
 		return acme.ExtendedOrder{}, errors.New("order[get]: empty URL")
 	}
 
 	var order acme.Order
 
-	_, err := o.core.postAsGet(orderURL, &order)
+	resp, err := o.core.postAsGet(ctx, orderURL, &order)
 	if err != nil {
 		return acme.ExtendedOrder{}, err
 	}
 
-	return acme.ExtendedOrder{Order: order}, nil
+	return acme.ExtendedOrder{Order: order, RetryAfter: getRetryAfter(resp)}, nil
+}
+
+// List Lists the URLs of the orders of an account, following the pagination (Link header, rel="next").
+// The limit is the maximum number of URLs (no limit if the value is 0 or negative).
+// - https://www.rfc-editor.org/rfc/rfc8555.html#section-7.1.2.1
+func (o *OrderService) List(ordersURL string, limit int) ([]string, error) {
+	if ordersURL == "" {
+		return nil, errors.New("order[list]: empty URL")
+	}
+
+	var orders []string
+
+	visited := map[string]struct{}{}
+
+	for pageURL := ordersURL; pageURL != ""; {
+		if _, ok := visited[pageURL]; ok {
+			return nil, fmt.Errorf("order[list]: pagination loop: %s", pageURL)
+		}
+
+		visited[pageURL] = struct{}{}
+
+		var page acme.OrdersList
+
+		resp, err := o.core.postAsGet(context.Background(), pageURL, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, page.Orders...)
+
+		if limit > 0 && len(orders) >= limit {
+			return orders[:limit], nil
+		}
+
+		pageURL = getLink(resp.Header, "next")
+	}
+
+	return orders, nil
 }
 
 // UpdateForCSR Updates an order for a CSR.
 func (o *OrderService) UpdateForCSR(orderURL string, csr []byte) (acme.ExtendedOrder, error) {
+	return o.UpdateForCSRWithContext(context.Background(), orderURL, csr)
+}
+
+// UpdateForCSRWithContext is like UpdateForCSR, but the request is canceled when the context is done.
+func (o *OrderService) UpdateForCSRWithContext(ctx context.Context, orderURL string, csr []byte) (acme.ExtendedOrder, error) {
 	csrMsg := acme.CSRMessage{
 		Csr: base64.RawURLEncoding.EncodeToString(csr),
 	}
 
 	var order acme.Order
 
-	_, err := o.core.post(orderURL, csrMsg, &order)
+	resp, err := o.core.post(ctx, orderURL, csrMsg, &order)
 	if err != nil {
 		return acme.ExtendedOrder{}, err
 	}
@@ -134,5 +188,5 @@ func (o *OrderService) UpdateForCSR(orderURL string, csr []byte) (acme.ExtendedO
 		return acme.ExtendedOrder{}, fmt.Errorf("invalid order: %w", order.Err())
 	}
 
-	return acme.ExtendedOrder{Order: order}, nil
+	return acme.ExtendedOrder{Order: order, RetryAfter: getRetryAfter(resp)}, nil
 }
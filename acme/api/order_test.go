@@ -1,15 +1,20 @@
 package api
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/go-acme/lego/v4/platform/pebble/pebbletest"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
 	"github.com/go-jose/go-jose/v4"
@@ -87,6 +92,19 @@ func TestOrderService_NewWithOptions(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with replaces",
+			opts: &OrderOptions{
+				ReplacesCertID: "aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE",
+			},
+			expected: acme.ExtendedOrder{
+				Order: acme.Order{
+					Status:      "valid",
+					Identifiers: []acme.Identifier{{Type: "dns", Value: "example.com"}},
+					Replaces:    "aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE",
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -101,6 +119,138 @@ func TestOrderService_NewWithOptions(t *testing.T) {
 	}
 }
 
+func TestOrderService_NewWithOptions_profile(t *testing.T) {
+	server := pebbletest.NewServer(t, pebble.WithProfiles(map[string]string{
+		"shortlived": "https://ca.example/docs/profiles#shortlived",
+	}))
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	core, err := New(server.Client(), "lego-test", server.URL(), "", privateKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"shortlived": "https://ca.example/docs/profiles#shortlived"}, core.GetDirectory().Meta.Profiles)
+
+	_, err = core.Accounts.New(acme.Account{TermsOfServiceAgreed: true})
+	require.NoError(t, err)
+
+	order, err := core.Orders.NewWithOptions([]string{"example.com"}, &OrderOptions{Profile: "shortlived"})
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusPending, order.Status)
+	assert.Equal(t, "shortlived", order.Profile)
+
+	_, err = core.Orders.NewWithOptions([]string{"example.com"}, &OrderOptions{Profile: "unknown"})
+	require.ErrorContains(t, err, "urn:ietf:params:acme:error:invalidProfile")
+}
+
+func TestOrderService_NewWithOptions_alreadyReplaced(t *testing.T) {
+	// small value keeps test fast
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, errK, "Could not generate test key")
+
+	var replaces []string
+
+	server := tester.MockACMEServer().
+		Route("POST /newOrder",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := readSignedBody(req, privateKey)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				order := acme.Order{}
+
+				err = json.Unmarshal(body, &order)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				replaces = append(replaces, order.Replaces)
+
+				if order.Replaces != "" {
+					rw.Header().Set("Content-Type", "application/problem+json")
+					rw.WriteHeader(http.StatusConflict)
+					_, _ = rw.Write([]byte(`{"type":"urn:ietf:params:acme:error:alreadyReplaced","detail":"already replaced","status":409}`))
+
+					return
+				}
+
+				servermock.JSONEncode(acme.Order{
+					Status:      acme.StatusValid,
+					Identifiers: order.Identifiers,
+				}).ServeHTTP(rw, req)
+			})).
+		BuildHTTPS(t)
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	order, err := core.Orders.NewWithOptions([]string{"example.com"}, &OrderOptions{ReplacesCertID: "aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE"})
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusValid, order.Status)
+
+	// The order is created again without the replaces field.
+	assert.Equal(t, []string{"aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE", ""}, replaces)
+}
+
+func TestOrderService_List(t *testing.T) {
+	// small value keeps test fast
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, errK, "Could not generate test key")
+
+	server := tester.MockACMEServer().
+		Route("POST /orders",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+				if req.URL.Query().Get("cursor") == "" {
+					rw.Header().Set("Link", fmt.Sprintf(`<%s/orders?cursor=2>; rel="next"`, serverURL))
+
+					servermock.JSONEncode(acme.OrdersList{Orders: []string{serverURL + "/order/1", serverURL + "/order/2"}}).ServeHTTP(rw, req)
+
+					return
+				}
+
+				servermock.JSONEncode(acme.OrdersList{Orders: []string{serverURL + "/order/3"}}).ServeHTTP(rw, req)
+			})).
+		BuildHTTPS(t)
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		limit    int
+		expected []string
+	}{
+		{
+			desc:     "all the pages",
+			expected: []string{server.URL + "/order/1", server.URL + "/order/2", server.URL + "/order/3"},
+		},
+		{
+			desc:     "limit",
+			limit:    1,
+			expected: []string{server.URL + "/order/1"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			orders, err := core.Orders.List(server.URL+"/orders", test.limit)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, orders)
+		})
+	}
+}
+
 func readSignedBody(r *http.Request, privateKey *rsa.PrivateKey) ([]byte, error) {
 	reqBody, err := io.ReadAll(r.Body)
 	if err != nil {
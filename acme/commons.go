@@ -128,12 +128,23 @@ type Account struct {
 	ExternalAccountBinding json.RawMessage `json:"externalAccountBinding,omitempty"`
 }
 
+// OrdersList the ACME orders list object.
+// - https://www.rfc-editor.org/rfc/rfc8555.html#section-7.1.2.1
+type OrdersList struct {
+	// orders (required, array of string):
+	// An array of URLs, each identifying an order belonging to the account.
+	// The server SHOULD include pending orders and SHOULD NOT include orders that are invalid.
+	Orders []string `json:"orders"`
+}
+
 // ExtendedOrder a extended Order.
 type ExtendedOrder struct {
 	Order
 
 	// The order URL, contains the value of the response header `Location`
 	Location string `json:"-"`
+	// Contains the value of the response header `Retry-After` (e.g. while the order is processing)
+	RetryAfter string `json:"-"`
 }
 
 // Order the ACME order Object.
@@ -2,6 +2,7 @@ package acme
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 )
 
@@ -26,6 +27,11 @@ type ProblemDetails struct {
 	// additional values to have a better error message (Not defined by the RFC)
 	Method string `json:"method,omitempty"`
 	URL    string `json:"url,omitempty"`
+
+	// the values of the headers of the response, to schedule the next requests (Not defined by the RFC)
+	RetryAfter string      `json:"-"` // the header `Retry-After`
+	Links      []string    `json:"-"` // the headers `Link`
+	RateLimit  http.Header `json:"-"` // the rate-limit headers (`RateLimit`, `RateLimit-*`, `X-RateLimit-*`)
 }
 
 func (p *ProblemDetails) Error() string {
@@ -85,6 +91,7 @@ func (e *AlreadyReplacedError) Unwrap() error {
 type RateLimitedError struct {
 	*ProblemDetails
 
+	// Deprecated: use ProblemDetails.RetryAfter instead.
 	RetryAfter string
 }
 
@@ -3,8 +3,6 @@ package certcrypto
 import (
 	"crypto"
 	"crypto/ecdsa"
-	"crypto/ed25519"
-	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -95,6 +93,10 @@ func ParsePEMPrivateKey(key []byte) (crypto.PrivateKey, error) {
 		return nil, errors.New("invalid PEM block")
 	}
 
+	if keyBlockDER.Type == encryptedPrivateKeyType {
+		return nil, ErrEncryptedPrivateKey
+	}
+
 	if keyBlockDER.Type != "PRIVATE KEY" && !strings.HasSuffix(keyBlockDER.Type, " PRIVATE KEY") {
 		return nil, fmt.Errorf("unknown PEM header %q", keyBlockDER.Type)
 	}
@@ -105,7 +107,8 @@ func ParsePEMPrivateKey(key []byte) (crypto.PrivateKey, error) {
 
 	if key, err := x509.ParsePKCS8PrivateKey(keyBlockDER.Bytes); err == nil {
 		switch key := key.(type) {
-		case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+		case crypto.Signer:
+			// RSA, ECDSA, Ed25519, and the key types supported by the Go version (e.g. ML-DSA).
 			return key, nil
 		default:
 			return nil, fmt.Errorf("found unknown private key type in PKCS#8 wrapping: %T", key)
@@ -120,19 +123,13 @@ func ParsePEMPrivateKey(key []byte) (crypto.PrivateKey, error) {
 }
 
 func GeneratePrivateKey(keyType KeyType) (crypto.PrivateKey, error) {
-	switch keyType {
-	case EC256:
-		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	case EC384:
-		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
-	case RSA2048:
-		return rsa.GenerateKey(rand.Reader, 2048)
-	case RSA3072:
-		return rsa.GenerateKey(rand.Reader, 3072)
-	case RSA4096:
-		return rsa.GenerateKey(rand.Reader, 4096)
-	case RSA8192:
-		return rsa.GenerateKey(rand.Reader, 8192)
+	err := CheckFIPSKeyType(keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	if definition, ok := keyTypes[keyType]; ok {
+		return definition.generate()
 	}
 
 	return nil, fmt.Errorf("invalid KeyType: %s", keyType)
@@ -202,6 +199,12 @@ func PEMBlock(data any) *pem.Block {
 		pemBlock = &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: key.Raw}
 	case DERCertificateBytes:
 		pemBlock = &pem.Block{Type: "CERTIFICATE", Bytes: []byte(data.(DERCertificateBytes))}
+	case crypto.Signer:
+		// The other private keys (e.g. Ed25519, ML-DSA) only have a PKCS#8 encoding.
+		keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+		if err == nil {
+			pemBlock = &pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}
+		}
 	}
 
 	return pemBlock
@@ -229,6 +232,29 @@ func PemDecodeTox509CSR(data []byte) (*x509.CertificateRequest, error) {
 	return x509.ParseCertificateRequest(pemBlock.Bytes)
 }
 
+// ParseCSR parses a certificate request, PEM-encoded or DER-encoded.
+// With PEM, the last CERTIFICATE REQUEST block is used.
+func ParseCSR(data []byte) (*x509.CertificateRequest, error) {
+	raw := data
+
+	rest := data
+	for {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type == "CERTIFICATE REQUEST" || block.Type == "NEW CERTIFICATE REQUEST" {
+			raw = block.Bytes
+		}
+	}
+
+	// No PEM-encoded CSR: the data is assumed to be a DER-encoded CSR.
+	return x509.ParseCertificateRequest(raw)
+}
+
 // ParsePEMCertificate returns Certificate from a PEM encoded certificate.
 // The certificate has to be PEM encoded. Any other encodings like DER will fail.
 func ParsePEMCertificate(cert []byte) (*x509.Certificate, error) {
@@ -129,6 +129,48 @@ func TestGenerateCSR(t *testing.T) {
 	}
 }
 
+func TestParseCSR(t *testing.T) {
+	privateKey, err := GeneratePrivateKey(EC256)
+	require.NoError(t, err)
+
+	der, err := CreateCSR(privateKey, CSROptions{Domain: testDomain1, SAN: []string{testDomain1, testDomain2}})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc string
+		data []byte
+	}{
+		{
+			desc: "DER",
+			data: der,
+		},
+		{
+			desc: "PEM",
+			data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+		},
+		{
+			desc: "PEM with other blocks",
+			data: append(PEMEncode(privateKey), pem.EncodeToMemory(&pem.Block{Type: "NEW CERTIFICATE REQUEST", Bytes: der})...),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			csr, err := ParseCSR(test.data)
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{testDomain1, testDomain2}, csr.DNSNames)
+		})
+	}
+}
+
+func TestParseCSR_invalid(t *testing.T) {
+	_, err := ParseCSR([]byte("not a CSR"))
+	require.Error(t, err)
+}
+
 func TestPEMEncode(t *testing.T) {
 	key, err := rsa.GenerateKey(rand.Reader, 1024)
 	require.NoError(t, err, "Error generating private key")
@@ -0,0 +1,56 @@
+package certcrypto
+
+import (
+	"crypto"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/youmark/pkcs8"
+)
+
+// encryptedPrivateKeyType the PEM block type of an encrypted PKCS#8 private key.
+const encryptedPrivateKeyType = "ENCRYPTED PRIVATE KEY"
+
+// ErrEncryptedPrivateKey is returned when a private key is encrypted and no passphrase is provided.
+var ErrEncryptedPrivateKey = errors.New("the private key is encrypted, a passphrase is required")
+
+// PEMEncodeEncrypted encodes a private key as an encrypted PKCS#8 PEM block (ENCRYPTED PRIVATE KEY),
+// using PBES2 (PBKDF2 with HMAC-SHA256, and AES-256-CBC).
+func PEMEncodeEncrypted(privateKey crypto.PrivateKey, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+
+	der, err := pkcs8.MarshalPrivateKey(privateKey, passphrase, nil)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: encryptedPrivateKeyType, Bytes: der}), nil
+}
+
+// ParsePEMPrivateKeyWithPassphrase parses a private key from a PEM block.
+// The encrypted PKCS#8 private keys (ENCRYPTED PRIVATE KEY) are decrypted with the passphrase,
+// the other private keys are parsed as with ParsePEMPrivateKey.
+func ParsePEMPrivateKeyWithPassphrase(key, passphrase []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.New("invalid PEM block")
+	}
+
+	if block.Type != encryptedPrivateKeyType {
+		return ParsePEMPrivateKey(key)
+	}
+
+	if len(passphrase) == 0 {
+		return nil, ErrEncryptedPrivateKey
+	}
+
+	privateKey, _, err := pkcs8.ParsePrivateKey(block.Bytes, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt private key: %w", err)
+	}
+
+	return privateKey, nil
+}
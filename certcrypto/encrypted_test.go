@@ -0,0 +1,111 @@
+package certcrypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPEMEncodeEncrypted(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	data, err := PEMEncodeEncrypted(privateKey, []byte("secret"))
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+	assert.Equal(t, "ENCRYPTED PRIVATE KEY", block.Type)
+
+	decrypted, err := ParsePEMPrivateKeyWithPassphrase(data, []byte("secret"))
+	require.NoError(t, err)
+
+	assert.True(t, privateKey.Equal(decrypted))
+}
+
+func TestPEMEncodeEncrypted_emptyPassphrase(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = PEMEncodeEncrypted(privateKey, nil)
+	require.EqualError(t, err, "empty passphrase")
+}
+
+func TestParsePEMPrivateKeyWithPassphrase(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	encrypted, err := PEMEncodeEncrypted(privateKey, []byte("secret"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc       string
+		data       []byte
+		passphrase []byte
+		assert     func(t *testing.T, err error)
+	}{
+		{
+			desc: "plaintext key",
+			data: PEMEncode(privateKey),
+			assert: func(t *testing.T, err error) {
+				t.Helper()
+
+				require.NoError(t, err)
+			},
+		},
+		{
+			desc:       "encrypted key",
+			data:       encrypted,
+			passphrase: []byte("secret"),
+			assert: func(t *testing.T, err error) {
+				t.Helper()
+
+				require.NoError(t, err)
+			},
+		},
+		{
+			desc: "encrypted key without passphrase",
+			data: encrypted,
+			assert: func(t *testing.T, err error) {
+				t.Helper()
+
+				require.ErrorIs(t, err, ErrEncryptedPrivateKey)
+			},
+		},
+		{
+			desc:       "encrypted key with a wrong passphrase",
+			data:       encrypted,
+			passphrase: []byte("wrong"),
+			assert: func(t *testing.T, err error) {
+				t.Helper()
+
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParsePEMPrivateKeyWithPassphrase(test.data, test.passphrase)
+			test.assert(t, err)
+		})
+	}
+}
+
+func TestParsePEMPrivateKey_encrypted(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	encrypted, err := PEMEncodeEncrypted(privateKey, []byte("secret"))
+	require.NoError(t, err)
+
+	_, err = ParsePEMPrivateKey(encrypted)
+	require.ErrorIs(t, err, ErrEncryptedPrivateKey)
+}
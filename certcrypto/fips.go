@@ -0,0 +1,70 @@
+package certcrypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/fips140"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrNotFIPSApproved is returned, in FIPS mode, when an algorithm is not FIPS-approved.
+var ErrNotFIPSApproved = errors.New("not FIPS-approved")
+
+var fipsMode atomic.Bool
+
+// SetFIPSMode enables or disables the FIPS mode at runtime.
+func SetFIPSMode(enabled bool) {
+	fipsMode.Store(enabled)
+}
+
+// FIPSMode returns true if the FIPS mode is enabled.
+// In FIPS mode, only the FIPS-approved algorithms can be used.
+//
+// The FIPS mode is enabled:
+//   - when lego is built with the `fips` build tag, or with the boringcrypto Go experiment,
+//   - when the Go Cryptographic Module is in FIPS 140-3 mode (GODEBUG=fips140=on),
+//   - by calling SetFIPSMode.
+func FIPSMode() bool {
+	return fipsBuild || fips140.Enabled() || fipsMode.Load()
+}
+
+// CheckFIPSKeyType returns an error if the FIPS mode is enabled and the key type is not FIPS-approved.
+func CheckFIPSKeyType(keyType KeyType) error {
+	if !FIPSMode() || isFIPSKeyType(keyType) {
+		return nil
+	}
+
+	return fmt.Errorf("key type %s: %w", keyType, ErrNotFIPSApproved)
+}
+
+// CheckFIPSPrivateKey returns an error if the FIPS mode is enabled and the private key is not FIPS-approved:
+// only RSA keys (2048 bits or more) and ECDSA keys (P-256, P-384, P-521) are approved.
+func CheckFIPSPrivateKey(privateKey crypto.PrivateKey) error {
+	if !FIPSMode() {
+		return nil
+	}
+
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < 2048 {
+			return fmt.Errorf("RSA key of %d bits: %w", key.N.BitLen(), ErrNotFIPSApproved)
+		}
+
+		return nil
+
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+			return nil
+		default:
+			return fmt.Errorf("ECDSA key on curve %s: %w", key.Curve.Params().Name, ErrNotFIPSApproved)
+		}
+
+	default:
+		return fmt.Errorf("private key of type %T: %w", privateKey, ErrNotFIPSApproved)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !fips && !goexperiment.boringcrypto
+
+package certcrypto
+
+// fipsBuild the FIPS mode is enabled by the build.
+const fipsBuild = false
@@ -0,0 +1,66 @@
+package certcrypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func enableFIPSMode(t *testing.T) {
+	t.Helper()
+
+	SetFIPSMode(true)
+
+	t.Cleanup(func() {
+		SetFIPSMode(false)
+	})
+}
+
+func TestCheckFIPSKeyType(t *testing.T) {
+	enableFIPSMode(t)
+
+	require.NoError(t, CheckFIPSKeyType(EC256))
+	require.NoError(t, CheckFIPSKeyType(RSA2048))
+
+	err := CheckFIPSKeyType("ed25519")
+	require.ErrorIs(t, err, ErrNotFIPSApproved)
+}
+
+func TestCheckFIPSPrivateKey(t *testing.T) {
+	enableFIPSMode(t)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckFIPSPrivateKey(ecKey))
+
+	//nolint:gosec // the weak key is the purpose of the test.
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	err = CheckFIPSPrivateKey(rsaKey)
+	require.EqualError(t, err, "RSA key of 1024 bits: not FIPS-approved")
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	err = CheckFIPSPrivateKey(edKey)
+	require.ErrorIs(t, err, ErrNotFIPSApproved)
+}
+
+func TestCheckFIPSPrivateKey_disabled(t *testing.T) {
+	if FIPSMode() {
+		t.Skip("FIPS mode enabled by the build or the environment")
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	assert.NoError(t, CheckFIPSPrivateKey(edKey))
+}
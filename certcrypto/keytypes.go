@@ -0,0 +1,91 @@
+package certcrypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// keyTypeDefinition the definition of a key type.
+// A new algorithm only requires a new definition (see registerKeyType).
+type keyTypeDefinition struct {
+	// name the name of the key type in the CLI (e.g. "ec256").
+	name string
+	// generate generates a private key.
+	generate func() (crypto.PrivateKey, error)
+	// fips true if the key type is FIPS-approved.
+	fips bool
+	// jws true if the key type can be used for the ACME account key (JWS signature).
+	jws bool
+}
+
+// keyTypes the definitions of the supported key types.
+var keyTypes = map[KeyType]keyTypeDefinition{}
+
+func init() {
+	registerKeyType(EC256, keyTypeDefinition{name: "ec256", generate: generateECDSA(elliptic.P256()), fips: true, jws: true})
+	registerKeyType(EC384, keyTypeDefinition{name: "ec384", generate: generateECDSA(elliptic.P384()), fips: true, jws: true})
+	registerKeyType(RSA2048, keyTypeDefinition{name: "rsa2048", generate: generateRSA(2048), fips: true, jws: true})
+	registerKeyType(RSA3072, keyTypeDefinition{name: "rsa3072", generate: generateRSA(3072), fips: true, jws: true})
+	registerKeyType(RSA4096, keyTypeDefinition{name: "rsa4096", generate: generateRSA(4096), fips: true, jws: true})
+	registerKeyType(RSA8192, keyTypeDefinition{name: "rsa8192", generate: generateRSA(8192), fips: true, jws: true})
+}
+
+func registerKeyType(keyType KeyType, definition keyTypeDefinition) {
+	if _, ok := keyTypes[keyType]; ok {
+		panic(fmt.Sprintf("key type %s already registered", keyType))
+	}
+
+	keyTypes[keyType] = definition
+}
+
+// ParseKeyType returns the key type matching the name (case-insensitive, e.g. "ec256", "RSA4096").
+func ParseKeyType(name string) (KeyType, error) {
+	for keyType, definition := range keyTypes {
+		if strings.EqualFold(definition.name, name) {
+			return keyType, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported key type: %s", name)
+}
+
+// KeyTypeNames returns the names of the supported key types, sorted.
+func KeyTypeNames() []string {
+	var names []string
+	for definition := range maps.Values(keyTypes) {
+		names = append(names, definition.name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// IsJWSKeyType returns true if the key type can be used for the ACME account key (JWS signature).
+// Some key types are only supported for the certificates (e.g. the experimental post-quantum key types).
+func IsJWSKeyType(keyType KeyType) bool {
+	return keyTypes[keyType].jws
+}
+
+func isFIPSKeyType(keyType KeyType) bool {
+	return keyTypes[keyType].fips
+}
+
+func generateECDSA(curve elliptic.Curve) func() (crypto.PrivateKey, error) {
+	return func() (crypto.PrivateKey, error) {
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	}
+}
+
+func generateRSA(bits int) func() (crypto.PrivateKey, error) {
+	return func() (crypto.PrivateKey, error) {
+		return rsa.GenerateKey(rand.Reader, bits)
+	}
+}
@@ -0,0 +1,31 @@
+//go:build pqc && go1.27
+
+package certcrypto
+
+import (
+	"crypto"
+	"crypto/mldsa"
+)
+
+// Experimental post-quantum key types (ML-DSA, FIPS 204), for testing against the PQC-capable ACME CAs.
+// Only for the certificates: the ACME account keys (JWS) don't support them.
+// Requires the `pqc` build tag and Go 1.27 or later (the key types are not available otherwise).
+//
+// The hybrid (composite ML-DSA) keys are not supported: the Go standard library can't create composite signatures yet.
+const (
+	MLDSA44 = KeyType("MLDSA44")
+	MLDSA65 = KeyType("MLDSA65")
+	MLDSA87 = KeyType("MLDSA87")
+)
+
+func init() {
+	registerKeyType(MLDSA44, keyTypeDefinition{name: "mldsa44", generate: generateMLDSA(mldsa.MLDSA44())})
+	registerKeyType(MLDSA65, keyTypeDefinition{name: "mldsa65", generate: generateMLDSA(mldsa.MLDSA65())})
+	registerKeyType(MLDSA87, keyTypeDefinition{name: "mldsa87", generate: generateMLDSA(mldsa.MLDSA87())})
+}
+
+func generateMLDSA(params mldsa.Parameters) func() (crypto.PrivateKey, error) {
+	return func() (crypto.PrivateKey, error) {
+		return mldsa.GenerateKey(params)
+	}
+}
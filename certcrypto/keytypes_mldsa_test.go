@@ -0,0 +1,54 @@
+//go:build pqc && go1.27
+
+package certcrypto
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePrivateKey_mldsa(t *testing.T) {
+	testCases := []struct {
+		keyType   KeyType
+		algorithm x509.SignatureAlgorithm
+	}{
+		{keyType: MLDSA44, algorithm: x509.MLDSA44},
+		{keyType: MLDSA65, algorithm: x509.MLDSA65},
+		{keyType: MLDSA87, algorithm: x509.MLDSA87},
+	}
+
+	for _, test := range testCases {
+		t.Run(string(test.keyType), func(t *testing.T) {
+			t.Parallel()
+
+			privateKey, err := GeneratePrivateKey(test.keyType)
+			require.NoError(t, err)
+
+			// PEM round trip.
+			parsed, err := ParsePEMPrivateKey(PEMEncode(privateKey))
+			require.NoError(t, err)
+
+			csrRaw, err := CreateCSR(parsed, CSROptions{Domain: "example.com", SAN: []string{"example.com"}})
+			require.NoError(t, err)
+
+			csr, err := x509.ParseCertificateRequest(csrRaw)
+			require.NoError(t, err)
+
+			require.NoError(t, csr.CheckSignature())
+
+			assert.Equal(t, test.algorithm, csr.SignatureAlgorithm)
+			assert.Equal(t, x509.MLDSA, csr.PublicKeyAlgorithm)
+			assert.False(t, IsJWSKeyType(test.keyType))
+		})
+	}
+}
+
+func TestParseKeyType_mldsa(t *testing.T) {
+	keyType, err := ParseKeyType("MLDSA65")
+	require.NoError(t, err)
+
+	assert.Equal(t, MLDSA65, keyType)
+}
@@ -0,0 +1,41 @@
+package certcrypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected KeyType
+	}{
+		{name: "ec256", expected: EC256},
+		{name: "EC384", expected: EC384},
+		{name: "rsa2048", expected: RSA2048},
+		{name: "RSA8192", expected: RSA8192},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			keyType, err := ParseKeyType(test.name)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, keyType)
+			assert.True(t, IsJWSKeyType(keyType))
+		})
+	}
+}
+
+func TestParseKeyType_unsupported(t *testing.T) {
+	_, err := ParseKeyType("rsa1024")
+	require.EqualError(t, err, "unsupported key type: rsa1024")
+}
+
+func TestKeyTypeNames(t *testing.T) {
+	assert.Subset(t, KeyTypeNames(), []string{"ec256", "ec384", "rsa2048", "rsa3072", "rsa4096", "rsa8192"})
+}
@@ -1,13 +1,13 @@
 package certificate
 
 import (
+	"context"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
-	"github.com/go-acme/lego/v4/log"
 )
 
-func (c *Certifier) getAuthorizations(order acme.ExtendedOrder) ([]acme.Authorization, error) {
+func (c *Certifier) getAuthorizations(ctx context.Context, order acme.ExtendedOrder) ([]acme.Authorization, error) {
 	resc, errc := make(chan acme.Authorization), make(chan domainError)
 
 	delay := time.Second / time.Duration(c.overallRequestLimit)
@@ -16,7 +16,7 @@ func (c *Certifier) getAuthorizations(order acme.ExtendedOrder) ([]acme.Authoriz
 		time.Sleep(delay)
 
 		go func(authzURL string) {
-			authz, err := c.core.Authorizations.Get(authzURL)
+			authz, err := c.core.Authorizations.GetWithContext(ctx, authzURL)
 			if err != nil {
 				errc <- domainError{Domain: authz.Identifier.Value, Error: err}
 				return
@@ -40,7 +40,7 @@ func (c *Certifier) getAuthorizations(order acme.ExtendedOrder) ([]acme.Authoriz
 	}
 
 	for i, auth := range order.Authorizations {
-		log.Infof("[%s] AuthURL: %s", order.Identifiers[i].Value, auth)
+		c.core.Logger().Infof("[%s] AuthURL: %s", order.Identifiers[i].Value, auth)
 	}
 
 	close(resc)
@@ -53,19 +53,19 @@ func (c *Certifier) deactivateAuthorizations(order acme.ExtendedOrder, force boo
 	for _, authzURL := range order.Authorizations {
 		auth, err := c.core.Authorizations.Get(authzURL)
 		if err != nil {
-			log.Infof("Unable to get the authorization for %s: %v", authzURL, err)
+			c.core.Logger().Infof("Unable to get the authorization for %s: %v", authzURL, err)
 			continue
 		}
 
 		if auth.Status == acme.StatusValid && !force {
-			log.Infof("Skipping deactivating of valid auth: %s", authzURL)
+			c.core.Logger().Infof("Skipping deactivating of valid auth: %s", authzURL)
 			continue
 		}
 
-		log.Infof("Deactivating auth: %s", authzURL)
+		c.core.Logger().Infof("Deactivating auth: %s", authzURL)
 
 		if c.core.Authorizations.Deactivate(authzURL) != nil {
-			log.Infof("Unable to deactivate the authorization: %s", authzURL)
+			c.core.Logger().Infof("Unable to deactivate the authorization: %s", authzURL)
 		}
 	}
 }
@@ -2,13 +2,18 @@ package certificate
 
 import (
 	"bytes"
+	"cmp"
+	"context"
 	"crypto"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -74,6 +79,9 @@ type ObtainRequest struct {
 	NotAfter       time.Time
 	Bundle         bool
 	PreferredChain string
+	// ChainSelector selects the certificate chain among the chains offered by the CA.
+	// It takes precedence over PreferredChain.
+	ChainSelector ChainSelector
 
 	// A string uniquely identifying the profile
 	// which will be used to affect issuance of the certificate requested by this Order.
@@ -96,6 +104,9 @@ type ObtainRequest struct {
 // See https://datatracker.ietf.org/doc/html/rfc8555#section-7.5.2.
 type ObtainForCSRRequest struct {
 	CSR *x509.CertificateRequest
+	// CSRDER the DER-encoded CSR, used when CSR is nil
+	// (e.g. a CSR generated on the fly by an HSM front-end).
+	CSRDER []byte
 
 	PrivateKey crypto.PrivateKey
 
@@ -103,6 +114,9 @@ type ObtainForCSRRequest struct {
 	NotAfter       time.Time
 	Bundle         bool
 	PreferredChain string
+	// ChainSelector selects the certificate chain among the chains offered by the CA.
+	// It takes precedence over PreferredChain.
+	ChainSelector ChainSelector
 
 	// A string uniquely identifying the profile
 	// which will be used to affect issuance of the certificate requested by this Order.
@@ -121,11 +135,21 @@ type resolver interface {
 	Solve(authorizations []acme.Authorization) error
 }
 
+// contextResolver is implemented by the resolvers that can cancel the challenges.
+type contextResolver interface {
+	SolveWithContext(ctx context.Context, authorizations []acme.Authorization) error
+}
+
 type CertifierOptions struct {
-	KeyType             certcrypto.KeyType
-	Timeout             time.Duration
-	OverallRequestLimit int
-	DisableCommonName   bool
+	KeyType certcrypto.KeyType
+	// Timeout the overall deadline to wait for the certificate, after the finalization of the order (30 seconds if not defined).
+	Timeout time.Duration
+	// OrderPollInterval the interval between the polls of the order while it is processed (Timeout/60 if not defined).
+	OrderPollInterval time.Duration
+	// OrderPollAttemptTimeout the timeout of each poll of the order (only the timeout of the HTTP client if not defined).
+	OrderPollAttemptTimeout time.Duration
+	OverallRequestLimit     int
+	DisableCommonName       bool
 }
 
 // Certifier A service to obtain/renew/revoke certificates.
@@ -157,6 +181,12 @@ func NewCertifier(core *api.Core, resolver resolver, options CertifierOptions) *
 // This function will never return a partial certificate.
 // If one domain in the list fails, the whole certificate will fail.
 func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
+	return c.ObtainWithContext(context.Background(), request)
+}
+
+// ObtainWithContext is like Obtain, but the order is aborted when the context is done (e.g. on SIGINT):
+// the requests and the challenges are canceled, and the pending authorizations of an aborted order are deactivated.
+func (c *Certifier) ObtainWithContext(ctx context.Context, request ObtainRequest) (*Resource, error) {
 	if len(request.Domains) == 0 {
 		return nil, errors.New("no domains to obtain a certificate for")
 	}
@@ -164,9 +194,9 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 	domains := sanitizeDomain(request.Domains)
 
 	if request.Bundle {
-		log.Infof("[%s] acme: Obtaining bundled SAN certificate", strings.Join(domains, ", "))
+		c.core.Logger().Infof("[%s] acme: Obtaining bundled SAN certificate", strings.Join(domains, ", "))
 	} else {
-		log.Infof("[%s] acme: Obtaining SAN certificate", strings.Join(domains, ", "))
+		c.core.Logger().Infof("[%s] acme: Obtaining SAN certificate", strings.Join(domains, ", "))
 	}
 
 	orderOpts := &api.OrderOptions{
@@ -176,30 +206,38 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 		ReplacesCertID: request.ReplacesCertID,
 	}
 
-	order, err := c.core.Orders.NewWithOptions(domains, orderOpts)
+	order, err := c.core.Orders.NewWithContext(ctx, domains, orderOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	authz, err := c.getAuthorizations(order)
+	authz, err := c.getAuthorizations(ctx, order)
+	if err == nil {
+		err = ctx.Err()
+	}
+
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
 		c.deactivateAuthorizations(order, request.AlwaysDeactivateAuthorizations)
 		return nil, err
 	}
 
-	err = c.resolver.Solve(authz)
+	err = c.solve(ctx, authz)
+	if err == nil {
+		err = ctx.Err()
+	}
+
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
 		c.deactivateAuthorizations(order, request.AlwaysDeactivateAuthorizations)
 		return nil, err
 	}
 
-	log.Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
+	c.core.Logger().Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
 
 	failures := newObtainError()
 
-	cert, err := c.getForOrder(domains, order, request)
+	cert, err := c.getForOrder(ctx, domains, order, request)
 	if err != nil {
 		for _, auth := range authz {
 			failures.Add(challenge.GetTargetedDomain(auth), err)
@@ -223,6 +261,20 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 // This function will never return a partial certificate.
 // If one domain in the list fails, the whole certificate will fail.
 func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error) {
+	return c.ObtainForCSRWithContext(context.Background(), request)
+}
+
+// ObtainForCSRWithContext is like ObtainForCSR, but the order is aborted when the context is done (see ObtainWithContext).
+func (c *Certifier) ObtainForCSRWithContext(ctx context.Context, request ObtainForCSRRequest) (*Resource, error) {
+	if request.CSR == nil && len(request.CSRDER) > 0 {
+		csr, err := x509.ParseCertificateRequest(request.CSRDER)
+		if err != nil {
+			return nil, fmt.Errorf("cannot obtain resource for CSR: %w", err)
+		}
+
+		request.CSR = csr
+	}
+
 	if request.CSR == nil {
 		return nil, errors.New("cannot obtain resource for CSR: CSR is missing")
 	}
@@ -232,9 +284,9 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 	domains := certcrypto.ExtractDomainsCSR(request.CSR)
 
 	if request.Bundle {
-		log.Infof("[%s] acme: Obtaining bundled SAN certificate given a CSR", strings.Join(domains, ", "))
+		c.core.Logger().Infof("[%s] acme: Obtaining bundled SAN certificate given a CSR", strings.Join(domains, ", "))
 	} else {
-		log.Infof("[%s] acme: Obtaining SAN certificate given a CSR", strings.Join(domains, ", "))
+		c.core.Logger().Infof("[%s] acme: Obtaining SAN certificate given a CSR", strings.Join(domains, ", "))
 	}
 
 	orderOpts := &api.OrderOptions{
@@ -244,26 +296,34 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 		ReplacesCertID: request.ReplacesCertID,
 	}
 
-	order, err := c.core.Orders.NewWithOptions(domains, orderOpts)
+	order, err := c.core.Orders.NewWithContext(ctx, domains, orderOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	authz, err := c.getAuthorizations(order)
+	authz, err := c.getAuthorizations(ctx, order)
+	if err == nil {
+		err = ctx.Err()
+	}
+
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
 		c.deactivateAuthorizations(order, request.AlwaysDeactivateAuthorizations)
 		return nil, err
 	}
 
-	err = c.resolver.Solve(authz)
+	err = c.solve(ctx, authz)
+	if err == nil {
+		err = ctx.Err()
+	}
+
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
 		c.deactivateAuthorizations(order, request.AlwaysDeactivateAuthorizations)
 		return nil, err
 	}
 
-	log.Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
+	c.core.Logger().Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
 
 	failures := newObtainError()
 
@@ -272,7 +332,7 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 		privateKey = certcrypto.PEMEncode(request.PrivateKey)
 	}
 
-	cert, err := c.getForCSR(domains, order, request.Bundle, request.CSR.Raw, privateKey, request.PreferredChain)
+	cert, err := c.getForCSR(ctx, domains, order, request.Bundle, request.CSR.Raw, privateKey, chainSelector(request.ChainSelector, request.PreferredChain))
 	if err != nil {
 		for _, auth := range authz {
 			failures.Add(challenge.GetTargetedDomain(auth), err)
@@ -291,7 +351,16 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 	return cert, failures.Join()
 }
 
-func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, request ObtainRequest) (*Resource, error) {
+// solve solves the challenges of the authorizations, the resolvers that can be canceled use the context.
+func (c *Certifier) solve(ctx context.Context, authz []acme.Authorization) error {
+	if r, ok := c.resolver.(contextResolver); ok {
+		return r.SolveWithContext(ctx, authz)
+	}
+
+	return c.resolver.Solve(authz)
+}
+
+func (c *Certifier) getForOrder(ctx context.Context, domains []string, order acme.ExtendedOrder, request ObtainRequest) (*Resource, error) {
 	privateKey := request.PrivateKey
 
 	if privateKey == nil {
@@ -301,6 +370,8 @@ func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, requ
 		if err != nil {
 			return nil, err
 		}
+	} else if err := certcrypto.CheckFIPSPrivateKey(privateKey); err != nil {
+		return nil, err
 	}
 
 	commonName := ""
@@ -338,11 +409,11 @@ func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, requ
 		return nil, err
 	}
 
-	return c.getForCSR(domains, order, request.Bundle, csr, certcrypto.PEMEncode(privateKey), request.PreferredChain)
+	return c.getForCSR(ctx, domains, order, request.Bundle, csr, certcrypto.PEMEncode(privateKey), chainSelector(request.ChainSelector, request.PreferredChain))
 }
 
-func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle bool, csr, privateKeyPem []byte, preferredChain string) (*Resource, error) {
-	respOrder, err := c.core.Orders.UpdateForCSR(order.Finalize, csr)
+func (c *Certifier) getForCSR(ctx context.Context, domains []string, order acme.ExtendedOrder, bundle bool, csr, privateKeyPem []byte, selector ChainSelector) (*Resource, error) {
+	respOrder, err := c.core.Orders.UpdateForCSRWithContext(ctx, order.Finalize, csr)
 	if err != nil {
 		return nil, err
 	}
@@ -355,7 +426,7 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 
 	if respOrder.Status == acme.StatusValid {
 		// if the certificate is available right away, shortcut!
-		ok, errR := c.checkResponse(respOrder, certRes, bundle, preferredChain)
+		ok, errR := c.checkResponse(ctx, respOrder, certRes, bundle, selector)
 		if errR != nil {
 			return nil, errR
 		}
@@ -370,23 +441,56 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 		timeout = 30 * time.Second
 	}
 
-	err = wait.For("certificate", timeout, timeout/60, func() (bool, error) {
-		ord, errW := c.core.Orders.Get(order.Location)
-		if errW != nil {
-			return false, errW
-		}
-
-		done, errW := c.checkResponse(ord, certRes, bundle, preferredChain)
-		if errW != nil {
-			return false, errW
-		}
+	interval := c.options.OrderPollInterval
+	if interval <= 0 {
+		interval = timeout / 60
+	}
 
-		return done, nil
+	err = wait.ForWithContext(ctx, "certificate", timeout, interval, func() (bool, error) {
+		return c.pollOrder(ctx, order.Location, certRes, bundle, selector)
 	})
 
 	return certRes, err
 }
 
+// pollOrder gets the order, and loads the certificate into certRes if it is ready.
+// The requests of an attempt longer than the OrderPollAttemptTimeout option are canceled.
+func (c *Certifier) pollOrder(ctx context.Context, orderURL string, certRes *Resource, bundle bool, selector ChainSelector) (bool, error) {
+	if c.options.OrderPollAttemptTimeout <= 0 {
+		return c.checkOrder(ctx, orderURL, certRes, bundle, selector)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, c.options.OrderPollAttemptTimeout)
+	defer cancel()
+
+	// The attempt works on a copy: a canceled attempt must not modify the resource.
+	attempt := *certRes
+
+	done, err := c.checkOrder(attemptCtx, orderURL, &attempt, bundle, selector)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+			return false, fmt.Errorf("order poll: time limit exceeded (%s)", c.options.OrderPollAttemptTimeout)
+		}
+
+		return false, err
+	}
+
+	if done {
+		*certRes = attempt
+	}
+
+	return done, nil
+}
+
+func (c *Certifier) checkOrder(ctx context.Context, orderURL string, certRes *Resource, bundle bool, selector ChainSelector) (bool, error) {
+	ord, err := c.core.Orders.GetWithContext(ctx, orderURL)
+	if err != nil {
+		return false, err
+	}
+
+	return c.checkResponse(ctx, ord, certRes, bundle, selector)
+}
+
 // checkResponse checks to see if the certificate is ready and a link is contained in the response.
 //
 // If so, loads it into certRes and returns true.
@@ -395,13 +499,13 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 // The certRes input should already have the Domain (common name) field populated.
 //
 // If bundle is true, the certificate will be bundled with the issuer's cert.
-func (c *Certifier) checkResponse(order acme.ExtendedOrder, certRes *Resource, bundle bool, preferredChain string) (bool, error) {
+func (c *Certifier) checkResponse(ctx context.Context, order acme.ExtendedOrder, certRes *Resource, bundle bool, selector ChainSelector) (bool, error) {
 	valid, err := checkOrderStatus(order)
 	if err != nil || !valid {
 		return valid, err
 	}
 
-	certs, err := c.core.Certificates.GetAll(order.Certificate, bundle)
+	certs, err := c.core.Certificates.GetAllWithContext(ctx, order.Certificate, bundle)
 	if err != nil {
 		return false, err
 	}
@@ -412,31 +516,29 @@ func (c *Certifier) checkResponse(order acme.ExtendedOrder, certRes *Resource, b
 	certRes.CertURL = order.Certificate
 	certRes.CertStableURL = order.Certificate
 
-	if preferredChain == "" {
-		log.Infof("[%s] Server responded with a certificate.", certRes.Domain)
+	if selector == nil {
+		c.core.Logger().Infof("[%s] Server responded with a certificate.", certRes.Domain)
 
 		return true, nil
 	}
 
-	for link, cert := range certs {
-		ok, err := hasPreferredChain(cert.Issuer, preferredChain)
-		if err != nil {
-			return false, err
-		}
+	chain, err := selectChain(certs, order.Certificate, selector)
+	if err != nil {
+		return false, err
+	}
 
-		if ok {
-			log.Infof("[%s] Server responded with a certificate for the preferred certificate chains %q.", certRes.Domain, preferredChain)
+	if chain != nil {
+		c.core.Logger().Infof("[%s] Server responded with a certificate for the preferred certificate chain (root %q).", certRes.Domain, chain.Root)
 
-			certRes.IssuerCertificate = cert.Issuer
-			certRes.Certificate = cert.Cert
-			certRes.CertURL = link
-			certRes.CertStableURL = link
+		certRes.IssuerCertificate = chain.IssuerCertificate
+		certRes.Certificate = chain.Certificate
+		certRes.CertURL = chain.URL
+		certRes.CertStableURL = chain.URL
 
-			return true, nil
-		}
+		return true, nil
 	}
 
-	log.Infof("lego has been configured to prefer certificate chains with issuer %q, but no chain from the CA matched this issuer. Using the default certificate chain instead.", preferredChain)
+	c.core.Logger().Infof("[%s] lego has been configured to prefer a certificate chain, but no chain from the CA matched. Using the default certificate chain instead.", certRes.Domain)
 
 	return true, nil
 }
@@ -447,7 +549,13 @@ func (c *Certifier) Revoke(cert []byte) error {
 }
 
 // RevokeWithReason takes a PEM encoded certificate or bundle and tries to revoke it at the CA.
+// The reason (optional) must be a valid RevocationReason.
 func (c *Certifier) RevokeWithReason(cert []byte, reason *uint) error {
+	err := validateRevocationReason(reason)
+	if err != nil {
+		return err
+	}
+
 	certificates, err := certcrypto.ParsePEMBundle(cert)
 	if err != nil {
 		return err
@@ -466,6 +574,44 @@ func (c *Certifier) RevokeWithReason(cert []byte, reason *uint) error {
 	return c.core.Certificates.Revoke(revokeMsg)
 }
 
+// RevokeWithPrivateKey takes a PEM encoded certificate or bundle and tries to revoke it at the CA.
+// The request is signed with the private key of the certificate instead of the account key:
+// a certificate can be revoked without the account used to obtain it.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.6
+func (c *Certifier) RevokeWithPrivateKey(cert []byte, reason *uint, privateKey crypto.PrivateKey) error {
+	err := validateRevocationReason(reason)
+	if err != nil {
+		return err
+	}
+
+	certificates, err := certcrypto.ParsePEMBundle(cert)
+	if err != nil {
+		return err
+	}
+
+	x509Cert := certificates[0]
+	if x509Cert.IsCA {
+		return errors.New("certificate bundle starts with a CA certificate")
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return errors.New("unsupported private key")
+	}
+
+	publicKey, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(x509Cert.PublicKey) {
+		return errors.New("the private key doesn't match the certificate")
+	}
+
+	revokeMsg := acme.RevokeCertMessage{
+		Certificate: base64.RawURLEncoding.EncodeToString(x509Cert.Raw),
+		Reason:      reason,
+	}
+
+	return c.core.Certificates.RevokeWithKey(revokeMsg, privateKey)
+}
+
 // RenewOptions options used by Certifier.RenewWithOptions.
 type RenewOptions struct {
 	NotBefore time.Time
@@ -473,6 +619,9 @@ type RenewOptions struct {
 	// If true, the []byte contains both the issuer certificate and your issued certificate as a bundle.
 	Bundle         bool
 	PreferredChain string
+	// ChainSelector selects the certificate chain among the chains offered by the CA.
+	// It takes precedence over PreferredChain.
+	ChainSelector ChainSelector
 
 	Profile string
 
@@ -528,7 +677,7 @@ func (c *Certifier) RenewWithOptions(certRes Resource, options *RenewOptions) (*
 
 	// This is just meant to be informal for the user.
 	timeLeft := x509Cert.NotAfter.Sub(time.Now().UTC())
-	log.Infof("[%s] acme: Trying renewal with %d hours remaining", certRes.Domain, int(timeLeft.Hours()))
+	c.core.Logger().Infof("[%s] acme: Trying renewal with %d hours remaining", certRes.Domain, int(timeLeft.Hours()))
 
 	// We always need to request a new certificate to renew.
 	// Start by checking to see if the certificate was based off a CSR,
@@ -546,6 +695,7 @@ func (c *Certifier) RenewWithOptions(certRes Resource, options *RenewOptions) (*
 			request.NotAfter = options.NotAfter
 			request.Bundle = options.Bundle
 			request.PreferredChain = options.PreferredChain
+			request.ChainSelector = options.ChainSelector
 			request.Profile = options.Profile
 			request.AlwaysDeactivateAuthorizations = options.AlwaysDeactivateAuthorizations
 		}
@@ -572,6 +722,7 @@ func (c *Certifier) RenewWithOptions(certRes Resource, options *RenewOptions) (*
 		request.NotAfter = options.NotAfter
 		request.Bundle = options.Bundle
 		request.PreferredChain = options.PreferredChain
+		request.ChainSelector = options.ChainSelector
 		request.EmailAddresses = options.EmailAddresses
 		request.Profile = options.Profile
 		request.AlwaysDeactivateAuthorizations = options.AlwaysDeactivateAuthorizations
@@ -608,22 +759,7 @@ func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
 
 	if len(certificates) == 1 {
 		// TODO: build fallback. If this fails, check the remaining array entries.
-		if len(issuedCert.IssuingCertificateURL) == 0 {
-			return nil, nil, errors.New("no issuing certificate URL")
-		}
-
-		resp, errC := c.core.HTTPClient.Get(issuedCert.IssuingCertificateURL[0])
-		if errC != nil {
-			return nil, nil, errC
-		}
-		defer resp.Body.Close()
-
-		issuerBytes, errC := io.ReadAll(http.MaxBytesReader(nil, resp.Body, maxBodySize))
-		if errC != nil {
-			return nil, nil, errC
-		}
-
-		issuerCert, errC := x509.ParseCertificate(issuerBytes)
+		issuerCert, errC := c.getIssuerCertificate(issuedCert)
 		if errC != nil {
 			return nil, nil, errC
 		}
@@ -636,6 +772,30 @@ func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
 	issuerCert := certificates[1]
 
 	// Finally kick off the OCSP request.
+	return c.requestOCSP(issuedCert, issuerCert)
+}
+
+// getIssuerCertificate fetches the issuer certificate from the IssuingCertificateURL of the certificate.
+func (c *Certifier) getIssuerCertificate(cert *x509.Certificate) (*x509.Certificate, error) {
+	if len(cert.IssuingCertificateURL) == 0 {
+		return nil, errors.New("no issuing certificate URL")
+	}
+
+	resp, err := c.core.HTTPClient.Get(cert.IssuingCertificateURL[0])
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	issuerBytes, err := io.ReadAll(http.MaxBytesReader(nil, resp.Body, maxBodySize))
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(issuerBytes)
+}
+
+func (c *Certifier) requestOCSP(issuedCert, issuerCert *x509.Certificate) ([]byte, *ocsp.Response, error) {
 	ocspReq, err := ocsp.CreateRequest(issuedCert, issuerCert, nil)
 	if err != nil {
 		return nil, nil, err
@@ -692,6 +852,211 @@ func (c *Certifier) Get(url string, bundle bool) (*Resource, error) {
 	}, nil
 }
 
+// GetByURLRequest the request to fetch a previously issued certificate.
+//
+// If `ChainSelector` or `PreferredChain` is set, the selected chain is used (see PreferredChainSelector),
+// otherwise the default chain is used.
+//
+// If `PrivateKey` is set, it must match the public key of the certificate,
+// and it is added to the returned Resource.
+type GetByURLRequest struct {
+	URL            string
+	Bundle         bool
+	PreferredChain string
+	ChainSelector  ChainSelector
+	PrivateKey     crypto.PrivateKey
+}
+
+// GetByURL fetches a previously issued certificate (e.g. from an order completed by another process or an interrupted run),
+// without creating a new order.
+//
+// The returned Resource will not have the CSR field populated,
+// and the PrivateKey field is only populated if the request contains the private key.
+func (c *Certifier) GetByURL(request GetByURLRequest) (*Resource, error) {
+	if request.URL == "" {
+		return nil, errors.New("certificate URL is missing")
+	}
+
+	certs, err := c.core.Certificates.GetAll(request.URL, request.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	link := request.URL
+
+	if selector := chainSelector(request.ChainSelector, request.PreferredChain); selector != nil {
+		chain, errS := selectChain(certs, request.URL, selector)
+		if errS != nil {
+			return nil, errS
+		}
+
+		if chain != nil {
+			link = chain.URL
+		} else {
+			c.core.Logger().Infof("lego has been configured to prefer a certificate chain, but no chain from the CA matched. Using the default certificate chain instead.")
+		}
+	}
+
+	cert, err := certcrypto.ParsePEMCertificate(certs[link].Cert)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := certcrypto.GetCertificateMainDomain(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	certRes := &Resource{
+		Domain:            domain,
+		Certificate:       certs[link].Cert,
+		IssuerCertificate: certs[link].Issuer,
+		CertURL:           link,
+		CertStableURL:     link,
+	}
+
+	if request.PrivateKey != nil {
+		err = checkPublicKey(cert, request.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] %w", domain, err)
+		}
+
+		certRes.PrivateKey = certcrypto.PEMEncode(request.PrivateKey)
+	}
+
+	return certRes, nil
+}
+
+// checkPublicKey checks that the private key matches the public key of the certificate.
+func checkPublicKey(cert *x509.Certificate, privateKey crypto.PrivateKey) error {
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return fmt.Errorf("unsupported private key type: %T", privateKey)
+	}
+
+	publicKey, ok := cert.PublicKey.(interface{ Equal(x crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(signer.Public()) {
+		return errors.New("the private key doesn't match the public key of the certificate")
+	}
+
+	return nil
+}
+
+// Chain a certificate chain offered by the CA.
+type Chain struct {
+	// URL the URL of the chain: the certificate URL, or an alternate URL (Link header, rel="alternate").
+	URL string
+	// Root the common name of the issuer of the top certificate of the chain.
+	Root string
+
+	Certificate       []byte
+	IssuerCertificate []byte
+}
+
+// GetAllChains returns all the certificate chains offered by the CA:
+// the chain of the certificate URL first, then the alternate chains (Link header, rel="alternate") sorted by root.
+// - https://www.rfc-editor.org/rfc/rfc8555.html#section-7.4.2
+//
+// If bundle is true, the Certificate field of the chains includes the issuer certificates.
+func (c *Certifier) GetAllChains(certURL string, bundle bool) ([]Chain, error) {
+	certs, err := c.core.Certificates.GetAll(certURL, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return newChains(certs, certURL)
+}
+
+// ChainSelector selects a certificate chain among the chains offered by the CA,
+// ordered as by Certifier.GetAllChains (the default chain first).
+// It returns the index of the selected chain, or -1 to use the default chain.
+type ChainSelector func(chains []Chain) int
+
+// PreferredChainSelector returns a ChainSelector selecting the first chain matching the preferred chain:
+//   - the common name of the issuer of the top certificate of the chain (e.g. "ISRG Root X1"),
+//   - the subject of this issuer, the root (e.g. "CN=ISRG Root X1,O=Internet Security Research Group,C=US"),
+//   - the SHA-256 fingerprint of any certificate of the issuer bundle (hexadecimal, with or without colons).
+func PreferredChainSelector(preferredChain string) ChainSelector {
+	return func(chains []Chain) int {
+		for i, chain := range chains {
+			ok, err := hasPreferredChain(chain.IssuerCertificate, preferredChain)
+			if err == nil && ok {
+				return i
+			}
+		}
+
+		return -1
+	}
+}
+
+// chainSelector returns the selector, or a PreferredChainSelector if the preferred chain is set.
+func chainSelector(selector ChainSelector, preferredChain string) ChainSelector {
+	if selector != nil {
+		return selector
+	}
+
+	if preferredChain != "" {
+		return PreferredChainSelector(preferredChain)
+	}
+
+	return nil
+}
+
+// selectChain returns the chain selected by the selector, or nil if no chain is selected.
+func selectChain(certs map[string]*acme.RawCertificate, certURL string, selector ChainSelector) (*Chain, error) {
+	chains, err := newChains(certs, certURL)
+	if err != nil {
+		return nil, err
+	}
+
+	i := selector(chains)
+	if i < 0 || i >= len(chains) {
+		return nil, nil
+	}
+
+	return &chains[i], nil
+}
+
+func newChains(certs map[string]*acme.RawCertificate, certURL string) ([]Chain, error) {
+	chains := make([]Chain, 0, len(certs))
+
+	for link, cert := range certs {
+		root, err := getChainRoot(cert.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("chain %s: %w", link, err)
+		}
+
+		chains = append(chains, Chain{
+			URL:               link,
+			Root:              root,
+			Certificate:       cert.Cert,
+			IssuerCertificate: cert.Issuer,
+		})
+	}
+
+	slices.SortFunc(chains, func(a, b Chain) int {
+		switch {
+		case a.URL == certURL:
+			return -1
+		case b.URL == certURL:
+			return 1
+		default:
+			return cmp.Or(strings.Compare(a.Root, b.Root), strings.Compare(a.URL, b.URL))
+		}
+	})
+
+	return chains, nil
+}
+
+func getChainRoot(issuer []byte) (string, error) {
+	certs, err := certcrypto.ParsePEMBundle(issuer)
+	if err != nil {
+		return "", err
+	}
+
+	return certs[len(certs)-1].Issuer.CommonName, nil
+}
+
 func hasPreferredChain(issuer []byte, preferredChain string) (bool, error) {
 	certs, err := certcrypto.ParsePEMBundle(issuer)
 	if err != nil {
@@ -700,10 +1065,19 @@ func hasPreferredChain(issuer []byte, preferredChain string) (bool, error) {
 
 	topCert := certs[len(certs)-1]
 
-	if topCert.Issuer.CommonName == preferredChain {
+	if topCert.Issuer.CommonName == preferredChain || topCert.Issuer.String() == preferredChain {
 		return true, nil
 	}
 
+	fingerprint := strings.ToLower(strings.ReplaceAll(preferredChain, ":", ""))
+
+	for _, cert := range certs {
+		sum := sha256.Sum256(cert.Raw)
+		if hex.EncodeToString(sum[:]) == fingerprint {
+			return true, nil
+		}
+	}
+
 	return false, nil
 }
 
@@ -1,11 +1,15 @@
 package certificate
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"fmt"
+	"io"
 	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
@@ -195,7 +199,7 @@ func Test_checkResponse(t *testing.T) {
 	}
 	certRes := &Resource{}
 
-	valid, err := certifier.checkResponse(order, certRes, true, "")
+	valid, err := certifier.checkResponse(t.Context(), order, certRes, true, nil)
 	require.NoError(t, err)
 	assert.True(t, valid)
 	assert.NotNil(t, certRes)
@@ -229,7 +233,7 @@ func Test_checkResponse_issuerRelUp(t *testing.T) {
 	}
 	certRes := &Resource{}
 
-	valid, err := certifier.checkResponse(order, certRes, true, "")
+	valid, err := certifier.checkResponse(t.Context(), order, certRes, true, nil)
 	require.NoError(t, err)
 	assert.True(t, valid)
 	assert.NotNil(t, certRes)
@@ -263,7 +267,7 @@ func Test_checkResponse_no_bundle(t *testing.T) {
 	}
 	certRes := &Resource{}
 
-	valid, err := certifier.checkResponse(order, certRes, false, "")
+	valid, err := certifier.checkResponse(t.Context(), order, certRes, false, nil)
 	require.NoError(t, err)
 	assert.True(t, valid)
 	assert.NotNil(t, certRes)
@@ -306,7 +310,7 @@ func Test_checkResponse_alternate(t *testing.T) {
 		Domain: "example.com",
 	}
 
-	valid, err := certifier.checkResponse(order, certRes, true, "DST Root CA X3")
+	valid, err := certifier.checkResponse(t.Context(), order, certRes, true, PreferredChainSelector("DST Root CA X3"))
 	require.NoError(t, err)
 
 	assert.True(t, valid)
@@ -320,6 +324,343 @@ func Test_checkResponse_alternate(t *testing.T) {
 	assert.Equal(t, issuerMock2, string(certRes.IssuerCertificate), "IssuerCertificate")
 }
 
+func Test_checkResponse_chainSelector(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /certificate",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Add("Link",
+					fmt.Sprintf(`<https://%s/certificate/1>;title="foo";rel="alternate"`, req.Context().Value(http.LocalAddrContextKey)))
+
+				servermock.RawStringResponse(certResponseMock).ServeHTTP(rw, req)
+			})).
+		Route("/certificate/1", servermock.RawStringResponse(certResponseMock2)).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	order := acme.ExtendedOrder{
+		Order: acme.Order{
+			Status:      acme.StatusValid,
+			Certificate: server.URL + "/certificate",
+		},
+	}
+	certRes := &Resource{
+		Domain: "example.com",
+	}
+
+	var roots []string
+
+	selector := func(chains []Chain) int {
+		for _, chain := range chains {
+			roots = append(roots, chain.Root)
+		}
+
+		return len(chains) - 1
+	}
+
+	valid, err := certifier.checkResponse(t.Context(), order, certRes, true, selector)
+	require.NoError(t, err)
+
+	assert.True(t, valid)
+	assert.Equal(t, []string{"Pebble Root CA 50ffbd", "DST Root CA X3"}, roots)
+	assert.Contains(t, certRes.CertURL, "/certificate/1")
+	assert.Equal(t, certResponseMock2, string(certRes.Certificate), "Certificate")
+	assert.Equal(t, issuerMock2, string(certRes.IssuerCertificate), "IssuerCertificate")
+}
+
+func Test_hasPreferredChain(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		issuer         string
+		preferredChain string
+		expected       bool
+	}{
+		{
+			desc:           "root common name",
+			issuer:         issuerMock,
+			preferredChain: "Pebble Root CA 50ffbd",
+			expected:       true,
+		},
+		{
+			desc:           "intermediate common name",
+			issuer:         issuerMock,
+			preferredChain: "Pebble Intermediate CA 395e61",
+		},
+		{
+			desc:           "root subject",
+			issuer:         issuerMock2,
+			preferredChain: "CN=DST Root CA X3,O=Digital Signature Trust Co.",
+			expected:       true,
+		},
+		{
+			desc:           "fingerprint",
+			issuer:         issuerMock,
+			preferredChain: "880f3463dc8f306451cab253ae10eb1dc33dd9ac469016f12c7b4f7dd75d34b1",
+			expected:       true,
+		},
+		{
+			desc:           "fingerprint with colons",
+			issuer:         issuerMock2,
+			preferredChain: "06:87:26:03:31:A7:24:03:D9:09:F1:05:E6:9B:CF:0D:32:E1:BD:24:93:FF:C6:D9:20:6D:11:BC:D6:77:07:39",
+			expected:       true,
+		},
+		{
+			desc:           "fingerprint of another chain",
+			issuer:         issuerMock2,
+			preferredChain: "880f3463dc8f306451cab253ae10eb1dc33dd9ac469016f12c7b4f7dd75d34b1",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ok, err := hasPreferredChain([]byte(test.issuer), test.preferredChain)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, ok)
+		})
+	}
+}
+
+func TestCertifier_GetAllChains(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /certificate",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Add("Link",
+					fmt.Sprintf(`<https://%s/certificate/1>;title="foo";rel="alternate"`, req.Context().Value(http.LocalAddrContextKey)))
+
+				servermock.RawStringResponse(certResponseMock).ServeHTTP(rw, req)
+			})).
+		Route("POST /certificate/1", servermock.RawStringResponse(certResponseMock2)).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	chains, err := certifier.GetAllChains(server.URL+"/certificate", false)
+	require.NoError(t, err)
+
+	require.Len(t, chains, 2)
+
+	assert.Equal(t, server.URL+"/certificate", chains[0].URL)
+	assert.Equal(t, "Pebble Root CA 50ffbd", chains[0].Root)
+	assert.Equal(t, issuerMock, string(chains[0].IssuerCertificate))
+
+	assert.Equal(t, server.URL+"/certificate/1", chains[1].URL)
+	assert.Equal(t, "DST Root CA X3", chains[1].Root)
+	assert.Equal(t, issuerMock2, string(chains[1].IssuerCertificate))
+}
+
+func TestCertifier_getForCSR_orderPolling(t *testing.T) {
+	var (
+		polls    atomic.Int32
+		canceled atomic.Bool
+	)
+
+	server := tester.MockACMEServer().
+		Route("POST /finalize", servermock.JSONEncode(acme.Order{Status: acme.StatusProcessing})).
+		Route("POST /order",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				switch polls.Add(1) {
+				case 1:
+					// The request of the first attempt is canceled.
+					// The body is read to detect the closed connection.
+					_, _ = io.Copy(io.Discard, req.Body)
+
+					select {
+					case <-req.Context().Done():
+						canceled.Store(true)
+					case <-time.After(5 * time.Second):
+					}
+
+					servermock.JSONEncode(acme.Order{Status: acme.StatusProcessing}).ServeHTTP(rw, req)
+
+				case 2:
+					servermock.JSONEncode(acme.Order{Status: acme.StatusProcessing}).ServeHTTP(rw, req)
+
+				default:
+					servermock.JSONEncode(acme.Order{
+						Status:      acme.StatusValid,
+						Certificate: fmt.Sprintf("https://%s/certificate", req.Context().Value(http.LocalAddrContextKey)),
+					}).ServeHTTP(rw, req)
+				}
+			})).
+		Route("POST /certificate", servermock.RawStringResponse(certResponseMock)).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{
+		KeyType:                 certcrypto.RSA2048,
+		Timeout:                 5 * time.Second,
+		OrderPollInterval:       10 * time.Millisecond,
+		OrderPollAttemptTimeout: 100 * time.Millisecond,
+	})
+
+	order := acme.ExtendedOrder{
+		Location: server.URL + "/order",
+		Order:    acme.Order{Finalize: server.URL + "/finalize"},
+	}
+
+	certRes, err := certifier.getForCSR(t.Context(), []string{"example.com"}, order, true, []byte("csr"), nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, certResponseMock, string(certRes.Certificate))
+	assert.Equal(t, server.URL+"/certificate", certRes.CertURL)
+	assert.GreaterOrEqual(t, polls.Load(), int32(3))
+	assert.True(t, canceled.Load())
+}
+
+func TestCertifier_getForCSR_orderTimeout(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /finalize", servermock.JSONEncode(acme.Order{Status: acme.StatusProcessing})).
+		Route("POST /order", servermock.JSONEncode(acme.Order{Status: acme.StatusProcessing})).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{
+		KeyType:           certcrypto.RSA2048,
+		Timeout:           200 * time.Millisecond,
+		OrderPollInterval: 10 * time.Millisecond,
+	})
+
+	order := acme.ExtendedOrder{
+		Location: server.URL + "/order",
+		Order:    acme.Order{Finalize: server.URL + "/finalize"},
+	}
+
+	_, err = certifier.getForCSR(t.Context(), []string{"example.com"}, order, true, []byte("csr"), nil, nil)
+	require.EqualError(t, err, "certificate: time limit exceeded")
+}
+
+func TestCertifier_ObtainForCSR_invalidCSRDER(t *testing.T) {
+	certifier := NewCertifier(nil, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	_, err := certifier.ObtainForCSR(ObtainForCSRRequest{CSRDER: []byte("not a CSR")})
+	require.ErrorContains(t, err, "cannot obtain resource for CSR: asn1: ")
+
+	_, err = certifier.ObtainForCSR(ObtainForCSRRequest{})
+	require.EqualError(t, err, "cannot obtain resource for CSR: CSR is missing")
+}
+
+func TestCertifier_ObtainWithContext_canceled(t *testing.T) {
+	var authzCalls atomic.Int32
+
+	server := tester.MockACMEServer().
+		Route("POST /newOrder", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			rw.Header().Set("Location", serverURL+"/order")
+
+			servermock.JSONEncode(acme.Order{
+				Status:         acme.StatusPending,
+				Identifiers:    []acme.Identifier{{Type: "dns", Value: "example.com"}},
+				Authorizations: []string{serverURL + "/authz"},
+				Finalize:       serverURL + "/finalize",
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /authz", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			authzCalls.Add(1)
+
+			servermock.JSONEncode(acme.Authorization{
+				Status:     acme.StatusPending,
+				Identifier: acme.Identifier{Type: "dns", Value: "example.com"},
+			}).ServeHTTP(rw, req)
+		})).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	// The context is canceled (e.g. SIGINT) during the challenges.
+	certifier := NewCertifier(core, resolverFunc(func(_ []acme.Authorization) error {
+		cancel()
+		return nil
+	}), CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	_, err = certifier.ObtainWithContext(ctx, ObtainRequest{Domains: []string{"example.com"}})
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The authorization is fetched, then fetched and deactivated.
+	assert.Equal(t, int32(3), authzCalls.Load())
+}
+
+func TestCertifier_ObtainWithContext_canceledDuringSolve(t *testing.T) {
+	var authzCalls atomic.Int32
+
+	server := tester.MockACMEServer().
+		Route("POST /newOrder", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			rw.Header().Set("Location", serverURL+"/order")
+
+			servermock.JSONEncode(acme.Order{
+				Status:         acme.StatusPending,
+				Identifiers:    []acme.Identifier{{Type: "dns", Value: "example.com"}},
+				Authorizations: []string{serverURL + "/authz"},
+				Finalize:       serverURL + "/finalize",
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /authz", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			authzCalls.Add(1)
+
+			servermock.JSONEncode(acme.Authorization{
+				Status:     acme.StatusPending,
+				Identifier: acme.Identifier{Type: "dns", Value: "example.com"},
+			}).ServeHTTP(rw, req)
+		})).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	// The solver is blocked until the context is canceled (e.g. SIGINT).
+	certifier := NewCertifier(core, contextResolverFunc(func(ctx context.Context, _ []acme.Authorization) error {
+		cancel()
+
+		<-ctx.Done()
+
+		return ctx.Err()
+	}), CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	_, err = certifier.ObtainWithContext(ctx, ObtainRequest{Domains: []string{"example.com"}})
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The authorization is fetched, then fetched and deactivated.
+	assert.Equal(t, int32(3), authzCalls.Load())
+}
+
 func Test_Get(t *testing.T) {
 	server := tester.MockACMEServer().
 		Route("POST /acme/cert/test-cert", servermock.RawStringResponse(certResponseMock)).
@@ -346,6 +687,51 @@ func Test_Get(t *testing.T) {
 	assert.Equal(t, issuerMock, string(certRes.IssuerCertificate), "IssuerCertificate")
 }
 
+func TestCertifier_GetByURL(t *testing.T) {
+	certKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(certKey, "example.com", nil)
+	require.NoError(t, err)
+
+	server := tester.MockACMEServer().
+		Route("POST /certificate",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Add("Link",
+					fmt.Sprintf(`<https://%s/certificate/1>;title="foo";rel="alternate"`, req.Context().Value(http.LocalAddrContextKey)))
+
+				servermock.RawStringResponse(string(certPEM)+issuerMock).ServeHTTP(rw, req)
+			})).
+		Route("POST /certificate/1", servermock.RawStringResponse(string(certPEM)+issuerMock2)).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	certRes, err := certifier.GetByURL(GetByURLRequest{
+		URL:            server.URL + "/certificate",
+		Bundle:         true,
+		PreferredChain: "DST Root CA X3",
+		PrivateKey:     certKey,
+	})
+	require.NoError(t, err)
+
+	// The common name of the certificates generated by GeneratePemCert.
+	assert.Equal(t, "ACME Challenge TEMP", certRes.Domain)
+	assert.Equal(t, server.URL+"/certificate/1", certRes.CertURL)
+	assert.Equal(t, issuerMock2, string(certRes.IssuerCertificate))
+	assert.Equal(t, certcrypto.PEMEncode(certKey), certRes.PrivateKey)
+	assert.Nil(t, certRes.CSR)
+
+	_, err = certifier.GetByURL(GetByURLRequest{URL: server.URL + "/certificate", PrivateKey: key})
+	require.EqualError(t, err, "[ACME Challenge TEMP] the private key doesn't match the public key of the certificate")
+}
+
 func Test_checkOrderStatus(t *testing.T) {
 	testCases := []struct {
 		desc       string
@@ -398,3 +784,45 @@ type resolverMock struct {
 func (r *resolverMock) Solve(_ []acme.Authorization) error {
 	return r.error
 }
+
+type resolverFunc func(authorizations []acme.Authorization) error
+
+func (f resolverFunc) Solve(authorizations []acme.Authorization) error {
+	return f(authorizations)
+}
+
+type contextResolverFunc func(ctx context.Context, authorizations []acme.Authorization) error
+
+func (f contextResolverFunc) Solve(authorizations []acme.Authorization) error {
+	return f(context.Background(), authorizations)
+}
+
+func (f contextResolverFunc) SolveWithContext(ctx context.Context, authorizations []acme.Authorization) error {
+	return f(ctx, authorizations)
+}
+
+func TestCertifier_RevokeWithPrivateKey(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /revokeCert", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})).
+		BuildHTTPS(t)
+
+	accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", accountKey)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	certKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(certKey, "example.com", nil)
+	require.NoError(t, err)
+
+	err = certifier.RevokeWithPrivateKey(certPEM, nil, certKey)
+	require.NoError(t, err)
+
+	err = certifier.RevokeWithPrivateKey(certPEM, nil, accountKey)
+	require.EqualError(t, err, "the private key doesn't match the certificate")
+}
@@ -1,8 +1,11 @@
 package certificate
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
 	"net/http"
 	"testing"
 	"time"
@@ -10,6 +13,8 @@ import (
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/go-acme/lego/v4/platform/pebble/pebbletest"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
 	"github.com/stretchr/testify/assert"
@@ -39,31 +44,26 @@ func Test_makeCertID(t *testing.T) {
 }
 
 func TestCertifier_GetRenewalInfo(t *testing.T) {
-	leaf, err := certcrypto.ParsePEMCertificate([]byte(ariLeafPEM))
-	require.NoError(t, err)
-
-	// Test with a fake API.
-	server := tester.MockACMEServer().
-		Route("GET /renewalInfo/"+ariLeafCertID,
-			servermock.RawStringResponse(`{
-				"suggestedWindow": {
-					"start": "2020-03-17T17:51:09Z",
-					"end": "2020-03-17T18:21:09Z"
+	server := pebbletest.NewServer(t,
+		pebble.WithRenewalInfo(func(*x509.Certificate) acme.RenewalInfoResponse {
+			return acme.RenewalInfoResponse{
+				SuggestedWindow: acme.Window{
+					Start: time.Date(2020, 3, 17, 17, 51, 9, 0, time.UTC),
+					End:   time.Date(2020, 3, 17, 18, 21, 9, 0, time.UTC),
 				},
-				"explanationUrl": "https://aricapable.ca.example/docs/renewal-advice/"
+				ExplanationURL: "https://aricapable.ca.example/docs/renewal-advice/",
 			}
-		}`).
-				WithHeader("Content-Type", "application/json").
-				WithHeader("Retry-After", "21600")).
-		BuildHTTPS(t)
+		}),
+		pebble.WithRenewalInfoRetryAfter(6*time.Hour),
+	)
 
-	key, err := rsa.GenerateKey(rand.Reader, 2048)
-	require.NoError(t, err, "Could not generate test key")
+	certifier := newPebbleCertifier(t, server)
 
-	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	res, err := certifier.Obtain(ObtainRequest{Domains: []string{"example.com"}})
 	require.NoError(t, err)
 
-	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+	leaf, err := certcrypto.ParsePEMCertificate(res.Certificate)
+	require.NoError(t, err)
 
 	ri, err := certifier.GetRenewalInfo(RenewalInfoRequest{leaf})
 	require.NoError(t, err)
@@ -237,3 +237,31 @@ func TestRenewalInfoResponse_ShouldRenew(t *testing.T) {
 		assert.Nil(t, rt)
 	})
 }
+
+// newPebbleCertifier returns a Certifier using an account registered on the server.
+// The server accepts all the challenges.
+func newPebbleCertifier(t *testing.T, server *pebble.Server) *Certifier {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL(), "", key)
+	require.NoError(t, err)
+
+	_, err = core.Accounts.New(acme.Account{TermsOfServiceAgreed: true})
+	require.NoError(t, err)
+
+	resolver := resolverFunc(func(authorizations []acme.Authorization) error {
+		for _, authz := range authorizations {
+			_, err := core.Challenges.New(authz.Challenges[0].URL)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return NewCertifier(core, resolver, CertifierOptions{KeyType: certcrypto.EC256})
+}
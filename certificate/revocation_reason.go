@@ -0,0 +1,100 @@
+package certificate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+// RevocationReason the reason of the revocation of a certificate (CRL reason code).
+// https://www.rfc-editor.org/rfc/rfc5280.html#section-5.3.1
+type RevocationReason uint
+
+const (
+	RevocationReasonUnspecified          = RevocationReason(acme.CRLReasonUnspecified)
+	RevocationReasonKeyCompromise        = RevocationReason(acme.CRLReasonKeyCompromise)
+	RevocationReasonCACompromise         = RevocationReason(acme.CRLReasonCACompromise)
+	RevocationReasonAffiliationChanged   = RevocationReason(acme.CRLReasonAffiliationChanged)
+	RevocationReasonSuperseded           = RevocationReason(acme.CRLReasonSuperseded)
+	RevocationReasonCessationOfOperation = RevocationReason(acme.CRLReasonCessationOfOperation)
+	RevocationReasonCertificateHold      = RevocationReason(acme.CRLReasonCertificateHold)
+	RevocationReasonRemoveFromCRL        = RevocationReason(acme.CRLReasonRemoveFromCRL)
+	RevocationReasonPrivilegeWithdrawn   = RevocationReason(acme.CRLReasonPrivilegeWithdrawn)
+	RevocationReasonAACompromise         = RevocationReason(acme.CRLReasonAACompromise)
+)
+
+var revocationReasonNames = map[RevocationReason]string{
+	RevocationReasonUnspecified:          "unspecified",
+	RevocationReasonKeyCompromise:        "keyCompromise",
+	RevocationReasonCACompromise:         "cACompromise",
+	RevocationReasonAffiliationChanged:   "affiliationChanged",
+	RevocationReasonSuperseded:           "superseded",
+	RevocationReasonCessationOfOperation: "cessationOfOperation",
+	RevocationReasonCertificateHold:      "certificateHold",
+	RevocationReasonRemoveFromCRL:        "removeFromCRL",
+	RevocationReasonPrivilegeWithdrawn:   "privilegeWithdrawn",
+	RevocationReasonAACompromise:         "aACompromise",
+}
+
+// ParseRevocationReason parses a revocation reason from its name (case-insensitive, e.g. "keyCompromise") or its code (e.g. "1").
+func ParseRevocationReason(value string) (RevocationReason, error) {
+	if code, err := strconv.ParseUint(value, 10, 32); err == nil {
+		reason := RevocationReason(code)
+
+		return reason, reason.Validate()
+	}
+
+	for reason, name := range revocationReasonNames {
+		if strings.EqualFold(name, value) {
+			return reason, reason.Validate()
+		}
+	}
+
+	return 0, fmt.Errorf("unknown revocation reason: %q", value)
+}
+
+func (r RevocationReason) String() string {
+	if name, ok := revocationReasonNames[r]; ok {
+		return name
+	}
+
+	return strconv.FormatUint(uint64(r), 10)
+}
+
+// Validate returns an error if the reason cannot be used to revoke a certificate:
+// the code 7 is not used, and removeFromCRL is only used in delta CRLs.
+func (r RevocationReason) Validate() error {
+	if _, ok := revocationReasonNames[r]; !ok {
+		return fmt.Errorf("invalid revocation reason: %d", uint(r))
+	}
+
+	if r == RevocationReasonRemoveFromCRL {
+		return fmt.Errorf("invalid revocation reason: %s is only used in delta CRLs", r)
+	}
+
+	return nil
+}
+
+// RevocationReasons returns the reasons that can be used to revoke a certificate.
+func RevocationReasons() []RevocationReason {
+	var reasons []RevocationReason
+
+	for code := range uint(RevocationReasonAACompromise) + 1 {
+		reason := RevocationReason(code)
+		if reason.Validate() == nil {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	return reasons
+}
+
+func validateRevocationReason(reason *uint) error {
+	if reason == nil {
+		return nil
+	}
+
+	return RevocationReason(*reason).Validate()
+}
@@ -0,0 +1,102 @@
+package certificate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRevocationReason(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected RevocationReason
+	}{
+		{
+			desc:     "name",
+			value:    "keyCompromise",
+			expected: RevocationReasonKeyCompromise,
+		},
+		{
+			desc:     "name case-insensitive",
+			value:    "CESSATIONOFOPERATION",
+			expected: RevocationReasonCessationOfOperation,
+		},
+		{
+			desc:     "code",
+			value:    "4",
+			expected: RevocationReasonSuperseded,
+		},
+		{
+			desc:     "unspecified",
+			value:    "0",
+			expected: RevocationReasonUnspecified,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			reason, err := ParseRevocationReason(test.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, reason)
+		})
+	}
+}
+
+func TestParseRevocationReason_error(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected string
+	}{
+		{
+			desc:     "unknown name",
+			value:    "lost",
+			expected: `unknown revocation reason: "lost"`,
+		},
+		{
+			desc:     "unused code",
+			value:    "7",
+			expected: "invalid revocation reason: 7",
+		},
+		{
+			desc:     "out of range",
+			value:    "11",
+			expected: "invalid revocation reason: 11",
+		},
+		{
+			desc:     "removeFromCRL",
+			value:    "removeFromCRL",
+			expected: "invalid revocation reason: removeFromCRL is only used in delta CRLs",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseRevocationReason(test.value)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestRevocationReasons(t *testing.T) {
+	expected := []RevocationReason{
+		RevocationReasonUnspecified,
+		RevocationReasonKeyCompromise,
+		RevocationReasonCACompromise,
+		RevocationReasonAffiliationChanged,
+		RevocationReasonSuperseded,
+		RevocationReasonCessationOfOperation,
+		RevocationReasonCertificateHold,
+		RevocationReasonPrivilegeWithdrawn,
+		RevocationReasonAACompromise,
+	}
+
+	assert.Equal(t, expected, RevocationReasons())
+}
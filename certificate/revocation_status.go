@@ -0,0 +1,142 @@
+package certificate
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// RevocationStatus the revocation status of a certificate.
+type RevocationStatus int
+
+const (
+	// RevocationStatusUnknown the status cannot be determined (no OCSP server and no CRL, or errors).
+	RevocationStatusUnknown RevocationStatus = iota
+	// RevocationStatusGood the certificate is not revoked.
+	RevocationStatusGood
+	// RevocationStatusRevoked the certificate is revoked.
+	RevocationStatusRevoked
+)
+
+func (s RevocationStatus) String() string {
+	switch s {
+	case RevocationStatusGood:
+		return "good"
+	case RevocationStatusRevoked:
+		return "revoked"
+	default:
+		return "unknown"
+	}
+}
+
+// RevocationStatusRequest contains the certificate to check.
+type RevocationStatusRequest struct {
+	Cert *x509.Certificate
+
+	// Issuer the issuer certificate (optional).
+	// If nil, the issuer certificate is fetched from the IssuingCertificateURL of the certificate.
+	Issuer *x509.Certificate
+}
+
+// GetRevocationStatus returns the revocation status of a certificate.
+// The OCSP responder of the certificate is used first, and the CRL distribution points are used as a fallback.
+//
+// The returned error contains the reasons why the status is RevocationStatusUnknown.
+func (c *Certifier) GetRevocationStatus(req RevocationStatusRequest) (RevocationStatus, error) {
+	if req.Cert == nil {
+		return RevocationStatusUnknown, errors.New("missing certificate")
+	}
+
+	issuer := req.Issuer
+	if issuer == nil {
+		var err error
+
+		issuer, err = c.getIssuerCertificate(req.Cert)
+		if err != nil {
+			return RevocationStatusUnknown, fmt.Errorf("issuer certificate: %w", err)
+		}
+	}
+
+	var errs []error
+
+	if len(req.Cert.OCSPServer) > 0 {
+		status, err := c.getOCSPStatus(req.Cert, issuer)
+		if err == nil {
+			return status, nil
+		}
+
+		errs = append(errs, fmt.Errorf("OCSP: %w", err))
+	}
+
+	for _, uri := range req.Cert.CRLDistributionPoints {
+		status, err := c.getCRLStatus(uri, req.Cert, issuer)
+		if err == nil {
+			return status, nil
+		}
+
+		errs = append(errs, fmt.Errorf("CRL %s: %w", uri, err))
+	}
+
+	if len(errs) == 0 {
+		return RevocationStatusUnknown, errors.New("no OCSP server and no CRL distribution point specified in cert")
+	}
+
+	return RevocationStatusUnknown, errors.Join(errs...)
+}
+
+func (c *Certifier) getOCSPStatus(cert, issuer *x509.Certificate) (RevocationStatus, error) {
+	_, ocspRes, err := c.requestOCSP(cert, issuer)
+	if err != nil {
+		return RevocationStatusUnknown, err
+	}
+
+	switch ocspRes.Status {
+	case ocsp.Good:
+		return RevocationStatusGood, nil
+	case ocsp.Revoked:
+		return RevocationStatusRevoked, nil
+	default:
+		return RevocationStatusUnknown, errors.New("unknown status")
+	}
+}
+
+func (c *Certifier) getCRLStatus(uri string, cert, issuer *x509.Certificate) (RevocationStatus, error) {
+	resp, err := c.core.HTTPClient.Get(uri)
+	if err != nil {
+		return RevocationStatusUnknown, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return RevocationStatusUnknown, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// The CRLs can be bigger than the other responses.
+	crlBytes, err := io.ReadAll(http.MaxBytesReader(nil, resp.Body, 100*maxBodySize))
+	if err != nil {
+		return RevocationStatusUnknown, err
+	}
+
+	crl, err := x509.ParseRevocationList(crlBytes)
+	if err != nil {
+		return RevocationStatusUnknown, err
+	}
+
+	err = crl.CheckSignatureFrom(issuer)
+	if err != nil {
+		return RevocationStatusUnknown, err
+	}
+
+	for _, entry := range crl.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return RevocationStatusRevoked, nil
+		}
+	}
+
+	return RevocationStatusGood, nil
+}
@@ -0,0 +1,245 @@
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+type revocationFixture struct {
+	caKey  crypto.Signer
+	caCert *x509.Certificate
+}
+
+func newRevocationFixture(t *testing.T) *revocationFixture {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Example CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, caKey.Public(), caKey)
+	require.NoError(t, err)
+
+	caCert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &revocationFixture{caKey: caKey, caCert: caCert}
+}
+
+func (f *revocationFixture) leaf(t *testing.T, serial int64, ocspURL, crlURL string) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+
+	if ocspURL != "" {
+		template.OCSPServer = []string{ocspURL}
+	}
+
+	if crlURL != "" {
+		template.CRLDistributionPoints = []string{crlURL}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, f.caCert, key.Public(), f.caKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func (f *revocationFixture) crl(t *testing.T, revoked ...int64) []byte {
+	t.Helper()
+
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Hour),
+		NextUpdate: time.Now().Add(time.Hour),
+	}
+
+	for _, serial := range revoked {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   big.NewInt(serial),
+			RevocationTime: time.Now().Add(-time.Minute),
+		})
+	}
+
+	crl, err := x509.CreateRevocationList(rand.Reader, template, f.caCert, f.caKey)
+	require.NoError(t, err)
+
+	return crl
+}
+
+func newRevocationCertifier(t *testing.T, routes map[string]http.HandlerFunc) (*Certifier, string) {
+	t.Helper()
+
+	builder := tester.MockACMEServer()
+	for pattern, handler := range routes {
+		builder.Route(pattern, handler)
+	}
+
+	server := builder.BuildHTTPS(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	return NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.EC256}), server.URL
+}
+
+func TestCertifier_GetRevocationStatus_crl(t *testing.T) {
+	fixture := newRevocationFixture(t)
+
+	crl := fixture.crl(t, 10)
+
+	certifier, serverURL := newRevocationCertifier(t, map[string]http.HandlerFunc{
+		"GET /crl": func(rw http.ResponseWriter, _ *http.Request) {
+			_, _ = rw.Write(crl)
+		},
+	})
+
+	testCases := []struct {
+		desc     string
+		serial   int64
+		expected RevocationStatus
+	}{
+		{
+			desc:     "revoked",
+			serial:   10,
+			expected: RevocationStatusRevoked,
+		},
+		{
+			desc:     "good",
+			serial:   20,
+			expected: RevocationStatusGood,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			cert := fixture.leaf(t, test.serial, "", serverURL+"/crl")
+
+			status, err := certifier.GetRevocationStatus(RevocationStatusRequest{Cert: cert, Issuer: fixture.caCert})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, status)
+		})
+	}
+}
+
+func TestCertifier_GetRevocationStatus_ocsp(t *testing.T) {
+	fixture := newRevocationFixture(t)
+
+	certifier, serverURL := newRevocationCertifier(t, map[string]http.HandlerFunc{
+		"POST /ocsp": func(rw http.ResponseWriter, req *http.Request) {
+			raw, err := io.ReadAll(req.Body)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			ocspReq, err := ocsp.ParseRequest(raw)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			resp, err := ocsp.CreateResponse(fixture.caCert, fixture.caCert, ocsp.Response{
+				Status:           ocsp.Revoked,
+				SerialNumber:     ocspReq.SerialNumber,
+				ThisUpdate:       time.Now().Add(-time.Hour),
+				NextUpdate:       time.Now().Add(time.Hour),
+				RevokedAt:        time.Now().Add(-time.Minute),
+				RevocationReason: ocsp.KeyCompromise,
+			}, fixture.caKey)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			_, _ = rw.Write(resp)
+		},
+		// The CRL must not be used when the OCSP responder answers.
+		"GET /crl": func(rw http.ResponseWriter, _ *http.Request) {
+			http.Error(rw, "unexpected call", http.StatusInternalServerError)
+		},
+	})
+
+	cert := fixture.leaf(t, 10, serverURL+"/ocsp", serverURL+"/crl")
+
+	status, err := certifier.GetRevocationStatus(RevocationStatusRequest{Cert: cert, Issuer: fixture.caCert})
+	require.NoError(t, err)
+
+	assert.Equal(t, RevocationStatusRevoked, status)
+}
+
+func TestCertifier_GetRevocationStatus_fallbackToCRL(t *testing.T) {
+	fixture := newRevocationFixture(t)
+
+	crl := fixture.crl(t, 10)
+
+	certifier, serverURL := newRevocationCertifier(t, map[string]http.HandlerFunc{
+		"POST /ocsp": func(rw http.ResponseWriter, _ *http.Request) {
+			http.Error(rw, "unavailable", http.StatusServiceUnavailable)
+		},
+		"GET /crl": func(rw http.ResponseWriter, _ *http.Request) {
+			_, _ = rw.Write(crl)
+		},
+	})
+
+	cert := fixture.leaf(t, 10, serverURL+"/ocsp", serverURL+"/crl")
+
+	status, err := certifier.GetRevocationStatus(RevocationStatusRequest{Cert: cert, Issuer: fixture.caCert})
+	require.NoError(t, err)
+
+	assert.Equal(t, RevocationStatusRevoked, status)
+}
+
+func TestCertifier_GetRevocationStatus_unknown(t *testing.T) {
+	fixture := newRevocationFixture(t)
+
+	certifier, _ := newRevocationCertifier(t, nil)
+
+	cert := fixture.leaf(t, 10, "", "")
+
+	status, err := certifier.GetRevocationStatus(RevocationStatusRequest{Cert: cert, Issuer: fixture.caCert})
+	require.EqualError(t, err, "no OCSP server and no CRL distribution point specified in cert")
+
+	assert.Equal(t, RevocationStatusUnknown, status)
+}
@@ -0,0 +1,165 @@
+package dns01
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// CAA property tags (RFC 8659).
+const (
+	CAATagIssue     = "issue"
+	CAATagIssueWild = "issuewild"
+	CAATagIODEF     = "iodef"
+)
+
+// caaFlagCritical the issuer critical flag (RFC 8659 section 4.1).
+const caaFlagCritical = 128
+
+// CAARecord a CAA record (RFC 8659).
+type CAARecord struct {
+	Flag  uint8
+	Tag   string
+	Value string
+}
+
+func (r CAARecord) String() string {
+	return fmt.Sprintf("%d %s %q", r.Flag, r.Tag, r.Value)
+}
+
+// CAAProvider is implemented by the DNS providers able to manage the CAA records of a domain.
+type CAAProvider interface {
+	// SetCAA replaces the CAA records of the domain.
+	SetCAA(domain string, records []CAARecord) error
+}
+
+// NewCAARecords creates the CAA records authorizing the CA identities (the directory `meta.caaIdentities`)
+// to issue certificates and wildcard certificates.
+// If accountURI is not empty, the issuance is restricted to this ACME account (RFC 8657).
+func NewCAARecords(identities []string, accountURI string) []CAARecord {
+	var records []CAARecord
+
+	for _, tag := range []string{CAATagIssue, CAATagIssueWild} {
+		for _, identity := range identities {
+			value := identity
+			if accountURI != "" {
+				value += "; accounturi=" + accountURI
+			}
+
+			records = append(records, CAARecord{Tag: tag, Value: value})
+		}
+	}
+
+	return records
+}
+
+// LookupCAA returns the relevant CAA record set of the domain, and the domain on which it has been found,
+// by climbing the DNS tree from the domain to the TLD (RFC 8659 section 3).
+// An empty record set means that no CAA record restricts the issuance.
+func LookupCAA(domain string) (string, []CAARecord, error) {
+	fqdn := dns.Fqdn(strings.TrimPrefix(domain, "*."))
+
+	for name := range DomainsSeq(fqdn) {
+		r, err := dnsQuery(name, dns.TypeCAA, recursiveNameservers, true)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if r.Rcode != dns.RcodeSuccess && r.Rcode != dns.RcodeNameError {
+			return "", nil, &DNSError{Message: fmt.Sprintf("unexpected response for '%s'", name), MsgOut: r}
+		}
+
+		var records []CAARecord
+
+		for _, rr := range r.Answer {
+			if caa, ok := rr.(*dns.CAA); ok {
+				records = append(records, CAARecord{Flag: caa.Flag, Tag: strings.ToLower(caa.Tag), Value: caa.Value})
+			}
+		}
+
+		if len(records) > 0 {
+			return name, records, nil
+		}
+	}
+
+	return "", nil, nil
+}
+
+// CheckCAA checks that the CAA records of the domain authorize one of the CA identities (the directory `meta.caaIdentities`)
+// to issue a certificate for the domain, for the ACME account accountURI.
+func CheckCAA(domain string, identities []string, accountURI string) error {
+	name, records, err := LookupCAA(domain)
+	if err != nil {
+		return fmt.Errorf("CAA lookup for %s: %w", domain, err)
+	}
+
+	return checkCAARecords(domain, name, records, identities, accountURI)
+}
+
+func checkCAARecords(domain, name string, records []CAARecord, identities []string, accountURI string) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	for _, record := range records {
+		if record.Flag&caaFlagCritical != 0 && !slices.Contains([]string{CAATagIssue, CAATagIssueWild, CAATagIODEF}, record.Tag) {
+			return fmt.Errorf("the CAA record %s of %s has the critical flag on an unknown tag", record, name)
+		}
+	}
+
+	tag := CAATagIssue
+
+	if strings.HasPrefix(domain, "*.") && slices.ContainsFunc(records, func(r CAARecord) bool { return r.Tag == CAATagIssueWild }) {
+		tag = CAATagIssueWild
+	}
+
+	var errs []error
+
+	for _, record := range records {
+		if record.Tag != tag {
+			continue
+		}
+
+		issuer, params := parseCAAValue(record.Value)
+
+		if !slices.ContainsFunc(identities, func(identity string) bool { return strings.EqualFold(identity, issuer) }) {
+			continue
+		}
+
+		uri, ok := params["accounturi"]
+		if ok && uri != accountURI {
+			errs = append(errs, fmt.Errorf("the CAA record %s of %s is bound to another account", record, name))
+			continue
+		}
+
+		return nil
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return fmt.Errorf("the CAA records of %s don't authorize %s to issue a certificate for %s",
+		name, strings.Join(identities, ", "), domain)
+}
+
+// parseCAAValue parses the value of an issue or issuewild property: `issuer-domain-name; key=value; key=value`.
+func parseCAAValue(value string) (string, map[string]string) {
+	parts := strings.Split(value, ";")
+
+	params := make(map[string]string)
+
+	for _, part := range parts[1:] {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		params[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(val)
+	}
+
+	return strings.TrimSpace(parts[0]), params
+}
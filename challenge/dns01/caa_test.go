@@ -0,0 +1,134 @@
+package dns01
+
+import (
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeCAA(name string, flag uint8, tag, value string) *dns.CAA {
+	return &dns.CAA{
+		Hdr:   dns.RR_Header{Name: name, Rrtype: dns.TypeCAA, Class: dns.ClassINET, Ttl: 10},
+		Flag:  flag,
+		Tag:   tag,
+		Value: value,
+	}
+}
+
+func TestNewCAARecords(t *testing.T) {
+	records := NewCAARecords([]string{"letsencrypt.org"}, "https://acme.example.com/acct/1")
+
+	expected := []CAARecord{
+		{Tag: CAATagIssue, Value: "letsencrypt.org; accounturi=https://acme.example.com/acct/1"},
+		{Tag: CAATagIssueWild, Value: "letsencrypt.org; accounturi=https://acme.example.com/acct/1"},
+	}
+
+	assert.Equal(t, expected, records)
+}
+
+func TestLookupCAA(t *testing.T) {
+	useAsNameserver(t, dnsmock.NewServer().
+		Query("www.example.com. CAA", dnsmock.Noop).
+		Query("example.com. CAA", dnsmock.Answer(fakeCAA("example.com.", 0, "issue", "letsencrypt.org"))).
+		Build(t))
+
+	name, records, err := LookupCAA("www.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com.", name)
+	assert.Equal(t, []CAARecord{{Tag: CAATagIssue, Value: "letsencrypt.org"}}, records)
+}
+
+func TestLookupCAA_none(t *testing.T) {
+	useAsNameserver(t, dnsmock.NewServer().
+		Query("example.com. CAA", dnsmock.Noop).
+		Query("com. CAA", dnsmock.Noop).
+		Build(t))
+
+	name, records, err := LookupCAA("example.com")
+	require.NoError(t, err)
+
+	assert.Empty(t, name)
+	assert.Empty(t, records)
+}
+
+func Test_checkCAARecords(t *testing.T) {
+	identities := []string{"letsencrypt.org"}
+	accountURI := "https://acme.example.com/acct/1"
+
+	testCases := []struct {
+		desc     string
+		domain   string
+		records  []CAARecord
+		expected string
+	}{
+		{
+			desc:   "no records",
+			domain: "example.com",
+		},
+		{
+			desc:    "authorized",
+			domain:  "example.com",
+			records: []CAARecord{{Tag: CAATagIssue, Value: "LetsEncrypt.org"}},
+		},
+		{
+			desc:    "authorized with account binding",
+			domain:  "example.com",
+			records: []CAARecord{{Tag: CAATagIssue, Value: "letsencrypt.org; accounturi=" + accountURI}},
+		},
+		{
+			desc:     "bound to another account",
+			domain:   "example.com",
+			records:  []CAARecord{{Tag: CAATagIssue, Value: "letsencrypt.org; accounturi=https://acme.example.com/acct/2"}},
+			expected: `the CAA record 0 issue "letsencrypt.org; accounturi=https://acme.example.com/acct/2" of example.com. is bound to another account`,
+		},
+		{
+			desc:     "other CA",
+			domain:   "example.com",
+			records:  []CAARecord{{Tag: CAATagIssue, Value: "pki.goog"}},
+			expected: "the CAA records of example.com. don't authorize letsencrypt.org to issue a certificate for example.com",
+		},
+		{
+			desc:     "forbidden",
+			domain:   "example.com",
+			records:  []CAARecord{{Tag: CAATagIssue, Value: ";"}},
+			expected: "the CAA records of example.com. don't authorize letsencrypt.org to issue a certificate for example.com",
+		},
+		{
+			desc:   "wildcard uses issue without issuewild",
+			domain: "*.example.com",
+			records: []CAARecord{
+				{Tag: CAATagIssue, Value: "letsencrypt.org"},
+			},
+		},
+		{
+			desc:   "wildcard uses issuewild",
+			domain: "*.example.com",
+			records: []CAARecord{
+				{Tag: CAATagIssue, Value: "letsencrypt.org"},
+				{Tag: CAATagIssueWild, Value: ";"},
+			},
+			expected: "the CAA records of example.com. don't authorize letsencrypt.org to issue a certificate for *.example.com",
+		},
+		{
+			desc:     "unknown critical tag",
+			domain:   "example.com",
+			records:  []CAARecord{{Flag: 128, Tag: "tbs", Value: "foo"}, {Tag: CAATagIssue, Value: "letsencrypt.org"}},
+			expected: `the CAA record 128 tbs "foo" of example.com. has the critical flag on an unknown tag`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			err := checkCAARecords(test.domain, "example.com.", test.records, identities, accountURI)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
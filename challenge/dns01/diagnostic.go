@@ -0,0 +1,227 @@
+package dns01
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/miekg/dns"
+)
+
+// Diagnostic codes.
+const (
+	// DiagnosticMissingTXT the TXT record is not served by some authoritative nameservers.
+	DiagnosticMissingTXT = "missing-txt"
+	// DiagnosticUnexpectedCNAME a CNAME is defined at `_acme-challenge` but is not followed.
+	DiagnosticUnexpectedCNAME = "unexpected-cname"
+	// DiagnosticNSMismatch the nameservers of the delegation (parent zone) and of the zone are different.
+	DiagnosticNSMismatch = "ns-mismatch"
+	// DiagnosticDNSSECBogus the responses of the zone fail the DNSSEC validation.
+	DiagnosticDNSSECBogus = "dnssec-bogus"
+)
+
+// Finding a problem detected by the diagnostic of the DNS delegation.
+type Finding struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Hint    string `json:"hint"`
+}
+
+// Diagnostic the result of the diagnostic of the DNS delegation, after the failure of a DNS-01 challenge.
+type Diagnostic struct {
+	Domain        string    `json:"domain"`
+	FQDN          string    `json:"fqdn"`
+	EffectiveFQDN string    `json:"effectiveFqdn"`
+	Findings      []Finding `json:"findings,omitempty"`
+}
+
+func (d *Diagnostic) add(code, hint, format string, args ...any) {
+	d.Findings = append(d.Findings, Finding{Code: code, Message: fmt.Sprintf(format, args...), Hint: hint})
+}
+
+// DiagnosticError the error of a DNS-01 challenge, with the diagnostic of the DNS delegation.
+type DiagnosticError struct {
+	Err        error
+	Diagnostic *Diagnostic
+}
+
+func (e *DiagnosticError) Error() string {
+	msg := new(strings.Builder)
+
+	msg.WriteString(e.Err.Error())
+
+	for _, finding := range e.Diagnostic.Findings {
+		_, _ = fmt.Fprintf(msg, "\n\t[%s] %s: %s", finding.Code, finding.Message, finding.Hint)
+	}
+
+	return msg.String()
+}
+
+func (e *DiagnosticError) Unwrap() error {
+	return e.Err
+}
+
+// isDNSProblem returns true if the error is a validation error related to the DNS (or a propagation error).
+func isDNSProblem(err error) bool {
+	var problem *acme.ProblemDetails
+	if !errors.As(err, &problem) {
+		return true
+	}
+
+	switch strings.TrimPrefix(problem.Type, "urn:ietf:params:acme:error:") {
+	case "dns", "unauthorized", "incorrectResponse":
+		return true
+	default:
+		return false
+	}
+}
+
+// diagnose checks the DNS delegation of the challenge.
+func diagnose(domain string, info ChallengeInfo) *Diagnostic {
+	d := &Diagnostic{
+		Domain:        domain,
+		FQDN:          info.FQDN,
+		EffectiveFQDN: info.EffectiveFQDN,
+	}
+
+	d.checkCNAME(info)
+	d.checkDNSSEC(info.EffectiveFQDN)
+	d.checkDelegation(info.EffectiveFQDN)
+	d.checkTXT(info.EffectiveFQDN, info.Value)
+
+	return d
+}
+
+// checkCNAME detects a CNAME at `_acme-challenge` when the CNAMEs are not followed (LEGO_DISABLE_CNAME_SUPPORT).
+func (d *Diagnostic) checkCNAME(info ChallengeInfo) {
+	if info.FQDN != info.EffectiveFQDN {
+		return
+	}
+
+	r, err := dnsQuery(info.FQDN, dns.TypeCNAME, recursiveNameservers, true)
+	if err != nil || r.Rcode != dns.RcodeSuccess {
+		return
+	}
+
+	target := updateDomainWithCName(r, info.FQDN)
+	if target == info.FQDN {
+		return
+	}
+
+	d.add(DiagnosticUnexpectedCNAME,
+		"the CA follows the CNAME: remove the CNAME, or let lego follow it (LEGO_DISABLE_CNAME_SUPPORT) and create the TXT record in the zone of the target",
+		"%s is a CNAME to %s", info.FQDN, target)
+}
+
+// checkDNSSEC detects the DNSSEC validation failures:
+// a validating resolver returns SERVFAIL for a bogus response, but answers if the checking is disabled (CD bit).
+func (d *Diagnostic) checkDNSSEC(fqdn string) {
+	r, err := dnsQuery(fqdn, dns.TypeTXT, recursiveNameservers, true)
+	if err != nil || r.Rcode != dns.RcodeServerFailure {
+		return
+	}
+
+	m := createDNSMsg(fqdn, dns.TypeTXT, true)
+	m.CheckingDisabled = true
+
+	for _, ns := range recursiveNameservers {
+		r, err = sendDNSQuery(m, ns)
+		if err != nil || r.Rcode == dns.RcodeServerFailure {
+			continue
+		}
+
+		d.add(DiagnosticDNSSECBogus,
+			"check the DS record at the registrar and the signatures of the zone (e.g. expired signatures, or a key rollover in progress)",
+			"the DNSSEC validation of %s fails (SERVFAIL with validation, %s without validation)", fqdn, dns.RcodeToString[r.Rcode])
+
+		return
+	}
+}
+
+// checkDelegation compares the nameservers of the delegation (in the parent zone) and the nameservers of the zone.
+func (d *Diagnostic) checkDelegation(fqdn string) {
+	zone, err := FindZoneByFqdn(fqdn)
+	if err != nil {
+		return
+	}
+
+	labels := dns.Split(zone)
+	if len(labels) < 2 {
+		// Top-level domains are delegated by the root zone.
+		return
+	}
+
+	childNss, err := lookupNameservers(zone)
+	if err != nil {
+		return
+	}
+
+	parentNss, err := lookupNameservers(zone[labels[1]:])
+	if err != nil {
+		return
+	}
+
+	var delegation []string
+
+	for _, ns := range parentNss {
+		r, errQ := dnsQuery(zone, dns.TypeNS, []string{net.JoinHostPort(ns, defaultNameserverPort)}, false)
+		if errQ != nil {
+			continue
+		}
+
+		for _, rr := range slices.Concat(r.Answer, r.Ns) {
+			if record, ok := rr.(*dns.NS); ok && strings.EqualFold(record.Hdr.Name, zone) {
+				delegation = append(delegation, strings.ToLower(record.Ns))
+			}
+		}
+
+		if len(delegation) > 0 {
+			break
+		}
+	}
+
+	if len(delegation) == 0 {
+		return
+	}
+
+	slices.Sort(delegation)
+	slices.Sort(childNss)
+
+	if slices.Equal(slices.Compact(delegation), slices.Compact(childNss)) {
+		return
+	}
+
+	d.add(DiagnosticNSMismatch,
+		"update the nameservers at the registrar (or in the parent zone) to the nameservers of the DNS provider, or the contrary",
+		"the nameservers of %s are different in the parent zone (%s) and in the zone (%s)",
+		zone, strings.Join(delegation, ","), strings.Join(childNss, ","))
+}
+
+// checkTXT detects the authoritative nameservers not serving the TXT record.
+func (d *Diagnostic) checkTXT(fqdn, value string) {
+	authoritativeNss, err := lookupNameservers(fqdn)
+	if err != nil {
+		return
+	}
+
+	var missing []string
+
+	for _, ns := range authoritativeNss {
+		found, _ := checkNameserversPropagation(fqdn, value, []string{ns}, true, nil)
+		if !found {
+			missing = append(missing, ns)
+		}
+	}
+
+	if len(missing) == 0 {
+		return
+	}
+
+	d.add(DiagnosticMissingTXT,
+		"check that the DNS provider manages the zone served by these nameservers (the zone can be hosted by another provider), "+
+			"and increase the propagation timeout if the provider is slow to publish the records",
+		"the TXT record %s is missing at the authoritative nameservers %s", fqdn, strings.Join(missing, ","))
+}
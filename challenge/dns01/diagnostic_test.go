@@ -0,0 +1,129 @@
+package dns01
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_diagnose(t *testing.T) {
+	mockResolver(t,
+		dnsmock.NewServer().
+			Query("ns0.lego.localhost. A",
+				dnsmock.Answer(fakeA("ns0.lego.localhost.", "127.0.0.1"))).
+			Query("ns1.lego.localhost. A",
+				dnsmock.Answer(fakeA("ns1.lego.localhost.", "127.0.0.1"))).
+			Query("example.com. NS",
+				dnsmock.Answer(
+					fakeNS("example.com.", "ns0.lego.localhost."),
+					fakeNS("example.com.", "ns2.lego.localhost."),
+				),
+			).
+			Query("_acme-challenge.example.com. TXT",
+				dnsmock.Answer(fakeTXT("_acme-challenge.example.com.", "other"))).
+			Build(t),
+	)
+
+	useAsNameserver(t,
+		dnsmock.NewServer().
+			Query("_acme-challenge.example.com. CNAME", dnsmock.CNAME("_acme-challenge.example.org.")).
+			Query("_acme-challenge.example.com. TXT", func(w dns.ResponseWriter, req *dns.Msg) {
+				m := new(dns.Msg)
+				m.SetReply(req)
+
+				if req.CheckingDisabled {
+					m.Answer = []dns.RR{fakeTXT("_acme-challenge.example.com.", "value")}
+				} else {
+					m.Rcode = dns.RcodeServerFailure
+				}
+
+				_ = w.WriteMsg(m)
+			}).
+			Query("_acme-challenge.example.com. SOA", dnsmock.Error(dns.RcodeNameError)).
+			Query("example.com. SOA", dnsmock.SOA("")).
+			Query("com. SOA", dnsmock.SOA("")).
+			Query("example.com. NS",
+				dnsmock.Answer(
+					fakeNS("example.com.", "ns0.lego.localhost."),
+					fakeNS("example.com.", "ns1.lego.localhost."),
+				),
+			).
+			Query("com. NS",
+				dnsmock.Answer(fakeNS("com.", "ns0.lego.localhost."))).
+			Build(t),
+	)
+
+	info := ChallengeInfo{
+		FQDN:          "_acme-challenge.example.com.",
+		EffectiveFQDN: "_acme-challenge.example.com.",
+		Value:         "value",
+	}
+
+	diagnostic := diagnose("example.com", info)
+
+	expected := &Diagnostic{
+		Domain:        "example.com",
+		FQDN:          "_acme-challenge.example.com.",
+		EffectiveFQDN: "_acme-challenge.example.com.",
+		Findings: []Finding{
+			{
+				Code:    DiagnosticUnexpectedCNAME,
+				Message: "_acme-challenge.example.com. is a CNAME to _acme-challenge.example.org.",
+				Hint: "the CA follows the CNAME: remove the CNAME, or let lego follow it (LEGO_DISABLE_CNAME_SUPPORT)" +
+					" and create the TXT record in the zone of the target",
+			},
+			{
+				Code:    DiagnosticDNSSECBogus,
+				Message: "the DNSSEC validation of _acme-challenge.example.com. fails (SERVFAIL with validation, NOERROR without validation)",
+				Hint:    "check the DS record at the registrar and the signatures of the zone (e.g. expired signatures, or a key rollover in progress)",
+			},
+			{
+				Code: DiagnosticNSMismatch,
+				Message: "the nameservers of example.com. are different in the parent zone (ns0.lego.localhost.,ns2.lego.localhost.)" +
+					" and in the zone (ns0.lego.localhost.,ns1.lego.localhost.)",
+				Hint: "update the nameservers at the registrar (or in the parent zone) to the nameservers of the DNS provider, or the contrary",
+			},
+			{
+				Code:    DiagnosticMissingTXT,
+				Message: "the TXT record _acme-challenge.example.com. is missing at the authoritative nameservers ns0.lego.localhost.,ns1.lego.localhost.",
+				Hint: "check that the DNS provider manages the zone served by these nameservers (the zone can be hosted by another provider)," +
+					" and increase the propagation timeout if the provider is slow to publish the records",
+			},
+		},
+	}
+
+	assert.Equal(t, expected, diagnostic)
+}
+
+func TestDiagnosticError(t *testing.T) {
+	cause := errors.New("time limit exceeded")
+
+	err := error(&DiagnosticError{
+		Err: cause,
+		Diagnostic: &Diagnostic{
+			Findings: []Finding{
+				{Code: DiagnosticMissingTXT, Message: "message", Hint: "hint"},
+			},
+		},
+	})
+
+	require.ErrorIs(t, err, cause)
+	require.EqualError(t, err, "time limit exceeded\n\t[missing-txt] message: hint")
+
+	var diagnosticError *DiagnosticError
+
+	require.ErrorAs(t, err, &diagnosticError)
+	assert.Len(t, diagnosticError.Diagnostic.Findings, 1)
+}
+
+func Test_isDNSProblem(t *testing.T) {
+	assert.True(t, isDNSProblem(errors.New("time limit exceeded")))
+	assert.True(t, isDNSProblem(&acme.ProblemDetails{Type: "urn:ietf:params:acme:error:dns"}))
+	assert.True(t, isDNSProblem(&acme.ProblemDetails{Type: "urn:ietf:params:acme:error:unauthorized"}))
+	assert.False(t, isDNSProblem(&acme.ProblemDetails{Type: acme.RateLimitedErr}))
+}
@@ -1,6 +1,7 @@
 package dns01
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/platform/wait"
 	"github.com/miekg/dns"
 )
@@ -30,8 +32,20 @@ const (
 
 type ValidateFunc func(core *api.Core, domain string, chlng acme.Challenge) error
 
+// ValidateContextFunc is like ValidateFunc, but the requests are canceled when the context is done.
+type ValidateContextFunc func(ctx context.Context, core *api.Core, domain string, chlng acme.Challenge) error
+
 type ChallengeOption func(*Challenge) error
 
+// WithValidateContext sets the validation function used by SolveWithContext
+// (the validation function of NewChallenge is used if not defined).
+func WithValidateContext(validate ValidateContextFunc) ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.validateContext = validate
+		return nil
+	}
+}
+
 // CondOption Conditional challenge option.
 func CondOption(condition bool, opt ChallengeOption) ChallengeOption {
 	if !condition {
@@ -46,11 +60,12 @@ func CondOption(condition bool, opt ChallengeOption) ChallengeOption {
 
 // Challenge implements the dns-01 challenge.
 type Challenge struct {
-	core       *api.Core
-	validate   ValidateFunc
-	provider   challenge.Provider
-	preCheck   preCheck
-	dnsTimeout time.Duration
+	core            *api.Core
+	validate        ValidateFunc
+	validateContext ValidateContextFunc
+	provider        challenge.Provider
+	preCheck        preCheck
+	dnsTimeout      time.Duration
 }
 
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
@@ -65,7 +80,7 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 	for _, opt := range opts {
 		err := opt(chlg)
 		if err != nil {
-			log.Infof("challenge option error: %v", err)
+			core.Logger().Infof("challenge option error: %v", err)
 		}
 	}
 
@@ -76,7 +91,7 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 // It does not validate record propagation, or do anything at all with the acme server.
 func (c *Challenge) PreSolve(authz acme.Authorization) error {
 	domain := challenge.GetTargetedDomain(authz)
-	log.Infof("[%s] acme: Preparing to solve DNS-01", domain)
+	c.core.Logger().Infof("[%s] acme: Preparing to solve DNS-01", domain)
 
 	chlng, err := challenge.FindChallenge(challenge.DNS01, authz)
 	if err != nil {
@@ -95,15 +110,20 @@ func (c *Challenge) PreSolve(authz acme.Authorization) error {
 
 	err = c.provider.Present(authz.Identifier.Value, chlng.Token, keyAuth)
 	if err != nil {
-		return fmt.Errorf("[%s] acme: error presenting token: %w", domain, err)
+		return redact.Error(fmt.Errorf("[%s] acme: error presenting token: %w", domain, err))
 	}
 
 	return nil
 }
 
 func (c *Challenge) Solve(authz acme.Authorization) error {
+	return c.SolveWithContext(context.Background(), authz)
+}
+
+// SolveWithContext is like Solve, but the propagation wait and the validation are canceled when the context is done.
+func (c *Challenge) SolveWithContext(ctx context.Context, authz acme.Authorization) error {
 	domain := challenge.GetTargetedDomain(authz)
-	log.Infof("[%s] acme: Trying to solve DNS-01", domain)
+	c.core.Logger().Infof("[%s] acme: Trying to solve DNS-01", domain)
 
 	chlng, err := challenge.FindChallenge(challenge.DNS01, authz)
 	if err != nil {
@@ -127,30 +147,66 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 		timeout, interval = DefaultPropagationTimeout, DefaultPollingInterval
 	}
 
-	log.Infof("[%s] acme: Checking DNS record propagation. [nameservers=%s]", domain, strings.Join(recursiveNameservers, ","))
+	c.core.Logger().Infof("[%s] acme: Checking DNS record propagation. [nameservers=%s]", domain, strings.Join(recursiveNameservers, ","))
+
+	start := time.Now()
 
-	time.Sleep(interval)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(interval):
+	}
 
-	err = wait.For("propagation", timeout, interval, func() (bool, error) {
+	err = wait.ForWithContext(ctx, "propagation", timeout, interval, func() (bool, error) {
 		stop, errP := c.preCheck.call(domain, info.EffectiveFQDN, info.Value)
 		if !stop || errP != nil {
-			log.Infof("[%s] acme: Waiting for DNS record propagation.", domain)
+			c.core.Logger().Infof("[%s] acme: Waiting for DNS record propagation.", domain)
 		}
 
 		return stop, errP
 	})
 	if err != nil {
-		return err
+		if ctx.Err() != nil {
+			return err
+		}
+
+		return c.diagnose(domain, info, err)
 	}
 
+	c.core.Logger().Infof("[%s] acme: DNS record propagated after %s.", domain, time.Since(start).Round(time.Second))
+
 	chlng.KeyAuthorization = keyAuth
 
-	return c.validate(c.core, domain, chlng)
+	if c.validateContext != nil {
+		err = c.validateContext(ctx, c.core, domain, chlng)
+	} else {
+		err = c.validate(c.core, domain, chlng)
+	}
+
+	if err != nil && isDNSProblem(err) {
+		return c.diagnose(domain, info, err)
+	}
+
+	return err
+}
+
+// diagnose runs the diagnostic of the DNS delegation after the failure of the challenge,
+// and returns the error with the result of the diagnostic (DiagnosticError).
+func (c *Challenge) diagnose(domain string, info ChallengeInfo, err error) error {
+	c.core.Logger().Infof("[%s] acme: Diagnosing the DNS delegation of %s.", domain, info.EffectiveFQDN)
+
+	diagnostic := diagnose(domain, info)
+
+	for _, finding := range diagnostic.Findings {
+		c.core.Logger().Warnf("[%s] acme: DNS diagnostic: %s: %s", domain, finding.Message, finding.Hint)
+	}
+
+	return &DiagnosticError{Err: err, Diagnostic: diagnostic}
 }
 
 // CleanUp cleans the challenge.
 func (c *Challenge) CleanUp(authz acme.Authorization) error {
-	log.Infof("[%s] acme: Cleaning DNS-01 challenge", challenge.GetTargetedDomain(authz))
+	c.core.Logger().Infof("[%s] acme: Cleaning DNS-01 challenge", challenge.GetTargetedDomain(authz))
 
 	chlng, err := challenge.FindChallenge(challenge.DNS01, authz)
 	if err != nil {
@@ -162,7 +218,17 @@ func (c *Challenge) CleanUp(authz acme.Authorization) error {
 		return err
 	}
 
-	return c.provider.CleanUp(authz.Identifier.Value, chlng.Token, keyAuth)
+	return redact.Error(c.provider.CleanUp(authz.Identifier.Value, chlng.Token, keyAuth))
+}
+
+// MultipleTXTValues reports whether the provider can manage several TXT values for the same FQDN.
+// The providers are assumed to support it, unless they implement MultiTXTProvider.
+func (c *Challenge) MultipleTXTValues() bool {
+	if p, ok := c.provider.(MultiTXTProvider); ok {
+		return p.MultipleTXTValues()
+	}
+
+	return true
 }
 
 func (c *Challenge) Sequential() (bool, time.Duration) {
@@ -177,6 +243,14 @@ type sequential interface {
 	Sequential() time.Duration
 }
 
+// MultiTXTProvider is implemented by the DNS providers declaring whether they can manage several TXT values for the same FQDN,
+// e.g. for a domain and its wildcard in the same order: both challenges use the FQDN `_acme-challenge.<domain>`.
+// When a provider can't, the challenges sharing an FQDN are solved in successive rounds, with a cleanup between the rounds.
+type MultiTXTProvider interface {
+	// MultipleTXTValues returns false if the creation of a TXT record replaces the existing values of the FQDN.
+	MultipleTXTValues() bool
+}
+
 // GetRecord returns a DNS record which will fulfill the `dns-01` challenge.
 //
 // Deprecated: use GetChallengeInfo instead.
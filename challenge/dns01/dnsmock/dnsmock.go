@@ -0,0 +1,93 @@
+// Package dnsmock provides a mock dns-01 challenge provider
+// that records the calls, allows injecting failures and latencies, and offers assertion helpers.
+package dnsmock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/challenge/internal/providermock"
+)
+
+var _ challenge.ProviderTimeout = (*Provider)(nil)
+
+// Call a recorded call to Present or CleanUp.
+type Call = providermock.Call
+
+// Operation the name of a provider operation.
+type Operation = providermock.Operation
+
+const (
+	OperationPresent = providermock.OperationPresent
+	OperationCleanUp = providermock.OperationCleanUp
+)
+
+// Provider a mock dns-01 challenge provider.
+type Provider struct {
+	*providermock.Mock
+
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewProvider creates a new Provider.
+func NewProvider() *Provider {
+	return &Provider{
+		Mock:     providermock.New(),
+		timeout:  time.Second,
+		interval: 100 * time.Millisecond,
+	}
+}
+
+// WithTimeout defines the values returned by Timeout.
+func (p *Provider) WithTimeout(timeout, interval time.Duration) *Provider {
+	p.timeout = timeout
+	p.interval = interval
+
+	return p
+}
+
+// Present records the call.
+func (p *Provider) Present(domain, token, keyAuth string) error {
+	return p.Do(OperationPresent, domain, token, keyAuth)
+}
+
+// CleanUp records the call.
+func (p *Provider) CleanUp(domain, token, keyAuth string) error {
+	return p.Do(OperationCleanUp, domain, token, keyAuth)
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+func (p *Provider) Timeout() (timeout, interval time.Duration) {
+	return p.timeout, p.interval
+}
+
+// Records returns the TXT records that are currently presented (FQDN to values).
+// The FQDNs are the effective FQDNs of the challenges (see dns01.GetChallengeInfo).
+func (p *Provider) Records() map[string][]string {
+	records := make(map[string][]string)
+
+	for _, c := range p.Active() {
+		info := dns01.GetChallengeInfo(c.Domain, c.KeyAuth)
+		records[info.EffectiveFQDN] = append(records[info.EffectiveFQDN], info.Value)
+	}
+
+	return records
+}
+
+// AssertRecord asserts that the TXT record matching the FQDN and the value is currently presented.
+func (p *Provider) AssertRecord(t testing.TB, fqdn, value string) bool {
+	t.Helper()
+
+	for _, v := range p.Records()[fqdn] {
+		if v == value {
+			return true
+		}
+	}
+
+	t.Errorf("TXT record %s %q is not presented", fqdn, value)
+
+	return false
+}
@@ -0,0 +1,35 @@
+package dnsmock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider(t *testing.T) {
+	t.Setenv("LEGO_DISABLE_CNAME_SUPPORT", "true")
+
+	provider := NewProvider()
+
+	provider.FailCleanUp(errors.New("boom"))
+
+	require.NoError(t, provider.Present("example.com", "token", "keyAuth"))
+
+	info := dns01.GetChallengeInfo("example.com", "keyAuth")
+
+	assert.Equal(t, map[string][]string{info.EffectiveFQDN: {info.Value}}, provider.Records())
+	assert.True(t, provider.AssertRecord(t, info.EffectiveFQDN, info.Value))
+
+	require.EqualError(t, provider.CleanUp("example.com", "token", "keyAuth"), "boom")
+
+	assert.Len(t, provider.Records(), 1)
+
+	require.NoError(t, provider.CleanUp("example.com", "token", "keyAuth"))
+
+	assert.Empty(t, provider.Records())
+	assert.True(t, provider.AssertAllCleanedUp(t))
+	assert.True(t, provider.AssertCalls(t, 1, 2))
+}
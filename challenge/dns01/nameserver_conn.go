@@ -0,0 +1,212 @@
+package dns01
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// connIdleTimeout is the duration after which an unused pooled connection is closed.
+const connIdleTimeout = 30 * time.Second
+
+// connPool keeps one TCP connection per nameserver,
+// so successive queries to the same nameserver are sent over the same connection
+// instead of paying the connection setup cost for each record.
+// The concurrent queries (e.g. the certificates obtained concurrently by a client) are pipelined on the connection.
+type connPool struct {
+	mu    sync.Mutex
+	conns map[string]*pooledConn
+}
+
+func newConnPool() *connPool {
+	return &connPool{conns: make(map[string]*pooledConn)}
+}
+
+// exchange sends the message to the nameserver over the pooled connection, dialing it if needed.
+// A failed exchange on a reused connection is retried once on a fresh connection,
+// because the server may have closed the connection in the meantime.
+func (p *connPool) exchange(m *dns.Msg, ns string) (*dns.Msg, error) {
+	pc := p.get(ns)
+
+	r, reused, err := pc.exchange(m, ns)
+	if err != nil && reused {
+		r, _, err = pc.exchange(m, ns)
+	}
+
+	if err != nil {
+		return r, &DNSError{Message: "DNS call error", MsgIn: m, NS: ns, Err: err}
+	}
+
+	return r, nil
+}
+
+func (p *connPool) get(ns string) *pooledConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pc, ok := p.conns[ns]
+	if !ok {
+		pc = &pooledConn{}
+		p.conns[ns] = pc
+	}
+
+	return pc
+}
+
+// pooledConn is a TCP connection to a nameserver, closed after connIdleTimeout without use.
+// The queries are pipelined: a query is written without waiting for the responses of the previous ones,
+// and the responses, read by a dedicated goroutine, are matched to the queries by their message ID.
+type pooledConn struct {
+	mu      sync.Mutex
+	conn    *dns.Conn
+	pending map[uint16]chan exchangeResult
+	timer   *time.Timer
+}
+
+type exchangeResult struct {
+	msg *dns.Msg
+	err error
+}
+
+// exchange sends the query and waits for its response.
+// It also reports whether the query has been sent over an existing connection.
+func (pc *pooledConn) exchange(m *dns.Msg, ns string) (*dns.Msg, bool, error) {
+	id, resc, reused, err := pc.send(m, ns)
+	if err != nil {
+		return nil, reused, err
+	}
+
+	select {
+	case res := <-resc:
+		return res.msg, reused, res.err
+
+	case <-time.After(dnsTimeout):
+		pc.mu.Lock()
+		defer pc.mu.Unlock()
+
+		// The connection is considered broken: the pending queries fail, and the next ones use a new connection.
+		delete(pc.pending, id)
+		pc.closeLocked()
+
+		return nil, reused, fmt.Errorf("no response after %s", dnsTimeout)
+	}
+}
+
+// send writes the query on the connection, dialing it if needed,
+// and returns the ID of the query and the channel of its response.
+func (pc *pooledConn) send(m *dns.Msg, ns string) (uint16, <-chan exchangeResult, bool, error) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	reused := pc.conn != nil
+
+	if pc.conn == nil {
+		client := &dns.Client{Net: "tcp", Timeout: dnsTimeout}
+
+		conn, err := client.Dial(ns)
+		if err != nil {
+			return 0, nil, reused, err
+		}
+
+		pc.conn = conn
+		pc.pending = make(map[uint16]chan exchangeResult)
+		pc.timer = time.AfterFunc(connIdleTimeout, func() { pc.closeIdle(conn) })
+
+		go pc.read(conn, pc.pending)
+	} else {
+		pc.timer.Reset(connIdleTimeout)
+	}
+
+	// The ID identifies the response of the query among the responses of the pipelined queries.
+	query := m.Copy()
+
+	for {
+		if _, ok := pc.pending[query.Id]; !ok {
+			break
+		}
+
+		query.Id = dns.Id()
+	}
+
+	resc := make(chan exchangeResult, 1)
+	pc.pending[query.Id] = resc
+
+	_ = pc.conn.SetWriteDeadline(time.Now().Add(dnsTimeout))
+
+	err := pc.conn.WriteMsg(query)
+	if err != nil {
+		delete(pc.pending, query.Id)
+		pc.closeLocked()
+
+		return 0, nil, reused, err
+	}
+
+	return query.Id, resc, reused, nil
+}
+
+// read dispatches the responses read on the connection to the pending queries,
+// until the connection is closed or broken: the remaining pending queries fail.
+func (pc *pooledConn) read(conn *dns.Conn, pending map[uint16]chan exchangeResult) {
+	for {
+		r, err := conn.ReadMsg()
+
+		pc.mu.Lock()
+
+		if err != nil {
+			for id, resc := range pending {
+				resc <- exchangeResult{err: err}
+
+				delete(pending, id)
+			}
+
+			if pc.conn == conn {
+				pc.closeLocked()
+			}
+
+			pc.mu.Unlock()
+
+			return
+		}
+
+		if resc, ok := pending[r.Id]; ok {
+			resc <- exchangeResult{msg: r}
+
+			delete(pending, r.Id)
+		}
+
+		pc.mu.Unlock()
+	}
+}
+
+// closeIdle closes the connection, unless queries are pending or the connection has been replaced.
+func (pc *pooledConn) closeIdle(conn *dns.Conn) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if pc.conn != conn {
+		return
+	}
+
+	if len(pc.pending) > 0 {
+		pc.timer.Reset(connIdleTimeout)
+
+		return
+	}
+
+	pc.closeLocked()
+}
+
+func (pc *pooledConn) closeLocked() {
+	if pc.timer != nil {
+		pc.timer.Stop()
+		pc.timer = nil
+	}
+
+	if pc.conn != nil {
+		_ = pc.conn.Close()
+		pc.conn = nil
+		pc.pending = nil
+	}
+}
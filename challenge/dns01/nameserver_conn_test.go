@@ -0,0 +1,165 @@
+package dns01
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingListener struct {
+	net.Listener
+
+	accepted atomic.Int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.accepted.Add(1)
+	}
+
+	return conn, err
+}
+
+func setupTCPServer(t *testing.T) (string, *countingListener) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	listener := &countingListener{Listener: ln}
+
+	mux := dns.NewServeMux()
+	mux.HandleFunc(".", func(w dns.ResponseWriter, req *dns.Msg) {
+		m := new(dns.Msg).SetReply(req)
+		m.Answer = append(m.Answer, fakeTXT(req.Question[0].Name, "value"))
+
+		_ = w.WriteMsg(m)
+	})
+
+	server := &dns.Server{Listener: listener, Handler: mux}
+
+	waitLock := sync.Mutex{}
+	waitLock.Lock()
+
+	server.NotifyStartedFunc = waitLock.Unlock
+
+	go func() {
+		err := server.ActivateAndServe()
+		if err != nil {
+			t.Log(err)
+		}
+	}()
+
+	waitLock.Lock()
+
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+
+	return ln.Addr().String(), listener
+}
+
+func Test_connPool_exchange(t *testing.T) {
+	addr, listener := setupTCPServer(t)
+
+	pool := newConnPool()
+
+	for _, fqdn := range []string{"a.example.com.", "b.example.com.", "c.example.com."} {
+		ok, err := checkNameserversPropagation(fqdn, "value", []string{addr}, false, pool)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	assert.EqualValues(t, 1, listener.accepted.Load())
+}
+
+func Test_connPool_exchange_closedConnection(t *testing.T) {
+	addr, listener := setupTCPServer(t)
+
+	pool := newConnPool()
+
+	r, err := pool.exchange(createDNSMsg("a.example.com.", dns.TypeTXT, false), addr)
+	require.NoError(t, err)
+	require.Len(t, r.Answer, 1)
+
+	// Simulates a connection closed by the server.
+	_ = pool.get(addr).conn.Close()
+
+	r, err = pool.exchange(createDNSMsg("b.example.com.", dns.TypeTXT, false), addr)
+	require.NoError(t, err)
+	require.Len(t, r.Answer, 1)
+
+	assert.EqualValues(t, 2, listener.accepted.Load())
+}
+
+func Test_connPool_exchange_pipelined(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = ln.Close() })
+
+	const queries = 5
+
+	// The server reads all the queries before responding (in reverse order):
+	// the queries must be sent without waiting for the previous responses.
+	go func() {
+		c, errA := ln.Accept()
+		if errA != nil {
+			return
+		}
+
+		conn := &dns.Conn{Conn: c}
+		defer func() { _ = conn.Close() }()
+
+		var reqs []*dns.Msg
+
+		for range queries {
+			req, errR := conn.ReadMsg()
+			if errR != nil {
+				return
+			}
+
+			reqs = append(reqs, req)
+		}
+
+		for _, req := range slices.Backward(reqs) {
+			m := new(dns.Msg).SetReply(req)
+			m.Answer = append(m.Answer, fakeTXT(req.Question[0].Name, req.Question[0].Name))
+
+			_ = conn.WriteMsg(m)
+		}
+	}()
+
+	pool := newConnPool()
+
+	var wg sync.WaitGroup
+
+	for i := range queries {
+		wg.Go(func() {
+			fqdn := fmt.Sprintf("%d.example.com.", i)
+
+			// Same ID for all the queries.
+			m := createDNSMsg(fqdn, dns.TypeTXT, false)
+			m.Id = 1
+
+			r, errE := pool.exchange(m, ln.Addr().String())
+			if !assert.NoError(t, errE) {
+				return
+			}
+
+			if assert.Len(t, r.Answer, 1) {
+				assert.Equal(t, []string{fqdn}, r.Answer[0].(*dns.TXT).Txt)
+			}
+		})
+	}
+
+	wg.Wait()
+}
@@ -49,6 +49,16 @@ func RecursiveNSsPropagationRequirement() ChallengeOption {
 	}
 }
 
+// ReuseAuthoritativeNssConnections keeps a TCP connection open per authoritative nameserver,
+// and reuses it for all the propagation checks sent to this nameserver (the concurrent checks are pipelined).
+// This reduces the latency of the checks when a lot of records are served by the same nameservers.
+func ReuseAuthoritativeNssConnections() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.preCheck.conns = newConnPool()
+		return nil
+	}
+}
+
 func PropagationWait(wait time.Duration, skipCheck bool) ChallengeOption {
 	return WrapPreCheck(func(domain, fqdn, value string, check PreCheckFunc) (bool, error) {
 		time.Sleep(wait)
@@ -70,6 +80,9 @@ type preCheck struct {
 
 	// require the TXT record to be propagated to all recursive name servers
 	requireRecursiveNssPropagation bool
+
+	// reused connections to the authoritative name servers (optional)
+	conns *connPool
 }
 
 func newPreCheck() preCheck {
@@ -99,7 +112,7 @@ func (p preCheck) checkDNSPropagation(fqdn, value string) (bool, error) {
 	}
 
 	if p.requireRecursiveNssPropagation {
-		_, err = checkNameserversPropagation(fqdn, value, recursiveNameservers, false)
+		_, err = checkNameserversPropagation(fqdn, value, recursiveNameservers, false, nil)
 		if err != nil {
 			return false, fmt.Errorf("recursive nameservers: %w", err)
 		}
@@ -114,7 +127,7 @@ func (p preCheck) checkDNSPropagation(fqdn, value string) (bool, error) {
 		return false, err
 	}
 
-	found, err := checkNameserversPropagation(fqdn, value, authoritativeNss, true)
+	found, err := checkNameserversPropagation(fqdn, value, authoritativeNss, true, p.conns)
 	if err != nil {
 		return found, fmt.Errorf("authoritative nameservers: %w", err)
 	}
@@ -123,13 +136,24 @@ func (p preCheck) checkDNSPropagation(fqdn, value string) (bool, error) {
 }
 
 // checkNameserversPropagation queries each of the given nameservers for the expected TXT record.
-func checkNameserversPropagation(fqdn, value string, nameservers []string, addPort bool) (bool, error) {
+// If conns is not nil, the queries are sent over the pooled connections.
+func checkNameserversPropagation(fqdn, value string, nameservers []string, addPort bool, conns *connPool) (bool, error) {
 	for _, ns := range nameservers {
 		if addPort {
 			ns = net.JoinHostPort(ns, defaultNameserverPort)
 		}
 
-		r, err := dnsQuery(fqdn, dns.TypeTXT, []string{ns}, false)
+		var (
+			r   *dns.Msg
+			err error
+		)
+
+		if conns != nil {
+			r, err = conns.exchange(createDNSMsg(fqdn, dns.TypeTXT, false), ns)
+		} else {
+			r, err = dnsQuery(fqdn, dns.TypeTXT, []string{ns}, false)
+		}
+
 		if err != nil {
 			return false, err
 		}
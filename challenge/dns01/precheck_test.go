@@ -130,7 +130,7 @@ func Test_checkNameserversPropagation_authoritativeNss(t *testing.T) {
 
 			addr := test.fakeDNSServer.Build(t)
 
-			ok, err := checkNameserversPropagation(test.fqdn, test.value, []string{addr.String()}, false)
+			ok, err := checkNameserversPropagation(test.fqdn, test.value, []string{addr.String()}, false, nil)
 
 			if test.expectedError == "" {
 				require.NoError(t, err)
@@ -0,0 +1,139 @@
+package dns01
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// TLSA certificate usages (RFC 7218).
+const (
+	TLSAUsagePKIXTA = 0
+	TLSAUsagePKIXEE = 1
+	TLSAUsageDANETA = 2
+	TLSAUsageDANEEE = 3
+)
+
+// TLSARecord a TLSA record (RFC 6698).
+type TLSARecord struct {
+	Usage        uint8
+	Selector     uint8
+	MatchingType uint8
+	Certificate  string // the certificate association data (hex encoded).
+}
+
+func (r TLSARecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", r.Usage, r.Selector, r.MatchingType, r.Certificate)
+}
+
+// TLSAProvider is implemented by the DNS providers able to manage TLSA records.
+type TLSAProvider interface {
+	// SetTLSA replaces the TLSA records of the fqdn (e.g. _25._tcp.mail.example.com.).
+	SetTLSA(fqdn string, records []TLSARecord) error
+}
+
+// NewTLSARecord computes the TLSA record of a certificate chain (the leaf certificate first).
+// The trust anchor usages (PKIX-TA, DANE-TA) use the issuer of the leaf certificate,
+// the end entity usages (PKIX-EE, DANE-EE) use the leaf certificate.
+func NewTLSARecord(chain []*x509.Certificate, usage, selector, matchingType uint8) (TLSARecord, error) {
+	if len(chain) == 0 {
+		return TLSARecord{}, errors.New("empty certificate chain")
+	}
+
+	cert := chain[0]
+
+	switch usage {
+	case TLSAUsagePKIXTA, TLSAUsageDANETA:
+		if len(chain) < 2 {
+			return TLSARecord{}, errors.New("the certificate chain doesn't contain the issuer certificate")
+		}
+
+		cert = chain[1]
+
+	case TLSAUsagePKIXEE, TLSAUsageDANEEE:
+		// the leaf certificate.
+
+	default:
+		return TLSARecord{}, fmt.Errorf("unsupported TLSA usage: %d", usage)
+	}
+
+	data, err := dns.CertificateToDANE(selector, matchingType, cert)
+	if err != nil {
+		return TLSARecord{}, err
+	}
+
+	return TLSARecord{Usage: usage, Selector: selector, MatchingType: matchingType, Certificate: data}, nil
+}
+
+// RolloverTLSARecords returns the TLSA records to publish during a certificate rollover:
+// the records of the new certificate are published alongside the records of the previous certificate,
+// which stay valid until the next rollover.
+func RolloverTLSARecords(current, previous []TLSARecord) []TLSARecord {
+	records := slices.Clone(current)
+
+	for _, record := range previous {
+		if !slices.Contains(records, record) {
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+// TLSAName returns the owner name of the TLSA records of a service (RFC 6698 section 3):
+// e.g. _25._tcp.mail.example.com.
+func TLSAName(domain string, port int, protocol string) string {
+	return fmt.Sprintf("_%d._%s.%s", port, protocol, dns.Fqdn(domain))
+}
+
+// CheckTLSAPropagation checks that all the authoritative nameservers of the fqdn serve the expected TLSA records.
+// It returns the highest TTL of the served records:
+// the resolvers can still serve the previous record set from their cache during this delay.
+func CheckTLSAPropagation(fqdn string, expected []TLSARecord) (time.Duration, error) {
+	authoritativeNss, err := lookupNameservers(fqdn)
+	if err != nil {
+		return 0, err
+	}
+
+	var ttl uint32
+
+	for _, ns := range authoritativeNss {
+		r, errQ := dnsQuery(fqdn, dns.TypeTLSA, []string{net.JoinHostPort(ns, defaultNameserverPort)}, false)
+		if errQ != nil {
+			return 0, errQ
+		}
+
+		if r.Rcode != dns.RcodeSuccess {
+			return 0, fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], fqdn)
+		}
+
+		var records []TLSARecord
+
+		for _, rr := range r.Answer {
+			if tlsa, ok := rr.(*dns.TLSA); ok {
+				records = append(records, TLSARecord{
+					Usage:        tlsa.Usage,
+					Selector:     tlsa.Selector,
+					MatchingType: tlsa.MatchingType,
+					Certificate:  strings.ToLower(tlsa.Certificate),
+				})
+
+				ttl = max(ttl, tlsa.Hdr.Ttl)
+			}
+		}
+
+		for _, record := range expected {
+			if !slices.Contains(records, record) {
+				return 0, fmt.Errorf("NS %s did not return the expected TLSA record [fqdn: %s, value: %s]: %v", ns, fqdn, record, records)
+			}
+		}
+	}
+
+	return time.Duration(ttl) * time.Second, nil
+}
@@ -0,0 +1,180 @@
+package dns01
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func TestNewTLSARecord(t *testing.T) {
+	chain := []*x509.Certificate{
+		{Raw: []byte("leaf"), RawSubjectPublicKeyInfo: []byte("leaf-spki")},
+		{Raw: []byte("issuer"), RawSubjectPublicKeyInfo: []byte("issuer-spki")},
+	}
+
+	testCases := []struct {
+		desc         string
+		chain        []*x509.Certificate
+		usage        uint8
+		selector     uint8
+		matchingType uint8
+		expected     TLSARecord
+		expectedErr  string
+	}{
+		{
+			desc:         "DANE-EE SPKI SHA-256",
+			chain:        chain,
+			usage:        TLSAUsageDANEEE,
+			selector:     1,
+			matchingType: 1,
+			expected:     TLSARecord{Usage: 3, Selector: 1, MatchingType: 1, Certificate: sha256Hex("leaf-spki")},
+		},
+		{
+			desc:         "DANE-TA full certificate SHA-256",
+			chain:        chain,
+			usage:        TLSAUsageDANETA,
+			selector:     0,
+			matchingType: 1,
+			expected:     TLSARecord{Usage: 2, Selector: 0, MatchingType: 1, Certificate: sha256Hex("issuer")},
+		},
+		{
+			desc:        "DANE-TA without issuer",
+			chain:       chain[:1],
+			usage:       TLSAUsageDANETA,
+			expectedErr: "the certificate chain doesn't contain the issuer certificate",
+		},
+		{
+			desc:        "unsupported usage",
+			chain:       chain,
+			usage:       4,
+			expectedErr: "unsupported TLSA usage: 4",
+		},
+		{
+			desc:         "bad matching type",
+			chain:        chain,
+			usage:        TLSAUsageDANEEE,
+			matchingType: 3,
+			expectedErr:  "dns: bad MatchingType or Selector",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			record, err := NewTLSARecord(test.chain, test.usage, test.selector, test.matchingType)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, record)
+		})
+	}
+}
+
+func TestRolloverTLSARecords(t *testing.T) {
+	current := []TLSARecord{{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "aa"}}
+	previous := []TLSARecord{
+		{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "bb"},
+		{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "aa"},
+	}
+
+	records := RolloverTLSARecords(current, previous)
+
+	expected := []TLSARecord{
+		{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "aa"},
+		{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "bb"},
+	}
+
+	assert.Equal(t, expected, records)
+}
+
+func TestTLSAName(t *testing.T) {
+	assert.Equal(t, "_25._tcp.mail.example.com.", TLSAName("mail.example.com", 25, "tcp"))
+}
+
+func TestCheckTLSAPropagation(t *testing.T) {
+	fakeTLSA := func(name, data string, ttl uint32) *dns.TLSA {
+		return &dns.TLSA{
+			Hdr:          dns.RR_Header{Name: name, Rrtype: dns.TypeTLSA, Class: dns.ClassINET, Ttl: ttl},
+			Usage:        3,
+			Selector:     1,
+			MatchingType: 1,
+			Certificate:  data,
+		}
+	}
+
+	mockResolver(t,
+		dnsmock.NewServer().
+			Query("ns0.lego.localhost. A",
+				dnsmock.Answer(fakeA("ns0.lego.localhost.", "127.0.0.1"))).
+			Query("_25._tcp.mail.example.com. TLSA",
+				dnsmock.Answer(
+					fakeTLSA("_25._tcp.mail.example.com.", "AA", 300),
+					fakeTLSA("_25._tcp.mail.example.com.", "bb", 600),
+				),
+			).
+			Build(t),
+	)
+
+	useAsNameserver(t,
+		dnsmock.NewServer().
+			Query("_25._tcp.mail.example.com. SOA", dnsmock.Error(dns.RcodeNameError)).
+			Query("_tcp.mail.example.com. SOA", dnsmock.Error(dns.RcodeNameError)).
+			Query("mail.example.com. SOA", dnsmock.Error(dns.RcodeNameError)).
+			Query("example.com. SOA", dnsmock.SOA("")).
+			Query("example.com. NS",
+				dnsmock.Answer(fakeNS("example.com.", "ns0.lego.localhost.")),
+			).
+			Build(t),
+	)
+
+	testCases := []struct {
+		desc          string
+		records       []TLSARecord
+		expectedError string
+	}{
+		{
+			desc: "propagated",
+			records: []TLSARecord{
+				{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "aa"},
+				{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "bb"},
+			},
+		},
+		{
+			desc:          "not propagated",
+			records:       []TLSARecord{{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "cc"}},
+			expectedError: "did not return the expected TLSA record [fqdn: _25._tcp.mail.example.com., value: 3 1 1 cc]",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			ClearFqdnCache()
+
+			ttl, err := CheckTLSAPropagation("_25._tcp.mail.example.com.", test.records)
+			if test.expectedError != "" {
+				require.ErrorContains(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, 10*time.Minute, ttl)
+		})
+	}
+}
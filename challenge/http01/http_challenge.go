@@ -1,6 +1,7 @@
 package http01
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"time"
@@ -8,15 +9,26 @@ import (
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/challenge"
-	"github.com/go-acme/lego/v4/log"
 )
 
 const PathPrefix = "/.well-known/acme-challenge/"
 
 type ValidateFunc func(core *api.Core, domain string, chlng acme.Challenge) error
 
+// ValidateContextFunc is like ValidateFunc, but the requests are canceled when the context is done.
+type ValidateContextFunc func(ctx context.Context, core *api.Core, domain string, chlng acme.Challenge) error
+
 type ChallengeOption func(*Challenge) error
 
+// WithValidateContext sets the validation function used by SolveWithContext
+// (the validation function of NewChallenge is used if not defined).
+func WithValidateContext(validate ValidateContextFunc) ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.validateContext = validate
+		return nil
+	}
+}
+
 // SetDelay sets a delay between the start of the HTTP server and the challenge validation.
 func SetDelay(delay time.Duration) ChallengeOption {
 	return func(chlg *Challenge) error {
@@ -35,10 +47,11 @@ func ChallengePath(token string) string {
 }
 
 type Challenge struct {
-	core     *api.Core
-	validate ValidateFunc
-	provider challenge.Provider
-	delay    time.Duration
+	core            *api.Core
+	validate        ValidateFunc
+	validateContext ValidateContextFunc
+	provider        challenge.Provider
+	delay           time.Duration
 }
 
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
@@ -51,7 +64,7 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 	for _, opt := range opts {
 		err := opt(chlg)
 		if err != nil {
-			log.Infof("challenge option error: %v", err)
+			core.Logger().Infof("challenge option error: %v", err)
 		}
 	}
 
@@ -63,8 +76,13 @@ func (c *Challenge) SetProvider(provider challenge.Provider) {
 }
 
 func (c *Challenge) Solve(authz acme.Authorization) error {
+	return c.SolveWithContext(context.Background(), authz)
+}
+
+// SolveWithContext is like Solve, but the validation is canceled when the context is done.
+func (c *Challenge) SolveWithContext(ctx context.Context, authz acme.Authorization) error {
 	domain := challenge.GetTargetedDomain(authz)
-	log.Infof("[%s] acme: Trying to solve HTTP-01", domain)
+	c.core.Logger().Infof("[%s] acme: Trying to solve HTTP-01", domain)
 
 	chlng, err := challenge.FindChallenge(challenge.HTTP01, authz)
 	if err != nil {
@@ -85,16 +103,24 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 	defer func() {
 		err := c.provider.CleanUp(authz.Identifier.Value, chlng.Token, keyAuth)
 		if err != nil {
-			log.Warnf("[%s] acme: cleaning up failed: %v", domain, err)
+			c.core.Logger().Warnf("[%s] acme: cleaning up failed: %v", domain, err)
 		}
 	}()
 
 	if c.delay > 0 {
-		time.Sleep(c.delay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.delay):
+		}
 	}
 
 	chlng.KeyAuthorization = keyAuth
 
+	if c.validateContext != nil {
+		return c.validateContext(ctx, c.core, domain, chlng)
+	}
+
 	return c.validate(c.core, domain, chlng)
 }
 
@@ -0,0 +1,89 @@
+// Package httpmock provides a mock http-01 challenge provider
+// that records the calls, allows injecting failures and latencies, and offers assertion helpers.
+package httpmock
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/internal/providermock"
+)
+
+const pathPrefix = "/.well-known/acme-challenge/"
+
+var _ challenge.Provider = (*Provider)(nil)
+
+// Call a recorded call to Present or CleanUp.
+type Call = providermock.Call
+
+// Operation the name of a provider operation.
+type Operation = providermock.Operation
+
+const (
+	OperationPresent = providermock.OperationPresent
+	OperationCleanUp = providermock.OperationCleanUp
+)
+
+// Provider a mock http-01 challenge provider.
+type Provider struct {
+	*providermock.Mock
+}
+
+// NewProvider creates a new Provider.
+func NewProvider() *Provider {
+	return &Provider{Mock: providermock.New()}
+}
+
+// Present records the call.
+func (p *Provider) Present(domain, token, keyAuth string) error {
+	return p.Do(OperationPresent, domain, token, keyAuth)
+}
+
+// CleanUp records the call.
+func (p *Provider) CleanUp(domain, token, keyAuth string) error {
+	return p.Do(OperationCleanUp, domain, token, keyAuth)
+}
+
+// Responses returns the challenge responses that are currently presented (path to key authorization).
+func (p *Provider) Responses() map[string]string {
+	responses := make(map[string]string)
+
+	for _, c := range p.Active() {
+		responses[pathPrefix+c.Token] = c.KeyAuth
+	}
+
+	return responses
+}
+
+// AssertResponse asserts that the challenge response for the token is currently presented.
+func (p *Provider) AssertResponse(t testing.TB, token, keyAuth string) bool {
+	t.Helper()
+
+	if p.Responses()[pathPrefix+token] == keyAuth {
+		return true
+	}
+
+	t.Errorf("the challenge response for the token %q is not presented", token)
+
+	return false
+}
+
+// ServeHTTP serves the challenge responses that are currently presented.
+func (p *Provider) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !strings.HasPrefix(req.URL.Path, pathPrefix) {
+		http.NotFound(rw, req)
+		return
+	}
+
+	keyAuth, ok := p.Responses()[req.URL.Path]
+	if !ok {
+		http.NotFound(rw, req)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/plain")
+
+	_, _ = rw.Write([]byte(keyAuth))
+}
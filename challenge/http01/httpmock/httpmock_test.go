@@ -0,0 +1,44 @@
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/challenge/http01"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider(t *testing.T) {
+	provider := NewProvider()
+
+	server := httptest.NewServer(provider)
+	t.Cleanup(server.Close)
+
+	require.NoError(t, provider.Present("example.com", "token", "keyAuth"))
+
+	assert.True(t, provider.AssertResponse(t, "token", "keyAuth"))
+
+	resp, err := server.Client().Get(server.URL + http01.ChallengePath("token"))
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "keyAuth", string(body))
+
+	require.NoError(t, provider.CleanUp("example.com", "token", "keyAuth"))
+
+	resp, err = server.Client().Get(server.URL + http01.ChallengePath("token"))
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.True(t, provider.AssertAllCleanedUp(t))
+}
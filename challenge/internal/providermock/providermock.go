@@ -0,0 +1,231 @@
+// Package providermock contains the shared implementation of the mock challenge providers.
+package providermock
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Operation the name of a provider operation.
+type Operation string
+
+const (
+	OperationPresent Operation = "Present"
+	OperationCleanUp Operation = "CleanUp"
+)
+
+// Call a recorded call to a provider operation.
+type Call struct {
+	Operation Operation
+	Domain    string
+	Token     string
+	KeyAuth   string
+	Err       error
+}
+
+// Mock records the calls to a provider and allows injecting failures and latencies.
+type Mock struct {
+	mu        sync.Mutex
+	calls     []Call
+	errors    map[Operation][]error
+	latencies map[Operation]time.Duration
+}
+
+// New creates a new Mock.
+func New() *Mock {
+	return &Mock{
+		errors:    make(map[Operation][]error),
+		latencies: make(map[Operation]time.Duration),
+	}
+}
+
+// Do records a call and returns the next injected error, if any.
+func (m *Mock) Do(op Operation, domain, token, keyAuth string) error {
+	m.mu.Lock()
+	latency := m.latencies[op]
+	m.mu.Unlock()
+
+	time.Sleep(latency)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var err error
+	if len(m.errors[op]) > 0 {
+		err = m.errors[op][0]
+		m.errors[op] = m.errors[op][1:]
+	}
+
+	m.calls = append(m.calls, Call{Operation: op, Domain: domain, Token: token, KeyAuth: keyAuth, Err: err})
+
+	return err
+}
+
+// FailPresent queues errors returned by the next calls to Present.
+func (m *Mock) FailPresent(errs ...error) {
+	m.fail(OperationPresent, errs)
+}
+
+// FailCleanUp queues errors returned by the next calls to CleanUp.
+func (m *Mock) FailCleanUp(errs ...error) {
+	m.fail(OperationCleanUp, errs)
+}
+
+func (m *Mock) fail(op Operation, errs []error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.errors[op] = append(m.errors[op], errs...)
+}
+
+// SetPresentLatency delays all the calls to Present.
+func (m *Mock) SetPresentLatency(d time.Duration) {
+	m.setLatency(OperationPresent, d)
+}
+
+// SetCleanUpLatency delays all the calls to CleanUp.
+func (m *Mock) SetCleanUpLatency(d time.Duration) {
+	m.setLatency(OperationCleanUp, d)
+}
+
+func (m *Mock) setLatency(op Operation, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.latencies[op] = d
+}
+
+// Calls returns all the recorded calls.
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.calls)
+}
+
+// PresentCalls returns the recorded calls to Present.
+func (m *Mock) PresentCalls() []Call {
+	return m.callsOf(OperationPresent)
+}
+
+// CleanUpCalls returns the recorded calls to CleanUp.
+func (m *Mock) CleanUpCalls() []Call {
+	return m.callsOf(OperationCleanUp)
+}
+
+func (m *Mock) callsOf(op Operation) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []Call
+
+	for _, c := range m.calls {
+		if c.Operation == op {
+			calls = append(calls, c)
+		}
+	}
+
+	return calls
+}
+
+// Active returns the successful Present calls without matching successful CleanUp call.
+func (m *Mock) Active() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var active []Call
+
+	for _, c := range m.calls {
+		if c.Err != nil {
+			continue
+		}
+
+		switch c.Operation {
+		case OperationPresent:
+			active = append(active, c)
+
+		case OperationCleanUp:
+			index := slices.IndexFunc(active, func(a Call) bool {
+				return a.Domain == c.Domain && a.Token == c.Token && a.KeyAuth == c.KeyAuth
+			})
+			if index >= 0 {
+				active = slices.Delete(active, index, index+1)
+			}
+		}
+	}
+
+	return active
+}
+
+// Reset removes the recorded calls, the injected errors, and the latencies.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = nil
+	m.errors = make(map[Operation][]error)
+	m.latencies = make(map[Operation]time.Duration)
+}
+
+// AssertPresented asserts that Present has been called successfully for the domain.
+func (m *Mock) AssertPresented(t testing.TB, domain string) bool {
+	t.Helper()
+
+	return m.assertCalled(t, OperationPresent, domain)
+}
+
+// AssertCleanedUp asserts that CleanUp has been called successfully for the domain.
+func (m *Mock) AssertCleanedUp(t testing.TB, domain string) bool {
+	t.Helper()
+
+	return m.assertCalled(t, OperationCleanUp, domain)
+}
+
+// AssertAllCleanedUp asserts that every successful Present call has been followed by a successful CleanUp call.
+func (m *Mock) AssertAllCleanedUp(t testing.TB) bool {
+	t.Helper()
+
+	active := m.Active()
+	if len(active) == 0 {
+		return true
+	}
+
+	var domains []string
+	for _, c := range active {
+		domains = append(domains, c.Domain)
+	}
+
+	t.Errorf("challenges not cleaned up: %v", domains)
+
+	return false
+}
+
+// AssertCalls asserts the number of calls to Present and CleanUp.
+func (m *Mock) AssertCalls(t testing.TB, present, cleanUp int) bool {
+	t.Helper()
+
+	p, c := len(m.PresentCalls()), len(m.CleanUpCalls())
+	if p == present && c == cleanUp {
+		return true
+	}
+
+	t.Errorf("unexpected calls: Present %d (expected %d), CleanUp %d (expected %d)", p, present, c, cleanUp)
+
+	return false
+}
+
+func (m *Mock) assertCalled(t testing.TB, op Operation, domain string) bool {
+	t.Helper()
+
+	for _, c := range m.callsOf(op) {
+		if c.Domain == domain && c.Err == nil {
+			return true
+		}
+	}
+
+	t.Errorf("%s has not been called successfully for %q", op, domain)
+
+	return false
+}
@@ -0,0 +1,69 @@
+package providermock
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMock_Do(t *testing.T) {
+	m := New()
+
+	m.FailPresent(errors.New("boom"))
+
+	err := m.Do(OperationPresent, "example.com", "token", "keyAuth")
+	require.EqualError(t, err, "boom")
+
+	err = m.Do(OperationPresent, "example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = m.Do(OperationPresent, "example.org", "token2", "keyAuth2")
+	require.NoError(t, err)
+
+	err = m.Do(OperationCleanUp, "example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Len(t, m.Calls(), 4)
+	assert.Len(t, m.PresentCalls(), 3)
+	assert.Len(t, m.CleanUpCalls(), 1)
+
+	expected := []Call{{Operation: OperationPresent, Domain: "example.org", Token: "token2", KeyAuth: "keyAuth2"}}
+	assert.Equal(t, expected, m.Active())
+
+	assert.True(t, m.AssertPresented(t, "example.com"))
+	assert.True(t, m.AssertCleanedUp(t, "example.com"))
+	assert.True(t, m.AssertCalls(t, 3, 1))
+
+	m.Reset()
+
+	assert.Empty(t, m.Calls())
+}
+
+func TestMock_latency(t *testing.T) {
+	m := New()
+
+	m.SetCleanUpLatency(50 * time.Millisecond)
+
+	start := time.Now()
+
+	require.NoError(t, m.Do(OperationCleanUp, "example.com", "token", "keyAuth"))
+
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestMock_assertions_failure(t *testing.T) {
+	m := New()
+
+	require.NoError(t, m.Do(OperationPresent, "example.com", "token", "keyAuth"))
+
+	mt := &testing.T{}
+
+	assert.False(t, m.AssertAllCleanedUp(mt))
+	assert.False(t, m.AssertCleanedUp(mt, "example.com"))
+	assert.False(t, m.AssertPresented(mt, "example.org"))
+	assert.False(t, m.AssertCalls(mt, 1, 1))
+	assert.True(t, mt.Failed())
+}
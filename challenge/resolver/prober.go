@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -14,6 +15,11 @@ type solver interface {
 	Solve(authorization acme.Authorization) error
 }
 
+// Interface for the challenge solvers that can be canceled.
+type contextSolver interface {
+	SolveWithContext(ctx context.Context, authorization acme.Authorization) error
+}
+
 // Interface for challenges like dns, where we can set a record in advance for ALL challenges.
 // This saves quite a bit of time vs creating the records and solving them serially.
 type preSolver interface {
@@ -29,6 +35,12 @@ type sequential interface {
 	Sequential() (bool, time.Duration)
 }
 
+// Interface for challenges like dns, where the challenges of a domain and its wildcard use the same record,
+// and where the provider may not be able to manage several values for this record.
+type multipleValues interface {
+	MultipleTXTValues() bool
+}
+
 // an authz with the solver we have chosen and the index of the challenge associated with it.
 type selectedAuthSolver struct {
 	authz  acme.Authorization
@@ -48,6 +60,13 @@ func NewProber(solverManager *SolverManager) *Prober {
 // Solve Looks through the challenge combinations to find a solvable match.
 // Then solves the challenges in series and returns.
 func (p *Prober) Solve(authorizations []acme.Authorization) error {
+	return p.SolveWithContext(context.Background(), authorizations)
+}
+
+// SolveWithContext is like Solve, but the challenges are canceled when the context is done.
+func (p *Prober) SolveWithContext(ctx context.Context, authorizations []acme.Authorization) error {
+	logger := p.solverManager.core.Logger()
+
 	failures := make(obtainError)
 
 	var (
@@ -62,7 +81,7 @@ func (p *Prober) Solve(authorizations []acme.Authorization) error {
 		domain := challenge.GetTargetedDomain(authz)
 		if authz.Status == acme.StatusValid {
 			// Boulder might recycle recent validated authz (see issue #267)
-			log.Infof("[%s] acme: authorization already valid; skipping challenge", domain)
+			logger.Infof("[%s] acme: authorization already valid; skipping challenge", domain)
 			continue
 		}
 
@@ -84,9 +103,16 @@ func (p *Prober) Solve(authorizations []acme.Authorization) error {
 		}
 	}
 
-	parallelSolve(authSolvers, failures)
+	rounds := splitRounds(authSolvers)
+	if len(rounds) > 1 {
+		logger.Infof("acme: the DNS provider doesn't support multiple TXT values for the same domain; solving the challenges in %d rounds.", len(rounds))
+	}
+
+	for _, round := range rounds {
+		parallelSolve(ctx, logger, round, failures)
+	}
 
-	sequentialSolve(authSolversSequential, failures)
+	sequentialSolve(ctx, logger, authSolversSequential, failures)
 
 	// Be careful not to return an empty failures map,
 	// for even an empty obtainError is a non-nil error value
@@ -97,7 +123,37 @@ func (p *Prober) Solve(authorizations []acme.Authorization) error {
 	return nil
 }
 
-func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
+// splitRounds splits the authorizations into rounds solved one after the other:
+// the authorizations sharing a record (a domain and its wildcard) are in different rounds
+// when the solver can't manage several values for the same record.
+// The records are cleaned up at the end of each round.
+func splitRounds(authSolvers []*selectedAuthSolver) [][]*selectedAuthSolver {
+	var rounds [][]*selectedAuthSolver
+
+	records := make(map[string]int)
+
+	for _, authSolver := range authSolvers {
+		var round int
+
+		if s, ok := authSolver.solver.(multipleValues); ok && !s.MultipleTXTValues() {
+			// The wildcard and the apex use the same record: the identifier value doesn't contain the wildcard prefix.
+			record := authSolver.authz.Identifier.Value
+
+			round = records[record]
+			records[record]++
+		}
+
+		if round >= len(rounds) {
+			rounds = append(rounds, nil)
+		}
+
+		rounds[round] = append(rounds[round], authSolver)
+	}
+
+	return rounds
+}
+
+func sequentialSolve(ctx context.Context, logger log.Printer, authSolvers []*selectedAuthSolver, failures obtainError) {
 	// Some CA are using the same token,
 	// this can be a problem with the DNS01 challenge when the DNS provider doesn't support duplicate TXT records.
 	// In the sequential mode, this is not a problem because we can solve the challenges in order.
@@ -112,7 +168,7 @@ func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 
 		if solvr, ok := authSolver.solver.(preSolver); ok {
 			if _, ok := uniq[authSolver.authz.Identifier.Value+chlg.Token]; ok && chlg.Token != "" {
-				log.Infof("acme: duplicate token for %q (DNS-01); skipping pre-solve.", authSolver.authz.Identifier.Value)
+				logger.Infof("acme: duplicate token for %q (DNS-01); skipping pre-solve.", authSolver.authz.Identifier.Value)
 				continue
 			}
 
@@ -120,7 +176,7 @@ func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 			if err != nil {
 				failures[domain] = err
 
-				cleanUp(authSolver.solver, authSolver.authz)
+				cleanUp(logger, authSolver.solver, authSolver.authz)
 
 				continue
 			}
@@ -129,34 +185,38 @@ func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 		}
 
 		// Solve challenge
-		err := authSolver.solver.Solve(authSolver.authz)
+		err := solve(ctx, authSolver.solver, authSolver.authz)
 		if err != nil {
 			failures[domain] = err
 
-			cleanUp(authSolver.solver, authSolver.authz)
+			cleanUp(logger, authSolver.solver, authSolver.authz)
 
 			continue
 		}
 
 		if _, ok := uniq[authSolver.authz.Identifier.Value+chlg.Token]; ok || chlg.Token == "" {
 			// Clean challenge
-			cleanUp(authSolver.solver, authSolver.authz)
+			cleanUp(logger, authSolver.solver, authSolver.authz)
 
 			if len(authSolvers)-1 > i {
 				solvr := authSolver.solver.(sequential)
 				_, interval := solvr.Sequential()
-				log.Infof("sequence: wait for %s", interval)
-				time.Sleep(interval)
+				logger.Infof("sequence: wait for %s", interval)
+
+				select {
+				case <-ctx.Done():
+				case <-time.After(interval):
+				}
 			}
 
 			delete(uniq, authSolver.authz.Identifier.Value+chlg.Token)
 		} else {
-			log.Infof("acme: duplicate token for %q (DNS-01); skipping cleanup.", authSolver.authz.Identifier.Value)
+			logger.Infof("acme: duplicate token for %q (DNS-01); skipping cleanup.", authSolver.authz.Identifier.Value)
 		}
 	}
 }
 
-func parallelSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
+func parallelSolve(ctx context.Context, logger log.Printer, authSolvers []*selectedAuthSolver, failures obtainError) {
 	// Some CA are using the same token,
 	// this can be a problem with the DNS01 challenge when the DNS provider doesn't support duplicate TXT records.
 	uniq := make(map[string]struct{})
@@ -168,7 +228,7 @@ func parallelSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 		chlg, err := challenge.FindChallenge(challenge.DNS01, authz)
 		if err == nil {
 			if _, ok := uniq[authz.Identifier.Value+chlg.Token]; ok {
-				log.Infof("acme: duplicate token for %q (DNS-01); skipping pre-solve.", authSolver.authz.Identifier.Value)
+				logger.Infof("acme: duplicate token for %q (DNS-01); skipping pre-solve.", authSolver.authz.Identifier.Value)
 				continue
 			}
 
@@ -191,12 +251,12 @@ func parallelSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 				if _, ok := uniq[authSolver.authz.Identifier.Value+chlg.Token]; ok {
 					delete(uniq, authSolver.authz.Identifier.Value+chlg.Token)
 				} else {
-					log.Infof("acme: duplicate token for %q (DNS-01); skipping cleanup.", authSolver.authz.Identifier.Value)
+					logger.Infof("acme: duplicate token for %q (DNS-01); skipping cleanup.", authSolver.authz.Identifier.Value)
 					continue
 				}
 			}
 
-			cleanUp(authSolver.solver, authSolver.authz)
+			cleanUp(logger, authSolver.solver, authSolver.authz)
 		}
 	}()
 
@@ -210,20 +270,33 @@ func parallelSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 			continue
 		}
 
-		err := authSolver.solver.Solve(authz)
+		err := solve(ctx, authSolver.solver, authz)
 		if err != nil {
 			failures[domain] = err
 		}
 	}
 }
 
-func cleanUp(solvr solver, authz acme.Authorization) {
+// solve solves the challenge of the authorization, the solvers that can be canceled use the context.
+func solve(ctx context.Context, solvr solver, authz acme.Authorization) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if s, ok := solvr.(contextSolver); ok {
+		return s.SolveWithContext(ctx, authz)
+	}
+
+	return solvr.Solve(authz)
+}
+
+func cleanUp(logger log.Printer, solvr solver, authz acme.Authorization) {
 	if solvr, ok := solvr.(cleanup); ok {
 		domain := challenge.GetTargetedDomain(authz)
 
 		err := solvr.CleanUp(authz)
 		if err != nil {
-			log.Warnf("[%s] acme: cleaning up failed: %v ", domain, err)
+			logger.Warnf("[%s] acme: cleaning up failed: %v ", domain, err)
 		}
 	}
 }
@@ -77,3 +77,48 @@ func createStubAuthorization(domain, status string, wildcard bool, chlgs ...acme
 		Challenges: chlgs,
 	}
 }
+
+// singleValueSolverMock a DNS-01 solver unable to manage several TXT values for the same domain.
+type singleValueSolverMock struct {
+	records map[string]string
+	events  []string
+}
+
+func (s *singleValueSolverMock) PreSolve(authorization acme.Authorization) error {
+	chlg, _ := challenge.FindChallenge(challenge.DNS01, authorization)
+
+	// The creation of the record replaces the existing value.
+	s.records[authorization.Identifier.Value] = chlg.Token
+	s.events = append(s.events, "present "+challenge.GetTargetedDomain(authorization))
+
+	return nil
+}
+
+func (s *singleValueSolverMock) Solve(authorization acme.Authorization) error {
+	chlg, _ := challenge.FindChallenge(challenge.DNS01, authorization)
+
+	if s.records[authorization.Identifier.Value] != chlg.Token {
+		return fmt.Errorf("the record of %s has been replaced", challenge.GetTargetedDomain(authorization))
+	}
+
+	return nil
+}
+
+func (s *singleValueSolverMock) CleanUp(authorization acme.Authorization) error {
+	delete(s.records, authorization.Identifier.Value)
+	s.events = append(s.events, "cleanup "+challenge.GetTargetedDomain(authorization))
+
+	return nil
+}
+
+func (s *singleValueSolverMock) MultipleTXTValues() bool {
+	return false
+}
+
+func createStubAuthorizationDNS01Token(domain string, wildcard bool, token string) acme.Authorization {
+	return createStubAuthorization(domain, acme.StatusProcessing, wildcard, acme.Challenge{
+		Type:      challenge.DNS01.String(),
+		Token:     token,
+		Validated: time.Now(),
+	})
+}
@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -155,3 +156,73 @@ func TestProber_Solve(t *testing.T) {
 		})
 	}
 }
+
+func TestProber_SolveWithContext_canceled(t *testing.T) {
+	httpSolver := &preSolverMock{
+		preSolve: map[string]error{},
+		solve:    map[string]error{},
+		cleanUp:  map[string]error{},
+	}
+
+	prober := &Prober{
+		solverManager: &SolverManager{solvers: map[challenge.Type]solver{challenge.HTTP01: httpSolver}},
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	err := prober.SolveWithContext(ctx, []acme.Authorization{
+		createStubAuthorizationHTTP01("example.com", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("example.org", acme.StatusProcessing),
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, "PreSolve: 2, Solve: 0, CleanUp: 2", httpSolver.String())
+}
+
+func TestProber_Solve_singleTXTValue(t *testing.T) {
+	dnsSolver := &singleValueSolverMock{records: map[string]string{}}
+
+	prober := &Prober{
+		solverManager: &SolverManager{solvers: map[challenge.Type]solver{challenge.DNS01: dnsSolver}},
+	}
+
+	authz := []acme.Authorization{
+		createStubAuthorizationDNS01Token("a.example", false, "token1"),
+		createStubAuthorizationDNS01Token("a.example", true, "token2"),
+		createStubAuthorizationDNS01Token("b.example", false, "token3"),
+	}
+
+	err := prober.Solve(authz)
+	require.NoError(t, err)
+
+	expected := []string{
+		"present a.example",
+		"present b.example",
+		"cleanup a.example",
+		"cleanup b.example",
+		"present *.a.example",
+		"cleanup *.a.example",
+	}
+
+	assert.Equal(t, expected, dnsSolver.events)
+}
+
+func Test_splitRounds(t *testing.T) {
+	single := &singleValueSolverMock{}
+	multiple := &preSolverMock{}
+
+	authSolvers := []*selectedAuthSolver{
+		{authz: createStubAuthorizationDNS01Token("a.example", false, "1"), solver: single},
+		{authz: createStubAuthorizationDNS01Token("a.example", true, "2"), solver: single},
+		{authz: createStubAuthorizationDNS01Token("b.example", false, "3"), solver: multiple},
+		{authz: createStubAuthorizationDNS01Token("b.example", true, "4"), solver: multiple},
+	}
+
+	rounds := splitRounds(authSolvers)
+
+	require.Len(t, rounds, 2)
+
+	assert.Equal(t, []*selectedAuthSolver{authSolvers[0], authSolvers[2], authSolvers[3]}, rounds[0])
+	assert.Equal(t, []*selectedAuthSolver{authSolvers[1]}, rounds[1])
+}
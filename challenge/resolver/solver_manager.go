@@ -14,7 +14,6 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/challenge/http01"
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
-	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/wait"
 )
 
@@ -38,18 +37,21 @@ func NewSolversManager(core *api.Core) *SolverManager {
 
 // SetHTTP01Provider specifies a custom provider p that can solve the given HTTP-01 challenge.
 func (c *SolverManager) SetHTTP01Provider(p challenge.Provider, opts ...http01.ChallengeOption) error {
+	opts = append([]http01.ChallengeOption{http01.WithValidateContext(validateWithContext)}, opts...)
 	c.solvers[challenge.HTTP01] = http01.NewChallenge(c.core, validate, p, opts...)
 	return nil
 }
 
 // SetTLSALPN01Provider specifies a custom provider p that can solve the given TLS-ALPN-01 challenge.
 func (c *SolverManager) SetTLSALPN01Provider(p challenge.Provider, opts ...tlsalpn01.ChallengeOption) error {
+	opts = append([]tlsalpn01.ChallengeOption{tlsalpn01.WithValidateContext(validateWithContext)}, opts...)
 	c.solvers[challenge.TLSALPN01] = tlsalpn01.NewChallenge(c.core, validate, p, opts...)
 	return nil
 }
 
 // SetDNS01Provider specifies a custom provider p that can solve the given DNS-01 challenge.
 func (c *SolverManager) SetDNS01Provider(p challenge.Provider, opts ...dns01.ChallengeOption) error {
+	opts = append([]dns01.ChallengeOption{dns01.WithValidateContext(validateWithContext)}, opts...)
 	c.solvers[challenge.DNS01] = dns01.NewChallenge(c.core, validate, p, opts...)
 	return nil
 }
@@ -67,18 +69,22 @@ func (c *SolverManager) chooseSolver(authz acme.Authorization) solver {
 	domain := challenge.GetTargetedDomain(authz)
 	for _, chlg := range authz.Challenges {
 		if solvr, ok := c.solvers[challenge.Type(chlg.Type)]; ok {
-			log.Infof("[%s] acme: use %s solver", domain, chlg.Type)
+			c.core.Logger().Infof("[%s] acme: use %s solver", domain, chlg.Type)
 			return solvr
 		}
 
-		log.Infof("[%s] acme: Could not find solver for: %s", domain, chlg.Type)
+		c.core.Logger().Infof("[%s] acme: Could not find solver for: %s", domain, chlg.Type)
 	}
 
 	return nil
 }
 
 func validate(core *api.Core, domain string, chlg acme.Challenge) error {
-	chlng, err := core.Challenges.New(chlg.URL)
+	return validateWithContext(context.Background(), core, domain, chlg)
+}
+
+func validateWithContext(ctx context.Context, core *api.Core, domain string, chlg acme.Challenge) error {
+	chlng, err := core.Challenges.NewWithContext(ctx, chlg.URL)
 	if err != nil {
 		return fmt.Errorf("failed to initiate challenge: %w", err)
 	}
@@ -89,7 +95,7 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 	}
 
 	if valid {
-		log.Infof("[%s] The server validated our request", domain)
+		core.Logger().Infof("[%s] The server validated our request", domain)
 		return nil
 	}
 
@@ -102,8 +108,6 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 		retryAfter = 5 * time.Second
 	}
 
-	ctx := context.Background()
-
 	bo := backoff.NewExponentialBackOff()
 	bo.InitialInterval = retryAfter
 	bo.MaxInterval = 10 * retryAfter
@@ -111,7 +115,7 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 	// After the path is sent, the ACME server will access our server.
 	// Repeatedly check the server for an updated status on our request.
 	operation := func() error {
-		authz, err := core.Authorizations.Get(chlng.AuthorizationURL)
+		authz, err := core.Authorizations.GetWithContext(ctx, chlng.AuthorizationURL)
 		if err != nil {
 			return backoff.Permanent(err)
 		}
@@ -122,7 +126,7 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 		}
 
 		if valid {
-			log.Infof("[%s] The server validated our request", domain)
+			core.Logger().Infof("[%s] The server validated our request", domain)
 			return nil
 		}
 
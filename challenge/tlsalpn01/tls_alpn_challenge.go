@@ -1,6 +1,7 @@
 package tlsalpn01
 
 import (
+	"context"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/tls"
@@ -13,7 +14,6 @@ import (
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/challenge"
-	"github.com/go-acme/lego/v4/log"
 )
 
 // idPeAcmeIdentifierV1 is the SMI Security for PKIX Certification Extension OID referencing the ACME extension.
@@ -22,8 +22,20 @@ var idPeAcmeIdentifierV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
 
 type ValidateFunc func(core *api.Core, domain string, chlng acme.Challenge) error
 
+// ValidateContextFunc is like ValidateFunc, but the requests are canceled when the context is done.
+type ValidateContextFunc func(ctx context.Context, core *api.Core, domain string, chlng acme.Challenge) error
+
 type ChallengeOption func(*Challenge) error
 
+// WithValidateContext sets the validation function used by SolveWithContext
+// (the validation function of NewChallenge is used if not defined).
+func WithValidateContext(validate ValidateContextFunc) ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.validateContext = validate
+		return nil
+	}
+}
+
 // SetDelay sets a delay between the start of the TLS listener and the challenge validation.
 func SetDelay(delay time.Duration) ChallengeOption {
 	return func(chlg *Challenge) error {
@@ -33,10 +45,11 @@ func SetDelay(delay time.Duration) ChallengeOption {
 }
 
 type Challenge struct {
-	core     *api.Core
-	validate ValidateFunc
-	provider challenge.Provider
-	delay    time.Duration
+	core            *api.Core
+	validate        ValidateFunc
+	validateContext ValidateContextFunc
+	provider        challenge.Provider
+	delay           time.Duration
 }
 
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
@@ -49,7 +62,7 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 	for _, opt := range opts {
 		err := opt(chlg)
 		if err != nil {
-			log.Infof("challenge option error: %v", err)
+			core.Logger().Infof("challenge option error: %v", err)
 		}
 	}
 
@@ -62,8 +75,13 @@ func (c *Challenge) SetProvider(provider challenge.Provider) {
 
 // Solve manages the provider to validate and solve the challenge.
 func (c *Challenge) Solve(authz acme.Authorization) error {
+	return c.SolveWithContext(context.Background(), authz)
+}
+
+// SolveWithContext is like Solve, but the validation is canceled when the context is done.
+func (c *Challenge) SolveWithContext(ctx context.Context, authz acme.Authorization) error {
 	domain := authz.Identifier.Value
-	log.Infof("[%s] acme: Trying to solve TLS-ALPN-01", challenge.GetTargetedDomain(authz))
+	c.core.Logger().Infof("[%s] acme: Trying to solve TLS-ALPN-01", challenge.GetTargetedDomain(authz))
 
 	chlng, err := challenge.FindChallenge(challenge.TLSALPN01, authz)
 	if err != nil {
@@ -84,16 +102,24 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 	defer func() {
 		err := c.provider.CleanUp(domain, chlng.Token, keyAuth)
 		if err != nil {
-			log.Warnf("[%s] acme: cleaning up failed: %v", challenge.GetTargetedDomain(authz), err)
+			c.core.Logger().Warnf("[%s] acme: cleaning up failed: %v", challenge.GetTargetedDomain(authz), err)
 		}
 	}()
 
 	if c.delay > 0 {
-		time.Sleep(c.delay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.delay):
+		}
 	}
 
 	chlng.KeyAuthorization = keyAuth
 
+	if c.validateContext != nil {
+		return c.validateContext(ctx, c.core, domain, chlng)
+	}
+
 	return c.validate(c.core, domain, chlng)
 }
 
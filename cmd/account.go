@@ -11,6 +11,9 @@ type Account struct {
 	Email        string                 `json:"email"`
 	Registration *registration.Resource `json:"registration"`
 	key          crypto.PrivateKey
+
+	// server the URL of the directory of the CA of the account.
+	server string
 }
 
 /** Implementation of the registration.User interface **/
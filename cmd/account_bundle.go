@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/registration"
+)
+
+// accountBundleVersion the version of the format of the account bundles.
+const accountBundleVersion = 1
+
+// accountBundle an account exported to be imported on another host (account export/import).
+type accountBundle struct {
+	Version      int                    `json:"version"`
+	Server       string                 `json:"server"`
+	Email        string                 `json:"email,omitempty"`
+	Registration *registration.Resource `json:"registration"`
+
+	// Key the PEM encoded private key of the account,
+	// encrypted (PKCS#8, ENCRYPTED PRIVATE KEY) when a passphrase is defined.
+	Key string `json:"key"`
+}
+
+// encodeAccountBundle creates the bundle of the account.
+// The private key is encrypted with the passphrase, if any.
+func encodeAccountBundle(server string, account *Account, passphrase []byte) ([]byte, error) {
+	var (
+		keyPEM []byte
+		err    error
+	)
+
+	if len(passphrase) > 0 {
+		keyPEM, err = certcrypto.PEMEncodeEncrypted(account.key, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		pemBlock := certcrypto.PEMBlock(account.key)
+		if pemBlock == nil {
+			return nil, fmt.Errorf("unsupported private key type: %T", account.key)
+		}
+
+		keyPEM = pem.EncodeToMemory(pemBlock)
+	}
+
+	bundle := accountBundle{
+		Version:      accountBundleVersion,
+		Server:       server,
+		Email:        account.Email,
+		Registration: account.Registration,
+		Key:          string(keyPEM),
+	}
+
+	return json.MarshalIndent(bundle, "", "\t")
+}
+
+// decodeAccountBundle reads the bundle of an account, and decrypts the private key with the passphrase.
+func decodeAccountBundle(data, passphrase []byte) (*accountBundle, crypto.PrivateKey, error) {
+	var bundle accountBundle
+
+	err := json.Unmarshal(data, &bundle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid account bundle: %w", err)
+	}
+
+	if bundle.Version != accountBundleVersion {
+		return nil, nil, fmt.Errorf("unsupported account bundle version: %d", bundle.Version)
+	}
+
+	if bundle.Server == "" || bundle.Registration == nil || bundle.Registration.URI == "" {
+		return nil, nil, errors.New("invalid account bundle: the server and the registration are required")
+	}
+
+	if isEncryptedPEM([]byte(bundle.Key)) && len(passphrase) == 0 {
+		return nil, nil, errors.New("the key of the account bundle is encrypted: a passphrase is required")
+	}
+
+	privateKey, err := certcrypto.ParsePEMPrivateKeyWithPassphrase([]byte(bundle.Key), passphrase)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid key in the account bundle: %w", err)
+	}
+
+	return &bundle, privateKey, nil
+}
+
+// readPassphraseFile reads a passphrase from a file, without the trailing line break.
+func readPassphraseFile(filename string) ([]byte, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase := bytes.TrimRight(data, "\r\n")
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("the passphrase file %s is empty", filename)
+	}
+
+	return passphrase, nil
+}
+
+func isEncryptedPEM(data []byte) bool {
+	block, _ := pem.Decode(data)
+
+	return block != nil && block.Type == "ENCRYPTED PRIVATE KEY"
+}
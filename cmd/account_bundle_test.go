@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_encodeAccountBundle(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		passphrase []byte
+		encrypted  bool
+	}{
+		{
+			desc: "without passphrase",
+		},
+		{
+			desc:       "with passphrase",
+			passphrase: []byte("secret"),
+			encrypted:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+			require.NoError(t, err)
+
+			account := &Account{
+				Email: "test@example.com",
+				Registration: &registration.Resource{
+					URI:  "https://ca.example.com/acme/acct/1",
+					Body: acme.Account{Status: acme.StatusValid},
+				},
+				key: privateKey,
+			}
+
+			data, err := encodeAccountBundle("https://ca.example.com/directory", account, test.passphrase)
+			require.NoError(t, err)
+
+			bundle, key, err := decodeAccountBundle(data, test.passphrase)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.encrypted, isEncryptedPEM([]byte(bundle.Key)))
+
+			assert.Equal(t, "https://ca.example.com/directory", bundle.Server)
+			assert.Equal(t, "test@example.com", bundle.Email)
+			assert.Equal(t, account.Registration, bundle.Registration)
+			assert.Equal(t, privateKey, key)
+		})
+	}
+}
+
+func Test_decodeAccountBundle_error(t *testing.T) {
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+
+	account := &Account{
+		Registration: &registration.Resource{URI: "https://ca.example.com/acme/acct/1"},
+		key:          privateKey,
+	}
+
+	encrypted, err := encodeAccountBundle("https://ca.example.com/directory", account, []byte("secret"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc       string
+		data       string
+		passphrase []byte
+		expected   string
+	}{
+		{
+			desc:     "invalid JSON",
+			data:     "foo",
+			expected: "invalid account bundle: invalid character 'o' in literal false (expecting 'a')",
+		},
+		{
+			desc:     "unsupported version",
+			data:     `{"version": 2}`,
+			expected: "unsupported account bundle version: 2",
+		},
+		{
+			desc:     "missing registration",
+			data:     `{"version": 1, "server": "https://ca.example.com/directory"}`,
+			expected: "invalid account bundle: the server and the registration are required",
+		},
+		{
+			desc:     "missing passphrase",
+			data:     string(encrypted),
+			expected: "the key of the account bundle is encrypted: a passphrase is required",
+		},
+		{
+			desc:       "wrong passphrase",
+			data:       string(encrypted),
+			passphrase: []byte("wrong"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := decodeAccountBundle([]byte(test.data), test.passphrase)
+			require.Error(t, err)
+
+			if test.expected != "" {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_readPassphraseFile(t *testing.T) {
+	dir := t.TempDir()
+
+	filename := filepath.Join(dir, "passphrase")
+	writeTestFile(t, filename, "secret\n")
+
+	passphrase, err := readPassphraseFile(filename)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("secret"), passphrase)
+
+	empty := filepath.Join(dir, "empty")
+	writeTestFile(t, empty, "\n")
+
+	_, err = readPassphraseFile(empty)
+	require.Error(t, err)
+}
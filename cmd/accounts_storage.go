@@ -4,6 +4,8 @@ import (
 	"crypto"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
+	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -60,6 +62,7 @@ const (
 type AccountsStorage struct {
 	userID          string
 	email           string
+	server          string
 	rootPath        string
 	rootUserPath    string
 	keysPath        string
@@ -68,18 +71,21 @@ type AccountsStorage struct {
 }
 
 // NewAccountsStorage Creates a new AccountsStorage.
-func NewAccountsStorage(ctx *cli.Context) *AccountsStorage {
+func NewAccountsStorage(ctx *cli.Context) (*AccountsStorage, error) {
 	// TODO: move to account struct?
-	email := ctx.String(flgEmail)
+	return newAccountsStorage(ctx, getServer(ctx), ctx.String(flgEmail))
+}
 
+// newAccountsStorage creates a new AccountsStorage for the server and the email (e.g. for an imported account).
+func newAccountsStorage(ctx *cli.Context, server, email string) (*AccountsStorage, error) {
 	userID := email
 	if userID == "" {
 		userID = userIDPlaceholder
 	}
 
-	serverURL, err := url.Parse(ctx.String(flgServer))
+	serverURL, err := url.Parse(server)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("invalid server URL %q: %w", server, err)
 	}
 
 	rootPath := filepath.Join(ctx.String(flgPath), baseAccountsRootFolderName)
@@ -90,23 +96,24 @@ func NewAccountsStorage(ctx *cli.Context) *AccountsStorage {
 	return &AccountsStorage{
 		userID:          userID,
 		email:           email,
+		server:          server,
 		rootPath:        rootPath,
 		rootUserPath:    rootUserPath,
 		keysPath:        filepath.Join(rootUserPath, baseKeysFolderName),
 		accountFilePath: filepath.Join(rootUserPath, accountFileName),
 		ctx:             ctx,
-	}
+	}, nil
 }
 
-func (s *AccountsStorage) ExistsAccountFilePath() bool {
+func (s *AccountsStorage) ExistsAccountFilePath() (bool, error) {
 	accountFile := filepath.Join(s.rootUserPath, accountFileName)
 	if _, err := os.Stat(accountFile); os.IsNotExist(err) {
-		return false
+		return false, nil
 	} else if err != nil {
-		log.Fatal(err)
+		return false, err
 	}
 
-	return true
+	return true, nil
 }
 
 func (s *AccountsStorage) GetRootPath() string {
@@ -125,6 +132,11 @@ func (s *AccountsStorage) GetEmail() string {
 	return s.email
 }
 
+// GetServer returns the URL of the directory of the CA of the accounts.
+func (s *AccountsStorage) GetServer() string {
+	return s.server
+}
+
 func (s *AccountsStorage) Save(account *Account) error {
 	jsonBytes, err := json.MarshalIndent(account, "", "\t")
 	if err != nil {
@@ -134,67 +146,142 @@ func (s *AccountsStorage) Save(account *Account) error {
 	return os.WriteFile(s.accountFilePath, jsonBytes, filePerm)
 }
 
-func (s *AccountsStorage) LoadAccount(privateKey crypto.PrivateKey) *Account {
+func (s *AccountsStorage) LoadAccount(privateKey crypto.PrivateKey) (*Account, error) {
 	fileBytes, err := os.ReadFile(s.accountFilePath)
 	if err != nil {
-		log.Fatalf("Could not load file for account %s: %v", s.GetUserID(), err)
+		return nil, fmt.Errorf("could not load file for account %s: %w", s.GetUserID(), err)
 	}
 
 	var account Account
 
 	err = json.Unmarshal(fileBytes, &account)
 	if err != nil {
-		log.Fatalf("Could not parse file for account %s: %v", s.GetUserID(), err)
+		return nil, fmt.Errorf("could not parse file for account %s: %w", s.GetUserID(), err)
 	}
 
 	account.key = privateKey
 
 	if account.Registration == nil || account.Registration.Body.Status == "" {
-		reg, err := tryRecoverRegistration(s.ctx, privateKey)
+		reg, err := tryRecoverRegistration(s.ctx, s.server, privateKey)
 		if err != nil {
-			log.Fatalf("Could not load account for %s. Registration is nil: %#v", s.GetUserID(), err)
+			return nil, fmt.Errorf("could not load account for %s, registration is nil: %w", s.GetUserID(), err)
 		}
 
 		account.Registration = reg
 
 		err = s.Save(&account)
 		if err != nil {
-			log.Fatalf("Could not save account for %s. Registration is nil: %#v", s.GetUserID(), err)
+			return nil, fmt.Errorf("could not save account for %s, registration is nil: %w", s.GetUserID(), err)
 		}
 	}
 
-	return &account
+	return &account, nil
 }
 
-func (s *AccountsStorage) GetPrivateKey(keyType certcrypto.KeyType) crypto.PrivateKey {
+func (s *AccountsStorage) GetPrivateKey(keyType certcrypto.KeyType) (crypto.PrivateKey, error) {
 	accKeyPath := filepath.Join(s.keysPath, s.GetUserID()+".key")
 
 	if _, err := os.Stat(accKeyPath); os.IsNotExist(err) {
-		log.Printf("No key found for account %s. Generating a %s key.", s.GetUserID(), keyType)
-		s.createKeysFolder()
+		log.Infof("No key found for account %s. Generating a %s key.", s.GetUserID(), keyType)
+
+		err = s.createKeysFolder()
+		if err != nil {
+			return nil, err
+		}
 
 		privateKey, err := generatePrivateKey(accKeyPath, keyType)
 		if err != nil {
-			log.Fatalf("Could not generate RSA private account key for account %s: %v", s.GetUserID(), err)
+			return nil, fmt.Errorf("could not generate the private key for account %s: %w", s.GetUserID(), err)
 		}
 
-		log.Printf("Saved key to %s", accKeyPath)
+		log.Infof("Saved key to %s", accKeyPath)
 
-		return privateKey
+		return privateKey, nil
 	}
 
-	privateKey, err := loadPrivateKey(accKeyPath)
+	privateKey, err := loadPrivateKey(accKeyPath, nil)
 	if err != nil {
-		log.Fatalf("Could not load RSA private key from file %s: %v", accKeyPath, err)
+		return nil, fmt.Errorf("could not load the private key from file %s: %w", accKeyPath, err)
 	}
 
-	return privateKey
+	return privateKey, nil
+}
+
+// LoadPrivateKey loads the private key of the account, without generating it.
+func (s *AccountsStorage) LoadPrivateKey() (crypto.PrivateKey, error) {
+	return loadPrivateKey(filepath.Join(s.keysPath, s.GetUserID()+".key"), nil)
 }
 
-func (s *AccountsStorage) createKeysFolder() {
+// SavePrivateKey saves the private key of the account (e.g. for an imported account).
+func (s *AccountsStorage) SavePrivateKey(privateKey crypto.PrivateKey) error {
+	err := s.createKeysFolder()
+	if err != nil {
+		return err
+	}
+
+	pemBlock := certcrypto.PEMBlock(privateKey)
+	if pemBlock == nil {
+		return fmt.Errorf("unsupported private key type: %T", privateKey)
+	}
+
+	return os.WriteFile(filepath.Join(s.keysPath, s.GetUserID()+".key"), pem.EncodeToMemory(pemBlock), filePerm)
+}
+
+// StagePrivateKey saves the next private key of the account next to the current one (key rollover).
+// The key is saved before the key change on the CA to never lose it.
+func (s *AccountsStorage) StagePrivateKey(privateKey crypto.PrivateKey) (string, error) {
+	err := s.createKeysFolder()
+	if err != nil {
+		return "", err
+	}
+
+	pemBlock := certcrypto.PEMBlock(privateKey)
+	if pemBlock == nil {
+		return "", fmt.Errorf("unsupported private key type: %T", privateKey)
+	}
+
+	stagedPath := filepath.Join(s.keysPath, s.GetUserID()+".key.next")
+
+	err = os.WriteFile(stagedPath, pem.EncodeToMemory(pemBlock), filePerm)
+	if err != nil {
+		return "", err
+	}
+
+	return stagedPath, nil
+}
+
+// ReplacePrivateKey replaces atomically the private key of the account by the staged one (see StagePrivateKey).
+func (s *AccountsStorage) ReplacePrivateKey(stagedPath string) error {
+	return os.Rename(stagedPath, filepath.Join(s.keysPath, s.GetUserID()+".key"))
+}
+
+// Move moves the account to the storage of another account (e.g. when the email of the account changes).
+func (s *AccountsStorage) Move(target *AccountsStorage) error {
+	if _, err := os.Stat(target.rootUserPath); err == nil {
+		return fmt.Errorf("the directory %s already exists", target.rootUserPath)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	err := createNonExistingFolder(filepath.Dir(target.rootUserPath))
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(s.rootUserPath, target.rootUserPath)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(filepath.Join(target.keysPath, s.userID+".key"), filepath.Join(target.keysPath, target.userID+".key"))
+}
+
+func (s *AccountsStorage) createKeysFolder() error {
 	if err := createNonExistingFolder(s.keysPath); err != nil {
-		log.Fatalf("Could not check/create directory for account %s: %v", s.GetUserID(), err)
+		return fmt.Errorf("could not check/create directory for account %s: %w", s.GetUserID(), err)
 	}
+
+	return nil
 }
 
 func generatePrivateKey(file string, keyType certcrypto.KeyType) (crypto.PrivateKey, error) {
@@ -219,13 +306,13 @@ func generatePrivateKey(file string, keyType certcrypto.KeyType) (crypto.Private
 	return privateKey, nil
 }
 
-func loadPrivateKey(file string) (crypto.PrivateKey, error) {
+func loadPrivateKey(file string, passphrase []byte) (crypto.PrivateKey, error) {
 	keyBytes, err := os.ReadFile(file)
 	if err != nil {
 		return nil, err
 	}
 
-	privateKey, err := certcrypto.ParsePEMPrivateKey(keyBytes)
+	privateKey, err := certcrypto.ParsePEMPrivateKeyWithPassphrase(keyBytes, passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -233,10 +320,10 @@ func loadPrivateKey(file string) (crypto.PrivateKey, error) {
 	return privateKey, nil
 }
 
-func tryRecoverRegistration(ctx *cli.Context, privateKey crypto.PrivateKey) (*registration.Resource, error) {
+func tryRecoverRegistration(ctx *cli.Context, server string, privateKey crypto.PrivateKey) (*registration.Resource, error) {
 	// couldn't load account but got a key. Try to look the account up.
 	config := lego.NewConfig(&Account{key: privateKey})
-	config.CADirURL = ctx.String(flgServer)
+	config.CADirURL = server
 	config.UserAgent = getUserAgent(ctx)
 
 	client, err := lego.NewClient(config)
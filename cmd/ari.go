@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+)
+
+// ARIInfo the last renewal information (ARI) returned by the CA for the certificate.
+// The information is used until the time indicated by the CA (Retry-After), instead of calling the renewalInfo endpoint at each renewal.
+type ARIInfo struct {
+	// CertID the ARI identifier of the certificate.
+	CertID          string      `json:"certID"`
+	SuggestedWindow acme.Window `json:"suggestedWindow"`
+	ExplanationURL  string      `json:"explanationURL,omitempty"`
+	// RetryAt the time after which the renewalInfo endpoint must be called again (Retry-After).
+	RetryAt time.Time `json:"retryAt"`
+}
+
+// usable returns true if the information is related to the certificate, and has not expired.
+func (a *ARIInfo) usable(certID string, now time.Time) bool {
+	return a != nil && a.CertID == certID && now.Before(a.RetryAt)
+}
+
+func (a *ARIInfo) response() *certificate.RenewalInfoResponse {
+	return &certificate.RenewalInfoResponse{
+		RenewalInfoResponse: acme.RenewalInfoResponse{
+			SuggestedWindow: a.SuggestedWindow,
+			ExplanationURL:  a.ExplanationURL,
+		},
+		RetryAfter: a.RetryAt.Sub(renewClock.Now()),
+	}
+}
+
+// getRenewalInfo returns the renewal information (ARI) of the certificate.
+// The response is stored in the resource file, and used until the time indicated by the CA (Retry-After):
+// the renewalInfo endpoint is not called again by the next executions (e.g. cron) before this time.
+func getRenewalInfo(client *lego.Client, certsStorage *CertificatesStorage, domain string, cert *x509.Certificate) (*certificate.RenewalInfoResponse, error) {
+	certID, err := certificate.MakeARICertID(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	now := renewClock.Now().UTC()
+
+	metadata, err := certsStorage.ReadResourceMetadata(domain)
+	if err == nil && metadata.ARI.usable(certID, now) {
+		log.Infof("[%s] acme: using the stored renewal information, the renewalInfo endpoint will be called again after %s",
+			domain, metadata.ARI.RetryAt.Format(time.RFC3339))
+
+		return metadata.ARI.response(), nil
+	}
+
+	renewalInfo, err := client.Certificate.GetRenewalInfo(certificate.RenewalInfoRequest{Cert: cert})
+	if err != nil {
+		return nil, err
+	}
+
+	// Without Retry-After, the renewal information is not stored.
+	if renewalInfo.RetryAfter <= 0 {
+		return renewalInfo, nil
+	}
+
+	err = certsStorage.updateResourceMetadata(domain, func(metadata *ResourceMetadata) {
+		metadata.ARI = &ARIInfo{
+			CertID:          certID,
+			SuggestedWindow: renewalInfo.SuggestedWindow,
+			ExplanationURL:  renewalInfo.ExplanationURL,
+			RetryAt:         now.Add(renewalInfo.RetryAfter),
+		}
+	})
+	if err != nil {
+		log.Warnf("[%s] acme: unable to store the renewal information: %v", domain, err)
+	}
+
+	return renewalInfo, nil
+}
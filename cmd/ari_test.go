@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge/http01/httpmock"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/platform/clock"
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/go-acme/lego/v4/platform/pebble/pebbletest"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getRenewalInfo(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	clk := clock.NewFake(now)
+
+	original := renewClock
+
+	t.Cleanup(func() {
+		renewClock = original
+	})
+
+	renewClock = clk
+
+	window := acme.Window{Start: now.Add(24 * time.Hour), End: now.Add(48 * time.Hour)}
+
+	var calls atomic.Int32
+
+	server := pebbletest.NewServer(t,
+		pebble.WithRenewalInfo(func(*x509.Certificate) acme.RenewalInfoResponse {
+			calls.Add(1)
+
+			return acme.RenewalInfoResponse{SuggestedWindow: window}
+		}),
+		pebble.WithRenewalInfoRetryAfter(6*time.Hour),
+	)
+
+	client, cert := newARITestCertificate(t, server)
+
+	storage := &CertificatesStorage{rootPath: t.TempDir()}
+
+	require.NoError(t, storage.WriteFile("example.com", resourceExt, []byte(`{"domain":"example.com"}`)))
+
+	// The renewal information is fetched, and stored.
+	info, err := getRenewalInfo(client, storage, "example.com", cert)
+	require.NoError(t, err)
+
+	assert.True(t, window.Start.Equal(info.SuggestedWindow.Start))
+	assert.Equal(t, int32(1), calls.Load())
+
+	metadata, err := storage.ReadResourceMetadata("example.com")
+	require.NoError(t, err)
+
+	require.NotNil(t, metadata.ARI)
+	assert.Equal(t, now.Add(6*time.Hour), metadata.ARI.RetryAt)
+
+	resource, err := storage.readResource("example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", resource.Domain)
+
+	// The stored renewal information is used before Retry-After.
+	clk.Advance(5 * time.Hour)
+
+	info, err = getRenewalInfo(client, storage, "example.com", cert)
+	require.NoError(t, err)
+
+	assert.True(t, window.End.Equal(info.SuggestedWindow.End))
+	assert.Equal(t, int32(1), calls.Load())
+
+	// The renewal information is fetched again after Retry-After.
+	clk.Advance(time.Hour)
+
+	_, err = getRenewalInfo(client, storage, "example.com", cert)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func Test_ARIInfo_usable(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	info := &ARIInfo{CertID: "a.b", RetryAt: now.Add(time.Hour)}
+
+	assert.True(t, info.usable("a.b", now))
+	assert.False(t, info.usable("c.d", now))
+	assert.False(t, info.usable("a.b", now.Add(time.Hour)))
+
+	var empty *ARIInfo
+
+	assert.False(t, empty.usable("a.b", now))
+}
+
+// newARITestCertificate obtains a certificate for example.com from the server.
+func newARITestCertificate(t *testing.T, server *pebble.Server) (*lego.Client, *x509.Certificate) {
+	t.Helper()
+
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+
+	account := &Account{Email: "test@example.com", key: privateKey}
+
+	config := lego.NewConfig(account)
+	config.CADirURL = server.URL()
+	config.HTTPClient = server.Client()
+	config.Certificate.KeyType = certcrypto.EC256
+
+	client, err := lego.NewClient(config)
+	require.NoError(t, err)
+
+	account.Registration, err = client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
+	require.NoError(t, err)
+
+	// The server accepts all the challenges.
+	provider := httpmock.NewProvider()
+
+	require.NoError(t, client.Challenge.SetHTTP01Provider(provider))
+
+	res, err := client.Certificate.Obtain(certificate.ObtainRequest{Domains: []string{"example.com"}})
+	require.NoError(t, err)
+
+	provider.AssertCalls(t, 1, 1)
+	provider.AssertAllCleanedUp(t)
+
+	cert, err := certcrypto.ParsePEMCertificate(res.Certificate)
+	require.NoError(t, err)
+
+	return client, cert
+}
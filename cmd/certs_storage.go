@@ -2,20 +2,27 @@ package cmd
 
 import (
 	"bytes"
+	"cmp"
+	"crypto"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/net/idna"
 	"software.sslmate.com/src/go-pkcs12"
@@ -28,11 +35,14 @@ const (
 
 const (
 	issuerExt   = ".issuer.crt"
+	chainExt    = ".chain-"
 	certExt     = ".crt"
 	keyExt      = ".key"
 	pemExt      = ".pem"
 	pfxExt      = ".pfx"
+	derExt      = ".der"
 	resourceExt = ".json"
+	renewalExt  = ".renewal.toml"
 )
 
 // CertificatesStorage a certificates' storage.
@@ -51,66 +61,202 @@ const (
 type CertificatesStorage struct {
 	rootPath    string
 	archivePath string
+	keyPath     string // the directory of the private keys, the root path if empty.
+	outPath     string // the directory of the certificate files (except the resource), the root path if empty.
 	pem         bool
 	pfx         bool
 	pfxPassword string
 	pfxFormat   string
+	der         bool
 	filename    string // Deprecated
+	certName    string // the base name of the files of the certificate (--cert-name), the main domain if empty.
+
+	keyPassphrase []byte
+
+	fileModes map[string]os.FileMode // by extension, filePerm if not defined.
+	fileOwner *fileOwner
 }
 
 // NewCertificatesStorage create a new certificates storage.
-func NewCertificatesStorage(ctx *cli.Context) *CertificatesStorage {
-	pfxFormat := ctx.String(flgPFXFormat)
+func NewCertificatesStorage(ctx *cli.Context) (*CertificatesStorage, error) {
+	pfxFormat, err := parsePFXFormat(ctx.String(flgPFXFormat))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PFX format: %w", err)
+	}
 
-	switch pfxFormat {
-	case "DES", "RC2", "SHA256":
-	default:
-		log.Fatalf("Invalid PFX format: %s", pfxFormat)
+	if certcrypto.FIPSMode() && pfxFormat != "SHA256" {
+		// The legacy formats are not FIPS-approved: uses the modern format by default.
+		if ctx.IsSet(flgPFXFormat) {
+			return nil, fmt.Errorf("the PFX format %s is %w, please use SHA256", pfxFormat, certcrypto.ErrNotFIPSApproved)
+		}
+
+		pfxFormat = "SHA256"
+	}
+
+	pfxPassword, err := getPFXPassword(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PFX password: %w", err)
+	}
+
+	fileModes, err := getFileModes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file mode: %w", err)
+	}
+
+	owner, err := getFileOwner(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file owner: %w", err)
+	}
+
+	certName := ctx.String(flgCertName)
+
+	err = checkCertName(certName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate name: %w", err)
+	}
+
+	keyPassphrase, err := getKeyPassphrase(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	return &CertificatesStorage{
 		rootPath:    filepath.Join(ctx.String(flgPath), baseCertificatesFolderName),
+		keyPath:     ctx.String(flgKeyDir),
+		outPath:     ctx.String(flgOut),
 		archivePath: filepath.Join(ctx.String(flgPath), baseArchivesFolderName),
 		pem:         ctx.Bool(flgPEM),
 		pfx:         ctx.Bool(flgPFX),
-		pfxPassword: ctx.String(flgPFXPass),
+		pfxPassword: pfxPassword,
 		pfxFormat:   pfxFormat,
+		der:         ctx.Bool(flgDER),
 		filename:    ctx.String(flgFilename),
+		certName:    certName,
+
+		keyPassphrase: keyPassphrase,
+
+		fileModes: fileModes,
+		fileOwner: owner,
+	}, nil
+}
+
+// withCertName returns a copy of the storage for the certificate stored with the name (e.g. a name of ListDomains).
+func (s *CertificatesStorage) withCertName(name string) *CertificatesStorage {
+	named := *s
+	named.certName = name
+
+	return &named
+}
+
+// baseName returns the base name of the files of the certificate: the name of the certificate (--cert-name),
+// or the main domain of the certificate.
+func (s *CertificatesStorage) baseName(domain string) (string, error) {
+	if s.certName != "" {
+		return s.certName, nil
 	}
+
+	return sanitizedDomain(domain)
 }
 
-func (s *CertificatesStorage) CreateRootFolder() {
+func (s *CertificatesStorage) CreateRootFolder() error {
 	err := createNonExistingFolder(s.rootPath)
 	if err != nil {
-		log.Fatalf("Could not check/create path: %v", err)
+		return fmt.Errorf("could not check/create path: %w", err)
+	}
+
+	for _, dir := range []string{s.keyPath, s.outPath} {
+		if dir == "" {
+			continue
+		}
+
+		err = createNonExistingFolder(dir)
+		if err != nil {
+			return fmt.Errorf("could not check/create path: %w", err)
+		}
+	}
+
+	if s.keyPath != "" && runtime.GOOS != "windows" {
+		info, errS := os.Stat(s.keyPath)
+		if errS == nil && info.Mode().Perm()&0o077 != 0 {
+			log.Warnf("The directory of the private keys (%s) is accessible by other users (%#o), the recommended permissions are 0700.",
+				s.keyPath, info.Mode().Perm())
+		}
 	}
+
+	return nil
 }
 
-func (s *CertificatesStorage) CreateArchiveFolder() {
+func (s *CertificatesStorage) CreateArchiveFolder() error {
 	err := createNonExistingFolder(s.archivePath)
 	if err != nil {
-		log.Fatalf("Could not check/create path: %v", err)
+		return fmt.Errorf("could not check/create path: %w", err)
 	}
+
+	return nil
 }
 
 func (s *CertificatesStorage) GetRootPath() string {
 	return s.rootPath
 }
 
-func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource) {
+// ResourceMetadata the lego metadata stored alongside the certificate resource.
+type ResourceMetadata struct {
+	KeyRotation *KeyRotation   `json:"keyRotation,omitempty"`
+	Outputs     *OutputOptions `json:"outputs,omitempty"`
+	ARI         *ARIInfo       `json:"ari,omitempty"`
+}
+
+// OutputOptions the options used to create the files derived from the certificate and the private key (.pem, .pfx, .der).
+// The files are created again by the "storage sync" command when the options change.
+type OutputOptions struct {
+	PEM       bool   `json:"pem,omitempty"`
+	PFX       bool   `json:"pfx,omitempty"`
+	PFXFormat string `json:"pfxFormat,omitempty"`
+	DER       bool   `json:"der,omitempty"`
+}
+
+// outputOptions returns the current output options, nil if no derivative file is created.
+func (s *CertificatesStorage) outputOptions() *OutputOptions {
+	if !s.pem && !s.pfx && !s.der {
+		return nil
+	}
+
+	options := &OutputOptions{PEM: s.pem, PFX: s.pfx, DER: s.der}
+	if s.pfx {
+		options.PFXFormat = s.pfxFormat
+	}
+
+	return options
+}
+
+// storedResource the content of the resource file.
+type storedResource struct {
+	*certificate.Resource
+	*ResourceMetadata
+}
+
+func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource, metadata *ResourceMetadata) {
+	err := s.saveResource(certRes, metadata)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// saveResource saves the files of the certificate, and the resource file.
+func (s *CertificatesStorage) saveResource(certRes *certificate.Resource, metadata *ResourceMetadata) error {
 	domain := certRes.Domain
 
 	// We store the certificate, private key and metadata in different files
 	// as web servers would not be able to work with a combined file.
 	err := s.WriteFile(domain, certExt, certRes.Certificate)
 	if err != nil {
-		log.Fatalf("Unable to save Certificate for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("unable to save Certificate for domain %s: %w", domain, err)
 	}
 
 	if certRes.IssuerCertificate != nil {
 		err = s.WriteFile(domain, issuerExt, certRes.IssuerCertificate)
 		if err != nil {
-			log.Fatalf("Unable to save IssuerCertificate for domain %s\n\t%v", domain, err)
+			return fmt.Errorf("unable to save IssuerCertificate for domain %s: %w", domain, err)
 		}
 	}
 
@@ -118,57 +264,173 @@ func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource) {
 	if certRes.PrivateKey != nil {
 		err = s.WriteCertificateFiles(domain, certRes)
 		if err != nil {
-			log.Fatalf("Unable to save PrivateKey for domain %s\n\t%v", domain, err)
+			return fmt.Errorf("unable to save PrivateKey for domain %s: %w", domain, err)
 		}
 	} else if s.pem || s.pfx {
 		// we don't have the private key; can't write the .pem or .pfx file
-		log.Fatalf("Unable to save PEM or PFX without private key for domain %s. Are you using a CSR?", domain)
+		return fmt.Errorf("unable to save PEM or PFX without private key for domain %s. Are you using a CSR?", domain)
+	} else {
+		err = s.writeDerivativeFiles(domain, certRes, nil)
+		if err != nil {
+			return fmt.Errorf("unable to save the certificate files for domain %s: %w", domain, err)
+		}
 	}
 
-	jsonBytes, err := json.MarshalIndent(certRes, "", "\t")
+	err = s.writeResource(certRes, metadata)
 	if err != nil {
-		log.Fatalf("Unable to marshal CertResource for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("unable to save CertResource for domain %s: %w", domain, err)
 	}
 
-	err = s.WriteFile(domain, resourceExt, jsonBytes)
+	return nil
+}
+
+// writeResource writes the resource file, with the current output options.
+func (s *CertificatesStorage) writeResource(certRes *certificate.Resource, metadata *ResourceMetadata) error {
+	stored := ResourceMetadata{}
+	if metadata != nil {
+		stored = *metadata
+	}
+
+	stored.Outputs = s.outputOptions()
+
+	jsonBytes, err := json.MarshalIndent(storedResource{Resource: certRes, ResourceMetadata: &stored}, "", "\t")
 	if err != nil {
-		log.Fatalf("Unable to save CertResource for domain %s\n\t%v", domain, err)
+		return err
 	}
+
+	return s.WriteFile(certRes.Domain, resourceExt, jsonBytes)
 }
 
 func (s *CertificatesStorage) ReadResource(domain string) certificate.Resource {
-	raw, err := s.ReadFile(domain, resourceExt)
+	resource, err := s.readResource(domain)
 	if err != nil {
 		log.Fatalf("Error while loading the meta data for domain %s\n\t%v", domain, err)
 	}
 
+	return resource
+}
+
+func (s *CertificatesStorage) readResource(domain string) (certificate.Resource, error) {
 	var resource certificate.Resource
-	if err = json.Unmarshal(raw, &resource); err != nil {
-		log.Fatalf("Error while marshaling the meta data for domain %s\n\t%v", domain, err)
+
+	raw, err := s.ReadFile(domain, resourceExt)
+	if err != nil {
+		return resource, err
 	}
 
-	return resource
+	err = json.Unmarshal(raw, &resource)
+	if err != nil {
+		return resource, fmt.Errorf("unmarshal the resource: %w", err)
+	}
+
+	return resource, nil
 }
 
-func (s *CertificatesStorage) ExistsFile(domain, extension string) bool {
-	filePath := s.GetFileName(domain, extension)
+// ReadResourceMetadata reads the lego metadata of the certificate resource.
+// An empty metadata is returned if the resource file doesn't exist.
+func (s *CertificatesStorage) ReadResourceMetadata(domain string) (*ResourceMetadata, error) {
+	metadata := &ResourceMetadata{}
 
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return false
-	} else if err != nil {
-		log.Fatal(err)
+	exists, err := s.ExistsFile(domain, resourceExt)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return metadata, nil
+	}
+
+	raw, err := s.ReadFile(domain, resourceExt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(raw, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// updateResourceMetadata updates the lego metadata of the resource file, the other fields of the file are kept as is.
+func (s *CertificatesStorage) updateResourceMetadata(domain string, update func(metadata *ResourceMetadata)) error {
+	raw, err := s.ReadFile(domain, resourceExt)
+	if err != nil {
+		return err
 	}
 
-	return true
+	stored := storedResource{Resource: &certificate.Resource{}, ResourceMetadata: &ResourceMetadata{}}
+
+	err = json.Unmarshal(raw, &stored)
+	if err != nil {
+		return fmt.Errorf("unmarshal the resource: %w", err)
+	}
+
+	update(stored.ResourceMetadata)
+
+	jsonBytes, err := json.MarshalIndent(stored, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return s.WriteFile(domain, resourceExt, jsonBytes)
+}
+
+func (s *CertificatesStorage) ExistsFile(domain, extension string) (bool, error) {
+	filePath, err := s.GetFileName(domain, extension)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (s *CertificatesStorage) ReadFile(domain, extension string) ([]byte, error) {
-	return os.ReadFile(s.GetFileName(domain, extension))
+	filePath, err := s.GetFileName(domain, extension)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(filePath)
 }
 
-func (s *CertificatesStorage) GetFileName(domain, extension string) string {
-	filename := sanitizedDomain(domain) + extension
-	return filepath.Join(s.rootPath, filename)
+func (s *CertificatesStorage) GetFileName(domain, extension string) (string, error) {
+	baseName, err := s.baseName(domain)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.getDir(extension), baseName+extension), nil
+}
+
+// isPrivateKeyFile returns true if the files with the extension contain the private key (.key, .pem, .pfx).
+func isPrivateKeyFile(extension string) bool {
+	return extension == keyExt || extension == pemExt || extension == pfxExt
+}
+
+// getDir returns the directory of the files with the extension.
+func (s *CertificatesStorage) getDir(extension string) string {
+	switch {
+	case extension == resourceExt || extension == renewalExt:
+		// The resource (metadata) and the renewal configuration stay with the state.
+		return s.rootPath
+	case isPrivateKeyFile(extension) && s.keyPath != "":
+		return s.keyPath
+	case s.outPath != "":
+		return s.outPath
+	default:
+		return s.rootPath
+	}
 }
 
 func (s *CertificatesStorage) ReadCertificate(domain, extension string) ([]*x509.Certificate, error) {
@@ -183,40 +445,162 @@ func (s *CertificatesStorage) ReadCertificate(domain, extension string) ([]*x509
 
 func (s *CertificatesStorage) WriteFile(domain, extension string, data []byte) error {
 	var baseFileName string
-	if s.filename != "" {
+	if s.filename != "" && s.certName == "" {
 		baseFileName = s.filename
 	} else {
-		baseFileName = sanitizedDomain(domain)
+		var err error
+
+		baseFileName, err = s.baseName(domain)
+		if err != nil {
+			return err
+		}
 	}
 
-	filePath := filepath.Join(s.rootPath, baseFileName+extension)
+	filePath := filepath.Join(s.getDir(extension), baseFileName+extension)
 
-	return os.WriteFile(filePath, data, filePerm)
+	mode, ok := s.fileModes[extension]
+	if !ok {
+		mode = filePerm
+	}
+
+	err := os.WriteFile(filePath, data, mode)
+	if err != nil {
+		return err
+	}
+
+	// The permissions of an existing file are not changed by os.WriteFile.
+	err = os.Chmod(filePath, mode)
+	if err != nil {
+		return err
+	}
+
+	if extension == resourceExt || extension == renewalExt {
+		return nil
+	}
+
+	return s.fileOwner.apply(filePath)
 }
 
 func (s *CertificatesStorage) WriteCertificateFiles(domain string, certRes *certificate.Resource) error {
-	err := s.WriteFile(domain, keyExt, certRes.PrivateKey)
+	keyBytes, err := s.encodePrivateKey(certRes.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("unable to encrypt key for domain %s: %w", domain, err)
+	}
+
+	err = s.WriteFile(domain, keyExt, keyBytes)
 	if err != nil {
 		return fmt.Errorf("unable to save key file: %w", err)
 	}
 
-	if s.pem {
-		err = s.WriteFile(domain, pemExt, bytes.Join([][]byte{certRes.Certificate, certRes.PrivateKey}, nil))
+	return s.writeDerivativeFiles(domain, certRes, keyBytes)
+}
+
+// writeDerivativeFiles writes the files derived from the certificate and the private key (.pem, .pfx, .der),
+// according to the output options.
+// keyBytes is the content of the key file, the .pem and .pfx files require it.
+func (s *CertificatesStorage) writeDerivativeFiles(domain string, certRes *certificate.Resource, keyBytes []byte) error {
+	if s.pem && keyBytes != nil {
+		err := s.WriteFile(domain, pemExt, bytes.Join([][]byte{certRes.Certificate, keyBytes}, nil))
 		if err != nil {
 			return fmt.Errorf("unable to save PEM file: %w", err)
 		}
 	}
 
-	if s.pfx {
-		err = s.WritePFXFile(domain, certRes)
+	if s.pfx && keyBytes != nil {
+		err := s.WritePFXFile(domain, certRes)
 		if err != nil {
 			return fmt.Errorf("unable to save PFX file: %w", err)
 		}
 	}
 
+	if s.der {
+		cert, err := certcrypto.ParsePEMCertificate(certRes.Certificate)
+		if err != nil {
+			return fmt.Errorf("unable to parse Certificate for domain %s: %w", domain, err)
+		}
+
+		err = s.WriteFile(domain, derExt, cert.Raw)
+		if err != nil {
+			return fmt.Errorf("unable to save DER file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// WriteChainFiles writes the certificate chains offered by the CA (<domain>.chain-<root>.crt),
+// and removes the files of the chains that are no longer offered.
+func (s *CertificatesStorage) WriteChainFiles(domain string, chains []certificate.Chain) error {
+	pattern, err := s.GetFileName(domain, chainExt+"*"+certExt)
+	if err != nil {
+		return err
+	}
+
+	stale, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range stale {
+		err = os.Remove(file)
+		if err != nil {
+			return fmt.Errorf("unable to remove the chain file: %w", err)
+		}
+	}
+
+	names := make(map[string]int)
+
+	for _, chain := range chains {
+		name := chainFileName(chain.Root)
+
+		names[name]++
+		if names[name] > 1 {
+			name += "-" + strconv.Itoa(names[name])
+		}
+
+		err = s.WriteFile(domain, chainExt+name+certExt, chain.Certificate)
+		if err != nil {
+			return fmt.Errorf("unable to save the chain file: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// ReadPrivateKey reads the private key of the certificate, and decrypts it if needed.
+func (s *CertificatesStorage) ReadPrivateKey(domain string) (crypto.PrivateKey, error) {
+	keyBytes, err := s.ReadFile(domain, keyExt)
+	if errors.Is(err, fs.ErrNotExist) && s.keyPath != "" {
+		// The private key of a certificate obtained before the definition of the key directory.
+		var baseName string
+
+		baseName, err = s.baseName(domain)
+		if err == nil {
+			keyBytes, err = os.ReadFile(filepath.Join(cmp.Or(s.outPath, s.rootPath), baseName+keyExt))
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return certcrypto.ParsePEMPrivateKeyWithPassphrase(keyBytes, s.keyPassphrase)
+}
+
+// encodePrivateKey encrypts the PEM encoded private key when a passphrase is defined.
+func (s *CertificatesStorage) encodePrivateKey(keyPEM []byte) ([]byte, error) {
+	if len(s.keyPassphrase) == 0 || len(keyPEM) == 0 {
+		return keyPEM, nil
+	}
+
+	privateKey, err := certcrypto.ParsePEMPrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return certcrypto.PEMEncodeEncrypted(privateKey, s.keyPassphrase)
+}
+
 func (s *CertificatesStorage) WritePFXFile(domain string, certRes *certificate.Resource) error {
 	certPemBlock, _ := pem.Decode(certRes.Certificate)
 	if certPemBlock == nil {
@@ -251,14 +635,169 @@ func (s *CertificatesStorage) WritePFXFile(domain string, certRes *certificate.R
 	return s.WriteFile(domain, pfxExt, pfxBytes)
 }
 
+// SyncDerivativeFiles creates again the files derived from the certificate and the private key (.pem, .pfx, .der)
+// when they are missing, older than the certificate or the private key, or when the output options have changed.
+// Returns true if the files have been created.
+func (s *CertificatesStorage) SyncDerivativeFiles(domain string, force bool) (bool, error) {
+	raw, err := s.ReadFile(domain, resourceExt)
+	if err != nil {
+		return false, err
+	}
+
+	certRes := &certificate.Resource{}
+	metadata := &ResourceMetadata{}
+
+	err = json.Unmarshal(raw, &storedResource{Resource: certRes, ResourceMetadata: metadata})
+	if err != nil {
+		return false, fmt.Errorf("unable to read the resource: %w", err)
+	}
+
+	if !force && !s.isDerivativeStale(domain, metadata.Outputs) {
+		return false, nil
+	}
+
+	// The files are the source of truth: they can be replaced by another tool.
+	certRes.Certificate, err = s.ReadFile(domain, certExt)
+	if err != nil {
+		return false, err
+	}
+
+	certRes.IssuerCertificate = nil
+
+	issuerExists, err := s.ExistsFile(domain, issuerExt)
+	if err != nil {
+		return false, err
+	}
+
+	if issuerExists {
+		certRes.IssuerCertificate, err = s.ReadFile(domain, issuerExt)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	var keyBytes []byte
+
+	keyExists, err := s.ExistsFile(domain, keyExt)
+	if err != nil {
+		return false, err
+	}
+
+	if keyExists {
+		keyBytes, err = s.ReadFile(domain, keyExt)
+		if err != nil {
+			return false, err
+		}
+
+		privateKey, errP := certcrypto.ParsePEMPrivateKeyWithPassphrase(keyBytes, s.keyPassphrase)
+		if errP != nil {
+			return false, fmt.Errorf("unable to read the private key: %w", errP)
+		}
+
+		certRes.PrivateKey = certcrypto.PEMEncode(privateKey)
+	} else if s.pem || s.pfx {
+		return false, errors.New("the private key is required to create the .pem and .pfx files")
+	}
+
+	err = s.writeDerivativeFiles(domain, certRes, keyBytes)
+	if err != nil {
+		return false, err
+	}
+
+	return true, s.writeResource(certRes, metadata)
+}
+
+// isDerivativeStale returns true if the output options have changed,
+// or if a derivative file is missing or older than the certificate or the private key.
+func (s *CertificatesStorage) isDerivativeStale(domain string, previous *OutputOptions) bool {
+	current := s.outputOptions()
+
+	if (previous == nil) != (current == nil) || previous != nil && *previous != *current {
+		return true
+	}
+
+	var base time.Time
+
+	for _, extension := range []string{certExt, issuerExt, keyExt} {
+		filePath, err := s.GetFileName(domain, extension)
+		if err != nil {
+			return true
+		}
+
+		info, err := os.Stat(filePath)
+		if err == nil && info.ModTime().After(base) {
+			base = info.ModTime()
+		}
+	}
+
+	for extension, enabled := range map[string]bool{pemExt: s.pem, pfxExt: s.pfx, derExt: s.der} {
+		if !enabled {
+			continue
+		}
+
+		filePath, err := s.GetFileName(domain, extension)
+		if err != nil {
+			return true
+		}
+
+		info, err := os.Stat(filePath)
+		if err != nil || info.ModTime().Before(base) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ListDomains returns the domains of the stored certificates (the names of the resource files).
+func (s *CertificatesStorage) ListDomains() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(s.rootPath, "*"+resourceExt))
+	if err != nil {
+		return nil, err
+	}
+
+	var domains []string
+	for _, match := range matches {
+		domains = append(domains, strings.TrimSuffix(filepath.Base(match), resourceExt))
+	}
+
+	return domains, nil
+}
+
 func (s *CertificatesStorage) MoveToArchive(domain string) error {
-	baseFilename := filepath.Join(s.rootPath, sanitizedDomain(domain))
+	baseName, err := s.baseName(domain)
+	if err != nil {
+		return err
+	}
+
+	baseFilename := filepath.Join(s.rootPath, baseName)
 
 	matches, err := filepath.Glob(baseFilename + ".*")
 	if err != nil {
 		return err
 	}
 
+	if s.keyPath != "" {
+		for _, extension := range []string{keyExt, pemExt, pfxExt} {
+			keyFile, errF := s.GetFileName(domain, extension)
+			if errF != nil {
+				return errF
+			}
+
+			if _, errS := os.Stat(keyFile); errS != nil {
+				continue
+			}
+
+			// The files of the key directory are archived as if they were in the root directory.
+			date := strconv.FormatInt(time.Now().Unix(), 10)
+
+			err = os.Rename(keyFile, filepath.Join(s.archivePath, date+"."+filepath.Base(keyFile)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	for _, oldFile := range matches {
 		if strings.TrimSuffix(oldFile, filepath.Ext(oldFile)) != baseFilename && oldFile != baseFilename+issuerExt {
 			continue
@@ -298,6 +837,47 @@ func getCertificateChain(certRes *certificate.Resource) ([]*x509.Certificate, er
 	return certChain, nil
 }
 
+// parsePFXFormat returns the PFX format (RC2, DES, SHA256),
+// the aliases modern (SHA256: AES-256 and SHA-256) and legacy (RC2) are resolved.
+func parsePFXFormat(value string) (string, error) {
+	switch strings.ToUpper(value) {
+	case "RC2", "LEGACY":
+		return "RC2", nil
+	case "DES":
+		return "DES", nil
+	case "SHA256", "MODERN":
+		return "SHA256", nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s", value)
+	}
+}
+
+// getPFXPassword returns the password of the .pfx file: the content of the password file (--pfx.pass-file),
+// or the password option (--pfx.pass, LEGO_PFX_PASSWORD).
+func getPFXPassword(ctx *cli.Context) (string, error) {
+	if !ctx.IsSet(flgPFXPassFile) {
+		return ctx.String(flgPFXPass), nil
+	}
+
+	if ctx.IsSet(flgPFXPass) {
+		return "", fmt.Errorf("--%s and --%s are mutually exclusive", flgPFXPass, flgPFXPassFile)
+	}
+
+	data, err := os.ReadFile(ctx.String(flgPFXPassFile))
+	if err != nil {
+		return "", fmt.Errorf("could not read the password file: %w", err)
+	}
+
+	password := strings.TrimRight(string(data), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("the password file %s is empty", ctx.String(flgPFXPassFile))
+	}
+
+	redact.Register(password)
+
+	return password, nil
+}
+
 func getPFXEncoder(pfxFormat string) (*pkcs12.Encoder, error) {
 	var encoder *pkcs12.Encoder
 
@@ -315,12 +895,76 @@ func getPFXEncoder(pfxFormat string) (*pkcs12.Encoder, error) {
 	return encoder, nil
 }
 
+// checkCertName checks that the name of a certificate (--cert-name) can be used as the base name of its files.
+func checkCertName(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(name, ".") {
+		return fmt.Errorf("%q: the name can't start with a dot", name)
+	}
+
+	for _, r := range name {
+		if !isCertNameChar(r) {
+			return fmt.Errorf("%q: only the letters, the digits, '.', '_', and '-' are allowed", name)
+		}
+	}
+
+	return nil
+}
+
+func isCertNameChar(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-'
+}
+
 // sanitizedDomain Make sure no funny chars are in the cert names (like wildcards ;)).
-func sanitizedDomain(domain string) string {
+func sanitizedDomain(domain string) (string, error) {
 	safe, err := idna.ToASCII(strings.NewReplacer(":", "-", "*", "_").Replace(domain))
 	if err != nil {
-		log.Fatal(err)
+		return "", fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+
+	return safe, nil
+}
+
+// chainFileName returns the name of a chain in the file names: the common name of the root, in lowercase, without special chars.
+func chainFileName(root string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return unicode.ToLower(r)
+		default:
+			return -1
+		}
+	}, root)
+
+	if name == "" {
+		return "unknown"
+	}
+
+	return name
+}
+
+// getKeyPassphrase returns the passphrase used to encrypt the private keys of the certificates.
+func getKeyPassphrase(ctx *cli.Context) ([]byte, error) {
+	if ctx.IsSet(flgKeyPassFile) {
+		data, err := os.ReadFile(ctx.String(flgKeyPassFile))
+		if err != nil {
+			return nil, fmt.Errorf("could not read the private key passphrase file: %w", err)
+		}
+
+		passphrase := bytes.TrimRight(data, "\r\n")
+		if len(passphrase) == 0 {
+			return nil, fmt.Errorf("the private key passphrase file %s is empty", ctx.String(flgKeyPassFile))
+		}
+
+		redact.Register(string(passphrase))
+
+		return passphrase, nil
 	}
 
-	return safe
+	return []byte(env.GetOrFile(envKeyPassword)), nil
 }
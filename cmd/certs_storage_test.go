@@ -1,13 +1,22 @@
 package cmd
 
 import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"flag"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"testing"
+	"time"
 
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
 )
 
 func TestCertificatesStorage_MoveToArchive(t *testing.T) {
@@ -113,3 +122,516 @@ func generateTestFiles(t *testing.T, dir, domain string) []string {
 
 	return filenames
 }
+
+func TestCertificatesStorage_ResourceMetadata(t *testing.T) {
+	storage := CertificatesStorage{
+		rootPath:    t.TempDir(),
+		archivePath: t.TempDir(),
+	}
+
+	metadata, err := storage.ReadResourceMetadata("example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, &ResourceMetadata{}, metadata)
+
+	expected := &ResourceMetadata{
+		KeyRotation: &KeyRotation{
+			Renewals:     3,
+			KeyRenewals:  1,
+			KeyCreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	storage.SaveResource(&certificate.Resource{Domain: "example.com", Certificate: []byte("cert")}, expected)
+
+	metadata, err = storage.ReadResourceMetadata("example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, metadata)
+
+	resource := storage.ReadResource("example.com")
+
+	assert.Equal(t, "example.com", resource.Domain)
+}
+
+func TestCertificatesStorage_ReadPrivateKey_encrypted(t *testing.T) {
+	domain := "example.com"
+
+	storage := CertificatesStorage{
+		rootPath:      t.TempDir(),
+		archivePath:   t.TempDir(),
+		pem:           true,
+		keyPassphrase: []byte("secret"),
+	}
+
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+
+	certRes := &certificate.Resource{
+		Domain:      domain,
+		PrivateKey:  certcrypto.PEMEncode(privateKey),
+		Certificate: []byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"),
+	}
+
+	err = storage.WriteCertificateFiles(domain, certRes)
+	require.NoError(t, err)
+
+	keyBytes, err := storage.ReadFile(domain, keyExt)
+	require.NoError(t, err)
+	assert.Contains(t, string(keyBytes), "ENCRYPTED PRIVATE KEY")
+
+	pemBytes, err := storage.ReadFile(domain, pemExt)
+	require.NoError(t, err)
+	assert.Contains(t, string(pemBytes), "ENCRYPTED PRIVATE KEY")
+
+	key, err := storage.ReadPrivateKey(domain)
+	require.NoError(t, err)
+
+	assert.True(t, privateKey.(interface{ Equal(crypto.PrivateKey) bool }).Equal(key))
+}
+
+func TestCertificatesStorage_SyncDerivativeFiles(t *testing.T) {
+	domain := "example.com"
+
+	storage := CertificatesStorage{
+		rootPath:    t.TempDir(),
+		archivePath: t.TempDir(),
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(privateKey, domain, nil)
+	require.NoError(t, err)
+
+	storage.SaveResource(&certificate.Resource{
+		Domain:      domain,
+		PrivateKey:  certcrypto.PEMEncode(privateKey),
+		Certificate: certPEM,
+	}, &ResourceMetadata{})
+
+	updated, err := storage.SyncDerivativeFiles(domain, false)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	// The output options have changed.
+	storage.pem = true
+	storage.der = true
+
+	updated, err = storage.SyncDerivativeFiles(domain, false)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	assert.FileExists(t, mustFileName(t, &storage, domain, pemExt))
+
+	derBytes, err := storage.ReadFile(domain, derExt)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(certPEM)
+	require.NoError(t, err)
+	assert.Equal(t, cert.Raw, derBytes)
+
+	metadata, err := storage.ReadResourceMetadata(domain)
+	require.NoError(t, err)
+	assert.Equal(t, &OutputOptions{PEM: true, DER: true}, metadata.Outputs)
+
+	updated, err = storage.SyncDerivativeFiles(domain, false)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	// The certificate has been replaced.
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(mustFileName(t, &storage, domain, certExt), future, future))
+
+	updated, err = storage.SyncDerivativeFiles(domain, false)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	domains, err := storage.ListDomains()
+	require.NoError(t, err)
+	assert.Equal(t, []string{domain}, domains)
+}
+
+func TestCertificatesStorage_WriteFile_fileModes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the file permissions are not supported on Windows")
+	}
+
+	domain := "example.com"
+
+	storage := CertificatesStorage{
+		rootPath:    t.TempDir(),
+		archivePath: t.TempDir(),
+		keyPath:     t.TempDir(),
+		fileModes: map[string]os.FileMode{
+			certExt: 0o640,
+			keyExt:  0o600,
+		},
+	}
+
+	// An existing file with other permissions.
+	err := os.WriteFile(filepath.Join(storage.rootPath, domain+certExt), []byte("old"), 0o666)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.WriteFile(domain, certExt, []byte("cert")))
+	require.NoError(t, storage.WriteFile(domain, keyExt, []byte("key")))
+	require.NoError(t, storage.WriteFile(domain, resourceExt, []byte("{}")))
+
+	testCases := []struct {
+		filename string
+		expected os.FileMode
+	}{
+		{filename: filepath.Join(storage.rootPath, domain+certExt), expected: 0o640},
+		{filename: filepath.Join(storage.keyPath, domain+keyExt), expected: 0o600},
+		{filename: filepath.Join(storage.rootPath, domain+resourceExt), expected: filePerm},
+	}
+
+	for _, test := range testCases {
+		info, err := os.Stat(test.filename)
+		require.NoError(t, err)
+
+		assert.Equal(t, test.expected, info.Mode().Perm(), test.filename)
+	}
+
+	assert.NoFileExists(t, filepath.Join(storage.rootPath, domain+keyExt))
+
+	key, err := storage.ReadFile(domain, keyExt)
+	require.NoError(t, err)
+	assert.Equal(t, "key", string(key))
+}
+
+func TestCertificatesStorage_WriteFile_out(t *testing.T) {
+	domain := "example.com"
+
+	storage := CertificatesStorage{
+		rootPath: t.TempDir(),
+		outPath:  t.TempDir(),
+	}
+
+	require.NoError(t, storage.WriteFile(domain, certExt, []byte("cert")))
+	require.NoError(t, storage.WriteFile(domain, keyExt, []byte("key")))
+	require.NoError(t, storage.WriteFile(domain, resourceExt, []byte("{}")))
+
+	assert.FileExists(t, filepath.Join(storage.outPath, domain+certExt))
+	assert.FileExists(t, filepath.Join(storage.outPath, domain+keyExt))
+	assert.FileExists(t, filepath.Join(storage.rootPath, domain+resourceExt))
+
+	assert.NoFileExists(t, filepath.Join(storage.rootPath, domain+certExt))
+	assert.NoFileExists(t, filepath.Join(storage.outPath, domain+resourceExt))
+}
+
+func TestCertificatesStorage_MoveToArchive_keyDir(t *testing.T) {
+	domain := "example.com"
+
+	storage := CertificatesStorage{
+		rootPath:    t.TempDir(),
+		archivePath: t.TempDir(),
+		keyPath:     t.TempDir(),
+	}
+
+	require.NoError(t, storage.WriteFile(domain, certExt, []byte("cert")))
+	require.NoError(t, storage.WriteFile(domain, keyExt, []byte("key")))
+
+	err := storage.MoveToArchive(domain)
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, filepath.Join(storage.rootPath, domain+certExt))
+	assert.NoFileExists(t, filepath.Join(storage.keyPath, domain+keyExt))
+
+	archive, err := os.ReadDir(storage.archivePath)
+	require.NoError(t, err)
+
+	assert.Len(t, archive, 2)
+}
+
+func TestCertificatesStorage_keyDir(t *testing.T) {
+	domain := "example.com"
+
+	storage := CertificatesStorage{
+		rootPath:    t.TempDir(),
+		archivePath: t.TempDir(),
+		keyPath:     t.TempDir(),
+	}
+
+	for _, extension := range []string{certExt, issuerExt, derExt, keyExt, pemExt, pfxExt} {
+		require.NoError(t, storage.WriteFile(domain, extension, []byte("content")))
+	}
+
+	for _, extension := range []string{certExt, issuerExt, derExt} {
+		assert.FileExists(t, filepath.Join(storage.rootPath, domain+extension))
+		assert.NoFileExists(t, filepath.Join(storage.keyPath, domain+extension))
+	}
+
+	for _, extension := range []string{keyExt, pemExt, pfxExt} {
+		assert.FileExists(t, filepath.Join(storage.keyPath, domain+extension))
+		assert.NoFileExists(t, filepath.Join(storage.rootPath, domain+extension))
+	}
+
+	err := storage.MoveToArchive(domain)
+	require.NoError(t, err)
+
+	archive, err := os.ReadDir(storage.archivePath)
+	require.NoError(t, err)
+
+	assert.Len(t, archive, 6)
+}
+
+func TestCertificatesStorage_ReadPrivateKey_keyDirFallback(t *testing.T) {
+	domain := "example.com"
+
+	storage := CertificatesStorage{
+		rootPath: t.TempDir(),
+	}
+
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+
+	// The private key stored before the definition of the key directory.
+	require.NoError(t, storage.WriteFile(domain, keyExt, certcrypto.PEMEncode(privateKey)))
+
+	storage.keyPath = t.TempDir()
+
+	key, err := storage.ReadPrivateKey(domain)
+	require.NoError(t, err)
+
+	assert.Equal(t, privateKey, key)
+}
+
+func TestCertificatesStorage_WriteChainFiles(t *testing.T) {
+	domain := "example.com"
+
+	storage := CertificatesStorage{
+		rootPath: t.TempDir(),
+	}
+
+	require.NoError(t, storage.WriteFile(domain, chainExt+"oldroot"+certExt, []byte("old")))
+
+	chains := []certificate.Chain{
+		{Root: "ISRG Root X1", Certificate: []byte("a")},
+		{Root: "ISRG Root X2", Certificate: []byte("b")},
+		{Root: "ISRG Root X2", Certificate: []byte("c")},
+	}
+
+	require.NoError(t, storage.WriteChainFiles(domain, chains))
+
+	assert.NoFileExists(t, filepath.Join(storage.rootPath, domain+".chain-oldroot.crt"))
+
+	for file, content := range map[string]string{
+		".chain-isrgrootx1.crt":   "a",
+		".chain-isrgrootx2.crt":   "b",
+		".chain-isrgrootx2-2.crt": "c",
+	} {
+		data, err := os.ReadFile(filepath.Join(storage.rootPath, domain+file))
+		require.NoError(t, err)
+
+		assert.Equal(t, content, string(data))
+	}
+}
+
+func Test_chainFileName(t *testing.T) {
+	testCases := []struct {
+		root     string
+		expected string
+	}{
+		{root: "ISRG Root X1", expected: "isrgrootx1"},
+		{root: "DST Root CA X3", expected: "dstrootcax3"},
+		{root: "Pebble Root CA 50ffbd", expected: "pebblerootca50ffbd"},
+		{root: "Zürich (Root)", expected: "zrichroot"},
+		{root: "", expected: "unknown"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.root, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, chainFileName(test.root))
+		})
+	}
+}
+
+func TestCertificatesStorage_certName(t *testing.T) {
+	storage := &CertificatesStorage{
+		rootPath:    t.TempDir(),
+		archivePath: t.TempDir(),
+		certName:    "web",
+	}
+
+	require.NoError(t, storage.WriteFile("www.example.com", certExt, []byte("cert")))
+	require.NoError(t, storage.WriteFile("www.example.com", resourceExt, []byte("{}")))
+
+	assert.FileExists(t, filepath.Join(storage.rootPath, "web"+certExt))
+	assert.FileExists(t, filepath.Join(storage.rootPath, "web"+resourceExt))
+
+	// The files are found regardless of the main domain.
+	exists, err := storage.ExistsFile("example.com", certExt)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	names, err := storage.ListDomains()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"web"}, names)
+
+	require.NoError(t, storage.MoveToArchive("example.com"))
+
+	assert.NoFileExists(t, filepath.Join(storage.rootPath, "web"+certExt))
+}
+
+func TestCertificatesStorage_withCertName(t *testing.T) {
+	storage := &CertificatesStorage{rootPath: t.TempDir()}
+
+	named := storage.withCertName("web")
+
+	assert.Equal(t, filepath.Join(storage.rootPath, "web"+certExt), mustFileName(t, named, "example.com", certExt))
+	assert.Equal(t, filepath.Join(storage.rootPath, "example.com"+certExt), mustFileName(t, storage, "example.com", certExt))
+}
+
+func Test_checkCertName(t *testing.T) {
+	testCases := []struct {
+		name       string
+		requireErr require.ErrorAssertionFunc
+	}{
+		{name: "", requireErr: require.NoError},
+		{name: "web", requireErr: require.NoError},
+		{name: "example.com_2-rsa", requireErr: require.NoError},
+		{name: ".hidden", requireErr: require.Error},
+		{name: "../web", requireErr: require.Error},
+		{name: "*.example.com", requireErr: require.Error},
+		{name: "my cert", requireErr: require.Error},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			test.requireErr(t, checkCertName(test.name))
+		})
+	}
+}
+
+func Test_parsePFXFormat(t *testing.T) {
+	testCases := []struct {
+		value      string
+		expected   string
+		requireErr require.ErrorAssertionFunc
+	}{
+		{value: "RC2", expected: "RC2", requireErr: require.NoError},
+		{value: "DES", expected: "DES", requireErr: require.NoError},
+		{value: "SHA256", expected: "SHA256", requireErr: require.NoError},
+		{value: "modern", expected: "SHA256", requireErr: require.NoError},
+		{value: "legacy", expected: "RC2", requireErr: require.NoError},
+		{value: "AES", requireErr: require.Error},
+		{value: "", requireErr: require.Error},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.value, func(t *testing.T) {
+			format, err := parsePFXFormat(test.value)
+			test.requireErr(t, err)
+
+			assert.Equal(t, test.expected, format)
+		})
+	}
+}
+
+func Test_getPFXPassword(t *testing.T) {
+	passFile := filepath.Join(t.TempDir(), "pfx.pass")
+
+	err := os.WriteFile(passFile, []byte("secret\n"), 0o600)
+	require.NoError(t, err)
+
+	emptyFile := filepath.Join(t.TempDir(), "empty.pass")
+
+	err = os.WriteFile(emptyFile, []byte("\n"), 0o600)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc       string
+		args       []string
+		expected   string
+		requireErr require.ErrorAssertionFunc
+	}{
+		{
+			desc:       "default",
+			expected:   "changeit",
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "password",
+			args:       []string{"--" + flgPFXPass, "foo"},
+			expected:   "foo",
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "password file",
+			args:       []string{"--" + flgPFXPassFile, passFile},
+			expected:   "secret",
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "empty password file",
+			args:       []string{"--" + flgPFXPassFile, emptyFile},
+			requireErr: require.Error,
+		},
+		{
+			desc:       "missing password file",
+			args:       []string{"--" + flgPFXPassFile, filepath.Join(t.TempDir(), "missing")},
+			requireErr: require.Error,
+		},
+		{
+			desc:       "password and password file",
+			args:       []string{"--" + flgPFXPass, "foo", "--" + flgPFXPassFile, passFile},
+			requireErr: require.Error,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			set.String(flgPFXPass, "changeit", "")
+			set.String(flgPFXPassFile, "", "")
+
+			require.NoError(t, set.Parse(test.args))
+
+			password, err := getPFXPassword(cli.NewContext(cli.NewApp(), set, nil))
+			test.requireErr(t, err)
+
+			assert.Equal(t, test.expected, password)
+		})
+	}
+}
+
+func TestCertificatesStorage_ExistsFile_error(t *testing.T) {
+	// The root directory is a file: the error is not a missing file.
+	rootPath := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(rootPath, []byte("content"), 0o600))
+
+	storage := &CertificatesStorage{rootPath: rootPath}
+
+	_, err := storage.ExistsFile("example.com", certExt)
+	require.Error(t, err)
+
+	_, err = storage.ReadResourceMetadata("example.com")
+	require.Error(t, err)
+
+	_, err = storage.ReadRenewalConfig("example.com")
+	require.Error(t, err)
+}
+
+func Test_sanitizedDomain(t *testing.T) {
+	name, err := sanitizedDomain("*.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "_.example.com", name)
+
+	_, err = sanitizedDomain("xn--zz.example.com")
+	require.Error(t, err)
+}
+
+// mustFileName returns the path of the file of the certificate.
+func mustFileName(t *testing.T, storage *CertificatesStorage, domain, extension string) string {
+	t.Helper()
+
+	filePath, err := storage.GetFileName(domain, extension)
+	require.NoError(t, err)
+
+	return filePath
+}
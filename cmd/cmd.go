@@ -1,14 +1,66 @@
 package cmd
 
-import "github.com/urfave/cli/v2"
+import (
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
 
 // CreateCommands Creates all CLI commands.
 func CreateCommands() []*cli.Command {
-	return []*cli.Command{
+	commands := []*cli.Command{
 		createRun(),
 		createRevoke(),
 		createRenew(),
 		createDNSHelp(),
 		createList(),
+		createSelfTest(),
+		createCAA(),
+		createCTWatch(),
+		createInit(),
+		createConfig(),
+		createMigrate(),
+		createExport(),
+		createOrders(),
+		createProfiles(),
+		createFetch(),
+		createStorage(),
+		createDaemon(),
+		createAccount(),
+		createSystemd(),
+	}
+
+	for _, command := range commands {
+		addCommandEnvVars("", command)
+		addConfigLayers(nil, command)
+
+		if command.Action != nil {
+			command.Action = withPrintConfig(command.Action)
+		}
+	}
+
+	return commands
+}
+
+// addCommandEnvVars adds an environment variable to every flag of the command and its subcommands.
+func addCommandEnvVars(parent string, command *cli.Command) {
+	name := strings.TrimPrefix(parent+"_"+command.Name, "_")
+
+	command.Flags = addEnvVars(name, command.Flags)
+
+	for _, sub := range command.Subcommands {
+		addCommandEnvVars(name, sub)
+	}
+}
+
+// addConfigLayers resolves the options of the command and its subcommands before their execution (see applyCommandConfig).
+func addConfigLayers(parent []string, command *cli.Command) {
+	path := append(slices.Clone(parent), command.Name)
+
+	command.Before = withConfigLayers(path, command.Before)
+
+	for _, sub := range command.Subcommands {
+		addConfigLayers(path, sub)
 	}
 }
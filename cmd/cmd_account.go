@@ -0,0 +1,488 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgAccountYes        = "yes"
+	flgAccountNewKeyType = "new-key-type"
+	flgAccountOutput     = "output"
+	flgAccountPassFile   = "passphrase-file"
+)
+
+func createAccount() *cli.Command {
+	return &cli.Command{
+		Name:  "account",
+		Usage: "Manage the account (--email) on the CA",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "show",
+				Usage:  "Display the account as known by the CA (status, contacts, orders URL).",
+				Action: accountShow,
+			},
+			{
+				Name: "update",
+				Usage: "Update the contact of the account on the CA." +
+					" The account (--" + flgEmail + " of the global options) is moved to the new email in the storage.",
+				Action: accountUpdate,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     flgEmail,
+						Usage:    "The new email of the account.",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name: "rollover",
+				Usage: "Replace the key of the account on the CA by a new key (key change)." +
+					" The new key replaces the previous one in the storage.",
+				Action: accountRollover,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  flgAccountNewKeyType,
+						Value: "ec256",
+						Usage: "Key type of the new account key. Supported: " + strings.Join(accountKeyTypeNames(), ", ") + ".",
+					},
+				},
+			},
+			{
+				Name: "export",
+				Usage: "Export the account (private key, registration, and server) as a bundle," +
+					" to import it on another host without a new registration.",
+				Action: accountExport,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:      flgAccountOutput,
+						Usage:     "The file of the bundle, '-' writes to stdout.",
+						Value:     "-",
+						TakesFile: true,
+					},
+					&cli.StringFlag{
+						Name:      flgAccountPassFile,
+						Usage:     "The file containing the passphrase used to encrypt the private key in the bundle (PKCS#8). By default, the private key is not encrypted.",
+						TakesFile: true,
+					},
+				},
+			},
+			{
+				Name: "import",
+				Usage: "Import an account from a bundle (account export)." +
+					" The account is stored for the server and the email of the bundle.",
+				ArgsUsage: "<bundle file, '-' reads from stdin>",
+				Action:    accountImport,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:      flgAccountPassFile,
+						Usage:     "The file containing the passphrase used to decrypt the private key of the bundle.",
+						TakesFile: true,
+					},
+				},
+			},
+			{
+				Name: "deactivate",
+				Usage: "Deactivate the account on the CA. A deactivated account can't be used anymore (irreversible)." +
+					" The certificates are not revoked.",
+				Action: accountDeactivate,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  flgAccountYes,
+						Usage: "Do not ask for confirmation before deactivating the account.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func accountShow(ctx *cli.Context) error {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, client, err := loadRegisteredAccount(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
+
+	reg, err := client.Registration.QueryRegistration()
+	if err != nil {
+		return fmt.Errorf("query the account: %w", err)
+	}
+
+	output := newAccountDetailsOutput(accountsStorage, account.Email, reg)
+
+	if ctx.Bool(flgJSON) {
+		return writeJSON(output)
+	}
+
+	return writeAccountDetails(ctx.App.Writer, output)
+}
+
+func accountUpdate(ctx *cli.Context) error {
+	email := ctx.String(flgEmail)
+
+	accountsStorage, err := newAccountsStorage(ctx, getServer(ctx), getAccountEmail(ctx))
+	if err != nil {
+		return err
+	}
+
+	target, err := newAccountsStorage(ctx, getServer(ctx), email)
+	if err != nil {
+		return err
+	}
+
+	if target.GetRootUserPath() != accountsStorage.GetRootUserPath() {
+		exists, errE := target.ExistsAccountFilePath()
+		if errE != nil {
+			return errE
+		}
+
+		if exists {
+			return fmt.Errorf("an account already exists for %s in the storage", email)
+		}
+	}
+
+	account, client, err := loadRegisteredAccount(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
+
+	reg, err := client.Registration.UpdateContacts(email)
+	if err != nil {
+		return fmt.Errorf("update the account: %w", err)
+	}
+
+	account.Email = email
+	account.Registration = reg
+
+	if target.GetRootUserPath() != accountsStorage.GetRootUserPath() {
+		err = accountsStorage.Move(target)
+		if err != nil {
+			return fmt.Errorf("the contact of the account has been updated on the CA, but the account has not been moved in the storage: %w", err)
+		}
+	}
+
+	err = target.Save(account)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("The contact of the account %s has been updated: %s", reg.URI, email)
+
+	return nil
+}
+
+func accountRollover(ctx *cli.Context) error {
+	keyType, err := certcrypto.ParseKeyType(ctx.String(flgAccountNewKeyType))
+	if err != nil {
+		return err
+	}
+
+	if !certcrypto.IsJWSKeyType(keyType) {
+		return fmt.Errorf("the key type %s cannot be used for an account key", ctx.String(flgAccountNewKeyType))
+	}
+
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, client, err := loadRegisteredAccount(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
+
+	newKey, err := certcrypto.GeneratePrivateKey(keyType)
+	if err != nil {
+		return fmt.Errorf("generate the new key: %w", err)
+	}
+
+	stagedPath, err := accountsStorage.StagePrivateKey(newKey)
+	if err != nil {
+		return fmt.Errorf("save the new key: %w", err)
+	}
+
+	err = client.Registration.RolloverKey(newKey)
+	if err != nil {
+		_ = os.Remove(stagedPath)
+
+		return fmt.Errorf("change the key of the account: %w", err)
+	}
+
+	err = accountsStorage.ReplacePrivateKey(stagedPath)
+	if err != nil {
+		return fmt.Errorf("the key of the account has been changed on the CA, but not in the storage (the new key is %s): %w", stagedPath, err)
+	}
+
+	log.Infof("The key of the account %s has been replaced by a new %s key.", account.Registration.URI, ctx.String(flgAccountNewKeyType))
+
+	return nil
+}
+
+func accountDeactivate(ctx *cli.Context) error {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, client, err := loadRegisteredAccount(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Bool(flgAccountYes) {
+		w := &wizard{in: bufio.NewReader(os.Stdin), out: ctx.App.Writer}
+
+		question := fmt.Sprintf("Deactivate the account %s (%s)? The account can't be used anymore.", account.Email, account.Registration.URI)
+
+		ok, errC := w.confirm(question, false)
+		if errC != nil {
+			return errC
+		}
+
+		if !ok {
+			log.Infof("Deactivation aborted.")
+
+			return nil
+		}
+	}
+
+	err = client.Registration.DeleteRegistration()
+	if err != nil {
+		return fmt.Errorf("deactivate the account: %w", err)
+	}
+
+	account.Registration.Body.Status = acme.StatusDeactivated
+
+	err = accountsStorage.Save(account)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("The account %s has been deactivated.", account.Registration.URI)
+
+	return nil
+}
+
+func accountExport(ctx *cli.Context) error {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	exists, err := accountsStorage.ExistsAccountFilePath()
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("account %s not found in the storage (%s)", accountsStorage.GetUserID(), accountsStorage.GetRootUserPath())
+	}
+
+	privateKey, err := accountsStorage.LoadPrivateKey()
+	if err != nil {
+		return fmt.Errorf("load the key of the account: %w", err)
+	}
+
+	account, err := accountsStorage.LoadAccount(privateKey)
+	if err != nil {
+		return err
+	}
+
+	if account.Registration == nil {
+		return fmt.Errorf("account %s is not registered", accountsStorage.GetUserID())
+	}
+
+	passphrase, err := getAccountPassphrase(ctx)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := encodeAccountBundle(accountsStorage.GetServer(), account, passphrase)
+	if err != nil {
+		return fmt.Errorf("create the bundle: %w", err)
+	}
+
+	output := ctx.String(flgAccountOutput)
+	if output == "-" {
+		_, err = ctx.App.Writer.Write(append(bundle, '\n'))
+
+		return err
+	}
+
+	err = os.WriteFile(output, append(bundle, '\n'), filePerm)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("The account %s has been exported to %s.", account.Registration.URI, output)
+
+	if len(passphrase) == 0 {
+		log.Warnf("The private key of the account is not encrypted in the bundle, use --%s to encrypt it.", flgAccountPassFile)
+	}
+
+	return nil
+}
+
+func accountImport(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("the bundle file is required")
+	}
+
+	data, err := readCertificateFile(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("read the bundle: %w", err)
+	}
+
+	passphrase, err := getAccountPassphrase(ctx)
+	if err != nil {
+		return err
+	}
+
+	bundle, privateKey, err := decodeAccountBundle(data, passphrase)
+	if err != nil {
+		return err
+	}
+
+	accountsStorage, err := newAccountsStorage(ctx, bundle.Server, bundle.Email)
+	if err != nil {
+		return err
+	}
+
+	exists, err := accountsStorage.ExistsAccountFilePath()
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return fmt.Errorf("an account already exists for %s in the storage (%s)", accountsStorage.GetUserID(), accountsStorage.GetRootUserPath())
+	}
+
+	err = accountsStorage.SavePrivateKey(privateKey)
+	if err != nil {
+		return fmt.Errorf("save the key of the account: %w", err)
+	}
+
+	err = accountsStorage.Save(&Account{Email: bundle.Email, Registration: bundle.Registration})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("The account %s (%s) has been imported for %s.", bundle.Registration.URI, bundle.Server, accountsStorage.GetUserID())
+
+	return nil
+}
+
+// getAccountPassphrase returns the passphrase of the account bundle (--passphrase-file), if any.
+func getAccountPassphrase(ctx *cli.Context) ([]byte, error) {
+	if !ctx.IsSet(flgAccountPassFile) {
+		return nil, nil
+	}
+
+	return readPassphraseFile(ctx.String(flgAccountPassFile))
+}
+
+// loadRegisteredAccount loads an account of the storage, and creates a client with it.
+// Unlike setupAccount, the account must exist: no key is generated.
+func loadRegisteredAccount(ctx *cli.Context, accountsStorage *AccountsStorage) (*Account, *lego.Client, error) {
+	exists, err := accountsStorage.ExistsAccountFilePath()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !exists {
+		return nil, nil, fmt.Errorf("account %s not found in the storage (%s)", accountsStorage.GetUserID(), accountsStorage.GetRootUserPath())
+	}
+
+	account, keyType, err := setupAccount(ctx, accountsStorage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if account.Registration == nil {
+		return nil, nil, fmt.Errorf("account %s is not registered. Use 'run' to register a new account", account.Email)
+	}
+
+	client, err := createClient(ctx, account.server, account, keyType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return account, client, nil
+}
+
+// accountKeyTypeNames returns the names of the key types that can be used for the account key.
+func accountKeyTypeNames() []string {
+	var names []string
+
+	for _, name := range certcrypto.KeyTypeNames() {
+		keyType, err := certcrypto.ParseKeyType(name)
+		if err == nil && certcrypto.IsJWSKeyType(keyType) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// getAccountEmail returns the email of the account (--email of the global options),
+// even if the command has its own email option (e.g. account update --email).
+func getAccountEmail(ctx *cli.Context) string {
+	lineage := ctx.Lineage()
+	if len(lineage) < 2 {
+		return ctx.String(flgEmail)
+	}
+
+	return lineage[1].String(flgEmail)
+}
+
+func newAccountDetailsOutput(accountsStorage *AccountsStorage, email string, reg *registration.Resource) accountDetailsOutput {
+	return accountDetailsOutput{
+		Email:    email,
+		Server:   accountsStorage.GetServer(),
+		URI:      reg.URI,
+		Status:   reg.Body.Status,
+		Contacts: reg.Body.Contact,
+		Orders:   reg.Body.Orders,
+		Path:     filepath.Join(accountsStorage.GetRootUserPath(), accountFileName),
+	}
+}
+
+// writeAccountDetails writes the information about the account.
+func writeAccountDetails(w io.Writer, output accountDetailsOutput) error {
+	ew := &errWriter{w: w}
+
+	ew.writef("Account: %s\n", output.URI)
+	ew.writef("  Email: %s\n", output.Email)
+	ew.writef("  Server: %s\n", output.Server)
+	ew.writef("  Status: %s\n", output.Status)
+
+	if len(output.Contacts) > 0 {
+		ew.writef("  Contacts: %s\n", strings.Join(output.Contacts, ", "))
+	}
+
+	if output.Orders != "" {
+		ew.writef("  Orders: %s\n", output.Orders)
+	}
+
+	ew.writef("  Path: %s\n", output.Path)
+
+	return ew.err
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_getAccountEmail(t *testing.T) {
+	var current, email string
+
+	command := createAccount()
+
+	for _, sub := range command.Subcommands {
+		if sub.Name == "update" {
+			sub.Action = func(ctx *cli.Context) error {
+				current = getAccountEmail(ctx)
+				email = ctx.String(flgEmail)
+
+				return nil
+			}
+		}
+	}
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags(t.TempDir())
+	app.Commands = []*cli.Command{command}
+
+	err := app.Run([]string{"lego", "--email", "old@example.com", "account", "update", "--email", "new@example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "old@example.com", current)
+	assert.Equal(t, "new@example.com", email)
+}
+
+func TestAccountsStorage_Move(t *testing.T) {
+	runCommand(t, "account", nil, func(ctx *cli.Context) error {
+		source, err := newAccountsStorage(ctx, "https://ca.example.com/directory", "old@example.com")
+		require.NoError(t, err)
+
+		target, err := newAccountsStorage(ctx, "https://ca.example.com/directory", "new@example.com")
+		require.NoError(t, err)
+
+		require.NoError(t, source.createKeysFolder())
+
+		err = os.WriteFile(filepath.Join(source.GetRootUserPath(), accountFileName), []byte("{}"), filePerm)
+		require.NoError(t, err)
+
+		err = os.WriteFile(filepath.Join(source.keysPath, "old@example.com.key"), []byte("key"), filePerm)
+		require.NoError(t, err)
+
+		err = source.Move(target)
+		require.NoError(t, err)
+
+		assert.NoDirExists(t, source.GetRootUserPath())
+		exists, err := target.ExistsAccountFilePath()
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.FileExists(t, filepath.Join(target.keysPath, "new@example.com.key"))
+
+		// The target already exists.
+		require.NoError(t, source.createKeysFolder())
+
+		err = source.Move(target)
+		require.Error(t, err)
+
+		return nil
+	}, "--path", t.TempDir(), "account")
+}
+
+func Test_writeAccountDetails(t *testing.T) {
+	output := accountDetailsOutput{
+		Email:    "test@example.com",
+		Server:   "https://ca.example.com/directory",
+		URI:      "https://ca.example.com/acme/acct/1",
+		Status:   "valid",
+		Contacts: []string{"mailto:test@example.com"},
+		Orders:   "https://ca.example.com/acme/acct/1/orders",
+		Path:     "/tmp/.lego/accounts/ca.example.com/test@example.com/account.json",
+	}
+
+	buf := &bytes.Buffer{}
+
+	err := writeAccountDetails(buf, output)
+	require.NoError(t, err)
+
+	expected := `Account: https://ca.example.com/acme/acct/1
+  Email: test@example.com
+  Server: https://ca.example.com/directory
+  Status: valid
+  Contacts: mailto:test@example.com
+  Orders: https://ca.example.com/acme/acct/1/orders
+  Path: /tmp/.lego/accounts/ca.example.com/test@example.com/account.json
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestAccountsStorage_ReplacePrivateKey(t *testing.T) {
+	runCommand(t, "account", nil, func(ctx *cli.Context) error {
+		accountsStorage, err := newAccountsStorage(ctx, "https://ca.example.com/directory", "test@example.com")
+		require.NoError(t, err)
+
+		oldKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+		require.NoError(t, err)
+
+		err = accountsStorage.SavePrivateKey(oldKey)
+		require.NoError(t, err)
+
+		newKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC384)
+		require.NoError(t, err)
+
+		stagedPath, err := accountsStorage.StagePrivateKey(newKey)
+		require.NoError(t, err)
+
+		err = accountsStorage.ReplacePrivateKey(stagedPath)
+		require.NoError(t, err)
+
+		assert.NoFileExists(t, stagedPath)
+
+		privateKey, err := loadPrivateKey(filepath.Join(accountsStorage.keysPath, "test@example.com.key"), nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, newKey, privateKey)
+
+		return nil
+	}, "--path", t.TempDir(), "account")
+}
+
+func Test_accountExport_accountImport(t *testing.T) {
+	source := t.TempDir()
+	target := t.TempDir()
+
+	bundleFile := filepath.Join(t.TempDir(), "account.json")
+
+	passFile := filepath.Join(t.TempDir(), "passphrase")
+	writeTestFile(t, passFile, "secret\n")
+
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+
+	reg := &registration.Resource{
+		URI:  "https://ca.example.com/acme/acct/1",
+		Body: acme.Account{Status: acme.StatusValid},
+	}
+
+	runCommand(t, "account", nil, func(ctx *cli.Context) error {
+		accountsStorage, err := newAccountsStorage(ctx, "https://ca.example.com/directory", "test@example.com")
+		require.NoError(t, err)
+
+		require.NoError(t, accountsStorage.SavePrivateKey(privateKey))
+		require.NoError(t, accountsStorage.Save(&Account{Email: "test@example.com", Registration: reg}))
+
+		return nil
+	}, "--path", source, "account")
+
+	newApp := func(path string) *cli.App {
+		app := cli.NewApp()
+		app.Flags = CreateFlags(path)
+		app.Commands = []*cli.Command{createAccount()}
+
+		return app
+	}
+
+	err = newApp(source).Run([]string{
+		"lego", "--path", source, "--server", "https://ca.example.com/directory", "--email", "test@example.com",
+		"account", "export", "--output", bundleFile, "--passphrase-file", passFile,
+	})
+	require.NoError(t, err)
+
+	err = newApp(target).Run([]string{"lego", "--path", target, "account", "import", bundleFile})
+	require.EqualError(t, err, "the key of the account bundle is encrypted: a passphrase is required")
+
+	err = newApp(target).Run([]string{"lego", "--path", target, "account", "import", "--passphrase-file", passFile, bundleFile})
+	require.NoError(t, err)
+
+	runCommand(t, "account", nil, func(ctx *cli.Context) error {
+		accountsStorage, errS := newAccountsStorage(ctx, "https://ca.example.com/directory", "test@example.com")
+		require.NoError(t, errS)
+
+		key, errL := accountsStorage.LoadPrivateKey()
+		require.NoError(t, errL)
+
+		assert.Equal(t, privateKey, key)
+
+		account, errL := accountsStorage.LoadAccount(key)
+		require.NoError(t, errL)
+		assert.Equal(t, reg, account.Registration)
+		assert.Equal(t, "test@example.com", account.Email)
+
+		return nil
+	}, "--path", target, "account")
+
+	// The account already exists.
+	err = newApp(target).Run([]string{"lego", "--path", target, "account", "import", "--passphrase-file", passFile, bundleFile})
+	require.Error(t, err)
+}
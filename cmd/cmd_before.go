@@ -1,22 +1,64 @@
 package cmd
 
 import (
-	"github.com/go-acme/lego/v4/log"
+	"fmt"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/urfave/cli/v2"
 )
 
+// Before resolves and checks the global options.
+// The errors are reported with the exit code 2 by renew --detailed-exit-code (see exitCodeError).
 func Before(ctx *cli.Context) error {
+	err := before(ctx)
+	if err != nil && detailedExitCodeRequested(ctx) {
+		return &exitStatus{code: exitCodeError, err: err}
+	}
+
+	return err
+}
+
+func before(ctx *cli.Context) error {
+	err := applyConfigFile(ctx)
+	if err != nil {
+		return fmt.Errorf("could not load the configuration file: %w", err)
+	}
+
+	// The options of the output can be defined by the configuration file.
+	err = setupOutput(ctx)
+	if err != nil {
+		return fmt.Errorf("could not set up the output: %w", err)
+	}
+
 	if ctx.String(flgPath) == "" {
-		log.Fatalf("Could not determine current working directory. Please pass --%s.", flgPath)
+		return fmt.Errorf("could not determine current working directory. Please pass --%s", flgPath)
 	}
 
-	err := createNonExistingFolder(ctx.String(flgPath))
+	err = createNonExistingFolder(ctx.String(flgPath))
 	if err != nil {
-		log.Fatalf("Could not check/create path: %v", err)
+		return fmt.Errorf("could not check/create path: %w", err)
+	}
+
+	if getServer(ctx) == "" {
+		return fmt.Errorf("could not determine current working server. Please pass --%s", flgServer)
 	}
 
-	if ctx.String(flgServer) == "" {
-		log.Fatalf("Could not determine current working server. Please pass --%s.", flgServer)
+	err = resolveDomains(ctx)
+	if err != nil {
+		return fmt.Errorf("could not read the domains: %w", err)
+	}
+
+	if ctx.Bool(flgFIPS) {
+		certcrypto.SetFIPSMode(true)
+	}
+
+	// The secrets are scrubbed from the logs and the errors.
+	redact.Register(ctx.String(flgHMAC), ctx.String(flgEABZeroSSLAPIKey), env.GetOrFile(envKeyPassword))
+
+	if ctx.IsSet(flgPFXPass) {
+		redact.Register(ctx.String(flgPFXPass))
 	}
 
 	return nil
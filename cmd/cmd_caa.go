@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/providers/dns"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgCAAIdentity    = "caa.identity"
+	flgCAABindAccount = "caa.bind-account"
+	flgStrictCAA      = "strict-caa"
+)
+
+func createCAA() *cli.Command {
+	return &cli.Command{
+		Name:  "caa",
+		Usage: "Manage the CAA records authorizing the CA to issue certificates for the domains",
+		Before: func(ctx *cli.Context) error {
+			if len(ctx.StringSlice(flgDomains)) == 0 {
+				log.Fatalf("Please specify --%s/-d", flgDomains)
+			}
+
+			return nil
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:   "set",
+				Usage:  "Create the CAA records authorizing the CA, using the DNS provider (--dns).",
+				Action: caaSet,
+				Flags:  createCAAFlags(),
+			},
+			{
+				Name:   "check",
+				Usage:  "Check that the CAA records of the domains authorize the CA.",
+				Action: caaCheck,
+				Flags:  createCAAFlags(),
+			},
+		},
+	}
+}
+
+func createCAAFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: flgCAAIdentity,
+			Usage: "The issuer domain names of the CA used inside the CAA records." +
+				" By default, the CAA identities provided by the ACME server directory are used.",
+		},
+		&cli.BoolFlag{
+			Name:  flgCAABindAccount,
+			Usage: "Restrict the CAA records to the ACME account (RFC 8657 accounturi parameter).",
+		},
+	}
+}
+
+func createStrictCAAFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name: flgStrictCAA,
+		Usage: "Fail, before solving the challenges, if the CAA records of a domain don't authorize the CA" +
+			" (the CAA identities of the ACME server directory). By default, only a warning is displayed.",
+	}
+}
+
+func caaSet(ctx *cli.Context) error {
+	if !ctx.IsSet(flgDNS) {
+		log.Fatalf("Please specify --%s", flgDNS)
+	}
+
+	client, accountURI := setupCAAClient(ctx)
+
+	return setCAA(ctx, client, accountURI)
+}
+
+func caaCheck(ctx *cli.Context) error {
+	client, accountURI := setupCAAClient(ctx)
+
+	identities := getCAAIdentities(ctx, client)
+
+	if servers := ctx.StringSlice(flgDNSResolvers); len(servers) > 0 {
+		_ = dns01.AddRecursiveNameservers(dns01.ParseNameservers(servers))(nil)
+	}
+
+	var failed bool
+
+	for _, domain := range ctx.StringSlice(flgDomains) {
+		err := dns01.CheckCAA(domain, identities, accountURI)
+		if err != nil {
+			failed = true
+
+			fmt.Printf("[FAIL] %s: %v\n", domain, err)
+
+			continue
+		}
+
+		fmt.Printf("[OK]   %s\n", domain)
+	}
+
+	if failed {
+		return errors.New("caa: the CAA records don't authorize the CA for all the domains")
+	}
+
+	return nil
+}
+
+func setupCAAClient(ctx *cli.Context) (*lego.Client, string) {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	account, keyType, err := setupAccount(ctx, accountsStorage)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var accountURI string
+
+	if ctx.Bool(flgCAABindAccount) {
+		if account.Registration == nil {
+			log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+		}
+
+		accountURI = account.Registration.URI
+	}
+
+	return newClient(ctx, account.server, account, keyType), accountURI
+}
+
+// setCAA creates the CAA records authorizing the CA for the domains, using the DNS provider.
+func setCAA(ctx *cli.Context, client *lego.Client, accountURI string) error {
+	provider, err := dns.NewDNSChallengeProviderByName(ctx.String(flgDNS))
+	if err != nil {
+		return err
+	}
+
+	caaProvider, ok := provider.(dns01.CAAProvider)
+	if !ok {
+		return fmt.Errorf("caa: the DNS provider %q doesn't support the management of CAA records", ctx.String(flgDNS))
+	}
+
+	records := dns01.NewCAARecords(getCAAIdentities(ctx, client), accountURI)
+
+	var domains []string
+
+	for _, domain := range ctx.StringSlice(flgDomains) {
+		domain = strings.TrimPrefix(domain, "*.")
+
+		if slices.Contains(domains, domain) {
+			continue
+		}
+
+		domains = append(domains, domain)
+	}
+
+	for _, domain := range domains {
+		err = caaProvider.SetCAA(domain, records)
+		if err != nil {
+			return fmt.Errorf("caa: %s: %w", domain, err)
+		}
+
+		log.Infof("[%s] caa: CAA records created: %s", domain, records)
+	}
+
+	return nil
+}
+
+// preflightCAA checks, before solving the challenges, that the CAA records of the domains authorize the CA
+// (the directory `meta.caaIdentities`): otherwise, the CA refuses to finalize the order.
+// The mismatches are logged as warnings, or returned as an error with --strict-caa.
+func preflightCAA(ctx *cli.Context, client *lego.Client, account *Account, domains []string) error {
+	if ctx.Bool(flgCAASet) {
+		// The CAA records have just been created (--caa.set), and may not be propagated yet.
+		return nil
+	}
+
+	identities := client.GetCAAIdentities()
+	if ctx.IsSet(flgCAAIdentity) {
+		identities = ctx.StringSlice(flgCAAIdentity)
+	}
+
+	if len(identities) == 0 {
+		// The CA doesn't provide CAA identities: nothing to compare.
+		return nil
+	}
+
+	if servers := ctx.StringSlice(flgDNSResolvers); len(servers) > 0 {
+		_ = dns01.AddRecursiveNameservers(dns01.ParseNameservers(servers))(nil)
+	}
+
+	var accountURI string
+	if account.Registration != nil {
+		accountURI = account.Registration.URI
+	}
+
+	var errs []error
+
+	for _, domain := range domains {
+		err := dns01.CheckCAA(domain, identities, accountURI)
+		if err != nil {
+			log.Warnf("[%s] caa: the CA will probably refuse to issue the certificate: %v", domain, err)
+
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 && ctx.Bool(flgStrictCAA) {
+		return fmt.Errorf("caa: the CAA records don't authorize the CA (--%s): %w", flgStrictCAA, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func getCAAIdentities(ctx *cli.Context, client *lego.Client) []string {
+	if ctx.IsSet(flgCAAIdentity) {
+		return ctx.StringSlice(flgCAAIdentity)
+	}
+
+	identities := client.GetCAAIdentities()
+	if len(identities) == 0 {
+		log.Fatalf("The ACME server doesn't provide CAA identities, please specify --%s", flgCAAIdentity)
+	}
+
+	return identities
+}
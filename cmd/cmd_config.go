@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/redact"
+	"github.com/urfave/cli/v2"
+)
+
+// Sources of the values of the options.
+const (
+	sourceFlag       = "flag"
+	sourceEnv        = "env"
+	sourceConfigFile = "config file"
+	sourceDefault    = "default"
+)
+
+// Keys of the application metadata.
+const (
+	metadataConfigKeys = "config-keys"
+	metadataConfigEnvs = "config-envs"
+)
+
+// secretFlags the options masked in the configuration dump.
+var secretFlags = []string{flgHMAC, flgEABZeroSSLAPIKey, flgPFXPass}
+
+func createConfig() *cli.Command {
+	return &cli.Command{
+		Name:  "config",
+		Usage: "Inspect the configuration",
+		Subcommands: []*cli.Command{
+			{
+				Name: "dump",
+				Usage: "Display the resolved configuration of the global options (flags, environment variables, configuration file, default values)," +
+					" with the source of each value. The secrets are masked.",
+				Action: configDump,
+			},
+		},
+	}
+}
+
+func configDump(ctx *cli.Context) error {
+	return writeConfig(ctx.App.Writer, ctx, os.Args[1:])
+}
+
+// withPrintConfig displays the resolved configuration before the action of the command (--print-config).
+func withPrintConfig(action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		if ctx.Bool(flgPrintConfig) {
+			err := writeConfig(ctx.App.ErrWriter, ctx, os.Args[1:])
+			if err != nil {
+				return err
+			}
+		}
+
+		return action(ctx)
+	}
+}
+
+// writeConfig writes the resolved configuration: the global options, the options of the command, and the environment variables of the configuration file.
+// The arguments are the command line arguments, used to find the options defined by flags.
+func writeConfig(w io.Writer, ctx *cli.Context, args []string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	ew := &errWriter{w: tw}
+
+	ew.writeln("# Global options")
+	writeFlags(ew, ctx, ctx.App.Flags, args)
+
+	if ctx.Command != nil && ctx.Command.Name != "dump" && len(ctx.Command.Flags) > 0 {
+		ew.writeln()
+		ew.writef("# %s options\n", ctx.Command.Name)
+		writeFlags(ew, ctx, ctx.Command.Flags, args)
+	}
+
+	if envs := getMetadataList(ctx, metadataConfigEnvs); len(envs) > 0 {
+		ew.writeln()
+		ew.writeln("# Environment variables (configuration file)")
+
+		for _, name := range envs {
+			ew.writef("%s = %q\n", name, redact.Replacement)
+		}
+	}
+
+	if ew.err != nil {
+		return ew.err
+	}
+
+	return tw.Flush()
+}
+
+func writeFlags(ew *errWriter, ctx *cli.Context, flags []cli.Flag, args []string) {
+	for _, flag := range flags {
+		name := flag.Names()[0]
+
+		if name == "help" || name == "version" {
+			continue
+		}
+
+		value := formatFlagValue(ctx, flag)
+		if slices.Contains(secretFlags, name) && value != `""` {
+			value = fmt.Sprintf("%q", redact.Replacement)
+		}
+
+		ew.writef("%s = %s\t# %s\n", name, redact.String(value), flagSource(ctx, flag, args))
+	}
+}
+
+func formatFlagValue(ctx *cli.Context, flag cli.Flag) string {
+	name := flag.Names()[0]
+
+	switch flag.(type) {
+	case *cli.BoolFlag:
+		return fmt.Sprint(ctx.Bool(name))
+	case *cli.IntFlag:
+		return fmt.Sprint(ctx.Int(name))
+	case *cli.UintFlag:
+		return fmt.Sprint(ctx.Uint(name))
+	case *cli.DurationFlag:
+		return fmt.Sprintf("%q", ctx.Duration(name))
+	case *cli.StringSliceFlag:
+		values := ctx.StringSlice(name)
+
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, fmt.Sprintf("%q", v))
+		}
+
+		return "[" + strings.Join(quoted, ", ") + "]"
+	case *cli.TimestampFlag:
+		t := getTime(ctx, name)
+		if t.IsZero() {
+			return `""`
+		}
+
+		return fmt.Sprintf("%q", t.Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("%q", ctx.String(name))
+	}
+}
+
+// flagSource returns the source of the value of the option.
+// The precedence order is: the command line, the environment variables (and their _FILE variants), the configuration file, the default value.
+func flagSource(ctx *cli.Context, flag cli.Flag, args []string) string {
+	if isOnCommandLine(args, flag.Names()) {
+		return sourceFlag
+	}
+
+	if f, ok := flag.(cli.DocGenerationFlag); ok {
+		for _, env := range f.GetEnvVars() {
+			if _, found := os.LookupEnv(env); found {
+				return fmt.Sprintf("%s (%s)", sourceEnv, env)
+			}
+
+			if _, found := os.LookupEnv(env + envFileSuffix); found && !isFlagEnvVar(contextFlags(ctx), env+envFileSuffix) {
+				return fmt.Sprintf("%s (%s)", sourceEnv, env+envFileSuffix)
+			}
+		}
+	}
+
+	if slices.Contains(getMetadataList(ctx, metadataConfigKeys), flag.Names()[0]) {
+		return sourceConfigFile
+	}
+
+	if ctx.IsSet(flag.Names()[0]) {
+		// Defined by lego itself (e.g. the domains read from a file).
+		return sourceFlag
+	}
+
+	return sourceDefault
+}
+
+// contextFlags returns the global options and the options of the command.
+func contextFlags(ctx *cli.Context) []cli.Flag {
+	if ctx.Command == nil {
+		return ctx.App.Flags
+	}
+
+	return slices.Concat(ctx.App.Flags, ctx.Command.Flags)
+}
+
+func isOnCommandLine(args, names []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+
+		for _, name := range names {
+			for _, prefix := range []string{"-", "--"} {
+				if arg == prefix+name || strings.HasPrefix(arg, prefix+name+"=") {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func addMetadataList(ctx *cli.Context, key, value string) {
+	if ctx.App.Metadata == nil {
+		ctx.App.Metadata = make(map[string]any)
+	}
+
+	values, _ := ctx.App.Metadata[key].([]string)
+	if slices.Contains(values, value) {
+		return
+	}
+
+	ctx.App.Metadata[key] = append(values, value)
+}
+
+func getMetadataList(ctx *cli.Context, key string) []string {
+	values, _ := ctx.App.Metadata[key].([]string)
+
+	return values
+}
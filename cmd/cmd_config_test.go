@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writeConfig(t *testing.T) {
+	t.Setenv("LEGO_SERVER", "https://acme.example.com/directory")
+	t.Setenv("LEGO_HMAC", "secret-hmac")
+
+	content := `
+email = "file@example.com"
+dns = "manual"
+
+[env]
+LEGO_TEST_DUMP_TOKEN = "secret"
+`
+
+	args := []string{"--path", "/tmp/lego"}
+
+	ctx, err := runWithConfig(t, content, args...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.Unsetenv("LEGO_TEST_DUMP_TOKEN") })
+
+	buf := &bytes.Buffer{}
+
+	err = writeConfig(buf, ctx, args)
+	require.NoError(t, err)
+
+	output := buf.String()
+
+	assert.Regexp(t, `(?m)^path = "/tmp/lego" +# flag$`, output)
+	assert.Regexp(t, `(?m)^server = \["https://acme.example.com/directory"\] +# env \(LEGO_SERVER\)$`, output)
+	assert.Regexp(t, `(?m)^email = "file@example.com" +# config file$`, output)
+	assert.Regexp(t, `(?m)^dns = "manual" +# config file$`, output)
+	assert.Regexp(t, `(?m)^key-type = "ec256" +# default$`, output)
+	assert.Regexp(t, `(?m)^hmac = "\*\*\*" +# env \(LEGO_HMAC\)$`, output)
+	assert.Regexp(t, `(?m)^LEGO_TEST_DUMP_TOKEN = "\*\*\*"$`, output)
+
+	assert.NotContains(t, output, "secret")
+}
+
+func Test_isOnCommandLine(t *testing.T) {
+	assert.True(t, isOnCommandLine([]string{"-d", "example.com"}, []string{"domains", "d"}))
+	assert.True(t, isOnCommandLine([]string{"--domains=example.com"}, []string{"domains", "d"}))
+	assert.False(t, isOnCommandLine([]string{"--domains-file", "domains.txt"}, []string{"domains", "d"}))
+	assert.False(t, isOnCommandLine([]string{"--", "-d"}, []string{"domains", "d"}))
+}
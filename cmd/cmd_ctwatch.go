@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/ctmonitor"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgCTInterval    = "ct.interval"
+	flgCTSince       = "ct.since"
+	flgCTOnce        = "ct.once"
+	flgCTHook        = "ct.hook"
+	flgCTHookTimeout = "ct.hook-timeout"
+)
+
+const (
+	hookEnvCTID         = "LEGO_CT_ID"
+	hookEnvCTIssuer     = "LEGO_CT_ISSUER"
+	hookEnvCTCommonName = "LEGO_CT_COMMON_NAME"
+	hookEnvCTDNSNames   = "LEGO_CT_DNS_NAMES"
+	hookEnvCTSerial     = "LEGO_CT_SERIAL"
+	hookEnvCTNotBefore  = "LEGO_CT_NOT_BEFORE"
+	hookEnvCTURL        = "LEGO_CT_URL"
+)
+
+func createCTWatch() *cli.Command {
+	return &cli.Command{
+		Name: "ct-watch",
+		Usage: "Watch the Certificate Transparency logs (crt.sh), and alert when a certificate not issued by this lego installation" +
+			" appears for the managed domains.",
+		Action: ctWatch,
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:  flgCTInterval,
+				Usage: "The interval between two checks of the CT logs.",
+				Value: time.Hour,
+			},
+			&cli.DurationFlag{
+				Name:  flgCTSince,
+				Usage: "Ignore the certificates issued before this duration.",
+				Value: 7 * 24 * time.Hour,
+			},
+			&cli.BoolFlag{
+				Name:  flgCTOnce,
+				Usage: "Check the CT logs once, and exit with an error if an unknown certificate is found.",
+			},
+			&cli.StringFlag{
+				Name:  flgCTHook,
+				Usage: "Define a hook. The hook is executed for each unknown certificate found.",
+			},
+			&cli.DurationFlag{
+				Name:  flgCTHookTimeout,
+				Usage: "Define the timeout for the hook execution.",
+				Value: 2 * time.Minute,
+			},
+		},
+	}
+}
+
+func ctWatch(ctx *cli.Context) error {
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	monitor := ctmonitor.NewMonitor(ctmonitor.NewCrtSh(), time.Now().Add(-ctx.Duration(flgCTSince)))
+
+	if ctx.Bool(flgCTOnce) {
+		found, err := ctCheck(ctx.Context, ctx, certsStorage, monitor)
+		if err != nil {
+			return err
+		}
+
+		if found > 0 {
+			return fmt.Errorf("ct-watch: %d unknown certificate(s) found", found)
+		}
+
+		return nil
+	}
+
+	sigCtx, stop := signal.NotifyContext(ctx.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(ctx.Duration(flgCTInterval))
+	defer ticker.Stop()
+
+	for {
+		_, err := ctCheck(sigCtx, ctx, certsStorage, monitor)
+		if err != nil {
+			log.Warnf("ct-watch: %v", err)
+		}
+
+		select {
+		case <-sigCtx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ctCheck checks the CT logs once, and returns the number of unknown certificates.
+func ctCheck(stdCtx context.Context, ctx *cli.Context, certsStorage *CertificatesStorage, monitor *ctmonitor.Monitor) (int, error) {
+	certs, err := readStoredCertificates(certsStorage)
+	if err != nil {
+		return 0, err
+	}
+
+	monitor.AddKnown(certs...)
+
+	domains := ctx.StringSlice(flgDomains)
+	if len(domains) == 0 {
+		domains = managedDomains(certs)
+	}
+
+	if len(domains) == 0 {
+		return 0, errors.New("ct-watch: no domains to watch")
+	}
+
+	log.Infof("ct-watch: checking the CT logs for %s", strings.Join(domains, ", "))
+
+	unknown, err := monitor.Check(stdCtx, domains)
+
+	for _, entry := range unknown {
+		log.Warnf("ct-watch: unknown certificate found: %s (issuer: %s, serial: %s, domains: %s, not before: %s)",
+			crtShURL(entry), entry.IssuerName, entry.SerialNumber, strings.Join(entry.DNSNames, ", "), entry.NotBefore)
+
+		meta := map[string]string{
+			hookEnvCTID:         strconv.FormatInt(entry.ID, 10),
+			hookEnvCTIssuer:     entry.IssuerName,
+			hookEnvCTCommonName: entry.CommonName,
+			hookEnvCTDNSNames:   strings.Join(entry.DNSNames, ","),
+			hookEnvCTSerial:     entry.SerialNumber,
+			hookEnvCTNotBefore:  entry.NotBefore.Format(time.RFC3339),
+			hookEnvCTURL:        crtShURL(entry),
+		}
+
+		errH := launchHook(stdCtx, ctx.String(flgCTHook), ctx.Duration(flgCTHookTimeout), meta)
+		if errH != nil {
+			log.Warnf("ct-watch: hook: %v", errH)
+		}
+	}
+
+	return len(unknown), err
+}
+
+// readStoredCertificates reads the leaf certificates of the certificates directory and of the archives directory.
+func readStoredCertificates(certsStorage *CertificatesStorage) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	for _, dir := range []string{certsStorage.rootPath, certsStorage.archivePath} {
+		matches, err := filepath.Glob(filepath.Join(dir, "*"+certExt))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, filename := range matches {
+			if strings.HasSuffix(filename, issuerExt) {
+				continue
+			}
+
+			data, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, err
+			}
+
+			cert, err := certcrypto.ParsePEMCertificate(data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", filename, err)
+			}
+
+			certs = append(certs, cert)
+		}
+	}
+
+	return certs, nil
+}
+
+// managedDomains returns the domains of the certificates, without the wildcard prefix.
+func managedDomains(certs []*x509.Certificate) []string {
+	var domains []string
+
+	for _, cert := range certs {
+		for _, domain := range cert.DNSNames {
+			domain = strings.TrimPrefix(domain, "*.")
+
+			if !slices.Contains(domains, domain) {
+				domains = append(domains, domain)
+			}
+		}
+	}
+
+	slices.Sort(domains)
+
+	return domains
+}
+
+func crtShURL(entry ctmonitor.Entry) string {
+	return "https://crt.sh/?id=" + strconv.FormatInt(entry.ID, 10)
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgDaemonInterval = "interval"
+)
+
+func createDaemon() *cli.Command {
+	renewCommand := createRenew()
+
+	// All the stored certificates are checked, the domains come from the certificates, a daemon can't be a dry run, and the daemon has no result to report with the exit code.
+	flags := slices.DeleteFunc(renewCommand.Flags, func(flag cli.Flag) bool {
+		return slices.ContainsFunc(flag.Names(), func(name string) bool {
+			return slices.Contains([]string{flgForceCertDomains, flgRenewAll, flgDryRun, flgDryRunServerMap, flgDetailedExitCode}, name)
+		})
+	})
+
+	return &cli.Command{
+		Name: "daemon",
+		Usage: "Keep running, and renew the stored certificates when needed" +
+			" (the renewal options and the hooks are the same as the renew command)",
+		Action: daemon,
+		Flags: append([]cli.Flag{
+			&cli.DurationFlag{
+				Name:  flgDaemonInterval,
+				Usage: "The interval between two checks of the certificates.",
+				Value: 6 * time.Hour,
+			},
+		}, flags...),
+	}
+}
+
+func daemon(ctx *cli.Context) error {
+	interval := ctx.Duration(flgDaemonInterval)
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval: %s", interval)
+	}
+
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, keyType, err := setupAccount(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
+
+	if account.Registration == nil {
+		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+	}
+
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = certsStorage.CreateRootFolder()
+	if err != nil {
+		return err
+	}
+
+	stop, cancel := signal.NotifyContext(ctx.Context, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// The service is ready when the account is loaded (systemd, Type=notify).
+	err = sdNotify(sdNotifyReady)
+	if err != nil {
+		log.Warnf("%v", err)
+	}
+
+	startWatchdog(stop)
+
+	// The random sleep is done once, before the first check, instead of before each renewal.
+	if !ctx.Bool(flgNoRandomSleep) {
+		var sleepTime time.Duration
+		if maxSleep := ctx.Duration(flgRandomSleepMax); maxSleep > 0 {
+			rnd := rand.New(rand.NewSource(renewClock.Now().UnixNano()))
+			sleepTime = time.Duration(rnd.Int63n(int64(maxSleep)))
+		}
+
+		log.Infof("daemon: random delay of %s", sleepTime)
+		sdStatus("random delay of %s before the first check", sleepTime)
+
+		select {
+		case <-stop.Done():
+			stopDaemon()
+			return nil
+		case <-time.After(sleepTime):
+		}
+
+		err = ctx.Set(flgNoRandomSleep, "true")
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Infof("daemon: checking the certificates every %s", interval)
+
+	for {
+		sdStatus("checking the certificates")
+
+		checkCertificates(ctx, stop, account, certsStorage, keyType)
+
+		sdStatus("next check at %s", renewClock.Now().Add(interval).Format(time.RFC3339))
+
+		select {
+		case <-stop.Done():
+			stopDaemon()
+			return nil
+
+		case <-time.After(interval):
+		}
+	}
+}
+
+// stopDaemon notifies systemd that the daemon is stopping.
+func stopDaemon() {
+	err := sdNotify(sdNotifyStopping)
+	if err != nil {
+		log.Warnf("%v", err)
+	}
+
+	log.Infof("daemon: stopped")
+}
+
+// checkCertificates renews the stored certificates which need it, and writes the summary of the check.
+// The path is locked (--lock) only during the check.
+func checkCertificates(ctx *cli.Context, stop context.Context, account *Account, certsStorage *CertificatesStorage, keyType certcrypto.KeyType) {
+	unlock, err := acquireLock(ctx)
+	if err != nil {
+		log.Warnf("daemon: the check is skipped: %v", err)
+		return
+	}
+
+	defer unlock()
+
+	startedAt := renewClock.Now().UTC()
+
+	// The errors are in the reports.
+	reports, _ := renewAll(ctx, stop, account, keyType, certsStorage)
+
+	logRenewalReports(reports)
+
+	errS := writeRenewalSummary(ctx, startedAt, reports...)
+	if errS != nil {
+		log.Warnf("%v", errS)
+	}
+}
@@ -3,13 +3,42 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/urfave/cli/v2"
 )
 
-const flgCode = "code"
+// Flag names.
+const (
+	flgCode          = "code"
+	flgDNSHelpOutput = "output"
+)
+
+// Output formats of the dnshelp command.
+const (
+	dnsHelpFormatText = "text"
+	dnsHelpFormatJSON = "json"
+)
+
+// dnsProvider the description of a DNS provider (dnshelp --output json).
+type dnsProvider struct {
+	Code          string              `json:"code"`
+	Name          string              `json:"name"`
+	Since         string              `json:"since"`
+	URL           string              `json:"url"`
+	Documentation string              `json:"documentation"`
+	Credentials   []dnsProviderEnvVar `json:"credentials,omitempty"`
+	Additional    []dnsProviderEnvVar `json:"additional,omitempty"`
+}
+
+// dnsProviderEnvVar an environment variable of a DNS provider.
+type dnsProviderEnvVar struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     string `json:"default,omitempty"`
+}
 
 func createDNSHelp() *cli.Command {
 	return &cli.Command{
@@ -22,11 +51,33 @@ func createDNSHelp() *cli.Command {
 				Aliases: []string{"c"},
 				Usage:   fmt.Sprintf("DNS code: %s", allDNSCodes()),
 			},
+			&cli.StringFlag{
+				Name: flgDNSHelpOutput,
+				Usage: fmt.Sprintf("The output format: %s, %s (same as --%s)."+
+					" The JSON output describes the providers (code, environment variables, default values, documentation URL), to generate configuration forms.",
+					dnsHelpFormatText, dnsHelpFormatJSON, flgJSON),
+				Value: dnsHelpFormatText,
+			},
 		},
 	}
 }
 
 func dnsHelp(ctx *cli.Context) error {
+	format := ctx.String(flgDNSHelpOutput)
+	if ctx.Bool(flgJSON) {
+		format = dnsHelpFormatJSON
+	}
+
+	switch format {
+	case dnsHelpFormatJSON:
+		return dnsHelpJSON(ctx)
+
+	case dnsHelpFormatText:
+
+	default:
+		return fmt.Errorf("--%s: unsupported value: %q", flgDNSHelpOutput, format)
+	}
+
 	code := ctx.String(flgCode)
 	if code == "" {
 		w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
@@ -53,6 +104,23 @@ func dnsHelp(ctx *cli.Context) error {
 	return displayDNSHelp(ctx.App.Writer, strings.ToLower(code))
 }
 
+// dnsHelpJSON displays the descriptions of the DNS providers, or of the provider of the code, as JSON.
+func dnsHelpJSON(ctx *cli.Context) error {
+	providers := dnsProviders()
+
+	code := strings.ToLower(ctx.String(flgCode))
+	if code == "" {
+		return writeJSON(providers)
+	}
+
+	index := slices.IndexFunc(providers, func(provider dnsProvider) bool { return provider.Code == code })
+	if index < 0 {
+		return fmt.Errorf("%q is not yet supported", code)
+	}
+
+	return writeJSON(providers[index])
+}
+
 type errWriter struct {
 	w   io.Writer
 	err error
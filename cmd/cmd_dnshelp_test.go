@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_dnsHelp_json(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	out := jsonOutput
+	jsonOutput = buf
+
+	t.Cleanup(func() { jsonOutput = out })
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(flgCode, "Exoscale", "")
+	set.String(flgDNSHelpOutput, dnsHelpFormatJSON, "")
+
+	err := dnsHelp(cli.NewContext(cli.NewApp(), set, nil))
+	require.NoError(t, err)
+
+	var provider dnsProvider
+
+	err = json.Unmarshal(buf.Bytes(), &provider)
+	require.NoError(t, err)
+
+	assert.Equal(t, "exoscale", provider.Code)
+	assert.Equal(t, "https://go-acme.github.io/lego/dns/exoscale", provider.Documentation)
+	assert.Contains(t, provider.Credentials, dnsProviderEnvVar{Name: "EXOSCALE_API_KEY", Description: "API key"})
+	assert.Contains(t, provider.Additional, dnsProviderEnvVar{
+		Name:        "EXOSCALE_HTTP_TIMEOUT",
+		Description: "API request timeout in seconds (Default: 60)",
+		Default:     "60",
+	})
+}
+
+func Test_dnsHelp_json_unknown(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(flgCode, "unknown", "")
+	set.String(flgDNSHelpOutput, dnsHelpFormatJSON, "")
+
+	err := dnsHelp(cli.NewContext(cli.NewApp(), set, nil))
+	require.EqualError(t, err, `"unknown" is not yet supported`)
+}
+
+func Test_dnsHelp_unsupportedOutput(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(flgDNSHelpOutput, "yaml", "")
+
+	err := dnsHelp(cli.NewContext(cli.NewApp(), set, nil))
+	require.EqualError(t, err, `--output: unsupported value: "yaml"`)
+}
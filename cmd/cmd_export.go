@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgExportFormat       = "format"
+	flgExportDir          = "dir"
+	flgExportK8sNamespace = "k8s.namespace"
+)
+
+// Export formats.
+const (
+	exportFormatCertbot   = "certbot"
+	exportFormatK8sSecret = "k8s-secret"
+	exportFormatPEMBundle = "pem-bundle"
+)
+
+func createExport() *cli.Command {
+	return &cli.Command{
+		Name: "export",
+		Usage: "Export the stored certificates (--domains, all the certificates by default)" +
+			" as a certbot live directory, Kubernetes TLS Secrets, or PEM bundles (private key and certificate chain).",
+		Action: export,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flgExportFormat,
+				Usage:    fmt.Sprintf("The export format: %s, %s, or %s.", exportFormatCertbot, exportFormatK8sSecret, exportFormatPEMBundle),
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name: flgExportDir,
+				Usage: "The output directory. With the k8s-secret and pem-bundle formats, '-' writes to stdout." +
+					" With the certbot format, the certificates are written in the live directory of the output directory.",
+				Value: ".",
+			},
+			&cli.StringFlag{
+				Name:  flgExportK8sNamespace,
+				Usage: "The namespace of the Kubernetes Secrets.",
+			},
+		},
+	}
+}
+
+// exportedCertificate the content of a stored certificate.
+type exportedCertificate struct {
+	name      string
+	cert      []byte
+	chain     []byte
+	key       []byte
+	fullChain []byte
+}
+
+func export(ctx *cli.Context) error {
+	format := ctx.String(flgExportFormat)
+
+	switch format {
+	case exportFormatCertbot, exportFormatK8sSecret, exportFormatPEMBundle:
+	default:
+		return fmt.Errorf("unsupported export format: %q", format)
+	}
+
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	names, err := exportNames(ctx, certsStorage)
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 {
+		log.Infof("No certificates found.")
+		return nil
+	}
+
+	out := ctx.String(flgExportDir)
+
+	if out == "-" && format == exportFormatCertbot {
+		return fmt.Errorf("the %s format can't be written to stdout", exportFormatCertbot)
+	}
+
+	for _, name := range names {
+		certificate, err := readExportedCertificate(certsStorage, name)
+		if err != nil {
+			return fmt.Errorf("[%s] %w", name, err)
+		}
+
+		switch format {
+		case exportFormatCertbot:
+			err = exportCertbot(out, certificate)
+		case exportFormatK8sSecret:
+			err = exportFile(ctx.App.Writer, out, name+".yaml", func(w io.Writer) error {
+				return writeK8sSecret(w, certificate, ctx.String(flgExportK8sNamespace))
+			})
+		case exportFormatPEMBundle:
+			err = exportFile(ctx.App.Writer, out, name+".pem", func(w io.Writer) error {
+				_, errW := w.Write(append(append([]byte{}, certificate.key...), certificate.fullChain...))
+				return errW
+			})
+		}
+
+		if err != nil {
+			return fmt.Errorf("[%s] %w", name, err)
+		}
+
+		log.Infof("[%s] The certificate has been exported (%s).", name, format)
+	}
+
+	return nil
+}
+
+// exportNames returns the names of the certificates to export: the domains, or all the stored certificates.
+func exportNames(ctx *cli.Context, certsStorage *CertificatesStorage) ([]string, error) {
+	domains := ctx.StringSlice(flgDomains)
+	if len(domains) > 0 {
+		// The certificate is named after the first domain.
+		name, err := sanitizedDomain(domains[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{name}, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(certsStorage.GetRootPath(), "*"+certExt))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, match := range matches {
+		if strings.HasSuffix(match, issuerExt) {
+			continue
+		}
+
+		names = append(names, strings.TrimSuffix(filepath.Base(match), certExt))
+	}
+
+	return names, nil
+}
+
+func readExportedCertificate(certsStorage *CertificatesStorage, name string) (*exportedCertificate, error) {
+	certs, err := certsStorage.ReadCertificate(name, certExt)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := certsStorage.ReadFile(name, issuerExt)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if len(chain) == 0 {
+		// The certificate is bundled with the issuers.
+		for _, cert := range certs[1:] {
+			chain = append(chain, certcrypto.PEMEncode(certcrypto.DERCertificateBytes(cert.Raw))...)
+		}
+	}
+
+	key, err := readExportedKey(certsStorage, name)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM := certcrypto.PEMEncode(certcrypto.DERCertificateBytes(certs[0].Raw))
+
+	return &exportedCertificate{
+		name:      name,
+		cert:      certPEM,
+		chain:     chain,
+		key:       key,
+		fullChain: append(append([]byte{}, certPEM...), chain...),
+	}, nil
+}
+
+// readExportedKey returns the PEM encoded private key, decrypted if needed.
+func readExportedKey(certsStorage *CertificatesStorage, name string) ([]byte, error) {
+	raw, err := certsStorage.ReadFile(name, keyExt)
+	if err != nil {
+		return nil, err
+	}
+
+	if block, _ := pem.Decode(raw); block == nil || !strings.Contains(block.Type, "ENCRYPTED") {
+		return raw, nil
+	}
+
+	key, err := certsStorage.ReadPrivateKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// exportCertbot writes the files of the certificate like the live directory of certbot (live/<name>/).
+func exportCertbot(out string, certificate *exportedCertificate) error {
+	dir := filepath.Join(out, "live", certificate.name)
+
+	err := createNonExistingFolder(dir)
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name    string
+		content []byte
+		mode    os.FileMode
+	}{
+		{name: "cert.pem", content: certificate.cert, mode: 0o644},
+		{name: "chain.pem", content: certificate.chain, mode: 0o644},
+		{name: "fullchain.pem", content: certificate.fullChain, mode: 0o644},
+		{name: "privkey.pem", content: certificate.key, mode: 0o600},
+	}
+
+	for _, file := range files {
+		err = os.WriteFile(filepath.Join(dir, file.name), file.content, file.mode)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportFile writes the content to a file of the output directory, or to stdout if the output directory is '-'.
+func exportFile(stdout io.Writer, out, filename string, write func(w io.Writer) error) error {
+	if out == "-" {
+		return write(stdout)
+	}
+
+	err := createNonExistingFolder(out)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+
+	err = write(buf)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(out, filename), buf.Bytes(), 0o600)
+}
+
+// writeK8sSecret writes a Kubernetes TLS Secret (YAML).
+func writeK8sSecret(w io.Writer, certificate *exportedCertificate, namespace string) error {
+	ew := &errWriter{w: w}
+
+	ew.writeln("apiVersion: v1")
+	ew.writeln("kind: Secret")
+	ew.writeln("metadata:")
+	ew.writef("  name: %s\n", k8sSecretName(certificate.name))
+
+	if namespace != "" {
+		ew.writef("  namespace: %s\n", namespace)
+	}
+
+	ew.writeln("type: kubernetes.io/tls")
+	ew.writeln("data:")
+	ew.writef("  tls.crt: %s\n", base64.StdEncoding.EncodeToString(certificate.fullChain))
+	ew.writef("  tls.key: %s\n", base64.StdEncoding.EncodeToString(certificate.key))
+	ew.writeln("---")
+
+	return ew.err
+}
+
+// k8sSecretName returns a valid name (RFC 1123 subdomain) for the Secret of the certificate.
+func k8sSecretName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.ReplaceAll(name, "_", "wildcard")
+	name = strings.ReplaceAll(name, ".", "-")
+
+	return strings.Trim(name, "-") + "-tls"
+}
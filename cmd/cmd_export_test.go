@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func runExport(t *testing.T, args ...string) (string, string) {
+	t.Helper()
+
+	path := t.TempDir()
+
+	certPEM, chainPEM, keyPEM := createTestCertificateFiles(t)
+
+	storage := &CertificatesStorage{rootPath: filepath.Join(path, baseCertificatesFolderName)}
+	require.NoError(t, os.MkdirAll(storage.rootPath, 0o700))
+
+	storage.SaveResource(&certificate.Resource{
+		Domain:            "example.com",
+		Certificate:       []byte(certPEM + chainPEM),
+		IssuerCertificate: []byte(chainPEM),
+		PrivateKey:        []byte(keyPEM),
+	}, &ResourceMetadata{})
+
+	stdout := &bytes.Buffer{}
+
+	app := cli.NewApp()
+	app.Writer = stdout
+	app.Flags = CreateFlags(path)
+	app.Commands = CreateCommands()
+
+	err := app.Run(append([]string{"lego", "export"}, args...))
+	require.NoError(t, err)
+
+	return path, stdout.String()
+}
+
+func Test_export_certbot(t *testing.T) {
+	out := t.TempDir()
+
+	_, _ = runExport(t, "--format", "certbot", "--dir", out)
+
+	for _, name := range []string{"cert.pem", "chain.pem", "fullchain.pem", "privkey.pem"} {
+		assert.FileExists(t, filepath.Join(out, "live", "example.com", name))
+	}
+
+	cert := readTestFile(t, filepath.Join(out, "live", "example.com", "cert.pem"))
+	chain := readTestFile(t, filepath.Join(out, "live", "example.com", "chain.pem"))
+
+	assert.Equal(t, cert+chain, readTestFile(t, filepath.Join(out, "live", "example.com", "fullchain.pem")))
+}
+
+func Test_export_k8sSecret(t *testing.T) {
+	path, stdout := runExport(t, "--format", "k8s-secret", "--dir", "-", "--k8s.namespace", "web")
+
+	fullChain := readTestFile(t, filepath.Join(path, baseCertificatesFolderName, "example.com.crt"))
+	key := readTestFile(t, filepath.Join(path, baseCertificatesFolderName, "example.com.key"))
+
+	expected := `apiVersion: v1
+kind: Secret
+metadata:
+  name: example-com-tls
+  namespace: web
+type: kubernetes.io/tls
+data:
+  tls.crt: ` + base64.StdEncoding.EncodeToString([]byte(fullChain)) + `
+  tls.key: ` + base64.StdEncoding.EncodeToString([]byte(key)) + `
+---
+`
+
+	assert.Equal(t, expected, stdout)
+}
+
+func Test_export_pemBundle(t *testing.T) {
+	out := t.TempDir()
+
+	path, _ := runExport(t, "--format", "pem-bundle", "--dir", out)
+
+	fullChain := readTestFile(t, filepath.Join(path, baseCertificatesFolderName, "example.com.crt"))
+	key := readTestFile(t, filepath.Join(path, baseCertificatesFolderName, "example.com.key"))
+
+	assert.Equal(t, key+fullChain, readTestFile(t, filepath.Join(out, "example.com.pem")))
+}
+
+func Test_k8sSecretName(t *testing.T) {
+	assert.Equal(t, "example-com-tls", k8sSecretName("example.com"))
+	assert.Equal(t, "wildcard-example-com-tls", k8sSecretName("_.example.com"))
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgFetchCertURL = "cert-url"
+)
+
+func createFetch() *cli.Command {
+	return &cli.Command{
+		Name: "fetch",
+		Usage: "Download a previously issued certificate from the CA and store it, without creating a new order" +
+			" (e.g. a certificate of an order completed by another process or by an interrupted run)",
+		Action: fetch,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flgFetchCertURL,
+				Usage:    "URL of the certificate (the 'certificate' field of the order).",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name: flgPrivateKey,
+				Usage: "Path to the private key (in PEM encoding) of the certificate." +
+					" The private key is required to create the .pem and .pfx files.",
+			},
+			&cli.BoolFlag{
+				Name:  flgNoBundle,
+				Usage: "Do not create a certificate bundle by adding the issuers certificate to the new certificate.",
+			},
+			&cli.StringFlag{
+				Name: flgPreferredChain,
+				Usage: "If the CA offers multiple certificate chains, prefer the chain matching this value:" +
+					" the Subject Common Name or the Subject of the root issuer, or the SHA-256 fingerprint of a certificate of the chain." +
+					" If no match, the default offered chain will be used.",
+			},
+		},
+	}
+}
+
+func fetch(ctx *cli.Context) error {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, keyType, err := setupAccount(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
+
+	if account.Registration == nil {
+		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+	}
+
+	client := newClient(ctx, account.server, account, keyType)
+
+	request := certificate.GetByURLRequest{
+		URL:            ctx.String(flgFetchCertURL),
+		Bundle:         !ctx.Bool(flgNoBundle),
+		PreferredChain: ctx.String(flgPreferredChain),
+	}
+
+	if ctx.IsSet(flgPrivateKey) {
+		passphrase, errP := getKeyPassphrase(ctx)
+		if errP != nil {
+			return errP
+		}
+
+		request.PrivateKey, err = loadPrivateKey(ctx.String(flgPrivateKey), passphrase)
+		if err != nil {
+			return fmt.Errorf("load private key: %w", err)
+		}
+	}
+
+	certRes, err := client.Certificate.GetByURL(request)
+	if err != nil {
+		return fmt.Errorf("could not fetch the certificate: %w", err)
+	}
+
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = certsStorage.CreateRootFolder()
+	if err != nil {
+		return err
+	}
+
+	certsStorage.SaveResource(certRes, &ResourceMetadata{})
+
+	log.Infof("[%s] The certificate has been stored.", certRes.Domain)
+
+	return nil
+}
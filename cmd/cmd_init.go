@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/providers/dns"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// Flag names.
+const (
+	flgInitForce = "force"
+)
+
+const defaultConfigFilename = "lego.toml"
+
+func createInit() *cli.Command {
+	return &cli.Command{
+		Name: "init",
+		Usage: "Interactive setup: asks for the email, the CA, the challenge type, and the DNS provider," +
+			" checks the configuration, and writes the configuration file (--config, default: " + defaultConfigFilename + ").",
+		Action: initConfig,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  flgInitForce,
+				Usage: "Overwrite the configuration file if it already exists.",
+			},
+		},
+	}
+}
+
+func initConfig(ctx *cli.Context) error {
+	filename := ctx.String(flgConfig)
+	if filename == "" {
+		filename = defaultConfigFilename
+	}
+
+	if _, err := os.Stat(filename); err == nil && !ctx.Bool(flgInitForce) {
+		return fmt.Errorf("init: the file %s already exists, use --%s to overwrite it", filename, flgInitForce)
+	}
+
+	w := &wizard{in: bufio.NewReader(os.Stdin), out: ctx.App.Writer}
+
+	config, err := w.run()
+	if err != nil {
+		return fmt.Errorf("init: %w", err)
+	}
+
+	err = writeConfigFile(filename, config)
+	if err != nil {
+		return fmt.Errorf("init: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(ctx.App.Writer, "\nThe configuration has been written to %s.\n", filename)
+	_, _ = fmt.Fprintf(ctx.App.Writer, "To obtain the certificate, run:\n\n\tlego --%s %s run\n", flgConfig, filename)
+
+	return nil
+}
+
+func writeConfigFile(filename string, config *configFile) error {
+	buf := &bytes.Buffer{}
+
+	var err error
+
+	if isYAMLFile(filename) {
+		err = yaml.NewEncoder(buf).Encode(config)
+	} else {
+		err = toml.NewEncoder(buf).Encode(config)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// The file can contain the credentials of the DNS provider.
+	return os.WriteFile(filename, buf.Bytes(), filePerm)
+}
+
+// wizard asks the questions of the init command.
+type wizard struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func (w *wizard) run() (*configFile, error) {
+	config := &configFile{}
+
+	var err error
+
+	config.Email, err = w.ask("Email address (used for the account and the expiration notices)", "")
+	if err != nil {
+		return nil, err
+	}
+
+	config.Server, err = w.askServer()
+	if err != nil {
+		return nil, err
+	}
+
+	config.AcceptTOS, err = w.confirm("Do you accept the terms of service of the CA?", true)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.AcceptTOS {
+		return nil, errors.New("the terms of service must be accepted")
+	}
+
+	config.KeyType, err = w.choose("Key type", []string{"ec256", "ec384", "rsa2048", "rsa3072", "rsa4096", "rsa8192"}, "ec256")
+	if err != nil {
+		return nil, err
+	}
+
+	domains, err := w.ask("Domains (separated by spaces, can be defined later)", "")
+	if err != nil {
+		return nil, err
+	}
+
+	config.Domains = strings.Fields(domains)
+
+	challenge, err := w.choose("Challenge type", []string{"http", "tls", "dns"}, "http")
+	if err != nil {
+		return nil, err
+	}
+
+	switch challenge {
+	case "http":
+		config.HTTP = true
+
+		w.checkPort("80", "HTTP-01")
+
+	case "tls":
+		config.TLS = true
+
+		w.checkPort("443", "TLS-ALPN-01")
+
+	case "dns":
+		config.DNS, config.Env, err = w.askDNSProvider()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+func (w *wizard) askServer() (string, error) {
+	choices := []string{"letsencrypt", "letsencrypt-staging", "custom"}
+
+	ca, err := w.choose("Certificate Authority", choices, "letsencrypt")
+	if err != nil {
+		return "", err
+	}
+
+	switch ca {
+	case "letsencrypt":
+		return lego.LEDirectoryProduction, nil
+	case "letsencrypt-staging":
+		return lego.LEDirectoryStaging, nil
+	default:
+		return w.ask("ACME directory URL", "")
+	}
+}
+
+// askDNSProvider asks for the DNS provider and its credentials,
+// and checks the credentials by creating the provider (preflight).
+func (w *wizard) askDNSProvider() (string, map[string]string, error) {
+	codes := strings.Split(allDNSCodes(), ", ")
+
+	for {
+		code, err := w.ask("DNS provider code (see 'lego dnshelp')", "")
+		if err != nil {
+			return "", nil, err
+		}
+
+		code = strings.ToLower(code)
+
+		if !slices.Contains(codes, code) {
+			w.println("Unknown DNS provider:", code)
+			continue
+		}
+
+		credentials, err := dnsCredentials(code)
+		if err != nil {
+			return "", nil, err
+		}
+
+		envs := map[string]string{}
+
+		for _, name := range credentials {
+			value, errA := w.ask(name+" (leave empty if not needed)", os.Getenv(name))
+			if errA != nil {
+				return "", nil, errA
+			}
+
+			if value != "" {
+				envs[name] = value
+			}
+		}
+
+		err = preflightDNSProvider(code, envs)
+		if err == nil {
+			w.println("The DNS provider configuration is valid.")
+
+			return code, envs, nil
+		}
+
+		w.println("The DNS provider configuration is invalid:", err)
+
+		retry, err := w.confirm("Try again?", true)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if !retry {
+			return code, envs, nil
+		}
+	}
+}
+
+// checkPort warns if the port of the challenge is not available.
+func (w *wizard) checkPort(port, challenge string) {
+	listener, err := net.Listen("tcp", net.JoinHostPort("", port))
+	if err != nil {
+		w.println(fmt.Sprintf("Warning: the port %s used by the %s challenge is not available: %v", port, challenge, err))
+		return
+	}
+
+	_ = listener.Close()
+}
+
+func (w *wizard) ask(question, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		_, _ = fmt.Fprintf(w.out, "%s [%s]: ", question, defaultValue)
+	} else {
+		_, _ = fmt.Fprintf(w.out, "%s: ", question)
+	}
+
+	text, err := w.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || text == "") {
+		return "", fmt.Errorf("could not read from console: %w", err)
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return defaultValue, nil
+	}
+
+	return text, nil
+}
+
+func (w *wizard) choose(question string, choices []string, defaultValue string) (string, error) {
+	for {
+		answer, err := w.ask(fmt.Sprintf("%s (%s)", question, strings.Join(choices, ", ")), defaultValue)
+		if err != nil {
+			return "", err
+		}
+
+		answer = strings.ToLower(answer)
+
+		if slices.Contains(choices, answer) {
+			return answer, nil
+		}
+
+		if i, errA := strconv.Atoi(answer); errA == nil && i > 0 && i <= len(choices) {
+			return choices[i-1], nil
+		}
+
+		w.println("Your input was invalid. Please answer with one of:", strings.Join(choices, ", "))
+	}
+}
+
+func (w *wizard) confirm(question string, defaultValue bool) (bool, error) {
+	def := "n"
+	if defaultValue {
+		def = "y"
+	}
+
+	answer, err := w.choose(question, []string{"y", "n"}, def)
+	if err != nil {
+		return false, err
+	}
+
+	return answer == "y", nil
+}
+
+func (w *wizard) println(a ...any) {
+	_, _ = fmt.Fprintln(w.out, a...)
+}
+
+var credentialPattern = regexp.MustCompile(`^\s*- "([A-Z0-9_]+)":`)
+
+// dnsCredentials returns the names of the environment variables of the credentials of the DNS provider.
+func dnsCredentials(code string) ([]string, error) {
+	buf := &bytes.Buffer{}
+
+	err := displayDNSHelp(buf, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		names   []string
+		inBlock bool
+	)
+
+	for line := range strings.Lines(buf.String()) {
+		switch {
+		case strings.HasPrefix(line, "Credentials:"):
+			inBlock = true
+		case strings.TrimSpace(line) == "":
+			inBlock = false
+		case inBlock:
+			if match := credentialPattern.FindStringSubmatch(line); match != nil {
+				names = append(names, match[1])
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// preflightDNSProvider creates the DNS provider with the credentials to check the configuration.
+func preflightDNSProvider(code string, envs map[string]string) error {
+	for name, value := range envs {
+		restore, exists := os.LookupEnv(name)
+
+		_ = os.Setenv(name, value)
+
+		defer func() {
+			if exists {
+				_ = os.Setenv(name, restore)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		}()
+	}
+
+	_, err := dns.NewDNSChallengeProviderByName(code)
+
+	return err
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_wizard_run(t *testing.T) {
+	input := strings.Join([]string{
+		"you@example.com",
+		"letsencrypt-staging",
+		"",
+		"rsa4096",
+		"example.com www.example.com",
+		"dns",
+		"unknown",
+		"manual",
+	}, "\n") + "\n"
+
+	w := &wizard{in: bufio.NewReader(strings.NewReader(input)), out: io.Discard}
+
+	config, err := w.run()
+	require.NoError(t, err)
+
+	expected := &configFile{
+		Email:     "you@example.com",
+		Server:    lego.LEDirectoryStaging,
+		AcceptTOS: true,
+		KeyType:   "rsa4096",
+		Domains:   []string{"example.com", "www.example.com"},
+		DNS:       "manual",
+		Env:       map[string]string{},
+	}
+
+	assert.Equal(t, expected, config)
+}
+
+func Test_wizard_run_tosRefused(t *testing.T) {
+	input := "you@example.com\n\nn\n"
+
+	w := &wizard{in: bufio.NewReader(strings.NewReader(input)), out: io.Discard}
+
+	_, err := w.run()
+	require.EqualError(t, err, "the terms of service must be accepted")
+}
+
+func Test_dnsCredentials(t *testing.T) {
+	credentials, err := dnsCredentials("gandiv5")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"GANDIV5_API_KEY", "GANDIV5_PERSONAL_ACCESS_TOKEN"}, credentials)
+}
@@ -1,28 +1,53 @@
 package cmd
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
 	"github.com/urfave/cli/v2"
 )
 
 const (
-	flgAccounts = "accounts"
-	flgNames    = "names"
+	flgAccounts       = "accounts"
+	flgNames          = "names"
+	flgARI            = "ari"
+	flgExpiringWithin = "expiring-within"
+	flgSort           = "sort"
+	flgListOutput     = "output"
+)
+
+// Output formats of the list command.
+const (
+	listFormatText  = "text"
+	listFormatTable = "table"
+	listFormatJSON  = "json"
 )
 
 func createList() *cli.Command {
 	return &cli.Command{
-		Name:   "list",
-		Usage:  "Display certificates and accounts information.",
-		Action: list,
+		Name:      "list",
+		Usage:     "Display certificates and accounts information.",
+		ArgsUsage: "[domain patterns (e.g. '*.example.com'): only the certificates with a matching domain]",
+		Action:    list,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:    flgAccounts,
@@ -34,6 +59,24 @@ func createList() *cli.Command {
 				Aliases: []string{"n"},
 				Usage:   "Display certificate common names only.",
 			},
+			&cli.BoolFlag{
+				Name:  flgARI,
+				Usage: "Display the renewal window suggested by the CA (ARI). Requires a request to the CA (--server) by certificate.",
+			},
+			&cli.StringFlag{
+				Name:  flgExpiringWithin,
+				Usage: "Only display the certificates expiring within this duration (e.g. 20d, 72h).",
+			},
+			&cli.StringFlag{
+				Name:  flgSort,
+				Usage: "Sort the certificates: name, expiry.",
+				Value: "name",
+			},
+			&cli.StringFlag{
+				Name:  flgListOutput,
+				Usage: fmt.Sprintf("The output format: %s, %s, %s (same as --%s).", listFormatText, listFormatTable, listFormatJSON, flgJSON),
+				Value: listFormatText,
+			},
 			// fake email, needed by NewAccountsStorage
 			&cli.StringFlag{
 				Name:   flgEmail,
@@ -45,6 +88,26 @@ func createList() *cli.Command {
 }
 
 func list(ctx *cli.Context) error {
+	format := ctx.String(flgListOutput)
+	if ctx.Bool(flgJSON) {
+		format = listFormatJSON
+	}
+
+	switch format {
+	case listFormatJSON:
+		return listJSON(ctx)
+
+	case listFormatTable:
+		if !ctx.Bool(flgNames) {
+			return listTable(ctx)
+		}
+
+	case listFormatText:
+
+	default:
+		return fmt.Errorf("--%s: unsupported value: %q", flgListOutput, format)
+	}
+
 	if ctx.Bool(flgAccounts) && !ctx.Bool(flgNames) {
 		if err := listAccount(ctx); err != nil {
 			return err
@@ -54,17 +117,135 @@ func list(ctx *cli.Context) error {
 	return listCertificates(ctx)
 }
 
-func listCertificates(ctx *cli.Context) error {
-	certsStorage := NewCertificatesStorage(ctx)
+// listTable displays the certificates and the accounts as tables (--output table).
+func listTable(ctx *cli.Context) error {
+	if ctx.Bool(flgAccounts) {
+		accounts, err := readAccounts(ctx)
+		if err != nil {
+			return err
+		}
 
-	matches, err := filepath.Glob(filepath.Join(certsStorage.GetRootPath(), "*.crt"))
+		err = writeAccountsTable(os.Stdout, accounts)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+	}
+
+	certsStorage, err := NewCertificatesStorage(ctx)
 	if err != nil {
 		return err
 	}
 
+	infos, err := readCertificateInfos(ctx, certsStorage, ctx.Bool(flgARI))
+	if err != nil {
+		return err
+	}
+
+	return writeCertificatesTable(os.Stdout, infos, ctx.Bool(flgARI))
+}
+
+func writeCertificatesTable(w io.Writer, infos []certificateInfo, ari bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := "NAME\tDOMAINS\tKEY TYPE\tISSUER\tEXPIRY DATE\tDAYS LEFT"
+	if ari {
+		header += "\tARI WINDOW"
+	}
+
+	_, _ = fmt.Fprintln(tw, header)
+
+	for _, info := range infos {
+		row := strings.Join([]string{
+			info.name,
+			strings.Join(certificateDomains(info.cert), ","),
+			formatKeyType(info.cert),
+			info.cert.Issuer.CommonName,
+			info.cert.NotAfter.UTC().Format(time.RFC3339),
+			formatDaysLeftShort(info.daysLeft),
+		}, "\t")
+
+		if ari {
+			row += "\t" + formatARIWindow(info.ariWindow)
+		}
+
+		_, _ = fmt.Fprintln(tw, row)
+	}
+
+	return tw.Flush()
+}
+
+func writeAccountsTable(w io.Writer, accounts []accountOutput) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "EMAIL\tSERVER\tPATH")
+
+	for _, account := range accounts {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", account.Email, account.Server, account.Path)
+	}
+
+	return tw.Flush()
+}
+
+// listJSON displays the certificates and the accounts as JSON (--json).
+func listJSON(ctx *cli.Context) error {
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	infos, err := readCertificateInfos(ctx, certsStorage, ctx.Bool(flgARI))
+	if err != nil {
+		return err
+	}
+
+	output := listOutput{Certificates: []*certificateOutput{}}
+
+	for _, info := range infos {
+		// The files are named after the domain of the stored certificate.
+		certificate := newCertificateOutput(certsStorage, strings.TrimSuffix(filepath.Base(info.path), certExt), info.cert)
+		certificate.Name = info.name
+		certificate.ARIWindow = info.ariWindow
+		certificate.DaysLeft = &info.daysLeft
+
+		output.Certificates = append(output.Certificates, certificate)
+	}
+
+	if ctx.Bool(flgAccounts) {
+		output.Accounts, err = readAccounts(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeJSON(output)
+}
+
+// certificateInfo the information about a stored certificate.
+type certificateInfo struct {
+	name     string
+	path     string
+	cert     *x509.Certificate
+	daysLeft int
+
+	ariWindow *acme.Window
+}
+
+func listCertificates(ctx *cli.Context) error {
 	names := ctx.Bool(flgNames)
 
-	if len(matches) == 0 {
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	infos, err := readCertificateInfos(ctx, certsStorage, ctx.Bool(flgARI) && !names)
+	if err != nil {
+		return err
+	}
+
+	if len(infos) == 0 {
 		if !names {
 			fmt.Println("No certificates found.")
 		}
@@ -76,85 +257,298 @@ func listCertificates(ctx *cli.Context) error {
 		fmt.Println("Found the following certs:")
 	}
 
+	for _, info := range infos {
+		if names {
+			fmt.Println(info.name)
+			continue
+		}
+
+		fmt.Println("  Certificate Name:", info.name)
+		fmt.Println("    Domains:", strings.Join(info.cert.DNSNames, ", "))
+
+		if len(info.cert.IPAddresses) > 0 {
+			fmt.Println("    IPs:", formatIPAddresses(info.cert.IPAddresses))
+		}
+
+		fmt.Println("    Key Type:", formatKeyType(info.cert))
+		fmt.Println("    Issuer:", info.cert.Issuer.CommonName)
+		fmt.Printf("    Expiry Date: %s (%s)\n", info.cert.NotAfter, formatDaysLeft(info.daysLeft))
+
+		if info.ariWindow != nil {
+			fmt.Println("    ARI Window:", formatARIWindow(info.ariWindow))
+		}
+
+		fmt.Println("    Certificate Path:", info.path)
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// readCertificateInfos reads the stored certificates, filtered (domain patterns, --expiring-within) and sorted (--sort).
+func readCertificateInfos(ctx *cli.Context, certsStorage *CertificatesStorage, ari bool) ([]certificateInfo, error) {
+	matches, err := filepath.Glob(filepath.Join(certsStorage.GetRootPath(), "*.crt"))
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := ctx.Args().Slice()
+
+	for _, pattern := range patterns {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid domain pattern %q: %w", pattern, err)
+		}
+	}
+
+	var expiringWithin time.Duration
+
+	if ctx.IsSet(flgExpiringWithin) {
+		expiringWithin, err = parseDays(ctx.String(flgExpiringWithin))
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", flgExpiringWithin, err)
+		}
+	}
+
+	now := time.Now()
+
+	var infos []certificateInfo
+
 	for _, filename := range matches {
-		if strings.HasSuffix(filename, issuerExt) {
+		// The issuer and the chains (--all-chains) are not stored certificates.
+		if strings.HasSuffix(filename, issuerExt) || strings.Contains(filepath.Base(filename), chainExt) {
 			continue
 		}
 
 		data, err := os.ReadFile(filename)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		pCert, err := certcrypto.ParsePEMCertificate(data)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		name, err := certcrypto.GetCertificateMainDomain(pCert)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		if names {
-			fmt.Println(name)
-		} else {
-			fmt.Println("  Certificate Name:", name)
-			fmt.Println("    Domains:", strings.Join(pCert.DNSNames, ", "))
+		// The certificate stored with a name (--cert-name) is displayed with its name.
+		baseName := strings.TrimSuffix(filepath.Base(filename), certExt)
+		if safe, errS := sanitizedDomain(name); errS != nil || baseName != safe {
+			name = baseName
+		}
 
-			if len(pCert.IPAddresses) > 0 {
-				fmt.Println("    IPs:", formatIPAddresses(pCert.IPAddresses))
-			}
+		if ctx.IsSet(flgCertName) && name != ctx.String(flgCertName) {
+			continue
+		}
+
+		if ctx.IsSet(flgExpiringWithin) && pCert.NotAfter.After(now.Add(expiringWithin)) {
+			continue
+		}
+
+		if len(patterns) > 0 && !matchDomains(pCert, patterns) {
+			continue
+		}
+
+		infos = append(infos, certificateInfo{
+			name:     name,
+			path:     filename,
+			cert:     pCert,
+			daysLeft: int(pCert.NotAfter.Sub(now).Hours() / 24),
+		})
+	}
+
+	err = sortCertificateInfos(infos, ctx.String(flgSort))
+	if err != nil {
+		return nil, err
+	}
+
+	if ari && len(infos) > 0 {
+		addARIWindows(ctx, infos)
+	}
+
+	return infos, nil
+}
+
+// addARIWindows gets the renewal windows suggested by the CA (ARI).
+func addARIWindows(ctx *cli.Context, infos []certificateInfo) {
+	// The renewalInfo endpoint doesn't require an account.
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	if err != nil {
+		log.Fatalf("Could not generate the private key: %v", err)
+	}
 
-			fmt.Println("    Expiry Date:", pCert.NotAfter)
-			fmt.Println("    Certificate Path:", filename)
-			fmt.Println()
+	client := newClient(ctx, getServer(ctx), &Account{key: privateKey}, certcrypto.EC256)
+
+	for i, info := range infos {
+		renewalInfo, err := client.Certificate.GetRenewalInfo(certificate.RenewalInfoRequest{Cert: info.cert})
+		if err != nil {
+			log.Warnf("[%s] acme: calling renewal info endpoint: %v", info.name, err)
+			continue
 		}
+
+		infos[i].ariWindow = &renewalInfo.SuggestedWindow
+	}
+}
+
+func sortCertificateInfos(infos []certificateInfo, sortBy string) error {
+	switch sortBy {
+	case "name":
+		slices.SortStableFunc(infos, func(a, b certificateInfo) int {
+			return strings.Compare(a.name, b.name)
+		})
+
+	case "expiry":
+		slices.SortStableFunc(infos, func(a, b certificateInfo) int {
+			return a.cert.NotAfter.Compare(b.cert.NotAfter)
+		})
+
+	default:
+		return fmt.Errorf("--%s: unsupported value: %q", flgSort, sortBy)
 	}
 
 	return nil
 }
 
-func listAccount(ctx *cli.Context) error {
-	accountsStorage := NewAccountsStorage(ctx)
+// parseDays parses a duration, with the support of days (e.g. 20d).
+func parseDays(value string) (time.Duration, error) {
+	if raw, ok := strings.CutSuffix(value, "d"); ok {
+		days, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %q", value)
+		}
 
-	matches, err := filepath.Glob(filepath.Join(accountsStorage.GetRootPath(), "*", "*", "*.json"))
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+// matchDomains returns true if a domain (or an IP address) of the certificate matches one of the patterns.
+func matchDomains(cert *x509.Certificate, patterns []string) bool {
+	for _, domain := range certificateDomains(cert) {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(domain)); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// certificateDomains returns the domains and the IP addresses of the certificate.
+func certificateDomains(cert *x509.Certificate) []string {
+	domains := slices.Clone(cert.DNSNames)
+	for _, ip := range cert.IPAddresses {
+		domains = append(domains, ip.String())
+	}
+
+	return domains
+}
+
+func formatDaysLeftShort(days int) string {
+	if days < 0 {
+		return "expired"
+	}
+
+	return strconv.Itoa(days)
+}
+
+func formatARIWindow(window *acme.Window) string {
+	if window == nil {
+		return "-"
+	}
+
+	return window.Start.Format(time.RFC3339) + " - " + window.End.Format(time.RFC3339)
+}
+
+func formatDaysLeft(days int) string {
+	if days < 0 {
+		return "expired"
+	}
+
+	return fmt.Sprintf("%d days left", days)
+}
+
+func formatKeyType(cert *x509.Certificate) string {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA %d", key.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "ECDSA " + key.Curve.Params().Name
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return cert.PublicKeyAlgorithm.String()
+	}
+}
+
+func listAccount(ctx *cli.Context) error {
+	accounts, err := readAccounts(ctx)
 	if err != nil {
 		return err
 	}
 
-	if len(matches) == 0 {
+	if len(accounts) == 0 {
 		fmt.Println("No accounts found.")
 		return nil
 	}
 
 	fmt.Println("Found the following accounts:")
 
+	for _, account := range accounts {
+		fmt.Println("  Email:", account.Email)
+		fmt.Println("  Server:", account.Server)
+		fmt.Println("  Path:", account.Path)
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// readAccounts reads the stored accounts.
+func readAccounts(ctx *cli.Context) ([]accountOutput, error) {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := filepath.Glob(filepath.Join(accountsStorage.GetRootPath(), "*", "*", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []accountOutput
+
 	for _, filename := range matches {
 		data, err := os.ReadFile(filename)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		var account Account
 
 		err = json.Unmarshal(data, &account)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		uri, err := url.Parse(account.Registration.URI)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		fmt.Println("  Email:", account.Email)
-		fmt.Println("  Server:", uri.Host)
-		fmt.Println("  Path:", filepath.Dir(filename))
-		fmt.Println()
+		accounts = append(accounts, accountOutput{
+			Email:  account.Email,
+			Server: uri.Host,
+			Path:   filepath.Dir(filename),
+		})
 	}
 
-	return nil
+	return accounts, nil
 }
 
 func formatIPAddresses(ipAddresses []net.IP) string {
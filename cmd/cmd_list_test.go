@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseDays(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "20d", expected: 20 * 24 * time.Hour},
+		{value: "72h", expected: 72 * time.Hour},
+		{value: "0d", expected: 0},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			duration, err := parseDays(test.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, duration)
+		})
+	}
+}
+
+func Test_parseDays_error(t *testing.T) {
+	_, err := parseDays("twod")
+	require.EqualError(t, err, `invalid duration: "twod"`)
+}
+
+func Test_sortCertificateInfos(t *testing.T) {
+	now := time.Now()
+
+	infos := []certificateInfo{
+		{name: "b.example.com", cert: &x509.Certificate{NotAfter: now.Add(time.Hour)}},
+		{name: "c.example.com", cert: &x509.Certificate{NotAfter: now.Add(3 * time.Hour)}},
+		{name: "a.example.com", cert: &x509.Certificate{NotAfter: now.Add(2 * time.Hour)}},
+	}
+
+	err := sortCertificateInfos(infos, "name")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a.example.com", "b.example.com", "c.example.com"}, infoNames(infos))
+
+	err = sortCertificateInfos(infos, "expiry")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"b.example.com", "a.example.com", "c.example.com"}, infoNames(infos))
+
+	err = sortCertificateInfos(infos, "foo")
+	require.EqualError(t, err, `--sort: unsupported value: "foo"`)
+}
+
+func infoNames(infos []certificateInfo) []string {
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.name)
+	}
+
+	return names
+}
+
+func Test_formatKeyType(t *testing.T) {
+	cert, _ := createTestCertificate(t, "example.com", false, nil, nil)
+
+	assert.Equal(t, "ECDSA P-256", formatKeyType(cert))
+}
+
+func Test_matchDomains(t *testing.T) {
+	cert := &x509.Certificate{
+		DNSNames:    []string{"www.example.com", "example.com"},
+		IPAddresses: []net.IP{net.ParseIP("192.0.2.1")},
+	}
+
+	testCases := []struct {
+		desc     string
+		patterns []string
+		expected bool
+	}{
+		{desc: "exact", patterns: []string{"example.com"}, expected: true},
+		{desc: "wildcard", patterns: []string{"*.example.com"}, expected: true},
+		{desc: "case", patterns: []string{"WWW.Example.com"}, expected: true},
+		{desc: "IP address", patterns: []string{"192.0.2.*"}, expected: true},
+		{desc: "one of the patterns", patterns: []string{"example.org", "example.com"}, expected: true},
+		{desc: "no match", patterns: []string{"*.example.org"}},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, matchDomains(cert, test.patterns))
+		})
+	}
+}
+
+func Test_writeCertificatesTable(t *testing.T) {
+	notAfter := time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	infos := []certificateInfo{
+		{
+			name: "example.com",
+			cert: &x509.Certificate{
+				DNSNames:           []string{"example.com", "www.example.com"},
+				Issuer:             pkix.Name{CommonName: "R10"},
+				NotAfter:           notAfter,
+				PublicKeyAlgorithm: x509.ECDSA,
+			},
+			daysLeft: 45,
+		},
+		{
+			name: "expired.example.org",
+			cert: &x509.Certificate{
+				DNSNames:           []string{"expired.example.org"},
+				IPAddresses:        []net.IP{net.ParseIP("192.0.2.1")},
+				Issuer:             pkix.Name{CommonName: "E5"},
+				NotAfter:           notAfter,
+				PublicKeyAlgorithm: x509.RSA,
+			},
+			daysLeft: -2,
+		},
+	}
+
+	buf := new(bytes.Buffer)
+
+	err := writeCertificatesTable(buf, infos, false)
+	require.NoError(t, err)
+
+	expected := `NAME                 DOMAINS                        KEY TYPE  ISSUER  EXPIRY DATE           DAYS LEFT
+example.com          example.com,www.example.com    ECDSA     R10     2026-12-01T00:00:00Z  45
+expired.example.org  expired.example.org,192.0.2.1  RSA       E5      2026-12-01T00:00:00Z  expired
+`
+
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgMigrateFrom  = "from"
+	flgMigrateForce = "force"
+)
+
+// Supported sources of the migration.
+const (
+	migrateFromCertbot = "certbot"
+	migrateFromAcmeSh  = "acme.sh"
+)
+
+// baseMigratedConfigsFolderName the directory (in the path) of the configuration files created from the renewal parameters.
+const baseMigratedConfigsFolderName = "migrated"
+
+func createMigrate() *cli.Command {
+	return &cli.Command{
+		Name:      "migrate",
+		Usage:     "Import the accounts, the private keys, the certificates, and the renewal parameters from certbot or acme.sh.",
+		ArgsUsage: "[directory (default: /etc/letsencrypt for certbot, ~/.acme.sh for acme.sh)]",
+		Action:    migrate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flgMigrateFrom,
+				Usage:    fmt.Sprintf("The client to import from: %s or %s.", migrateFromCertbot, migrateFromAcmeSh),
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  flgMigrateForce,
+				Usage: "Overwrite the existing certificates. By default, the certificates already stored are skipped.",
+			},
+		},
+	}
+}
+
+// migration the content imported from another client.
+type migration struct {
+	accounts     []migratedAccount
+	certificates []migratedCertificate
+}
+
+type migratedAccount struct {
+	server       string
+	email        string
+	key          crypto.PrivateKey
+	registration *registration.Resource
+}
+
+type migratedCertificate struct {
+	resource *certificate.Resource
+
+	// config the renewal parameters, using the keys of the configuration file (the global options).
+	config map[string]any
+}
+
+func migrate(ctx *cli.Context) error {
+	dir := ctx.Args().First()
+
+	var (
+		m   *migration
+		err error
+	)
+
+	switch ctx.String(flgMigrateFrom) {
+	case migrateFromCertbot:
+		if dir == "" {
+			dir = "/etc/letsencrypt"
+		}
+
+		m, err = readCertbot(dir, ctx.String(flgEmail))
+
+	case migrateFromAcmeSh:
+		if dir == "" {
+			home, errH := os.UserHomeDir()
+			if errH != nil {
+				return errH
+			}
+
+			dir = filepath.Join(home, ".acme.sh")
+		}
+
+		m, err = readAcmeSh(dir)
+
+	default:
+		return fmt.Errorf("unsupported client: %q (supported: %s, %s)", ctx.String(flgMigrateFrom), migrateFromCertbot, migrateFromAcmeSh)
+	}
+
+	if err != nil {
+		return fmt.Errorf("migrate from %s: %w", ctx.String(flgMigrateFrom), err)
+	}
+
+	if len(m.accounts) == 0 && len(m.certificates) == 0 {
+		return fmt.Errorf("migrate from %s: no account and no certificate found in %s", ctx.String(flgMigrateFrom), dir)
+	}
+
+	return applyMigration(ctx, m)
+}
+
+func applyMigration(ctx *cli.Context, m *migration) error {
+	for _, account := range m.accounts {
+		accountsStorage, err := newAccountsStorage(ctx, account.server, account.email)
+		if err != nil {
+			return fmt.Errorf("account %s: %w", account.email, err)
+		}
+
+		exists, err := accountsStorage.ExistsAccountFilePath()
+		if err != nil {
+			return fmt.Errorf("account %s: %w", account.email, err)
+		}
+
+		if exists {
+			log.Infof("The account %s (%s) already exists, skipped.", account.email, account.server)
+			continue
+		}
+
+		err = accountsStorage.SavePrivateKey(account.key)
+		if err != nil {
+			return fmt.Errorf("account %s: %w", account.email, err)
+		}
+
+		err = accountsStorage.Save(&Account{Email: account.email, Registration: account.registration})
+		if err != nil {
+			return fmt.Errorf("account %s: %w", account.email, err)
+		}
+
+		log.Infof("The account %s (%s) has been imported.", account.email, account.server)
+	}
+
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = certsStorage.CreateRootFolder()
+	if err != nil {
+		return err
+	}
+
+	configsPath := filepath.Join(ctx.String(flgPath), baseMigratedConfigsFolderName)
+
+	for _, cert := range m.certificates {
+		domain := cert.resource.Domain
+
+		exists, err := certsStorage.ExistsFile(domain, certExt)
+		if err != nil {
+			return fmt.Errorf("[%s] %w", domain, err)
+		}
+
+		if exists && !ctx.Bool(flgMigrateForce) {
+			log.Infof("[%s] The certificate already exists, skipped (use --%s to overwrite it).", domain, flgMigrateForce)
+			continue
+		}
+
+		certsStorage.SaveResource(cert.resource, &ResourceMetadata{})
+
+		err = createNonExistingFolder(configsPath)
+		if err != nil {
+			return err
+		}
+
+		name, err := sanitizedDomain(domain)
+		if err != nil {
+			return err
+		}
+
+		filename := filepath.Join(configsPath, name+".toml")
+
+		err = writeMigratedConfig(filename, cert.config)
+		if err != nil {
+			return fmt.Errorf("[%s] %w", domain, err)
+		}
+
+		log.Infof("[%s] The certificate has been imported, the renewal parameters are in %s.", domain, filename)
+	}
+
+	return nil
+}
+
+func writeMigratedConfig(filename string, config map[string]any) error {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	_, err = fmt.Fprintln(file, "# Imported renewal parameters, usage: lego --config "+filename+" renew")
+	if err != nil {
+		return err
+	}
+
+	return toml.NewEncoder(file).Encode(config)
+}
+
+// newMigratedResource creates the certificate resource from the PEM encoded files.
+// The name of the resource is the main domain of the certificate.
+func newMigratedResource(certPEM, chainPEM, keyPEM []byte) (*certificate.Resource, *x509.Certificate, error) {
+	certs, err := certcrypto.ParsePEMBundle(certPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("certificate: %w", err)
+	}
+
+	_, err = certcrypto.ParsePEMPrivateKey(keyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("private key: %w", err)
+	}
+
+	domain, err := certcrypto.GetCertificateMainDomain(certs[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &certificate.Resource{
+		Domain:     domain,
+		PrivateKey: keyPEM,
+		// The certificate is bundled with the issuers, like the certificates obtained by lego.
+		Certificate:       append(append([]byte{}, certcrypto.PEMEncode(certcrypto.DERCertificateBytes(certs[0].Raw))...), chainPEM...),
+		IssuerCertificate: chainPEM,
+	}
+
+	return res, certs[0], nil
+}
+
+// newMigratedConfig creates the configuration (the keys are the global options) from the certificate and the renewal parameters.
+func newMigratedConfig(cert *x509.Certificate, server, email string, challenge map[string]any) map[string]any {
+	config := map[string]any{
+		flgServer:  server,
+		flgDomains: certcrypto.ExtractDomains(cert),
+	}
+
+	if email != "" {
+		config[flgEmail] = email
+	}
+
+	if keyType := keyTypeOf(cert); keyType != "" {
+		config[flgKeyType] = keyType
+	}
+
+	maps.Copy(config, challenge)
+
+	return config
+}
+
+// keyTypeOf returns the lego key type (--key-type) of the key of the certificate.
+func keyTypeOf(cert *x509.Certificate) string {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("rsa%d", key.N.BitLen())
+	case *ecdsa.PublicKey:
+		switch key.Curve.Params().Name {
+		case "P-256":
+			return "ec256"
+		case "P-384":
+			return "ec384"
+		default:
+			return ""
+		}
+	default:
+		return ""
+	}
+}
+
+func readKeyValueFile(filename string) (map[string]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	return parseKeyValueFile(file)
+}
+
+// parseKeyValueFile parses the files of key/value pairs (e.g. the certbot renewal configuration, the acme.sh configuration):
+// `key = value`, `key='value'`, the sections (`[section]`, `[[section]]`) prefix the keys (`section.key`).
+func parseKeyValueFile(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+
+	var section string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[]") + "."
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		value = strings.Trim(value, `'"`)
+
+		values[section+strings.TrimSpace(key)] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func writeTestFile(t *testing.T, filename, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0o700))
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0o600))
+}
+
+// createTestCertificateFiles creates the PEM encoded certificate, issuer, and private key.
+func createTestCertificateFiles(t *testing.T) (certPEM, chainPEM, keyPEM string) {
+	t.Helper()
+
+	root, rootKey := createTestCertificate(t, "Root", true, nil, nil)
+	leaf, leafKey := createTestCertificate(t, "example.com", false, root, rootKey)
+
+	return string(certcrypto.PEMEncode(certcrypto.DERCertificateBytes(leaf.Raw))),
+		string(certcrypto.PEMEncode(certcrypto.DERCertificateBytes(root.Raw))),
+		string(certcrypto.PEMEncode(leafKey))
+}
+
+func createCertbotLayout(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwk, err := jose.JSONWebKey{Key: accountKey}.MarshalJSON()
+	require.NoError(t, err)
+
+	accountDir := filepath.Join(dir, "accounts", "acme-v02.api.letsencrypt.org", "directory", "abc123")
+
+	writeTestFile(t, filepath.Join(accountDir, "private_key.json"), string(jwk))
+	writeTestFile(t, filepath.Join(accountDir, "regr.json"),
+		`{"body": {"contact": ["mailto:certbot@example.com"]}, "uri": "https://acme-v02.api.letsencrypt.org/acme/acct/123"}`)
+
+	certPEM, chainPEM, keyPEM := createTestCertificateFiles(t)
+
+	writeTestFile(t, filepath.Join(dir, "live", "example.com", "cert.pem"), certPEM)
+	writeTestFile(t, filepath.Join(dir, "live", "example.com", "chain.pem"), chainPEM)
+	writeTestFile(t, filepath.Join(dir, "live", "example.com", "privkey.pem"), keyPEM)
+
+	writeTestFile(t, filepath.Join(dir, "renewal", "example.com.conf"), `# renew_before_expiry = 30 days
+version = 2.11.0
+archive_dir = /etc/letsencrypt/archive/example.com
+cert = /etc/letsencrypt/live/example.com/cert.pem
+
+# Options used in the renewal process
+[renewalparams]
+account = abc123
+authenticator = dns-cloudflare
+dns_cloudflare_credentials = /root/.secrets/cloudflare.ini
+server = https://acme-v02.api.letsencrypt.org/directory
+key_type = ecdsa
+`)
+
+	return dir
+}
+
+func Test_readCertbot(t *testing.T) {
+	dir := createCertbotLayout(t)
+
+	m, err := readCertbot(dir, "")
+	require.NoError(t, err)
+
+	require.Len(t, m.accounts, 1)
+
+	account := m.accounts[0]
+	assert.Equal(t, "certbot@example.com", account.email)
+	assert.Equal(t, "https://acme-v02.api.letsencrypt.org/directory", account.server)
+	assert.Equal(t, "https://acme-v02.api.letsencrypt.org/acme/acct/123", account.registration.URI)
+	assert.Equal(t, "valid", account.registration.Body.Status)
+	assert.IsType(t, &rsa.PrivateKey{}, account.key)
+
+	require.Len(t, m.certificates, 1)
+
+	cert := m.certificates[0]
+	assert.Equal(t, "example.com", cert.resource.Domain)
+
+	expected := map[string]any{
+		"server":   "https://acme-v02.api.letsencrypt.org/directory",
+		"email":    "certbot@example.com",
+		"domains":  []string{"example.com"},
+		"key-type": "ec256",
+		"dns":      "cloudflare",
+	}
+
+	assert.Equal(t, expected, cert.config)
+}
+
+func Test_readAcmeSh(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(dir, "account.conf"), "ACCOUNT_EMAIL='acmesh@example.com'\n")
+
+	_, accountKey := createTestCertificate(t, "account", false, nil, nil)
+
+	caDir := filepath.Join(dir, "ca", "acme-v02.api.letsencrypt.org", "directory")
+	writeTestFile(t, filepath.Join(caDir, "account.key"), string(certcrypto.PEMEncode(accountKey)))
+	writeTestFile(t, filepath.Join(caDir, "ca.conf"), "ACCOUNT_URL='https://acme-v02.api.letsencrypt.org/acme/acct/456'\n")
+
+	certPEM, chainPEM, keyPEM := createTestCertificateFiles(t)
+
+	certDir := filepath.Join(dir, "example.com_ecc")
+	writeTestFile(t, filepath.Join(certDir, "example.com.cer"), certPEM)
+	writeTestFile(t, filepath.Join(certDir, "ca.cer"), chainPEM)
+	writeTestFile(t, filepath.Join(certDir, "example.com.key"), keyPEM)
+	writeTestFile(t, filepath.Join(certDir, "example.com.conf"), `Le_Domain='example.com'
+Le_Alt='no'
+Le_Webroot='/var/www/html'
+Le_Keylength='ec-256'
+Le_API='https://acme-v02.api.letsencrypt.org/directory'
+`)
+
+	m, err := readAcmeSh(dir)
+	require.NoError(t, err)
+
+	require.Len(t, m.accounts, 1)
+
+	account := m.accounts[0]
+	assert.Equal(t, "acmesh@example.com", account.email)
+	assert.Equal(t, "https://acme-v02.api.letsencrypt.org/directory", account.server)
+	assert.Equal(t, "https://acme-v02.api.letsencrypt.org/acme/acct/456", account.registration.URI)
+
+	require.Len(t, m.certificates, 1)
+
+	expected := map[string]any{
+		"server":       "https://acme-v02.api.letsencrypt.org/directory",
+		"email":        "acmesh@example.com",
+		"domains":      []string{"example.com"},
+		"key-type":     "ec256",
+		"http":         true,
+		"http.webroot": "/var/www/html",
+	}
+
+	assert.Equal(t, expected, m.certificates[0].config)
+}
+
+func Test_migrate(t *testing.T) {
+	dir := createCertbotLayout(t)
+	path := t.TempDir()
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags(path)
+	app.Commands = CreateCommands()
+
+	err := app.Run([]string{"lego", "migrate", "--from", "certbot", dir})
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(path, "accounts", "acme-v02.api.letsencrypt.org", "certbot@example.com", "account.json"))
+	assert.FileExists(t, filepath.Join(path, "accounts", "acme-v02.api.letsencrypt.org", "certbot@example.com", "keys", "certbot@example.com.key"))
+	assert.FileExists(t, filepath.Join(path, "certificates", "example.com.crt"))
+	assert.FileExists(t, filepath.Join(path, "certificates", "example.com.key"))
+	assert.FileExists(t, filepath.Join(path, "certificates", "example.com.issuer.crt"))
+
+	configFile := filepath.Join(path, "migrated", "example.com.toml")
+	assert.FileExists(t, configFile)
+
+	// The configuration file is usable.
+	ctx, err := runWithConfig(t, readTestFile(t, configFile))
+	require.NoError(t, err)
+
+	assert.Equal(t, "cloudflare", ctx.String(flgDNS))
+	assert.Equal(t, []string{"example.com"}, ctx.StringSlice(flgDomains))
+}
+
+func readTestFile(t *testing.T, filename string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	return string(content)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgOrdersLimit = "limit"
+)
+
+func createOrders() *cli.Command {
+	return &cli.Command{
+		Name:  "orders",
+		Usage: "Inspect the orders of the account on the CA",
+		Subcommands: []*cli.Command{
+			{
+				Name: "list",
+				Usage: "Display the orders of the account (status, identifiers, expiry, error) fetched from the CA." +
+					" Helps to find the pending orders and to debug the rate limits. The CA must provide the list of the orders.",
+				Action: ordersList,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  flgOrdersLimit,
+						Usage: "The maximum number of orders to display (0 means no limit).",
+						Value: 20,
+					},
+				},
+			},
+		},
+	}
+}
+
+func ordersList(ctx *cli.Context) error {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, keyType, err := setupAccount(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
+
+	if account.Registration == nil {
+		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+	}
+
+	client := newClient(ctx, account.server, account, keyType)
+
+	orders, err := client.Registration.ListOrders(ctx.Int(flgOrdersLimit))
+	if err != nil {
+		return fmt.Errorf("list orders: %w", err)
+	}
+
+	return writeOrders(ctx.App.Writer, orders)
+}
+
+// writeOrders writes the information about the orders.
+func writeOrders(w io.Writer, orders []acme.ExtendedOrder) error {
+	ew := &errWriter{w: w}
+
+	if len(orders) == 0 {
+		ew.writeln("No orders found.")
+		return ew.err
+	}
+
+	ew.writeln("Found the following orders:")
+
+	for _, order := range orders {
+		var identifiers []string
+		for _, identifier := range order.Identifiers {
+			identifiers = append(identifiers, identifier.Value)
+		}
+
+		ew.writef("  Order: %s\n", order.Location)
+		ew.writef("    Status: %s\n", order.Status)
+		ew.writef("    Identifiers: %s\n", strings.Join(identifiers, ", "))
+
+		if order.Expires != "" {
+			ew.writef("    Expires: %s\n", order.Expires)
+		}
+
+		if order.Profile != "" {
+			ew.writef("    Profile: %s\n", order.Profile)
+		}
+
+		if order.Error != nil {
+			ew.writef("    Error: %s\n", order.Error)
+		}
+
+		if order.Certificate != "" {
+			ew.writef("    Certificate: %s\n", order.Certificate)
+		}
+
+		ew.writeln("")
+	}
+
+	return ew.err
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writeOrders(t *testing.T) {
+	orders := []acme.ExtendedOrder{
+		{
+			Order: acme.Order{
+				Status:      acme.StatusPending,
+				Expires:     "2025-01-08T00:00:00Z",
+				Identifiers: []acme.Identifier{{Type: "dns", Value: "example.com"}, {Type: "dns", Value: "www.example.com"}},
+			},
+			Location: "https://acme.example.com/order/1",
+		},
+		{
+			Order: acme.Order{
+				Status:      acme.StatusInvalid,
+				Identifiers: []acme.Identifier{{Type: "dns", Value: "example.org"}},
+				Error:       &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:unauthorized", Detail: "boom", HTTPStatus: 403},
+			},
+			Location: "https://acme.example.com/order/2",
+		},
+	}
+
+	buf := &bytes.Buffer{}
+
+	err := writeOrders(buf, orders)
+	require.NoError(t, err)
+
+	expected := `Found the following orders:
+  Order: https://acme.example.com/order/1
+    Status: pending
+    Identifiers: example.com, www.example.com
+    Expires: 2025-01-08T00:00:00Z
+
+  Order: https://acme.example.com/order/2
+    Status: invalid
+    Identifiers: example.org
+    Error: acme: error: 403 :: urn:ietf:params:acme:error:unauthorized :: boom
+
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func Test_writeOrders_empty(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	err := writeOrders(buf, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "No orders found.\n", buf.String())
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/urfave/cli/v2"
+)
+
+func createProfiles() *cli.Command {
+	return &cli.Command{
+		Name: "profiles",
+		Usage: "Display the certificate profiles advertised by the CA (draft-ietf-acme-profiles)," +
+			" the valid values of the --profile option of the run and renew commands.",
+		Action: profiles,
+	}
+}
+
+func profiles(ctx *cli.Context) error {
+	// Only the directory is fetched: a temporary key is enough, no account is needed.
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	if err != nil {
+		return err
+	}
+
+	account := &Account{Email: ctx.String(flgEmail), key: privateKey}
+
+	for _, server := range getServers(ctx) {
+		client, err := lego.NewClient(newClientConfig(ctx, server, account, certcrypto.EC256))
+		if err != nil {
+			return fmt.Errorf("could not create client: %w", err)
+		}
+
+		err = writeProfiles(ctx.App.Writer, server, client.GetProfiles())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeProfiles writes the certificate profiles of the CA, sorted by name.
+func writeProfiles(w io.Writer, server string, profiles map[string]string) error {
+	ew := &errWriter{w: w}
+
+	if len(profiles) == 0 {
+		ew.writef("The CA %s doesn't advertise certificate profiles.\n", server)
+		return ew.err
+	}
+
+	ew.writef("Profiles of the CA %s:\n", server)
+
+	for _, name := range slices.Sorted(maps.Keys(profiles)) {
+		ew.writef("  %s: %s\n", name, profiles[name])
+	}
+
+	return ew.err
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writeProfiles(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		profiles map[string]string
+		expected string
+	}{
+		{
+			desc: "profiles",
+			profiles: map[string]string{
+				"tlsserver":  "https://letsencrypt.org/docs/profiles#tlsserver",
+				"classic":    "https://letsencrypt.org/docs/profiles#classic",
+				"shortlived": "https://letsencrypt.org/docs/profiles#shortlived",
+			},
+			expected: `Profiles of the CA https://acme.example.com/directory:
+  classic: https://letsencrypt.org/docs/profiles#classic
+  shortlived: https://letsencrypt.org/docs/profiles#shortlived
+  tlsserver: https://letsencrypt.org/docs/profiles#tlsserver
+`,
+		},
+		{
+			desc:     "no profiles",
+			expected: "The CA https://acme.example.com/directory doesn't advertise certificate profiles.\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+
+			err := writeProfiles(buf, "https://acme.example.com/directory", test.profiles)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
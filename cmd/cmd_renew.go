@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"crypto"
 	"crypto/x509"
 	"errors"
-	"math/rand"
+	"fmt"
+	"hash/fnv"
 	"os"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme/api"
@@ -14,6 +17,7 @@ import (
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/clock"
 	"github.com/mattn/go-isatty"
 	"github.com/urfave/cli/v2"
 )
@@ -28,34 +32,46 @@ const (
 	flgRenewHook              = "renew-hook"
 	flgRenewHookTimeout       = "renew-hook-timeout"
 	flgNoRandomSleep          = "no-random-sleep"
+	flgRandomSleepMax         = "random-sleep-max"
 	flgForceCertDomains       = "force-cert-domains"
+	flgCheckRevocation        = "check-revocation"
+	flgRenewAll               = "all"
+	flgRenewConcurrency       = "concurrency"
 )
 
+// defaultRandomSleepMax the default maximum of the random sleep before a renewal.
+// https://github.com/certbot/certbot/blob/284023a1b7672be2bd4018dd7623b3b92197d4b0/certbot/certbot/_internal/renewal.py#L472
+const defaultRandomSleepMax = 8 * time.Minute
+
+// renewClock is the clock used by the renewal logic (renewal decision, ARI and random sleeps).
+var renewClock clock.Clock = clock.Real()
+
 func createRenew() *cli.Command {
 	return &cli.Command{
 		Name:   "renew",
 		Usage:  "Renew a certificate",
 		Action: renew,
 		Before: func(ctx *cli.Context) error {
-			// we require either domains or csr, but not both
-			hasDomains := len(ctx.StringSlice(flgDomains)) > 0
-
-			hasCsr := ctx.String(flgCSR) != ""
-			if hasDomains && hasCsr {
-				log.Fatalf("Please specify either --%s/-d or --%s/-c, but not both", flgDomains, flgCSR)
+			err := checkRenewOptions(ctx)
+			if err != nil && ctx.Bool(flgDetailedExitCode) {
+				return &exitStatus{code: exitCodeError, err: err}
 			}
 
-			if !hasDomains && !hasCsr {
-				log.Fatalf("Please specify --%s/-d (or --%s/-c if you already have a CSR)", flgDomains, flgCSR)
-			}
-
-			if ctx.Bool(flgForceCertDomains) && hasCsr {
-				log.Fatalf("--%s only works with --%s/-d, --%s/-c doesn't support this option.", flgForceCertDomains, flgDomains, flgCSR)
-			}
-
-			return nil
+			return err
 		},
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
+			&cli.BoolFlag{
+				Name: flgRenewAll,
+				Usage: "Renew all the stored certificates which need it (instead of the certificate of --" + flgDomains + " or --" + flgCSR + ")." +
+					" The exit code is not zero if a renewal fails.",
+			},
+			createDetailedExitCodeFlag(),
+			&cli.IntFlag{
+				Name: flgRenewConcurrency,
+				Usage: "The number of certificates renewed in parallel (with --" + flgRenewAll + ")." +
+					" The built-in servers of the HTTP-01 and TLS-ALPN-01 challenges only support one renewal at a time.",
+				Value: 1,
+			},
 			&cli.IntFlag{
 				Name:  flgRenewDays,
 				Value: 30,
@@ -75,6 +91,17 @@ func createRenew() *cli.Command {
 				Name:  flgARIWaitToRenewDuration,
 				Usage: "The maximum duration you're willing to sleep for a renewal time returned by the renewalInfo endpoint.",
 			},
+			&cli.BoolFlag{
+				Name: flgCheckRevocation,
+				Usage: "Check the revocation status (OCSP, CRL) of the certificate:" +
+					" a revoked certificate is renewed immediately, regardless of the renewal threshold.",
+			},
+			&cli.StringFlag{
+				Name: flgOut,
+				Usage: "Directory where the certificate files are written, instead of the certificates directory of the path." +
+					" The account and the resource file (.json) stay in the path.",
+				TakesFile: true,
+			},
 			&cli.BoolFlag{
 				Name:  flgReuseKey,
 				Usage: "Used to indicate you want to reuse your current private key for the new certificate.",
@@ -100,12 +127,19 @@ func createRenew() *cli.Command {
 			},
 			&cli.StringFlag{
 				Name: flgPreferredChain,
-				Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name." +
+				Usage: "If the CA offers multiple certificate chains, prefer the chain matching this value:" +
+					" the Subject Common Name or the Subject of the root issuer, or the SHA-256 fingerprint of a certificate of the chain." +
 					" If no match, the default offered chain will be used.",
 			},
+			&cli.BoolFlag{
+				Name: flgAllChains,
+				Usage: "Download and store all the certificate chains offered by the CA (<domain>.chain-<root>.crt)," +
+					" to be able to switch to another trust path without issuing a new certificate.",
+			},
 			&cli.StringFlag{
-				Name:  flgProfile,
-				Usage: "If the CA offers multiple certificate profiles (draft-ietf-acme-profiles), choose this one.",
+				Name: flgProfile,
+				Usage: "If the CA offers multiple certificate profiles (draft-ietf-acme-profiles), choose this one." +
+					" The 'profiles' command displays them.",
 			},
 			&cli.StringFlag{
 				Name:  flgAlwaysDeactivateAuthorizations,
@@ -117,30 +151,217 @@ func createRenew() *cli.Command {
 			},
 			&cli.DurationFlag{
 				Name:  flgRenewHookTimeout,
-				Usage: "Define the timeout for the hook execution.",
-				Value: 2 * time.Minute,
+				Usage: "Define the timeout for the hook execution. Overrides --" + flgHookTimeout + " for this hook.",
 			},
 			&cli.BoolFlag{
 				Name: flgNoRandomSleep,
 				Usage: "Do not add a random sleep before the renewal." +
 					" We do not recommend using this flag if you are doing your renewals in an automated way.",
 			},
+			&cli.DurationFlag{
+				Name: flgRandomSleepMax,
+				Usage: "The maximum duration of the random sleep before the renewal." +
+					" The sleep of a certificate is derived from its name: each certificate is renewed with a stable offset in this window.",
+				Value: defaultRandomSleepMax,
+			},
 			&cli.BoolFlag{
 				Name:  flgForceCertDomains,
 				Usage: "Check and ensure that the cert's domain list matches those passed in the domains argument.",
 			},
-		},
+			createStrictCAAFlag(),
+		}, slices.Concat(createTLSAFlags(), createKeyRotationFlags(), createIssuerPolicyFlags(), createRenewWindowFlags(),
+			createRenewSummaryFlags(), createDeployFlags(), createDryRunFlags(), createHookFlags(), createLockFlags())...),
 	}
 }
 
+// checkRenewOptions checks the options of the renew command, and sets up the dry run.
+func checkRenewOptions(ctx *cli.Context) error {
+	// The certificates of the configuration file are checked in their own context.
+	if useCertificateBlocks(ctx) {
+		return nil
+	}
+
+	// we require either domains or csr, but not both
+	hasDomains := len(ctx.StringSlice(flgDomains)) > 0
+
+	hasCsr := ctx.String(flgCSR) != ""
+
+	hasCertName := ctx.String(flgCertName) != ""
+
+	if ctx.Bool(flgRenewAll) {
+		if hasDomains || hasCsr || hasCertName {
+			return fmt.Errorf("--%s renews all the stored certificates: --%s/-d, --%s/-c, and --%s can't be used with it", flgRenewAll, flgDomains, flgCSR, flgCertName)
+		}
+
+		return setupDryRun(ctx)
+	}
+
+	if hasDomains && hasCsr {
+		return fmt.Errorf("please specify either --%s/-d or --%s/-c, but not both", flgDomains, flgCSR)
+	}
+
+	// The domains of a certificate identified by its name are the domains of the stored certificate.
+	if !hasDomains && !hasCsr && !hasCertName {
+		return fmt.Errorf("please specify --%s/-d, --%s (or --%s/-c if you already have a CSR)", flgDomains, flgCertName, flgCSR)
+	}
+
+	if ctx.Bool(flgForceCertDomains) && hasCsr {
+		return fmt.Errorf("--%s only works with --%s/-d, --%s/-c doesn't support this option", flgForceCertDomains, flgDomains, flgCSR)
+	}
+
+	return setupDryRun(ctx)
+}
+
 func renew(ctx *cli.Context) error {
-	account, keyType := setupAccount(ctx, NewAccountsStorage(ctx))
+	reports, err := renewCertificates(ctx)
+
+	if ctx.Bool(flgDetailedExitCode) {
+		return newDetailedExitStatus(reports, err)
+	}
+
+	return err
+}
+
+// renewCertificates renews the certificates of the configuration file, the stored certificates (--all),
+// or the certificate defined by the domains or the CSR.
+func renewCertificates(ctx *cli.Context) ([]*renewalReport, error) {
+	unlock, err := acquireLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	if useCertificateBlocks(ctx) {
+		return renewCertificateBlocks(ctx)
+	}
+
+	if ctx.Bool(flgRenewAll) {
+		account, keyType, certsStorage, cleanup, err := setupRenewal(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		defer cleanup()
+
+		return renewAllStored(ctx, account, keyType, certsStorage)
+	}
+
+	report, err := renewCertificate(ctx)
+
+	errS := writeRenewalSummary(ctx, report.StartedAt, report)
+	if errS != nil {
+		log.Warnf("%v", errS)
+	}
+
+	errJ := writeRenewalJSON(ctx, report.StartedAt, report)
+	if errJ != nil {
+		log.Warnf("%v", errJ)
+	}
+
+	return []*renewalReport{report}, err
+}
+
+// renewCertificateBlocks renews the certificates of the configuration file, and reports the result of each certificate.
+func renewCertificateBlocks(ctx *cli.Context) ([]*renewalReport, error) {
+	startedAt := renewClock.Now().UTC()
+
+	var reports []*renewalReport
+
+	err := forEachCertificateBlock(ctx, func(blockCtx *cli.Context) error {
+		report, err := renewCertificate(blockCtx)
+
+		reports = append(reports, report)
+
+		return err
+	})
+
+	logRenewalReports(reports)
+
+	errS := writeRenewalSummary(ctx, startedAt, reports...)
+	if errS != nil {
+		log.Warnf("%v", errS)
+	}
+
+	errJ := writeRenewalJSON(ctx, startedAt, reports...)
+	if errJ != nil {
+		log.Warnf("%v", errJ)
+	}
+
+	if err != nil {
+		return reports, fmt.Errorf("one or more certificates have not been renewed:\n%w", err)
+	}
+
+	return reports, nil
+}
+
+// setupRenewal loads the account and the storage of the certificates.
+// The cleanup function removes the temporary storage of a dry run.
+func setupRenewal(ctx *cli.Context) (*Account, certcrypto.KeyType, *CertificatesStorage, func(), error) {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return nil, "", nil, nil, err
+	}
+
+	account, keyType, err := setupAccount(ctx, accountsStorage)
+	if err != nil {
+		return nil, "", nil, nil, err
+	}
 
 	if account.Registration == nil {
-		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+		if !ctx.Bool(flgDryRun) {
+			return nil, "", nil, nil, fmt.Errorf("account %s is not registered. Use 'run' to register a new account", account.Email)
+		}
+
+		// The account of the staging directory is registered by the first dry run.
+		client, err := setupClient(ctx, account, keyType)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+
+		err = registerAccount(ctx, accountsStorage, account, client)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+	}
+
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return nil, "", nil, nil, err
+	}
+
+	cleanup := func() {}
+
+	if ctx.Bool(flgDryRun) {
+		cleanup, err = useDryRunStorage(certsStorage)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+	}
+
+	return account, keyType, certsStorage, cleanup, nil
+}
+
+// renewCertificate renews the certificate defined by the domains or the CSR, if it needs it.
+// The options of the renewal configuration of the certificate are used if they are not defined by the options.
+func renewCertificate(ctx *cli.Context) (*renewalReport, error) {
+	ctx, err := withRenewalConfig(ctx)
+	if err != nil {
+		report := newRenewalReport()
+		report.done(err)
+
+		return report, err
+	}
+
+	account, keyType, certsStorage, cleanup, err := setupRenewal(ctx)
+	if err != nil {
+		report := newRenewalReport()
+		report.done(err)
+
+		return report, err
 	}
 
-	certsStorage := NewCertificatesStorage(ctx)
+	defer cleanup()
 
 	bundle := !ctx.Bool(flgNoBundle)
 
@@ -148,29 +369,53 @@ func renew(ctx *cli.Context) error {
 		hookEnvAccountEmail: account.Email,
 	}
 
-	// CSR
-	if ctx.IsSet(flgCSR) {
-		return renewForCSR(ctx, account, keyType, certsStorage, bundle, meta)
+	report := newRenewalReport()
+
+	switch {
+	case ctx.IsSet(flgCSR):
+		err = renewForCSR(ctx, account, keyType, certsStorage, bundle, meta, report)
+
+	case len(ctx.StringSlice(flgDomains)) > 0:
+		err = renewForDomains(ctx, account, keyType, certsStorage, ctx.StringSlice(flgDomains), bundle, meta, report)
+
+	default:
+		// The certificate is identified by its name (--cert-name).
+		var certRes certificate.Resource
+
+		certRes, err = certsStorage.readResource(ctx.String(flgCertName))
+		if err == nil {
+			err = renewForDomains(ctx, account, keyType, certsStorage, []string{certRes.Domain}, bundle, meta, report)
+		}
 	}
 
-	// Domains
-	return renewForDomains(ctx, account, keyType, certsStorage, bundle, meta)
+	report.done(err)
+
+	return report, err
 }
 
-func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage, bundle bool, meta map[string]string) error {
-	domains := ctx.StringSlice(flgDomains)
+func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage,
+	domains []string, bundle bool, meta map[string]string, report *renewalReport,
+) (err error) {
 	domain := domains[0]
 
+	report.Domain = domain
+
 	// load the cert resource from files.
 	// We store the certificate, private key and metadata in different files
 	// as web servers would not be able to work with a combined file.
 	certificates, err := certsStorage.ReadCertificate(domain, certExt)
 	if err != nil {
-		log.Fatalf("Error while loading the certificate for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("error while loading the certificate for domain %s: %w", domain, err)
 	}
 
 	cert := certificates[0]
 
+	if cert.IsCA {
+		return fmt.Errorf("the certificate bundle of the domain %s starts with a CA certificate", domain)
+	}
+
+	report.Certificate = newCertificateOutput(certsStorage, domain, cert)
+
 	var (
 		ariRenewalTime *time.Time
 		replacesCertID string
@@ -178,23 +423,34 @@ func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyT
 
 	var client *lego.Client
 
-	if !ctx.Bool(flgARIDisable) {
-		client = setupClient(ctx, account, keyType)
+	if !ctx.Bool(flgARIDisable) || ctx.Bool(flgCheckRevocation) {
+		client, err = setupClient(ctx, account, keyType)
+		if err != nil {
+			return err
+		}
+	}
+
+	revoked := ctx.Bool(flgCheckRevocation) && isRevoked(certificates, domain, client)
 
-		ariRenewalTime = getARIRenewalTime(ctx, cert, domain, client)
-		if ariRenewalTime != nil {
-			now := time.Now().UTC()
+	if !ctx.Bool(flgARIDisable) {
+		ariRenewalTime = getARIRenewalTime(ctx, cert, domain, client, certsStorage)
+		if ariRenewalTime != nil && !revoked {
+			now := renewClock.Now().UTC()
 
 			// Figure out if we need to sleep before renewing.
 			if ariRenewalTime.After(now) {
 				log.Infof("[%s] Sleeping %s until renewal time %s", domain, ariRenewalTime.Sub(now), ariRenewalTime)
-				time.Sleep(ariRenewalTime.Sub(now))
+
+				err = renewClock.SleepContext(ctx.Context, ariRenewalTime.Sub(now))
+				if err != nil {
+					return fmt.Errorf("renewal of %s canceled: %w", domain, err)
+				}
 			}
 		}
 
 		replacesCertID, err = certificate.MakeARICertID(cert)
 		if err != nil {
-			log.Fatalf("Error while construction the ARI CertID for domain %s\n\t%v", domain, err)
+			return fmt.Errorf("error while construction the ARI CertID for domain %s: %w", domain, err)
 		}
 	}
 
@@ -202,44 +458,90 @@ func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyT
 
 	certDomains := certcrypto.ExtractDomains(cert)
 
-	if ariRenewalTime == nil && !needRenewal(cert, domain, ctx.Int(flgRenewDays), ctx.Bool(flgRenewDynamic)) &&
-		(!forceDomains || slices.Equal(certDomains, domains)) {
+	domainsChanged := forceDomains && !slices.Equal(certDomains, domains)
+
+	// A dry run always renews the certificate.
+	dryRun := ctx.Bool(flgDryRun)
+
+	if !dryRun && !revoked && ariRenewalTime == nil && !needRenewal(cert, domain, ctx.Int(flgRenewDays), ctx.Bool(flgRenewDynamic)) && !domainsChanged {
+		report.skip(reasonNotDue, cert.NotAfter)
 		return nil
 	}
 
+	window, err := getRenewWindow(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !dryRun && !revoked && deferRenewal(window, cert.NotAfter, domain) {
+		report.skip(reasonRenewWindow, cert.NotAfter)
+		return nil
+	}
+
+	if dryRun {
+		report.renew(reasonDryRun)
+	} else {
+		report.renew(renewalReason(revoked, ariRenewalTime != nil, domainsChanged))
+	}
+
+	if revoked {
+		emergencyRenewal(domain, meta)
+	}
+
 	if client == nil {
-		client = setupClient(ctx, account, keyType)
+		client, err = setupClient(ctx, account, keyType)
+		if err != nil {
+			return err
+		}
 	}
 
 	// This is just meant to be informal for the user.
-	timeLeft := cert.NotAfter.Sub(time.Now().UTC())
+	timeLeft := cert.NotAfter.Sub(renewClock.Now().UTC())
 	log.Infof("[%s] acme: Trying renewal with %d hours remaining", domain, int(timeLeft.Hours()))
 
-	var privateKey crypto.PrivateKey
+	metadata, err := certsStorage.ReadResourceMetadata(domain)
+	if err != nil {
+		return fmt.Errorf("error while loading the meta data for domain %s: %w", domain, err)
+	}
 
-	if ctx.Bool(flgReuseKey) {
-		keyBytes, errR := certsStorage.ReadFile(domain, keyExt)
-		if errR != nil {
-			log.Fatalf("Error while loading the private key for domain %s\n\t%v", domain, errR)
+	keyRotation := getKeyRotation(ctx, metadata.KeyRotation)
+
+	reuseKey := ctx.Bool(flgReuseKey)
+
+	if keyRotation != nil {
+		reuseKey = !keyRotation.Due(renewClock.Now())
+		if !reuseKey {
+			log.Infof("[%s] renewal: rotating the private key (key rotation policy)", domain)
 		}
+	}
+
+	var privateKey crypto.PrivateKey
+
+	if reuseKey {
+		var errR error
 
-		privateKey, errR = certcrypto.ParsePEMPrivateKey(keyBytes)
+		privateKey, errR = certsStorage.ReadPrivateKey(domain)
 		if errR != nil {
-			return errR
+			return fmt.Errorf("error while loading the private key for domain %s: %w", domain, errR)
 		}
 	}
 
 	// https://github.com/go-acme/lego/issues/1656
 	// https://github.com/certbot/certbot/blob/284023a1b7672be2bd4018dd7623b3b92197d4b0/certbot/certbot/_internal/renewal.py#L435-L440
-	if !isatty.IsTerminal(os.Stdout.Fd()) && !ctx.Bool(flgNoRandomSleep) {
-		// https://github.com/certbot/certbot/blob/284023a1b7672be2bd4018dd7623b3b92197d4b0/certbot/certbot/_internal/renewal.py#L472
-		const jitter = 8 * time.Minute
+	if !isatty.IsTerminal(os.Stdout.Fd()) && !ctx.Bool(flgNoRandomSleep) && !revoked {
+		baseName, errB := certsStorage.baseName(domain)
+		if errB != nil {
+			return errB
+		}
 
-		rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
-		sleepTime := time.Duration(rnd.Int63n(int64(jitter)))
+		sleepTime := renewalJitter(baseName, ctx.Duration(flgRandomSleepMax))
 
 		log.Infof("renewal: random delay of %s", sleepTime)
-		time.Sleep(sleepTime)
+
+		err = renewClock.SleepContext(ctx.Context, sleepTime)
+		if err != nil {
+			return fmt.Errorf("renewal of %s canceled: %w", domain, err)
+		}
 	}
 
 	renewalDomains := slices.Clone(domains)
@@ -263,41 +565,120 @@ func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyT
 		request.ReplacesCertID = replacesCertID
 	}
 
-	certRes, err := client.Certificate.Obtain(request)
+	err = preflightCAA(ctx, client, account, renewalDomains)
+	if err != nil {
+		return err
+	}
+
+	meta[hookEnvCertDomain] = domain
+
+	postHook, err := launchPreHook(ctx, meta)
+	defer postHook(&err)
+
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+
+	// The client of the CA which has issued the certificate.
+	var issuerClient *lego.Client
+
+	certRes, err := obtainWithFailover(ctx, client, account, keyType, func(client *lego.Client, primary bool) (*certificate.Resource, error) {
+		issuerClient = client
+
+		if !primary {
+			// The certificate has not been issued by the fallback CA.
+			request.ReplacesCertID = ""
+		}
+
+		return client.Certificate.ObtainWithContext(ctx.Context, request)
+	})
+	if err != nil {
+		return err
 	}
 
 	certRes.Domain = domain
 
-	certsStorage.SaveResource(certRes)
+	err = checkIssuerPolicy(ctx, certRes)
+	if err != nil {
+		return err
+	}
+
+	if keyRotation != nil {
+		keyRotation.Renewed(renewClock.Now(), reuseKey)
+		metadata.KeyRotation = keyRotation
+	}
+
+	// The renewal information is related to the replaced certificate.
+	metadata.ARI = nil
+
+	err = certsStorage.saveResource(certRes, metadata)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(flgAllChains) {
+		err = saveAllChains(ctx, issuerClient, certsStorage, certRes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(ctx.StringSlice(flgTLSAPort)) > 0 {
+		err = publishTLSA(ctx, certRes, certificates)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = deployCertificate(ctx, certRes)
+	if err != nil {
+		return err
+	}
 
-	addPathToMetadata(meta, domain, certRes, certsStorage)
+	if newCerts, errP := certcrypto.ParsePEMBundle(certRes.Certificate); errP == nil {
+		report.NotAfter = &newCerts[0].NotAfter
+		report.Certificate = newCertificateOutput(certsStorage, domain, newCerts[0])
+	}
 
-	return launchHook(ctx.String(flgRenewHook), ctx.Duration(flgRenewHookTimeout), meta)
+	err = addPathToMetadata(meta, domain, certRes, certsStorage)
+	if err != nil {
+		return err
+	}
+
+	return launchCertificateHook(ctx, ctx.String(flgRenewHook), getHookTimeout(ctx, flgRenewHookTimeout), meta, certRes)
 }
 
-func renewForCSR(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage, bundle bool, meta map[string]string) error {
+func renewForCSR(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage,
+	bundle bool, meta map[string]string, report *renewalReport,
+) (err error) {
 	csr, err := readCSRFile(ctx.String(flgCSR))
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	domain, err := certcrypto.GetCSRMainDomain(csr)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
+		return err
 	}
 
+	report.Domain = domain
+
 	// load the cert resource from files.
 	// We store the certificate, private key and metadata in different files
 	// as web servers would not be able to work with a combined file.
 	certificates, err := certsStorage.ReadCertificate(domain, certExt)
 	if err != nil {
-		log.Fatalf("Error while loading the certificate for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("error while loading the certificate for domain %s: %w", domain, err)
 	}
 
 	cert := certificates[0]
 
+	if cert.IsCA {
+		return fmt.Errorf("the certificate bundle of the domain %s starts with a CA certificate", domain)
+	}
+
+	report.Certificate = newCertificateOutput(certsStorage, domain, cert)
+
 	var (
 		ariRenewalTime *time.Time
 		replacesCertID string
@@ -305,36 +686,74 @@ func renewForCSR(ctx *cli.Context, account *Account, keyType certcrypto.KeyType,
 
 	var client *lego.Client
 
-	if !ctx.Bool(flgARIDisable) {
-		client = setupClient(ctx, account, keyType)
+	if !ctx.Bool(flgARIDisable) || ctx.Bool(flgCheckRevocation) {
+		client, err = setupClient(ctx, account, keyType)
+		if err != nil {
+			return err
+		}
+	}
+
+	revoked := ctx.Bool(flgCheckRevocation) && isRevoked(certificates, domain, client)
 
-		ariRenewalTime = getARIRenewalTime(ctx, cert, domain, client)
-		if ariRenewalTime != nil {
-			now := time.Now().UTC()
+	if !ctx.Bool(flgARIDisable) {
+		ariRenewalTime = getARIRenewalTime(ctx, cert, domain, client, certsStorage)
+		if ariRenewalTime != nil && !revoked {
+			now := renewClock.Now().UTC()
 
 			// Figure out if we need to sleep before renewing.
 			if ariRenewalTime.After(now) {
 				log.Infof("[%s] Sleeping %s until renewal time %s", domain, ariRenewalTime.Sub(now), ariRenewalTime)
-				time.Sleep(ariRenewalTime.Sub(now))
+
+				err = renewClock.SleepContext(ctx.Context, ariRenewalTime.Sub(now))
+				if err != nil {
+					return fmt.Errorf("renewal of %s canceled: %w", domain, err)
+				}
 			}
 		}
 
 		replacesCertID, err = certificate.MakeARICertID(cert)
 		if err != nil {
-			log.Fatalf("Error while construction the ARI CertID for domain %s\n\t%v", domain, err)
+			return fmt.Errorf("error while construction the ARI CertID for domain %s: %w", domain, err)
 		}
 	}
 
-	if ariRenewalTime == nil && !needRenewal(cert, domain, ctx.Int(flgRenewDays), ctx.Bool(flgRenewDynamic)) {
+	// A dry run always renews the certificate.
+	dryRun := ctx.Bool(flgDryRun)
+
+	if !dryRun && !revoked && ariRenewalTime == nil && !needRenewal(cert, domain, ctx.Int(flgRenewDays), ctx.Bool(flgRenewDynamic)) {
+		report.skip(reasonNotDue, cert.NotAfter)
 		return nil
 	}
 
+	window, err := getRenewWindow(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !dryRun && !revoked && deferRenewal(window, cert.NotAfter, domain) {
+		report.skip(reasonRenewWindow, cert.NotAfter)
+		return nil
+	}
+
+	if dryRun {
+		report.renew(reasonDryRun)
+	} else {
+		report.renew(renewalReason(revoked, ariRenewalTime != nil, false))
+	}
+
+	if revoked {
+		emergencyRenewal(domain, meta)
+	}
+
 	if client == nil {
-		client = setupClient(ctx, account, keyType)
+		client, err = setupClient(ctx, account, keyType)
+		if err != nil {
+			return err
+		}
 	}
 
 	// This is just meant to be informal for the user.
-	timeLeft := cert.NotAfter.Sub(time.Now().UTC())
+	timeLeft := cert.NotAfter.Sub(renewClock.Now().UTC())
 	log.Infof("[%s] acme: Trying renewal with %d hours remaining", domain, int(timeLeft.Hours()))
 
 	request := certificate.ObtainForCSRRequest{
@@ -351,37 +770,256 @@ func renewForCSR(ctx *cli.Context, account *Account, keyType certcrypto.KeyType,
 		request.ReplacesCertID = replacesCertID
 	}
 
-	certRes, err := client.Certificate.ObtainForCSR(request)
+	err = preflightCAA(ctx, client, account, certcrypto.ExtractDomainsCSR(csr))
+	if err != nil {
+		return err
+	}
+
+	meta[hookEnvCertDomain] = domain
+
+	postHook, err := launchPreHook(ctx, meta)
+	defer postHook(&err)
+
+	if err != nil {
+		return err
+	}
+
+	// The client of the CA which has issued the certificate.
+	var issuerClient *lego.Client
+
+	certRes, err := obtainWithFailover(ctx, client, account, keyType, func(client *lego.Client, primary bool) (*certificate.Resource, error) {
+		issuerClient = client
+
+		if !primary {
+			// The certificate has not been issued by the fallback CA.
+			request.ReplacesCertID = ""
+		}
+
+		return client.Certificate.ObtainForCSRWithContext(ctx.Context, request)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = checkIssuerPolicy(ctx, certRes)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := certsStorage.ReadResourceMetadata(domain)
+	if err != nil {
+		return fmt.Errorf("error while loading the meta data for domain %s: %w", domain, err)
+	}
+
+	// The renewal information is related to the replaced certificate.
+	metadata.ARI = nil
+
+	err = certsStorage.saveResource(certRes, metadata)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
-	certsStorage.SaveResource(certRes)
+	if ctx.Bool(flgAllChains) {
+		err = saveAllChains(ctx, issuerClient, certsStorage, certRes)
+		if err != nil {
+			return err
+		}
+	}
 
-	addPathToMetadata(meta, domain, certRes, certsStorage)
+	if len(ctx.StringSlice(flgTLSAPort)) > 0 {
+		err = publishTLSA(ctx, certRes, certificates)
+		if err != nil {
+			return err
+		}
+	}
 
-	return launchHook(ctx.String(flgRenewHook), ctx.Duration(flgRenewHookTimeout), meta)
+	err = deployCertificate(ctx, certRes)
+	if err != nil {
+		return err
+	}
+
+	if newCerts, errP := certcrypto.ParsePEMBundle(certRes.Certificate); errP == nil {
+		report.NotAfter = &newCerts[0].NotAfter
+		report.Certificate = newCertificateOutput(certsStorage, domain, newCerts[0])
+	}
+
+	err = addPathToMetadata(meta, domain, certRes, certsStorage)
+	if err != nil {
+		return err
+	}
+
+	return launchCertificateHook(ctx, ctx.String(flgRenewHook), getHookTimeout(ctx, flgRenewHookTimeout), meta, certRes)
 }
 
-func needRenewal(x509Cert *x509.Certificate, domain string, days int, dynamic bool) bool {
-	if x509Cert.IsCA {
-		log.Fatalf("[%s] Certificate bundle starts with a CA certificate", domain)
+// renewAllStored renews all the stored certificates which need it (--all), and reports the result of each certificate.
+func renewAllStored(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage) ([]*renewalReport, error) {
+	startedAt := renewClock.Now().UTC()
+
+	reports, err := renewAll(ctx, ctx.Context, account, keyType, certsStorage)
+
+	logRenewalReports(reports)
+
+	errS := writeRenewalSummary(ctx, startedAt, reports...)
+	if errS != nil {
+		log.Warnf("%v", errS)
 	}
 
+	errJ := writeRenewalJSON(ctx, startedAt, reports...)
+	if errJ != nil {
+		log.Warnf("%v", errJ)
+	}
+
+	if err != nil {
+		return reports, fmt.Errorf("one or more certificates have not been renewed:\n%w", err)
+	}
+
+	return reports, nil
+}
+
+// renewAll renews the stored certificates which need it, the certificates are identified by their main domain.
+// The certificates are renewed in parallel (--concurrency), with the same account.
+// The renewal stops before the next certificate when the context is done.
+// Each certificate has its own report and hooks, the errors are joined.
+func renewAll(ctx *cli.Context, stop context.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage) ([]*renewalReport, error) {
+	names, err := certsStorage.ListDomains()
+	if err != nil {
+		return nil, fmt.Errorf("list the certificates: %w", err)
+	}
+
+	concurrency := max(ctx.Int(flgRenewConcurrency), 1)
+
+	if concurrency > 1 && usesChallengeServer(ctx) {
+		log.Warnf("renewal: the built-in servers of the HTTP-01 and TLS-ALPN-01 challenges use a single port: the certificates are renewed one by one")
+
+		concurrency = 1
+	}
+
+	reports := make([]*renewalReport, len(names))
+	errs := make([]error, len(names))
+
+	// The contexts of the certificates (renewal configuration) are created before the renewals:
+	// the creation of a context is not safe for concurrent use.
+	// The key type of a certificate can be defined by its renewal configuration:
+	// an invalid key type is the failure of this certificate only.
+	contexts := make([]*cli.Context, len(names))
+	keyTypes := make([]certcrypto.KeyType, len(names))
+
+	for i, name := range names {
+		contexts[i], keyTypes[i], errs[i] = newRenewalKeyContext(ctx, certsStorage.withCertName(name), name, keyType)
+		if errs[i] != nil {
+			contexts[i] = nil
+
+			reports[i] = newRenewalReport()
+			reports[i].Domain = name
+			reports[i].done(errs[i])
+
+			errs[i] = fmt.Errorf("[%s] %w", name, errs[i])
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	slots := make(chan struct{}, concurrency)
+
+	for i, name := range names {
+		if contexts[i] == nil {
+			continue
+		}
+
+		slots <- struct{}{}
+
+		if stop.Err() != nil {
+			break
+		}
+
+		wg.Go(func() {
+			defer func() { <-slots }()
+
+			reports[i], errs[i] = renewStored(contexts[i], account, keyTypes[i], certsStorage, name)
+		})
+	}
+
+	wg.Wait()
+
+	// The certificates not processed (stopped) have no report.
+	reports = slices.DeleteFunc(reports, func(report *renewalReport) bool { return report == nil })
+
+	return reports, errors.Join(errs...)
+}
+
+// newRenewalKeyContext creates the context of a stored certificate (see newRenewalContext),
+// and returns the key type of the certificate: the key type of the renewal configuration, or the default key type.
+func newRenewalKeyContext(ctx *cli.Context, certsStorage *CertificatesStorage, name string, keyType certcrypto.KeyType) (*cli.Context, certcrypto.KeyType, error) {
+	certCtx, err := newRenewalContext(ctx, certsStorage, name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if certCtx == ctx {
+		return certCtx, keyType, nil
+	}
+
+	certKeyType, err := getKeyType(certCtx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return certCtx, certKeyType, nil
+}
+
+// renewStored renews the stored certificate if it needs it.
+// The certificate is identified by the name of its files, which can differ from its main domain (--cert-name).
+func renewStored(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage, name string) (*renewalReport, error) {
+	report := newRenewalReport()
+
+	certsStorage = certsStorage.withCertName(name)
+
+	certRes, err := certsStorage.readResource(name)
+	if err == nil {
+		meta := map[string]string{
+			hookEnvAccountEmail: account.Email,
+		}
+
+		err = renewForDomains(ctx, account, keyType, certsStorage, []string{certRes.Domain}, !ctx.Bool(flgNoBundle), meta, report)
+	}
+
+	if report.Domain == "" {
+		report.Domain = name
+	}
+
+	report.done(err)
+
+	if err != nil {
+		return report, fmt.Errorf("[%s] %w", report.Domain, err)
+	}
+
+	return report, nil
+}
+
+// usesChallengeServer returns true if a challenge uses a built-in server (HTTP-01 without webroot, memcached, or S3, and TLS-ALPN-01).
+func usesChallengeServer(ctx *cli.Context) bool {
+	if ctx.Bool(flgTLS) {
+		return true
+	}
+
+	return ctx.Bool(flgHTTP) && !ctx.IsSet(flgHTTPWebroot) && !ctx.IsSet(flgHTTPMemcachedHost) && !ctx.IsSet(flgHTTPS3Bucket)
+}
+
+func needRenewal(x509Cert *x509.Certificate, domain string, days int, dynamic bool) bool {
 	if dynamic {
-		return needRenewalDynamic(x509Cert, domain, time.Now())
+		return needRenewalDynamic(x509Cert, domain, renewClock.Now())
 	}
 
 	if days < 0 {
 		return true
 	}
 
-	notAfter := int(time.Until(x509Cert.NotAfter).Hours() / 24.0)
+	notAfter := int(x509Cert.NotAfter.Sub(renewClock.Now()).Hours() / 24.0)
 	if notAfter <= days {
 		return true
 	}
 
-	log.Printf("[%s] The certificate expires in %d days, the number of days defined to perform the renewal is %d: no renewal.",
+	log.Infof("[%s] The certificate expires in %d days, the number of days defined to perform the renewal is %d: no renewal.",
 		domain, notAfter, days)
 
 	return false
@@ -408,12 +1046,8 @@ func needRenewalDynamic(x509Cert *x509.Certificate, domain string, now time.Time
 }
 
 // getARIRenewalTime checks if the certificate needs to be renewed using the renewalInfo endpoint.
-func getARIRenewalTime(ctx *cli.Context, cert *x509.Certificate, domain string, client *lego.Client) *time.Time {
-	if cert.IsCA {
-		log.Fatalf("[%s] Certificate bundle starts with a CA certificate", domain)
-	}
-
-	renewalInfo, err := client.Certificate.GetRenewalInfo(certificate.RenewalInfoRequest{Cert: cert})
+func getARIRenewalTime(ctx *cli.Context, cert *x509.Certificate, domain string, client *lego.Client, certsStorage *CertificatesStorage) *time.Time {
+	renewalInfo, err := getRenewalInfo(client, certsStorage, domain, cert)
 	if err != nil {
 		if errors.Is(err, api.ErrNoARI) {
 			// The server does not advertise a renewal info endpoint.
@@ -426,7 +1060,7 @@ func getARIRenewalTime(ctx *cli.Context, cert *x509.Certificate, domain string,
 		return nil
 	}
 
-	now := time.Now().UTC()
+	now := renewClock.Now().UTC()
 
 	renewalTime := renewalInfo.ShouldRenewAt(now, ctx.Duration(flgARIWaitToRenewDuration))
 	if renewalTime == nil {
@@ -443,6 +1077,44 @@ func getARIRenewalTime(ctx *cli.Context, cert *x509.Certificate, domain string,
 	return renewalTime
 }
 
+// renewalJitter returns the sleep before the renewal of a certificate, between 0 and the maximum.
+// The sleep is derived from the name of the certificate (FNV-1a hash), instead of being random at each execution,
+// so the renewals of a fleet are spread over the window, each certificate with a stable offset.
+func renewalJitter(name string, maxSleep time.Duration) time.Duration {
+	if maxSleep <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+
+	return time.Duration(h.Sum64() % uint64(maxSleep))
+}
+
+// isRevoked checks the revocation status (OCSP, CRL) of the certificate.
+// An unknown status is not considered as revoked.
+func isRevoked(certificates []*x509.Certificate, domain string, client *lego.Client) bool {
+	request := certificate.RevocationStatusRequest{Cert: certificates[0]}
+	if len(certificates) > 1 {
+		request.Issuer = certificates[1]
+	}
+
+	status, err := client.Certificate.GetRevocationStatus(request)
+	if err != nil {
+		log.Warnf("[%s] renewal: unable to check the revocation status: %v", domain, err)
+		return false
+	}
+
+	return status == certificate.RevocationStatusRevoked
+}
+
+// emergencyRenewal notifies the renewal of a revoked certificate.
+func emergencyRenewal(domain string, meta map[string]string) {
+	log.Warnf("[%s] renewal: the certificate has been revoked, emergency renewal", domain)
+
+	meta[hookEnvRenewalEmergency] = "true"
+}
+
 func merge(prevDomains, nextDomains []string) []string {
 	for _, next := range nextDomains {
 		if slices.Contains(prevDomains, next) {
@@ -1,11 +1,26 @@
 package cmd
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/clock"
+	"github.com/go-acme/lego/v4/registration"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
 )
 
 func Test_merge(t *testing.T) {
@@ -58,6 +73,8 @@ func Test_merge(t *testing.T) {
 }
 
 func Test_needRenewal(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
 	testCases := []struct {
 		desc     string
 		x509Cert *x509.Certificate
@@ -67,7 +84,7 @@ func Test_needRenewal(t *testing.T) {
 		{
 			desc: "30 days, NotAfter now",
 			x509Cert: &x509.Certificate{
-				NotAfter: time.Now(),
+				NotAfter: now,
 			},
 			days:     30,
 			expected: true,
@@ -75,15 +92,23 @@ func Test_needRenewal(t *testing.T) {
 		{
 			desc: "30 days, NotAfter 31 days",
 			x509Cert: &x509.Certificate{
-				NotAfter: time.Now().Add(31*24*time.Hour + 1*time.Second),
+				NotAfter: now.Add(31 * 24 * time.Hour),
 			},
 			days:     30,
 			expected: false,
 		},
+		{
+			desc: "30 days, NotAfter 30 days and 23 hours",
+			x509Cert: &x509.Certificate{
+				NotAfter: now.Add(30*24*time.Hour + 23*time.Hour),
+			},
+			days:     30,
+			expected: true,
+		},
 		{
 			desc: "30 days, NotAfter 30 days",
 			x509Cert: &x509.Certificate{
-				NotAfter: time.Now().Add(30 * 24 * time.Hour),
+				NotAfter: now.Add(30 * 24 * time.Hour),
 			},
 			days:     30,
 			expected: true,
@@ -91,7 +116,7 @@ func Test_needRenewal(t *testing.T) {
 		{
 			desc: "0 days, NotAfter 30 days: only the day of the expiration",
 			x509Cert: &x509.Certificate{
-				NotAfter: time.Now().Add(30 * 24 * time.Hour),
+				NotAfter: now.Add(30 * 24 * time.Hour),
 			},
 			days:     0,
 			expected: false,
@@ -99,7 +124,7 @@ func Test_needRenewal(t *testing.T) {
 		{
 			desc: "-1 days, NotAfter 30 days: always renew",
 			x509Cert: &x509.Certificate{
-				NotAfter: time.Now().Add(30 * 24 * time.Hour),
+				NotAfter: now.Add(30 * 24 * time.Hour),
 			},
 			days:     -1,
 			expected: true,
@@ -108,6 +133,8 @@ func Test_needRenewal(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
+			useFakeClock(t, now)
+
 			actual := needRenewal(test.x509Cert, "foo.com", test.days, false)
 
 			assert.Equal(t, test.expected, actual)
@@ -115,6 +142,24 @@ func Test_needRenewal(t *testing.T) {
 	}
 }
 
+func Test_needRenewal_dynamic(t *testing.T) {
+	notBefore := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	x509Cert := &x509.Certificate{
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(90 * 24 * time.Hour),
+	}
+
+	clk := useFakeClock(t, notBefore)
+
+	// The renewal is due after 2/3 of the lifetime (60 days).
+	clk.Advance(60*24*time.Hour - time.Second)
+	assert.False(t, needRenewal(x509Cert, "foo.com", 0, true))
+
+	clk.Advance(2 * time.Second)
+	assert.True(t, needRenewal(x509Cert, "foo.com", 0, true))
+}
+
 func Test_needRenewalDynamic(t *testing.T) {
 	testCases := []struct {
 		desc                string
@@ -167,3 +212,340 @@ func Test_needRenewalDynamic(t *testing.T) {
 		})
 	}
 }
+
+func useFakeClock(t *testing.T, now time.Time) *clock.Fake {
+	t.Helper()
+
+	clk := clock.NewFake(now)
+
+	original := renewClock
+
+	t.Cleanup(func() {
+		renewClock = original
+	})
+
+	renewClock = clk
+
+	return clk
+}
+
+func Test_renewAll(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Bool(flgARIDisable, true, "")
+	set.Int(flgRenewDays, 30, "")
+
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+	storage := &CertificatesStorage{rootPath: t.TempDir()}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.WriteFile("example.com", certExt, cert))
+	require.NoError(t, storage.WriteFile("example.com", resourceExt, []byte(`{"domain":"example.com"}`)))
+	require.NoError(t, storage.WriteFile("example.org", resourceExt, []byte(`{`)))
+
+	reports, err := renewAll(ctx, t.Context(), &Account{Email: "test@example.com"}, certcrypto.RSA2048, storage)
+	require.Error(t, err)
+
+	require.Len(t, reports, 2)
+
+	assert.Equal(t, "example.com", reports[0].Domain)
+	assert.Equal(t, renewalSkipped, reports[0].Decision)
+
+	assert.Equal(t, "example.org", reports[1].Domain)
+	assert.Equal(t, renewalFailed, reports[1].Decision)
+}
+
+func Test_renewAll_certName(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Bool(flgARIDisable, true, "")
+	set.Int(flgRenewDays, 30, "")
+
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+	storage := &CertificatesStorage{rootPath: t.TempDir()}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	// The certificate is stored with a name (--cert-name) which is not its main domain.
+	named := storage.withCertName("web")
+
+	require.NoError(t, named.WriteFile("example.com", certExt, cert))
+	require.NoError(t, named.WriteFile("example.com", resourceExt, []byte(`{"domain":"example.com"}`)))
+
+	reports, err := renewAll(ctx, t.Context(), &Account{Email: "test@example.com"}, certcrypto.RSA2048, storage)
+	require.NoError(t, err)
+
+	require.Len(t, reports, 1)
+
+	assert.Equal(t, "example.com", reports[0].Domain)
+	assert.Equal(t, renewalSkipped, reports[0].Decision)
+}
+
+func Test_renewAll_concurrency(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Bool(flgARIDisable, true, "")
+	set.Int(flgRenewDays, 30, "")
+	set.Int(flgRenewConcurrency, 3, "")
+
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+	storage := &CertificatesStorage{rootPath: t.TempDir()}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	domains := []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com", "e.example.com"}
+
+	for _, domain := range domains {
+		cert, errC := certcrypto.GeneratePemCert(privateKey, domain, nil)
+		require.NoError(t, errC)
+
+		require.NoError(t, storage.WriteFile(domain, certExt, cert))
+		require.NoError(t, storage.WriteFile(domain, resourceExt, []byte(`{"domain":"`+domain+`"}`)))
+	}
+
+	reports, err := renewAll(ctx, t.Context(), &Account{Email: "test@example.com"}, certcrypto.RSA2048, storage)
+	require.NoError(t, err)
+
+	require.Len(t, reports, len(domains))
+
+	// The order of the reports is the order of the certificates.
+	for i, domain := range domains {
+		assert.Equal(t, domain, reports[i].Domain)
+		assert.Equal(t, renewalSkipped, reports[i].Decision)
+	}
+}
+
+func Test_usesChallengeServer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		args     []string
+		expected bool
+	}{
+		{
+			desc: "no challenge",
+		},
+		{
+			desc:     "HTTP-01 built-in server",
+			args:     []string{"--" + flgHTTP},
+			expected: true,
+		},
+		{
+			desc: "HTTP-01 webroot",
+			args: []string{"--" + flgHTTP, "--" + flgHTTPWebroot, "/var/www"},
+		},
+		{
+			desc:     "TLS-ALPN-01",
+			args:     []string{"--" + flgTLS},
+			expected: true,
+		},
+		{
+			desc: "DNS-01",
+			args: []string{"--" + flgDNS, "manual"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			set.Bool(flgHTTP, false, "")
+			set.String(flgHTTPWebroot, "", "")
+			set.Bool(flgTLS, false, "")
+			set.String(flgDNS, "", "")
+
+			require.NoError(t, set.Parse(test.args))
+
+			ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+			assert.Equal(t, test.expected, usesChallengeServer(ctx))
+		})
+	}
+}
+
+func Test_renewAllStored(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Bool(flgARIDisable, true, "")
+
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+	storage := &CertificatesStorage{rootPath: t.TempDir()}
+
+	require.NoError(t, storage.WriteFile("example.org", resourceExt, []byte(`{`)))
+
+	reports, err := renewAllStored(ctx, &Account{Email: "test@example.com"}, certcrypto.RSA2048, storage)
+	require.ErrorContains(t, err, "one or more certificates have not been renewed:\n[example.org] unmarshal the resource")
+
+	require.Len(t, reports, 1)
+	assert.Equal(t, renewalFailed, reports[0].Decision)
+}
+
+func Test_renewalJitter(t *testing.T) {
+	const maxSleep = 8 * time.Minute
+
+	sleep := renewalJitter("example.com", maxSleep)
+
+	assert.GreaterOrEqual(t, sleep, time.Duration(0))
+	assert.Less(t, sleep, maxSleep)
+
+	// The sleep of a certificate is stable.
+	assert.Equal(t, sleep, renewalJitter("example.com", maxSleep))
+
+	// The sleeps of the certificates are spread over the window.
+	assert.NotEqual(t, sleep, renewalJitter("example.org", maxSleep))
+
+	assert.Zero(t, renewalJitter("example.com", 0))
+}
+
+func Test_renewAll_partialFailure(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := newFakeCA(t)
+
+	mux := server.Config.Handler.(*http.ServeMux)
+
+	// The CA refuses the order of fail.example.com, and issues the other certificates.
+	mux.HandleFunc("POST /order", func(rw http.ResponseWriter, req *http.Request) {
+		var order acme.Order
+
+		err := readJWSPayload(req, &order)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// The common name of the test certificates is not a domain.
+		i := slices.IndexFunc(order.Identifiers, func(id acme.Identifier) bool { return strings.HasSuffix(id.Value, ".example.com") })
+		if i < 0 {
+			http.Error(rw, "invalid order", http.StatusBadRequest)
+			return
+		}
+
+		domain := order.Identifiers[i].Value
+
+		if domain == "fail.example.com" {
+			rw.Header().Set("Content-Type", "application/problem+json")
+			rw.WriteHeader(http.StatusForbidden)
+
+			_ = json.NewEncoder(rw).Encode(acme.ProblemDetails{Type: "urn:ietf:params:acme:error:rejectedIdentifier", Detail: "refused"})
+
+			return
+		}
+
+		rw.Header().Set("Location", server.URL+"/order/"+domain)
+		rw.WriteHeader(http.StatusCreated)
+
+		_ = json.NewEncoder(rw).Encode(acme.Order{
+			Status:      acme.StatusReady,
+			Identifiers: order.Identifiers,
+			Finalize:    server.URL + "/finalize/" + domain,
+		})
+	})
+
+	mux.HandleFunc("POST /finalize/{domain}", func(rw http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(rw).Encode(acme.Order{
+			Status:      acme.StatusValid,
+			Certificate: server.URL + "/cert/" + req.PathValue("domain"),
+		})
+	})
+
+	mux.HandleFunc("POST /cert/{domain}", func(rw http.ResponseWriter, req *http.Request) {
+		cert, errG := certcrypto.GeneratePemCert(privateKey, req.PathValue("domain"), nil)
+		if errG != nil {
+			http.Error(rw, errG.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_, _ = rw.Write(cert)
+	})
+
+	path := t.TempDir()
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(flgPath, path, "")
+
+	accountsStorage, err := newAccountsStorage(cli.NewContext(cli.NewApp(), set, nil), server.URL+"/directory", "test@example.com")
+	require.NoError(t, err)
+
+	accountKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+
+	require.NoError(t, accountsStorage.SavePrivateKey(accountKey))
+	require.NoError(t, accountsStorage.Save(&Account{
+		Email:        "test@example.com",
+		Registration: &registration.Resource{URI: server.URL + "/account/1", Body: acme.Account{Status: acme.StatusValid}},
+	}))
+
+	storage := &CertificatesStorage{rootPath: filepath.Join(path, baseCertificatesFolderName)}
+	require.NoError(t, storage.CreateRootFolder())
+
+	domains := []string{"a.example.com", "fail.example.com", "badkey.example.com", "c.example.com"}
+
+	for _, domain := range domains {
+		cert, errC := certcrypto.GeneratePemCert(privateKey, domain, nil)
+		require.NoError(t, errC)
+
+		require.NoError(t, storage.WriteFile(domain, certExt, cert))
+		require.NoError(t, storage.WriteFile(domain, resourceExt, []byte(`{"domain":"`+domain+`"}`)))
+	}
+
+	// An invalid key type in the renewal configuration of a certificate.
+	require.NoError(t, storage.SaveRenewalConfig("badkey.example.com", map[string]any{flgKeyType: "invalid"}))
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags(path)
+	app.Commands = []*cli.Command{createRenew()}
+
+	err = app.Run([]string{
+		"lego", "--path", path, "--server", server.URL + "/directory", "--email", "test@example.com", "--tls-skip-verify", "--dns", "manual",
+		"renew", "--" + flgRenewAll, "--" + flgDetailedExitCode, "--" + flgARIDisable, "--" + flgNoRandomSleep, "--" + flgRenewDays, "400",
+	})
+	require.ErrorContains(t, err, "[fail.example.com]")
+	require.ErrorContains(t, err, "[badkey.example.com]")
+
+	assert.Equal(t, exitCodePartial, ExitCode(err))
+
+	// The other certificates have been renewed.
+	for _, domain := range []string{"a.example.com", "c.example.com"} {
+		resource, errR := storage.readResource(domain)
+		require.NoError(t, errR)
+
+		assert.Equal(t, server.URL+"/cert/"+domain, resource.CertURL)
+	}
+
+	for _, domain := range []string{"fail.example.com", "badkey.example.com"} {
+		resource, errR := storage.readResource(domain)
+		require.NoError(t, errR)
+
+		assert.Empty(t, resource.CertURL)
+	}
+}
+
+// readJWSPayload decodes the payload of the JWS of the request (flattened JSON serialization).
+func readJWSPayload(req *http.Request, v any) error {
+	var jws struct {
+		Payload string `json:"payload"`
+	}
+
+	err := json.NewDecoder(req.Body).Decode(&jws)
+	if err != nil {
+		return err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, v)
+}
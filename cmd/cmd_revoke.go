@@ -1,15 +1,34 @@
 package cmd
 
 import (
-	"github.com/go-acme/lego/v4/acme"
+	"bufio"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/ctmonitor"
+	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/urfave/cli/v2"
 )
 
 // Flag names.
 const (
-	flgKeep   = "keep"
-	flgReason = "reason"
+	flgKeep             = "keep"
+	flgReason           = "reason"
+	flgRevokeSerial     = "serial"
+	flgRevokeIssuerPath = "issuer-path"
+	flgRevokeCertURL    = "cert-url"
+	flgRevokeYes        = "yes"
+	flgRevokeCertPath   = "cert-path"
+	flgRevokeCertKey    = "cert-key"
 )
 
 func createRevoke() *cli.Command {
@@ -23,62 +42,378 @@ func createRevoke() *cli.Command {
 				Aliases: []string{"k"},
 				Usage:   "Keep the certificates after the revocation instead of archiving them.",
 			},
-			&cli.UintFlag{
+			&cli.StringFlag{
 				Name: flgReason,
-				Usage: "Identifies the reason for the certificate revocation." +
+				Usage: "Identifies the reason for the certificate revocation, by its name or its code." +
 					" See https://www.rfc-editor.org/rfc/rfc5280.html#section-5.3.1." +
-					" Valid values are:" +
-					" 0 (unspecified), 1 (keyCompromise), 2 (cACompromise), 3 (affiliationChanged)," +
-					" 4 (superseded), 5 (cessationOfOperation), 6 (certificateHold), 8 (removeFromCRL)," +
-					" 9 (privilegeWithdrawn), or 10 (aACompromise).",
-				Value: acme.CRLReasonUnspecified,
+					" Valid values are: " + revocationReasonsUsage() + ".",
+				Value: certificate.RevocationReasonUnspecified.String(),
+			},
+			&cli.StringFlag{
+				Name: flgRevokeSerial,
+				Usage: "Serial number (hex) of a certificate to revoke without local files." +
+					" The certificate is looked up in the Certificate Transparency logs (crt.sh). Requires --" + flgRevokeIssuerPath + ".",
+			},
+			&cli.StringFlag{
+				Name:  flgRevokeIssuerPath,
+				Usage: "Path to the PEM encoded issuer certificate of the certificate identified by --" + flgRevokeSerial + ".",
+			},
+			&cli.StringFlag{
+				Name:  flgRevokeCertURL,
+				Usage: "URL of a certificate to revoke without local files. The certificate is fetched from the CA with the account.",
+			},
+			&cli.StringFlag{
+				Name: flgRevokeCertPath,
+				Usage: "Path to the PEM encoded certificate to revoke, instead of the certificates of the storage (--domains)." +
+					" '-' reads the certificate from stdin.",
+				TakesFile: true,
+			},
+			&cli.BoolFlag{
+				Name: flgRevokeCertKey,
+				Usage: "Sign the revocation request with the private key of the certificate instead of the account key (e.g. if the account is lost)." +
+					" The private key is read from --" + flgPrivateKey + ", or from the storage (--domains).",
+			},
+			&cli.StringFlag{
+				Name:      flgPrivateKey,
+				Usage:     "Path to the PEM encoded private key of the certificate to revoke. Implies --" + flgRevokeCertKey + ".",
+				TakesFile: true,
+			},
+			&cli.BoolFlag{
+				Name:  flgRevokeYes,
+				Usage: "Do not ask for confirmation before revoking a certificate identified by --" + flgRevokeSerial + " or --" + flgRevokeCertURL + ".",
 			},
 		},
 	}
 }
 
 func revoke(ctx *cli.Context) error {
-	account, keyType := setupAccount(ctx, NewAccountsStorage(ctx))
+	_, err := certificate.ParseRevocationReason(ctx.String(flgReason))
+	if err != nil {
+		return fmt.Errorf("--%s: %w", flgReason, err)
+	}
+
+	withCertKey := useCertKey(ctx)
+
+	var client *lego.Client
+
+	if withCertKey {
+		if ctx.IsSet(flgRevokeSerial) || ctx.IsSet(flgRevokeCertURL) {
+			return fmt.Errorf("--%s requires --%s or --%s", flgRevokeCertKey, flgRevokeCertPath, flgDomains)
+		}
+
+		// The requests are signed with the private key of the certificate: a temporary key is enough, no account is needed.
+		privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+		if err != nil {
+			return err
+		}
+
+		client = newClient(ctx, getServer(ctx), &Account{key: privateKey}, certcrypto.EC256)
+	} else {
+		accountsStorage, err := NewAccountsStorage(ctx)
+		if err != nil {
+			return err
+		}
+
+		account, keyType, err := setupAccount(ctx, accountsStorage)
+		if err != nil {
+			return err
+		}
+
+		if account.Registration == nil {
+			log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+		}
+
+		client = newClient(ctx, account.server, account, keyType)
+	}
+
+	if ctx.IsSet(flgRevokeSerial) || ctx.IsSet(flgRevokeCertURL) {
+		return revokeWithoutFiles(ctx, client)
+	}
+
+	if ctx.IsSet(flgRevokeCertPath) {
+		return revokeFromPath(ctx, client)
+	}
+
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
 
-	if account.Registration == nil {
-		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+	err = certsStorage.CreateRootFolder()
+	if err != nil {
+		return err
 	}
 
-	client := newClient(ctx, account, keyType)
+	domains := ctx.StringSlice(flgDomains)
 
-	certsStorage := NewCertificatesStorage(ctx)
-	certsStorage.CreateRootFolder()
+	// The certificate identified by its name (--cert-name) is revoked once.
+	if name := ctx.String(flgCertName); name != "" {
+		domains = []string{name}
+	}
 
-	for _, domain := range ctx.StringSlice(flgDomains) {
-		log.Printf("Trying to revoke certificate for domain %s", domain)
+	for _, domain := range domains {
+		log.Infof("Trying to revoke certificate for domain %s", domain)
 
 		certBytes, err := certsStorage.ReadFile(domain, certExt)
 		if err != nil {
 			log.Fatalf("Error while revoking the certificate for domain %s\n\t%v", domain, err)
 		}
 
-		reason := ctx.Uint(flgReason)
+		var privateKey crypto.PrivateKey
+
+		if withCertKey {
+			privateKey, err = getCertKey(ctx, func() (crypto.PrivateKey, error) { return certsStorage.ReadPrivateKey(domain) })
+			if err != nil {
+				log.Fatalf("Error while reading the private key for domain %s\n\t%v", domain, err)
+			}
+		}
 
-		err = client.Certificate.RevokeWithReason(certBytes, &reason)
+		err = revokeCertificate(ctx, client, certBytes, privateKey)
 		if err != nil {
 			log.Fatalf("Error while revoking the certificate for domain %s\n\t%v", domain, err)
 		}
 
-		log.Println("Certificate was revoked.")
+		log.Infof("Certificate was revoked.")
 
 		if ctx.Bool(flgKeep) {
 			return nil
 		}
 
-		certsStorage.CreateArchiveFolder()
+		err = certsStorage.CreateArchiveFolder()
+		if err != nil {
+			return err
+		}
 
 		err = certsStorage.MoveToArchive(domain)
 		if err != nil {
 			return err
 		}
 
-		log.Println("Certificate was archived for domain:", domain)
+		log.Infof("Certificate was archived for domain: %s", domain)
+	}
+
+	return nil
+}
+
+// revokeFromPath revokes the certificate read from a file or from stdin (--cert-path), instead of the storage.
+func revokeFromPath(ctx *cli.Context, client *lego.Client) error {
+	certBytes, err := readCertificateFile(ctx.String(flgRevokeCertPath))
+	if err != nil {
+		return fmt.Errorf("read the certificate: %w", err)
+	}
+
+	cert, err := certcrypto.ParsePEMCertificate(certBytes)
+	if err != nil {
+		return fmt.Errorf("read the certificate: %w", err)
+	}
+
+	var privateKey crypto.PrivateKey
+
+	if useCertKey(ctx) {
+		privateKey, err = getCertKey(ctx, func() (crypto.PrivateKey, error) {
+			return nil, fmt.Errorf("--%s is required with --%s and --%s", flgPrivateKey, flgRevokeCertKey, flgRevokeCertPath)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	serial := ctmonitor.NormalizeSerial(cert.SerialNumber.Text(16))
+
+	log.Infof("Trying to revoke certificate %s for %s", serial, strings.Join(certcrypto.ExtractDomains(cert), ", "))
+
+	err = revokeCertificate(ctx, client, certBytes, privateKey)
+	if err != nil {
+		log.Fatalf("Error while revoking the certificate %s\n\t%v", serial, err)
+	}
+
+	log.Infof("Certificate was revoked.")
+
+	return nil
+}
+
+// readCertificateFile reads the PEM encoded certificate, '-' reads the certificate from stdin.
+func readCertificateFile(filename string) ([]byte, error) {
+	if filename == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(filename)
+}
+
+// useCertKey returns true if the revocation requests are signed with the private key of the certificate (--cert-key, --private-key).
+func useCertKey(ctx *cli.Context) bool {
+	return ctx.Bool(flgRevokeCertKey) || ctx.IsSet(flgPrivateKey)
+}
+
+// getCertKey returns the private key of the certificate (--private-key), or the private key read by the fallback.
+func getCertKey(ctx *cli.Context, fallback func() (crypto.PrivateKey, error)) (crypto.PrivateKey, error) {
+	if ctx.IsSet(flgPrivateKey) {
+		passphrase, err := getKeyPassphrase(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return loadPrivateKey(ctx.String(flgPrivateKey), passphrase)
+	}
+
+	return fallback()
+}
+
+// revokeCertificate revokes the certificate, the request is signed with the account key,
+// or with the private key of the certificate if defined.
+func revokeCertificate(ctx *cli.Context, client *lego.Client, certBytes []byte, privateKey crypto.PrivateKey) error {
+	parsed, err := certificate.ParseRevocationReason(ctx.String(flgReason))
+	if err != nil {
+		return err
 	}
 
+	reason := uint(parsed)
+
+	if privateKey != nil {
+		return client.Certificate.RevokeWithPrivateKey(certBytes, &reason, privateKey)
+	}
+
+	return client.Certificate.RevokeWithReason(certBytes, &reason)
+}
+
+// revocationReasonsUsage returns the names and the codes of the revocation reasons (e.g. "keyCompromise (1)").
+func revocationReasonsUsage() string {
+	var values []string
+
+	for _, reason := range certificate.RevocationReasons() {
+		values = append(values, fmt.Sprintf("%s (%d)", reason, uint(reason)))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// revokeWithoutFiles revokes a certificate identified by its serial number or its URL,
+// for the certificates whose local files were lost.
+func revokeWithoutFiles(ctx *cli.Context, client *lego.Client) error {
+	cert, err := findRemoteCertificate(ctx, client)
+	if err != nil {
+		log.Fatalf("Error while looking up the certificate to revoke: %v", err)
+	}
+
+	serial := ctmonitor.NormalizeSerial(cert.SerialNumber.Text(16))
+
+	if !ctx.Bool(flgRevokeYes) {
+		w := &wizard{in: bufio.NewReader(os.Stdin), out: ctx.App.Writer}
+
+		question := fmt.Sprintf("Revoke the certificate %s for %s (issuer: %s, expires: %s)?",
+			serial, strings.Join(certcrypto.ExtractDomains(cert), ", "), cert.Issuer.CommonName, cert.NotAfter.Format("2006-01-02"))
+
+		ok, errC := w.confirm(question, false)
+		if errC != nil {
+			return errC
+		}
+
+		if !ok {
+			log.Infof("Revocation aborted.")
+
+			return nil
+		}
+	}
+
+	log.Infof("Trying to revoke certificate %s", serial)
+
+	err = revokeCertificate(ctx, client, certcrypto.PEMEncode(certcrypto.DERCertificateBytes(cert.Raw)), nil)
+	if err != nil {
+		log.Fatalf("Error while revoking the certificate %s\n\t%v", serial, err)
+	}
+
+	log.Infof("Certificate was revoked.")
+
 	return nil
 }
+
+func findRemoteCertificate(ctx *cli.Context, client *lego.Client) (*x509.Certificate, error) {
+	serial := ctx.String(flgRevokeSerial)
+
+	if ctx.IsSet(flgRevokeCertURL) {
+		res, err := client.Certificate.Get(ctx.String(flgRevokeCertURL), false)
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := certcrypto.ParsePEMCertificate(res.Certificate)
+		if err != nil {
+			return nil, err
+		}
+
+		if serial != "" && ctmonitor.NormalizeSerial(serial) != ctmonitor.NormalizeSerial(cert.SerialNumber.Text(16)) {
+			return nil, fmt.Errorf("the serial number of the certificate (%s) doesn't match %s", cert.SerialNumber.Text(16), serial)
+		}
+
+		return cert, nil
+	}
+
+	if !ctx.IsSet(flgRevokeIssuerPath) {
+		return nil, fmt.Errorf("--%s is required with --%s", flgRevokeIssuerPath, flgRevokeSerial)
+	}
+
+	issuerBytes, err := os.ReadFile(ctx.String(flgRevokeIssuerPath))
+	if err != nil {
+		return nil, err
+	}
+
+	issuer, err := certcrypto.ParsePEMCertificate(issuerBytes)
+	if err != nil {
+		return nil, fmt.Errorf("issuer: %w", err)
+	}
+
+	return lookupCertificate(ctx.Context, ctmonitor.NewCrtSh(), serial, issuer)
+}
+
+// lookupCertificate finds the certificate with the serial number issued by the issuer in the Certificate Transparency logs.
+func lookupCertificate(ctx context.Context, crtsh *ctmonitor.CrtSh, serial string, issuer *x509.Certificate) (*x509.Certificate, error) {
+	entries, err := crtsh.SearchSerial(ctx, serial)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+
+	for _, entry := range entries {
+		cert, err := crtsh.Download(ctx, entry.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// crt.sh returns both the precertificate and the certificate: only the certificate can be revoked.
+		if isPrecertificate(cert) {
+			continue
+		}
+
+		if ctmonitor.NormalizeSerial(cert.SerialNumber.Text(16)) != ctmonitor.NormalizeSerial(serial) {
+			continue
+		}
+
+		if cert.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+
+		return cert, nil
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return nil, fmt.Errorf("no certificate with the serial number %s issued by %q found", serial, issuer.Subject.CommonName)
+}
+
+// oidPrecertificatePoison is the OID of the precertificate poison extension (RFC 6962).
+var oidPrecertificatePoison = []int{1, 3, 6, 1, 4, 1, 11129, 2, 4, 3}
+
+func isPrecertificate(cert *x509.Certificate) bool {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidPrecertificatePoison) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/ctmonitor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_lookupCertificate(t *testing.T) {
+	root, rootKey := createTestCertificate(t, "Root", true, nil, nil)
+	leaf, _ := createTestCertificate(t, "example.com", false, root, rootKey)
+
+	otherRoot, otherRootKey := createTestCertificate(t, "Other Root", true, nil, nil)
+	other, _ := createTestCertificate(t, "example.org", false, otherRoot, otherRootKey)
+
+	serial := leaf.SerialNumber.Text(16)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("d") {
+		case "1":
+			_, _ = rw.Write(other.Raw)
+		case "2":
+			_, _ = rw.Write(leaf.Raw)
+		default:
+			if req.URL.Query().Get("serial") != serial {
+				http.Error(rw, "unexpected serial", http.StatusBadRequest)
+				return
+			}
+
+			_, _ = fmt.Fprintf(rw, `[%s, %s]`, crtShEntry(1, serial), crtShEntry(2, serial))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	crtsh := ctmonitor.NewCrtSh()
+	crtsh.BaseURL, _ = url.Parse(server.URL)
+
+	cert, err := lookupCertificate(context.Background(), crtsh, serial, root)
+	require.NoError(t, err)
+
+	assert.Equal(t, leaf.Raw, cert.Raw)
+
+	_, err = lookupCertificate(context.Background(), crtsh, serial, otherRoot)
+	require.Error(t, err)
+}
+
+func Test_getCertKey(t *testing.T) {
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(t.TempDir(), "example.com.key")
+
+	err = os.WriteFile(keyPath, certcrypto.PEMEncode(privateKey), 0o600)
+	require.NoError(t, err)
+
+	errFallback := errors.New("fallback")
+
+	fallback := func() (crypto.PrivateKey, error) { return nil, errFallback }
+
+	testCases := []struct {
+		desc        string
+		args        []string
+		expectedUse bool
+		expectedErr error
+	}{
+		{
+			desc: "account key",
+			args: []string{"revoke"},
+		},
+		{
+			desc:        "storage",
+			args:        []string{"revoke", "--cert-key"},
+			expectedUse: true,
+			expectedErr: errFallback,
+		},
+		{
+			desc:        "private key",
+			args:        []string{"revoke", "--private-key", keyPath},
+			expectedUse: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			runCommand(t, "revoke", createRevoke().Flags, func(ctx *cli.Context) error {
+				assert.Equal(t, test.expectedUse, useCertKey(ctx))
+
+				if !test.expectedUse {
+					return nil
+				}
+
+				key, err := getCertKey(ctx, fallback)
+				if test.expectedErr != nil {
+					require.ErrorIs(t, err, test.expectedErr)
+					return nil
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, privateKey, key)
+
+				return nil
+			}, test.args...)
+		})
+	}
+}
+
+func crtShEntry(id int, serial string) string {
+	return fmt.Sprintf(`{"id":%d,"issuer_name":"CN=Root","common_name":"example.com","name_value":"example.com",`+
+		`"serial_number":%q,"not_before":"2025-01-02T09:00:00","not_after":"2025-04-02T09:00:00"}`, id, serial)
+}
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"bufio"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
@@ -22,10 +26,15 @@ const (
 	flgNotAfter                       = "not-after"
 	flgPrivateKey                     = "private-key"
 	flgPreferredChain                 = "preferred-chain"
+	flgAllChains                      = "all-chains"
 	flgProfile                        = "profile"
 	flgAlwaysDeactivateAuthorizations = "always-deactivate-authorizations"
 	flgRunHook                        = "run-hook"
 	flgRunHookTimeout                 = "run-hook-timeout"
+	flgCAASet                         = "caa.set"
+	flgRunForce                       = "force"
+	flgOut                            = "out"
+	flgRunCert                        = "cert"
 )
 
 func createRun() *cli.Command {
@@ -33,6 +42,16 @@ func createRun() *cli.Command {
 		Name:  "run",
 		Usage: "Register an account, then create and install a certificate",
 		Before: func(ctx *cli.Context) error {
+			// The groups of domains (--cert) are checked in the context of each certificate.
+			if ctx.IsSet(flgRunCert) {
+				return checkCertificateGroups(ctx)
+			}
+
+			// The certificates of the configuration file are checked in their own context.
+			if useCertificateBlocks(ctx) {
+				return nil
+			}
+
 			// we require either domains or csr, but not both
 			hasDomains := len(ctx.StringSlice(flgDomains)) > 0
 
@@ -45,10 +64,10 @@ func createRun() *cli.Command {
 				log.Fatal("Please specify --domains/-d (or --csr/-c if you already have a CSR)")
 			}
 
-			return nil
+			return setupDryRun(ctx)
 		},
 		Action: run,
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:  flgNoBundle,
 				Usage: "Do not create a certificate bundle by adding the issuers certificate to the new certificate.",
@@ -74,12 +93,19 @@ func createRun() *cli.Command {
 			},
 			&cli.StringFlag{
 				Name: flgPreferredChain,
-				Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name." +
+				Usage: "If the CA offers multiple certificate chains, prefer the chain matching this value:" +
+					" the Subject Common Name or the Subject of the root issuer, or the SHA-256 fingerprint of a certificate of the chain." +
 					" If no match, the default offered chain will be used.",
 			},
+			&cli.BoolFlag{
+				Name: flgAllChains,
+				Usage: "Download and store all the certificate chains offered by the CA (<domain>.chain-<root>.crt)," +
+					" to be able to switch to another trust path without issuing a new certificate.",
+			},
 			&cli.StringFlag{
-				Name:  flgProfile,
-				Usage: "If the CA offers multiple certificate profiles (draft-ietf-acme-profiles), choose this one.",
+				Name: flgProfile,
+				Usage: "If the CA offers multiple certificate profiles (draft-ietf-acme-profiles), choose this one." +
+					" The 'profiles' command displays them.",
 			},
 			&cli.StringFlag{
 				Name:  flgAlwaysDeactivateAuthorizations,
@@ -91,10 +117,34 @@ func createRun() *cli.Command {
 			},
 			&cli.DurationFlag{
 				Name:  flgRunHookTimeout,
-				Usage: "Define the timeout for the hook execution.",
-				Value: 2 * time.Minute,
+				Usage: "Define the timeout for the hook execution. Overrides --" + flgHookTimeout + " for this hook.",
 			},
-		},
+			&cli.StringFlag{
+				Name: flgOut,
+				Usage: "Directory where the certificate files are written, instead of the certificates directory of the path." +
+					" The account and the resource file (.json) stay in the path.",
+				TakesFile: true,
+			},
+			&cli.GenericFlag{
+				Name: flgRunCert,
+				Usage: "Obtain a certificate for a group of domains (comma-separated), e.g. --" + flgRunCert + " \"example.com,www.example.com\" --" + flgRunCert + " example.org." +
+					" Repeat the option to obtain several independent certificates, with the same account and options, in one execution." +
+					" Can't be used with --" + flgDomains + "/-d, --" + flgCSR + "/-c, or --" + flgCertName + ".",
+				Value: &certificateGroups{},
+			},
+			&cli.BoolFlag{
+				Name: flgRunForce,
+				Usage: "Obtain a new certificate even if the stored certificate is still valid and covers the requested domains." +
+					" By default, the command does nothing in this case.",
+			},
+			&cli.BoolFlag{
+				Name: flgCAASet,
+				Usage: "Create the CAA records authorizing the CA, using the DNS provider (--dns), before requesting the certificate." +
+					" The DNS provider must support the management of CAA records.",
+			},
+			createStrictCAAFlag(),
+		}, slices.Concat(createCAAFlags(), createTLSAFlags(), createKeyRotationFlags(), createIssuerPolicyFlags(),
+			createDeployFlags(), createDryRunFlags(), createHookFlags(), createLockFlags())...),
 	}
 }
 
@@ -110,45 +160,212 @@ server. Making regular backups of this folder is ideal.
 `
 
 func run(ctx *cli.Context) error {
-	accountsStorage := NewAccountsStorage(ctx)
+	unlock, err := acquireLock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	switch {
+	case ctx.IsSet(flgRunCert):
+		return forEachCertificateGroup(ctx, runCertificate)
+
+	case useCertificateBlocks(ctx):
+		return forEachCertificateBlock(ctx, runCertificate)
+
+	default:
+		return runCertificate(ctx)
+	}
+}
+
+// runCertificate obtains the certificate defined by the domains or the CSR.
+func runCertificate(ctx *cli.Context) error {
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(flgDryRun) {
+		cleanup, errC := useDryRunStorage(certsStorage)
+		if errC != nil {
+			return errC
+		}
+
+		defer cleanup()
+	}
+
+	// A dry run always obtains a certificate.
+	if !ctx.Bool(flgRunForce) && !ctx.Bool(flgDryRun) {
+		domain, cert, errF := findValidCertificate(ctx, certsStorage)
+		if errF != nil {
+			return errF
+		}
 
-	account, keyType := setupAccount(ctx, accountsStorage)
+		if cert != nil {
+			log.Infof("[%s] The stored certificate is valid until %s and covers the requested domains, nothing to do. Use --%s to obtain a new certificate.",
+				domain, cert.NotAfter.Format(time.RFC3339), flgRunForce)
+
+			writeRunJSON(ctx, runOutput{
+				Domain:      domain,
+				Decision:    renewalSkipped,
+				Reason:      "the stored certificate is valid and covers the requested domains",
+				Certificate: newCertificateOutput(certsStorage, domain, cert),
+			}, nil)
+
+			return nil
+		}
+	}
+
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, keyType, err := setupAccount(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
 
-	client := setupClient(ctx, account, keyType)
+	client, err := setupClient(ctx, account, keyType)
+	if err != nil {
+		return err
+	}
 
 	if account.Registration == nil {
-		reg, err := register(ctx, client)
+		err = registerAccount(ctx, accountsStorage, account, client)
 		if err != nil {
-			log.Fatalf("Could not complete registration\n\t%v", err)
+			return err
 		}
+	}
 
-		account.Registration = reg
-		if err = accountsStorage.Save(account); err != nil {
-			log.Fatal(err)
+	if ctx.Bool(flgCAASet) {
+		var accountURI string
+		if ctx.Bool(flgCAABindAccount) {
+			accountURI = account.Registration.URI
 		}
 
-		fmt.Printf(rootPathWarningMessage, accountsStorage.GetRootPath())
+		err = setCAA(ctx, client, accountURI)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = certsStorage.CreateRootFolder()
+	if err != nil {
+		return err
+	}
+
+	meta := map[string]string{
+		hookEnvAccountEmail: account.Email,
+	}
+
+	if domain, errD := getRenewalDomain(ctx); errD == nil {
+		meta[hookEnvCertDomain] = domain
+	}
+
+	postHook, err := launchPreHook(ctx, meta)
+
+	var cert *certificate.Resource
+	if err == nil {
+		cert, err = obtainCertificate(ctx, client, account)
+	}
+
+	if err != nil {
+		postHook(&err)
+
+		writeRunJSON(ctx, runOutput{Decision: renewalFailed}, err)
+
+		// The other certificates of the execution (--cert, configuration file) are still obtained.
+		return fmt.Errorf("could not obtain certificates: %w", err)
+	}
+
+	output := runOutput{Domain: cert.Domain, Decision: runObtained}
+
+	err = installCertificate(ctx, client, account, certsStorage, cert)
+
+	errM := addPathToMetadata(meta, cert.Domain, cert, certsStorage)
+	if err == nil {
+		err = errM
+	}
+
+	postHook(&err)
+
+	if err != nil {
+		output.Decision = renewalFailed
+	} else if certificates, errP := certcrypto.ParsePEMBundle(cert.Certificate); errP == nil {
+		output.Certificate = newCertificateOutput(certsStorage, cert.Domain, certificates[0])
+	}
+
+	writeRunJSON(ctx, output, err)
+
+	return err
+}
+
+// installCertificate checks the issuer of the obtained certificate, saves the certificate, publishes the TLSA records,
+// deploys the certificate, and launches the hook.
+func installCertificate(ctx *cli.Context, client *lego.Client, account *Account, certsStorage *CertificatesStorage, cert *certificate.Resource) error {
+	err := checkIssuerPolicy(ctx, cert)
+	if err != nil {
+		return err
+	}
+
+	exists, err := certsStorage.ExistsFile(cert.Domain, certExt)
+	if err != nil {
+		return err
+	}
+
+	var previous []*x509.Certificate
+	if exists {
+		previous, _ = certsStorage.ReadCertificate(cert.Domain, certExt)
 	}
 
-	certsStorage := NewCertificatesStorage(ctx)
-	certsStorage.CreateRootFolder()
+	metadata := &ResourceMetadata{}
 
-	cert, err := obtainCertificate(ctx, client)
+	if keyRotation := getKeyRotation(ctx, nil); keyRotation != nil {
+		keyRotation.Renewed(time.Now(), false)
+		metadata.KeyRotation = keyRotation
+	}
+
+	err = certsStorage.saveResource(cert, metadata)
 	if err != nil {
-		// Make sure to return a non-zero exit code if ObtainSANCertificate returned at least one error.
-		// Due to us not returning partial certificate we can just exit here instead of at the end.
-		log.Fatalf("Could not obtain certificates:\n\t%v", err)
+		return err
 	}
 
-	certsStorage.SaveResource(cert)
+	err = certsStorage.SaveRenewalConfig(cert.Domain, newRenewalConfig(ctx))
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(flgAllChains) {
+		err = saveAllChains(ctx, client, certsStorage, cert)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(ctx.StringSlice(flgTLSAPort)) > 0 {
+		err = publishTLSA(ctx, cert, previous)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = deployCertificate(ctx, cert)
+	if err != nil {
+		return err
+	}
 
 	meta := map[string]string{
 		hookEnvAccountEmail: account.Email,
 	}
 
-	addPathToMetadata(meta, cert.Domain, cert, certsStorage)
+	err = addPathToMetadata(meta, cert.Domain, cert, certsStorage)
+	if err != nil {
+		return err
+	}
 
-	return launchHook(ctx.String(flgRunHook), ctx.Duration(flgRunHookTimeout), meta)
+	return launchCertificateHook(ctx, ctx.String(flgRunHook), getHookTimeout(ctx, flgRunHookTimeout), meta, cert)
 }
 
 func handleTOS(ctx *cli.Context, client *lego.Client) bool {
@@ -159,10 +376,10 @@ func handleTOS(ctx *cli.Context, client *lego.Client) bool {
 
 	reader := bufio.NewReader(os.Stdin)
 
-	log.Printf("Please review the TOS at %s", client.GetToSURL())
+	log.Infof("Please review the TOS at %s", client.GetToSURL())
 
 	for {
-		fmt.Println("Do you accept the TOS? Y/n")
+		_, _ = fmt.Fprintln(textOutput, "Do you accept the TOS? Y/n")
 
 		text, err := reader.ReadString('\n')
 		if err != nil {
@@ -176,15 +393,32 @@ func handleTOS(ctx *cli.Context, client *lego.Client) bool {
 		case "n", "N":
 			return false
 		default:
-			fmt.Println("Your input was invalid. Please answer with one of Y/y, n/N or by pressing enter.")
+			_, _ = fmt.Fprintln(textOutput, "Your input was invalid. Please answer with one of Y/y, n/N or by pressing enter.")
 		}
 	}
 }
 
+// registerAccount registers the account, and saves it.
+func registerAccount(ctx *cli.Context, accountsStorage *AccountsStorage, account *Account, client *lego.Client) error {
+	reg, err := register(ctx, client)
+	if err != nil {
+		return fmt.Errorf("could not complete registration: %w", err)
+	}
+
+	account.Registration = reg
+	if err = accountsStorage.Save(account); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(textOutput, rootPathWarningMessage, accountsStorage.GetRootPath())
+
+	return nil
+}
+
 func register(ctx *cli.Context, client *lego.Client) (*registration.Resource, error) {
 	accepted := handleTOS(ctx, client)
 	if !accepted {
-		log.Fatal("You did not accept the TOS. Unable to proceed.")
+		return nil, errors.New("you did not accept the TOS: unable to proceed")
 	}
 
 	if ctx.Bool(flgEAB) {
@@ -192,7 +426,7 @@ func register(ctx *cli.Context, client *lego.Client) (*registration.Resource, er
 		hmacEncoded := ctx.String(flgHMAC)
 
 		if kid == "" || hmacEncoded == "" {
-			log.Fatalf("Requires arguments --%s and --%s.", flgKID, flgHMAC)
+			return nil, fmt.Errorf("requires arguments --%s and --%s", flgKID, flgHMAC)
 		}
 
 		return client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
@@ -202,10 +436,33 @@ func register(ctx *cli.Context, client *lego.Client) (*registration.Resource, er
 		})
 	}
 
+	credentials, err := provisionEAB(ctx, getServer(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if credentials != nil {
+		return client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
+			TermsOfServiceAgreed: accepted,
+			Kid:                  credentials.KID,
+			HmacEncoded:          credentials.HMACEncoded,
+		})
+	}
+
 	return client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
 }
 
-func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Resource, error) {
+// saveAllChains downloads and stores all the certificate chains offered by the CA (--all-chains).
+func saveAllChains(ctx *cli.Context, client *lego.Client, certsStorage *CertificatesStorage, certRes *certificate.Resource) error {
+	chains, err := client.Certificate.GetAllChains(certRes.CertURL, !ctx.Bool(flgNoBundle))
+	if err != nil {
+		return fmt.Errorf("could not get the certificate chains for domain %s: %w", certRes.Domain, err)
+	}
+
+	return certsStorage.WriteChainFiles(certRes.Domain, chains)
+}
+
+func obtainCertificate(ctx *cli.Context, client *lego.Client, account *Account) (*certificate.Resource, error) {
 	bundle := !ctx.Bool(flgNoBundle)
 
 	domains := ctx.StringSlice(flgDomains)
@@ -223,15 +480,23 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 		}
 
 		if ctx.IsSet(flgPrivateKey) {
-			var err error
+			passphrase, err := getKeyPassphrase(ctx)
+			if err != nil {
+				return nil, err
+			}
 
-			request.PrivateKey, err = loadPrivateKey(ctx.String(flgPrivateKey))
+			request.PrivateKey, err = loadPrivateKey(ctx.String(flgPrivateKey), passphrase)
 			if err != nil {
 				return nil, fmt.Errorf("load private key: %w", err)
 			}
 		}
 
-		return client.Certificate.Obtain(request)
+		err := preflightCAA(ctx, client, account, domains)
+		if err != nil {
+			return nil, err
+		}
+
+		return client.Certificate.ObtainWithContext(ctx.Context, request)
 	}
 
 	// read the CSR
@@ -252,13 +517,83 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 	}
 
 	if ctx.IsSet(flgPrivateKey) {
-		var err error
+		passphrase, err := getKeyPassphrase(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-		request.PrivateKey, err = loadPrivateKey(ctx.String(flgPrivateKey))
+		request.PrivateKey, err = loadPrivateKey(ctx.String(flgPrivateKey), passphrase)
 		if err != nil {
 			return nil, fmt.Errorf("load private key: %w", err)
 		}
 	}
 
-	return client.Certificate.ObtainForCSR(request)
+	err = preflightCAA(ctx, client, account, certcrypto.ExtractDomainsCSR(csr))
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Certificate.ObtainForCSRWithContext(ctx.Context, request)
+}
+
+// findValidCertificate returns the stored certificate if it is not expired and covers the requested domains.
+func findValidCertificate(ctx *cli.Context, certsStorage *CertificatesStorage) (string, *x509.Certificate, error) {
+	domains := ctx.StringSlice(flgDomains)
+
+	var domain string
+
+	if len(domains) > 0 {
+		domain = domains[0]
+	} else {
+		csr, err := readCSRFile(ctx.String(flgCSR))
+		if err != nil {
+			return "", nil, err
+		}
+
+		domain, err = certcrypto.GetCSRMainDomain(csr)
+		if err != nil {
+			return "", nil, err
+		}
+
+		domains = certcrypto.ExtractDomainsCSR(csr)
+	}
+
+	exists, err := certsStorage.ExistsFile(domain, certExt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !exists {
+		return domain, nil, nil
+	}
+
+	certificates, err := certsStorage.ReadCertificate(domain, certExt)
+	if err != nil {
+		log.Warnf("[%s] Unable to read the stored certificate: %v", domain, err)
+
+		return domain, nil, nil
+	}
+
+	if !isValidFor(certificates[0], domains, time.Now()) {
+		return domain, nil, nil
+	}
+
+	return domain, certificates[0], nil
+}
+
+// isValidFor returns true if the certificate is not expired and covers all the domains.
+func isValidFor(cert *x509.Certificate, domains []string, now time.Time) bool {
+	if now.After(cert.NotAfter) || now.Before(cert.NotBefore) {
+		return false
+	}
+
+	certDomains := certcrypto.ExtractDomains(cert)
+
+	for _, domain := range domains {
+		if !slices.Contains(certDomains, domain) {
+			return false
+		}
+	}
+
+	return true
 }
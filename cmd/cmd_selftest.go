@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/urfave/cli/v2"
+)
+
+func createSelfTest() *cli.Command {
+	return &cli.Command{
+		Name: "selftest",
+		Usage: "Run a full issue, renew, and revoke cycle against a local embedded ACME server," +
+			" using the challenge configuration, to check it before using a production server.",
+		Before: func(ctx *cli.Context) error {
+			if len(ctx.StringSlice(flgDomains)) == 0 {
+				return fmt.Errorf("please specify --%s/-d", flgDomains)
+			}
+
+			return nil
+		},
+		Action: selfTest,
+	}
+}
+
+type selfTestStep struct {
+	name string
+	err  error
+}
+
+func selfTest(ctx *cli.Context) error {
+	server, err := pebble.New(pebble.WithValidator(selfTestValidator(ctx)))
+	if err != nil {
+		return fmt.Errorf("selftest: start the local ACME server: %w", err)
+	}
+
+	defer server.Close()
+
+	log.Infof("selftest: local ACME server started: %s", server.URL())
+
+	steps := runSelfTest(ctx, server)
+
+	var failed bool
+
+	for _, step := range steps {
+		if step.err != nil {
+			failed = true
+
+			fmt.Printf("[FAIL] %s: %v\n", step.name, step.err)
+
+			continue
+		}
+
+		fmt.Printf("[OK]   %s\n", step.name)
+	}
+
+	if failed {
+		return errors.New("selftest: failed")
+	}
+
+	return nil
+}
+
+func runSelfTest(ctx *cli.Context, server *pebble.Server) []selfTestStep {
+	var steps []selfTestStep
+
+	keyType, err := getKeyType(ctx)
+	if err != nil {
+		return append(steps, selfTestStep{name: "create the account key", err: err})
+	}
+
+	privateKey, err := certcrypto.GeneratePrivateKey(keyType)
+	if err != nil {
+		return append(steps, selfTestStep{name: "create the account key", err: err})
+	}
+
+	account := &Account{Email: ctx.String(flgEmail), key: privateKey}
+
+	config := lego.NewConfig(account)
+	config.CADirURL = server.URL()
+	config.HTTPClient = server.Client()
+	config.Certificate = lego.CertificateConfig{
+		KeyType: keyType,
+		Timeout: getOrderTimeout(ctx),
+	}
+	config.UserAgent = getUserAgent(ctx)
+
+	client, err := lego.NewClient(config)
+	if err != nil {
+		return append(steps, selfTestStep{name: "create the client", err: err})
+	}
+
+	err = setupChallenges(ctx, client)
+	if err != nil {
+		return append(steps, selfTestStep{name: "set up the challenges", err: err})
+	}
+
+	account.Registration, err = client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
+
+	steps = append(steps, selfTestStep{name: "register a temporary account", err: err})
+	if err != nil {
+		return steps
+	}
+
+	request := certificate.ObtainRequest{
+		Domains: ctx.StringSlice(flgDomains),
+		Bundle:  true,
+	}
+
+	certRes, err := client.Certificate.Obtain(request)
+
+	steps = append(steps, selfTestStep{name: "issue a certificate", err: err})
+	if err != nil {
+		return steps
+	}
+
+	leaf, err := certcrypto.ParsePEMCertificate(certRes.Certificate)
+	if err == nil {
+		request.ReplacesCertID, err = certificate.MakeARICertID(leaf)
+	}
+
+	if err == nil {
+		certRes, err = client.Certificate.Obtain(request)
+	}
+
+	steps = append(steps, selfTestStep{name: "renew the certificate", err: err})
+	if err != nil {
+		return steps
+	}
+
+	err = client.Certificate.Revoke(certRes.Certificate)
+
+	return append(steps, selfTestStep{name: "revoke the certificate", err: err})
+}
+
+// selfTestValidator validates the challenges like a real ACME server would do, where possible:
+// the standalone solvers are contacted locally, the other HTTP-01 solvers through the domain,
+// and the DNS-01 records are resolved with the system resolver.
+func selfTestValidator(ctx *cli.Context) pebble.Validator {
+	standaloneHTTP := !ctx.IsSet(flgHTTPWebroot) && !ctx.IsSet(flgHTTPMemcachedHost) && !ctx.IsSet(flgHTTPS3Bucket)
+
+	return func(chlg acme.Challenge, identifier acme.Identifier, keyAuth string) error {
+		switch chlg.Type {
+		case "http-01":
+			addr := net.JoinHostPort(identifier.Value, "80")
+			if standaloneHTTP {
+				addr = localAddr(ctx.String(flgHTTPPort))
+			}
+
+			return pebble.SolverValidator(addr, "")(chlg, identifier, keyAuth)
+
+		case "tls-alpn-01":
+			return pebble.SolverValidator("", localAddr(ctx.String(flgTLSPort)))(chlg, identifier, keyAuth)
+
+		case "dns-01":
+			info := dns01.GetChallengeInfo(strings.TrimPrefix(identifier.Value, "*."), keyAuth)
+
+			values, err := net.LookupTXT(info.EffectiveFQDN)
+			if err != nil {
+				return err
+			}
+
+			if !slices.Contains(values, info.Value) {
+				return fmt.Errorf("the TXT record %s doesn't contain the expected value", info.EffectiveFQDN)
+			}
+
+			return nil
+
+		default:
+			return fmt.Errorf("unsupported challenge type: %s", chlg.Type)
+		}
+	}
+}
+
+// localAddr converts the listen address of a standalone solver ("interface:port" or ":port") to a dialable address.
+func localAddr(iface string) string {
+	host, port, err := net.SplitHostPort(iface)
+	if err != nil {
+		return iface
+	}
+
+	if host == "" {
+		host = "127.0.0.1"
+	}
+
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgSyncForce    = "force"
+	flgSyncWatch    = "watch"
+	flgSyncInterval = "interval"
+)
+
+func createStorage() *cli.Command {
+	return &cli.Command{
+		Name:  "storage",
+		Usage: "Manage the stored certificates",
+		Subcommands: []*cli.Command{
+			{
+				Name: "sync",
+				Usage: "Create again the files derived from the certificates and the private keys (.pem, .pfx, .der)" +
+					" when they are missing, older than the certificate or the private key, or when the output options have changed" +
+					" (e.g. --pfx enabled after the issuance). Uses the domains (--domains) or all the stored certificates." +
+					" Java KeyStore files (.jks) are not created: the .pfx file can be used as a PKCS#12 Java keystore.",
+				Action: storageSync,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  flgSyncForce,
+						Usage: "Create the files even if they are up to date (e.g. after a change of the PFX password).",
+					},
+					&cli.BoolFlag{
+						Name:  flgSyncWatch,
+						Usage: "Keep running, and check the files at each interval.",
+					},
+					&cli.DurationFlag{
+						Name:  flgSyncInterval,
+						Usage: "The interval between two checks of the files (with --" + flgSyncWatch + ").",
+						Value: time.Minute,
+					},
+				},
+			},
+		},
+	}
+}
+
+func storageSync(ctx *cli.Context) error {
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = certsStorage.CreateRootFolder()
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Bool(flgSyncWatch) {
+		return syncDerivativeFiles(certsStorage, ctx.StringSlice(flgDomains), ctx.Bool(flgSyncForce))
+	}
+
+	sigCtx, stop := signal.NotifyContext(ctx.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watchDerivativeFiles(sigCtx, certsStorage, ctx.StringSlice(flgDomains), ctx.Duration(flgSyncInterval), ctx.Bool(flgSyncForce))
+
+	return nil
+}
+
+// watchDerivativeFiles synchronizes the derivative files at each interval, until the context is done.
+// The files are only forced during the first synchronization.
+func watchDerivativeFiles(ctx context.Context, certsStorage *CertificatesStorage, domains []string, interval time.Duration, force bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := syncDerivativeFiles(certsStorage, domains, force)
+		if err != nil {
+			log.Warnf("storage sync: %v", err)
+		}
+
+		force = false
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncDerivativeFiles synchronizes the derivative files of the domains, or of all the stored certificates.
+func syncDerivativeFiles(certsStorage *CertificatesStorage, domains []string, force bool) error {
+	if len(domains) == 0 {
+		var err error
+
+		domains, err = certsStorage.ListDomains()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, domain := range domains {
+		updated, err := certsStorage.SyncDerivativeFiles(domain, force)
+		if err != nil {
+			return fmt.Errorf("[%s] %w", domain, err)
+		}
+
+		if updated {
+			log.Infof("[%s] The derivative files have been created.", domain)
+		}
+	}
+
+	return nil
+}
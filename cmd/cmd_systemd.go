@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgSystemdName            = "name"
+	flgSystemdDir             = "dir"
+	flgSystemdDaemon          = "daemon"
+	flgSystemdOnCalendar      = "on-calendar"
+	flgSystemdRandomizedDelay = "randomized-delay"
+	flgSystemdWatchdog        = "watchdog"
+	flgSystemdEnvFile         = "env-file"
+)
+
+const defaultSystemdDir = "/etc/systemd/system"
+
+func createSystemd() *cli.Command {
+	return &cli.Command{
+		Name:  "systemd",
+		Usage: "Integration with systemd",
+		Subcommands: []*cli.Command{
+			{
+				Name: "install",
+				Usage: "Write a service and a timer renewing the stored certificates (renew --all), or a service running the daemon command (--daemon)." +
+					" The units use the global options of the current command line, the arguments after '--' are added to the renew or daemon command.",
+				ArgsUsage: "[-- <options of the renew or daemon command>]",
+				Action:    systemdInstall,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  flgSystemdName,
+						Usage: "The name of the units (<name>.service, <name>.timer).",
+						Value: "lego",
+					},
+					&cli.StringFlag{
+						Name:      flgSystemdDir,
+						Usage:     "The directory where the units are written, '-' writes to stdout.",
+						Value:     defaultSystemdDir,
+						TakesFile: true,
+					},
+					&cli.BoolFlag{
+						Name:  flgSystemdDaemon,
+						Usage: "Write a service running the daemon command (Type=notify), instead of a service and a timer.",
+					},
+					&cli.StringFlag{
+						Name:  flgSystemdOnCalendar,
+						Usage: "The calendar event of the timer (OnCalendar).",
+						Value: "*-*-* 00,12:00:00",
+					},
+					&cli.DurationFlag{
+						Name:  flgSystemdRandomizedDelay,
+						Usage: "The random delay added to the calendar event of the timer (RandomizedDelaySec), to spread the load on the CA.",
+						Value: 12 * time.Hour,
+					},
+					&cli.DurationFlag{
+						Name:  flgSystemdWatchdog,
+						Usage: "The watchdog timeout of the daemon service (WatchdogSec), 0 disables the watchdog.",
+						Value: 5 * time.Minute,
+					},
+					&cli.StringFlag{
+						Name: flgSystemdEnvFile,
+						Usage: "The environment file of the service (EnvironmentFile), e.g. for the credentials of the DNS provider." +
+							" The environment variables of the current command line are not written in the units.",
+						TakesFile: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// systemdUnit a unit file.
+type systemdUnit struct {
+	Name    string
+	Content string
+}
+
+// systemdOptions the options of the units.
+type systemdOptions struct {
+	Name             string
+	Command          []string
+	WorkingDirectory string
+	EnvFile          string
+	Daemon           bool
+	OnCalendar       string
+	RandomizedDelay  time.Duration
+	Watchdog         time.Duration
+}
+
+func systemdInstall(ctx *cli.Context) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("systemd: %w", err)
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("systemd: %w", err)
+	}
+
+	opts := systemdOptions{
+		Name:             ctx.String(flgSystemdName),
+		WorkingDirectory: workingDirectory,
+		EnvFile:          ctx.String(flgSystemdEnvFile),
+		Daemon:           ctx.Bool(flgSystemdDaemon),
+		OnCalendar:       ctx.String(flgSystemdOnCalendar),
+		RandomizedDelay:  ctx.Duration(flgSystemdRandomizedDelay),
+		Watchdog:         ctx.Duration(flgSystemdWatchdog),
+	}
+
+	opts.Command = append([]string{executable}, globalArguments(os.Args[1:], "systemd")...)
+
+	if opts.Daemon {
+		opts.Command = append(opts.Command, "daemon")
+	} else {
+		opts.Command = append(opts.Command, "renew", "--"+flgRenewAll)
+	}
+
+	opts.Command = append(opts.Command, ctx.Args().Slice()...)
+
+	units := newSystemdUnits(opts)
+
+	dir := ctx.String(flgSystemdDir)
+
+	if dir == "-" {
+		return writeSystemdUnits(ctx.App.Writer, units)
+	}
+
+	for _, unit := range units {
+		filename := filepath.Join(dir, unit.Name)
+
+		err = os.WriteFile(filename, []byte(unit.Content), filePerm)
+		if err != nil {
+			return fmt.Errorf("systemd: %w", err)
+		}
+
+		_, _ = fmt.Fprintf(ctx.App.Writer, "%s written.\n", filename)
+	}
+
+	// The timer starts the service, the service of the daemon is started directly.
+	enabled := units[len(units)-1].Name
+
+	_, _ = fmt.Fprintf(ctx.App.Writer, "To enable the renewal, run:\n\n\tsystemctl daemon-reload && systemctl enable --now %s\n", enabled)
+
+	return nil
+}
+
+// writeSystemdUnits writes the units, separated by a comment with the name of the unit.
+func writeSystemdUnits(w io.Writer, units []systemdUnit) error {
+	for i, unit := range units {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+
+		_, err := fmt.Fprintf(w, "# %s\n%s", unit.Name, unit.Content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newSystemdUnits creates the service and the timer, or the service of the daemon.
+func newSystemdUnits(opts systemdOptions) []systemdUnit {
+	service := &strings.Builder{}
+
+	_, _ = fmt.Fprintln(service, "[Unit]")
+
+	if opts.Daemon {
+		_, _ = fmt.Fprintln(service, "Description=Renew the certificates with lego (daemon)")
+	} else {
+		_, _ = fmt.Fprintln(service, "Description=Renew the certificates with lego")
+	}
+
+	_, _ = fmt.Fprintln(service, "Documentation=https://go-acme.github.io/lego/")
+	_, _ = fmt.Fprintln(service, "Wants=network-online.target")
+	_, _ = fmt.Fprintln(service, "After=network-online.target")
+	_, _ = fmt.Fprintln(service)
+	_, _ = fmt.Fprintln(service, "[Service]")
+
+	if opts.Daemon {
+		_, _ = fmt.Fprintln(service, "Type=notify")
+	} else {
+		_, _ = fmt.Fprintln(service, "Type=oneshot")
+	}
+
+	_, _ = fmt.Fprintf(service, "WorkingDirectory=%s\n", systemdQuote(opts.WorkingDirectory))
+
+	if opts.EnvFile != "" {
+		_, _ = fmt.Fprintf(service, "EnvironmentFile=%s\n", systemdQuote(opts.EnvFile))
+	}
+
+	_, _ = fmt.Fprintf(service, "ExecStart=%s\n", systemdCommandLine(opts.Command))
+
+	if !opts.Daemon {
+		return []systemdUnit{
+			{Name: opts.Name + ".service", Content: service.String()},
+			{Name: opts.Name + ".timer", Content: newSystemdTimer(opts)},
+		}
+	}
+
+	_, _ = fmt.Fprintln(service, "Restart=on-failure")
+
+	if opts.Watchdog > 0 {
+		_, _ = fmt.Fprintf(service, "WatchdogSec=%s\n", systemdTimeSpan(opts.Watchdog))
+	}
+
+	_, _ = fmt.Fprintln(service)
+	_, _ = fmt.Fprintln(service, "[Install]")
+	_, _ = fmt.Fprintln(service, "WantedBy=multi-user.target")
+
+	return []systemdUnit{{Name: opts.Name + ".service", Content: service.String()}}
+}
+
+func newSystemdTimer(opts systemdOptions) string {
+	timer := &strings.Builder{}
+
+	_, _ = fmt.Fprintln(timer, "[Unit]")
+	_, _ = fmt.Fprintln(timer, "Description=Renew the certificates with lego")
+	_, _ = fmt.Fprintln(timer)
+	_, _ = fmt.Fprintln(timer, "[Timer]")
+	_, _ = fmt.Fprintf(timer, "OnCalendar=%s\n", opts.OnCalendar)
+
+	if opts.RandomizedDelay > 0 {
+		_, _ = fmt.Fprintf(timer, "RandomizedDelaySec=%s\n", systemdTimeSpan(opts.RandomizedDelay))
+	}
+
+	_, _ = fmt.Fprintln(timer, "Persistent=true")
+	_, _ = fmt.Fprintln(timer)
+	_, _ = fmt.Fprintln(timer, "[Install]")
+	_, _ = fmt.Fprintln(timer, "WantedBy=timers.target")
+
+	return timer.String()
+}
+
+// globalArguments returns the arguments before the command (the global options).
+func globalArguments(args []string, command string) []string {
+	index := slices.Index(args, command)
+	if index < 0 {
+		return nil
+	}
+
+	return slices.Clone(args[:index])
+}
+
+// systemdCommandLine returns the command line of ExecStart.
+func systemdCommandLine(command []string) string {
+	var quoted []string
+
+	for _, arg := range command {
+		quoted = append(quoted, systemdQuote(arg))
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// systemdQuote quotes the value, and escapes the specifiers (%) and the variables ($) of systemd.
+func systemdQuote(value string) string {
+	value = strings.NewReplacer("%", "%%", "$", "$$").Replace(value)
+
+	if value != "" && !strings.ContainsAny(value, " \t\"'\\;") {
+		return value
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// systemdTimeSpan returns the duration in seconds (the default unit of the time spans of systemd).
+func systemdTimeSpan(d time.Duration) string {
+	return strconv.FormatInt(int64(d.Seconds()), 10)
+}
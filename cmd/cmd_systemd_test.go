@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newSystemdUnits(t *testing.T) {
+	opts := systemdOptions{
+		Name:             "lego",
+		Command:          []string{"/usr/bin/lego", "--email", "you@example.com", "--dns", "gandiv5", "renew", "--all", "--renew-hook", "./my hook.sh"},
+		WorkingDirectory: "/var/lib/lego",
+		OnCalendar:       "*-*-* 00,12:00:00",
+		RandomizedDelay:  12 * time.Hour,
+	}
+
+	units := newSystemdUnits(opts)
+	require.Len(t, units, 2)
+
+	assert.Equal(t, "lego.service", units[0].Name)
+	assert.Equal(t, `[Unit]
+Description=Renew the certificates with lego
+Documentation=https://go-acme.github.io/lego/
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+Type=oneshot
+WorkingDirectory=/var/lib/lego
+ExecStart=/usr/bin/lego --email you@example.com --dns gandiv5 renew --all --renew-hook "./my hook.sh"
+`, units[0].Content)
+
+	assert.Equal(t, "lego.timer", units[1].Name)
+	assert.Equal(t, `[Unit]
+Description=Renew the certificates with lego
+
+[Timer]
+OnCalendar=*-*-* 00,12:00:00
+RandomizedDelaySec=43200
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`, units[1].Content)
+}
+
+func Test_newSystemdUnits_daemon(t *testing.T) {
+	opts := systemdOptions{
+		Name:             "lego-daemon",
+		Command:          []string{"/usr/bin/lego", "--dns", "gandiv5", "daemon"},
+		WorkingDirectory: "/var/lib/lego",
+		EnvFile:          "/etc/lego/lego.env",
+		Daemon:           true,
+		Watchdog:         5 * time.Minute,
+	}
+
+	units := newSystemdUnits(opts)
+	require.Len(t, units, 1)
+
+	assert.Equal(t, "lego-daemon.service", units[0].Name)
+	assert.Equal(t, `[Unit]
+Description=Renew the certificates with lego (daemon)
+Documentation=https://go-acme.github.io/lego/
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+Type=notify
+WorkingDirectory=/var/lib/lego
+EnvironmentFile=/etc/lego/lego.env
+ExecStart=/usr/bin/lego --dns gandiv5 daemon
+Restart=on-failure
+WatchdogSec=300
+
+[Install]
+WantedBy=multi-user.target
+`, units[0].Content)
+}
+
+func Test_globalArguments(t *testing.T) {
+	args := []string{"--email", "you@example.com", "--dns", "gandiv5", "systemd", "install", "--daemon"}
+
+	assert.Equal(t, []string{"--email", "you@example.com", "--dns", "gandiv5"}, globalArguments(args, "systemd"))
+	assert.Empty(t, globalArguments([]string{"systemd", "install"}, "systemd"))
+}
+
+func Test_systemdQuote(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "--all", expected: "--all"},
+		{value: "./my hook.sh", expected: `"./my hook.sh"`},
+		{value: `say "hello"`, expected: `"say \"hello\""`},
+		{value: "100%", expected: "100%%"},
+		{value: "$HOME", expected: "$$HOME"},
+		{value: "", expected: `""`},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.value, func(t *testing.T) {
+			assert.Equal(t, test.expected, systemdQuote(test.value))
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// configEnvSection the section of the configuration file containing the environment variables (e.g. the DNS provider credentials).
+const configEnvSection = "env"
+
+// configFile the content of a configuration file written by the init command.
+// The keys are the names of the global flags.
+type configFile struct {
+	Email     string            `toml:"email,omitempty"      yaml:"email,omitempty"`
+	Server    string            `toml:"server,omitempty"     yaml:"server,omitempty"`
+	AcceptTOS bool              `toml:"accept-tos,omitempty" yaml:"accept-tos,omitempty"`
+	KeyType   string            `toml:"key-type,omitempty"   yaml:"key-type,omitempty"`
+	Domains   []string          `toml:"domains,omitempty"    yaml:"domains,omitempty"`
+	HTTP      bool              `toml:"http,omitempty"       yaml:"http,omitempty"`
+	TLS       bool              `toml:"tls,omitempty"        yaml:"tls,omitempty"`
+	DNS       string            `toml:"dns,omitempty"        yaml:"dns,omitempty"`
+	Env       map[string]string `toml:"env,omitempty"        yaml:"env,omitempty"`
+}
+
+// The values of the options are resolved in layers, the first defined value wins:
+//  1. the flags of the command line.
+//  2. the environment variables (LEGO_<FLAG>, LEGO_<COMMAND>_<FLAG>),
+//     or the content of the file defined by the environment variable suffixed by _FILE (e.g. LEGO_HMAC_FILE).
+//  3. the configuration file (--config): the options of the certificate (see forEachCertificateBlock),
+//     the global options at the top level, and the options of a command in the table of the command (e.g. [renew], [orders.list]).
+//  4. the default values.
+//
+// The environment variables of the DNS providers follow the same precedence:
+// the environment variable, then the _FILE variant, then the env section of the configuration file.
+
+// configValuesKey the key of the application metadata containing the values of the configuration file.
+const configValuesKey = "config-values"
+
+// applyConfigFile resolves the global options (see the layers above),
+// and defines the environment variables of the env section of the configuration file.
+func applyConfigFile(ctx *cli.Context) error {
+	values, err := loadConfigFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		switch {
+		case key == configEnvSection:
+			err = applyConfigEnv(ctx, value)
+			if err != nil {
+				return err
+			}
+
+		case key == configCertificatesSection:
+			// Applied by the run and renew commands (forEachCertificateBlock).
+			_, err = getCertificateBlocks(ctx)
+			if err != nil {
+				return err
+			}
+
+		case isCommandSection(ctx.App.Commands, key, value):
+			// Applied by the command (applyCommandConfig).
+
+		case !slices.ContainsFunc(ctx.App.Flags, func(flag cli.Flag) bool { return slices.Contains(flag.Names(), key) }):
+			return fmt.Errorf("unknown option: %q", key)
+		}
+	}
+
+	return resolveFlags(ctx, ctx.App.Flags, values)
+}
+
+// withConfigLayers resolves the options of the command (see the layers above) before the Before function of the command.
+// The path is the names of the command and its parents (e.g. ["orders", "list"]).
+func withConfigLayers(path []string, before cli.BeforeFunc) cli.BeforeFunc {
+	return func(ctx *cli.Context) error {
+		err := applyCommandConfig(ctx, path)
+		if err != nil {
+			return fmt.Errorf("[%s] %w", strings.Join(path, "."), err)
+		}
+
+		if before != nil {
+			return before(ctx)
+		}
+
+		return nil
+	}
+}
+
+// applyCommandConfig resolves the options of the command with the table of the command in the configuration file.
+func applyCommandConfig(ctx *cli.Context, path []string) error {
+	values, err := loadConfigFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range path {
+		table, ok := values[name].(map[string]any)
+		if !ok {
+			values = nil
+			break
+		}
+
+		values = table
+	}
+
+	for key, value := range values {
+		if isCommandSection(ctx.Command.Subcommands, key, value) {
+			continue
+		}
+
+		if !slices.ContainsFunc(ctx.Command.Flags, func(flag cli.Flag) bool { return slices.Contains(flag.Names(), key) }) {
+			return fmt.Errorf("unknown option: %q", key)
+		}
+	}
+
+	return resolveFlags(ctx, ctx.Command.Flags, values)
+}
+
+// resolveFlags defines the options not defined by a flag or an environment variable,
+// with the _FILE environment variables, then with the values of the configuration file.
+func resolveFlags(ctx *cli.Context, flags []cli.Flag, values map[string]any) error {
+	for _, flag := range flags {
+		name := flag.Names()[0]
+
+		if ctx.IsSet(name) {
+			continue
+		}
+
+		found, err := applyEnvFile(ctx, flag, contextFlags(ctx))
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if found {
+			continue
+		}
+
+		value, ok := lookupConfigValue(values, flag.Names())
+		if !ok {
+			continue
+		}
+
+		err = setFlagValue(ctx, name, value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		addMetadataList(ctx, metadataConfigKeys, name)
+	}
+
+	return nil
+}
+
+// applyEnvFile defines the option with the content of the file defined by a _FILE environment variable.
+// The _FILE variables which are the environment variables of other options (e.g. LEGO_DOMAINS_FILE for --domains-file) are ignored.
+func applyEnvFile(ctx *cli.Context, flag cli.Flag, all []cli.Flag) (bool, error) {
+	f, ok := flag.(cli.DocGenerationFlag)
+	if !ok {
+		return false, nil
+	}
+
+	for _, env := range f.GetEnvVars() {
+		filename := os.Getenv(env + envFileSuffix)
+		if filename == "" || isFlagEnvVar(all, env+envFileSuffix) {
+			continue
+		}
+
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return false, fmt.Errorf("read the file defined by %s: %w", env+envFileSuffix, err)
+		}
+
+		err = ctx.Set(flag.Names()[0], strings.TrimRight(string(data), "\r\n"))
+		if err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func isFlagEnvVar(flags []cli.Flag, name string) bool {
+	return slices.ContainsFunc(flags, func(flag cli.Flag) bool {
+		f, ok := flag.(cli.DocGenerationFlag)
+
+		return ok && slices.Contains(f.GetEnvVars(), name)
+	})
+}
+
+// loadConfigFile reads the configuration file (--config) once, the values are kept in the application metadata.
+// The values are empty if there is no configuration file, or during the init command which creates it.
+func loadConfigFile(ctx *cli.Context) (map[string]any, error) {
+	if values, ok := ctx.App.Metadata[configValuesKey].(map[string]any); ok {
+		return values, nil
+	}
+
+	values := map[string]any{}
+
+	filename := ctx.String(flgConfig)
+
+	// The init command creates the configuration file.
+	if filename != "" && !isInitCommand(ctx) {
+		var err error
+
+		values, err = decodeConfigFile(filename)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if ctx.App.Metadata == nil {
+		ctx.App.Metadata = make(map[string]any)
+	}
+
+	ctx.App.Metadata[configValuesKey] = values
+
+	return values, nil
+}
+
+// decodeConfigFile decodes the configuration file: YAML if the extension is .yaml or .yml, TOML otherwise.
+func decodeConfigFile(filename string) (map[string]any, error) {
+	if !isYAMLFile(filename) {
+		values := map[string]any{}
+
+		_, err := toml.DecodeFile(filename, &values)
+		if err != nil {
+			return nil, err
+		}
+
+		return values, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+
+	err = yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := normalizeYAML(raw).(map[string]any)
+	if !ok {
+		return map[string]any{}, nil
+	}
+
+	return values, nil
+}
+
+// normalizeYAML converts the maps decoded by YAML (map[any]any) to the maps decoded by TOML (map[string]any).
+func normalizeYAML(value any) any {
+	switch v := value.(type) {
+	case map[any]any:
+		values := make(map[string]any, len(v))
+		for key, item := range v {
+			values[fmt.Sprint(key)] = normalizeYAML(item)
+		}
+
+		return values
+
+	case map[string]any:
+		values := make(map[string]any, len(v))
+		for key, item := range v {
+			values[key] = normalizeYAML(item)
+		}
+
+		return values
+
+	case []any:
+		values := make([]any, len(v))
+		for i, item := range v {
+			values[i] = normalizeYAML(item)
+		}
+
+		return values
+
+	default:
+		return v
+	}
+}
+
+func isYAMLFile(filename string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+func isInitCommand(ctx *cli.Context) bool {
+	for _, c := range ctx.Lineage() {
+		if c.Command != nil && c.Command.Name == "init" {
+			return true
+		}
+	}
+
+	return ctx.Args().First() == "init"
+}
+
+// isCommandSection returns true if the key is the table of a command.
+func isCommandSection(commands []*cli.Command, key string, value any) bool {
+	if _, ok := value.(map[string]any); !ok {
+		return false
+	}
+
+	return slices.ContainsFunc(commands, func(command *cli.Command) bool { return command.HasName(key) })
+}
+
+func lookupConfigValue(values map[string]any, names []string) (any, bool) {
+	for _, name := range names {
+		if value, ok := values[name]; ok {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+func applyConfigEnv(ctx *cli.Context, value any) error {
+	envs, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("the %q section must be a table", configEnvSection)
+	}
+
+	for name, v := range envs {
+		// The environment variables (and their _FILE variants) take precedence.
+		if _, exists := os.LookupEnv(name); exists {
+			continue
+		}
+
+		if _, exists := os.LookupEnv(name + envFileSuffix); exists {
+			continue
+		}
+
+		err := os.Setenv(name, fmt.Sprint(v))
+		if err != nil {
+			return err
+		}
+
+		addMetadataList(ctx, metadataConfigEnvs, name)
+	}
+
+	return nil
+}
+
+func setFlagValue(ctx *cli.Context, name string, value any) error {
+	switch v := value.(type) {
+	case []any:
+		var items []string
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+
+		// The serialized form overwrites the values of the flag.
+		return ctx.Set(name, cli.NewStringSlice(items...).Serialize())
+
+	case map[string]any:
+		return fmt.Errorf("unsupported value: %v", v)
+
+	default:
+		return ctx.Set(name, fmt.Sprint(v))
+	}
+}
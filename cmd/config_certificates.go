@@ -0,0 +1,468 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// configCertificatesSection the section of the configuration file containing the options of each certificate.
+//
+//	[[certificates]]
+//	domains = ["example.com", "www.example.com"]
+//	dns = "cloudflare"
+//	env = { CF_DNS_API_TOKEN = "xxx" }
+const configCertificatesSection = "certificates"
+
+// certificateContextKey the key of the context value containing the options of a certificate (certificateOptions).
+type certificateContextKey struct{}
+
+// certificateOptions the layers of options of a certificate, in addition to the global options.
+type certificateOptions struct {
+	// block the options of the certificate in the configuration file.
+	block map[string]any
+
+	// renewal the renewal configuration of the certificate, written when the certificate has been obtained.
+	renewal map[string]any
+}
+
+// getCertificateBlocks returns the options of the certificates defined by the configuration file.
+func getCertificateBlocks(ctx *cli.Context) ([]map[string]any, error) {
+	values, err := loadConfigFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := values[configCertificatesSection]
+	if !ok {
+		return nil, nil
+	}
+
+	var blocks []map[string]any
+
+	switch v := raw.(type) {
+	case []map[string]any:
+		// TOML
+		blocks = v
+
+	case []any:
+		// YAML
+		for _, item := range v {
+			block, okB := item.(map[string]any)
+			if !okB {
+				return nil, fmt.Errorf("the %q section must be a list of tables", configCertificatesSection)
+			}
+
+			blocks = append(blocks, block)
+		}
+
+	default:
+		return nil, fmt.Errorf("the %q section must be a list of tables", configCertificatesSection)
+	}
+
+	flags := getCertificateFlags(ctx)
+
+	for i, block := range blocks {
+		err = validateCertificateBlock(flags, block)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", configCertificatesSection, i, err)
+		}
+	}
+
+	return blocks, nil
+}
+
+// getCertificateFlags returns the options allowed in a certificate block: the global options, and the options of the run and renew commands.
+func getCertificateFlags(ctx *cli.Context) []cli.Flag {
+	flags := slices.Clone(ctx.App.Flags)
+
+	for _, name := range []string{"run", "renew"} {
+		if command := ctx.App.Command(name); command != nil {
+			flags = append(flags, command.Flags...)
+		}
+	}
+
+	return flags
+}
+
+func validateCertificateBlock(flags []cli.Flag, block map[string]any) error {
+	for key, value := range block {
+		if key == configEnvSection {
+			if _, ok := value.(map[string]any); !ok {
+				return fmt.Errorf("the %q section must be a table", configEnvSection)
+			}
+
+			continue
+		}
+
+		if !slices.ContainsFunc(flags, func(flag cli.Flag) bool { return slices.Contains(flag.Names(), key) }) {
+			return fmt.Errorf("unknown option: %q", key)
+		}
+	}
+
+	for _, name := range []string{flgDomains, flgDomainsFile, flgCSR} {
+		if _, ok := block[name]; ok {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%q, %q, or %q is required", flgDomains, flgDomainsFile, flgCSR)
+}
+
+// useCertificateBlocks returns true if the command handles the certificates of the configuration file:
+// the certificates are ignored when the domains (or the CSR) are defined by the command line or the environment variables.
+func useCertificateBlocks(ctx *cli.Context) bool {
+	// Already the context of a certificate.
+	if getCertificateOptions(ctx) != nil {
+		return false
+	}
+
+	// renew --all renews the stored certificates with the global options.
+	if ctx.Bool(flgRenewAll) {
+		return false
+	}
+
+	for _, name := range []string{flgDomains, flgDomainsFile, flgDomainsStdin, flgCSR} {
+		if isSetByUser(ctx, name) {
+			return false
+		}
+	}
+
+	blocks, err := getCertificateBlocks(ctx)
+
+	return err == nil && len(blocks) > 0
+}
+
+// isSetByUser returns true if the option is defined by a flag or an environment variable.
+func isSetByUser(ctx *cli.Context, name string) bool {
+	return ctx.IsSet(name) && !slices.Contains(getMetadataList(ctx, metadataConfigKeys), name)
+}
+
+// forEachCertificateBlock calls fn with the context of each certificate of the configuration file.
+// The options of a certificate take precedence over the global options of the configuration file,
+// the flags and the environment variables still take precedence over the options of a certificate.
+// The errors are joined.
+func forEachCertificateBlock(ctx *cli.Context, fn cli.ActionFunc) error {
+	blocks, err := getCertificateBlocks(ctx)
+	if err != nil {
+		return err
+	}
+
+	return forEachCertificate(ctx, blocks, fn)
+}
+
+// forEachCertificateGroup calls fn with the context of each group of domains (--cert),
+// as if each group was a certificate of the configuration file.
+func forEachCertificateGroup(ctx *cli.Context, fn cli.ActionFunc) error {
+	groups, _ := ctx.Generic(flgRunCert).(*certificateGroups)
+	if groups == nil {
+		return nil
+	}
+
+	var blocks []map[string]any
+
+	for _, group := range *groups {
+		var domains []any
+		for _, domain := range group {
+			domains = append(domains, domain)
+		}
+
+		blocks = append(blocks, map[string]any{flgDomains: domains})
+	}
+
+	return forEachCertificate(ctx, blocks, fn)
+}
+
+// forEachCertificate calls fn with the context of each certificate, and logs the result of each certificate.
+// The errors are joined.
+func forEachCertificate(ctx *cli.Context, blocks []map[string]any, fn cli.ActionFunc) error {
+	var errs []error
+
+	for i, block := range blocks {
+		name := certificateBlockName(i, block)
+
+		err := runCertificateBlock(ctx, block, fn)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("[%s] %w", name, err))
+		}
+	}
+
+	if len(blocks) > 1 {
+		log.Infof("%d certificates handled, %d failed", len(blocks), len(errs))
+	}
+
+	return errors.Join(errs...)
+}
+
+func runCertificateBlock(ctx *cli.Context, block map[string]any, fn cli.ActionFunc) error {
+	restore, err := applyCertificateEnv(ctx, block)
+	if err != nil {
+		return err
+	}
+
+	defer restore()
+
+	blockCtx, err := newCertificateContext(ctx, &certificateOptions{block: block})
+	if err != nil {
+		return err
+	}
+
+	return fn(blockCtx)
+}
+
+// getCertificateOptions returns the options of the certificate, if the context is the context of a certificate.
+func getCertificateOptions(ctx *cli.Context) *certificateOptions {
+	if ctx.Context == nil {
+		return nil
+	}
+
+	options, _ := ctx.Context.Value(certificateContextKey{}).(*certificateOptions)
+
+	return options
+}
+
+// newCertificateContext creates the contexts (global and command) of a certificate,
+// and calls the Before function of the command with it.
+// The options of the certificate block take precedence over the renewal configuration,
+// and the renewal configuration takes precedence over the global options of the configuration file.
+func newCertificateContext(ctx *cli.Context, options *certificateOptions) (*cli.Context, error) {
+	lineage := ctx.Lineage()
+	if len(lineage) < 2 {
+		return nil, errors.New("the command has no parent")
+	}
+
+	root := lineage[1]
+
+	var base *cli.Context
+	if len(lineage) > 2 {
+		base = lineage[2]
+	}
+
+	values, err := loadConfigFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rootCtx, err := copyUserFlags(root, root.Command.Flags, base)
+	if err != nil {
+		return nil, err
+	}
+
+	rootCtx.Command = root.Command
+	rootCtx.Context = context.WithValue(ctx.Context, certificateContextKey{}, options)
+
+	// The domains and the CSR of a certificate are only defined by its block.
+	global := maps.Clone(values)
+	for _, name := range []string{flgDomains, flgDomainsFile, flgCSR} {
+		delete(global, name)
+	}
+
+	// The challenges of a certificate replace the challenges of the global options.
+	if definesChallenge(options.block) || definesChallenge(options.renewal) {
+		for _, name := range challengeFlags {
+			delete(global, name)
+		}
+	}
+
+	for _, layer := range []map[string]any{options.block, options.renewal, global} {
+		err = resolveFlags(rootCtx, root.Command.Flags, layer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = resolveDomains(rootCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCtx, err := copyUserFlags(ctx, ctx.Command.Flags, rootCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCtx.Command = ctx.Command
+
+	for _, layer := range []map[string]any{options.block, options.renewal} {
+		err = resolveFlags(blockCtx, ctx.Command.Flags, layer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The options of the table of the command, and the checks of the command.
+	if ctx.Command.Before != nil {
+		err = ctx.Command.Before(blockCtx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return blockCtx, nil
+}
+
+// copyUserFlags creates a context with the options defined by the flags and the environment variables.
+func copyUserFlags(ctx *cli.Context, flags []cli.Flag, parent *cli.Context) (*cli.Context, error) {
+	set := flag.NewFlagSet(ctx.Command.Name, flag.ContinueOnError)
+
+	// The groups of domains (--cert) are the certificates: they are not options of a certificate.
+	flags = slices.DeleteFunc(slices.Clone(flags), func(f cli.Flag) bool {
+		return slices.Contains(f.Names(), flgRunCert)
+	})
+
+	for _, f := range flags {
+		err := f.Apply(set)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range flags {
+		name := f.Names()[0]
+
+		if !isSetByUser(ctx, name) {
+			continue
+		}
+
+		err := set.Set(name, flagString(ctx, f))
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", name, err)
+		}
+	}
+
+	return cli.NewContext(ctx.App, set, parent), nil
+}
+
+func flagString(ctx *cli.Context, f cli.Flag) string {
+	name := f.Names()[0]
+
+	switch f := f.(type) {
+	case *cli.StringSliceFlag:
+		// The serialized form overwrites the values of the flag.
+		return cli.NewStringSlice(ctx.StringSlice(name)...).Serialize()
+
+	case *cli.TimestampFlag:
+		if t := ctx.Timestamp(name); t != nil {
+			return t.Format(f.Layout)
+		}
+
+		return ""
+
+	default:
+		return fmt.Sprint(ctx.Value(name))
+	}
+}
+
+// applyCertificateEnv defines the environment variables of the env section of a certificate.
+// The environment variables take precedence, except the variables defined by the env section at the top level of the configuration file.
+// The restore function restores the previous environment variables.
+func applyCertificateEnv(ctx *cli.Context, block map[string]any) (func(), error) {
+	envs, _ := block[configEnvSection].(map[string]any)
+
+	previous := map[string]*string{}
+
+	restore := func() {
+		for name, value := range previous {
+			if value == nil {
+				_ = os.Unsetenv(name)
+			} else {
+				_ = os.Setenv(name, *value)
+			}
+		}
+	}
+
+	fromConfig := getMetadataList(ctx, metadataConfigEnvs)
+
+	for name, v := range envs {
+		if !slices.Contains(fromConfig, name) {
+			if _, exists := os.LookupEnv(name); exists {
+				continue
+			}
+
+			if _, exists := os.LookupEnv(name + envFileSuffix); exists {
+				continue
+			}
+		}
+
+		if value, exists := os.LookupEnv(name); exists {
+			previous[name] = &value
+		} else {
+			previous[name] = nil
+		}
+
+		err := os.Setenv(name, fmt.Sprint(v))
+		if err != nil {
+			restore()
+			return nil, err
+		}
+	}
+
+	return restore, nil
+}
+
+// certificateBlockName returns the main domain of the certificate, or the position of the certificate in the configuration file.
+func certificateBlockName(i int, block map[string]any) string {
+	if domains, ok := block[flgDomains].([]any); ok && len(domains) > 0 {
+		return fmt.Sprint(domains[0])
+	}
+
+	if domain, ok := block[flgDomains].(string); ok && domain != "" {
+		return domain
+	}
+
+	return fmt.Sprintf("%s[%d]", configCertificatesSection, i)
+}
+
+// certificateGroups the groups of domains (--cert): each value of the flag is a certificate, with comma-separated domains.
+type certificateGroups [][]string
+
+func (g *certificateGroups) Set(value string) error {
+	var group []string
+
+	for domain := range strings.SplitSeq(value, ",") {
+		domain = strings.TrimSpace(domain)
+		if domain != "" {
+			group = append(group, domain)
+		}
+	}
+
+	if len(group) == 0 {
+		return fmt.Errorf("no domain in %q", value)
+	}
+
+	*g = append(*g, group)
+
+	return nil
+}
+
+func (g *certificateGroups) String() string {
+	if g == nil {
+		return ""
+	}
+
+	var values []string
+	for _, group := range *g {
+		values = append(values, strings.Join(group, ","))
+	}
+
+	return strings.Join(values, " ")
+}
+
+// checkCertificateGroups checks that the groups of domains (--cert) are the only definition of the certificates.
+func checkCertificateGroups(ctx *cli.Context) error {
+	for _, name := range []string{flgDomains, flgDomainsFile, flgDomainsStdin, flgCSR, flgCertName} {
+		if isSetByUser(ctx, name) {
+			return fmt.Errorf("--%s defines the certificates: --%s can't be used with it", flgRunCert, name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_getCertificateBlocks(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		name     string
+		content  string
+		expected int
+		err      string
+	}{
+		{
+			desc:     "TOML",
+			name:     "lego.toml",
+			content:  "[[certificates]]\ndomains = [\"a.example.com\"]\n\n[[certificates]]\ncsr = \"b.csr\"",
+			expected: 2,
+		},
+		{
+			desc:     "YAML",
+			name:     "lego.yaml",
+			content:  "certificates:\n  - domains: [a.example.com]\n    env:\n      FOO: bar\n  - domains-file: domains.txt",
+			expected: 2,
+		},
+		{
+			desc:    "not a list",
+			name:    "lego.toml",
+			content: "certificates = \"example.com\"",
+			err:     `the "certificates" section must be a list of tables`,
+		},
+		{
+			desc:    "unknown option",
+			name:    "lego.toml",
+			content: "[[certificates]]\ndomains = [\"a.example.com\"]\nfoo = \"bar\"",
+			err:     `certificates[0]: unknown option: "foo"`,
+		},
+		{
+			desc:    "missing domains",
+			name:    "lego.yaml",
+			content: "certificates:\n  - domains: [a.example.com]\n  - dns: manual",
+			err:     `certificates[1]: "domains", "domains-file", or "csr" is required`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx, err := runWithConfigFile(t, test.name, test.content)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			blocks, err := getCertificateBlocks(ctx)
+			require.NoError(t, err)
+
+			assert.Len(t, blocks, test.expected)
+		})
+	}
+}
+
+func Test_forEachCertificateBlock(t *testing.T) {
+	t.Setenv("LEGO_TEST_BLOCK_EXISTING", "from-env")
+
+	content := `
+email = "file@example.com"
+dns = "manual"
+
+[env]
+LEGO_TEST_BLOCK_GLOBAL = "global"
+
+[[certificates]]
+domains = ["a.example.com", "www.a.example.com"]
+dns = "exec"
+value = "from-block"
+
+[certificates.env]
+LEGO_TEST_BLOCK_GLOBAL = "block"
+LEGO_TEST_BLOCK_EXISTING = "block"
+
+[[certificates]]
+domains = ["b.example.com"]
+`
+
+	filename := filepath.Join(t.TempDir(), "lego.toml")
+
+	err := os.WriteFile(filename, []byte(content), 0o600)
+	require.NoError(t, err)
+
+	type result struct {
+		domains  []string
+		email    string
+		dns      string
+		value    string
+		global   string
+		existing string
+	}
+
+	var results []result
+
+	var action cli.ActionFunc
+
+	action = func(ctx *cli.Context) error {
+		if useCertificateBlocks(ctx) {
+			return forEachCertificateBlock(ctx, action)
+		}
+
+		results = append(results, result{
+			domains:  ctx.StringSlice(flgDomains),
+			email:    ctx.String(flgEmail),
+			dns:      ctx.String(flgDNS),
+			value:    ctx.String("value"),
+			global:   os.Getenv("LEGO_TEST_BLOCK_GLOBAL"),
+			existing: os.Getenv("LEGO_TEST_BLOCK_EXISTING"),
+		})
+
+		return nil
+	}
+
+	command := &cli.Command{
+		Name: "run",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "value", Value: "default"},
+		},
+		Action: action,
+	}
+
+	addCommandEnvVars("", command)
+	addConfigLayers(nil, command)
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags(t.TempDir())
+	app.Before = applyConfigFile
+	app.Commands = []*cli.Command{command}
+
+	err = app.Run([]string{"lego", "--config", filename, "--email", "flag@example.com", "run"})
+	require.NoError(t, err)
+
+	expected := []result{
+		{
+			domains:  []string{"a.example.com", "www.a.example.com"},
+			email:    "flag@example.com",
+			dns:      "exec",
+			value:    "from-block",
+			global:   "block",
+			existing: "from-env",
+		},
+		{
+			domains:  []string{"b.example.com"},
+			email:    "flag@example.com",
+			dns:      "manual",
+			value:    "default",
+			global:   "global",
+			existing: "from-env",
+		},
+	}
+
+	assert.Equal(t, expected, results)
+
+	_ = os.Unsetenv("LEGO_TEST_BLOCK_GLOBAL")
+}
+
+func Test_useCertificateBlocks_flag(t *testing.T) {
+	content := "[[certificates]]\ndomains = [\"a.example.com\"]"
+
+	ctx, err := runWithConfig(t, content, "--domains", "b.example.com")
+	require.NoError(t, err)
+
+	assert.False(t, useCertificateBlocks(ctx))
+}
+
+func Test_forEachCertificateGroup(t *testing.T) {
+	type result struct {
+		domains []string
+		email   string
+		value   string
+	}
+
+	var results []result
+
+	var action cli.ActionFunc
+
+	action = func(ctx *cli.Context) error {
+		if ctx.IsSet(flgRunCert) {
+			return forEachCertificateGroup(ctx, action)
+		}
+
+		results = append(results, result{
+			domains: ctx.StringSlice(flgDomains),
+			email:   ctx.String(flgEmail),
+			value:   ctx.String("value"),
+		})
+
+		return nil
+	}
+
+	command := &cli.Command{
+		Name: "run",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "value", Value: "default"},
+			&cli.GenericFlag{Name: flgRunCert, Value: &certificateGroups{}},
+		},
+		Action: action,
+	}
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags(t.TempDir())
+	app.Commands = []*cli.Command{command}
+
+	err := app.Run([]string{"lego", "--email", "flag@example.com", "run",
+		"--value", "from-flag", "--cert", "a.example.com, www.a.example.com", "--cert", "b.example.com"})
+	require.NoError(t, err)
+
+	expected := []result{
+		{
+			domains: []string{"a.example.com", "www.a.example.com"},
+			email:   "flag@example.com",
+			value:   "from-flag",
+		},
+		{
+			domains: []string{"b.example.com"},
+			email:   "flag@example.com",
+			value:   "from-flag",
+		},
+	}
+
+	assert.Equal(t, expected, results)
+}
+
+func Test_certificateGroups_Set(t *testing.T) {
+	groups := &certificateGroups{}
+
+	require.NoError(t, groups.Set("a.example.com,www.a.example.com"))
+	require.NoError(t, groups.Set(" b.example.com "))
+	require.Error(t, groups.Set(" , "))
+
+	assert.Equal(t, &certificateGroups{{"a.example.com", "www.a.example.com"}, {"b.example.com"}}, groups)
+	assert.Equal(t, "a.example.com,www.a.example.com b.example.com", groups.String())
+}
+
+func Test_checkCertificateGroups(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Var(&certificateGroups{{"a.example.com"}}, flgRunCert, "")
+	set.Var(cli.NewStringSlice(), flgDomains, "")
+
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+	require.NoError(t, checkCertificateGroups(ctx))
+
+	require.NoError(t, set.Set(flgDomains, "b.example.com"))
+
+	require.EqualError(t, checkCertificateGroups(ctx), "--cert defines the certificates: --domains can't be used with it")
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func runWithConfig(t *testing.T, content string, args ...string) (*cli.Context, error) {
+	t.Helper()
+
+	return runWithConfigFile(t, "lego.toml", content, args...)
+}
+
+func runWithConfigFile(t *testing.T, name, content string, args ...string) (*cli.Context, error) {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), name)
+
+	err := os.WriteFile(filename, []byte(content), 0o600)
+	require.NoError(t, err)
+
+	var result *cli.Context
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags(t.TempDir())
+	app.Before = applyConfigFile
+	app.Action = func(ctx *cli.Context) error {
+		result = ctx
+		return nil
+	}
+
+	err = app.Run(append([]string{"lego", "--config", filename}, args...))
+
+	return result, err
+}
+
+func Test_applyConfigFile(t *testing.T) {
+	t.Setenv("LEGO_TEST_CONFIG_EXISTING", "from-env")
+
+	content := `
+email = "file@example.com"
+server = "https://acme.example.com/directory"
+accept-tos = true
+domains = ["example.com", "www.example.com"]
+dns = "manual"
+http-timeout = 30
+
+[env]
+LEGO_TEST_CONFIG_TOKEN = "secret"
+LEGO_TEST_CONFIG_EXISTING = "from-file"
+`
+
+	ctx, err := runWithConfig(t, content, "--email", "flag@example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "flag@example.com", ctx.String(flgEmail))
+	assert.Equal(t, "https://acme.example.com/directory", getServer(ctx))
+	assert.True(t, ctx.Bool(flgAcceptTOS))
+	assert.Equal(t, []string{"example.com", "www.example.com"}, ctx.StringSlice(flgDomains))
+	assert.Equal(t, "manual", ctx.String(flgDNS))
+	assert.Equal(t, 30, ctx.Int(flgHTTPTimeout))
+
+	assert.Equal(t, "secret", os.Getenv("LEGO_TEST_CONFIG_TOKEN"))
+	assert.Equal(t, "from-env", os.Getenv("LEGO_TEST_CONFIG_EXISTING"))
+
+	_ = os.Unsetenv("LEGO_TEST_CONFIG_TOKEN")
+}
+
+func Test_applyConfigFile_yaml(t *testing.T) {
+	content := `
+email: file@example.com
+accept-tos: true
+domains:
+  - example.com
+  - www.example.com
+http-timeout: 30
+env:
+  LEGO_TEST_CONFIG_YAML: secret
+`
+
+	ctx, err := runWithConfigFile(t, "lego.yaml", content)
+	require.NoError(t, err)
+
+	assert.Equal(t, "file@example.com", ctx.String(flgEmail))
+	assert.True(t, ctx.Bool(flgAcceptTOS))
+	assert.Equal(t, []string{"example.com", "www.example.com"}, ctx.StringSlice(flgDomains))
+	assert.Equal(t, 30, ctx.Int(flgHTTPTimeout))
+
+	assert.Equal(t, "secret", os.Getenv("LEGO_TEST_CONFIG_YAML"))
+
+	_ = os.Unsetenv("LEGO_TEST_CONFIG_YAML")
+}
+
+func Test_applyConfigFile_unknownOption(t *testing.T) {
+	_, err := runWithConfig(t, `foo = "bar"`)
+	require.EqualError(t, err, `unknown option: "foo"`)
+}
+
+func Test_applyConfigFile_envFile(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "hmac")
+
+	err := os.WriteFile(secret, []byte("from-secret-file\n"), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv("LEGO_HMAC_FILE", secret)
+	t.Setenv("LEGO_KID", "from-env")
+	t.Setenv("LEGO_KID_FILE", secret)
+
+	content := `
+hmac = "from-config"
+kid = "from-config"
+email = "file@example.com"
+`
+
+	ctx, err := runWithConfig(t, content)
+	require.NoError(t, err)
+
+	assert.Equal(t, "from-secret-file", ctx.String(flgHMAC))
+	assert.Equal(t, "from-env", ctx.String(flgKID))
+	assert.Equal(t, "file@example.com", ctx.String(flgEmail))
+}
+
+func Test_applyCommandConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		content  string
+		args     []string
+		env      map[string]string
+		expected string
+		err      string
+	}{
+		{
+			desc:     "default",
+			expected: "default",
+		},
+		{
+			desc:     "config file",
+			content:  "[test]\nvalue = \"from-config\"",
+			expected: "from-config",
+		},
+		{
+			desc:     "env",
+			content:  "[test]\nvalue = \"from-config\"",
+			env:      map[string]string{"LEGO_TEST_VALUE": "from-env"},
+			expected: "from-env",
+		},
+		{
+			desc:     "flag",
+			content:  "[test]\nvalue = \"from-config\"",
+			args:     []string{"--value", "from-flag"},
+			env:      map[string]string{"LEGO_TEST_VALUE": "from-env"},
+			expected: "from-flag",
+		},
+		{
+			desc:    "unknown option",
+			content: "[test]\nfoo = \"bar\"",
+			err:     `[test] unknown option: "foo"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+
+			filename := filepath.Join(t.TempDir(), "lego.toml")
+
+			err := os.WriteFile(filename, []byte(test.content), 0o600)
+			require.NoError(t, err)
+
+			var value string
+
+			command := &cli.Command{
+				Name: "test",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "value", Value: "default"},
+				},
+				Action: func(ctx *cli.Context) error {
+					value = ctx.String("value")
+					return nil
+				},
+			}
+
+			addCommandEnvVars("", command)
+			addConfigLayers(nil, command)
+
+			app := cli.NewApp()
+			app.Flags = CreateFlags(t.TempDir())
+			app.Before = applyConfigFile
+			app.Commands = []*cli.Command{command}
+
+			err = app.Run(append([]string{"lego", "--config", filename, "test"}, test.args...))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
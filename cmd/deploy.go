@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/providers/deploy"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgDeploy        = "deploy"
+	flgDeployTimeout = "deploy-timeout"
+)
+
+func createDeployFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: flgDeploy,
+			Usage: "Install the certificate with a deployer (e.g. kubernetes) after its issuance or renewal, before the hook." +
+				" The deployers are configured with environment variables.",
+		},
+		&cli.DurationFlag{
+			Name:  flgDeployTimeout,
+			Usage: "The timeout of each deployer.",
+			Value: 2 * time.Minute,
+		},
+	}
+}
+
+// deployCertificate installs the certificate with the deployers (--deploy).
+func deployCertificate(ctx *cli.Context, certRes *certificate.Resource) error {
+	for _, name := range ctx.StringSlice(flgDeploy) {
+		deployer, err := deploy.NewDeployerByName(name)
+		if err != nil {
+			return fmt.Errorf("deploy: %w", err)
+		}
+
+		ctxTimeout, cancel := context.WithTimeout(ctx.Context, ctx.Duration(flgDeployTimeout))
+
+		err = deployer.Deploy(ctxTimeout, certRes)
+
+		cancel()
+
+		if err != nil {
+			return fmt.Errorf("deploy: %w", err)
+		}
+
+		log.Infof("[%s] The certificate has been deployed (%s).", certRes.Domain, name)
+	}
+
+	return nil
+}
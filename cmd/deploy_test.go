@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_deployCertificate(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	deployers := cli.NewStringSlice()
+	require.NoError(t, deployers.Set("unknown"))
+
+	set.Var(deployers, flgDeploy, "")
+
+	err := deployCertificate(cli.NewContext(cli.NewApp(), set, nil), &certificate.Resource{Domain: "example.com"})
+	require.EqualError(t, err, "deploy: unrecognized deployer: unknown")
+}
+
+func Test_deployCertificate_none(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	err := deployCertificate(cli.NewContext(cli.NewApp(), set, nil), &certificate.Resource{Domain: "example.com"})
+	require.NoError(t, err)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// resolveDomains merges the domains from the file (--domains-file) and from stdin (--domains-stdin, --domains -)
+// with the domains from the flag (--domains).
+func resolveDomains(ctx *cli.Context) error {
+	domains := ctx.StringSlice(flgDomains)
+
+	fromStdin := ctx.Bool(flgDomainsStdin) || slices.Contains(domains, "-")
+
+	if !ctx.IsSet(flgDomainsFile) && !fromStdin {
+		return nil
+	}
+
+	domains = slices.DeleteFunc(slices.Clone(domains), func(domain string) bool {
+		return domain == "-"
+	})
+
+	if ctx.IsSet(flgDomainsFile) {
+		fromFile, err := readDomainsFile(ctx.String(flgDomainsFile))
+		if err != nil {
+			return err
+		}
+
+		domains = mergeDomains(domains, fromFile)
+	}
+
+	if fromStdin {
+		values, err := readDomains(os.Stdin, true)
+		if err != nil {
+			return fmt.Errorf("stdin: %w", err)
+		}
+
+		domains = mergeDomains(domains, values)
+	}
+
+	// The serialized form overwrites the values of the flag.
+	return ctx.Set(flgDomains, cli.NewStringSlice(domains...).Serialize())
+}
+
+func readDomainsFile(filename string) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	domains, err := readDomains(file, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return domains, nil
+}
+
+// readDomains reads one domain per line, or several domains separated by spaces if multiPerLine is true.
+// The empty lines and the comments (starting with #) are ignored.
+func readDomains(r io.Reader, multiPerLine bool) ([]string, error) {
+	var domains []string
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		if multiPerLine {
+			domains = append(domains, strings.Fields(line)...)
+			continue
+		}
+
+		domain := strings.TrimSpace(line)
+		if domain == "" {
+			continue
+		}
+
+		if strings.ContainsAny(domain, " \t") {
+			return nil, fmt.Errorf("invalid domain: %q", domain)
+		}
+
+		domains = append(domains, domain)
+	}
+
+	return domains, scanner.Err()
+}
+
+// mergeDomains merges the domains without duplicates, and preserves the order (the first domain is the main domain).
+func mergeDomains(lists ...[]string) []string {
+	var domains []string
+
+	for _, list := range lists {
+		for _, domain := range list {
+			if !slices.Contains(domains, domain) {
+				domains = append(domains, domain)
+			}
+		}
+	}
+
+	return domains
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readDomains(t *testing.T) {
+	content := `# main domain
+example.com
+
+www.example.com # the website
+  *.api.example.com
+`
+
+	domains, err := readDomains(strings.NewReader(content), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "www.example.com", "*.api.example.com"}, domains)
+}
+
+func Test_readDomains_error(t *testing.T) {
+	_, err := readDomains(strings.NewReader("example.com www.example.com\n"), false)
+	require.EqualError(t, err, `invalid domain: "example.com www.example.com"`)
+}
+
+func Test_readDomains_multiPerLine(t *testing.T) {
+	content := "example.com www.example.com\n\tapi.example.com # comment\n"
+
+	domains, err := readDomains(strings.NewReader(content), true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "www.example.com", "api.example.com"}, domains)
+}
+
+func Test_mergeDomains(t *testing.T) {
+	domains := mergeDomains(
+		[]string{"example.com", "www.example.com"},
+		[]string{"www.example.com", "api.example.com"},
+	)
+
+	assert.Equal(t, []string{"example.com", "www.example.com", "api.example.com"}, domains)
+}
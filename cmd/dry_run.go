@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgDryRun          = "dry-run"
+	flgDryRunServerMap = "dry-run.server-map"
+)
+
+// stagingDirectories the staging directories of the CAs, by production directory.
+var stagingDirectories = map[string]string{
+	lego.LEDirectoryProduction:                   lego.LEDirectoryStaging,
+	"https://dv.acme-v02.api.pki.goog/directory": "https://dv.acme-v02.test-api.pki.goog/directory",
+}
+
+func createDryRunFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name: flgDryRun,
+			Usage: "Obtain the certificate from the staging directory of the CA, without modifying the stored certificates." +
+				" The hooks, the deployment, the issuer policy, and the publication of the DNS records (CAA, TLSA) are disabled.",
+		},
+		&cli.StringSliceFlag{
+			Name: flgDryRunServerMap,
+			Usage: "The staging directory of a CA used by --" + flgDryRun + ", as '<production directory URL>=<staging directory URL>'." +
+				" The staging directories of Let's Encrypt and Google Trust Services are known.",
+		},
+	}
+}
+
+// setupDryRun replaces the CAs by their staging directories, and disables the side effects of the command (--dry-run).
+func setupDryRun(ctx *cli.Context) error {
+	if !ctx.Bool(flgDryRun) {
+		return nil
+	}
+
+	servers, err := getStagingServers(ctx)
+	if err != nil {
+		return fmt.Errorf("dry-run: %w", err)
+	}
+
+	log.Infof("dry-run: using the staging directory %s", servers[0])
+
+	// The serialized form overwrites the values of the flag.
+	err = ctx.Set(flgServer, cli.NewStringSlice(servers...).Serialize())
+	if err != nil {
+		return err
+	}
+
+	disabled := []struct {
+		name  string
+		value string
+	}{
+		{name: flgRunHook},
+		{name: flgRenewHook},
+		{name: flgDeploy, value: cli.NewStringSlice().Serialize()},
+		{name: flgTLSAPort, value: cli.NewStringSlice().Serialize()},
+		{name: flgCAASet, value: "false"},
+		// The staging directories use other issuers.
+		{name: flgIssuerAllow, value: cli.NewStringSlice().Serialize()},
+		// The certificates have not been issued by the staging directories.
+		{name: flgCheckRevocation, value: "false"},
+	}
+
+	for _, option := range disabled {
+		if !ctx.IsSet(option.name) {
+			continue
+		}
+
+		log.Infof("dry-run: --%s is ignored", option.name)
+
+		err = ctx.Set(option.name, option.value)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The certificates have not been issued by the staging directories:
+	// the renewal information is not checked, and the renewal is not delayed.
+	for _, name := range []string{flgARIDisable, flgNoRandomSleep} {
+		if !hasFlag(ctx, name) {
+			continue
+		}
+
+		err = ctx.Set(name, "true")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getStagingServers returns the staging directories of the CAs (--server).
+// The fallback CAs without staging directory are ignored.
+func getStagingServers(ctx *cli.Context) ([]string, error) {
+	mapping, err := getStagingDirectories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var servers []string
+
+	for i, server := range getServers(ctx) {
+		staging, ok := mapping[strings.TrimSuffix(server, "/")]
+
+		switch {
+		case ok:
+			servers = append(servers, staging)
+
+		case slices.Contains(slices.Collect(maps.Values(mapping)), server):
+			// Already a staging directory.
+			servers = append(servers, server)
+
+		case i == 0:
+			return nil, fmt.Errorf("the staging directory of %s is unknown, use --%s", server, flgDryRunServerMap)
+
+		default:
+			log.Warnf("dry-run: the staging directory of %s is unknown, the CA is ignored", server)
+		}
+	}
+
+	return servers, nil
+}
+
+// getStagingDirectories returns the known staging directories, and the staging directories defined by --dry-run.server-map.
+func getStagingDirectories(ctx *cli.Context) (map[string]string, error) {
+	mapping := maps.Clone(stagingDirectories)
+
+	for _, value := range ctx.StringSlice(flgDryRunServerMap) {
+		production, staging, ok := strings.Cut(value, "=")
+		if !ok || strings.TrimSpace(production) == "" || strings.TrimSpace(staging) == "" {
+			return nil, fmt.Errorf("invalid staging directory mapping: %q", value)
+		}
+
+		mapping[strings.TrimSuffix(strings.TrimSpace(production), "/")] = strings.TrimSpace(staging)
+	}
+
+	return mapping, nil
+}
+
+// useDryRunStorage replaces the directories of the storage by copies in a temporary directory:
+// the stored files are read, but never modified.
+// The cleanup function removes the temporary directory.
+func useDryRunStorage(certsStorage *CertificatesStorage) (func(), error) {
+	dir, err := os.MkdirTemp("", "lego-dry-run-")
+	if err != nil {
+		return nil, fmt.Errorf("dry-run: %w", err)
+	}
+
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	copies := map[string]string{}
+
+	directories := []struct {
+		name string
+		path *string
+	}{
+		{name: baseCertificatesFolderName, path: &certsStorage.rootPath},
+		{name: "keys", path: &certsStorage.keyPath},
+		{name: "out", path: &certsStorage.outPath},
+	}
+
+	for _, directory := range directories {
+		if *directory.path == "" {
+			continue
+		}
+
+		if dst, ok := copies[*directory.path]; ok {
+			*directory.path = dst
+			continue
+		}
+
+		dst := filepath.Join(dir, directory.name)
+
+		err = copyDir(*directory.path, dst)
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("dry-run: copy %s: %w", *directory.path, err)
+		}
+
+		copies[*directory.path] = dst
+		*directory.path = dst
+	}
+
+	certsStorage.archivePath = filepath.Join(dir, baseArchivesFolderName)
+
+	log.Infof("dry-run: the files are written in the temporary directory %s", dir)
+
+	return cleanup, nil
+}
+
+// copyDir copies the directory, if it exists.
+func copyDir(src, dst string) error {
+	_, err := os.Stat(src)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.CopyFS(dst, os.DirFS(src))
+}
+
+// hasFlag returns true if the flag is an option of the command.
+func hasFlag(ctx *cli.Context, name string) bool {
+	return slices.ContainsFunc(ctx.Command.Flags, func(flag cli.Flag) bool { return slices.Contains(flag.Names(), name) })
+}
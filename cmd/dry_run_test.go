@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_getStagingServers(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		servers    []string
+		mapping    []string
+		expected   []string
+		requireErr require.ErrorAssertionFunc
+	}{
+		{
+			desc:       "Let's Encrypt",
+			servers:    []string{lego.LEDirectoryProduction},
+			expected:   []string{lego.LEDirectoryStaging},
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "staging directory",
+			servers:    []string{lego.LEDirectoryStaging},
+			expected:   []string{lego.LEDirectoryStaging},
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "mapping",
+			servers:    []string{"https://ca.example.com/directory/"},
+			mapping:    []string{"https://ca.example.com/directory=https://staging.ca.example.com/directory"},
+			expected:   []string{"https://staging.ca.example.com/directory"},
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "unknown fallback",
+			servers:    []string{lego.LEDirectoryProduction, "https://ca.example.com/directory"},
+			expected:   []string{lego.LEDirectoryStaging},
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "unknown primary",
+			servers:    []string{"https://ca.example.com/directory", lego.LEDirectoryProduction},
+			requireErr: require.Error,
+		},
+		{
+			desc:       "invalid mapping",
+			servers:    []string{lego.LEDirectoryProduction},
+			mapping:    []string{"https://ca.example.com/directory"},
+			requireErr: require.Error,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			set.Var(cli.NewStringSlice(test.servers...), flgServer, "")
+			set.Var(cli.NewStringSlice(test.mapping...), flgDryRunServerMap, "")
+
+			ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+			servers, err := getStagingServers(ctx)
+			test.requireErr(t, err)
+
+			assert.Equal(t, test.expected, servers)
+		})
+	}
+}
+
+func Test_setupDryRun(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Var(cli.NewStringSlice(lego.LEDirectoryProduction), flgServer, "")
+	set.Var(cli.NewStringSlice(), flgDryRunServerMap, "")
+	set.Bool(flgDryRun, false, "")
+	set.String(flgRenewHook, "", "")
+	set.Var(cli.NewStringSlice(), flgDeploy, "")
+	set.Bool(flgARIDisable, false, "")
+	set.Bool(flgCheckRevocation, false, "")
+
+	require.NoError(t, set.Parse([]string{"--" + flgDryRun, "--" + flgRenewHook, "./hook.sh", "--" + flgDeploy, "local", "--" + flgCheckRevocation}))
+
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+	ctx.Command.Flags = []cli.Flag{&cli.BoolFlag{Name: flgARIDisable}}
+
+	err := setupDryRun(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{lego.LEDirectoryStaging}, ctx.StringSlice(flgServer))
+	assert.Empty(t, ctx.String(flgRenewHook))
+	assert.Empty(t, ctx.StringSlice(flgDeploy))
+	assert.True(t, ctx.Bool(flgARIDisable))
+	assert.False(t, ctx.Bool(flgCheckRevocation))
+}
+
+func Test_useDryRunStorage(t *testing.T) {
+	dir := t.TempDir()
+
+	storage := &CertificatesStorage{
+		rootPath:    filepath.Join(dir, "certificates"),
+		archivePath: filepath.Join(dir, "archives"),
+		keyPath:     filepath.Join(dir, "keys"),
+	}
+
+	require.NoError(t, os.MkdirAll(storage.rootPath, 0o700))
+	require.NoError(t, os.MkdirAll(storage.keyPath, 0o700))
+
+	require.NoError(t, storage.WriteFile("example.com", certExt, []byte("cert")))
+	require.NoError(t, storage.WriteFile("example.com", keyExt, []byte("key")))
+
+	cleanup, err := useDryRunStorage(storage)
+	require.NoError(t, err)
+
+	assert.NotContains(t, storage.rootPath, dir)
+	assert.NotContains(t, storage.keyPath, dir)
+	assert.NotContains(t, storage.archivePath, dir)
+
+	// The stored files are readable.
+	assert.FileExists(t, filepath.Join(storage.rootPath, "example.com"+certExt))
+	assert.FileExists(t, filepath.Join(storage.keyPath, "example.com"+keyExt))
+
+	require.NoError(t, storage.WriteFile("example.com", certExt, []byte("new cert")))
+
+	require.NoError(t, os.MkdirAll(storage.archivePath, 0o700))
+	require.NoError(t, storage.MoveToArchive("example.com"))
+
+	// The real files are not modified.
+	content, err := os.ReadFile(filepath.Join(dir, "certificates", "example.com"+certExt))
+	require.NoError(t, err)
+	assert.Equal(t, "cert", string(content))
+
+	assert.FileExists(t, filepath.Join(dir, "keys", "example.com"+keyExt))
+	assert.NoDirExists(t, filepath.Join(dir, "archives"))
+
+	tmp := filepath.Dir(storage.rootPath)
+
+	cleanup()
+
+	assert.NoDirExists(t, tmp)
+}
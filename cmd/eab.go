@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/go-acme/lego/v4/cmd/internal/eab"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// canProvisionEAB checks if the External Account Binding credentials can be provisioned with the API of the CA (server):
+// ZeroSSL with an API key, Google Trust Services with the Application Default Credentials.
+func canProvisionEAB(ctx *cli.Context, server string) bool {
+	switch {
+	case eab.IsZeroSSL(server):
+		return ctx.String(flgEABZeroSSLAPIKey) != ""
+	case eab.IsGTS(server):
+		return true
+	default:
+		return false
+	}
+}
+
+// provisionEAB provisions the External Account Binding credentials with the API of the CA (server).
+// The credentials are nil if the CA is not supported, or if the API credentials are not defined.
+func provisionEAB(ctx *cli.Context, server string) (*eab.Credentials, error) {
+	if !canProvisionEAB(ctx, server) {
+		return nil, nil
+	}
+
+	if eab.IsGTS(server) {
+		client, err := eab.NewGTSClient(ctx.Context, server, ctx.String(flgEABGTSProject))
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("Creating the External Account Binding credentials with the Google Cloud Public CA API.")
+
+		return client.CreateCredentials(ctx.Context)
+	}
+
+	client, err := eab.NewZeroSSLClient(ctx.String(flgEABZeroSSLAPIKey))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Generating the External Account Binding credentials with the ZeroSSL API.")
+
+	return client.GenerateCredentials(ctx.Context)
+}
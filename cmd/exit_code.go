@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgDetailedExitCode = "detailed-exit-code"
+)
+
+// Exit codes of the renew command with --detailed-exit-code.
+const (
+	// exitCodeNothingRenewed no certificate has been renewed, and no error.
+	exitCodeNothingRenewed = 0
+	// exitCodeRenewed one or more certificates have been renewed, and no error.
+	exitCodeRenewed = 1
+	// exitCodeError the renewal has failed: invalid options, account, or the renewal of all the certificates.
+	exitCodeError = 2
+	// exitCodePartial one or more certificates have been renewed, and the renewal of one or more certificates has failed.
+	exitCodePartial = 3
+)
+
+func createDetailedExitCodeFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name: flgDetailedExitCode,
+		Usage: fmt.Sprintf("Use the exit code to report the result of the renewal: %d (nothing renewed), %d (renewed), %d (error), %d (renewed with errors)."+
+			" By default, the exit code is 0 (success), 1 (error), or the exit code of the failed hook.",
+			exitCodeNothingRenewed, exitCodeRenewed, exitCodeError, exitCodePartial),
+	}
+}
+
+// exitStatus the result of a command reported by the exit code.
+// The error is nil if the exit code only reports the result (e.g. a renewed certificate).
+type exitStatus struct {
+	code int
+	err  error
+}
+
+func (e *exitStatus) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+
+	return e.err.Error()
+}
+
+func (e *exitStatus) Unwrap() error {
+	return e.err
+}
+
+// newDetailedExitStatus returns the exit status of the renewal (--detailed-exit-code), or nil if nothing has been renewed.
+func newDetailedExitStatus(reports []*renewalReport, err error) error {
+	renewed := slices.ContainsFunc(reports, func(report *renewalReport) bool {
+		return report != nil && report.Decision == renewalRenewed
+	})
+
+	switch {
+	case err != nil && renewed:
+		return &exitStatus{code: exitCodePartial, err: err}
+	case err != nil:
+		return &exitStatus{code: exitCodeError, err: err}
+	case renewed:
+		return &exitStatus{code: exitCodeRenewed}
+	default:
+		return nil
+	}
+}
+
+// ExitCode returns the exit code of the command:
+// the exit code of the result (renew --detailed-exit-code), the exit code of the failed hook, or 1.
+func ExitCode(err error) int {
+	var status *exitStatus
+	if errors.As(err, &status) {
+		return status.code
+	}
+
+	if code := getHookExitCode(err); code != nil {
+		return *code
+	}
+
+	return 1
+}
+
+// ExitStatus returns the exit code of the command (see ExitCode),
+// and the error to report, or nil if the exit code only reports the result of the command.
+func ExitStatus(err error) (int, error) {
+	var status *exitStatus
+	if errors.As(err, &status) && status.err == nil {
+		return status.code, nil
+	}
+
+	return ExitCode(err), err
+}
+
+// detailedExitCodeRequested reports whether the command is run with --detailed-exit-code (flag or environment variable).
+// It is used before the parsing of the flags of the command (e.g. by the global Before function).
+func detailedExitCodeRequested(ctx *cli.Context) bool {
+	args := ctx.Args().Slice()
+	if len(args) == 0 {
+		return false
+	}
+
+	command := ctx.App.Command(args[0])
+	if command == nil {
+		return false
+	}
+
+	idx := slices.IndexFunc(command.Flags, func(flag cli.Flag) bool {
+		return slices.Contains(flag.Names(), flgDetailedExitCode)
+	})
+	if idx < 0 {
+		return false
+	}
+
+	requested := false
+
+	if flag, ok := command.Flags[idx].(*cli.BoolFlag); ok {
+		for _, name := range flag.EnvVars {
+			if v, err := strconv.ParseBool(os.Getenv(name)); err == nil {
+				requested = v
+			}
+		}
+	}
+
+	for _, arg := range args[1:] {
+		if arg == "--" {
+			break
+		}
+
+		name, value, found := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != flgDetailedExitCode {
+			continue
+		}
+
+		if !found {
+			requested = true
+			continue
+		}
+
+		if v, err := strconv.ParseBool(value); err == nil {
+			requested = v
+		}
+	}
+
+	return requested
+}
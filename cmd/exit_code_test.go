@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_newDetailedExitStatus(t *testing.T) {
+	errRenew := errors.New("renewal error")
+
+	testCases := []struct {
+		desc     string
+		reports  []*renewalReport
+		err      error
+		expected int
+	}{
+		{
+			desc:     "nothing renewed",
+			reports:  []*renewalReport{{Decision: renewalSkipped}},
+			expected: exitCodeNothingRenewed,
+		},
+		{
+			desc:     "no certificates",
+			expected: exitCodeNothingRenewed,
+		},
+		{
+			desc:     "renewed",
+			reports:  []*renewalReport{{Decision: renewalSkipped}, {Decision: renewalRenewed}},
+			expected: exitCodeRenewed,
+		},
+		{
+			desc:     "error",
+			reports:  []*renewalReport{{Decision: renewalSkipped}, {Decision: renewalFailed}},
+			err:      errRenew,
+			expected: exitCodeError,
+		},
+		{
+			desc:     "error without report",
+			err:      errRenew,
+			expected: exitCodeError,
+		},
+		{
+			desc:     "partial",
+			reports:  []*renewalReport{{Decision: renewalRenewed}, {Decision: renewalFailed}},
+			err:      errRenew,
+			expected: exitCodePartial,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := newDetailedExitStatus(test.reports, test.err)
+
+			if test.expected == exitCodeNothingRenewed {
+				require.NoError(t, err)
+				return
+			}
+
+			code, errS := ExitStatus(err)
+			assert.Equal(t, test.expected, code)
+
+			if test.err == nil {
+				assert.NoError(t, errS)
+			} else {
+				assert.ErrorIs(t, errS, test.err)
+			}
+		})
+	}
+}
+
+func TestExitCode_detailed(t *testing.T) {
+	// The detailed exit code takes precedence over the exit code of the failed hook.
+	err := &exitStatus{code: exitCodeError, err: &hookError{exitCode: 42, err: errors.New("exit status 42")}}
+
+	assert.Equal(t, exitCodeError, ExitCode(err))
+}
+
+func TestBefore_detailedExitCode(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		args     []string
+		env      string
+		expected int
+	}{
+		{
+			desc:     "default",
+			args:     []string{"renew"},
+			expected: 1,
+		},
+		{
+			desc:     "flag",
+			args:     []string{"renew", "--" + flgDetailedExitCode},
+			expected: exitCodeError,
+		},
+		{
+			desc:     "flag value",
+			args:     []string{"renew", "--" + flgDetailedExitCode + "=true"},
+			expected: exitCodeError,
+		},
+		{
+			desc:     "disabled",
+			args:     []string{"renew", "--" + flgDetailedExitCode + "=false"},
+			expected: 1,
+		},
+		{
+			desc:     "environment variable",
+			args:     []string{"renew"},
+			env:      "true",
+			expected: exitCodeError,
+		},
+		{
+			desc:     "after the arguments terminator",
+			args:     []string{"renew", "--", "--" + flgDetailedExitCode},
+			expected: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			dir := t.TempDir()
+
+			// A typo in the configuration file.
+			configFile := filepath.Join(dir, "lego.yml")
+			require.NoError(t, os.WriteFile(configFile, []byte("emial: test@example.com\n"), 0o600))
+
+			command := createRenew()
+			addCommandEnvVars("", command)
+
+			if test.env != "" {
+				idx := slices.IndexFunc(command.Flags, func(flag cli.Flag) bool { return slices.Contains(flag.Names(), flgDetailedExitCode) })
+				t.Setenv(command.Flags[idx].(*cli.BoolFlag).EnvVars[0], test.env)
+			}
+
+			app := cli.NewApp()
+			app.Flags = CreateFlags(dir)
+			app.Before = Before
+			app.Commands = []*cli.Command{command}
+
+			err := app.Run(append([]string{"lego", "--config", configFile}, test.args...))
+			require.ErrorContains(t, err, "could not load the configuration file")
+
+			assert.Equal(t, test.expected, ExitCode(err))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/urfave/cli/v2"
+)
+
+// getServers returns the URLs of the directories of the CAs (--server), in priority order.
+func getServers(ctx *cli.Context) []string {
+	var servers []string
+
+	for _, server := range ctx.StringSlice(flgServer) {
+		server = strings.TrimSpace(server)
+		if server != "" {
+			servers = append(servers, server)
+		}
+	}
+
+	return servers
+}
+
+// getServer returns the URL of the directory of the primary CA.
+func getServer(ctx *cli.Context) string {
+	servers := getServers(ctx)
+	if len(servers) == 0 {
+		return ""
+	}
+
+	return servers[0]
+}
+
+// obtainWithFailover obtains the certificate with the client of the primary CA,
+// then with the fallback CAs (--server), in priority order, while the CAs are unavailable.
+// The primary argument of the obtain function is false for the fallback CAs.
+func obtainWithFailover(ctx *cli.Context, client *lego.Client, account *Account, keyType certcrypto.KeyType,
+	obtain func(client *lego.Client, primary bool) (*certificate.Resource, error),
+) (*certificate.Resource, error) {
+	certRes, err := obtain(client, true)
+
+	current := account.server
+
+	for _, server := range getServers(ctx) {
+		if err == nil || !isCAUnavailable(err) {
+			break
+		}
+
+		if server == account.server {
+			continue
+		}
+
+		if retryAfter := getRetryAfter(err); retryAfter > 0 {
+			log.Warnf("failover: the CA %s is unavailable (retry after %s), trying %s: %v", current, retryAfter, server, err)
+		} else {
+			log.Warnf("failover: the CA %s is unavailable, trying %s: %v", current, server, err)
+		}
+
+		current = server
+
+		fallback, errF := setupFallbackClient(ctx, server, account.Email, keyType)
+		if errF != nil {
+			err = fmt.Errorf("failover to %s: %w", server, errF)
+			continue
+		}
+
+		certRes, err = obtain(fallback, false)
+	}
+
+	return certRes, err
+}
+
+// setupFallbackClient creates the client of a fallback CA, with the account of the user for this CA.
+// The account is registered if needed, only if the TOS have been accepted (--accept-tos).
+func setupFallbackClient(ctx *cli.Context, server, email string, keyType certcrypto.KeyType) (*lego.Client, error) {
+	accountsStorage, err := newAccountsStorage(ctx, server, email)
+	if err != nil {
+		return nil, err
+	}
+
+	account, _, err := setupAccount(ctx, accountsStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := createClient(ctx, server, account, keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setupChallenges(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Registration != nil {
+		return client, nil
+	}
+
+	if !ctx.Bool(flgAcceptTOS) {
+		return nil, fmt.Errorf("the account %s is not registered: use --%s to register it automatically", accountsStorage.GetUserID(), flgAcceptTOS)
+	}
+
+	log.Infof("failover: registering the account %s", accountsStorage.GetUserID())
+
+	credentials, err := provisionEAB(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	if credentials != nil {
+		account.Registration, err = client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
+			TermsOfServiceAgreed: true,
+			Kid:                  credentials.KID,
+			HmacEncoded:          credentials.HMACEncoded,
+		})
+	} else {
+		account.Registration, err = client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not complete registration: %w", err)
+	}
+
+	err = accountsStorage.Save(account)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// isCAUnavailable checks if the error means that the CA is unavailable:
+// server errors (5xx, still failing after the retries of the HTTP client) and rate limits.
+func isCAUnavailable(err error) bool {
+	var problem *acme.ProblemDetails
+	if !errors.As(err, &problem) {
+		return false
+	}
+
+	return problem.HTTPStatus >= http.StatusInternalServerError || problem.Type == acme.RateLimitedErr
+}
+
+// getRetryAfter returns the delay requested by the CA before the next attempt (Retry-After header of the error), or 0.
+func getRetryAfter(err error) time.Duration {
+	var problem *acme.ProblemDetails
+	if !errors.As(err, &problem) || problem.RetryAfter == "" {
+		return 0
+	}
+
+	retryAfter, errP := api.ParseRetryAfter(problem.RetryAfter)
+	if errP != nil {
+		return 0
+	}
+
+	return retryAfter
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// newFakeCA creates a minimal ACME server: directory, nonces, and account registration.
+func newFakeCA(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("GET /directory", func(rw http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(rw).Encode(acme.Directory{
+			NewNonceURL:   server.URL + "/nonce",
+			NewAccountURL: server.URL + "/account",
+			NewOrderURL:   server.URL + "/order",
+			RenewalInfo:   server.URL + "/renewalInfo",
+		})
+	})
+
+	mux.HandleFunc("HEAD /nonce", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Replay-Nonce", "nonce")
+	})
+
+	mux.HandleFunc("POST /account", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Replay-Nonce", "nonce")
+		rw.Header().Set("Location", server.URL+"/account/1")
+		rw.WriteHeader(http.StatusCreated)
+
+		_ = json.NewEncoder(rw).Encode(acme.Account{Status: acme.StatusValid})
+	})
+
+	return server
+}
+
+func runFailover(t *testing.T, primaryErr error, args ...string) ([]string, error) {
+	t.Helper()
+
+	fallback := newFakeCA(t)
+
+	var (
+		calls []string
+		err   error
+	)
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags(t.TempDir())
+	app.Action = func(ctx *cli.Context) error {
+		account := &Account{Email: "test@example.com", server: getServer(ctx)}
+
+		_, err = obtainWithFailover(ctx, nil, account, certcrypto.EC256, func(client *lego.Client, primary bool) (*certificate.Resource, error) {
+			if primary {
+				calls = append(calls, "primary")
+				return nil, primaryErr
+			}
+
+			calls = append(calls, "fallback")
+
+			return &certificate.Resource{Domain: "example.com"}, nil
+		})
+
+		return nil
+	}
+
+	errR := app.Run(append([]string{"lego", "--server", "https://primary.example.com/directory", "--server", fallback.URL + "/directory",
+		"--key-type", "ec256", "--dns", "manual", "--email", "test@example.com", "--tls-skip-verify"}, args...))
+	require.NoError(t, errR)
+
+	return calls, err
+}
+
+func Test_obtainWithFailover(t *testing.T) {
+	primaryErr := &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:serverInternal", HTTPStatus: http.StatusServiceUnavailable}
+
+	calls, err := runFailover(t, fmt.Errorf("error: %w", primaryErr), "--accept-tos")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"primary", "fallback"}, calls)
+}
+
+func Test_obtainWithFailover_notUnavailable(t *testing.T) {
+	primaryErr := &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:unauthorized", HTTPStatus: http.StatusForbidden}
+
+	calls, err := runFailover(t, primaryErr, "--accept-tos")
+	require.ErrorIs(t, err, primaryErr)
+
+	assert.Equal(t, []string{"primary"}, calls)
+}
+
+func Test_obtainWithFailover_notRegistered(t *testing.T) {
+	primaryErr := &acme.ProblemDetails{Type: acme.RateLimitedErr, HTTPStatus: http.StatusTooManyRequests}
+
+	calls, err := runFailover(t, primaryErr)
+	require.ErrorContains(t, err, "use --accept-tos to register it automatically")
+
+	assert.Equal(t, []string{"primary"}, calls)
+}
+
+func Test_isCAUnavailable(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		err      error
+		expected bool
+	}{
+		{
+			desc:     "server error",
+			err:      &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:serverInternal", HTTPStatus: http.StatusInternalServerError},
+			expected: true,
+		},
+		{
+			desc:     "rate limited",
+			err:      fmt.Errorf("error: %w", &acme.ProblemDetails{Type: acme.RateLimitedErr, HTTPStatus: http.StatusTooManyRequests}),
+			expected: true,
+		},
+		{
+			desc: "unauthorized",
+			err:  &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:unauthorized", HTTPStatus: http.StatusForbidden},
+		},
+		{
+			desc: "other error",
+			err:  errors.New("boom"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, isCAUnavailable(test.err))
+		})
+	}
+}
+
+func Test_getServers(t *testing.T) {
+	var servers []string
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags(t.TempDir())
+	app.Action = func(ctx *cli.Context) error {
+		servers = getServers(ctx)
+		return nil
+	}
+
+	err := app.Run([]string{"lego", "--server", "https://a.example.com/directory", "--server", "https://b.example.com/directory"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"https://a.example.com/directory", "https://b.example.com/directory"}, servers)
+}
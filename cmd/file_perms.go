@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+)
+
+// fileOwner the owner and the group applied to the stored files (-1 means unchanged).
+type fileOwner struct {
+	uid int
+	gid int
+}
+
+func (o *fileOwner) apply(filePath string) error {
+	if o == nil {
+		return nil
+	}
+
+	return os.Chown(filePath, o.uid, o.gid)
+}
+
+// getFileModes returns the permissions of the stored files by extension.
+func getFileModes(ctx *cli.Context) (map[string]os.FileMode, error) {
+	modes := make(map[string]os.FileMode)
+
+	for _, item := range []struct {
+		flag       string
+		extensions []string
+	}{
+		{flag: flgFileModeKey, extensions: []string{keyExt}},
+		{flag: flgFileModeCert, extensions: []string{certExt, issuerExt, derExt}},
+		{flag: flgFileModePEM, extensions: []string{pemExt}},
+		{flag: flgFileModePFX, extensions: []string{pfxExt}},
+	} {
+		mode, err := parseFileMode(ctx.String(item.flag))
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", item.flag, err)
+		}
+
+		for _, ext := range item.extensions {
+			modes[ext] = mode
+		}
+	}
+
+	return modes, nil
+}
+
+// getFileOwner returns the owner and the group to apply to the stored files, or nil if none is defined.
+func getFileOwner(ctx *cli.Context) (*fileOwner, error) {
+	if ctx.String(flgFileOwner) == "" && ctx.String(flgFileGroup) == "" {
+		return nil, nil
+	}
+
+	owner := &fileOwner{uid: -1, gid: -1}
+
+	if name := ctx.String(flgFileOwner); name != "" {
+		uid, err := lookupID(name, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+
+			return u.Uid, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", flgFileOwner, err)
+		}
+
+		owner.uid = uid
+	}
+
+	if name := ctx.String(flgFileGroup); name != "" {
+		gid, err := lookupID(name, func(name string) (string, error) {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return "", err
+			}
+
+			return g.Gid, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", flgFileGroup, err)
+		}
+
+		owner.gid = gid
+	}
+
+	return owner, nil
+}
+
+// lookupID returns the numeric ID, or resolves the name.
+func lookupID(value string, lookup func(name string) (string, error)) (int, error) {
+	if id, err := strconv.Atoi(value); err == nil {
+		return id, nil
+	}
+
+	raw, err := lookup(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(raw)
+}
+
+func parseFileMode(value string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid file mode %q: %w", value, err)
+	}
+
+	if mode > 0o777 {
+		return 0, fmt.Errorf("invalid file mode %q: only the permission bits are supported", value)
+	}
+
+	return os.FileMode(mode), nil
+}
+
+func formatFileMode(mode os.FileMode) string {
+	return fmt.Sprintf("%04o", mode.Perm())
+}
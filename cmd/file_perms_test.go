@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseFileMode(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected os.FileMode
+	}{
+		{desc: "with leading zero", value: "0640", expected: 0o640},
+		{desc: "without leading zero", value: "644", expected: 0o644},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			mode, err := parseFileMode(test.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, mode)
+		})
+	}
+}
+
+func Test_parseFileMode_errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected string
+	}{
+		{
+			desc:     "not octal",
+			value:    "0x644",
+			expected: `invalid file mode "0x644": strconv.ParseUint: parsing "0x644": invalid syntax`,
+		},
+		{
+			desc:     "special bits",
+			value:    "4755",
+			expected: `invalid file mode "4755": only the permission bits are supported`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseFileMode(test.value)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func Test_lookupID(t *testing.T) {
+	id, err := lookupID("33", func(string) (string, error) {
+		t.Fatal("unexpected lookup")
+		return "", nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 33, id)
+
+	id, err = lookupID("www-data", func(name string) (string, error) {
+		assert.Equal(t, "www-data", name)
+		return "42", nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 42, id)
+}
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/lego"
 	"github.com/urfave/cli/v2"
@@ -12,7 +15,10 @@ import (
 
 // Flag names.
 const (
+	flgConfig                   = "config"
 	flgDomains                  = "domains"
+	flgDomainsFile              = "domains-file"
+	flgDomainsStdin             = "domains-stdin"
 	flgServer                   = "server"
 	flgAcceptTOS                = "accept-tos"
 	flgEmail                    = "email"
@@ -21,8 +27,11 @@ const (
 	flgEAB                      = "eab"
 	flgKID                      = "kid"
 	flgHMAC                     = "hmac"
+	flgEABZeroSSLAPIKey         = "eab.zerossl-api-key"
+	flgEABGTSProject            = "eab.gts-project"
 	flgKeyType                  = "key-type"
 	flgFilename                 = "filename"
+	flgCertName                 = "cert-name"
 	flgPath                     = "path"
 	flgHTTP                     = "http"
 	flgHTTPPort                 = "http.port"
@@ -39,6 +48,7 @@ const (
 	flgDNSPropagationWait       = "dns.propagation-wait"
 	flgDNSPropagationDisableANS = "dns.propagation-disable-ans"
 	flgDNSPropagationRNS        = "dns.propagation-rns"
+	flgDNSPropagationReuseConn  = "dns.propagation-reuse-conn"
 	flgDNSResolvers             = "dns.resolvers"
 	flgHTTPTimeout              = "http-timeout"
 	flgTLSSkipVerify            = "tls-skip-verify"
@@ -47,36 +57,78 @@ const (
 	flgPFX                      = "pfx"
 	flgPFXPass                  = "pfx.pass"
 	flgPFXFormat                = "pfx.format"
+	flgPFXPassFile              = "pfx.pass-file"
+	flgDER                      = "der"
 	flgCertTimeout              = "cert.timeout"
+	flgOrderTimeout             = "order-timeout"
+	flgOrderPollInterval        = "order-poll-interval"
+	flgOrderAttemptTimeout      = "order-attempt-timeout"
 	flgOverallRequestLimit      = "overall-request-limit"
+	flgBadNonceRetries          = "bad-nonce-retries"
 	flgUserAgent                = "user-agent"
+	flgFIPS                     = "fips"
+	flgKeyPassFile              = "key-pass-file"
+	flgKeyDir                   = "key-dir"
+	flgKeyPathDir               = "key-path-dir"
+	flgFileModeKey              = "file-mode.key"
+	flgFileModeCert             = "file-mode.cert"
+	flgFileModePEM              = "file-mode.pem"
+	flgFileModePFX              = "file-mode.pfx"
+	flgFileOwner                = "file-owner"
+	flgFileGroup                = "file-group"
+	flgNoColor                  = "no-color"
+	flgLogLevel                 = "log-level"
+	flgLogFormat                = "log-format"
+	flgJSON                     = "json"
+	flgPrintConfig              = "print-config"
 )
 
 const (
+	envConfig      = "LEGO_CONFIG"
 	envEAB         = "LEGO_EAB"
 	envEABHMAC     = "LEGO_EAB_HMAC"
 	envEABKID      = "LEGO_EAB_KID"
 	envEmail       = "LEGO_EMAIL"
+	envFIPS        = "LEGO_FIPS"
+	envKeyPassFile = "LEGO_KEY_PASS_FILE"
+	envKeyPassword = "LEGO_KEY_PASSWORD"
 	envPath        = "LEGO_PATH"
 	envPFX         = "LEGO_PFX"
 	envPFXFormat   = "LEGO_PFX_FORMAT"
 	envPFXPassword = "LEGO_PFX_PASSWORD"
+	envPFXPassFile = "LEGO_PFX_PASS_FILE"
 	envServer      = "LEGO_SERVER"
 )
 
 func CreateFlags(defaultPath string) []cli.Flag {
-	return []cli.Flag{
+	return addEnvVars("", []cli.Flag{
+		&cli.StringFlag{
+			Name:      flgConfig,
+			EnvVars:   []string{envConfig},
+			Usage:     "Path to a configuration file (YAML if the extension is .yaml or .yml, TOML otherwise). The flags and the environment variables override the values of the file.",
+			TakesFile: true,
+		},
 		&cli.StringSliceFlag{
 			Name:    flgDomains,
 			Aliases: []string{"d"},
-			Usage:   "Add a domain to the process. Can be specified multiple times.",
+			Usage:   "Add a domain to the process. Can be specified multiple times. Use '-' to read the domains from stdin.",
 		},
 		&cli.StringFlag{
+			Name:      flgDomainsFile,
+			Usage:     "Read the domains from a file (one domain per line, the lines starting with # are ignored). Merged with --domains.",
+			TakesFile: true,
+		},
+		&cli.BoolFlag{
+			Name:  flgDomainsStdin,
+			Usage: "Read the domains from stdin (separated by spaces or new lines). Merged with --domains. Same as '--domains -'.",
+		},
+		&cli.StringSliceFlag{
 			Name:    flgServer,
 			Aliases: []string{"s"},
 			EnvVars: []string{envServer},
-			Usage:   "CA hostname (and optionally :port). The server certificate must be trusted in order to avoid further modifications to the client.",
-			Value:   lego.LEDirectoryProduction,
+			Usage: "CA hostname (and optionally :port). The server certificate must be trusted in order to avoid further modifications to the client." +
+				" Can be specified multiple times: the other CAs, in priority order, are used by the renewal when the first CA is unavailable.",
+			Value: cli.NewStringSlice(lego.LEDirectoryProduction),
 		},
 		&cli.BoolFlag{
 			Name:    flgAcceptTOS,
@@ -96,7 +148,7 @@ func CreateFlags(defaultPath string) []cli.Flag {
 		&cli.StringFlag{
 			Name:    flgCSR,
 			Aliases: []string{"c"},
-			Usage:   "Certificate signing request filename, if an external CSR is to be used.",
+			Usage:   "Certificate signing request filename (PEM or DER), if an external CSR is to be used. Use '-' to read the CSR from the standard input.",
 		},
 		&cli.BoolFlag{
 			Name:    flgEAB,
@@ -113,15 +165,30 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			EnvVars: []string{envEABHMAC},
 			Usage:   "MAC key from External CA. Should be in Base64 URL Encoding without padding format. Used for External Account Binding.",
 		},
+		&cli.StringFlag{
+			Name: flgEABZeroSSLAPIKey,
+			Usage: "ZeroSSL API key. Used to generate the External Account Binding credentials during the registration," +
+				" when the server is ZeroSSL and --eab is not used.",
+		},
+		&cli.StringFlag{
+			Name: flgEABGTSProject,
+			Usage: "Google Cloud project used to create the External Account Binding credentials during the registration," +
+				" when the server is Google Trust Services and --eab is not used. By default, the project of the Application Default Credentials.",
+		},
 		&cli.StringFlag{
 			Name:    flgKeyType,
 			Aliases: []string{"k"},
 			Value:   "ec256",
-			Usage:   "Key type to use for private keys. Supported: rsa2048, rsa3072, rsa4096, rsa8192, ec256, ec384.",
+			Usage:   "Key type to use for private keys. Supported: " + strings.Join(certcrypto.KeyTypeNames(), ", ") + ".",
 		},
 		&cli.StringFlag{
 			Name:  flgFilename,
-			Usage: "(deprecated) Filename of the generated certificate.",
+			Usage: "(deprecated) Filename of the generated certificate. Use --" + flgCertName + " instead.",
+		},
+		&cli.StringFlag{
+			Name: flgCertName,
+			Usage: "The name of the stored certificate (the base name of its files), instead of its first domain." +
+				" Identifies the certificate for the run, renew, revoke, and list commands, regardless of the order of the domains.",
 		},
 		&cli.StringFlag{
 			Name:    flgPath,
@@ -191,6 +258,10 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Name:  flgDNSPropagationRNS,
 			Usage: "By setting this flag to true, use all the recursive nameservers to check the propagation of the TXT record.",
 		},
+		&cli.BoolFlag{
+			Name:  flgDNSPropagationReuseConn,
+			Usage: "By setting this flag to true, reuse a TCP connection per authoritative name server to check the propagation of the TXT records.",
+		},
 		&cli.DurationFlag{
 			Name:  flgDNSPropagationWait,
 			Usage: "By setting this flag, disables all the propagation checks of the TXT record and uses a wait duration instead.",
@@ -231,26 +302,128 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			EnvVars: []string{envPFXPassword},
 		},
 		&cli.StringFlag{
-			Name:    flgPFXFormat,
-			Usage:   "The encoding format to use when encrypting the .pfx (PCKS#12) file. Supported: RC2, DES, SHA256.",
+			Name: flgPFXFormat,
+			Usage: "The encoding format to use when encrypting the .pfx (PCKS#12) file." +
+				" Supported: RC2, DES, SHA256 (AES-256 and SHA-256), modern (alias of SHA256), legacy (alias of RC2).",
 			Value:   "RC2",
 			EnvVars: []string{envPFXFormat},
 		},
+		&cli.StringFlag{
+			Name:      flgPFXPassFile,
+			Usage:     "The file containing the password used to encrypt the .pfx (PCKS#12) file. Replaces the --" + flgPFXPass + " option.",
+			EnvVars:   []string{envPFXPassFile},
+			TakesFile: true,
+		},
+		&cli.BoolFlag{
+			Name:  flgDER,
+			Usage: "Generate an additional .der file containing the certificate (without the issuers) in DER encoding.",
+		},
+		&cli.StringFlag{
+			Name: flgKeyPassFile,
+			Usage: "The file containing the passphrase used to encrypt the private key of the certificate (PKCS#8, .key and .pem files)." +
+				" The passphrase can also be defined with the " + envKeyPassword + " environment variable.",
+			EnvVars:   []string{envKeyPassFile},
+			TakesFile: true,
+		},
+		&cli.StringFlag{
+			Name:    flgKeyDir,
+			Aliases: []string{flgKeyPathDir},
+			Usage: "Directory to use for storing the files containing the private keys of the certificates (.key, .pem, .pfx files)," +
+				" e.g. a directory only readable by root. Default: the certificates directory.",
+			TakesFile: true,
+		},
+		&cli.StringFlag{
+			Name:  flgFileModeKey,
+			Usage: "The permissions (octal) of the .key files.",
+			Value: formatFileMode(filePerm),
+		},
+		&cli.StringFlag{
+			Name:  flgFileModeCert,
+			Usage: "The permissions (octal) of the .crt and .der files.",
+			Value: formatFileMode(filePerm),
+		},
+		&cli.StringFlag{
+			Name:  flgFileModePEM,
+			Usage: "The permissions (octal) of the .pem files.",
+			Value: formatFileMode(filePerm),
+		},
+		&cli.StringFlag{
+			Name:  flgFileModePFX,
+			Usage: "The permissions (octal) of the .pfx files.",
+			Value: formatFileMode(filePerm),
+		},
+		&cli.StringFlag{
+			Name:  flgFileOwner,
+			Usage: "The owner (name or UID) of the .key, .crt, .pem, and .pfx files. Requires the appropriate privileges (root).",
+		},
+		&cli.StringFlag{
+			Name:  flgFileGroup,
+			Usage: "The group (name or GID) of the .key, .crt, .pem, and .pfx files. Requires the appropriate privileges (root).",
+		},
 		&cli.IntFlag{
 			Name:  flgCertTimeout,
 			Usage: "Set the certificate timeout value to a specific value in seconds. Only used when obtaining certificates.",
 			Value: 30,
 		},
+		&cli.DurationFlag{
+			Name: flgOrderTimeout,
+			Usage: "Overall deadline to wait for the certificate while the order is processed by the CA (e.g. 5m)." +
+				" Takes precedence over --" + flgCertTimeout + ".",
+		},
+		&cli.DurationFlag{
+			Name:  flgOrderPollInterval,
+			Usage: "Interval between the polls of the order while it is processed by the CA. By default, 1/60 of the order timeout.",
+		},
+		&cli.DurationFlag{
+			Name:  flgOrderAttemptTimeout,
+			Usage: "Timeout of each poll of the order. By default, only the HTTP timeout applies.",
+		},
 		&cli.IntFlag{
 			Name:  flgOverallRequestLimit,
 			Usage: "ACME overall requests limit.",
 			Value: certificate.DefaultOverallRequestLimit,
 		},
+		&cli.IntFlag{
+			Name:  flgBadNonceRetries,
+			Usage: "Maximum number of retries, with a fresh nonce, of a request rejected by the CA with a badNonce error. 0 disables the retries.",
+			Value: api.DefaultBadNonceRetries,
+		},
 		&cli.StringFlag{
 			Name:  flgUserAgent,
 			Usage: "Add to the user-agent sent to the CA to identify an application embedding lego-cli",
 		},
-	}
+		&cli.BoolFlag{
+			Name: flgFIPS,
+			Usage: "Restrict the key generation, the account key signatures, and the PFX encoding to FIPS-approved algorithms." +
+				" Always enabled with a FIPS build or when the Go Cryptographic Module is in FIPS 140-3 mode.",
+			EnvVars: []string{envFIPS},
+		},
+		&cli.BoolFlag{
+			Name: flgNoColor,
+			Usage: "Disable the colors and the progress display." +
+				" The output is always plain when it is not a terminal or when the NO_COLOR environment variable is set.",
+		},
+		&cli.StringFlag{
+			Name:  flgLogLevel,
+			Usage: "The minimum level of the log entries: debug, info, warn, or error.",
+			Value: "info",
+		},
+		&cli.StringFlag{
+			Name: flgLogFormat,
+			Usage: "The format of the log entries: '" + logFormatText + "' or '" + logFormatJSON + "'" +
+				" (one JSON object per line, with the domain as a field).",
+			Value: logFormatText,
+		},
+		&cli.BoolFlag{
+			Name: flgJSON,
+			Usage: "Display the result of the list, run, and renew commands as JSON on stdout." +
+				" The logs and the other messages are displayed on stderr.",
+		},
+		&cli.BoolFlag{
+			Name:  flgPrintConfig,
+			Usage: "Display the resolved configuration (with the source of each value, and the secrets masked) before running the command.",
+		},
+	})
 }
 
 func getTime(ctx *cli.Context, name string) time.Time {
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+const envPrefix = "LEGO_"
+
+// envFileSuffix the suffix of the environment variables defining a file containing the value (e.g. LEGO_HMAC_FILE).
+const envFileSuffix = "_FILE"
+
+// addEnvVars adds an environment variable to every flag:
+// LEGO_<FLAG> for the global flags, and LEGO_<COMMAND>_<FLAG> for the flags of a command.
+// The existing environment variables of a flag are kept first.
+func addEnvVars(command string, flags []cli.Flag) []cli.Flag {
+	for _, flag := range flags {
+		name := envVarName(command, flag.Names()[0])
+
+		switch f := flag.(type) {
+		case *cli.BoolFlag:
+			f.EnvVars = appendEnvVar(f.EnvVars, name)
+		case *cli.DurationFlag:
+			f.EnvVars = appendEnvVar(f.EnvVars, name)
+		case *cli.GenericFlag:
+			f.EnvVars = appendEnvVar(f.EnvVars, name)
+		case *cli.IntFlag:
+			f.EnvVars = appendEnvVar(f.EnvVars, name)
+		case *cli.StringFlag:
+			f.EnvVars = appendEnvVar(f.EnvVars, name)
+		case *cli.StringSliceFlag:
+			f.EnvVars = appendEnvVar(f.EnvVars, name)
+		case *cli.TimestampFlag:
+			f.EnvVars = appendEnvVar(f.EnvVars, name)
+		case *cli.UintFlag:
+			f.EnvVars = appendEnvVar(f.EnvVars, name)
+		}
+	}
+
+	return flags
+}
+
+func envVarName(command, flag string) string {
+	name := envPrefix
+
+	if command != "" {
+		name += command + "_"
+	}
+
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(name + flag))
+}
+
+func appendEnvVar(envVars []string, name string) []string {
+	if slices.Contains(envVars, name) {
+		return envVars
+	}
+
+	return append(envVars, name)
+}
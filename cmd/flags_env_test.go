@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_addEnvVars(t *testing.T) {
+	flags := addEnvVars("renew", []cli.Flag{
+		&cli.StringFlag{Name: "renew-hook"},
+		&cli.BoolFlag{Name: "ari-disable", EnvVars: []string{"FOO"}},
+		&cli.StringSliceFlag{Name: "issuer.allow"},
+	})
+
+	assert.Equal(t, []string{"LEGO_RENEW_RENEW_HOOK"}, flags[0].(*cli.StringFlag).EnvVars)
+	assert.Equal(t, []string{"FOO", "LEGO_RENEW_ARI_DISABLE"}, flags[1].(*cli.BoolFlag).EnvVars)
+	assert.Equal(t, []string{"LEGO_RENEW_ISSUER_ALLOW"}, flags[2].(*cli.StringSliceFlag).EnvVars)
+}
+
+func Test_addEnvVars_all(t *testing.T) {
+	flags := CreateFlags("")
+
+	var collect func(commands []*cli.Command)
+
+	collect = func(commands []*cli.Command) {
+		for _, command := range commands {
+			flags = append(flags, command.Flags...)
+			collect(command.Subcommands)
+		}
+	}
+
+	collect(CreateCommands())
+
+	for _, flag := range flags {
+		f, ok := flag.(cli.DocGenerationFlag)
+		if !ok {
+			continue
+		}
+
+		assert.NotEmpty(t, f.GetEnvVars(), flag.Names()[0])
+	}
+}
+
+func Test_envVarName(t *testing.T) {
+	assert.Equal(t, "LEGO_KEY_TYPE", envVarName("", "key-type"))
+	assert.Equal(t, "LEGO_DNS_PROPAGATION_WAIT", envVarName("", "dns.propagation-wait"))
+	assert.Equal(t, "LEGO_CT_WATCH_SINCE", envVarName("ct-watch", "since"))
+}
@@ -2,15 +2,36 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgPreHook      = "pre-hook"
+	flgPostHook     = "post-hook"
+	flgHookTimeout  = "hook-timeout"
+	flgHookMaterial = "hook-material"
+)
+
+// The ways to give the certificate material to the run/renew hook (--hook-material).
+const (
+	hookMaterialEnv   = "env"
+	hookMaterialStdin = "stdin"
 )
 
 const (
@@ -21,14 +42,147 @@ const (
 	hookEnvIssuerCertKeyPath = "LEGO_ISSUER_CERT_PATH"
 	hookEnvCertPEMPath       = "LEGO_CERT_PEM_PATH"
 	hookEnvCertPFXPath       = "LEGO_CERT_PFX_PATH"
+	hookEnvCertDERPath       = "LEGO_CERT_DER_PATH"
+	hookEnvRenewalEmergency  = "LEGO_RENEWAL_EMERGENCY"
+	hookEnvCert              = "LEGO_CERT"
+	hookEnvCertKey           = "LEGO_CERT_KEY"
+	hookEnvIssuerCert        = "LEGO_ISSUER_CERT"
 )
 
-func launchHook(hook string, timeout time.Duration, meta map[string]string) error {
+func createHookFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: flgPreHook,
+			Usage: "Define a hook executed before the challenges, only when a certificate is effectively requested" +
+				" (e.g. to stop a web server and free the port 80).",
+		},
+		&cli.StringFlag{
+			Name: flgPostHook,
+			Usage: "Define a hook executed after the request of a certificate, even if the request has failed" +
+				" (e.g. to start a web server again).",
+		},
+		&cli.DurationFlag{
+			Name: flgHookTimeout,
+			Usage: "Define the timeout for the execution of the hooks (pre-hook, post-hook, and run/renew hook)." +
+				" The hook is killed after the timeout.",
+			Value: 2 * time.Minute,
+		},
+		&cli.StringFlag{
+			Name: flgHookMaterial,
+			Usage: "Give the PEM encoded certificate, private key, and issuer certificate to the run/renew hook," +
+				" in addition to the paths of the files." +
+				" Values: '" + hookMaterialEnv + "' (" + hookEnvCert + ", " + hookEnvCertKey + ", " + hookEnvIssuerCert + ")," +
+				" or '" + hookMaterialStdin + "' (the PEM blocks on stdin).",
+		},
+	}
+}
+
+// getHookTimeout returns the timeout of a hook: the timeout of the hook (e.g. --renew-hook-timeout) if defined, or --hook-timeout.
+func getHookTimeout(ctx *cli.Context, name string) time.Duration {
+	if name != "" && ctx.IsSet(name) {
+		return ctx.Duration(name)
+	}
+
+	return ctx.Duration(flgHookTimeout)
+}
+
+// launchPreHook launches the pre-hook, and returns a function launching the post-hook.
+// The post-hook is launched even if the pre-hook or the request of the certificate have failed:
+// its error is joined to the error of the request.
+func launchPreHook(ctx *cli.Context, meta map[string]string) (func(err *error), error) {
+	noop := func(*error) {}
+
+	// The options of the hooks are checked before the request of the certificate.
+	switch ctx.String(flgHookMaterial) {
+	case "", hookMaterialEnv, hookMaterialStdin:
+	default:
+		return noop, fmt.Errorf("--%s: unsupported value: %q", flgHookMaterial, ctx.String(flgHookMaterial))
+	}
+
+	timeout := getHookTimeout(ctx, "")
+
+	postHook := func(err *error) {
+		errP := launchHook(ctx.Context, ctx.String(flgPostHook), timeout, meta)
+		if errP != nil {
+			*err = errors.Join(*err, fmt.Errorf("post-hook: %w", errP))
+		}
+	}
+
+	err := launchHook(ctx.Context, ctx.String(flgPreHook), timeout, meta)
+	if err != nil {
+		return postHook, fmt.Errorf("pre-hook: %w", err)
+	}
+
+	return postHook, nil
+}
+
+// hookError the error of a hook which has exited with a non-zero exit code.
+type hookError struct {
+	exitCode int
+	err      error
+}
+
+func (e *hookError) Error() string {
+	return e.err.Error()
+}
+
+func (e *hookError) Unwrap() error {
+	return e.err
+}
+
+// getHookExitCode returns the exit code of the failed hook, if the error is the error of a hook.
+func getHookExitCode(err error) *int {
+	var hookErr *hookError
+	if !errors.As(err, &hookErr) {
+		return nil
+	}
+
+	return &hookErr.exitCode
+}
+
+// launchCertificateHook launches the run/renew hook of a certificate,
+// with the certificate material if requested (--hook-material).
+func launchCertificateHook(ctx *cli.Context, hook string, timeout time.Duration, meta map[string]string, certRes *certificate.Resource) error {
+	if hook == "" {
+		return nil
+	}
+
+	var stdin io.Reader
+
+	switch ctx.String(flgHookMaterial) {
+	case hookMaterialEnv:
+		meta = maps.Clone(meta)
+
+		meta[hookEnvCert] = string(certRes.Certificate)
+
+		if certRes.PrivateKey != nil {
+			meta[hookEnvCertKey] = string(certRes.PrivateKey)
+		}
+
+		if certRes.IssuerCertificate != nil {
+			meta[hookEnvIssuerCert] = string(certRes.IssuerCertificate)
+		}
+
+	case hookMaterialStdin:
+		stdin = bytes.NewReader(slices.Concat(certRes.Certificate, certRes.PrivateKey, certRes.IssuerCertificate))
+	}
+
+	return launchHookWithInput(ctx.Context, hook, timeout, meta, stdin)
+}
+
+// launchHook executes the hook, and streams its output (stdout and stderr) to the logger.
+// The hook is killed after the timeout, or when the context is canceled.
+func launchHook(ctx context.Context, hook string, timeout time.Duration, meta map[string]string) error {
+	return launchHookWithInput(ctx, hook, timeout, meta, nil)
+}
+
+// launchHookWithInput executes the hook like launchHook, the content of the reader (optional) is written on the stdin of the hook.
+func launchHookWithInput(ctx context.Context, hook string, timeout time.Duration, meta map[string]string, stdin io.Reader) error {
 	if hook == "" {
 		return nil
 	}
 
-	ctxCmd, cancel := context.WithTimeout(context.Background(), timeout)
+	ctxCmd, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	parts := strings.Fields(hook)
@@ -36,13 +190,17 @@ func launchHook(hook string, timeout time.Duration, meta map[string]string) erro
 	cmd := exec.CommandContext(ctxCmd, parts[0], parts[1:]...)
 
 	cmd.Env = append(os.Environ(), metaToEnv(meta)...)
+	cmd.Stdin = stdin
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return fmt.Errorf("create pipe: %w", err)
 	}
 
-	cmd.Stderr = cmd.Stdout
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("create pipe: %w", err)
+	}
 
 	err = cmd.Start()
 	if err != nil {
@@ -55,13 +213,16 @@ func launchHook(hook string, timeout time.Duration, meta map[string]string) erro
 		if ctxCmd.Err() != nil {
 			_ = cmd.Process.Kill()
 			_ = stdout.Close()
+			_ = stderr.Close()
 		}
 	}()
 
-	scanner := bufio.NewScanner(stdout)
-	for scanner.Scan() {
-		fmt.Println(scanner.Text())
-	}
+	var wg sync.WaitGroup
+
+	wg.Go(func() { streamHookOutput(stdout, log.Infof) })
+	wg.Go(func() { streamHookOutput(stderr, log.Warnf) })
+
+	wg.Wait()
 
 	err = cmd.Wait()
 	if err != nil {
@@ -69,12 +230,24 @@ func launchHook(hook string, timeout time.Duration, meta map[string]string) erro
 			return errors.New("hook timed out")
 		}
 
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			return &hookError{exitCode: exitErr.ExitCode(), err: fmt.Errorf("wait command: %w", err)}
+		}
+
 		return fmt.Errorf("wait command: %w", err)
 	}
 
 	return nil
 }
 
+func streamHookOutput(r io.Reader, logf func(format string, args ...any)) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		logf("hook: %s", scanner.Text())
+	}
+}
+
 func metaToEnv(meta map[string]string) []string {
 	var envs []string
 
@@ -85,20 +258,38 @@ func metaToEnv(meta map[string]string) []string {
 	return envs
 }
 
-func addPathToMetadata(meta map[string]string, domain string, certRes *certificate.Resource, certsStorage *CertificatesStorage) {
-	meta[hookEnvCertDomain] = domain
-	meta[hookEnvCertPath] = certsStorage.GetFileName(domain, certExt)
-	meta[hookEnvCertKeyPath] = certsStorage.GetFileName(domain, keyExt)
+func addPathToMetadata(meta map[string]string, domain string, certRes *certificate.Resource, certsStorage *CertificatesStorage) error {
+	files := map[string]string{
+		hookEnvCertPath:    certExt,
+		hookEnvCertKeyPath: keyExt,
+	}
 
 	if certRes.IssuerCertificate != nil {
-		meta[hookEnvIssuerCertKeyPath] = certsStorage.GetFileName(domain, issuerExt)
+		files[hookEnvIssuerCertKeyPath] = issuerExt
 	}
 
 	if certsStorage.pem {
-		meta[hookEnvCertPEMPath] = certsStorage.GetFileName(domain, pemExt)
+		files[hookEnvCertPEMPath] = pemExt
 	}
 
 	if certsStorage.pfx {
-		meta[hookEnvCertPFXPath] = certsStorage.GetFileName(domain, pfxExt)
+		files[hookEnvCertPFXPath] = pfxExt
 	}
+
+	if certsStorage.der {
+		files[hookEnvCertDERPath] = derExt
+	}
+
+	meta[hookEnvCertDomain] = domain
+
+	for name, extension := range files {
+		filePath, err := certsStorage.GetFileName(domain, extension)
+		if err != nil {
+			return err
+		}
+
+		meta[name] = filePath
+	}
+
+	return nil
 }
@@ -0,0 +1,22 @@
+// Package eab provisions the External Account Binding credentials with the APIs of the CAs (ZeroSSL, Google Trust Services).
+package eab
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Credentials the External Account Binding credentials.
+type Credentials struct {
+	KID         string
+	HMACEncoded string
+}
+
+func matchHost(server, host string) bool {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(serverURL.Hostname(), host)
+}
@@ -0,0 +1,149 @@
+package eab
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	gtsBaseURL        = "https://publicca.googleapis.com"
+	gtsStagingBaseURL = "https://preprod-publicca.googleapis.com"
+)
+
+const (
+	gtsACMEHost        = "dv.acme-v02.api.pki.goog"
+	gtsStagingACMEHost = "dv.acme-v02.test-api.pki.goog"
+)
+
+const gtsScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// IsGTS checks if the directory URL is a Google Trust Services ACME server (production or staging).
+func IsGTS(server string) bool {
+	return slices.ContainsFunc([]string{gtsACMEHost, gtsStagingACMEHost}, func(host string) bool {
+		return matchHost(server, host)
+	})
+}
+
+// GTSClient the client of the Google Cloud Public Certificate Authority API, to create the EAB credentials.
+type GTSClient struct {
+	project string
+
+	BaseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewGTSClient creates a new GTSClient for the ACME server, authenticated with the Application Default Credentials.
+// The project is the project of the credentials, if not defined.
+func NewGTSClient(ctx context.Context, server, project string) (*GTSClient, error) {
+	credentials, err := google.FindDefaultCredentials(ctx, gtsScope)
+	if err != nil {
+		return nil, fmt.Errorf("gts: %w", err)
+	}
+
+	if project == "" {
+		project = credentials.ProjectID
+	}
+
+	if project == "" {
+		return nil, errors.New("gts: the project is missing, and the Application Default Credentials don't define a project")
+	}
+
+	rawURL := gtsBaseURL
+	if matchHost(server, gtsStagingACMEHost) {
+		rawURL = gtsStagingBaseURL
+	}
+
+	baseURL, _ := url.Parse(rawURL)
+
+	return &GTSClient{
+		project:    project,
+		BaseURL:    baseURL,
+		HTTPClient: oauth2.NewClient(ctx, credentials.TokenSource),
+	}, nil
+}
+
+// CreateCredentials creates a new external account key.
+// https://cloud.google.com/certificate-manager/docs/reference/public-ca/rest/v1/projects.locations.externalAccountKeys/create
+func (c *GTSClient) CreateCredentials(ctx context.Context) (*Credentials, error) {
+	endpoint := c.BaseURL.JoinPath("v1", "projects", c.project, "locations", "global", "externalAccountKeys")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader([]byte("{}")))
+	if err != nil {
+		return nil, fmt.Errorf("gts: unable to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gts: unable to perform the request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("gts: unable to read the response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr gtsErrorResponse
+
+		if json.Unmarshal(raw, &apiErr) == nil && apiErr.Error != nil {
+			return nil, fmt.Errorf("gts: %w", apiErr.Error)
+		}
+
+		return nil, fmt.Errorf("gts: unexpected status code: [status code: %d] body: %s", resp.StatusCode, string(raw))
+	}
+
+	var key gtsExternalAccountKey
+
+	err = json.Unmarshal(raw, &key)
+	if err != nil {
+		return nil, fmt.Errorf("gts: unable to unmarshal the response: %w", err)
+	}
+
+	if key.KeyID == "" || key.B64MacKey == "" {
+		return nil, errors.New("gts: the credentials are missing in the response")
+	}
+
+	// The field is a bytes field (base64 encoded in JSON), containing the base64url encoded HMAC key.
+	hmacEncoded, err := base64.StdEncoding.DecodeString(key.B64MacKey)
+	if err != nil {
+		return nil, fmt.Errorf("gts: HMAC key: %w", err)
+	}
+
+	return &Credentials{KID: key.KeyID, HMACEncoded: string(hmacEncoded)}, nil
+}
+
+type gtsExternalAccountKey struct {
+	Name      string `json:"name"`
+	KeyID     string `json:"keyId"`
+	B64MacKey string `json:"b64MacKey"`
+}
+
+type gtsErrorResponse struct {
+	Error *gtsError `json:"error"`
+}
+
+type gtsError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+func (e *gtsError) Error() string {
+	return fmt.Sprintf("%d: %s: %s", e.Code, e.Status, e.Message)
+}
@@ -0,0 +1,63 @@
+package eab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockGTSBuilder() *servermock.Builder[*GTSClient] {
+	return servermock.NewBuilder(
+		func(server *httptest.Server) (*GTSClient, error) {
+			baseURL, _ := url.Parse(server.URL)
+
+			return &GTSClient{
+				project:    "lego-test",
+				BaseURL:    baseURL,
+				HTTPClient: server.Client(),
+			}, nil
+		},
+		servermock.CheckHeader().
+			WithJSONHeaders(),
+	)
+}
+
+func TestGTSClient_CreateCredentials(t *testing.T) {
+	client := mockGTSBuilder().
+		Route("POST /v1/projects/lego-test/locations/global/externalAccountKeys",
+			servermock.ResponseFromFixture("gts_credentials.json"),
+			servermock.CheckRequestJSONBody(`{}`)).
+		Build(t)
+
+	credentials, err := client.CreateCredentials(t.Context())
+	require.NoError(t, err)
+
+	expected := &Credentials{
+		KID:         "ff1bcbd5-1c5a-4f71-9d6e-2d1e35e4f6a3",
+		HMACEncoded: "MjXU3MH-Z0WQ7piMAnVsCpD1shgMiWx6ggPWiTmydgUaj7dWWWfQfA",
+	}
+
+	assert.Equal(t, expected, credentials)
+}
+
+func TestGTSClient_CreateCredentials_error(t *testing.T) {
+	client := mockGTSBuilder().
+		Route("POST /v1/projects/lego-test/locations/global/externalAccountKeys",
+			servermock.ResponseFromFixture("gts_error.json").
+				WithStatusCode(http.StatusForbidden)).
+		Build(t)
+
+	_, err := client.CreateCredentials(t.Context())
+	require.EqualError(t, err, "gts: 403: PERMISSION_DENIED: Permission 'publicca.externalAccountKeys.create' denied on resource 'projects/lego-test/locations/global'.")
+}
+
+func TestIsGTS(t *testing.T) {
+	assert.True(t, IsGTS("https://dv.acme-v02.api.pki.goog/directory"))
+	assert.True(t, IsGTS("https://dv.acme-v02.test-api.pki.goog/directory"))
+	assert.False(t, IsGTS("https://acme-v02.api.letsencrypt.org/directory"))
+}
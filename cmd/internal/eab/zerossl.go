@@ -0,0 +1,118 @@
+package eab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const zeroSSLBaseURL = "https://api.zerossl.com"
+
+const zeroSSLACMEHost = "acme.zerossl.com"
+
+// IsZeroSSL checks if the directory URL is the ZeroSSL ACME server.
+func IsZeroSSL(server string) bool {
+	return matchHost(server, zeroSSLACMEHost)
+}
+
+// ZeroSSLClient the client of the ZeroSSL API, to generate the EAB credentials.
+type ZeroSSLClient struct {
+	apiKey string
+
+	BaseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewZeroSSLClient creates a new ZeroSSLClient.
+func NewZeroSSLClient(apiKey string) (*ZeroSSLClient, error) {
+	if apiKey == "" {
+		return nil, errors.New("zerossl: the API key is missing")
+	}
+
+	baseURL, _ := url.Parse(zeroSSLBaseURL)
+
+	return &ZeroSSLClient{
+		apiKey:     apiKey,
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// GenerateCredentials generates new EAB credentials.
+// https://zerossl.com/documentation/acme/generate-eab-credentials/
+func (c *ZeroSSLClient) GenerateCredentials(ctx context.Context) (*Credentials, error) {
+	endpoint := c.BaseURL.JoinPath("acme", "eab-credentials")
+
+	query := endpoint.Query()
+	query.Set("access_key", c.apiKey)
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("zerossl: unable to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		// The error contains the URL, and then the API key.
+		return nil, fmt.Errorf("zerossl: unable to perform the request: %w", errors.Unwrap(err))
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("zerossl: unable to read the response body: %w", err)
+	}
+
+	var result zeroSSLResponse
+
+	err = json.Unmarshal(raw, &result)
+	if err != nil {
+		return nil, fmt.Errorf("zerossl: unexpected response: [status code: %d] body: %s", resp.StatusCode, string(raw))
+	}
+
+	if !result.Success {
+		if result.Error != nil {
+			return nil, fmt.Errorf("zerossl: %w", result.Error)
+		}
+
+		return nil, fmt.Errorf("zerossl: unexpected response: [status code: %d] body: %s", resp.StatusCode, string(raw))
+	}
+
+	if result.KID == "" || result.HMACKey == "" {
+		return nil, errors.New("zerossl: the credentials are missing in the response")
+	}
+
+	return &Credentials{KID: result.KID, HMACEncoded: result.HMACKey}, nil
+}
+
+type zeroSSLResponse struct {
+	Success bool          `json:"success"`
+	KID     string        `json:"eab_kid"`
+	HMACKey string        `json:"eab_hmac_key"`
+	Error   *zeroSSLError `json:"error"`
+}
+
+type zeroSSLError struct {
+	Code int    `json:"code"`
+	Type string `json:"type"`
+	Info string `json:"info"`
+}
+
+func (e *zeroSSLError) Error() string {
+	msg := fmt.Sprintf("%d: %s", e.Code, e.Type)
+
+	if e.Info != "" {
+		msg += ": " + e.Info
+	}
+
+	return msg
+}
@@ -0,0 +1,63 @@
+package eab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockZeroSSLBuilder() *servermock.Builder[*ZeroSSLClient] {
+	return servermock.NewBuilder(
+		func(server *httptest.Server) (*ZeroSSLClient, error) {
+			client, err := NewZeroSSLClient("secret")
+			if err != nil {
+				return nil, err
+			}
+
+			client.BaseURL, _ = url.Parse(server.URL)
+			client.HTTPClient = server.Client()
+
+			return client, nil
+		},
+		servermock.CheckHeader().
+			WithAccept("application/json"),
+		servermock.CheckQueryParameter().Strict().
+			With("access_key", "secret"),
+	)
+}
+
+func TestZeroSSLClient_GenerateCredentials(t *testing.T) {
+	client := mockZeroSSLBuilder().
+		Route("POST /acme/eab-credentials", servermock.ResponseFromFixture("zerossl_credentials.json")).
+		Build(t)
+
+	credentials, err := client.GenerateCredentials(t.Context())
+	require.NoError(t, err)
+
+	expected := &Credentials{
+		KID:         "GD-VvWydSVFuss_GhBwYQQ",
+		HMACEncoded: "MjXU3MH-Z0WQ7piMAnVsCpD1shgMiWx6ggPWiTmydgUaj7dWWWfQfA",
+	}
+
+	assert.Equal(t, expected, credentials)
+}
+
+func TestZeroSSLClient_GenerateCredentials_error(t *testing.T) {
+	client := mockZeroSSLBuilder().
+		Route("POST /acme/eab-credentials", servermock.ResponseFromFixture("zerossl_error.json").
+			WithStatusCode(http.StatusUnauthorized)).
+		Build(t)
+
+	_, err := client.GenerateCredentials(t.Context())
+	require.EqualError(t, err, "zerossl: 101: invalid_access_key: You have not supplied a valid API Access Key.")
+}
+
+func TestIsZeroSSL(t *testing.T) {
+	assert.True(t, IsZeroSSL("https://acme.zerossl.com/v2/DV90"))
+	assert.False(t, IsZeroSSL("https://acme-v02.api.letsencrypt.org/directory"))
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgIssuerAllow = "issuer.allow"
+)
+
+func createIssuerPolicyFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: flgIssuerAllow,
+			Usage: "Issuer allowlist: the Subject Key Identifier or the SHA-256 fingerprint (hex) of an accepted issuing CA." +
+				" The certificate is not saved if its chain doesn't terminate in an allowed issuer.",
+		},
+	}
+}
+
+// checkIssuerPolicy checks that the chain of the certificate terminates in an allowed issuer.
+// An empty allowlist allows all the issuers.
+func checkIssuerPolicy(ctx *cli.Context, certRes *certificate.Resource) error {
+	allowed, err := parseIssuerAllowlist(ctx.StringSlice(flgIssuerAllow))
+	if err != nil {
+		return err
+	}
+
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	chain, err := getFullChain(certRes)
+	if err != nil {
+		return fmt.Errorf("issuer policy: %w", err)
+	}
+
+	if isAllowedIssuer(chain, allowed) {
+		return nil
+	}
+
+	return fmt.Errorf("issuer policy: the certificate for %s (issuer: %q) is not issued by an allowed CA (--%s)",
+		certRes.Domain, chain[0].Issuer.String(), flgIssuerAllow)
+}
+
+// isAllowedIssuer returns true if one of the issuers of the chain (the leaf excepted),
+// or the root CA referenced by the last certificate of the chain (Authority Key Identifier), is allowed.
+func isAllowedIssuer(chain []*x509.Certificate, allowed [][]byte) bool {
+	matches := func(id []byte) bool {
+		for _, a := range allowed {
+			if len(id) > 0 && bytes.Equal(a, id) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, cert := range chain[1:] {
+		fingerprint := sha256.Sum256(cert.Raw)
+
+		if matches(cert.SubjectKeyId) || matches(fingerprint[:]) {
+			return true
+		}
+	}
+
+	// The root CA is usually not part of the chain.
+	return matches(chain[len(chain)-1].AuthorityKeyId)
+}
+
+func parseIssuerAllowlist(values []string) ([][]byte, error) {
+	var allowed [][]byte
+
+	for _, value := range values {
+		raw, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(value), ":", ""))
+		if err != nil || len(raw) == 0 {
+			return nil, fmt.Errorf("issuer policy: invalid SKI or fingerprint: %q", value)
+		}
+
+		allowed = append(allowed, raw)
+	}
+
+	return allowed, nil
+}
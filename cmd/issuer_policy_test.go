@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestCertificate(t *testing.T, cn string, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func Test_isAllowedIssuer(t *testing.T) {
+	root, rootKey := createTestCertificate(t, "Root", true, nil, nil)
+	intermediate, intermediateKey := createTestCertificate(t, "Intermediate", true, root, rootKey)
+	leaf, _ := createTestCertificate(t, "example.com", false, intermediate, intermediateKey)
+
+	otherRoot, _ := createTestCertificate(t, "Other Root", true, nil, nil)
+
+	chain := []*x509.Certificate{leaf, intermediate}
+
+	intermediateFingerprint := sha256.Sum256(intermediate.Raw)
+	leafFingerprint := sha256.Sum256(leaf.Raw)
+
+	testCases := []struct {
+		desc     string
+		allowed  [][]byte
+		expected bool
+	}{
+		{
+			desc:     "intermediate SKI",
+			allowed:  [][]byte{intermediate.SubjectKeyId},
+			expected: true,
+		},
+		{
+			desc:     "intermediate fingerprint",
+			allowed:  [][]byte{intermediateFingerprint[:]},
+			expected: true,
+		},
+		{
+			desc:     "root SKI (not in the chain)",
+			allowed:  [][]byte{root.SubjectKeyId},
+			expected: true,
+		},
+		{
+			desc:     "other root",
+			allowed:  [][]byte{otherRoot.SubjectKeyId},
+			expected: false,
+		},
+		{
+			desc:     "the leaf is not an issuer",
+			allowed:  [][]byte{leafFingerprint[:]},
+			expected: false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, isAllowedIssuer(chain, test.allowed))
+		})
+	}
+}
+
+func Test_parseIssuerAllowlist(t *testing.T) {
+	allowed, err := parseIssuerAllowlist([]string{"C5:B1:AB:4E", "c5b1ab4e"})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]byte{{0xc5, 0xb1, 0xab, 0x4e}, {0xc5, 0xb1, 0xab, 0x4e}}, allowed)
+
+	_, err = parseIssuerAllowlist([]string{"foo"})
+	require.EqualError(t, err, `issuer policy: invalid SKI or fingerprint: "foo"`)
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Decision of the run command, the other decisions are the renewal decisions (skipped, failed).
+const runObtained = "obtained"
+
+// certificateOutput the description of a stored certificate (--json, and renewal summary).
+type certificateOutput struct {
+	Name         string            `json:"name"                yaml:"name"`
+	Domains      []string          `json:"domains"             yaml:"domains"`
+	IPs          []string          `json:"ips,omitempty"       yaml:"ips,omitempty"`
+	KeyType      string            `json:"keyType"             yaml:"keyType"`
+	Issuer       string            `json:"issuer"              yaml:"issuer"`
+	SerialNumber string            `json:"serialNumber"        yaml:"serialNumber"`
+	NotBefore    time.Time         `json:"notBefore"           yaml:"notBefore"`
+	NotAfter     time.Time         `json:"notAfter"            yaml:"notAfter"`
+	DaysLeft     *int              `json:"daysLeft,omitempty"  yaml:"daysLeft,omitempty"`
+	ARIWindow    *acme.Window      `json:"ariWindow,omitempty" yaml:"ariWindow,omitempty"`
+	Files        *certificateFiles `json:"files"               yaml:"files"`
+}
+
+// certificateFiles the paths of the files of a certificate, only the existing files are defined.
+type certificateFiles struct {
+	Certificate string `json:"certificate"          yaml:"certificate"`
+	Issuer      string `json:"issuer,omitempty"     yaml:"issuer,omitempty"`
+	PrivateKey  string `json:"privateKey,omitempty" yaml:"privateKey,omitempty"`
+	PEM         string `json:"pem,omitempty"        yaml:"pem,omitempty"`
+	PFX         string `json:"pfx,omitempty"        yaml:"pfx,omitempty"`
+	DER         string `json:"der,omitempty"        yaml:"der,omitempty"`
+	Resource    string `json:"resource,omitempty"   yaml:"resource,omitempty"`
+}
+
+func newCertificateOutput(certsStorage *CertificatesStorage, name string, cert *x509.Certificate) *certificateOutput {
+	output := &certificateOutput{
+		Name:         name,
+		Domains:      cert.DNSNames,
+		KeyType:      formatKeyType(cert),
+		Issuer:       cert.Issuer.CommonName,
+		SerialNumber: cert.SerialNumber.String(),
+		NotBefore:    cert.NotBefore.UTC(),
+		NotAfter:     cert.NotAfter.UTC(),
+		Files:        &certificateFiles{},
+	}
+
+	for _, ip := range cert.IPAddresses {
+		output.IPs = append(output.IPs, ip.String())
+	}
+
+	// The files which can't be checked are omitted.
+	files := map[string]*string{
+		certExt:     &output.Files.Certificate,
+		issuerExt:   &output.Files.Issuer,
+		keyExt:      &output.Files.PrivateKey,
+		pemExt:      &output.Files.PEM,
+		pfxExt:      &output.Files.PFX,
+		derExt:      &output.Files.DER,
+		resourceExt: &output.Files.Resource,
+	}
+
+	for ext, path := range files {
+		if exists, err := certsStorage.ExistsFile(name, ext); err != nil || !exists {
+			continue
+		}
+
+		*path, _ = certsStorage.GetFileName(name, ext)
+	}
+
+	return output
+}
+
+// runOutput the result of the run command (--json).
+type runOutput struct {
+	Domain         string              `json:"domain,omitempty"`
+	Decision       string              `json:"decision"`
+	Reason         string              `json:"reason,omitempty"`
+	Error          string              `json:"error,omitempty"`
+	HookExitCode   *int                `json:"hookExitCode,omitempty"`
+	DNSDiagnostics []*dns01.Diagnostic `json:"dnsDiagnostics,omitempty"`
+	Certificate    *certificateOutput  `json:"certificate,omitempty"`
+}
+
+// writeRunJSON displays the result of the run command as JSON on stdout (--json).
+func writeRunJSON(ctx *cli.Context, output runOutput, err error) {
+	if !ctx.Bool(flgJSON) {
+		return
+	}
+
+	if err != nil {
+		output.Error = err.Error()
+		output.HookExitCode = getHookExitCode(err)
+		output.DNSDiagnostics = collectDNSDiagnostics(err)
+	}
+
+	errJ := writeJSON(output)
+	if errJ != nil {
+		log.Warnf("%v", errJ)
+	}
+}
+
+// listOutput the result of the list command (--json).
+type listOutput struct {
+	Certificates []*certificateOutput `json:"certificates"`
+	Accounts     []accountOutput      `json:"accounts,omitempty"`
+}
+
+// accountOutput the description of a stored account.
+type accountOutput struct {
+	Email  string `json:"email"`
+	Server string `json:"server"`
+	Path   string `json:"path"`
+}
+
+// accountDetailsOutput the description of an account fetched from the CA.
+type accountDetailsOutput struct {
+	Email    string   `json:"email"`
+	Server   string   `json:"server"`
+	URI      string   `json:"uri"`
+	Status   string   `json:"status"`
+	Contacts []string `json:"contacts,omitempty"`
+	Orders   string   `json:"orders,omitempty"`
+	Path     string   `json:"path"`
+}
+
+// writeJSON writes the result of a command on stdout (--json).
+func writeJSON(value any) error {
+	encoder := json.NewEncoder(jsonOutput)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_newCertificateOutput(t *testing.T) {
+	storage := &CertificatesStorage{rootPath: t.TempDir()}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.WriteFile("example.com", certExt, certPEM))
+	require.NoError(t, storage.WriteFile("example.com", keyExt, []byte("key")))
+
+	cert, err := certcrypto.ParsePEMCertificate(certPEM)
+	require.NoError(t, err)
+
+	output := newCertificateOutput(storage, "example.com", cert)
+
+	assert.Equal(t, "example.com", output.Name)
+	assert.Equal(t, []string{"example.com"}, output.Domains)
+	assert.Equal(t, "RSA 2048", output.KeyType)
+	assert.Equal(t, cert.NotAfter.UTC(), output.NotAfter)
+
+	expected := &certificateFiles{
+		Certificate: mustFileName(t, storage, "example.com", certExt),
+		PrivateKey:  mustFileName(t, storage, "example.com", keyExt),
+	}
+
+	assert.Equal(t, expected, output.Files)
+}
+
+func Test_writeRunJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	out := jsonOutput
+	jsonOutput = buf
+
+	t.Cleanup(func() { jsonOutput = out })
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Bool(flgJSON, true, "")
+
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+	writeRunJSON(ctx, runOutput{Domain: "example.com", Decision: renewalFailed}, errors.New("boom"))
+
+	assert.JSONEq(t, `{"domain":"example.com","decision":"failed","error":"boom"}`, buf.String())
+}
+
+func Test_writeRunJSON_disabled(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	out := jsonOutput
+	jsonOutput = buf
+
+	t.Cleanup(func() { jsonOutput = out })
+
+	ctx := cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", flag.ContinueOnError), nil)
+
+	writeRunJSON(ctx, runOutput{Domain: "example.com", Decision: runObtained}, nil)
+
+	assert.Empty(t, buf.String())
+}
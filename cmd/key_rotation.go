@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgRotateKeyRenewals = "rotate-key.renewals"
+	flgRotateKeyDays     = "rotate-key.days"
+)
+
+func createKeyRotationFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name: flgRotateKeyRenewals,
+			Usage: "Key rotation policy: reuse the private key during the renewals, and rotate it every N renewals." +
+				" The policy is stored with the certificate and applied by the renew command.",
+		},
+		&cli.IntFlag{
+			Name: flgRotateKeyDays,
+			Usage: "Key rotation policy: reuse the private key during the renewals, and rotate it every N days." +
+				" The policy is stored with the certificate and applied by the renew command.",
+		},
+	}
+}
+
+// KeyRotation the key rotation policy of a certificate.
+type KeyRotation struct {
+	// Renewals rotates the key every N renewals.
+	Renewals int `json:"renewals,omitempty"`
+	// Days rotates the key every N days.
+	Days int `json:"days,omitempty"`
+
+	// KeyRenewals the number of renewals since the creation of the current key.
+	KeyRenewals int `json:"keyRenewals"`
+	// KeyCreatedAt the creation date of the current key.
+	KeyCreatedAt time.Time `json:"keyCreatedAt"`
+}
+
+// getKeyRotation returns the key rotation policy from the flags, or the stored policy.
+func getKeyRotation(ctx *cli.Context, stored *KeyRotation) *KeyRotation {
+	if !ctx.IsSet(flgRotateKeyRenewals) && !ctx.IsSet(flgRotateKeyDays) {
+		return stored
+	}
+
+	policy := &KeyRotation{
+		Renewals: ctx.Int(flgRotateKeyRenewals),
+		Days:     ctx.Int(flgRotateKeyDays),
+	}
+
+	if stored != nil {
+		policy.KeyRenewals = stored.KeyRenewals
+		policy.KeyCreatedAt = stored.KeyCreatedAt
+	}
+
+	return policy
+}
+
+// Due returns true if the key must be rotated.
+func (k *KeyRotation) Due(now time.Time) bool {
+	if k.Renewals > 0 && k.KeyRenewals >= k.Renewals {
+		return true
+	}
+
+	if k.Days > 0 && !now.Before(k.KeyCreatedAt.AddDate(0, 0, k.Days)) {
+		return true
+	}
+
+	return false
+}
+
+// Renewed records a renewal.
+func (k *KeyRotation) Renewed(now time.Time, keyReused bool) {
+	if keyReused {
+		k.KeyRenewals++
+		return
+	}
+
+	k.KeyRenewals = 0
+	k.KeyCreatedAt = now
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyRotation_Due(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		policy   KeyRotation
+		expected bool
+	}{
+		{
+			desc:   "renewals: not due",
+			policy: KeyRotation{Renewals: 3, KeyRenewals: 2, KeyCreatedAt: now},
+		},
+		{
+			desc:     "renewals: due",
+			policy:   KeyRotation{Renewals: 3, KeyRenewals: 3, KeyCreatedAt: now},
+			expected: true,
+		},
+		{
+			desc:   "days: not due",
+			policy: KeyRotation{Days: 90, KeyCreatedAt: now.AddDate(0, 0, -89)},
+		},
+		{
+			desc:     "days: due",
+			policy:   KeyRotation{Days: 90, KeyCreatedAt: now.AddDate(0, 0, -90)},
+			expected: true,
+		},
+		{
+			desc:     "days: unknown key creation date",
+			policy:   KeyRotation{Days: 90},
+			expected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.policy.Due(now))
+		})
+	}
+}
+
+func TestKeyRotation_Renewed(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	policy := &KeyRotation{Renewals: 2, KeyCreatedAt: now.AddDate(0, -1, 0)}
+
+	policy.Renewed(now, true)
+	assert.Equal(t, 1, policy.KeyRenewals)
+	assert.Equal(t, now.AddDate(0, -1, 0), policy.KeyCreatedAt)
+
+	policy.Renewed(now, false)
+	assert.Equal(t, 0, policy.KeyRenewals)
+	assert.Equal(t, now, policy.KeyCreatedAt)
+}
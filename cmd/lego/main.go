@@ -3,10 +3,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
+	"syscall"
 
 	"github.com/go-acme/lego/v4/cmd"
 	"github.com/go-acme/lego/v4/log"
@@ -38,8 +41,21 @@ func main() {
 
 	app.Commands = cmd.CreateCommands()
 
-	err = app.Run(os.Args)
+	// SIGINT and SIGTERM cancel the context of the commands: the sleeps and the orders in progress are aborted.
+	// A second signal terminates the process.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	context.AfterFunc(ctx, stop)
+
+	err = app.RunContext(ctx, os.Args)
 	if err != nil {
-		log.Fatal(err)
+		// The exit code of a failed hook, or the result of the renewal (renew --detailed-exit-code), is the exit code of the command.
+		code, errS := cmd.ExitStatus(err)
+		if errS == nil {
+			os.Exit(code)
+		}
+
+		log.Exit(code, errS)
 	}
 }
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/gofrs/flock"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgLock        = "lock"
+	flgLockTimeout = "lock-timeout"
+)
+
+const lockFileName = "lock"
+
+// lockRetryDelay the delay between two attempts to acquire the lock (--lock-timeout).
+const lockRetryDelay = 500 * time.Millisecond
+
+func createLockFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name: flgLock,
+			Usage: "Lock the path (<path>/" + lockFileName + ") during the command," +
+				" to prevent concurrent executions (e.g. two renewals started by cron) from using the same account and certificates.",
+		},
+		&cli.DurationFlag{
+			Name: flgLockTimeout,
+			Usage: "The maximum duration to wait for the lock (--" + flgLock + ") held by another process." +
+				" By default, the command fails immediately.",
+		},
+	}
+}
+
+// acquireLock locks the path (--lock): the lock is held by the process until the unlock function is called.
+// The lock is an advisory lock on a file (flock on Unix, LockFileEx on Windows), released by the system if the process dies.
+func acquireLock(ctx *cli.Context) (func(), error) {
+	if !ctx.Bool(flgLock) {
+		return func() {}, nil
+	}
+
+	path := filepath.Join(ctx.String(flgPath), lockFileName)
+
+	lock := flock.New(path)
+
+	timeout := ctx.Duration(flgLockTimeout)
+
+	locked, err := tryLock(ctx.Context, lock, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("lock %s: %w", path, err)
+	}
+
+	switch {
+	case !locked && timeout > 0:
+		return nil, fmt.Errorf("lock %s: the path is still used by another process after %s", path, timeout)
+	case !locked:
+		return nil, fmt.Errorf("lock %s: the path is used by another process (use --%s to wait for it)", path, flgLockTimeout)
+	}
+
+	log.Infof("lock: %s acquired", path)
+
+	return func() {
+		errU := lock.Unlock()
+		if errU != nil {
+			log.Warnf("lock: %s: %v", path, errU)
+		}
+	}, nil
+}
+
+// tryLock tries to acquire the lock until the timeout, or only once if the timeout is 0.
+func tryLock(ctx context.Context, lock *flock.Flock, timeout time.Duration) (bool, error) {
+	if timeout <= 0 {
+		return lock.TryLock()
+	}
+
+	log.Infof("lock: waiting up to %s for %s", timeout, lock.Path())
+
+	lockCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	locked, err := lock.TryLockContext(lockCtx, lockRetryDelay)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return false, nil
+	}
+
+	return locked, err
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func newLockContext(t *testing.T, path string, timeout time.Duration) *cli.Context {
+	t.Helper()
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(flgPath, path, "")
+	set.Bool(flgLock, true, "")
+	set.Duration(flgLockTimeout, timeout, "")
+
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func Test_acquireLock(t *testing.T) {
+	path := t.TempDir()
+
+	unlock, err := acquireLock(newLockContext(t, path, 0))
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(path, lockFileName))
+
+	_, err = acquireLock(newLockContext(t, path, 0))
+	require.ErrorContains(t, err, "the path is used by another process")
+
+	_, err = acquireLock(newLockContext(t, path, 100*time.Millisecond))
+	require.ErrorContains(t, err, "the path is still used by another process after 100ms")
+
+	unlock()
+
+	unlock, err = acquireLock(newLockContext(t, path, 0))
+	require.NoError(t, err)
+
+	unlock()
+}
+
+func Test_acquireLock_disabled(t *testing.T) {
+	path := t.TempDir()
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(flgPath, path, "")
+
+	unlock, err := acquireLock(cli.NewContext(cli.NewApp(), set, nil))
+	require.NoError(t, err)
+
+	unlock()
+
+	assert.NoFileExists(t, filepath.Join(path, lockFileName))
+}
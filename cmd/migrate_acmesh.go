@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/registration"
+)
+
+// acmeShDNSProviders the acme.sh DNS APIs (dns_<name>) and the matching lego DNS providers.
+var acmeShDNSProviders = map[string]string{
+	"ali":           "alidns",
+	"aws":           "route53",
+	"azure":         "azuredns",
+	"cf":            "cloudflare",
+	"dgon":          "digitalocean",
+	"dp":            "dnspod",
+	"duckdns":       "duckdns",
+	"gandi_livedns": "gandiv5",
+	"gcloud":        "gcloud",
+	"gd":            "godaddy",
+	"he":            "hurricane",
+	"hetzner":       "hetzner",
+	"linode_v4":     "linode",
+	"namecheap":     "namecheap",
+	"nsupdate":      "rfc2136",
+	"ovh":           "ovh",
+	"pdns":          "pdns",
+	"porkbun":       "porkbun",
+}
+
+// readAcmeSh reads the accounts (ca/<server>/), the certificates, and the renewal parameters (<domain>[_ecc]/) of acme.sh.
+func readAcmeSh(dir string) (*migration, error) {
+	m := &migration{}
+
+	email, err := readAcmeShEmail(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = filepath.WalkDir(filepath.Join(dir, "ca"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+
+			return err
+		}
+
+		if d.IsDir() || d.Name() != "account.key" {
+			return nil
+		}
+
+		server, err := filepath.Rel(filepath.Join(dir, "ca"), filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+
+		account, err := readAcmeShAccount(filepath.Dir(path), "https://"+filepath.ToSlash(server), email)
+		if err != nil {
+			return fmt.Errorf("account %s: %w", server, err)
+		}
+
+		m.accounts = append(m.accounts, *account)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		domain := strings.TrimSuffix(entry.Name(), "_ecc")
+
+		if _, err := os.Stat(filepath.Join(dir, entry.Name(), domain+".conf")); err != nil {
+			continue
+		}
+
+		cert, err := readAcmeShCertificate(filepath.Join(dir, entry.Name()), domain, email)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+
+		m.certificates = append(m.certificates, *cert)
+	}
+
+	return m, nil
+}
+
+// readAcmeShEmail reads the email of the accounts (ACCOUNT_EMAIL in account.conf).
+func readAcmeShEmail(dir string) (string, error) {
+	params, err := readKeyValueFile(filepath.Join(dir, "account.conf"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return params["ACCOUNT_EMAIL"], nil
+}
+
+// readAcmeShAccount reads the account: ca/<server>/{account.key,ca.conf}.
+func readAcmeShAccount(dir, server, email string) (*migratedAccount, error) {
+	keyBytes, err := os.ReadFile(filepath.Join(dir, "account.key"))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := certcrypto.ParsePEMPrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("account.key: %w", err)
+	}
+
+	params, err := readKeyValueFile(filepath.Join(dir, "ca.conf"))
+	if err != nil {
+		return nil, err
+	}
+
+	if params["ACCOUNT_URL"] == "" {
+		return nil, errors.New("ca.conf: missing ACCOUNT_URL")
+	}
+
+	reg := &registration.Resource{
+		URI:  params["ACCOUNT_URL"],
+		Body: acme.Account{Status: acme.StatusValid},
+	}
+
+	if email != "" {
+		reg.Body.Contact = []string{"mailto:" + email}
+	}
+
+	return &migratedAccount{server: server, email: email, key: key, registration: reg}, nil
+}
+
+// readAcmeShCertificate reads the certificate files and the renewal parameters (<domain>.conf).
+func readAcmeShCertificate(dir, domain, email string) (*migratedCertificate, error) {
+	params, err := readKeyValueFile(filepath.Join(dir, domain+".conf"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files [3][]byte
+
+	for i, filename := range []string{domain + ".cer", "ca.cer", domain + ".key"} {
+		files[i], err = os.ReadFile(filepath.Join(dir, filename))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, cert, err := newMigratedResource(files[0], files[1], files[2])
+	if err != nil {
+		return nil, err
+	}
+
+	server := params["Le_API"]
+	if server == "" {
+		server = lego.LEDirectoryProduction
+	}
+
+	config := newMigratedConfig(cert, server, email, acmeShChallenge(domain, params["Le_Webroot"]))
+
+	return &migratedCertificate{resource: res, config: config}, nil
+}
+
+// acmeShChallenge maps the mode (Le_Webroot) of the renewal parameters to the challenge options.
+func acmeShChallenge(domain, mode string) map[string]any {
+	// One mode by domain, separated by commas.
+	mode, _, _ = strings.Cut(mode, ",")
+
+	switch {
+	case mode == "" || mode == "no":
+		// standalone
+		return map[string]any{flgHTTP: true}
+
+	case mode == "alpn":
+		return map[string]any{flgTLS: true}
+
+	case mode == "dns":
+		return map[string]any{flgDNS: "manual"}
+
+	case strings.HasPrefix(mode, "dns_"):
+		api := strings.TrimPrefix(mode, "dns_")
+
+		provider := acmeShDNSProviders[api]
+		if provider == "" {
+			log.Warnf("[%s] The acme.sh DNS API %q has no equivalent, please define the DNS provider (--%s).", domain, api, flgDNS)
+			return nil
+		}
+
+		log.Infof("[%s] The credentials of the DNS provider %q must be defined in the env section of the configuration file.", domain, provider)
+
+		return map[string]any{flgDNS: provider}
+
+	case strings.HasPrefix(mode, "/"):
+		return map[string]any{flgHTTP: true, flgHTTPWebroot: mode}
+
+	default:
+		// nginx, apache, etc.
+		log.Warnf("[%s] The acme.sh mode %q has no equivalent, the built-in HTTP server is used.", domain, mode)
+
+		return map[string]any{flgHTTP: true}
+	}
+}
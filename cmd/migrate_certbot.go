@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/go-jose/go-jose/v4"
+)
+
+// certbotDNSProviders the certbot DNS plugins (dns-<name>) and the matching lego DNS providers.
+var certbotDNSProviders = map[string]string{
+	"cloudflare":   "cloudflare",
+	"digitalocean": "digitalocean",
+	"dnsimple":     "dnsimple",
+	"dnsmadeeasy":  "dnsmadeeasy",
+	"google":       "gcloud",
+	"linode":       "linode",
+	"luadns":       "luadns",
+	"nsone":        "ns1",
+	"ovh":          "ovh",
+	"rfc2136":      "rfc2136",
+	"route53":      "route53",
+	"sakuracloud":  "sakuracloud",
+}
+
+// certbotRegistration the content of the regr.json file of a certbot account.
+type certbotRegistration struct {
+	Body acme.Account `json:"body"`
+	URI  string       `json:"uri"`
+}
+
+// readCertbot reads the accounts (accounts/), the certificates (live/), and the renewal parameters (renewal/) of certbot.
+// The email is used for the accounts without contact.
+func readCertbot(dir, defaultEmail string) (*migration, error) {
+	accounts, err := readCertbotAccounts(filepath.Join(dir, "accounts"), defaultEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &migration{}
+
+	emails := make(map[string]string)
+
+	for id, account := range accounts {
+		m.accounts = append(m.accounts, account)
+		emails[id] = account.email
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "renewal", "*.conf"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, match := range matches {
+		name := strings.TrimSuffix(filepath.Base(match), ".conf")
+
+		cert, err := readCertbotCertificate(dir, name, emails)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		m.certificates = append(m.certificates, *cert)
+	}
+
+	return m, nil
+}
+
+// readCertbotAccounts reads the accounts: accounts/<server>/<account ID>/{regr.json,private_key.json}.
+func readCertbotAccounts(dir, defaultEmail string) (map[string]migratedAccount, error) {
+	accounts := make(map[string]migratedAccount)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+
+			return err
+		}
+
+		if d.IsDir() || d.Name() != "regr.json" {
+			return nil
+		}
+
+		accountDir := filepath.Dir(path)
+
+		server, err := filepath.Rel(dir, filepath.Dir(accountDir))
+		if err != nil {
+			return err
+		}
+
+		account, err := readCertbotAccount(accountDir, "https://"+filepath.ToSlash(server), defaultEmail)
+		if err != nil {
+			return fmt.Errorf("account %s: %w", filepath.Base(accountDir), err)
+		}
+
+		accounts[filepath.Base(accountDir)] = *account
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func readCertbotAccount(dir, server, defaultEmail string) (*migratedAccount, error) {
+	regrBytes, err := os.ReadFile(filepath.Join(dir, "regr.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var regr certbotRegistration
+
+	err = json.Unmarshal(regrBytes, &regr)
+	if err != nil {
+		return nil, fmt.Errorf("regr.json: %w", err)
+	}
+
+	keyBytes, err := os.ReadFile(filepath.Join(dir, "private_key.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var jwk jose.JSONWebKey
+
+	err = jwk.UnmarshalJSON(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("private_key.json: %w", err)
+	}
+
+	email := defaultEmail
+
+	for _, contact := range regr.Body.Contact {
+		if strings.HasPrefix(contact, "mailto:") {
+			email = strings.TrimPrefix(contact, "mailto:")
+			break
+		}
+	}
+
+	if regr.Body.Status == "" {
+		// The recent versions of certbot don't store the account body.
+		regr.Body.Status = acme.StatusValid
+	}
+
+	return &migratedAccount{
+		server:       server,
+		email:        email,
+		key:          jwk.Key,
+		registration: &registration.Resource{Body: regr.Body, URI: regr.URI},
+	}, nil
+}
+
+// readCertbotCertificate reads the certificate files (live/<name>/) and the renewal parameters (renewal/<name>.conf).
+func readCertbotCertificate(dir, name string, emails map[string]string) (*migratedCertificate, error) {
+	params, err := readKeyValueFile(filepath.Join(dir, "renewal", name+".conf"))
+	if err != nil {
+		return nil, err
+	}
+
+	liveDir := filepath.Join(dir, "live", name)
+
+	var files [3][]byte
+
+	for i, filename := range []string{"cert.pem", "chain.pem", "privkey.pem"} {
+		files[i], err = os.ReadFile(filepath.Join(liveDir, filename))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, cert, err := newMigratedResource(files[0], files[1], files[2])
+	if err != nil {
+		return nil, err
+	}
+
+	server := params["renewalparams.server"]
+	if server == "" {
+		server = lego.LEDirectoryProduction
+	}
+
+	config := newMigratedConfig(cert, server, emails[params["renewalparams.account"]], certbotChallenge(name, params))
+
+	return &migratedCertificate{resource: res, config: config}, nil
+}
+
+// certbotChallenge maps the authenticator of the renewal parameters to the challenge options.
+func certbotChallenge(name string, params map[string]string) map[string]any {
+	authenticator := params["renewalparams.authenticator"]
+
+	switch {
+	case authenticator == "webroot":
+		challenge := map[string]any{flgHTTP: true}
+
+		webroot := strings.TrimSuffix(params["renewalparams.webroot_path"], ",")
+		if webroot == "" {
+			webroot = params["webroot_map."+name]
+		}
+
+		if webroot != "" {
+			challenge[flgHTTPWebroot] = strings.Split(webroot, ",")[0]
+		}
+
+		return challenge
+
+	case authenticator == "manual":
+		return map[string]any{flgDNS: "manual"}
+
+	case strings.HasPrefix(authenticator, "dns-"):
+		plugin := strings.TrimPrefix(authenticator, "dns-")
+
+		provider := certbotDNSProviders[plugin]
+		if provider == "" {
+			log.Warnf("[%s] The certbot DNS plugin %q has no equivalent, please define the DNS provider (--%s).", name, plugin, flgDNS)
+			return nil
+		}
+
+		log.Infof("[%s] The credentials of the DNS provider %q must be defined in the env section of the configuration file.", name, provider)
+
+		return map[string]any{flgDNS: provider}
+
+	default:
+		// standalone, nginx, apache, etc.
+		if authenticator != "standalone" {
+			log.Warnf("[%s] The certbot authenticator %q has no equivalent, the built-in HTTP server is used.", name, authenticator)
+		}
+
+		return map[string]any{flgHTTP: true}
+	}
+}
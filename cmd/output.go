@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	stdlog "log"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+)
+
+// Log formats (--log-format).
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+const (
+	envNoColor             = "NO_COLOR"
+	envDebugACMEHTTPClient = "LEGO_DEBUG_ACME_HTTP_CLIENT"
+)
+
+// ANSI escape sequences.
+const (
+	ansiReset     = "\x1b[0m"
+	ansiRed       = "\x1b[31m"
+	ansiGreen     = "\x1b[32m"
+	ansiYellow    = "\x1b[33m"
+	ansiCyan      = "\x1b[36m"
+	ansiDim       = "\x1b[2m"
+	ansiClearLine = "\r\x1b[K"
+)
+
+const progressRefreshInterval = time.Second
+
+// Challenge status of a domain.
+const (
+	statusPreparing   = "preparing"
+	statusSolving     = "solving"
+	statusPropagating = "propagating"
+	statusValidating  = "validating"
+	statusValid       = "valid"
+)
+
+// The log messages of the challenges, with the status of the domain they lead to.
+var progressMessages = []struct {
+	prefix string
+	status string
+}{
+	{prefix: "acme: Preparing to solve", status: statusPreparing},
+	{prefix: "acme: Trying to solve", status: statusSolving},
+	{prefix: "acme: Checking DNS record propagation", status: statusPropagating},
+	{prefix: "acme: DNS record propagated", status: statusValidating},
+	{prefix: "The server validated our request", status: statusValid},
+	{prefix: "acme: authorization already valid", status: statusValid},
+}
+
+var domainMessagePattern = regexp.MustCompile(`^\[([^\]]+)] (.+)$`)
+
+// textOutput the output of the messages of the commands and of the hooks: stdout, or stderr when stdout is used by the JSON output (--json).
+var textOutput io.Writer = os.Stdout
+
+// jsonOutput the output of the results of the commands (--json).
+var jsonOutput io.Writer = os.Stdout
+
+// setupOutput replaces the logger with a JSON logger (--log-format),
+// or with a colorized logger and a progress display when the output is a terminal.
+// The entries below the level (--log-level) are discarded.
+func setupOutput(ctx *cli.Context) error {
+	if ctx.Bool(flgJSON) {
+		textOutput = os.Stderr
+	}
+
+	level, err := getLogLevel(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch ctx.String(flgLogFormat) {
+	case logFormatJSON:
+		log.SetDefault(slog.New(&domainHandler{Handler: slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level})}))
+		return nil
+
+	case logFormatText:
+		// The entries are formatted below.
+
+	default:
+		return fmt.Errorf("unsupported log format: %q", ctx.String(flgLogFormat))
+	}
+
+	if ctx.Bool(flgNoColor) || os.Getenv(envNoColor) != "" || !isatty.IsTerminal(os.Stderr.Fd()) {
+		log.SetDefault(log.NewLeveledStdLogger(stdlog.New(os.Stderr, "", stdlog.LstdFlags), level))
+		return nil
+	}
+
+	logger := newTTYLogger(os.Stderr)
+
+	go func() {
+		for range time.Tick(progressRefreshInterval) {
+			logger.refresh()
+		}
+	}()
+
+	log.SetDefault(log.NewLeveledStdLogger(logger, level))
+
+	return nil
+}
+
+// getLogLevel returns the minimum level of the log entries (--log-level).
+// The level is debug with LEGO_DEBUG_ACME_HTTP_CLIENT, unless --log-level is defined.
+func getLogLevel(ctx *cli.Context) (slog.Level, error) {
+	if _, v := os.LookupEnv(envDebugACMEHTTPClient); v && !ctx.IsSet(flgLogLevel) {
+		return slog.LevelDebug, nil
+	}
+
+	var level slog.Level
+
+	err := level.UnmarshalText([]byte(ctx.String(flgLogLevel)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid log level: %q", ctx.String(flgLogLevel))
+	}
+
+	return level, nil
+}
+
+// domainHandler moves the domain of the messages ("[example.com] message") to a field of the entries (domain).
+type domainHandler struct {
+	slog.Handler
+}
+
+func (h *domainHandler) Handle(ctx context.Context, record slog.Record) error {
+	matches := domainMessagePattern.FindStringSubmatch(record.Message)
+	if matches == nil {
+		return h.Handler.Handle(ctx, record)
+	}
+
+	entry := slog.NewRecord(record.Time, record.Level, matches[2], record.PC)
+	entry.AddAttrs(slog.String("domain", matches[1]))
+
+	record.Attrs(func(attr slog.Attr) bool {
+		entry.AddAttrs(attr)
+		return true
+	})
+
+	return h.Handler.Handle(ctx, entry)
+}
+
+func (h *domainHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &domainHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *domainHandler) WithGroup(name string) slog.Handler {
+	return &domainHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+type domainProgress struct {
+	status string
+	since  time.Time
+}
+
+// ttyLogger is a logger for terminals:
+// the levels are colorized, and the challenge status of the domains is displayed on the last line.
+type ttyLogger struct {
+	mu sync.Mutex
+
+	out io.Writer
+	now func() time.Time
+
+	domains  []string
+	progress map[string]*domainProgress
+
+	// lineShown is true when the progress line is displayed.
+	lineShown bool
+}
+
+func newTTYLogger(out io.Writer) *ttyLogger {
+	return &ttyLogger{
+		out:      out,
+		now:      time.Now,
+		progress: make(map[string]*domainProgress),
+	}
+}
+
+func (l *ttyLogger) Fatal(args ...any) {
+	l.write(ansiRed, fmt.Sprint(args...))
+	os.Exit(1)
+}
+
+func (l *ttyLogger) Fatalln(args ...any) {
+	l.write(ansiRed, strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+	os.Exit(1)
+}
+
+func (l *ttyLogger) Fatalf(format string, args ...any) {
+	l.write(ansiRed, fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
+
+func (l *ttyLogger) Print(args ...any) {
+	l.print(fmt.Sprint(args...))
+}
+
+func (l *ttyLogger) Println(args ...any) {
+	l.print(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (l *ttyLogger) Printf(format string, args ...any) {
+	l.print(fmt.Sprintf(format, args...))
+}
+
+func (l *ttyLogger) print(msg string) {
+	switch {
+	case strings.HasPrefix(msg, "[ERROR] "):
+		l.write(ansiRed, msg)
+	case strings.HasPrefix(msg, "[WARN] "):
+		l.write(ansiYellow, msg)
+	case strings.HasPrefix(msg, "[INFO] "):
+		l.track(strings.TrimPrefix(msg, "[INFO] "))
+		l.write(ansiCyan, msg)
+	default:
+		l.write("", msg)
+	}
+}
+
+// write writes the message, colorizing the level (or the whole message without level), and redraws the progress line.
+func (l *ttyLogger) write(color, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if color != "" {
+		level, rest, found := strings.Cut(msg, "] ")
+		if found && (level == "[ERROR" || level == "[WARN" || level == "[INFO") {
+			msg = color + level + "]" + ansiReset + " " + rest
+		} else {
+			msg = color + msg + ansiReset
+		}
+	}
+
+	l.clearLine()
+
+	_, _ = fmt.Fprintf(l.out, "%s%s%s %s\n", ansiDim, l.now().Format("2006/01/02 15:04:05"), ansiReset, msg)
+
+	l.drawLine()
+}
+
+// track updates the challenge status of a domain from a log message.
+func (l *ttyLogger) track(msg string) {
+	matches := domainMessagePattern.FindStringSubmatch(msg)
+	if matches == nil {
+		return
+	}
+
+	domain, text := matches[1], matches[2]
+
+	for _, m := range progressMessages {
+		if !strings.HasPrefix(text, m.prefix) {
+			continue
+		}
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		p, ok := l.progress[domain]
+		if !ok {
+			p = &domainProgress{}
+			l.progress[domain] = p
+			l.domains = append(l.domains, domain)
+		}
+
+		if p.status != m.status {
+			p.status = m.status
+			p.since = l.now()
+		}
+
+		return
+	}
+}
+
+// refresh redraws the progress line to update the time waited on the propagation.
+func (l *ttyLogger) refresh() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.lineShown {
+		return
+	}
+
+	l.clearLine()
+	l.drawLine()
+}
+
+func (l *ttyLogger) clearLine() {
+	if !l.lineShown {
+		return
+	}
+
+	_, _ = fmt.Fprint(l.out, ansiClearLine)
+
+	l.lineShown = false
+}
+
+func (l *ttyLogger) drawLine() {
+	line := l.progressLine()
+	if line == "" {
+		return
+	}
+
+	_, _ = fmt.Fprint(l.out, line)
+
+	l.lineShown = true
+}
+
+// progressLine returns the challenge status of the domains.
+// The progress is reset when all the domains are valid.
+func (l *ttyLogger) progressLine() string {
+	if len(l.domains) == 0 {
+		return ""
+	}
+
+	allValid := !slices.ContainsFunc(l.domains, func(domain string) bool {
+		return l.progress[domain].status != statusValid
+	})
+
+	if allValid {
+		l.domains = nil
+		l.progress = make(map[string]*domainProgress)
+
+		return ""
+	}
+
+	var parts []string
+
+	for _, domain := range l.domains {
+		p := l.progress[domain]
+
+		switch p.status {
+		case statusValid:
+			parts = append(parts, fmt.Sprintf("%s %s%s%s", domain, ansiGreen, p.status, ansiReset))
+		case statusPropagating:
+			parts = append(parts, fmt.Sprintf("%s %s%s (%s)%s", domain, ansiYellow, p.status, l.now().Sub(p.since).Round(time.Second), ansiReset))
+		default:
+			parts = append(parts, fmt.Sprintf("%s %s%s%s", domain, ansiCyan, p.status, ansiReset))
+		}
+	}
+
+	return strings.Join(parts, " | ")
+}
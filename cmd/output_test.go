@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_ttyLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	now := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	logger := newTTYLogger(buf)
+	logger.now = func() time.Time { return now }
+
+	logger.Printf("[INFO] [example.com] acme: Trying to solve DNS-01")
+	logger.Printf("[INFO] [www.example.com] acme: Checking DNS record propagation. [nameservers=1.1.1.1:53]")
+
+	assert.Equal(t, "example.com \x1b[36msolving\x1b[0m | www.example.com \x1b[33mpropagating (0s)\x1b[0m", logger.progressLine())
+
+	now = now.Add(12 * time.Second)
+
+	logger.Printf("[WARN] something")
+
+	assert.Contains(t, buf.String(), "\x1b[33m[WARN]\x1b[0m something\n")
+	assert.Contains(t, buf.String(), "www.example.com \x1b[33mpropagating (12s)\x1b[0m")
+
+	logger.Printf("[INFO] [example.com] The server validated our request")
+	logger.Printf("[INFO] [www.example.com] The server validated our request")
+
+	assert.Empty(t, logger.progressLine())
+	assert.False(t, logger.lineShown)
+}
+
+func Test_domainHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	})
+
+	logger := slog.New(&domainHandler{Handler: handler}).With("tenant", "foo")
+
+	logger.Info("[example.com] acme: Obtaining SAN certificate", "attempt", 2)
+	logger.Warn("renewal: no domain")
+
+	expected := `{"level":"INFO","msg":"acme: Obtaining SAN certificate","tenant":"foo","domain":"example.com","attempt":2}
+{"level":"WARN","msg":"renewal: no domain","tenant":"foo"}
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func Test_getLogLevel(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		value      string
+		debugEnv   bool
+		expected   slog.Level
+		requireErr require.ErrorAssertionFunc
+	}{
+		{
+			desc:       "default",
+			expected:   slog.LevelInfo,
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "warn",
+			value:      "warn",
+			expected:   slog.LevelWarn,
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "debug environment variable",
+			debugEnv:   true,
+			expected:   slog.LevelDebug,
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "level and debug environment variable",
+			value:      "error",
+			debugEnv:   true,
+			expected:   slog.LevelError,
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "invalid",
+			value:      "verbose",
+			requireErr: require.Error,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			if test.debugEnv {
+				t.Setenv(envDebugACMEHTTPClient, "1")
+			}
+
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			set.String(flgLogLevel, "info", "")
+
+			if test.value != "" {
+				require.NoError(t, set.Set(flgLogLevel, test.value))
+			}
+
+			level, err := getLogLevel(cli.NewContext(cli.NewApp(), set, nil))
+			test.requireErr(t, err)
+
+			assert.Equal(t, test.expected, level)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// Flag names.
+const (
+	flgRenewSummaryFile = "summary-file"
+)
+
+// Renewal decisions.
+const (
+	renewalRenewed = "renewed"
+	renewalSkipped = "skipped"
+	renewalFailed  = "failed"
+)
+
+func createRenewSummaryFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: flgRenewSummaryFile,
+			Usage: "Write a report of the renewal (certificates, decisions, reasons, and timings) in this file." +
+				" The format is YAML if the extension is .yaml or .yml, JSON otherwise.",
+			TakesFile: true,
+		},
+	}
+}
+
+// Reasons of the skipped renewals.
+const (
+	reasonNotDue      = "the certificate is not due for renewal"
+	reasonRenewWindow = "outside the renewal window"
+)
+
+// reasonDryRun the reason of the renewals of a dry run (--dry-run).
+const reasonDryRun = "dry run"
+
+// renewalSummary the report of a renewal run.
+type renewalSummary struct {
+	StartedAt       time.Time        `json:"startedAt"       yaml:"startedAt"`
+	DurationSeconds float64          `json:"durationSeconds" yaml:"durationSeconds"`
+	Certificates    []*renewalReport `json:"certificates"    yaml:"certificates"`
+}
+
+// renewalReport the decision about a certificate.
+type renewalReport struct {
+	Domain          string     `json:"domain"             yaml:"domain"`
+	Decision        string     `json:"decision"           yaml:"decision"`
+	Reason          string     `json:"reason,omitempty"   yaml:"reason,omitempty"`
+	Error           string     `json:"error,omitempty"    yaml:"error,omitempty"`
+	NotAfter        *time.Time `json:"notAfter,omitempty" yaml:"notAfter,omitempty"`
+	StartedAt       time.Time  `json:"startedAt"          yaml:"startedAt"`
+	DurationSeconds float64    `json:"durationSeconds"    yaml:"durationSeconds"`
+
+	// The time before which the CA asks to not retry (Retry-After header of the error).
+	RetryAt *time.Time `json:"retryAt,omitempty" yaml:"retryAt,omitempty"`
+
+	// The exit code of the failed hook.
+	HookExitCode *int `json:"hookExitCode,omitempty" yaml:"hookExitCode,omitempty"`
+
+	// The diagnostics of the DNS delegation of the failed DNS-01 challenges.
+	DNSDiagnostics []*dns01.Diagnostic `json:"dnsDiagnostics,omitempty" yaml:"dnsDiagnostics,omitempty"`
+
+	// The renewed certificate, or the stored certificate if the certificate has not been renewed.
+	Certificate *certificateOutput `json:"certificate,omitempty" yaml:"certificate,omitempty"`
+}
+
+func newRenewalReport() *renewalReport {
+	return &renewalReport{StartedAt: renewClock.Now().UTC()}
+}
+
+func (r *renewalReport) skip(reason string, notAfter time.Time) {
+	r.Decision = renewalSkipped
+	r.Reason = reason
+	r.NotAfter = &notAfter
+}
+
+func (r *renewalReport) renew(reason string) {
+	r.Decision = renewalRenewed
+	r.Reason = reason
+}
+
+// done sets the duration, and the decision if the renewal failed.
+func (r *renewalReport) done(err error) {
+	r.DurationSeconds = renewClock.Now().Sub(r.StartedAt).Seconds()
+
+	if err != nil {
+		r.Decision = renewalFailed
+		r.Error = err.Error()
+		r.HookExitCode = getHookExitCode(err)
+		r.DNSDiagnostics = collectDNSDiagnostics(err)
+
+		if retryAfter := getRetryAfter(err); retryAfter > 0 {
+			retryAt := renewClock.Now().Add(retryAfter).UTC()
+			r.RetryAt = &retryAt
+		}
+	}
+}
+
+// collectDNSDiagnostics returns the diagnostics of the DNS delegation contained in the error tree (see dns01.DiagnosticError).
+func collectDNSDiagnostics(err error) []*dns01.Diagnostic {
+	switch x := err.(type) {
+	case *dns01.DiagnosticError:
+		return []*dns01.Diagnostic{x.Diagnostic}
+
+	case interface{ Unwrap() []error }:
+		var diagnostics []*dns01.Diagnostic
+
+		for _, e := range x.Unwrap() {
+			diagnostics = append(diagnostics, collectDNSDiagnostics(e)...)
+		}
+
+		return diagnostics
+
+	case interface{ Unwrap() error }:
+		return collectDNSDiagnostics(x.Unwrap())
+
+	default:
+		return nil
+	}
+}
+
+// renewalReason explains why a certificate is renewed.
+func renewalReason(revoked, ari, domainsChanged bool) string {
+	switch {
+	case revoked:
+		return "the certificate has been revoked"
+	case ari:
+		return "the renewal time suggested by the CA (renewalInfo) has been reached"
+	case domainsChanged:
+		return "the domains of the certificate have changed"
+	default:
+		return "the certificate is due for renewal"
+	}
+}
+
+// logRenewalReports logs the decision about each certificate, and the number of certificates by decision.
+func logRenewalReports(reports []*renewalReport) {
+	counts := map[string]int{}
+
+	for _, report := range reports {
+		counts[report.Decision]++
+
+		switch report.Decision {
+		case renewalFailed:
+			log.Warnf("[%s] renewal: %s: %s", report.Domain, report.Decision, report.Error)
+		default:
+			log.Infof("[%s] renewal: %s: %s", report.Domain, report.Decision, report.Reason)
+		}
+	}
+
+	log.Infof("renewal: %d certificate(s) checked: %d renewed, %d skipped, %d failed",
+		len(reports), counts[renewalRenewed], counts[renewalSkipped], counts[renewalFailed])
+}
+
+// writeRenewalSummary writes the report in the file (--summary-file), if defined.
+func writeRenewalSummary(ctx *cli.Context, startedAt time.Time, reports ...*renewalReport) error {
+	filename := ctx.String(flgRenewSummaryFile)
+	if filename == "" {
+		return nil
+	}
+
+	// The summary of a dry run doesn't replace the summary of the real renewals.
+	if ctx.Bool(flgDryRun) {
+		log.Infof("dry-run: the summary is not written in %s", filename)
+		return nil
+	}
+
+	summary := newRenewalSummary(startedAt, reports)
+
+	var (
+		content []byte
+		err     error
+	)
+
+	if isYAMLFile(filename) {
+		content, err = yaml.Marshal(summary)
+	} else {
+		content, err = json.MarshalIndent(summary, "", "  ")
+	}
+
+	if err != nil {
+		return fmt.Errorf("renewal summary: %w", err)
+	}
+
+	err = os.WriteFile(filename, content, 0o644)
+	if err != nil {
+		return fmt.Errorf("renewal summary: %w", err)
+	}
+
+	return nil
+}
+
+// writeRenewalJSON displays the report as JSON on stdout (--json).
+func writeRenewalJSON(ctx *cli.Context, startedAt time.Time, reports ...*renewalReport) error {
+	if !ctx.Bool(flgJSON) {
+		return nil
+	}
+
+	return writeJSON(newRenewalSummary(startedAt, reports))
+}
+
+func newRenewalSummary(startedAt time.Time, reports []*renewalReport) renewalSummary {
+	if reports == nil {
+		reports = []*renewalReport{}
+	}
+
+	return renewalSummary{
+		StartedAt:       startedAt,
+		DurationSeconds: renewClock.Now().Sub(startedAt).Seconds(),
+		Certificates:    reports,
+	}
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_writeRenewalSummary(t *testing.T) {
+	startedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	notAfter := time.Date(2025, 3, 2, 3, 4, 5, 0, time.UTC)
+
+	reports := []*renewalReport{
+		{Domain: "example.com", Decision: renewalSkipped, Reason: reasonNotDue, NotAfter: &notAfter, StartedAt: startedAt, DurationSeconds: 1.5},
+		{Domain: "example.org", Decision: renewalFailed, Reason: "the certificate is due for renewal", Error: "boom", StartedAt: startedAt},
+	}
+
+	testCases := []struct {
+		filename string
+		expected string
+	}{
+		{
+			filename: "summary.json",
+			expected: `{
+  "startedAt": "2025-01-02T03:04:05Z",
+  "durationSeconds": 0,
+  "certificates": [
+    {
+      "domain": "example.com",
+      "decision": "skipped",
+      "reason": "the certificate is not due for renewal",
+      "notAfter": "2025-03-02T03:04:05Z",
+      "startedAt": "2025-01-02T03:04:05Z",
+      "durationSeconds": 1.5
+    },
+    {
+      "domain": "example.org",
+      "decision": "failed",
+      "reason": "the certificate is due for renewal",
+      "error": "boom",
+      "startedAt": "2025-01-02T03:04:05Z",
+      "durationSeconds": 0
+    }
+  ]
+}`,
+		},
+		{
+			filename: "summary.yaml",
+			expected: `startedAt: 2025-01-02T03:04:05Z
+durationSeconds: 0
+certificates:
+- domain: example.com
+  decision: skipped
+  reason: the certificate is not due for renewal
+  notAfter: 2025-03-02T03:04:05Z
+  startedAt: 2025-01-02T03:04:05Z
+  durationSeconds: 1.5
+- domain: example.org
+  decision: failed
+  reason: the certificate is due for renewal
+  error: boom
+  startedAt: 2025-01-02T03:04:05Z
+  durationSeconds: 0
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.filename, func(t *testing.T) {
+			useFakeClock(t, startedAt)
+
+			filename := filepath.Join(t.TempDir(), test.filename)
+
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			set.String(flgRenewSummaryFile, filename, "")
+
+			err := writeRenewalSummary(cli.NewContext(cli.NewApp(), set, nil), startedAt, reports...)
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(filename)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, string(content))
+		})
+	}
+}
+
+func Test_renewalReport_done(t *testing.T) {
+	report := &renewalReport{Domain: "example.com", StartedAt: renewClock.Now()}
+	report.renew(renewalReason(false, true, false))
+
+	report.done(errors.New("boom"))
+
+	assert.Equal(t, renewalFailed, report.Decision)
+	assert.Equal(t, "the renewal time suggested by the CA (renewalInfo) has been reached", report.Reason)
+	assert.Equal(t, "boom", report.Error)
+}
+
+func Test_renewalReport_done_dnsDiagnostics(t *testing.T) {
+	diagnostic := &dns01.Diagnostic{
+		Domain: "example.com",
+		Findings: []dns01.Finding{
+			{Code: dns01.DiagnosticMissingTXT, Message: "message", Hint: "hint"},
+		},
+	}
+
+	err := fmt.Errorf("error: one or more domains had a problem:\n%w", errors.Join(
+		fmt.Errorf("example.com: %w", &dns01.DiagnosticError{Err: errors.New("time limit exceeded"), Diagnostic: diagnostic}),
+		fmt.Errorf("example.org: %w", errors.New("boom")),
+	))
+
+	report := &renewalReport{Domain: "example.com", StartedAt: renewClock.Now()}
+
+	report.done(err)
+
+	assert.Equal(t, []*dns01.Diagnostic{diagnostic}, report.DNSDiagnostics)
+}
+
+func Test_renewalReport_done_retryAfter(t *testing.T) {
+	report := &renewalReport{Domain: "example.com", StartedAt: renewClock.Now()}
+
+	report.done(fmt.Errorf("could not obtain certificates: %w", &acme.RateLimitedError{
+		ProblemDetails: &acme.ProblemDetails{Type: acme.RateLimitedErr, HTTPStatus: 429, RetryAfter: "3600"},
+	}))
+
+	require.NotNil(t, report.RetryAt)
+	assert.WithinDuration(t, renewClock.Now().Add(time.Hour), *report.RetryAt, time.Minute)
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgRenewWindow         = "renew-window"
+	flgRenewWindowDays     = "renew-window-days"
+	flgRenewWindowTimezone = "renew-window-tz"
+)
+
+const dayDuration = 24 * time.Hour
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+func createRenewWindowFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: flgRenewWindow,
+			Usage: "Restricts the renewals to a daily time window (e.g. '02:00-05:00')." +
+				" Outside the window, the renewal is deferred to the next window, unless the certificate expires first.",
+		},
+		&cli.StringSliceFlag{
+			Name:  flgRenewWindowDays,
+			Usage: "Restricts the renewal window to the days of the week (sun, mon, tue, wed, thu, fri, sat). All the days by default.",
+		},
+		&cli.StringFlag{
+			Name:  flgRenewWindowTimezone,
+			Usage: "The timezone of the renewal window (e.g. 'Europe/Paris'). The local timezone by default.",
+		},
+	}
+}
+
+// renewWindow a daily time window, in a timezone, restricted to some days of the week.
+type renewWindow struct {
+	// start and end are the offsets from midnight.
+	start, end time.Duration
+
+	// days are the days of the week when the window starts (all the days if empty).
+	days []time.Weekday
+
+	location *time.Location
+}
+
+func getRenewWindow(ctx *cli.Context) (*renewWindow, error) {
+	if !ctx.IsSet(flgRenewWindow) {
+		return nil, nil
+	}
+
+	window, err := parseRenewWindow(ctx.String(flgRenewWindow), ctx.StringSlice(flgRenewWindowDays), ctx.String(flgRenewWindowTimezone))
+	if err != nil {
+		return nil, fmt.Errorf("invalid renewal window: %w", err)
+	}
+
+	return window, nil
+}
+
+func parseRenewWindow(value string, days []string, timezone string) (*renewWindow, error) {
+	rawStart, rawEnd, found := strings.Cut(value, "-")
+	if !found {
+		return nil, fmt.Errorf("%q: the format must be 'HH:MM-HH:MM'", value)
+	}
+
+	start, err := parseTimeOfDay(rawStart)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := parseTimeOfDay(rawEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	if start == end {
+		return nil, fmt.Errorf("%q: the start and the end must be different", value)
+	}
+
+	window := &renewWindow{start: start, end: end, location: time.Local}
+
+	for _, day := range days {
+		for _, d := range strings.Split(day, ",") {
+			weekday, ok := weekdays[strings.ToLower(strings.TrimSpace(d))]
+			if !ok {
+				return nil, fmt.Errorf("unknown day of the week: %q", d)
+			}
+
+			window.days = append(window.days, weekday)
+		}
+	}
+
+	if timezone != "" {
+		window.location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return window, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%q: the format must be 'HH:MM'", value)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains returns true if the time is inside the window.
+func (w *renewWindow) Contains(t time.Time) bool {
+	t = t.In(w.location)
+
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, w.location)
+	offset := t.Sub(midnight)
+
+	if w.start < w.end {
+		return offset >= w.start && offset < w.end && w.allowed(t.Weekday())
+	}
+
+	// The window spans midnight.
+	switch {
+	case offset >= w.start:
+		return w.allowed(t.Weekday())
+	case offset < w.end:
+		// The window started the day before.
+		return w.allowed(midnight.Add(-time.Hour).Weekday())
+	default:
+		return false
+	}
+}
+
+// Next returns the start of the next window, or the time itself if it is inside the window.
+func (w *renewWindow) Next(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+
+	local := t.In(w.location)
+
+	for i := range 8 {
+		day := time.Date(local.Year(), local.Month(), local.Day()+i, 0, 0, 0, 0, w.location)
+
+		start := day.Add(w.start)
+		if start.After(t) && w.allowed(day.Weekday()) {
+			return start
+		}
+	}
+
+	// Unreachable: a day of the week is always allowed within 8 days.
+	return t.Add(7 * dayDuration)
+}
+
+func (w *renewWindow) allowed(day time.Weekday) bool {
+	return len(w.days) == 0 || slices.Contains(w.days, day)
+}
+
+// deferRenewal returns true if the renewal must be deferred to the next window.
+// A certificate expiring before the next window is renewed immediately.
+func deferRenewal(window *renewWindow, notAfter time.Time, domain string) bool {
+	if window == nil {
+		return false
+	}
+
+	now := renewClock.Now()
+
+	if window.Contains(now) {
+		return false
+	}
+
+	next := window.Next(now)
+
+	if !next.Before(notAfter) {
+		log.Warnf("[%s] renewal: the certificate expires before the next renewal window (%s), renewing now", domain, next)
+
+		return false
+	}
+
+	log.Infof("[%s] renewal: outside the renewal window, the renewal is deferred to %s", domain, next)
+
+	return true
+}
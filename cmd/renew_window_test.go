@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseRenewWindow_error(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		days     []string
+		timezone string
+		expected string
+	}{
+		{desc: "no separator", value: "02:00", expected: `"02:00": the format must be 'HH:MM-HH:MM'`},
+		{desc: "invalid time", value: "02:00-25:00", expected: `"25:00": the format must be 'HH:MM'`},
+		{desc: "empty window", value: "02:00-02:00", expected: `"02:00-02:00": the start and the end must be different`},
+		{desc: "invalid day", value: "02:00-05:00", days: []string{"mon,foo"}, expected: `unknown day of the week: "foo"`},
+		{desc: "invalid timezone", value: "02:00-05:00", timezone: "Foo/Bar", expected: "unknown time zone Foo/Bar"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseRenewWindow(test.value, test.days, test.timezone)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func Test_renewWindow(t *testing.T) {
+	// 2025-01-04 is a Saturday.
+	testCases := []struct {
+		desc         string
+		value        string
+		days         []string
+		now          time.Time
+		expectedIn   bool
+		expectedNext time.Time
+	}{
+		{
+			desc:         "inside",
+			value:        "02:00-05:00",
+			now:          time.Date(2025, 1, 4, 3, 0, 0, 0, time.UTC),
+			expectedIn:   true,
+			expectedNext: time.Date(2025, 1, 4, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:         "before",
+			value:        "02:00-05:00",
+			now:          time.Date(2025, 1, 4, 1, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2025, 1, 4, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:         "after",
+			value:        "02:00-05:00",
+			now:          time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2025, 1, 5, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:         "days",
+			value:        "02:00-05:00",
+			days:         []string{"mon", "tue"},
+			now:          time.Date(2025, 1, 4, 3, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2025, 1, 6, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:         "spans midnight",
+			value:        "22:00-02:00",
+			days:         []string{"fri"},
+			now:          time.Date(2025, 1, 4, 1, 0, 0, 0, time.UTC),
+			expectedIn:   true,
+			expectedNext: time.Date(2025, 1, 4, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:         "spans midnight, outside",
+			value:        "22:00-02:00",
+			days:         []string{"fri"},
+			now:          time.Date(2025, 1, 4, 23, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2025, 1, 10, 22, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			window, err := parseRenewWindow(test.value, test.days, "UTC")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedIn, window.Contains(test.now))
+			assert.Equal(t, test.expectedNext, window.Next(test.now))
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/urfave/cli/v2"
+)
+
+// challengeFlags the options of the challenges.
+var challengeFlags = []string{
+	flgHTTP, flgHTTPPort, flgHTTPDelay, flgHTTPProxyHeader, flgHTTPWebroot, flgHTTPMemcachedHost, flgHTTPS3Bucket,
+	flgTLS, flgTLSPort, flgTLSDelay,
+	flgDNS, flgDNSDisableCP, flgDNSPropagationDisableANS, flgDNSPropagationRNS, flgDNSPropagationWait, flgDNSResolvers,
+}
+
+// renewalConfigFlags the options written in the renewal configuration of a certificate (the names of the options of the renew command).
+var renewalConfigFlags = slices.Concat([]string{
+	flgKeyType,
+	flgPreferredChain, flgMustStaple,
+	flgRenewHook, flgRenewHookTimeout,
+	flgPreHook, flgPostHook, flgHookTimeout, flgHookMaterial,
+	flgDeploy,
+}, challengeFlags)
+
+// renewalHookFlags the options of the run command written as the options of the renew command.
+var renewalHookFlags = map[string]string{
+	flgRunHook:        flgRenewHook,
+	flgRunHookTimeout: flgRenewHookTimeout,
+}
+
+// newRenewalConfig creates the renewal configuration of a certificate from the options of the run command.
+// The key type is always written, the other options only if they are defined.
+func newRenewalConfig(ctx *cli.Context) map[string]any {
+	config := map[string]any{
+		flgKeyType: ctx.String(flgKeyType),
+	}
+
+	for _, flag := range contextFlags(ctx) {
+		name := flag.Names()[0]
+
+		if !ctx.IsSet(name) {
+			continue
+		}
+
+		if renewName, ok := renewalHookFlags[name]; ok {
+			name = renewName
+		}
+
+		if !slices.Contains(renewalConfigFlags, name) {
+			continue
+		}
+
+		config[name] = renewalConfigValue(ctx, flag)
+	}
+
+	return config
+}
+
+func renewalConfigValue(ctx *cli.Context, f cli.Flag) any {
+	name := f.Names()[0]
+
+	switch f.(type) {
+	case *cli.StringSliceFlag:
+		return ctx.StringSlice(name)
+	case *cli.BoolFlag:
+		return ctx.Bool(name)
+	case *cli.IntFlag:
+		return ctx.Int(name)
+	default:
+		return flagString(ctx, f)
+	}
+}
+
+// SaveRenewalConfig writes the renewal configuration of the certificate, next to the resource.
+func (s *CertificatesStorage) SaveRenewalConfig(domain string, config map[string]any) error {
+	buf := &bytes.Buffer{}
+
+	_, _ = fmt.Fprintf(buf, "# The options used to obtain the certificate, used by the renew command.\n")
+	_, _ = fmt.Fprintf(buf, "# The command line options, the environment variables, and the certificate blocks of the configuration file take precedence.\n")
+
+	err := toml.NewEncoder(buf).Encode(config)
+	if err != nil {
+		return fmt.Errorf("renewal configuration: %w", err)
+	}
+
+	return s.WriteFile(domain, renewalExt, buf.Bytes())
+}
+
+// ReadRenewalConfig reads the renewal configuration of the certificate.
+// A nil configuration is returned if the file doesn't exist.
+func (s *CertificatesStorage) ReadRenewalConfig(domain string) (map[string]any, error) {
+	filePath, err := s.GetFileName(domain, renewalExt)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.ExistsFile(domain, renewalExt)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, nil
+	}
+
+	config := map[string]any{}
+
+	_, err = toml.DecodeFile(filePath, &config)
+	if err != nil {
+		return nil, fmt.Errorf("renewal configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// newRenewalContext creates the context of the renewal of a certificate with its renewal configuration.
+// The context is returned unchanged if the certificate has no renewal configuration.
+func newRenewalContext(ctx *cli.Context, certsStorage *CertificatesStorage, domain string) (*cli.Context, error) {
+	config, err := certsStorage.ReadRenewalConfig(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		return ctx, nil
+	}
+
+	for key := range config {
+		if !slices.Contains(renewalConfigFlags, key) {
+			return nil, fmt.Errorf("renewal configuration: unknown option: %q", key)
+		}
+	}
+
+	options := &certificateOptions{renewal: config}
+
+	if current := getCertificateOptions(ctx); current != nil {
+		options.block = current.block
+	}
+
+	// The challenges defined by the options, or by the certificate block, replace the challenges of the renewal configuration.
+	userChallenge := slices.ContainsFunc([]string{flgHTTP, flgTLS, flgDNS}, func(name string) bool { return isSetByUser(ctx, name) })
+
+	if userChallenge || definesChallenge(options.block) {
+		for _, name := range challengeFlags {
+			delete(config, name)
+		}
+	}
+
+	return newCertificateContext(ctx, options)
+}
+
+// getRenewalDomain returns the main domain of the certificate to renew (--domains or --csr).
+func getRenewalDomain(ctx *cli.Context) (string, error) {
+	if !ctx.IsSet(flgCSR) {
+		domains := ctx.StringSlice(flgDomains)
+		if len(domains) == 0 {
+			return "", errors.New("no domain")
+		}
+
+		return domains[0], nil
+	}
+
+	csr, err := readCSRFile(ctx.String(flgCSR))
+	if err != nil {
+		return "", err
+	}
+
+	return certcrypto.GetCSRMainDomain(csr)
+}
+
+// withRenewalConfig returns the context of the renewal of the certificate defined by the options (--cert-name, --domains, or --csr).
+func withRenewalConfig(ctx *cli.Context) (*cli.Context, error) {
+	// The files of the certificate are identified by its name (--cert-name).
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := ctx.String(flgCertName); name != "" {
+		return newRenewalContext(ctx, certsStorage, name)
+	}
+
+	domain, err := getRenewalDomain(ctx)
+	if err != nil {
+		// The error is reported by the renewal.
+		return ctx, nil
+	}
+
+	return newRenewalContext(ctx, certsStorage, domain)
+}
+
+// definesChallenge returns true if the options define a challenge.
+func definesChallenge(values map[string]any) bool {
+	return slices.ContainsFunc([]string{flgHTTP, flgTLS, flgDNS}, func(name string) bool {
+		_, ok := values[name]
+		return ok
+	})
+}
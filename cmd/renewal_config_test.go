@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func runCommand(t *testing.T, name string, flags []cli.Flag, action cli.ActionFunc, args ...string) {
+	t.Helper()
+
+	command := &cli.Command{
+		Name:   name,
+		Flags:  flags,
+		Action: action,
+	}
+
+	addCommandEnvVars("", command)
+	addConfigLayers(nil, command)
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags(t.TempDir())
+	app.Before = applyConfigFile
+	app.Commands = []*cli.Command{command}
+
+	err := app.Run(append([]string{"lego"}, args...))
+	require.NoError(t, err)
+}
+
+func Test_newRenewalConfig(t *testing.T) {
+	var config map[string]any
+
+	runCommand(t, "run", createRun().Flags, func(ctx *cli.Context) error {
+		config = newRenewalConfig(ctx)
+		return nil
+	}, "--dns", "manual", "--dns.resolvers", "1.1.1.1:53", "--email", "test@example.com",
+		"run", "--run-hook", "./hook.sh", "--must-staple", "--no-bundle")
+
+	expected := map[string]any{
+		flgKeyType:      "ec256",
+		flgDNS:          "manual",
+		flgDNSResolvers: []string{"1.1.1.1:53"},
+		flgRenewHook:    "./hook.sh",
+		flgMustStaple:   true,
+	}
+
+	assert.Equal(t, expected, config)
+}
+
+func TestCertificatesStorage_RenewalConfig(t *testing.T) {
+	storage := &CertificatesStorage{rootPath: t.TempDir(), outPath: t.TempDir()}
+
+	config, err := storage.ReadRenewalConfig("example.com")
+	require.NoError(t, err)
+	assert.Nil(t, config)
+
+	err = storage.SaveRenewalConfig("example.com", map[string]any{
+		flgKeyType:      "rsa2048",
+		flgDNSResolvers: []string{"1.1.1.1:53"},
+		flgMustStaple:   true,
+	})
+	require.NoError(t, err)
+
+	assert.FileExists(t, mustFileName(t, storage, "example.com", renewalExt))
+	assert.NoFileExists(t, filepath.Join(storage.outPath, "example.com"+renewalExt))
+
+	config, err = storage.ReadRenewalConfig("example.com")
+	require.NoError(t, err)
+
+	expected := map[string]any{
+		flgKeyType:      "rsa2048",
+		flgDNSResolvers: []any{"1.1.1.1:53"},
+		flgMustStaple:   true,
+	}
+
+	assert.Equal(t, expected, config)
+}
+
+func Test_newRenewalContext(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		args         []string
+		expectedDNS  string
+		expectedHTTP bool
+		expectedHook string
+	}{
+		{
+			desc:         "renewal configuration",
+			args:         []string{"renew"},
+			expectedDNS:  "exec",
+			expectedHook: "./hook.sh",
+		},
+		{
+			desc:         "options",
+			args:         []string{"renew", "--renew-hook", "./other.sh"},
+			expectedDNS:  "exec",
+			expectedHook: "./other.sh",
+		},
+		{
+			desc:         "challenge",
+			args:         []string{"--http", "renew"},
+			expectedHTTP: true,
+			expectedHook: "./hook.sh",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			storage := &CertificatesStorage{rootPath: t.TempDir()}
+
+			err := storage.SaveRenewalConfig("example.com", map[string]any{
+				flgKeyType:   "rsa2048",
+				flgDNS:       "exec",
+				flgRenewHook: "./hook.sh",
+			})
+			require.NoError(t, err)
+
+			runCommand(t, "renew", createRenew().Flags, func(ctx *cli.Context) error {
+				renewalCtx, err := newRenewalContext(ctx, storage, "example.com")
+				require.NoError(t, err)
+
+				assert.Equal(t, "rsa2048", renewalCtx.String(flgKeyType))
+				assert.Equal(t, test.expectedDNS, renewalCtx.String(flgDNS))
+				assert.Equal(t, test.expectedHTTP, renewalCtx.Bool(flgHTTP))
+				assert.Equal(t, test.expectedHook, renewalCtx.String(flgRenewHook))
+
+				return nil
+			}, test.args...)
+		})
+	}
+}
+
+func Test_newRenewalContext_unknownOption(t *testing.T) {
+	storage := &CertificatesStorage{rootPath: t.TempDir()}
+
+	err := storage.SaveRenewalConfig("example.com", map[string]any{flgEmail: "test@example.com"})
+	require.NoError(t, err)
+
+	runCommand(t, "renew", createRenew().Flags, func(ctx *cli.Context) error {
+		_, err := newRenewalContext(ctx, storage, "example.com")
+		require.EqualError(t, err, `renewal configuration: unknown option: "email"`)
+
+		return nil
+	}, "renew")
+}
@@ -4,12 +4,12 @@ import (
 	"context"
 	"crypto/x509"
 	"encoding/json"
-	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
@@ -24,40 +24,95 @@ import (
 const filePerm os.FileMode = 0o600
 
 // setupClient creates a new client with challenge settings.
-func setupClient(ctx *cli.Context, account *Account, keyType certcrypto.KeyType) *lego.Client {
-	client := newClient(ctx, account, keyType)
+func setupClient(ctx *cli.Context, account *Account, keyType certcrypto.KeyType) (*lego.Client, error) {
+	client, err := createClient(ctx, account.server, account, keyType)
+	if err != nil {
+		return nil, err
+	}
 
-	setupChallenges(ctx, client)
+	err = setupChallenges(ctx, client)
+	if err != nil {
+		return nil, err
+	}
 
-	return client
+	return client, nil
 }
 
-func setupAccount(ctx *cli.Context, accountsStorage *AccountsStorage) (*Account, certcrypto.KeyType) {
-	keyType := getKeyType(ctx)
-	privateKey := accountsStorage.GetPrivateKey(keyType)
+func setupAccount(ctx *cli.Context, accountsStorage *AccountsStorage) (*Account, certcrypto.KeyType, error) {
+	keyType, err := getKeyType(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	privateKey, err := accountsStorage.GetPrivateKey(getAccountKeyType(keyType))
+	if err != nil {
+		return nil, "", err
+	}
+
+	exists, err := accountsStorage.ExistsAccountFilePath()
+	if err != nil {
+		return nil, "", err
+	}
 
 	var account *Account
-	if accountsStorage.ExistsAccountFilePath() {
-		account = accountsStorage.LoadAccount(privateKey)
+	if exists {
+		account, err = accountsStorage.LoadAccount(privateKey)
+		if err != nil {
+			return nil, "", err
+		}
 	} else {
 		account = &Account{Email: accountsStorage.GetEmail(), key: privateKey}
 	}
 
-	return account, keyType
+	account.server = accountsStorage.GetServer()
+
+	return account, keyType, nil
+}
+
+func newClient(ctx *cli.Context, server string, acc registration.User, keyType certcrypto.KeyType) *lego.Client {
+	client, err := createClient(ctx, server, acc, keyType)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return client
+}
+
+// createClient creates a new client for the CA (server).
+func createClient(ctx *cli.Context, server string, acc registration.User, keyType certcrypto.KeyType) (*lego.Client, error) {
+	client, err := lego.NewClient(newClientConfig(ctx, server, acc, keyType))
+	if err != nil {
+		return nil, fmt.Errorf("could not create client: %w", err)
+	}
+
+	if client.GetExternalAccountRequired() && !ctx.IsSet(flgEAB) && !canProvisionEAB(ctx, server) {
+		return nil, fmt.Errorf("server requires External Account Binding. Use --%s with --%s and --%s", flgEAB, flgKID, flgHMAC)
+	}
+
+	return client, nil
 }
 
-func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyType) *lego.Client {
+// newClientConfig creates the configuration of a client for the CA (server).
+func newClientConfig(ctx *cli.Context, server string, acc registration.User, keyType certcrypto.KeyType) *lego.Config {
 	config := lego.NewConfig(acc)
-	config.CADirURL = ctx.String(flgServer)
+	config.CADirURL = server
 
 	config.Certificate = lego.CertificateConfig{
-		KeyType:             keyType,
-		Timeout:             time.Duration(ctx.Int(flgCertTimeout)) * time.Second,
-		OverallRequestLimit: ctx.Int(flgOverallRequestLimit),
-		DisableCommonName:   ctx.Bool(flgDisableCommonName),
+		KeyType:                 keyType,
+		Timeout:                 getOrderTimeout(ctx),
+		OrderPollInterval:       ctx.Duration(flgOrderPollInterval),
+		OrderPollAttemptTimeout: ctx.Duration(flgOrderAttemptTimeout),
+		OverallRequestLimit:     ctx.Int(flgOverallRequestLimit),
+		DisableCommonName:       ctx.Bool(flgDisableCommonName),
 	}
 	config.UserAgent = getUserAgent(ctx)
 
+	// 0 disables the retries in the CLI, and means the default value in the library.
+	config.BadNonceRetries = ctx.Int(flgBadNonceRetries)
+	if config.BadNonceRetries == 0 {
+		config.BadNonceRetries = -1
+	}
+
 	if ctx.IsSet(flgHTTPTimeout) {
 		config.HTTPClient.Timeout = time.Duration(ctx.Int(flgHTTPTimeout)) * time.Second
 	}
@@ -77,45 +132,42 @@ func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyTy
 	retryClient.CheckRetry = checkRetry
 	retryClient.Logger = nil
 
-	if _, v := os.LookupEnv("LEGO_DEBUG_ACME_HTTP_CLIENT"); v {
-		retryClient.Logger = log.Logger
+	if _, v := os.LookupEnv(envDebugACMEHTTPClient); v {
+		retryClient.Logger = log.Default()
 	}
 
 	config.HTTPClient = retryClient.StandardClient()
 
-	client, err := lego.NewClient(config)
+	return config
+}
+
+// getKeyType the type from which private keys should be generated.
+func getKeyType(ctx *cli.Context) (certcrypto.KeyType, error) {
+	keyType, err := certcrypto.ParseKeyType(ctx.String(flgKeyType))
 	if err != nil {
-		log.Fatalf("Could not create client: %v", err)
+		return "", fmt.Errorf("--%s: %w", flgKeyType, err)
 	}
 
-	if client.GetExternalAccountRequired() && !ctx.IsSet(flgEAB) {
-		log.Fatalf("Server requires External Account Binding. Use --%s with --%s and --%s.", flgEAB, flgKID, flgHMAC)
+	return keyType, nil
+}
+
+// getAccountKeyType the type of the account private key:
+// the key types not supported by the ACME protocol (JWS) are only used for the certificates.
+func getAccountKeyType(keyType certcrypto.KeyType) certcrypto.KeyType {
+	if certcrypto.IsJWSKeyType(keyType) {
+		return keyType
 	}
 
-	return client
+	return certcrypto.EC256
 }
 
-// getKeyType the type from which private keys should be generated.
-func getKeyType(ctx *cli.Context) certcrypto.KeyType {
-	keyType := ctx.String(flgKeyType)
-	switch strings.ToUpper(keyType) {
-	case "RSA2048":
-		return certcrypto.RSA2048
-	case "RSA3072":
-		return certcrypto.RSA3072
-	case "RSA4096":
-		return certcrypto.RSA4096
-	case "RSA8192":
-		return certcrypto.RSA8192
-	case "EC256":
-		return certcrypto.EC256
-	case "EC384":
-		return certcrypto.EC384
-	}
-
-	log.Fatalf("Unsupported KeyType: %s", keyType)
-
-	return ""
+// getOrderTimeout returns the overall deadline to wait for the certificate (--order-timeout or --cert.timeout).
+func getOrderTimeout(ctx *cli.Context) time.Duration {
+	if ctx.IsSet(flgOrderTimeout) {
+		return ctx.Duration(flgOrderTimeout)
+	}
+
+	return time.Duration(ctx.Int(flgCertTimeout)) * time.Second
 }
 
 func getUserAgent(ctx *cli.Context) string {
@@ -133,37 +185,34 @@ func createNonExistingFolder(path string) error {
 }
 
 func readCSRFile(filename string) (*x509.CertificateRequest, error) {
-	bytes, err := os.ReadFile(filename)
+	var (
+		raw []byte
+		err error
+	)
+
+	if filename == "-" {
+		raw, err = readStdinCSR()
+	} else {
+		raw, err = os.ReadFile(filename)
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	raw := bytes
-
-	// see if we can find a PEM-encoded CSR
-	var p *pem.Block
-
-	rest := bytes
-	for {
-		// decode a PEM block
-		p, rest = pem.Decode(rest)
-
-		// did we fail?
-		if p == nil {
-			break
-		}
+	// PEM-encoded or DER-encoded CSR.
+	return certcrypto.ParseCSR(raw)
+}
 
-		// did we get a CSR?
-		if p.Type == "CERTIFICATE REQUEST" || p.Type == "NEW CERTIFICATE REQUEST" {
-			raw = p.Bytes
-		}
+// readStdinCSR reads the CSR from stdin (--csr -) only once: the commands read the CSR several times.
+var readStdinCSR = sync.OnceValues(func() ([]byte, error) {
+	raw, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("stdin: %w", err)
 	}
 
-	// no PEM-encoded CSR
-	// assume we were given a DER-encoded ASN.1 CSR
-	// (if this assumption is wrong, parsing these bytes will fail)
-	return x509.ParseCertificateRequest(raw)
-}
+	return raw, nil
+})
 
 func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
 	rt, err := retryablehttp.ErrorPropagatedRetryPolicy(ctx, resp, err)
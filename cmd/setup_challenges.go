@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"strings"
@@ -19,66 +20,63 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-func setupChallenges(ctx *cli.Context, client *lego.Client) {
+func setupChallenges(ctx *cli.Context, client *lego.Client) error {
 	if !ctx.Bool(flgHTTP) && !ctx.Bool(flgTLS) && !ctx.IsSet(flgDNS) {
-		log.Fatalf("No challenge selected. You must specify at least one challenge: `--%s`, `--%s`, `--%s`.", flgHTTP, flgTLS, flgDNS)
+		return fmt.Errorf("no challenge selected: you must specify at least one challenge: `--%s`, `--%s`, `--%s`", flgHTTP, flgTLS, flgDNS)
 	}
 
 	if ctx.Bool(flgHTTP) {
-		err := client.Challenge.SetHTTP01Provider(setupHTTPProvider(ctx), http01.SetDelay(ctx.Duration(flgHTTPDelay)))
+		provider, err := setupHTTPProvider(ctx)
 		if err != nil {
-			log.Fatal(err)
+			return err
+		}
+
+		err = client.Challenge.SetHTTP01Provider(provider, http01.SetDelay(ctx.Duration(flgHTTPDelay)))
+		if err != nil {
+			return err
 		}
 	}
 
 	if ctx.Bool(flgTLS) {
-		err := client.Challenge.SetTLSALPN01Provider(setupTLSProvider(ctx), tlsalpn01.SetDelay(ctx.Duration(flgTLSDelay)))
+		provider, err := setupTLSProvider(ctx)
 		if err != nil {
-			log.Fatal(err)
+			return err
+		}
+
+		err = client.Challenge.SetTLSALPN01Provider(provider, tlsalpn01.SetDelay(ctx.Duration(flgTLSDelay)))
+		if err != nil {
+			return err
 		}
 	}
 
 	if ctx.IsSet(flgDNS) {
 		err := setupDNS(ctx, client)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
+
+	return nil
 }
 
 //nolint:gocyclo // the complexity is expected.
-func setupHTTPProvider(ctx *cli.Context) challenge.Provider {
+func setupHTTPProvider(ctx *cli.Context) (challenge.Provider, error) {
 	switch {
 	case ctx.IsSet(flgHTTPWebroot):
-		ps, err := webroot.NewHTTPProvider(ctx.String(flgHTTPWebroot))
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		return ps
+		return webroot.NewHTTPProvider(ctx.String(flgHTTPWebroot))
 	case ctx.IsSet(flgHTTPMemcachedHost):
-		ps, err := memcached.NewMemcachedProvider(ctx.StringSlice(flgHTTPMemcachedHost))
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		return ps
+		return memcached.NewMemcachedProvider(ctx.StringSlice(flgHTTPMemcachedHost))
 	case ctx.IsSet(flgHTTPS3Bucket):
-		ps, err := s3.NewHTTPProvider(ctx.String(flgHTTPS3Bucket))
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		return ps
+		return s3.NewHTTPProvider(ctx.String(flgHTTPS3Bucket))
 	case ctx.IsSet(flgHTTPPort):
 		iface := ctx.String(flgHTTPPort)
 		if !strings.Contains(iface, ":") {
-			log.Fatalf("The --%s switch only accepts interface:port or :port for its argument.", flgHTTPPort)
+			return nil, fmt.Errorf("the --%s switch only accepts interface:port or :port for its argument", flgHTTPPort)
 		}
 
 		host, port, err := net.SplitHostPort(iface)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 
 		srv := http01.NewProviderServer(host, port)
@@ -86,39 +84,37 @@ func setupHTTPProvider(ctx *cli.Context) challenge.Provider {
 			srv.SetProxyHeader(header)
 		}
 
-		return srv
+		return srv, nil
 	case ctx.Bool(flgHTTP):
 		srv := http01.NewProviderServer("", "")
 		if header := ctx.String(flgHTTPProxyHeader); header != "" {
 			srv.SetProxyHeader(header)
 		}
 
-		return srv
+		return srv, nil
 	default:
-		log.Fatal("Invalid HTTP challenge options.")
-		return nil
+		return nil, errors.New("invalid HTTP challenge options")
 	}
 }
 
-func setupTLSProvider(ctx *cli.Context) challenge.Provider {
+func setupTLSProvider(ctx *cli.Context) (challenge.Provider, error) {
 	switch {
 	case ctx.IsSet(flgTLSPort):
 		iface := ctx.String(flgTLSPort)
 		if !strings.Contains(iface, ":") {
-			log.Fatalf("The --%s switch only accepts interface:port or :port for its argument.", flgTLSPort)
+			return nil, fmt.Errorf("the --%s switch only accepts interface:port or :port for its argument", flgTLSPort)
 		}
 
 		host, port, err := net.SplitHostPort(iface)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 
-		return tlsalpn01.NewProviderServer(host, port)
+		return tlsalpn01.NewProviderServer(host, port), nil
 	case ctx.Bool(flgTLS):
-		return tlsalpn01.NewProviderServer("", "")
+		return tlsalpn01.NewProviderServer("", ""), nil
 	default:
-		log.Fatal("Invalid HTTP challenge options.")
-		return nil
+		return nil, errors.New("invalid TLS challenge options")
 	}
 }
 
@@ -155,6 +151,9 @@ func setupDNS(ctx *cli.Context, client *lego.Client) error {
 		dns01.CondOption(ctx.Bool(flgDNSPropagationRNS),
 			dns01.RecursiveNSsPropagationRequirement()),
 
+		dns01.CondOption(ctx.Bool(flgDNSPropagationReuseConn),
+			dns01.ReuseAuthoritativeNssConnections()),
+
 		dns01.CondOption(ctx.IsSet(flgDNSTimeout),
 			dns01.AddDNSTimeout(time.Duration(ctx.Int(flgDNSTimeout))*time.Second)),
 	)
@@ -164,7 +163,7 @@ func setupDNS(ctx *cli.Context, client *lego.Client) error {
 
 func checkPropagationExclusiveOptions(ctx *cli.Context) error {
 	if ctx.IsSet(flgDNSDisableCP) {
-		log.Printf("The flag '%s' is deprecated use '%s' instead.", flgDNSDisableCP, flgDNSPropagationDisableANS)
+		log.Warnf("The flag '%s' is deprecated use '%s' instead.", flgDNSDisableCP, flgDNSPropagationDisableANS)
 	}
 
 	if (isSetBool(ctx, flgDNSDisableCP) || isSetBool(ctx, flgDNSPropagationDisableANS)) && ctx.IsSet(flgDNSPropagationWait) {
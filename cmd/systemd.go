@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+)
+
+// The states sent to systemd (sd_notify).
+const (
+	sdNotifyReady    = "READY=1"
+	sdNotifyStopping = "STOPPING=1"
+	sdNotifyWatchdog = "WATCHDOG=1"
+)
+
+// sdNotify sends a state to systemd (sd_notify), if the process is a service with a notification socket (Type=notify).
+// https://www.freedesktop.org/software/systemd/man/latest/sd_notify.html
+func sdNotify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+
+	// Abstract namespace socket.
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("systemd: notify: %w", err)
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	_, err = conn.Write([]byte(state))
+	if err != nil {
+		return fmt.Errorf("systemd: notify: %w", err)
+	}
+
+	return nil
+}
+
+// sdStatus sends the status of the service to systemd (displayed by systemctl status).
+func sdStatus(format string, args ...any) {
+	err := sdNotify("STATUS=" + fmt.Sprintf(format, args...))
+	if err != nil {
+		log.Warnf("%v", err)
+	}
+}
+
+// sdWatchdogInterval returns the interval between two notifications of the watchdog:
+// half of the watchdog timeout of the service (WatchdogSec), or 0 if the watchdog is disabled.
+func sdWatchdogInterval() time.Duration {
+	pid := os.Getenv("WATCHDOG_PID")
+	if pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+
+	return time.Duration(usec) * time.Microsecond / 2
+}
+
+// startWatchdog notifies the watchdog of systemd until the context is done.
+func startWatchdog(ctx context.Context) {
+	interval := sdWatchdogInterval()
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-ticker.C:
+				err := sdNotify(sdNotifyWatchdog)
+				if err != nil {
+					log.Warnf("%v", err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sdNotify(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unixgram sockets are not supported on Windows")
+	}
+
+	socket := filepath.Join(t.TempDir(), "notify.sock")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	t.Setenv("NOTIFY_SOCKET", socket)
+
+	err = sdNotify(sdNotifyReady)
+	require.NoError(t, err)
+
+	buf := make([]byte, 64)
+
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, sdNotifyReady, string(buf[:n]))
+}
+
+func Test_sdNotify_noSocket(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+
+	err := sdNotify(sdNotifyReady)
+	require.NoError(t, err)
+}
+
+func Test_sdWatchdogInterval(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		usec     string
+		pid      string
+		expected time.Duration
+	}{
+		{
+			desc:     "watchdog",
+			usec:     "300000000",
+			expected: 150 * time.Second,
+		},
+		{
+			desc:     "watchdog of the process",
+			usec:     "300000000",
+			pid:      strconv.Itoa(os.Getpid()),
+			expected: 150 * time.Second,
+		},
+		{
+			desc: "watchdog of another process",
+			usec: "300000000",
+			pid:  "1",
+		},
+		{
+			desc: "no watchdog",
+		},
+		{
+			desc: "invalid",
+			usec: "foo",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Setenv("WATCHDOG_USEC", test.usec)
+			t.Setenv("WATCHDOG_PID", test.pid)
+
+			assert.Equal(t, test.expected, sdWatchdogInterval())
+		})
+	}
+}
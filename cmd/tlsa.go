@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/go-acme/lego/v4/providers/dns"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgTLSAPort         = "tlsa.port"
+	flgTLSAUsage        = "tlsa.usage"
+	flgTLSASelector     = "tlsa.selector"
+	flgTLSAMatchingType = "tlsa.matching-type"
+)
+
+func createTLSAFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: flgTLSAPort,
+			Usage: "Publish the DANE TLSA records of the certificate for this port (e.g. 25, 443/tcp), using the DNS provider (--dns)." +
+				" The records of the previous certificate are kept until the next renewal (rollover)." +
+				" The certificate is deployed (hooks) once the records are propagated to the authoritative nameservers, and the cached records expired (TTL)." +
+				" The DNS provider must support the management of TLSA records.",
+		},
+		&cli.UintFlag{
+			Name:  flgTLSAUsage,
+			Usage: "The certificate usage of the TLSA records: 0 (PKIX-TA), 1 (PKIX-EE), 2 (DANE-TA), or 3 (DANE-EE).",
+			Value: dns01.TLSAUsageDANEEE,
+		},
+		&cli.UintFlag{
+			Name:  flgTLSASelector,
+			Usage: "The selector of the TLSA records: 0 (full certificate), or 1 (SubjectPublicKeyInfo).",
+			Value: 1,
+		},
+		&cli.UintFlag{
+			Name:  flgTLSAMatchingType,
+			Usage: "The matching type of the TLSA records: 0 (exact match), 1 (SHA-256), or 2 (SHA-512).",
+			Value: 1,
+		},
+	}
+}
+
+type tlsaService struct {
+	port     int
+	protocol string
+}
+
+// publishTLSA publishes the TLSA records of the certificate, alongside the records of the previous certificate (if any).
+func publishTLSA(ctx *cli.Context, certRes *certificate.Resource, previous []*x509.Certificate) error {
+	services, err := parseTLSAPorts(ctx.StringSlice(flgTLSAPort))
+	if err != nil {
+		return err
+	}
+
+	provider, err := dns.NewDNSChallengeProviderByName(ctx.String(flgDNS))
+	if err != nil {
+		return err
+	}
+
+	tlsaProvider, ok := provider.(dns01.TLSAProvider)
+	if !ok {
+		return fmt.Errorf("tlsa: the DNS provider %q doesn't support the management of TLSA records", ctx.String(flgDNS))
+	}
+
+	chain, err := getFullChain(certRes)
+	if err != nil {
+		return fmt.Errorf("tlsa: %w", err)
+	}
+
+	usage, selector, matchingType := uint8(ctx.Uint(flgTLSAUsage)), uint8(ctx.Uint(flgTLSASelector)), uint8(ctx.Uint(flgTLSAMatchingType))
+
+	record, err := dns01.NewTLSARecord(chain, usage, selector, matchingType)
+	if err != nil {
+		return fmt.Errorf("tlsa: %w", err)
+	}
+
+	var previousRecords []dns01.TLSARecord
+
+	if len(previous) > 0 {
+		previousRecord, errP := dns01.NewTLSARecord(previous, usage, selector, matchingType)
+		if errP != nil {
+			log.Warnf("tlsa: the TLSA record of the previous certificate will not be published: %v", errP)
+		} else {
+			previousRecords = append(previousRecords, previousRecord)
+		}
+	}
+
+	records := dns01.RolloverTLSARecords([]dns01.TLSARecord{record}, previousRecords)
+
+	var names []string
+
+	for _, domain := range certcrypto.ExtractDomains(chain[0]) {
+		if strings.HasPrefix(domain, "*.") {
+			log.Warnf("[%s] tlsa: TLSA records cannot be published for a wildcard domain", domain)
+			continue
+		}
+
+		for _, service := range services {
+			name := dns01.TLSAName(domain, service.port, service.protocol)
+
+			err = tlsaProvider.SetTLSA(name, records)
+			if err != nil {
+				return fmt.Errorf("tlsa: %s: %w", name, err)
+			}
+
+			log.Infof("[%s] tlsa: TLSA records published: %s %v", domain, name, records)
+
+			names = append(names, name)
+		}
+	}
+
+	return waitTLSAPropagation(provider, names, records)
+}
+
+// waitTLSAPropagation waits for the propagation of the TLSA records to the authoritative nameservers,
+// then for the expiration of the previous record sets cached by the resolvers (TTL):
+// the new certificate must not be deployed before its TLSA record is visible to the clients.
+func waitTLSAPropagation(provider challenge.Provider, names []string, records []dns01.TLSARecord) error {
+	timeout, interval := dns01.DefaultPropagationTimeout, dns01.DefaultPollingInterval
+
+	if p, ok := provider.(challenge.ProviderTimeout); ok {
+		timeout, interval = p.Timeout()
+	}
+
+	var ttl time.Duration
+
+	for _, name := range names {
+		err := wait.For("TLSA records propagation", timeout, interval, func() (bool, error) {
+			nameTTL, errC := dns01.CheckTLSAPropagation(name, records)
+			if errC != nil {
+				return false, errC
+			}
+
+			ttl = max(ttl, nameTTL)
+
+			return true, nil
+		})
+		if err != nil {
+			return fmt.Errorf("tlsa: %s: %w", name, err)
+		}
+	}
+
+	if ttl > 0 {
+		log.Infof("tlsa: waiting %s for the expiration of the cached TLSA records", ttl)
+
+		time.Sleep(ttl)
+	}
+
+	return nil
+}
+
+// getFullChain returns the certificate chain, the leaf certificate first.
+func getFullChain(certRes *certificate.Resource) ([]*x509.Certificate, error) {
+	chain, err := certcrypto.ParsePEMBundle(certRes.Certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chain) > 1 || len(certRes.IssuerCertificate) == 0 {
+		return chain, nil
+	}
+
+	issuers, err := getCertificateChain(certRes)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(chain, issuers...), nil
+}
+
+func parseTLSAPorts(values []string) ([]tlsaService, error) {
+	var services []tlsaService
+
+	for _, value := range values {
+		rawPort, protocol, ok := strings.Cut(value, "/")
+		if !ok {
+			protocol = "tcp"
+		}
+
+		port, err := strconv.Atoi(rawPort)
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("tlsa: invalid port: %q", value)
+		}
+
+		switch protocol {
+		case "tcp", "udp", "sctp":
+		default:
+			return nil, fmt.Errorf("tlsa: invalid protocol: %q", value)
+		}
+
+		services = append(services, tlsaService{port: port, protocol: protocol})
+	}
+
+	return services, nil
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseTLSAPorts(t *testing.T) {
+	services, err := parseTLSAPorts([]string{"25", "853/udp"})
+	require.NoError(t, err)
+
+	expected := []tlsaService{
+		{port: 25, protocol: "tcp"},
+		{port: 853, protocol: "udp"},
+	}
+
+	assert.Equal(t, expected, services)
+}
+
+func Test_parseTLSAPorts_error(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "smtp", expected: `tlsa: invalid port: "smtp"`},
+		{value: "70000", expected: `tlsa: invalid port: "70000"`},
+		{value: "25/foo", expected: `tlsa: invalid protocol: "25/foo"`},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.value, func(t *testing.T) {
+			_, err := parseTLSAPorts([]string{test.value})
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
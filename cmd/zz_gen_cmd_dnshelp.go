@@ -212,6 +212,3418 @@ func allDNSCodes() string {
 	return strings.Join(providers, ", ")
 }
 
+func dnsProviders() []dnsProvider {
+	return []dnsProvider{
+		{
+			Code:          "acme-dns",
+			Name:          "Joohoi's ACME-DNS",
+			Since:         "v1.1.0",
+			URL:           "https://github.com/joohoi/acme-dns",
+			Documentation: "https://go-acme.github.io/lego/dns/acme-dns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ACME_DNS_API_BASE", Description: "The ACME-DNS API address"},
+				{Name: "ACME_DNS_STORAGE_BASE_URL", Description: "The ACME-DNS JSON account data server."},
+				{Name: "ACME_DNS_STORAGE_PATH", Description: "The ACME-DNS JSON account data file. A per-domain account will be registered/persisted to this file and used for TXT updates."},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ACME_DNS_ALLOWLIST", Description: "Source networks using CIDR notation (multiple values should be separated with a comma)."},
+			},
+		},
+		{
+			Code:          "active24",
+			Name:          "Active24",
+			Since:         "v4.23.0",
+			URL:           "https://www.active24.cz",
+			Documentation: "https://go-acme.github.io/lego/dns/active24",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ACTIVE24_API_KEY", Description: "API key"},
+				{Name: "ACTIVE24_SECRET", Description: "Secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ACTIVE24_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ACTIVE24_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ACTIVE24_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "ACTIVE24_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "alidns",
+			Name:          "Alibaba Cloud DNS",
+			Since:         "v1.1.0",
+			URL:           "https://www.alibabacloud.com/product/dns",
+			Documentation: "https://go-acme.github.io/lego/dns/alidns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ALICLOUD_ACCESS_KEY", Description: "Access key ID"},
+				{Name: "ALICLOUD_RAM_ROLE", Description: "Your instance RAM role (https://www.alibabacloud.com/help/en/ecs/user-guide/attach-an-instance-ram-role-to-an-ecs-instance)"},
+				{Name: "ALICLOUD_SECRET_KEY", Description: "Access Key secret"},
+				{Name: "ALICLOUD_SECURITY_TOKEN", Description: "STS Security Token (optional)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ALICLOUD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "ALICLOUD_LINE", Description: "Line (Default: default)", Default: "default"},
+				{Name: "ALICLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ALICLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "ALICLOUD_REGION_ID", Description: "Region ID (Default: cn-hangzhou)", Default: "cn-hangzhou"},
+				{Name: "ALICLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "aliesa",
+			Name:          "AlibabaCloud ESA",
+			Since:         "v4.29.0",
+			URL:           "https://www.alibabacloud.com/en/product/esa",
+			Documentation: "https://go-acme.github.io/lego/dns/aliesa",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ALIESA_ACCESS_KEY", Description: "Access key ID"},
+				{Name: "ALIESA_RAM_ROLE", Description: "Your instance RAM role (https://www.alibabacloud.com/help/en/ecs/user-guide/attach-an-instance-ram-role-to-an-ecs-instance)"},
+				{Name: "ALIESA_SECRET_KEY", Description: "Access Key secret"},
+				{Name: "ALIESA_SECURITY_TOKEN", Description: "STS Security Token (optional)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ALIESA_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ALIESA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ALIESA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "ALIESA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "allinkl",
+			Name:          "all-inkl",
+			Since:         "v4.5.0",
+			URL:           "https://all-inkl.com",
+			Documentation: "https://go-acme.github.io/lego/dns/allinkl",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ALL_INKL_LOGIN", Description: "KAS login"},
+				{Name: "ALL_INKL_PASSWORD", Description: "KAS password"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ALL_INKL_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ALL_INKL_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ALL_INKL_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "alwaysdata",
+			Name:          "Alwaysdata",
+			Since:         "v4.31.0",
+			URL:           "https://alwaysdata.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/alwaysdata",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ALWAYSDATA_API_KEY", Description: "API Key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ALWAYSDATA_ACCOUNT", Description: "Account name"},
+				{Name: "ALWAYSDATA_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ALWAYSDATA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ALWAYSDATA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "ALWAYSDATA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "anexia",
+			Name:          "Anexia CloudDNS",
+			Since:         "v4.28.0",
+			URL:           "https://www.anexia-it.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/anexia",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ANEXIA_TOKEN", Description: "API token for Anexia Engine"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ANEXIA_API_URL", Description: "API endpoint URL (default: https://engine.anexia-it.com)", Default: "https://engine.anexia-it.com"},
+				{Name: "ANEXIA_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ANEXIA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ANEXIA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "ANEXIA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "artfiles",
+			Name:          "ArtFiles",
+			Since:         "v4.32.0",
+			URL:           "https://www.artfiles.de/extras/domains/",
+			Documentation: "https://go-acme.github.io/lego/dns/artfiles",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ARTFILES_PASSWORD", Description: "API password"},
+				{Name: "ARTFILES_USERNAME", Description: "API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ARTFILES_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ARTFILES_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ARTFILES_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 360)", Default: "360"},
+				{Name: "ARTFILES_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "arvancloud",
+			Name:          "ArvanCloud",
+			Since:         "v3.8.0",
+			URL:           "https://arvancloud.ir",
+			Documentation: "https://go-acme.github.io/lego/dns/arvancloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ARVANCLOUD_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ARVANCLOUD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ARVANCLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ARVANCLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "ARVANCLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "auroradns",
+			Name:          "Aurora DNS",
+			Since:         "v0.4.0",
+			URL:           "https://www.pcextreme.com/dns-health-checks",
+			Documentation: "https://go-acme.github.io/lego/dns/auroradns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "AURORA_API_KEY", Description: "API key or username to used"},
+				{Name: "AURORA_SECRET", Description: "Secret password to be used"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "AURORA_ENDPOINT", Description: "API endpoint URL"},
+				{Name: "AURORA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "AURORA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "AURORA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "autodns",
+			Name:          "Autodns",
+			Since:         "v3.2.0",
+			URL:           "https://www.internetx.com/domains/autodns/",
+			Documentation: "https://go-acme.github.io/lego/dns/autodns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "AUTODNS_API_PASSWORD", Description: "User Password"},
+				{Name: "AUTODNS_API_USER", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "AUTODNS_CONTEXT", Description: "API context (4 for production, 1 for testing. Defaults to 4)"},
+				{Name: "AUTODNS_ENDPOINT", Description: "API endpoint URL, defaults to https://api.autodns.com/v1/"},
+				{Name: "AUTODNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "AUTODNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "AUTODNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "AUTODNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "axelname",
+			Name:          "Axelname",
+			Since:         "v4.23.0",
+			URL:           "https://axelname.ru",
+			Documentation: "https://go-acme.github.io/lego/dns/axelname",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "AXELNAME_NICKNAME", Description: "Account nickname"},
+				{Name: "AXELNAME_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "AXELNAME_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "AXELNAME_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "AXELNAME_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "AXELNAME_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "azion",
+			Name:          "Azion",
+			Since:         "v4.24.0",
+			URL:           "https://www.azion.com/en/products/edge-dns/",
+			Documentation: "https://go-acme.github.io/lego/dns/azion",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "AZION_PERSONAL_TOKEN", Description: "Your Azion personal token."},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "AZION_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "AZION_PAGE_SIZE", Description: "The page size for the API request (Default: 50)", Default: "50"},
+				{Name: "AZION_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "AZION_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "AZION_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "azure",
+			Name:          "Azure (deprecated)",
+			Since:         "v0.4.0",
+			URL:           "https://azure.microsoft.com/services/dns/",
+			Documentation: "https://go-acme.github.io/lego/dns/azure",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "AZURE_CLIENT_ID", Description: "Client ID"},
+				{Name: "AZURE_CLIENT_SECRET", Description: "Client secret"},
+				{Name: "AZURE_ENVIRONMENT", Description: "Azure environment, one of: public, usgovernment, german, and china"},
+				{Name: "AZURE_RESOURCE_GROUP", Description: "Resource group"},
+				{Name: "AZURE_SUBSCRIPTION_ID", Description: "Subscription ID"},
+				{Name: "AZURE_TENANT_ID", Description: "Tenant ID"},
+				{Name: "instance metadata service", Description: "If the credentials are **not** set via the environment, then it will attempt to get a bearer token via the [instance metadata service](https://docs.microsoft.com/en-us/azure/virtual-machines/windows/instance-metadata-service)."},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "AZURE_METADATA_ENDPOINT", Description: "Metadata Service endpoint URL"},
+				{Name: "AZURE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "AZURE_PRIVATE_ZONE", Description: "Set to true to use Azure Private DNS Zones and not public"},
+				{Name: "AZURE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "AZURE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+				{Name: "AZURE_ZONE_NAME", Description: "Zone name to use inside Azure DNS service to add the TXT record in"},
+			},
+		},
+		{
+			Code:          "azuredns",
+			Name:          "Azure DNS",
+			Since:         "v4.13.0",
+			URL:           "https://azure.microsoft.com/services/dns/",
+			Documentation: "https://go-acme.github.io/lego/dns/azuredns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "AZURE_CLIENT_CERTIFICATE_PATH", Description: "Client certificate path"},
+				{Name: "AZURE_CLIENT_ID", Description: "Client ID"},
+				{Name: "AZURE_CLIENT_SECRET", Description: "Client secret"},
+				{Name: "AZURE_TENANT_ID", Description: "Tenant ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "AZURE_AUTH_METHOD", Description: "Specify which authentication method to use"},
+				{Name: "AZURE_AUTH_MSI_TIMEOUT", Description: "Managed Identity timeout duration"},
+				{Name: "AZURE_ENVIRONMENT", Description: "Azure environment, one of: public, usgovernment, and china"},
+				{Name: "AZURE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "AZURE_PRIVATE_ZONE", Description: "Set to true to use Azure Private DNS Zones and not public"},
+				{Name: "AZURE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "AZURE_RESOURCE_GROUP", Description: "DNS zone resource group"},
+				{Name: "AZURE_SERVICEDISCOVERY_FILTER", Description: "Advanced ServiceDiscovery filter using Kusto query condition"},
+				{Name: "AZURE_SUBSCRIPTION_ID", Description: "DNS zone subscription ID"},
+				{Name: "AZURE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+				{Name: "AZURE_ZONE_NAME", Description: "Zone name to use inside Azure DNS service to add the TXT record in"},
+			},
+		},
+		{
+			Code:          "baiducloud",
+			Name:          "Baidu Cloud",
+			Since:         "v4.23.0",
+			URL:           "https://cloud.baidu.com",
+			Documentation: "https://go-acme.github.io/lego/dns/baiducloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "BAIDUCLOUD_ACCESS_KEY_ID", Description: "Access key"},
+				{Name: "BAIDUCLOUD_SECRET_ACCESS_KEY", Description: "Secret access key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "BAIDUCLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "BAIDUCLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "BAIDUCLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "beget",
+			Name:          "Beget.com",
+			Since:         "v4.27.0",
+			URL:           "https://beget.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/beget",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "BEGET_PASSWORD", Description: "API password"},
+				{Name: "BEGET_USERNAME", Description: "API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "BEGET_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "BEGET_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 30)", Default: "30"},
+				{Name: "BEGET_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "BEGET_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "binarylane",
+			Name:          "Binary Lane",
+			Since:         "v4.26.0",
+			URL:           "https://www.binarylane.com.au/",
+			Documentation: "https://go-acme.github.io/lego/dns/binarylane",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "BINARYLANE_API_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "BINARYLANE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "BINARYLANE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "BINARYLANE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "BINARYLANE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "bindman",
+			Name:          "Bindman",
+			Since:         "v2.6.0",
+			URL:           "https://github.com/labbsr0x/bindman-dns-webhook",
+			Documentation: "https://go-acme.github.io/lego/dns/bindman",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "BINDMAN_MANAGER_ADDRESS", Description: "The server URL, should have scheme, hostname, and port (if required) of the Bindman-DNS Manager server"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "BINDMAN_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 60)", Default: "60"},
+				{Name: "BINDMAN_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "BINDMAN_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "bluecat",
+			Name:          "Bluecat",
+			Since:         "v0.5.0",
+			URL:           "https://www.bluecatnetworks.com",
+			Documentation: "https://go-acme.github.io/lego/dns/bluecat",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "BLUECAT_CONFIG_NAME", Description: "Configuration name"},
+				{Name: "BLUECAT_DNS_VIEW", Description: "External DNS View Name"},
+				{Name: "BLUECAT_PASSWORD", Description: "API password"},
+				{Name: "BLUECAT_SERVER_URL", Description: "The server URL, should have scheme, hostname, and port (if required) of the authoritative Bluecat BAM serve"},
+				{Name: "BLUECAT_USER_NAME", Description: "API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "BLUECAT_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "BLUECAT_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "BLUECAT_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "BLUECAT_SKIP_DEPLOY", Description: "Skip deployements"},
+				{Name: "BLUECAT_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "bluecatv2",
+			Name:          "Bluecat v2",
+			Since:         "v4.32.0",
+			URL:           "https://www.bluecatnetworks.com",
+			Documentation: "https://go-acme.github.io/lego/dns/bluecatv2",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "BLUECATV2_CONFIG_NAME", Description: "Configuration name"},
+				{Name: "BLUECATV2_PASSWORD", Description: "API password"},
+				{Name: "BLUECATV2_SERVER_URL", Description: "The server URL: it should have a scheme, hostname, and port (if required) of the authoritative Bluecat BAM serve"},
+				{Name: "BLUECATV2_USERNAME", Description: "API username"},
+				{Name: "BLUECATV2_VIEW_NAME", Description: "DNS View Name"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "BLUECATV2_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "BLUECATV2_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "BLUECATV2_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "BLUECATV2_SKIP_DEPLOY", Description: "Skip quick deployements"},
+				{Name: "BLUECATV2_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "bookmyname",
+			Name:          "BookMyName",
+			Since:         "v4.23.0",
+			URL:           "https://www.bookmyname.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/bookmyname",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "BOOKMYNAME_PASSWORD", Description: "Password"},
+				{Name: "BOOKMYNAME_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "BOOKMYNAME_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "BOOKMYNAME_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "BOOKMYNAME_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "BOOKMYNAME_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "brandit",
+			Name:          "Brandit (deprecated)",
+			Since:         "v4.11.0",
+			URL:           "https://www.brandit.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/brandit",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "BRANDIT_API_KEY", Description: "The API key"},
+				{Name: "BRANDIT_API_USERNAME", Description: "The API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "BRANDIT_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "BRANDIT_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "BRANDIT_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 600)", Default: "600"},
+				{Name: "BRANDIT_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "bunny",
+			Name:          "Bunny",
+			Since:         "v4.11.0",
+			URL:           "https://bunny.net",
+			Documentation: "https://go-acme.github.io/lego/dns/bunny",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "BUNNY_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "BUNNY_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "BUNNY_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "BUNNY_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "BUNNY_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "checkdomain",
+			Name:          "Checkdomain",
+			Since:         "v3.3.0",
+			URL:           "https://checkdomain.de/",
+			Documentation: "https://go-acme.github.io/lego/dns/checkdomain",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CHECKDOMAIN_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CHECKDOMAIN_ENDPOINT", Description: "API endpoint URL, defaults to https://api.checkdomain.de"},
+				{Name: "CHECKDOMAIN_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "CHECKDOMAIN_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 300)", Default: "300"},
+				{Name: "CHECKDOMAIN_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 7)", Default: "7"},
+				{Name: "CHECKDOMAIN_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "civo",
+			Name:          "Civo",
+			Since:         "v4.9.0",
+			URL:           "https://civo.com",
+			Documentation: "https://go-acme.github.io/lego/dns/civo",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CIVO_TOKEN", Description: "Authentication token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CIVO_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 30)", Default: "30"},
+				{Name: "CIVO_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "CIVO_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "clouddns",
+			Name:          "CloudDNS",
+			Since:         "v3.6.0",
+			URL:           "https://vshosting.eu/",
+			Documentation: "https://go-acme.github.io/lego/dns/clouddns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CLOUDDNS_CLIENT_ID", Description: "Client ID"},
+				{Name: "CLOUDDNS_EMAIL", Description: "Account email"},
+				{Name: "CLOUDDNS_PASSWORD", Description: "Account password"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CLOUDDNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "CLOUDDNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "CLOUDDNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "CLOUDDNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "cloudflare",
+			Name:          "Cloudflare",
+			Since:         "v0.3.0",
+			URL:           "https://www.cloudflare.com/dns/",
+			Documentation: "https://go-acme.github.io/lego/dns/cloudflare",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CF_API_EMAIL", Description: "Account email"},
+				{Name: "CF_API_KEY", Description: "API key"},
+				{Name: "CF_DNS_API_TOKEN", Description: "API token with DNS:Edit permission (since v3.1.0)"},
+				{Name: "CF_ZONE_API_TOKEN", Description: "API token with Zone:Read permission (since v3.1.0)"},
+				{Name: "CLOUDFLARE_API_KEY", Description: "Alias to CF_API_KEY"},
+				{Name: "CLOUDFLARE_DNS_API_TOKEN", Description: "Alias to CF_DNS_API_TOKEN"},
+				{Name: "CLOUDFLARE_EMAIL", Description: "Alias to CF_API_EMAIL"},
+				{Name: "CLOUDFLARE_ZONE_API_TOKEN", Description: "Alias to CF_ZONE_API_TOKEN"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CLOUDFLARE_BASE_URL", Description: "API base URL (Default: https://api.cloudflare.com/client/v4)", Default: "https://api.cloudflare.com/client/v4"},
+				{Name: "CLOUDFLARE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: )"},
+				{Name: "CLOUDFLARE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "CLOUDFLARE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "CLOUDFLARE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "cloudns",
+			Name:          "ClouDNS",
+			Since:         "v2.3.0",
+			URL:           "https://www.cloudns.net",
+			Documentation: "https://go-acme.github.io/lego/dns/cloudns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CLOUDNS_AUTH_ID", Description: "The API user ID"},
+				{Name: "CLOUDNS_AUTH_PASSWORD", Description: "The password for API user ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CLOUDNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "CLOUDNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "CLOUDNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 180)", Default: "180"},
+				{Name: "CLOUDNS_SUB_AUTH_ID", Description: "The API sub user ID"},
+				{Name: "CLOUDNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "cloudru",
+			Name:          "Cloud.ru",
+			Since:         "v4.14.0",
+			URL:           "https://cloud.ru",
+			Documentation: "https://go-acme.github.io/lego/dns/cloudru",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CLOUDRU_KEY_ID", Description: "Key ID (login)"},
+				{Name: "CLOUDRU_SECRET", Description: "Key Secret"},
+				{Name: "CLOUDRU_SERVICE_INSTANCE_ID", Description: "Service Instance ID (parentId)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CLOUDRU_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "CLOUDRU_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "CLOUDRU_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "CLOUDRU_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 120)", Default: "120"},
+				{Name: "CLOUDRU_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "cloudxns",
+			Name:          "CloudXNS (Deprecated)",
+			Since:         "v0.5.0",
+			URL:           "https://github.com/go-acme/lego/issues/2323",
+			Documentation: "https://go-acme.github.io/lego/dns/cloudxns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CLOUDXNS_API_KEY", Description: "The API key"},
+				{Name: "CLOUDXNS_SECRET_KEY", Description: "The API secret key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CLOUDXNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: )"},
+				{Name: "CLOUDXNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: )"},
+				{Name: "CLOUDXNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: )"},
+				{Name: "CLOUDXNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: )"},
+			},
+		},
+		{
+			Code:          "com35",
+			Name:          "35.com/三五互联",
+			Since:         "v4.31.0",
+			URL:           "https://www.35.cn/",
+			Documentation: "https://go-acme.github.io/lego/dns/com35",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "COM35_PASSWORD", Description: "API password"},
+				{Name: "COM35_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "COM35_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "COM35_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "COM35_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "COM35_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "conoha",
+			Name:          "ConoHa v2",
+			Since:         "v1.2.0",
+			URL:           "https://www.conoha.jp/",
+			Documentation: "https://go-acme.github.io/lego/dns/conoha",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CONOHA_API_PASSWORD", Description: "The API password"},
+				{Name: "CONOHA_API_USERNAME", Description: "The API username"},
+				{Name: "CONOHA_TENANT_ID", Description: "Tenant ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CONOHA_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "CONOHA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "CONOHA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "CONOHA_REGION", Description: "The region (Default: tyo1)", Default: "tyo1"},
+				{Name: "CONOHA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "conohav3",
+			Name:          "ConoHa v3",
+			Since:         "v4.24.0",
+			URL:           "https://www.conoha.jp/",
+			Documentation: "https://go-acme.github.io/lego/dns/conohav3",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CONOHAV3_API_PASSWORD", Description: "The API password"},
+				{Name: "CONOHAV3_API_USER_ID", Description: "The API user ID"},
+				{Name: "CONOHAV3_TENANT_ID", Description: "Tenant ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CONOHAV3_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "CONOHAV3_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "CONOHAV3_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "CONOHAV3_REGION", Description: "The region (Default: c3j1)", Default: "c3j1"},
+				{Name: "CONOHAV3_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "constellix",
+			Name:          "Constellix",
+			Since:         "v3.4.0",
+			URL:           "https://constellix.com",
+			Documentation: "https://go-acme.github.io/lego/dns/constellix",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CONSTELLIX_API_KEY", Description: "User API key"},
+				{Name: "CONSTELLIX_SECRET_KEY", Description: "User secret key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CONSTELLIX_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "CONSTELLIX_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "CONSTELLIX_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "CONSTELLIX_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "corenetworks",
+			Name:          "Core-Networks",
+			Since:         "v4.20.0",
+			URL:           "https://www.core-networks.de/",
+			Documentation: "https://go-acme.github.io/lego/dns/corenetworks",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CORENETWORKS_LOGIN", Description: "The username of the API account"},
+				{Name: "CORENETWORKS_PASSWORD", Description: "The password"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CORENETWORKS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "CORENETWORKS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "CORENETWORKS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "CORENETWORKS_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "CORENETWORKS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "cpanel",
+			Name:          "CPanel/WHM",
+			Since:         "v4.16.0",
+			URL:           "https://cpanel.net/",
+			Documentation: "https://go-acme.github.io/lego/dns/cpanel",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CPANEL_BASE_URL", Description: "API server URL"},
+				{Name: "CPANEL_TOKEN", Description: "API token"},
+				{Name: "CPANEL_USERNAME", Description: "username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CPANEL_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "CPANEL_MODE", Description: "use cpanel API or WHM API (Default: cpanel)", Default: "cpanel"},
+				{Name: "CPANEL_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "CPANEL_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "CPANEL_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "czechia",
+			Name:          "Czechia",
+			Since:         "v4.33.0",
+			URL:           "https://www.czechia.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/czechia",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "CZECHIA_TOKEN", Description: "Authorization token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "CZECHIA_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "CZECHIA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "CZECHIA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "CZECHIA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "ddnss",
+			Name:          "DDnss (DynDNS Service)",
+			Since:         "v4.32.0",
+			URL:           "https://ddnss.de/",
+			Documentation: "https://go-acme.github.io/lego/dns/ddnss",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DDNSS_KEY", Description: "Update key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DDNSS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DDNSS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "DDNSS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "DDNSS_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "DDNSS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "derak",
+			Name:          "Derak Cloud",
+			Since:         "v4.12.0",
+			URL:           "https://derak.cloud/",
+			Documentation: "https://go-acme.github.io/lego/dns/derak",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DERAK_API_KEY", Description: "The API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DERAK_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DERAK_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "DERAK_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "DERAK_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+				{Name: "DERAK_WEBSITE_ID", Description: "Force the zone/website ID"},
+			},
+		},
+		{
+			Code:          "desec",
+			Name:          "deSEC.io",
+			Since:         "v3.7.0",
+			URL:           "https://desec.io",
+			Documentation: "https://go-acme.github.io/lego/dns/desec",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DESEC_TOKEN", Description: "Domain token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DESEC_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DESEC_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 4)", Default: "4"},
+				{Name: "DESEC_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "DESEC_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "designate",
+			Name:          "Designate DNSaaS for Openstack",
+			Since:         "v2.2.0",
+			URL:           "https://docs.openstack.org/designate/latest/",
+			Documentation: "https://go-acme.github.io/lego/dns/designate",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "OS_APPLICATION_CREDENTIAL_ID", Description: "Application credential ID"},
+				{Name: "OS_APPLICATION_CREDENTIAL_NAME", Description: "Application credential name"},
+				{Name: "OS_APPLICATION_CREDENTIAL_SECRET", Description: "Application credential secret"},
+				{Name: "OS_AUTH_URL", Description: "Identity endpoint URL"},
+				{Name: "OS_PASSWORD", Description: "Password"},
+				{Name: "OS_PROJECT_NAME", Description: "Project name"},
+				{Name: "OS_REGION_NAME", Description: "Region name"},
+				{Name: "OS_USERNAME", Description: "Username"},
+				{Name: "OS_USER_ID", Description: "User ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DESIGNATE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "DESIGNATE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 600)", Default: "600"},
+				{Name: "DESIGNATE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 10)", Default: "10"},
+				{Name: "DESIGNATE_ZONE_NAME", Description: "The zone name to use in the OpenStack Project to manage TXT records."},
+				{Name: "OS_PROJECT_ID", Description: "Project ID"},
+				{Name: "OS_TENANT_NAME", Description: "Tenant name (deprecated see OS_PROJECT_NAME and OS_PROJECT_ID)"},
+			},
+		},
+		{
+			Code:          "digitalocean",
+			Name:          "Digital Ocean",
+			Since:         "v0.3.0",
+			URL:           "https://www.digitalocean.com/docs/networking/dns/",
+			Documentation: "https://go-acme.github.io/lego/dns/digitalocean",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DO_AUTH_TOKEN", Description: "Authentication token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DO_API_URL", Description: "The URL of the API"},
+				{Name: "DO_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DO_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "DO_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "DO_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 30)", Default: "30"},
+			},
+		},
+		{
+			Code:          "directadmin",
+			Name:          "DirectAdmin",
+			Since:         "v4.18.0",
+			URL:           "https://www.directadmin.com",
+			Documentation: "https://go-acme.github.io/lego/dns/directadmin",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DIRECTADMIN_API_URL", Description: "URL of the API"},
+				{Name: "DIRECTADMIN_PASSWORD", Description: "API password"},
+				{Name: "DIRECTADMIN_USERNAME", Description: "API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DIRECTADMIN_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DIRECTADMIN_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "DIRECTADMIN_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "DIRECTADMIN_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 30)", Default: "30"},
+				{Name: "DIRECTADMIN_ZONE_NAME", Description: "Zone name used to add the TXT record"},
+			},
+		},
+		{
+			Code:          "dnsexit",
+			Name:          "DNSExit",
+			Since:         "v4.32.0",
+			URL:           "https://dnsexit.com",
+			Documentation: "https://go-acme.github.io/lego/dns/dnsexit",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DNSEXIT_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DNSEXIT_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DNSEXIT_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "DNSEXIT_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "DNSEXIT_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "dnshomede",
+			Name:          "dnsHome.de",
+			Since:         "v4.10.0",
+			URL:           "https://www.dnshome.de",
+			Documentation: "https://go-acme.github.io/lego/dns/dnshomede",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DNSHOMEDE_CREDENTIALS", Description: "Comma-separated list of domain:password credential pairs"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DNSHOMEDE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DNSHOMEDE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 1200)", Default: "1200"},
+				{Name: "DNSHOMEDE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 2)", Default: "2"},
+				{Name: "DNSHOMEDE_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "dnsimple",
+			Name:          "DNSimple",
+			Since:         "v0.3.0",
+			URL:           "https://dnsimple.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/dnsimple",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DNSIMPLE_OAUTH_TOKEN", Description: "OAuth token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DNSIMPLE_BASE_URL", Description: "API endpoint URL"},
+				{Name: "DNSIMPLE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "DNSIMPLE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "DNSIMPLE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "dnsmadeeasy",
+			Name:          "DNS Made Easy",
+			Since:         "v0.4.0",
+			URL:           "https://dnsmadeeasy.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/dnsmadeeasy",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DNSMADEEASY_API_KEY", Description: "The API key"},
+				{Name: "DNSMADEEASY_API_SECRET", Description: "The API Secret key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DNSMADEEASY_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "DNSMADEEASY_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "DNSMADEEASY_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "DNSMADEEASY_SANDBOX", Description: "Activate the sandbox (boolean)"},
+				{Name: "DNSMADEEASY_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "dnspod",
+			Name:          "DNSPod (deprecated)",
+			Since:         "v0.4.0",
+			URL:           "https://www.dnspod.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/dnspod",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DNSPOD_API_KEY", Description: "The user token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DNSPOD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DNSPOD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "DNSPOD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "DNSPOD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "dode",
+			Name:          "Domain Offensive (do.de)",
+			Since:         "v2.4.0",
+			URL:           "https://www.do.de/",
+			Documentation: "https://go-acme.github.io/lego/dns/dode",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DODE_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DODE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DODE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "DODE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "DODE_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "domeneshop",
+			Name:          "Domeneshop",
+			Since:         "v4.3.0",
+			URL:           "https://domene.shop",
+			Documentation: "https://go-acme.github.io/lego/dns/domeneshop",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DOMENESHOP_API_SECRET", Description: "API secret"},
+				{Name: "DOMENESHOP_API_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DOMENESHOP_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DOMENESHOP_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 20)", Default: "20"},
+				{Name: "DOMENESHOP_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "dreamhost",
+			Name:          "DreamHost",
+			Since:         "v1.1.0",
+			URL:           "https://www.dreamhost.com",
+			Documentation: "https://go-acme.github.io/lego/dns/dreamhost",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DREAMHOST_API_KEY", Description: "The API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DREAMHOST_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DREAMHOST_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 60)", Default: "60"},
+				{Name: "DREAMHOST_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "duckdns",
+			Name:          "Duck DNS",
+			Since:         "v0.5.0",
+			URL:           "https://www.duckdns.org/",
+			Documentation: "https://go-acme.github.io/lego/dns/duckdns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DUCKDNS_TOKEN", Description: "Account token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DUCKDNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DUCKDNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "DUCKDNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "DUCKDNS_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "dyn",
+			Name:          "Dyn",
+			Since:         "v0.3.0",
+			URL:           "https://dyn.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/dyn",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DYN_CUSTOMER_NAME", Description: "Customer name"},
+				{Name: "DYN_PASSWORD", Description: "Password"},
+				{Name: "DYN_USER_NAME", Description: "User name"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DYN_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "DYN_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "DYN_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "DYN_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "dyndnsfree",
+			Name:          "DynDnsFree.de",
+			Since:         "v4.23.0",
+			URL:           "https://www.dyndnsfree.de",
+			Documentation: "https://go-acme.github.io/lego/dns/dyndnsfree",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DYNDNSFREE_PASSWORD", Description: "Password"},
+				{Name: "DYNDNSFREE_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DYNDNSFREE_HTTP_TIMEOUT", Description: "Request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DYNDNSFREE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "DYNDNSFREE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "dynu",
+			Name:          "Dynu",
+			Since:         "v3.5.0",
+			URL:           "https://www.dynu.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/dynu",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DYNU_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DYNU_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "DYNU_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "DYNU_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 180)", Default: "180"},
+				{Name: "DYNU_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "easydns",
+			Name:          "EasyDNS",
+			Since:         "v2.6.0",
+			URL:           "https://easydns.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/easydns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "EASYDNS_KEY", Description: "API Key"},
+				{Name: "EASYDNS_TOKEN", Description: "API Token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "EASYDNS_ENDPOINT", Description: "The endpoint URL of the API Server"},
+				{Name: "EASYDNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "EASYDNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "EASYDNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "EASYDNS_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "EASYDNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "edgecenter",
+			Name:          "EdgeCenter",
+			Since:         "v4.29.0",
+			URL:           "https://edgecenter.ru/dns",
+			Documentation: "https://go-acme.github.io/lego/dns/edgecenter",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "EDGECENTER_PERMANENT_API_TOKEN", Description: "Permanent API token (https://edgecenter.ru/blog/permanent-api-token-explained/)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "EDGECENTER_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "EDGECENTER_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 20)", Default: "20"},
+				{Name: "EDGECENTER_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 360)", Default: "360"},
+				{Name: "EDGECENTER_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "edgedns",
+			Name:          "Akamai EdgeDNS",
+			Since:         "v3.9.0",
+			URL:           "https://www.akamai.com/us/en/products/security/edge-dns.jsp",
+			Documentation: "https://go-acme.github.io/lego/dns/edgedns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "AKAMAI_ACCESS_TOKEN", Description: "Access token, managed by the Akamai EdgeGrid client"},
+				{Name: "AKAMAI_CLIENT_SECRET", Description: "Client secret, managed by the Akamai EdgeGrid client"},
+				{Name: "AKAMAI_CLIENT_TOKEN", Description: "Client token, managed by the Akamai EdgeGrid client"},
+				{Name: "AKAMAI_EDGERC", Description: "Path to the .edgerc file, managed by the Akamai EdgeGrid client"},
+				{Name: "AKAMAI_EDGERC_SECTION", Description: "Configuration section, managed by the Akamai EdgeGrid client"},
+				{Name: "AKAMAI_HOST", Description: "API host, managed by the Akamai EdgeGrid client"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "AKAMAI_ACCOUNT_SWITCH_KEY", Description: "Target account ID when the DNS zone and credentials belong to different accounts"},
+				{Name: "AKAMAI_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 15)", Default: "15"},
+				{Name: "AKAMAI_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 180)", Default: "180"},
+				{Name: "AKAMAI_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "edgeone",
+			Name:          "Tencent EdgeOne",
+			Since:         "v4.26.0",
+			URL:           "https://edgeone.ai",
+			Documentation: "https://go-acme.github.io/lego/dns/edgeone",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "EDGEONE_SECRET_ID", Description: "Access key ID"},
+				{Name: "EDGEONE_SECRET_KEY", Description: "Access Key secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "EDGEONE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "EDGEONE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 30)", Default: "30"},
+				{Name: "EDGEONE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 1200)", Default: "1200"},
+				{Name: "EDGEONE_REGION", Description: "Region"},
+				{Name: "EDGEONE_SESSION_TOKEN", Description: "Access Key token"},
+				{Name: "EDGEONE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+				{Name: "EDGEONE_ZONES_MAPPING", Description: "Mapping between DNS zones and site IDs. (ex: 'example.org:id1,example.com:id2')"},
+			},
+		},
+		{
+			Code:          "efficientip",
+			Name:          "Efficient IP",
+			Since:         "v4.13.0",
+			URL:           "https://efficientip.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/efficientip",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "EFFICIENTIP_DNS_NAME", Description: "DNS name (ex: dns.smart)"},
+				{Name: "EFFICIENTIP_HOSTNAME", Description: "Hostname (ex: foo.example.com)"},
+				{Name: "EFFICIENTIP_PASSWORD", Description: "Password"},
+				{Name: "EFFICIENTIP_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "EFFICIENTIP_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "EFFICIENTIP_INSECURE_SKIP_VERIFY", Description: "Whether or not to verify EfficientIP API certificate"},
+				{Name: "EFFICIENTIP_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "EFFICIENTIP_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "EFFICIENTIP_VIEW_NAME", Description: "View name (ex: external)"},
+			},
+		},
+		{
+			Code:          "epik",
+			Name:          "Epik",
+			Since:         "v4.5.0",
+			URL:           "https://www.epik.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/epik",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "EPIK_SIGNATURE", Description: "Epik API signature (https://registrar.epik.com/account/api-settings/)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "EPIK_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "EPIK_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "EPIK_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "EPIK_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "eurodns",
+			Name:          "EuroDNS",
+			Since:         "v4.33.0",
+			URL:           "https://www.eurodns.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/eurodns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "EURODNS_API_KEY", Description: "API key"},
+				{Name: "EURODNS_APP_ID", Description: "Application ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "EURODNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "EURODNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "EURODNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "EURODNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "excedo",
+			Name:          "Excedo",
+			Since:         "v4.33.0",
+			URL:           "https://excedo.se/",
+			Documentation: "https://go-acme.github.io/lego/dns/excedo",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "EXCEDO_API_KEY", Description: "API key"},
+				{Name: "EXCEDO_API_URL", Description: "API base URL"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "EXCEDO_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "EXCEDO_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "EXCEDO_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "EXCEDO_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "exec",
+			Name:          "External program",
+			Since:         "v0.5.0",
+			URL:           "/dns/exec",
+			Documentation: "https://go-acme.github.io/lego/dns/exec",
+		},
+		{
+			Code:          "exoscale",
+			Name:          "Exoscale",
+			Since:         "v0.4.0",
+			URL:           "https://www.exoscale.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/exoscale",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "EXOSCALE_API_KEY", Description: "API key"},
+				{Name: "EXOSCALE_API_SECRET", Description: "API secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "EXOSCALE_ENDPOINT", Description: "API endpoint URL"},
+				{Name: "EXOSCALE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 60)", Default: "60"},
+				{Name: "EXOSCALE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "EXOSCALE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "EXOSCALE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "f5xc",
+			Name:          "F5 XC",
+			Since:         "v4.23.0",
+			URL:           "https://www.f5.com/products/distributed-cloud-services",
+			Documentation: "https://go-acme.github.io/lego/dns/f5xc",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "F5XC_API_TOKEN", Description: "API token"},
+				{Name: "F5XC_GROUP_NAME", Description: "Group name"},
+				{Name: "F5XC_TENANT_NAME", Description: "XC Tenant shortname"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "F5XC_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "F5XC_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "F5XC_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "F5XC_SERVER", Description: "Server domain (Default: console.ves.volterra.io)", Default: "console.ves.volterra.io"},
+				{Name: "F5XC_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "freemyip",
+			Name:          "freemyip.com",
+			Since:         "v4.5.0",
+			URL:           "https://freemyip.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/freemyip",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "FREEMYIP_TOKEN", Description: "Account token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "FREEMYIP_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "FREEMYIP_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "FREEMYIP_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "FREEMYIP_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "FREEMYIP_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "gandi",
+			Name:          "Gandi",
+			Since:         "v0.3.0",
+			URL:           "https://www.gandi.net",
+			Documentation: "https://go-acme.github.io/lego/dns/gandi",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "GANDI_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "GANDI_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 60)", Default: "60"},
+				{Name: "GANDI_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 60)", Default: "60"},
+				{Name: "GANDI_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 2400)", Default: "2400"},
+				{Name: "GANDI_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "gandiv5",
+			Name:          "Gandi Live DNS (v5)",
+			Since:         "v0.5.0",
+			URL:           "https://www.gandi.net",
+			Documentation: "https://go-acme.github.io/lego/dns/gandiv5",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "GANDIV5_API_KEY", Description: "API key (Deprecated)"},
+				{Name: "GANDIV5_PERSONAL_ACCESS_TOKEN", Description: "Personal Access Token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "GANDIV5_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "GANDIV5_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 20)", Default: "20"},
+				{Name: "GANDIV5_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 1200)", Default: "1200"},
+				{Name: "GANDIV5_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "gcloud",
+			Name:          "Google Cloud",
+			Since:         "v0.3.0",
+			URL:           "https://cloud.google.com",
+			Documentation: "https://go-acme.github.io/lego/dns/gcloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "Application Default Credentials", Description: "[Documentation](https://cloud.google.com/docs/authentication/production#providing_credentials_to_your_application)"},
+				{Name: "GCE_PROJECT", Description: "Project name (by default, the project name is auto-detected by using the metadata service)"},
+				{Name: "GCE_SERVICE_ACCOUNT", Description: "Account"},
+				{Name: "GCE_SERVICE_ACCOUNT_FILE", Description: "Account file path"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "GCE_ALLOW_PRIVATE_ZONE", Description: "Allows requested domain to be in private DNS zone, works only with a private ACME server (by default: false)"},
+				{Name: "GCE_IMPERSONATE_SERVICE_ACCOUNT", Description: "Service account email to impersonate"},
+				{Name: "GCE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "GCE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 180)", Default: "180"},
+				{Name: "GCE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+				{Name: "GCE_ZONE_ID", Description: "Allows to skip the automatic detection of the zone"},
+			},
+		},
+		{
+			Code:          "gcore",
+			Name:          "G-Core",
+			Since:         "v4.5.0",
+			URL:           "https://gcore.com/dns/",
+			Documentation: "https://go-acme.github.io/lego/dns/gcore",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "GCORE_PERMANENT_API_TOKEN", Description: "Permanent API token (https://gcore.com/blog/permanent-api-token-explained/)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "GCORE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "GCORE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 20)", Default: "20"},
+				{Name: "GCORE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 360)", Default: "360"},
+				{Name: "GCORE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "gigahostno",
+			Name:          "Gigahost.no",
+			Since:         "v4.29.0",
+			URL:           "https://gigahost.no/",
+			Documentation: "https://go-acme.github.io/lego/dns/gigahostno",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "GIGAHOSTNO_PASSWORD", Description: "Password"},
+				{Name: "GIGAHOSTNO_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "GIGAHOSTNO_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "GIGAHOSTNO_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "GIGAHOSTNO_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "GIGAHOSTNO_SECRET", Description: "TOTP secret"},
+				{Name: "GIGAHOSTNO_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "glesys",
+			Name:          "Glesys",
+			Since:         "v0.5.0",
+			URL:           "https://glesys.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/glesys",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "GLESYS_API_KEY", Description: "API key"},
+				{Name: "GLESYS_API_USER", Description: "API user"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "GLESYS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "GLESYS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 20)", Default: "20"},
+				{Name: "GLESYS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 1200)", Default: "1200"},
+				{Name: "GLESYS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "godaddy",
+			Name:          "Go Daddy",
+			Since:         "v0.5.0",
+			URL:           "https://godaddy.com",
+			Documentation: "https://go-acme.github.io/lego/dns/godaddy",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "GODADDY_API_KEY", Description: "API key"},
+				{Name: "GODADDY_API_SECRET", Description: "API secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "GODADDY_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "GODADDY_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "GODADDY_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "GODADDY_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "googledomains",
+			Name:          "Google Domains",
+			Since:         "v4.11.0",
+			URL:           "https://github.com/go-acme/lego/issues/2553",
+			Documentation: "https://go-acme.github.io/lego/dns/googledomains",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "GOOGLE_DOMAINS_ACCESS_TOKEN", Description: "Access token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "GOOGLE_DOMAINS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "GOOGLE_DOMAINS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "GOOGLE_DOMAINS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "gravity",
+			Name:          "Gravity",
+			Since:         "v4.30.0",
+			URL:           "https://gravity.beryju.io/",
+			Documentation: "https://go-acme.github.io/lego/dns/gravity",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "GRAVITY_PASSWORD", Description: "Password"},
+				{Name: "GRAVITY_SERVER_URL", Description: "URL of the server"},
+				{Name: "GRAVITY_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "GRAVITY_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "GRAVITY_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "GRAVITY_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "GRAVITY_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 1)", Default: "1"},
+			},
+		},
+		{
+			Code:          "hetzner",
+			Name:          "Hetzner",
+			Since:         "v3.7.0",
+			URL:           "https://hetzner.com",
+			Documentation: "https://go-acme.github.io/lego/dns/hetzner",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "HETZNER_API_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "HETZNER_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "HETZNER_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "HETZNER_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "HETZNER_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "hostingde",
+			Name:          "Hosting.de",
+			Since:         "v1.1.0",
+			URL:           "https://www.hosting.de/",
+			Documentation: "https://go-acme.github.io/lego/dns/hostingde",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "HOSTINGDE_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "HOSTINGDE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "HOSTINGDE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "HOSTINGDE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "HOSTINGDE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+				{Name: "HOSTINGDE_ZONE_NAME", Description: "Zone name in ACE format"},
+			},
+		},
+		{
+			Code:          "hostinger",
+			Name:          "Hostinger",
+			Since:         "v4.27.0",
+			URL:           "https://www.hostinger.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/hostinger",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "HOSTINGER_API_TOKEN", Description: "API Token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "HOSTINGER_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "HOSTINGER_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "HOSTINGER_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "HOSTINGER_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "hostingnl",
+			Name:          "Hosting.nl",
+			Since:         "v4.30.0",
+			URL:           "https://hosting.nl",
+			Documentation: "https://go-acme.github.io/lego/dns/hostingnl",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "HOSTINGNL_API_KEY", Description: "The API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "HOSTINGNL_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "HOSTINGNL_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "HOSTINGNL_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "HOSTINGNL_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "hosttech",
+			Name:          "Hosttech",
+			Since:         "v4.5.0",
+			URL:           "https://www.hosttech.eu/",
+			Documentation: "https://go-acme.github.io/lego/dns/hosttech",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "HOSTTECH_API_KEY", Description: "API login"},
+				{Name: "HOSTTECH_PASSWORD", Description: "API password"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "HOSTTECH_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "HOSTTECH_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "HOSTTECH_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "HOSTTECH_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "httpnet",
+			Name:          "http.net",
+			Since:         "v4.15.0",
+			URL:           "https://www.http.net/",
+			Documentation: "https://go-acme.github.io/lego/dns/httpnet",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "HTTPNET_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "HTTPNET_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "HTTPNET_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "HTTPNET_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "HTTPNET_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+				{Name: "HTTPNET_ZONE_NAME", Description: "Zone name in ACE format"},
+			},
+		},
+		{
+			Code:          "httpreq",
+			Name:          "HTTP request",
+			Since:         "v2.0.0",
+			URL:           "/lego/dns/httpreq/",
+			Documentation: "https://go-acme.github.io/lego/dns/httpreq",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "HTTPREQ_ENDPOINT", Description: "The URL of the server"},
+				{Name: "HTTPREQ_MODE", Description: "`RAW`, none"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "HTTPREQ_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "HTTPREQ_PASSWORD", Description: "Basic authentication password"},
+				{Name: "HTTPREQ_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "HTTPREQ_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "HTTPREQ_USERNAME", Description: "Basic authentication username"},
+			},
+		},
+		{
+			Code:          "huaweicloud",
+			Name:          "Huawei Cloud",
+			Since:         "v4.19",
+			URL:           "https://huaweicloud.com",
+			Documentation: "https://go-acme.github.io/lego/dns/huaweicloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "HUAWEICLOUD_ACCESS_KEY_ID", Description: "Access key ID"},
+				{Name: "HUAWEICLOUD_REGION", Description: "Region"},
+				{Name: "HUAWEICLOUD_SECRET_ACCESS_KEY", Description: "Access Key secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "HUAWEICLOUD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "HUAWEICLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "HUAWEICLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "HUAWEICLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "hurricane",
+			Name:          "Hurricane Electric DNS",
+			Since:         "v4.3.0",
+			URL:           "https://dns.he.net/",
+			Documentation: "https://go-acme.github.io/lego/dns/hurricane",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "HURRICANE_TOKENS", Description: "TXT record names and tokens"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "HURRICANE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "HURRICANE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "HURRICANE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation (Default: 300)", Default: "300"},
+				{Name: "HURRICANE_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "hyperone",
+			Name:          "HyperOne",
+			Since:         "v3.9.0",
+			URL:           "https://www.hyperone.com",
+			Documentation: "https://go-acme.github.io/lego/dns/hyperone",
+			Additional: []dnsProviderEnvVar{
+				{Name: "HYPERONE_API_URL", Description: "Allows to pass custom API Endpoint to be used in the challenge (default https://api.hyperone.com/v2)"},
+				{Name: "HYPERONE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "HYPERONE_LOCATION_ID", Description: "Specifies location (region) to be used in API calls. (default pl-waw-1)"},
+				{Name: "HYPERONE_PASSPORT_LOCATION", Description: "Allows to pass custom passport file location (default ~/.h1/passport.json)"},
+				{Name: "HYPERONE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 60)", Default: "60"},
+				{Name: "HYPERONE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 2)", Default: "2"},
+				{Name: "HYPERONE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "ibmcloud",
+			Name:          "IBM Cloud (SoftLayer)",
+			Since:         "v4.5.0",
+			URL:           "https://www.ibm.com/cloud/",
+			Documentation: "https://go-acme.github.io/lego/dns/ibmcloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SOFTLAYER_API_KEY", Description: "Classic Infrastructure API key"},
+				{Name: "SOFTLAYER_USERNAME", Description: "Username (IBM Cloud is {accountID}_{emailAddress})"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SOFTLAYER_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "SOFTLAYER_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "SOFTLAYER_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SOFTLAYER_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "iij",
+			Name:          "Internet Initiative Japan",
+			Since:         "v1.1.0",
+			URL:           "https://www.iij.ad.jp/en/",
+			Documentation: "https://go-acme.github.io/lego/dns/iij",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "IIJ_API_ACCESS_KEY", Description: "API access key"},
+				{Name: "IIJ_API_SECRET_KEY", Description: "API secret key"},
+				{Name: "IIJ_DO_SERVICE_CODE", Description: "DO service code"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "IIJ_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 4)", Default: "4"},
+				{Name: "IIJ_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 240)", Default: "240"},
+				{Name: "IIJ_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "iijdpf",
+			Name:          "IIJ DNS Platform Service",
+			Since:         "v4.7.0",
+			URL:           "https://www.iij.ad.jp/en/biz/dns-pfm/",
+			Documentation: "https://go-acme.github.io/lego/dns/iijdpf",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "IIJ_DPF_API_TOKEN", Description: "API token"},
+				{Name: "IIJ_DPF_DPM_SERVICE_CODE", Description: "IIJ Managed DNS Service's service code"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "IIJ_DPF_API_ENDPOINT", Description: "API endpoint URL, defaults to https://api.dns-platform.jp/dpf/v1"},
+				{Name: "IIJ_DPF_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "IIJ_DPF_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 660)", Default: "660"},
+				{Name: "IIJ_DPF_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "infoblox",
+			Name:          "Infoblox",
+			Since:         "v4.4.0",
+			URL:           "https://www.infoblox.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/infoblox",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "INFOBLOX_HOST", Description: "Host URI"},
+				{Name: "INFOBLOX_PASSWORD", Description: "Account Password"},
+				{Name: "INFOBLOX_USERNAME", Description: "Account Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "INFOBLOX_CA_CERTIFICATE", Description: "The path to the CA certificate (PEM encoded)"},
+				{Name: "INFOBLOX_DNS_VIEW", Description: "The view for the TXT records (Default: External)", Default: "External"},
+				{Name: "INFOBLOX_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "INFOBLOX_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "INFOBLOX_PORT", Description: "The port for the infoblox grid manager  (Default: 443)", Default: "443"},
+				{Name: "INFOBLOX_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "INFOBLOX_SSL_VERIFY", Description: "Whether or not to verify the TLS certificate  (Default: true)", Default: "true"},
+				{Name: "INFOBLOX_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+				{Name: "INFOBLOX_WAPI_VERSION", Description: "The version of WAPI being used  (Default: 2.11)", Default: "2.11"},
+			},
+		},
+		{
+			Code:          "infomaniak",
+			Name:          "Infomaniak",
+			Since:         "v4.1.0",
+			URL:           "https://www.infomaniak.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/infomaniak",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "INFOMANIAK_ACCESS_TOKEN", Description: "Access token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "INFOMANIAK_ENDPOINT", Description: "https://api.infomaniak.com"},
+				{Name: "INFOMANIAK_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "INFOMANIAK_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "INFOMANIAK_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "INFOMANIAK_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "internetbs",
+			Name:          "Internet.bs",
+			Since:         "v4.5.0",
+			URL:           "https://internetbs.net",
+			Documentation: "https://go-acme.github.io/lego/dns/internetbs",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "INTERNET_BS_API_KEY", Description: "API key"},
+				{Name: "INTERNET_BS_PASSWORD", Description: "API password"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "INTERNET_BS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "INTERNET_BS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "INTERNET_BS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "INTERNET_BS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "inwx",
+			Name:          "INWX",
+			Since:         "v2.0.0",
+			URL:           "https://www.inwx.de/en",
+			Documentation: "https://go-acme.github.io/lego/dns/inwx",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "INWX_PASSWORD", Description: "Password"},
+				{Name: "INWX_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "INWX_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "INWX_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 360)", Default: "360"},
+				{Name: "INWX_SANDBOX", Description: "Activate the sandbox (boolean)"},
+				{Name: "INWX_SHARED_SECRET", Description: "shared secret related to 2FA"},
+				{Name: "INWX_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "ionos",
+			Name:          "Ionos",
+			Since:         "v4.2.0",
+			URL:           "https://ionos.com",
+			Documentation: "https://go-acme.github.io/lego/dns/ionos",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "IONOS_API_KEY", Description: "API key `<prefix>.<secret>` https://developer.hosting.ionos.com/docs/getstarted"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "IONOS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "IONOS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "IONOS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 900)", Default: "900"},
+				{Name: "IONOS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "ionoscloud",
+			Name:          "Ionos Cloud",
+			Since:         "v4.30.0",
+			URL:           "https://cloud.ionos.de/network/cloud-dns",
+			Documentation: "https://go-acme.github.io/lego/dns/ionoscloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "IONOSCLOUD_API_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "IONOSCLOUD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "IONOSCLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "IONOSCLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "IONOSCLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "ipv64",
+			Name:          "IPv64",
+			Since:         "v4.13.0",
+			URL:           "https://ipv64.net/",
+			Documentation: "https://go-acme.github.io/lego/dns/ipv64",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "IPV64_API_KEY", Description: "Account API Key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "IPV64_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "IPV64_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "IPV64_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "ispconfig",
+			Name:          "ISPConfig 3",
+			Since:         "v4.31.0",
+			URL:           "https://www.ispconfig.org/",
+			Documentation: "https://go-acme.github.io/lego/dns/ispconfig",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ISPCONFIG_PASSWORD", Description: "Password"},
+				{Name: "ISPCONFIG_SERVER_URL", Description: "Server URL"},
+				{Name: "ISPCONFIG_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ISPCONFIG_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ISPCONFIG_INSECURE_SKIP_VERIFY", Description: "Whether to verify the API certificate"},
+				{Name: "ISPCONFIG_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ISPCONFIG_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "ISPCONFIG_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "ispconfigddns",
+			Name:          "ISPConfig 3 - Dynamic DNS (DDNS) Module",
+			Since:         "v4.31.0",
+			URL:           "https://www.ispconfig.org/",
+			Documentation: "https://go-acme.github.io/lego/dns/ispconfigddns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ISPCONFIG_DDNS_SERVER_URL", Description: "API server URL (ex: https://panel.example.com:8080)"},
+				{Name: "ISPCONFIG_DDNS_TOKEN", Description: "DDNS API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ISPCONFIG_DDNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ISPCONFIG_DDNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ISPCONFIG_DDNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "ISPCONFIG_DDNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "iwantmyname",
+			Name:          "iwantmyname (Deprecated)",
+			Since:         "v4.7.0",
+			URL:           "https://iwantmyname.com",
+			Documentation: "https://go-acme.github.io/lego/dns/iwantmyname",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "IWANTMYNAME_PASSWORD", Description: "API password"},
+				{Name: "IWANTMYNAME_USERNAME", Description: "API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "IWANTMYNAME_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "IWANTMYNAME_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "IWANTMYNAME_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "IWANTMYNAME_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "jdcloud",
+			Name:          "JD Cloud",
+			Since:         "v4.31.0",
+			URL:           "https://www.jdcloud.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/jdcloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "JDCLOUD_ACCESS_KEY_ID", Description: "Access key ID"},
+				{Name: "JDCLOUD_ACCESS_KEY_SECRET", Description: "Access key secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "JDCLOUD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "JDCLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "JDCLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "JDCLOUD_REGION_ID", Description: "Region ID (Default: cn-north-1)", Default: "cn-north-1"},
+				{Name: "JDCLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "joker",
+			Name:          "Joker",
+			Since:         "v2.6.0",
+			URL:           "https://joker.com",
+			Documentation: "https://go-acme.github.io/lego/dns/joker",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "JOKER_API_KEY", Description: "API key (only with DMAPI mode)"},
+				{Name: "JOKER_API_MODE", Description: "'DMAPI' or 'SVC'. DMAPI is for resellers accounts. (Default: DMAPI)", Default: "DMAPI"},
+				{Name: "JOKER_PASSWORD", Description: "Joker.com password"},
+				{Name: "JOKER_USERNAME", Description: "Joker.com username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "JOKER_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 60)", Default: "60"},
+				{Name: "JOKER_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "JOKER_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "JOKER_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60), only with 'SVC' mode", Default: "60"},
+				{Name: "JOKER_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "keyhelp",
+			Name:          "KeyHelp",
+			Since:         "v4.26.0",
+			URL:           "https://www.keyweb.de/en/keyhelp/keyhelp/",
+			Documentation: "https://go-acme.github.io/lego/dns/keyhelp",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "KEYHELP_API_KEY", Description: "API key"},
+				{Name: "KEYHELP_BASE_URL", Description: "Server URL"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "KEYHELP_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "KEYHELP_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "KEYHELP_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "KEYHELP_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "leaseweb",
+			Name:          "Leaseweb",
+			Since:         "v4.32.0",
+			URL:           "https://www.leaseweb.com/en/",
+			Documentation: "https://go-acme.github.io/lego/dns/leaseweb",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "LEASEWEB_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "LEASEWEB_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "LEASEWEB_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "LEASEWEB_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "LEASEWEB_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "liara",
+			Name:          "Liara",
+			Since:         "v4.10.0",
+			URL:           "https://liara.ir",
+			Documentation: "https://go-acme.github.io/lego/dns/liara",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "LIARA_API_KEY", Description: "The API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "LIARA_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "LIARA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "LIARA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "LIARA_TEAM_ID", Description: "The team ID to access services in a team"},
+				{Name: "LIARA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "lightsail",
+			Name:          "Amazon Lightsail",
+			Since:         "v0.5.0",
+			URL:           "https://aws.amazon.com/lightsail/",
+			Documentation: "https://go-acme.github.io/lego/dns/lightsail",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "AWS_ACCESS_KEY_ID", Description: "Managed by the AWS client. Access key ID (`AWS_ACCESS_KEY_ID_FILE` is not supported, use `AWS_SHARED_CREDENTIALS_FILE` instead)"},
+				{Name: "AWS_SECRET_ACCESS_KEY", Description: "Managed by the AWS client. Secret access key (`AWS_SECRET_ACCESS_KEY_FILE` is not supported, use `AWS_SHARED_CREDENTIALS_FILE` instead)"},
+				{Name: "DNS_ZONE", Description: "Domain name of the DNS zone"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "AWS_SHARED_CREDENTIALS_FILE", Description: "Managed by the AWS client. Shared credentials file."},
+				{Name: "LIGHTSAIL_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "LIGHTSAIL_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "limacity",
+			Name:          "Lima-City",
+			Since:         "v4.18.0",
+			URL:           "https://www.lima-city.de",
+			Documentation: "https://go-acme.github.io/lego/dns/limacity",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "LIMACITY_API_KEY", Description: "The API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "LIMACITY_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "LIMACITY_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 80)", Default: "80"},
+				{Name: "LIMACITY_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 480)", Default: "480"},
+				{Name: "LIMACITY_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 90)", Default: "90"},
+				{Name: "LIMACITY_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "linode",
+			Name:          "Linode (v4)",
+			Since:         "v1.1.0",
+			URL:           "https://www.linode.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/linode",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "LINODE_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "LINODE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "LINODE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 15)", Default: "15"},
+				{Name: "LINODE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "LINODE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "liquidweb",
+			Name:          "Liquid Web",
+			Since:         "v3.1.0",
+			URL:           "https://liquidweb.com",
+			Documentation: "https://go-acme.github.io/lego/dns/liquidweb",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "LWAPI_PASSWORD", Description: "Liquid Web API Password"},
+				{Name: "LWAPI_USERNAME", Description: "Liquid Web API Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "LWAPI_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 60)", Default: "60"},
+				{Name: "LWAPI_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "LWAPI_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "LWAPI_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+				{Name: "LWAPI_URL", Description: "Liquid Web API endpoint"},
+				{Name: "LWAPI_ZONE", Description: "DNS Zone"},
+			},
+		},
+		{
+			Code:          "loopia",
+			Name:          "Loopia",
+			Since:         "v4.2.0",
+			URL:           "https://loopia.com",
+			Documentation: "https://go-acme.github.io/lego/dns/loopia",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "LOOPIA_API_PASSWORD", Description: "API password"},
+				{Name: "LOOPIA_API_USER", Description: "API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "LOOPIA_API_URL", Description: "API endpoint. Ex: https://api.loopia.se/RPCSERV or https://api.loopia.rs/RPCSERV"},
+				{Name: "LOOPIA_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 60)", Default: "60"},
+				{Name: "LOOPIA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2400)", Default: "2400"},
+				{Name: "LOOPIA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "LOOPIA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "luadns",
+			Name:          "LuaDNS",
+			Since:         "v3.7.0",
+			URL:           "https://luadns.com",
+			Documentation: "https://go-acme.github.io/lego/dns/luadns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "LUADNS_API_TOKEN", Description: "API token"},
+				{Name: "LUADNS_API_USERNAME", Description: "Username (your email)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "LUADNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "LUADNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "LUADNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "LUADNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "mailinabox",
+			Name:          "Mail-in-a-Box",
+			Since:         "v4.16.0",
+			URL:           "https://mailinabox.email",
+			Documentation: "https://go-acme.github.io/lego/dns/mailinabox",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "MAILINABOX_BASE_URL", Description: "Base API URL (ex: https://box.example.com)"},
+				{Name: "MAILINABOX_EMAIL", Description: "User email"},
+				{Name: "MAILINABOX_PASSWORD", Description: "User password"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "MAILINABOX_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "MAILINABOX_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 4)", Default: "4"},
+				{Name: "MAILINABOX_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "manageengine",
+			Name:          "ManageEngine CloudDNS",
+			Since:         "v4.21.0",
+			URL:           "https://clouddns.manageengine.com",
+			Documentation: "https://go-acme.github.io/lego/dns/manageengine",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "MANAGEENGINE_CLIENT_ID", Description: "Client ID"},
+				{Name: "MANAGEENGINE_CLIENT_SECRET", Description: "Client Secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "MANAGEENGINE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "MANAGEENGINE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "MANAGEENGINE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "manual",
+			Name:          "Manual",
+			Since:         "v0.3.0",
+			URL:           "",
+			Documentation: "https://go-acme.github.io/lego/dns/manual",
+		},
+		{
+			Code:          "metaname",
+			Name:          "Metaname",
+			Since:         "v4.13.0",
+			URL:           "https://metaname.net",
+			Documentation: "https://go-acme.github.io/lego/dns/metaname",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "METANAME_ACCOUNT_REFERENCE", Description: "The four-digit reference of a Metaname account"},
+				{Name: "METANAME_API_KEY", Description: "API Key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "METANAME_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "METANAME_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "METANAME_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "metaregistrar",
+			Name:          "Metaregistrar",
+			Since:         "v4.23.0",
+			URL:           "https://metaregistrar.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/metaregistrar",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "METAREGISTRAR_API_TOKEN", Description: "The API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "METAREGISTRAR_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "METAREGISTRAR_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "METAREGISTRAR_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "METAREGISTRAR_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "mijnhost",
+			Name:          "mijn.host",
+			Since:         "v4.18.0",
+			URL:           "https://mijn.host/",
+			Documentation: "https://go-acme.github.io/lego/dns/mijnhost",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "MIJNHOST_API_KEY", Description: "The API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "MIJNHOST_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "MIJNHOST_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "MIJNHOST_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "MIJNHOST_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "MIJNHOST_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "mittwald",
+			Name:          "Mittwald",
+			Since:         "v1.48.0",
+			URL:           "https://www.mittwald.de/",
+			Documentation: "https://go-acme.github.io/lego/dns/mittwald",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "MITTWALD_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "MITTWALD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "MITTWALD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "MITTWALD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "MITTWALD_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 120)", Default: "120"},
+				{Name: "MITTWALD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "myaddr",
+			Name:          "myaddr.{tools,dev,io}",
+			Since:         "v4.22.0",
+			URL:           "https://myaddr.tools/",
+			Documentation: "https://go-acme.github.io/lego/dns/myaddr",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "MYADDR_PRIVATE_KEYS_MAPPING", Description: "Mapping between subdomains and private keys. The format is: `<subdomain1>:<private_key1>,<subdomain2>:<private_key2>,<subdomain3>:<private_key3>`"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "MYADDR_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "MYADDR_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "MYADDR_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "MYADDR_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 2)", Default: "2"},
+				{Name: "MYADDR_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "mydnsjp",
+			Name:          "MyDNS.jp",
+			Since:         "v1.2.0",
+			URL:           "https://www.mydns.jp",
+			Documentation: "https://go-acme.github.io/lego/dns/mydnsjp",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "MYDNSJP_MASTER_ID", Description: "Master ID"},
+				{Name: "MYDNSJP_PASSWORD", Description: "Password"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "MYDNSJP_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "MYDNSJP_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "MYDNSJP_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "mythicbeasts",
+			Name:          "MythicBeasts",
+			Since:         "v0.3.7",
+			URL:           "https://www.mythic-beasts.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/mythicbeasts",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "MYTHICBEASTS_PASSWORD", Description: "Password"},
+				{Name: "MYTHICBEASTS_USERNAME", Description: "User name"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "MYTHICBEASTS_API_ENDPOINT", Description: "The endpoint for the API (must implement v2)"},
+				{Name: "MYTHICBEASTS_AUTH_API_ENDPOINT", Description: "The endpoint for Mythic Beasts' Authentication"},
+				{Name: "MYTHICBEASTS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "MYTHICBEASTS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "MYTHICBEASTS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "MYTHICBEASTS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "namecheap",
+			Name:          "Namecheap",
+			Since:         "v0.3.0",
+			URL:           "https://www.namecheap.com",
+			Documentation: "https://go-acme.github.io/lego/dns/namecheap",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NAMECHEAP_API_KEY", Description: "API key"},
+				{Name: "NAMECHEAP_API_USER", Description: "API user"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NAMECHEAP_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 60)", Default: "60"},
+				{Name: "NAMECHEAP_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 15)", Default: "15"},
+				{Name: "NAMECHEAP_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 3600)", Default: "3600"},
+				{Name: "NAMECHEAP_SANDBOX", Description: "Activate the sandbox (boolean)"},
+				{Name: "NAMECHEAP_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "namedotcom",
+			Name:          "Name.com",
+			Since:         "v0.5.0",
+			URL:           "https://www.name.com",
+			Documentation: "https://go-acme.github.io/lego/dns/namedotcom",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NAMECOM_API_TOKEN", Description: "API token"},
+				{Name: "NAMECOM_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NAMECOM_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "NAMECOM_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 20)", Default: "20"},
+				{Name: "NAMECOM_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 900)", Default: "900"},
+				{Name: "NAMECOM_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "namesilo",
+			Name:          "Namesilo",
+			Since:         "v2.7.0",
+			URL:           "https://www.namesilo.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/namesilo",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NAMESILO_API_KEY", Description: "Client ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NAMESILO_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "NAMESILO_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60), it is better to set larger than 15 minutes", Default: "60"},
+				{Name: "NAMESILO_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600), should be in [3600, 2592000]", Default: "3600"},
+			},
+		},
+		{
+			Code:          "namesurfer",
+			Name:          "FusionLayer NameSurfer",
+			Since:         "v4.32.0",
+			URL:           "https://www.fusionlayer.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/namesurfer",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NAMESURFER_API_KEY", Description: "API key name"},
+				{Name: "NAMESURFER_API_SECRET", Description: "API secret"},
+				{Name: "NAMESURFER_BASE_URL", Description: "The base URL of NameSurfer API (jsonrpc10) endpoint URL (e.g., https://foo.example.com:8443/API/NSService_10)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NAMESURFER_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "NAMESURFER_INSECURE_SKIP_VERIFY", Description: "Whether to verify the API certificate"},
+				{Name: "NAMESURFER_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "NAMESURFER_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "NAMESURFER_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+				{Name: "NAMESURFER_VIEW", Description: "DNS view name (optional, default: empty string)"},
+			},
+		},
+		{
+			Code:          "nearlyfreespeech",
+			Name:          "NearlyFreeSpeech.NET",
+			Since:         "v4.8.0",
+			URL:           "https://nearlyfreespeech.net/",
+			Documentation: "https://go-acme.github.io/lego/dns/nearlyfreespeech",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NEARLYFREESPEECH_API_KEY", Description: "API Key for API requests"},
+				{Name: "NEARLYFREESPEECH_LOGIN", Description: "Username for API requests"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NEARLYFREESPEECH_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "NEARLYFREESPEECH_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "NEARLYFREESPEECH_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "NEARLYFREESPEECH_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "NEARLYFREESPEECH_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "neodigit",
+			Name:          "Neodigit",
+			Since:         "v4.30.0",
+			URL:           "https://www.neodigit.net",
+			Documentation: "https://go-acme.github.io/lego/dns/neodigit",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NEODIGIT_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NEODIGIT_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "NEODIGIT_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "NEODIGIT_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "NEODIGIT_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "netcup",
+			Name:          "Netcup",
+			Since:         "v1.1.0",
+			URL:           "https://www.netcup.eu/",
+			Documentation: "https://go-acme.github.io/lego/dns/netcup",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NETCUP_API_KEY", Description: "API key"},
+				{Name: "NETCUP_API_PASSWORD", Description: "API password"},
+				{Name: "NETCUP_CUSTOMER_NUMBER", Description: "Customer number"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NETCUP_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "NETCUP_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 30)", Default: "30"},
+				{Name: "NETCUP_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 900)", Default: "900"},
+			},
+		},
+		{
+			Code:          "netlify",
+			Name:          "Netlify",
+			Since:         "v3.7.0",
+			URL:           "https://www.netlify.com",
+			Documentation: "https://go-acme.github.io/lego/dns/netlify",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NETLIFY_TOKEN", Description: "Token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NETLIFY_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "NETLIFY_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "NETLIFY_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "NETLIFY_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "netnod",
+			Name:          "Netnod",
+			Since:         "v4.34.0",
+			URL:           "https://www.netnod.se/dns/",
+			Documentation: "https://go-acme.github.io/lego/dns/netnod",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NETNOD_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NETNOD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "NETNOD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "NETNOD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "NETNOD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "nicmanager",
+			Name:          "Nicmanager",
+			Since:         "v4.5.0",
+			URL:           "https://www.nicmanager.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/nicmanager",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NICMANAGER_API_EMAIL", Description: "Email-based login"},
+				{Name: "NICMANAGER_API_LOGIN", Description: "Login, used for Username-based login"},
+				{Name: "NICMANAGER_API_PASSWORD", Description: "Password, always required"},
+				{Name: "NICMANAGER_API_USERNAME", Description: "Username, used for Username-based login"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NICMANAGER_API_MODE", Description: "mode: 'anycast' or 'zones' (for FreeDNS) (default: 'anycast')", Default: "'anycast'"},
+				{Name: "NICMANAGER_API_OTP", Description: "TOTP Secret (optional)"},
+				{Name: "NICMANAGER_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "NICMANAGER_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "NICMANAGER_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "NICMANAGER_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 900)", Default: "900"},
+			},
+		},
+		{
+			Code:          "nicru",
+			Name:          "RU CENTER",
+			Since:         "v4.24.0",
+			URL:           "https://nic.ru/",
+			Documentation: "https://go-acme.github.io/lego/dns/nicru",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NICRU_PASSWORD", Description: "Password for an account in RU CENTER"},
+				{Name: "NICRU_SECRET", Description: "Secret for application in DNS-hosting RU CENTER"},
+				{Name: "NICRU_SERVICE_ID", Description: "Service ID for application in DNS-hosting RU CENTER"},
+				{Name: "NICRU_SERVICE_NAME", Description: "Service Name for DNS-hosting RU CENTER"},
+				{Name: "NICRU_USER", Description: "Agreement for an account in RU CENTER"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NICRU_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 60)", Default: "60"},
+				{Name: "NICRU_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 600)", Default: "600"},
+				{Name: "NICRU_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 30)", Default: "30"},
+			},
+		},
+		{
+			Code:          "nifcloud",
+			Name:          "NIFCloud",
+			Since:         "v1.1.0",
+			URL:           "https://www.nifcloud.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/nifcloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NIFCLOUD_ACCESS_KEY_ID", Description: "Access key"},
+				{Name: "NIFCLOUD_SECRET_ACCESS_KEY", Description: "Secret access key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NIFCLOUD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "NIFCLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "NIFCLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "NIFCLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "njalla",
+			Name:          "Njalla",
+			Since:         "v4.3.0",
+			URL:           "https://njal.la",
+			Documentation: "https://go-acme.github.io/lego/dns/njalla",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NJALLA_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NJALLA_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "NJALLA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "NJALLA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "NJALLA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "nodion",
+			Name:          "Nodion",
+			Since:         "v4.11.0",
+			URL:           "https://www.nodion.com",
+			Documentation: "https://go-acme.github.io/lego/dns/nodion",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NODION_API_TOKEN", Description: "The API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NODION_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "NODION_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "NODION_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "NODION_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "ns1",
+			Name:          "NS1",
+			Since:         "v0.4.0",
+			URL:           "https://ns1.com",
+			Documentation: "https://go-acme.github.io/lego/dns/ns1",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "NS1_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "NS1_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "NS1_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "NS1_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "NS1_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "octenium",
+			Name:          "Octenium",
+			Since:         "v4.27.0",
+			URL:           "https://octenium.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/octenium",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "OCTENIUM_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "OCTENIUM_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "OCTENIUM_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "OCTENIUM_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "OCTENIUM_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "onecloudru",
+			Name:          "1cloud.ru",
+			Since:         "v4.34.0",
+			URL:           "https://1cloud.ru/",
+			Documentation: "https://go-acme.github.io/lego/dns/onecloudru",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ONECLOUDRU_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ONECLOUDRU_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ONECLOUDRU_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ONECLOUDRU_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "ONECLOUDRU_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "onlinenet",
+			Name:          "Online.net",
+			Since:         "v4.34.0",
+			URL:           "https://online.net/",
+			Documentation: "https://go-acme.github.io/lego/dns/onlinenet",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ONLINENET_API_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ONLINENET_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ONLINENET_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 15)", Default: "15"},
+				{Name: "ONLINENET_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 240)", Default: "240"},
+				{Name: "ONLINENET_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "oraclecloud",
+			Name:          "Oracle Cloud",
+			Since:         "v2.3.0",
+			URL:           "https://cloud.oracle.com/home",
+			Documentation: "https://go-acme.github.io/lego/dns/oraclecloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "OCI_COMPARTMENT_OCID", Description: "Compartment OCID"},
+				{Name: "OCI_FINGERPRINT", Description: "Public key fingerprint (ignored if `OCI_AUTH_TYPE` is not empty)"},
+				{Name: "OCI_PRIVATE_KEY_PASSWORD", Description: "Private key password (ignored if `OCI_AUTH_TYPE` is not empty)"},
+				{Name: "OCI_PRIVATE_KEY_PATH", Description: "Private key file (ignored if `OCI_AUTH_TYPE` is not empty)"},
+				{Name: "OCI_REGION", Description: "Region (it can be empty if `OCI_AUTH_TYPE` is not empty)."},
+				{Name: "OCI_TENANCY_OCID", Description: "Tenancy OCID (ignored if `OCI_AUTH_TYPE` is not empty)"},
+				{Name: "OCI_USER_OCID", Description: "User OCID (ignored if `OCI_AUTH_TYPE` is not empty)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "OCI_AUTH_TYPE", Description: "Authorization type. Possible values: 'instance_principal', 'user_principal', ''. (Default: '')", Default: "''"},
+				{Name: "OCI_CONFIG_FILE", Description: "Path to the configuration file. (only for `OCI_AUTH_TYPE=user_principal`)"},
+				{Name: "OCI_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 60)", Default: "60"},
+				{Name: "OCI_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "OCI_PROFILE", Description: "Profile name. (only for `OCI_AUTH_TYPE=user_principal`)"},
+				{Name: "OCI_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "OCI_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+				{Name: "TF_VAR_fingerprint", Description: "Alias on `OCI_FINGERPRINT`"},
+				{Name: "TF_VAR_private_key_path", Description: "Alias on `OCI_PRIVATE_KEY_PATH`"},
+				{Name: "TF_VAR_region", Description: "Alias on `OCI_REGION`"},
+				{Name: "TF_VAR_tenancy_ocid", Description: "Alias on `OCI_TENANCY_OCID`"},
+				{Name: "TF_VAR_user_ocid", Description: "Alias on `OCI_USER_OCID`"},
+			},
+		},
+		{
+			Code:          "otc",
+			Name:          "Open Telekom Cloud",
+			Since:         "v0.4.1",
+			URL:           "https://cloud.telekom.de/en",
+			Documentation: "https://go-acme.github.io/lego/dns/otc",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "OTC_DOMAIN_NAME", Description: "Domain name"},
+				{Name: "OTC_PASSWORD", Description: "Password"},
+				{Name: "OTC_PROJECT_NAME", Description: "Project name"},
+				{Name: "OTC_USER_NAME", Description: "User name"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "OTC_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "OTC_IDENTITY_ENDPOINT", Description: "Identity endpoint URL (default: https://iam.eu-de.otc.t-systems.com:443/v3/auth/tokens)", Default: "https://iam.eu-de.otc.t-systems.com:443/v3/auth/tokens"},
+				{Name: "OTC_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "OTC_PRIVATE_ZONE", Description: "Set to true to use private zones only (default: use public zones only)", Default: "use public zones only"},
+				{Name: "OTC_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "OTC_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "OTC_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "ovh",
+			Name:          "OVH",
+			Since:         "v0.4.0",
+			URL:           "https://www.ovh.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/ovh",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "OVH_ACCESS_TOKEN", Description: "Access token"},
+				{Name: "OVH_APPLICATION_KEY", Description: "Application key (Application Key authentication)"},
+				{Name: "OVH_APPLICATION_SECRET", Description: "Application secret (Application Key authentication)"},
+				{Name: "OVH_CLIENT_ID", Description: "Client ID (OAuth2)"},
+				{Name: "OVH_CLIENT_SECRET", Description: "Client secret (OAuth2)"},
+				{Name: "OVH_CONSUMER_KEY", Description: "Consumer key (Application Key authentication)"},
+				{Name: "OVH_ENDPOINT", Description: "Endpoint URL (ovh-eu or ovh-ca)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "OVH_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 180)", Default: "180"},
+				{Name: "OVH_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "OVH_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "OVH_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "pdns",
+			Name:          "PowerDNS",
+			Since:         "v0.4.0",
+			URL:           "https://www.powerdns.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/pdns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "PDNS_API_KEY", Description: "API key"},
+				{Name: "PDNS_API_URL", Description: "API URL"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "PDNS_API_VERSION", Description: "Skip API version autodetection and use the provided version number."},
+				{Name: "PDNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "PDNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "PDNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "PDNS_SERVER_NAME", Description: "Name of the server in the URL, 'localhost' by default"},
+				{Name: "PDNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "plesk",
+			Name:          "plesk.com",
+			Since:         "v4.11.0",
+			URL:           "https://www.plesk.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/plesk",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "PLESK_PASSWORD", Description: "API password"},
+				{Name: "PLESK_SERVER_BASE_URL", Description: "Base URL of the server (ex: https://plesk.myserver.com:8443)"},
+				{Name: "PLESK_USERNAME", Description: "API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "PLESK_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "PLESK_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "PLESK_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "PLESK_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "porkbun",
+			Name:          "Porkbun",
+			Since:         "v4.4.0",
+			URL:           "https://porkbun.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/porkbun",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "PORKBUN_API_KEY", Description: "API key"},
+				{Name: "PORKBUN_SECRET_API_KEY", Description: "secret API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "PORKBUN_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "PORKBUN_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "PORKBUN_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 600)", Default: "600"},
+				{Name: "PORKBUN_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "rackspace",
+			Name:          "Rackspace",
+			Since:         "v0.4.0",
+			URL:           "https://www.rackspace.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/rackspace",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "RACKSPACE_API_KEY", Description: "API key"},
+				{Name: "RACKSPACE_USER", Description: "API user"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "RACKSPACE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "RACKSPACE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 3)", Default: "3"},
+				{Name: "RACKSPACE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "RACKSPACE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "rainyun",
+			Name:          "Rain Yun/雨云",
+			Since:         "v4.21.0",
+			URL:           "https://www.rainyun.com",
+			Documentation: "https://go-acme.github.io/lego/dns/rainyun",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "RAINYUN_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "RAINYUN_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "RAINYUN_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "RAINYUN_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "RAINYUN_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "rcodezero",
+			Name:          "RcodeZero",
+			Since:         "v4.13",
+			URL:           "https://www.rcodezero.at/",
+			Documentation: "https://go-acme.github.io/lego/dns/rcodezero",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "RCODEZERO_API_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "RCODEZERO_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "RCODEZERO_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "RCODEZERO_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 240)", Default: "240"},
+				{Name: "RCODEZERO_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "regfish",
+			Name:          "Regfish",
+			Since:         "v4.20.0",
+			URL:           "https://regfish.de/",
+			Documentation: "https://go-acme.github.io/lego/dns/regfish",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "REGFISH_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "REGFISH_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "REGFISH_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "REGFISH_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "REGFISH_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "regru",
+			Name:          "reg.ru",
+			Since:         "v3.5.0",
+			URL:           "https://www.reg.ru/",
+			Documentation: "https://go-acme.github.io/lego/dns/regru",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "REGRU_PASSWORD", Description: "API password"},
+				{Name: "REGRU_USERNAME", Description: "API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "REGRU_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "REGRU_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "REGRU_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "REGRU_TLS_CERT", Description: "authentication certificate"},
+				{Name: "REGRU_TLS_KEY", Description: "authentication private key"},
+				{Name: "REGRU_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "rfc2136",
+			Name:          "DNS Update (RFC2136)",
+			Since:         "v0.3.0",
+			URL:           "https://www.rfc-editor.org/rfc/rfc2136.html",
+			Documentation: "https://go-acme.github.io/lego/dns/rfc2136",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "DNSUPDATE_NAMESERVER", Description: "Network address in the form \"host\" or \"host:port\""},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "DNSUPDATE_DNS_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "DNSUPDATE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "DNSUPDATE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "DNSUPDATE_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "DNSUPDATE_TSIG_ALGORITHM", Description: "TSIG algorithm. See [miekg/dns#tsig.go](https://github.com/miekg/dns/blob/master/tsig.go) for supported values. To disable TSIG authentication, leave the `DNSUPDATE_TSIG_KEY` or `DNSUPDATE_TSIG_SECRET` variables unset."},
+				{Name: "DNSUPDATE_TSIG_FILE", Description: "Path to a key file generated by tsig-keygen"},
+				{Name: "DNSUPDATE_TSIG_GSS_KEYTAB_FILE", Description: "Path to Kerberos keytab file. The TSIG algorithm must be `gss-tsig.`."},
+				{Name: "DNSUPDATE_TSIG_GSS_PASSWORD", Description: "Kerberos password. The TSIG algorithm must be `gss-tsig.`."},
+				{Name: "DNSUPDATE_TSIG_GSS_REALM", Description: "Kerberos realm. The TSIG algorithm must be `gss-tsig.`."},
+				{Name: "DNSUPDATE_TSIG_GSS_USERNAME", Description: "Kerberos username. The TSIG algorithm must be `gss-tsig.`."},
+				{Name: "DNSUPDATE_TSIG_KEY", Description: "Name of the secret key as defined in DNS server configuration. To disable TSIG authentication, leave the `DNSUPDATE_TSIG_KEY` variable unset."},
+				{Name: "DNSUPDATE_TSIG_SECRET", Description: "Secret key payload. To disable TSIG authentication, leave the `DNSUPDATE_TSIG_SECRET` variable unset."},
+				{Name: "DNSUPDATE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+				{Name: "DNSUPDATE_ZONES", Description: "List of potential zones (separated by commas)"},
+			},
+		},
+		{
+			Code:          "rimuhosting",
+			Name:          "RimuHosting",
+			Since:         "v0.3.5",
+			URL:           "https://rimuhosting.com",
+			Documentation: "https://go-acme.github.io/lego/dns/rimuhosting",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "RIMUHOSTING_API_KEY", Description: "User API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "RIMUHOSTING_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "RIMUHOSTING_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "RIMUHOSTING_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "RIMUHOSTING_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "route53",
+			Name:          "Amazon Route 53",
+			Since:         "v0.3.0",
+			URL:           "https://aws.amazon.com/route53/",
+			Documentation: "https://go-acme.github.io/lego/dns/route53",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "AWS_ACCESS_KEY_ID", Description: "Managed by the AWS client. Access key ID (`AWS_ACCESS_KEY_ID_FILE` is not supported, use `AWS_SHARED_CREDENTIALS_FILE` instead)"},
+				{Name: "AWS_ASSUME_ROLE_ARN", Description: "Managed by the AWS Role ARN (`AWS_ASSUME_ROLE_ARN_FILE` is not supported)"},
+				{Name: "AWS_EXTERNAL_ID", Description: "Managed by STS AssumeRole API operation (`AWS_EXTERNAL_ID_FILE` is not supported)"},
+				{Name: "AWS_HOSTED_ZONE_ID", Description: "Override the hosted zone ID."},
+				{Name: "AWS_PROFILE", Description: "Managed by the AWS client (`AWS_PROFILE_FILE` is not supported)"},
+				{Name: "AWS_REGION", Description: "Managed by the AWS client (`AWS_REGION_FILE` is not supported)"},
+				{Name: "AWS_SDK_LOAD_CONFIG", Description: "Managed by the AWS client. Retrieve the region from the CLI config file (`AWS_SDK_LOAD_CONFIG_FILE` is not supported)"},
+				{Name: "AWS_SECRET_ACCESS_KEY", Description: "Managed by the AWS client. Secret access key (`AWS_SECRET_ACCESS_KEY_FILE` is not supported, use `AWS_SHARED_CREDENTIALS_FILE` instead)"},
+				{Name: "AWS_WAIT_FOR_RECORD_SETS_CHANGED", Description: "Wait for changes to be INSYNC (it can be unstable)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "AWS_MAX_RETRIES", Description: "The number of maximum returns the service will use to make an individual API request"},
+				{Name: "AWS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 4)", Default: "4"},
+				{Name: "AWS_PRIVATE_ZONE", Description: "Set to true to use private zones only (default: use public zones only)", Default: "use public zones only"},
+				{Name: "AWS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "AWS_SHARED_CREDENTIALS_FILE", Description: "Managed by the AWS client. Shared credentials file."},
+				{Name: "AWS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 10)", Default: "10"},
+			},
+		},
+		{
+			Code:          "safedns",
+			Name:          "ANS SafeDNS",
+			Since:         "v4.6.0",
+			URL:           "https://www.ans.co.uk/",
+			Documentation: "https://go-acme.github.io/lego/dns/safedns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SAFEDNS_AUTH_TOKEN", Description: "Authentication token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SAFEDNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SAFEDNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "SAFEDNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "SAFEDNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "sakuracloud",
+			Name:          "Sakura Cloud",
+			Since:         "v1.1.0",
+			URL:           "https://cloud.sakura.ad.jp/",
+			Documentation: "https://go-acme.github.io/lego/dns/sakuracloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SAKURACLOUD_ACCESS_TOKEN", Description: "Access token"},
+				{Name: "SAKURACLOUD_ACCESS_TOKEN_SECRET", Description: "Access token secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SAKURACLOUD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "SAKURACLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "SAKURACLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "SAKURACLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "scaleway",
+			Name:          "Scaleway",
+			Since:         "v3.4.0",
+			URL:           "https://developers.scaleway.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/scaleway",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SCW_PROJECT_ID", Description: "Project to use (optional)"},
+				{Name: "SCW_SECRET_KEY", Description: "Secret key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SCW_ACCESS_KEY", Description: "Access key"},
+				{Name: "SCW_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SCW_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "SCW_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "SCW_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "selectel",
+			Name:          "Selectel",
+			Since:         "v1.2.0",
+			URL:           "https://kb.selectel.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/selectel",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SELECTEL_API_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SELECTEL_BASE_URL", Description: "API endpoint URL"},
+				{Name: "SELECTEL_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SELECTEL_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "SELECTEL_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "SELECTEL_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "selectelv2",
+			Name:          "Selectel v2",
+			Since:         "v4.17.0",
+			URL:           "https://selectel.ru",
+			Documentation: "https://go-acme.github.io/lego/dns/selectelv2",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SELECTELV2_ACCOUNT_ID", Description: "Selectel account ID (INT)"},
+				{Name: "SELECTELV2_PASSWORD", Description: "Openstack username's password"},
+				{Name: "SELECTELV2_PROJECT_ID", Description: "Cloud project ID (UUID)"},
+				{Name: "SELECTELV2_USERNAME", Description: "Openstack username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SELECTELV2_AUTH_REGION", Description: "Location for auth endpoint like ResellAPI or Keystone (default: 'ru-1')", Default: "'ru-1'"},
+				{Name: "SELECTELV2_AUTH_URL", Description: "Identity endpoint (defaul: 'https://cloud.api.selcloud.ru/identity/v3/')"},
+				{Name: "SELECTELV2_BASE_URL", Description: "API endpoint URL"},
+				{Name: "SELECTELV2_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SELECTELV2_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "SELECTELV2_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "SELECTELV2_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+				{Name: "SELECTELV2_USER_DOMAIN_NAME", Description: "To specify the domain name (account ID) where the user is located. (default: SELECTELV2_ACCOUNT_ID)", Default: "SELECTELV2_ACCOUNT_ID"},
+			},
+		},
+		{
+			Code:          "selfhostde",
+			Name:          "SelfHost.(de|eu)",
+			Since:         "v4.19.0",
+			URL:           "https://www.selfhost.de",
+			Documentation: "https://go-acme.github.io/lego/dns/selfhostde",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SELFHOSTDE_PASSWORD", Description: "Password"},
+				{Name: "SELFHOSTDE_RECORDS_MAPPING", Description: "Record IDs mapping with domains (ex: example.com:123:456,example.org:789,foo.example.com:147)"},
+				{Name: "SELFHOSTDE_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SELFHOSTDE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SELFHOSTDE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 30)", Default: "30"},
+				{Name: "SELFHOSTDE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 240)", Default: "240"},
+				{Name: "SELFHOSTDE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "servercow",
+			Name:          "Servercow",
+			Since:         "v3.4.0",
+			URL:           "https://servercow.de/",
+			Documentation: "https://go-acme.github.io/lego/dns/servercow",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SERVERCOW_PASSWORD", Description: "API password"},
+				{Name: "SERVERCOW_USERNAME", Description: "API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SERVERCOW_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SERVERCOW_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "SERVERCOW_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "SERVERCOW_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "shellrent",
+			Name:          "Shellrent",
+			Since:         "v4.16.0",
+			URL:           "https://www.shellrent.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/shellrent",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SHELLRENT_TOKEN", Description: "Token"},
+				{Name: "SHELLRENT_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SHELLRENT_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SHELLRENT_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "SHELLRENT_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "SHELLRENT_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+		{
+			Code:          "simply",
+			Name:          "Simply.com",
+			Since:         "v4.4.0",
+			URL:           "https://www.simply.com/en/domains/",
+			Documentation: "https://go-acme.github.io/lego/dns/simply",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SIMPLY_ACCOUNT_NAME", Description: "Account name"},
+				{Name: "SIMPLY_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SIMPLY_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SIMPLY_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "SIMPLY_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "SIMPLY_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "sonic",
+			Name:          "Sonic",
+			Since:         "v4.4.0",
+			URL:           "https://www.sonic.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/sonic",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SONIC_API_KEY", Description: "API Key"},
+				{Name: "SONIC_USER_ID", Description: "User ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SONIC_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "SONIC_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "SONIC_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "SONIC_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "SONIC_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "spaceship",
+			Name:          "Spaceship",
+			Since:         "v4.22.0",
+			URL:           "https://www.spaceship.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/spaceship",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SPACESHIP_API_KEY", Description: "API key"},
+				{Name: "SPACESHIP_API_SECRET", Description: "API secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SPACESHIP_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SPACESHIP_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "SPACESHIP_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "SPACESHIP_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "stackpath",
+			Name:          "Stackpath",
+			Since:         "v1.1.0",
+			URL:           "https://www.stackpath.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/stackpath",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "STACKPATH_CLIENT_ID", Description: "Client ID"},
+				{Name: "STACKPATH_CLIENT_SECRET", Description: "Client secret"},
+				{Name: "STACKPATH_STACK_ID", Description: "Stack ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "STACKPATH_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "STACKPATH_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "STACKPATH_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "syse",
+			Name:          "Syse",
+			Since:         "v4.30.0",
+			URL:           "https://www.syse.no/",
+			Documentation: "https://go-acme.github.io/lego/dns/syse",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SYSE_CREDENTIALS", Description: "Comma-separated list of `zone:password` credential pairs"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "SYSE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "SYSE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "SYSE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 1200)", Default: "1200"},
+				{Name: "SYSE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "technitium",
+			Name:          "Technitium",
+			Since:         "v4.20.0",
+			URL:           "https://technitium.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/technitium",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "TECHNITIUM_API_TOKEN", Description: "API token"},
+				{Name: "TECHNITIUM_SERVER_BASE_URL", Description: "Server base URL"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "TECHNITIUM_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "TECHNITIUM_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "TECHNITIUM_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "TECHNITIUM_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "tencentcloud",
+			Name:          "Tencent Cloud DNS",
+			Since:         "v4.6.0",
+			URL:           "https://cloud.tencent.com/product/dns",
+			Documentation: "https://go-acme.github.io/lego/dns/tencentcloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "TENCENTCLOUD_SECRET_ID", Description: "Access key ID"},
+				{Name: "TENCENTCLOUD_SECRET_KEY", Description: "Access Key secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "TENCENTCLOUD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "TENCENTCLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "TENCENTCLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "TENCENTCLOUD_REGION", Description: "Region"},
+				{Name: "TENCENTCLOUD_SESSION_TOKEN", Description: "Access Key token"},
+				{Name: "TENCENTCLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "timewebcloud",
+			Name:          "Timeweb Cloud",
+			Since:         "v4.20.0",
+			URL:           "https://timeweb.cloud/",
+			Documentation: "https://go-acme.github.io/lego/dns/timewebcloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "TIMEWEBCLOUD_AUTH_TOKEN", Description: "Authentication token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "TIMEWEBCLOUD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 10)", Default: "10"},
+				{Name: "TIMEWEBCLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "TIMEWEBCLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "todaynic",
+			Name:          "TodayNIC/时代互联",
+			Since:         "v4.32.0",
+			URL:           "https://www.todaynic.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/todaynic",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "TODAYNIC_API_KEY", Description: "API key"},
+				{Name: "TODAYNIC_AUTH_USER_ID", Description: "account ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "TODAYNIC_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "TODAYNIC_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "TODAYNIC_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "TODAYNIC_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "transip",
+			Name:          "TransIP",
+			Since:         "v2.0.0",
+			URL:           "https://www.transip.nl/",
+			Documentation: "https://go-acme.github.io/lego/dns/transip",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "TRANSIP_ACCOUNT_NAME", Description: "Account name"},
+				{Name: "TRANSIP_PRIVATE_KEY_PATH", Description: "Private key path"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "TRANSIP_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "TRANSIP_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "TRANSIP_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 600)", Default: "600"},
+				{Name: "TRANSIP_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 10)", Default: "10"},
+			},
+		},
+		{
+			Code:          "ucloud",
+			Name:          "UCloud",
+			Since:         "v4.34.0",
+			URL:           "https://www.ucloud.cn/",
+			Documentation: "https://go-acme.github.io/lego/dns/ucloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "UCLOUD_PRIVATE_KEY", Description: "Private key"},
+				{Name: "UCLOUD_PUBLIC_KEY", Description: "Public key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "UCLOUD_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "UCLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "UCLOUD_PROJECT_ID", Description: "Project ID"},
+				{Name: "UCLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "UCLOUD_REGION", Description: "Region"},
+				{Name: "UCLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "ultradns",
+			Name:          "Ultradns",
+			Since:         "v4.10.0",
+			URL:           "https://vercara.com/authoritative-dns",
+			Documentation: "https://go-acme.github.io/lego/dns/ultradns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ULTRADNS_PASSWORD", Description: "API Password"},
+				{Name: "ULTRADNS_USERNAME", Description: "API Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ULTRADNS_ENDPOINT", Description: "API endpoint URL, defaults to https://api.ultradns.com/"},
+				{Name: "ULTRADNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 4)", Default: "4"},
+				{Name: "ULTRADNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "ULTRADNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "uniteddomains",
+			Name:          "United-Domains",
+			Since:         "v4.29.0",
+			URL:           "https://www.united-domains.de/",
+			Documentation: "https://go-acme.github.io/lego/dns/uniteddomains",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "UNITEDDOMAINS_API_KEY", Description: "API key `<prefix>.<secret>` https://www.united-domains.de/help/faq-article/getting-started-with-the-united-domains-dns-api/"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "UNITEDDOMAINS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "UNITEDDOMAINS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "UNITEDDOMAINS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 900)", Default: "900"},
+				{Name: "UNITEDDOMAINS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "variomedia",
+			Name:          "Variomedia",
+			Since:         "v4.8.0",
+			URL:           "https://www.variomedia.de/",
+			Documentation: "https://go-acme.github.io/lego/dns/variomedia",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "VARIOMEDIA_API_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "VARIOMEDIA_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "VARIOMEDIA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "VARIOMEDIA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "VARIOMEDIA_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "VARIOMEDIA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "vegadns",
+			Name:          "VegaDNS",
+			Since:         "v1.1.0",
+			URL:           "https://github.com/shupp/VegaDNS-API",
+			Documentation: "https://go-acme.github.io/lego/dns/vegadns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "SECRET_VEGADNS_KEY", Description: "API key"},
+				{Name: "SECRET_VEGADNS_SECRET", Description: "API secret"},
+				{Name: "VEGADNS_URL", Description: "API endpoint URL"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "VEGADNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 60)", Default: "60"},
+				{Name: "VEGADNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 720)", Default: "720"},
+				{Name: "VEGADNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 10)", Default: "10"},
+			},
+		},
+		{
+			Code:          "vercel",
+			Name:          "Vercel",
+			Since:         "v4.7.0",
+			URL:           "https://vercel.com",
+			Documentation: "https://go-acme.github.io/lego/dns/vercel",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "VERCEL_API_TOKEN", Description: "Authentication token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "VERCEL_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "VERCEL_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "VERCEL_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "VERCEL_TEAM_ID", Description: "Team ID (ex: team_xxxxxxxxxxxxxxxxxxxxxxxx)"},
+				{Name: "VERCEL_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "versio",
+			Name:          "Versio.[nl|eu|uk]",
+			Since:         "v2.7.0",
+			URL:           "https://www.versio.nl/domeinnamen",
+			Documentation: "https://go-acme.github.io/lego/dns/versio",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "VERSIO_PASSWORD", Description: "Basic authentication password"},
+				{Name: "VERSIO_USERNAME", Description: "Basic authentication username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "VERSIO_ENDPOINT", Description: "The endpoint URL of the API Server"},
+				{Name: "VERSIO_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "VERSIO_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "VERSIO_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "VERSIO_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "VERSIO_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "vinyldns",
+			Name:          "VinylDNS",
+			Since:         "v4.4.0",
+			URL:           "https://www.vinyldns.io",
+			Documentation: "https://go-acme.github.io/lego/dns/vinyldns",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "VINYLDNS_ACCESS_KEY", Description: "The VinylDNS API key"},
+				{Name: "VINYLDNS_HOST", Description: "The VinylDNS API URL"},
+				{Name: "VINYLDNS_SECRET_KEY", Description: "The VinylDNS API Secret key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "VINYLDNS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "VINYLDNS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 4)", Default: "4"},
+				{Name: "VINYLDNS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "VINYLDNS_QUOTE_VALUE", Description: "Adds quotes around the TXT record value (Default: false)", Default: "false"},
+				{Name: "VINYLDNS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 30)", Default: "30"},
+			},
+		},
+		{
+			Code:          "virtualname",
+			Name:          "Virtualname",
+			Since:         "v4.30.0",
+			URL:           "https://www.virtualname.es/",
+			Documentation: "https://go-acme.github.io/lego/dns/virtualname",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "VIRTUALNAME_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "VIRTUALNAME_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "VIRTUALNAME_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "VIRTUALNAME_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+				{Name: "VIRTUALNAME_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "vkcloud",
+			Name:          "VK Cloud",
+			Since:         "v4.9.0",
+			URL:           "https://mcs.mail.ru/",
+			Documentation: "https://go-acme.github.io/lego/dns/vkcloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "VK_CLOUD_PASSWORD", Description: "Password for VK Cloud account"},
+				{Name: "VK_CLOUD_PROJECT_ID", Description: "String ID of project in VK Cloud"},
+				{Name: "VK_CLOUD_USERNAME", Description: "Email of VK Cloud account"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "VK_CLOUD_DNS_ENDPOINT", Description: "URL of DNS API. Defaults to https://mcs.mail.ru/public-dns but can be changed for usage with private clouds"},
+				{Name: "VK_CLOUD_DOMAIN_NAME", Description: "Openstack users domain name. Defaults to `users` but can be changed for usage with private clouds"},
+				{Name: "VK_CLOUD_IDENTITY_ENDPOINT", Description: "URL of OpenStack Auth API, Defaults to https://infra.mail.ru:35357/v3/ but can be changed for usage with private clouds"},
+				{Name: "VK_CLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "VK_CLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "VK_CLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "volcengine",
+			Name:          "Volcano Engine/火山引擎",
+			Since:         "v4.19.0",
+			URL:           "https://www.volcengine.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/volcengine",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "VOLC_ACCESSKEY", Description: "Access Key ID (AK)"},
+				{Name: "VOLC_SECRETKEY", Description: "Secret Access Key (SK)"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "VOLC_HOST", Description: "API host"},
+				{Name: "VOLC_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 15)", Default: "15"},
+				{Name: "VOLC_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "VOLC_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 240)", Default: "240"},
+				{Name: "VOLC_REGION", Description: "Region"},
+				{Name: "VOLC_SCHEME", Description: "API scheme"},
+				{Name: "VOLC_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "vscale",
+			Name:          "Vscale",
+			Since:         "v2.0.0",
+			URL:           "https://vscale.io/",
+			Documentation: "https://go-acme.github.io/lego/dns/vscale",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "VSCALE_API_TOKEN", Description: "API token"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "VSCALE_BASE_URL", Description: "API endpoint URL"},
+				{Name: "VSCALE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "VSCALE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "VSCALE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "VSCALE_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "vultr",
+			Name:          "Vultr",
+			Since:         "v0.3.1",
+			URL:           "https://www.vultr.com/",
+			Documentation: "https://go-acme.github.io/lego/dns/vultr",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "VULTR_API_KEY", Description: "API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "VULTR_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "VULTR_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "VULTR_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "VULTR_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "webnames",
+			Name:          "webnames.ru",
+			Since:         "v4.15.0",
+			URL:           "https://www.webnames.ru/",
+			Documentation: "https://go-acme.github.io/lego/dns/webnames",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "WEBNAMESRU_API_KEY", Description: "Domain API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "WEBNAMESRU_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "WEBNAMESRU_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "WEBNAMESRU_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "webnamesca",
+			Name:          "webnames.ca",
+			Since:         "v4.28.0",
+			URL:           "https://www.webnames.ca/",
+			Documentation: "https://go-acme.github.io/lego/dns/webnamesca",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "WEBNAMESCA_API_KEY", Description: "API key"},
+				{Name: "WEBNAMESCA_API_USER", Description: "API username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "WEBNAMESCA_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "WEBNAMESCA_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "WEBNAMESCA_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "WEBNAMESCA_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)", Default: "120"},
+			},
+		},
+		{
+			Code:          "websupport",
+			Name:          "Websupport",
+			Since:         "v4.10.0",
+			URL:           "https://websupport.sk",
+			Documentation: "https://go-acme.github.io/lego/dns/websupport",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "WEBSUPPORT_API_KEY", Description: "API key"},
+				{Name: "WEBSUPPORT_SECRET", Description: "API secret"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "WEBSUPPORT_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "WEBSUPPORT_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "WEBSUPPORT_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "WEBSUPPORT_SEQUENCE_INTERVAL", Description: "Time between sequential requests in seconds (Default: 60)", Default: "60"},
+				{Name: "WEBSUPPORT_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)", Default: "600"},
+			},
+		},
+		{
+			Code:          "wedos",
+			Name:          "WEDOS",
+			Since:         "v4.4.0",
+			URL:           "https://www.wedos.com",
+			Documentation: "https://go-acme.github.io/lego/dns/wedos",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "WEDOS_USERNAME", Description: "Username is the same as for the admin account"},
+				{Name: "WEDOS_WAPI_PASSWORD", Description: "Password needs to be generated and IP allowed in the admin interface"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "WEDOS_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "WEDOS_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "WEDOS_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 600)", Default: "600"},
+				{Name: "WEDOS_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "westcn",
+			Name:          "West.cn/西部数码",
+			Since:         "v4.21.0",
+			URL:           "https://www.west.cn",
+			Documentation: "https://go-acme.github.io/lego/dns/westcn",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "WESTCN_PASSWORD", Description: "API password"},
+				{Name: "WESTCN_USERNAME", Description: "Username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "WESTCN_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "WESTCN_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 10)", Default: "10"},
+				{Name: "WESTCN_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 120)", Default: "120"},
+				{Name: "WESTCN_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "yandex",
+			Name:          "Yandex PDD",
+			Since:         "v3.7.0",
+			URL:           "https://pdd.yandex.com",
+			Documentation: "https://go-acme.github.io/lego/dns/yandex",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "YANDEX_PDD_TOKEN", Description: "Basic authentication username"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "YANDEX_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "YANDEX_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "YANDEX_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "YANDEX_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 21600)", Default: "21600"},
+			},
+		},
+		{
+			Code:          "yandex360",
+			Name:          "Yandex 360",
+			Since:         "v4.14.0",
+			URL:           "https://360.yandex.ru",
+			Documentation: "https://go-acme.github.io/lego/dns/yandex360",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "YANDEX360_OAUTH_TOKEN", Description: "The OAuth Token"},
+				{Name: "YANDEX360_ORG_ID", Description: "The organization ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "YANDEX360_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "YANDEX360_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "YANDEX360_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "YANDEX360_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 21600)", Default: "21600"},
+			},
+		},
+		{
+			Code:          "yandexcloud",
+			Name:          "Yandex Cloud",
+			Since:         "v4.9.0",
+			URL:           "https://cloud.yandex.com",
+			Documentation: "https://go-acme.github.io/lego/dns/yandexcloud",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "YANDEX_CLOUD_FOLDER_ID", Description: "The string id of folder (aka project) in Yandex Cloud"},
+				{Name: "YANDEX_CLOUD_IAM_TOKEN", Description: "The base64 encoded json which contains information about iam token of service account with `dns.admin` permissions"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "YANDEX_CLOUD_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "YANDEX_CLOUD_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "YANDEX_CLOUD_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "zoneedit",
+			Name:          "ZoneEdit",
+			Since:         "v4.25.0",
+			URL:           "https://www.zoneedit.com",
+			Documentation: "https://go-acme.github.io/lego/dns/zoneedit",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ZONEEDIT_AUTH_TOKEN", Description: "Authentication token"},
+				{Name: "ZONEEDIT_USER", Description: "User ID"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ZONEEDIT_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ZONEEDIT_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ZONEEDIT_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+			},
+		},
+		{
+			Code:          "zoneee",
+			Name:          "Zone.ee",
+			Since:         "v2.1.0",
+			URL:           "https://www.zone.ee/",
+			Documentation: "https://go-acme.github.io/lego/dns/zoneee",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ZONEEE_API_KEY", Description: "API key"},
+				{Name: "ZONEEE_API_USER", Description: "API user"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ZONEEE_ENDPOINT", Description: "API endpoint URL"},
+				{Name: "ZONEEE_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ZONEEE_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 5)", Default: "5"},
+				{Name: "ZONEEE_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 300)", Default: "300"},
+			},
+		},
+		{
+			Code:          "zonomi",
+			Name:          "Zonomi",
+			Since:         "v3.5.0",
+			URL:           "https://zonomi.com",
+			Documentation: "https://go-acme.github.io/lego/dns/zonomi",
+			Credentials: []dnsProviderEnvVar{
+				{Name: "ZONOMI_API_KEY", Description: "User API key"},
+			},
+			Additional: []dnsProviderEnvVar{
+				{Name: "ZONOMI_HTTP_TIMEOUT", Description: "API request timeout in seconds (Default: 30)", Default: "30"},
+				{Name: "ZONOMI_POLLING_INTERVAL", Description: "Time between DNS propagation check in seconds (Default: 2)", Default: "2"},
+				{Name: "ZONOMI_PROPAGATION_TIMEOUT", Description: "Maximum waiting time for DNS propagation in seconds (Default: 60)", Default: "60"},
+				{Name: "ZONOMI_TTL", Description: "The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)", Default: "3600"},
+			},
+		},
+	}
+}
+
 func displayDNSHelp(w io.Writer, name string) error {
 	w = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 	ew := &errWriter{w: w}
@@ -0,0 +1,168 @@
+package ctmonitor
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultCrtShURL = "https://crt.sh"
+
+// crt.sh timestamps layout.
+const crtShTimeLayout = "2006-01-02T15:04:05"
+
+var _ Source = (*CrtSh)(nil)
+
+// CrtSh a Source based on the crt.sh search API.
+type CrtSh struct {
+	BaseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewCrtSh creates a new CrtSh.
+func NewCrtSh() *CrtSh {
+	baseURL, _ := url.Parse(defaultCrtShURL)
+
+	return &CrtSh{
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+type crtShEntry struct {
+	ID           int64  `json:"id"`
+	IssuerName   string `json:"issuer_name"`
+	CommonName   string `json:"common_name"`
+	NameValue    string `json:"name_value"`
+	SerialNumber string `json:"serial_number"`
+	NotBefore    string `json:"not_before"`
+	NotAfter     string `json:"not_after"`
+}
+
+// Search returns the certificates logged for the domain and its subdomains.
+func (c *CrtSh) Search(ctx context.Context, domain string) ([]Entry, error) {
+	query := url.Values{}
+	query.Set("q", domain)
+	query.Set("exclude", "expired")
+
+	return c.search(ctx, query)
+}
+
+// SearchSerial returns the certificates logged with the serial number (hex encoded).
+func (c *CrtSh) SearchSerial(ctx context.Context, serial string) ([]Entry, error) {
+	query := url.Values{}
+	query.Set("serial", NormalizeSerial(serial))
+
+	return c.search(ctx, query)
+}
+
+// Download downloads the certificate (DER) identified by the crt.sh ID.
+func (c *CrtSh) Download(ctx context.Context, id int64) (*x509.Certificate, error) {
+	endpoint := c.BaseURL.JoinPath("/")
+
+	query := endpoint.Query()
+	query.Set("d", strconv.FormatInt(id, 10))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	raw, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(raw)
+	if block != nil {
+		raw = block.Bytes
+	}
+
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("crt.sh: certificate %d: %w", id, err)
+	}
+
+	return cert, nil
+}
+
+func (c *CrtSh) search(ctx context.Context, query url.Values) ([]Entry, error) {
+	endpoint := c.BaseURL.JoinPath("/")
+
+	query.Set("output", "json")
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	raw, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []crtShEntry
+
+	err = json.Unmarshal(raw, &results)
+	if err != nil {
+		return nil, fmt.Errorf("crt.sh: unmarshal response: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(results))
+
+	for _, result := range results {
+		entry := Entry{
+			ID:           result.ID,
+			IssuerName:   result.IssuerName,
+			CommonName:   result.CommonName,
+			DNSNames:     strings.Fields(result.NameValue),
+			SerialNumber: result.SerialNumber,
+		}
+
+		entry.NotBefore, err = time.Parse(crtShTimeLayout, result.NotBefore)
+		if err != nil {
+			return nil, fmt.Errorf("crt.sh: entry %d: %w", result.ID, err)
+		}
+
+		entry.NotAfter, err = time.Parse(crtShTimeLayout, result.NotAfter)
+		if err != nil {
+			return nil, fmt.Errorf("crt.sh: entry %d: %w", result.ID, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (c *CrtSh) do(req *http.Request) ([]byte, error) {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("crt.sh: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("crt.sh: read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("crt.sh: unexpected status code: %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	return raw, nil
+}
@@ -0,0 +1,81 @@
+package ctmonitor
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockBuilder() *servermock.Builder[*CrtSh] {
+	return servermock.NewBuilder(
+		func(server *httptest.Server) (*CrtSh, error) {
+			client := NewCrtSh()
+			client.HTTPClient = server.Client()
+			client.BaseURL, _ = url.Parse(server.URL)
+
+			return client, nil
+		},
+		servermock.CheckHeader().
+			WithAccept("application/json"),
+	)
+}
+
+func TestCrtSh_Search(t *testing.T) {
+	client := mockBuilder().
+		Route("GET /",
+			servermock.ResponseFromFixture("crtsh.json"),
+			servermock.CheckQueryParameter().Strict().
+				With("q", "example.com").
+				With("output", "json").
+				With("exclude", "expired")).
+		Build(t)
+
+	entries, err := client.Search(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	require.Len(t, entries, 2)
+
+	expected := Entry{
+		ID:           12345,
+		IssuerName:   "C=US, O=Let's Encrypt, CN=R11",
+		CommonName:   "example.com",
+		DNSNames:     []string{"example.com", "www.example.com"},
+		SerialNumber: "04a1b2c3d4",
+		NotBefore:    time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, expected, entries[0])
+}
+
+func TestCrtSh_Search_error(t *testing.T) {
+	client := mockBuilder().
+		Route("GET /",
+			servermock.RawStringResponse("Service Unavailable").
+				WithStatusCode(503)).
+		Build(t)
+
+	_, err := client.Search(context.Background(), "example.com")
+	require.EqualError(t, err, "crt.sh: unexpected status code: 503: Service Unavailable")
+}
+
+func TestCrtSh_SearchSerial(t *testing.T) {
+	client := mockBuilder().
+		Route("GET /",
+			servermock.ResponseFromFixture("crtsh.json"),
+			servermock.CheckQueryParameter().Strict().
+				With("serial", "4a1b2c3d4").
+				With("output", "json")).
+		Build(t)
+
+	entries, err := client.SearchSerial(context.Background(), "04:A1:B2:C3:D4")
+	require.NoError(t, err)
+
+	require.Len(t, entries, 2)
+}
@@ -0,0 +1,124 @@
+// Package ctmonitor detects the certificates, logged in Certificate Transparency logs,
+// issued for a set of domains but not known by the installation (misissuance, rogue issuance).
+package ctmonitor
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry a certificate found in the CT logs.
+type Entry struct {
+	ID           int64
+	IssuerName   string
+	CommonName   string
+	DNSNames     []string
+	SerialNumber string // hex encoded.
+	NotBefore    time.Time
+	NotAfter     time.Time
+}
+
+// Source searches the CT logs.
+type Source interface {
+	// Search returns the certificates logged for the domain.
+	Search(ctx context.Context, domain string) ([]Entry, error)
+}
+
+// Monitor reports the logged certificates that are not known.
+type Monitor struct {
+	source Source
+	since  time.Time
+
+	mu       sync.Mutex
+	known    map[string]struct{}
+	reported map[string]struct{}
+}
+
+// NewMonitor creates a new Monitor.
+// The certificates issued (notBefore) before since are ignored.
+func NewMonitor(source Source, since time.Time) *Monitor {
+	return &Monitor{
+		source:   source,
+		since:    since,
+		known:    make(map[string]struct{}),
+		reported: make(map[string]struct{}),
+	}
+}
+
+// AddKnown marks the certificates as issued by the installation.
+func (m *Monitor) AddKnown(certs ...*x509.Certificate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, cert := range certs {
+		m.known[NormalizeSerial(cert.SerialNumber.Text(16))] = struct{}{}
+	}
+}
+
+// Check searches the CT logs for the domains,
+// and returns the unknown certificates that have not been already reported by a previous call.
+func (m *Monitor) Check(ctx context.Context, domains []string) ([]Entry, error) {
+	var (
+		unknown []Entry
+		errs    []error
+	)
+
+	for _, domain := range domains {
+		entries, err := m.source.Search(ctx, strings.TrimPrefix(domain, "*."))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", domain, err))
+			continue
+		}
+
+		unknown = append(unknown, m.filter(entries)...)
+	}
+
+	return unknown, errors.Join(errs...)
+}
+
+func (m *Monitor) filter(entries []Entry) []Entry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var unknown []Entry
+
+	for _, entry := range entries {
+		if entry.NotBefore.Before(m.since) {
+			continue
+		}
+
+		serial := NormalizeSerial(entry.SerialNumber)
+
+		if _, ok := m.known[serial]; ok {
+			continue
+		}
+
+		// The precertificate and the certificate have the same serial number.
+		if _, ok := m.reported[serial]; ok {
+			continue
+		}
+
+		m.reported[serial] = struct{}{}
+
+		unknown = append(unknown, entry)
+	}
+
+	return unknown
+}
+
+// NormalizeSerial normalizes a hex encoded serial number (lower case, without leading zeros or separators).
+func NormalizeSerial(serial string) string {
+	serial = strings.ToLower(strings.ReplaceAll(serial, ":", ""))
+
+	serial = strings.TrimLeft(serial, "0")
+	if serial == "" {
+		return "0"
+	}
+
+	return serial
+}
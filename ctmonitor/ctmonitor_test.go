@@ -0,0 +1,69 @@
+package ctmonitor
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource map[string][]Entry
+
+func (f fakeSource) Search(_ context.Context, domain string) ([]Entry, error) {
+	entries, ok := f[domain]
+	if !ok {
+		return nil, errors.New("unavailable")
+	}
+
+	return entries, nil
+}
+
+func TestMonitor_Check(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	source := fakeSource{
+		"example.com": {
+			{ID: 1, SerialNumber: "04a1b2c3d4", NotBefore: now.Add(-time.Hour)},
+			{ID: 2, SerialNumber: "0badcafe", NotBefore: now.Add(-time.Hour)},
+			// precertificate
+			{ID: 3, SerialNumber: "0BADCAFE", NotBefore: now.Add(-time.Hour)},
+			// too old
+			{ID: 4, SerialNumber: "beef", NotBefore: now.Add(-48 * time.Hour)},
+		},
+	}
+
+	monitor := NewMonitor(source, now.Add(-24*time.Hour))
+
+	serial, _ := new(big.Int).SetString("4a1b2c3d4", 16)
+
+	monitor.AddKnown(&x509.Certificate{SerialNumber: serial})
+
+	unknown, err := monitor.Check(context.Background(), []string{"*.example.com"})
+	require.NoError(t, err)
+
+	require.Len(t, unknown, 1)
+	assert.EqualValues(t, 2, unknown[0].ID)
+
+	// already reported.
+	unknown, err = monitor.Check(context.Background(), []string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Empty(t, unknown)
+}
+
+func TestMonitor_Check_error(t *testing.T) {
+	monitor := NewMonitor(fakeSource{}, time.Time{})
+
+	_, err := monitor.Check(context.Background(), []string{"example.org"})
+	require.EqualError(t, err, "example.org: unavailable")
+}
+
+func TestNormalizeSerial(t *testing.T) {
+	assert.Equal(t, "4a1b2", NormalizeSerial("00:04:A1:B2"))
+	assert.Equal(t, "0", NormalizeSerial("00"))
+}
@@ -0,0 +1,186 @@
+package inprocess
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/go-acme/lego/v4/platform/tester/challtestsrv"
+	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cmdNameChallSrv = "pebble-challtestsrv"
+
+// challTestSrv a pebble-challtestsrv process listening on ephemeral ports.
+type challTestSrv struct {
+	Client *challtestsrv.Client
+
+	DNSAddr  string
+	HTTPAddr string
+	TLSAddr  string
+}
+
+// startChallTestSrv launches pebble-challtestsrv.
+// The test is skipped if the binary is not installed.
+func startChallTestSrv(t *testing.T) *challTestSrv {
+	t.Helper()
+
+	if _, err := exec.LookPath(cmdNameChallSrv); err != nil {
+		t.Skipf("skipping because %s binary not found", cmdNameChallSrv)
+	}
+
+	srv := &challTestSrv{
+		DNSAddr:  freeAddr(t),
+		HTTPAddr: freeAddr(t),
+		TLSAddr:  freeAddr(t),
+	}
+
+	managementAddr := freeAddr(t)
+
+	//nolint:gosec // the arguments are controlled by the test.
+	cmd := exec.CommandContext(t.Context(), cmdNameChallSrv,
+		"-management", managementAddr,
+		"-dns01", srv.DNSAddr,
+		"-http01", srv.HTTPAddr,
+		"-tlsalpn01", srv.TLSAddr,
+		"-https01", "",
+		"-doh", "",
+		"-defaultIPv4", "127.0.0.1",
+		"-defaultIPv6", "",
+	)
+
+	require.NoError(t, cmd.Start())
+
+	t.Cleanup(func() { _ = cmd.Wait() })
+
+	client, err := challtestsrv.NewClient("http://" + managementAddr)
+	require.NoError(t, err)
+
+	srv.Client = client
+
+	err = wait.For("challtestsrv", 10*time.Second, 100*time.Millisecond, func() (bool, error) {
+		conn, errD := net.Dial("tcp", managementAddr)
+		if errD != nil {
+			return false, errD
+		}
+
+		return true, conn.Close()
+	})
+	require.NoError(t, err)
+
+	return srv
+}
+
+// Validator validates the challenges with the records and the responses served by challtestsrv.
+func (s *challTestSrv) Validator() pebble.Validator {
+	solvers := pebble.SolverValidator(s.HTTPAddr, s.TLSAddr)
+
+	return func(chlg acme.Challenge, identifier acme.Identifier, keyAuth string) error {
+		if chlg.Type != string(challenge.DNS01) {
+			return solvers(chlg, identifier, keyAuth)
+		}
+
+		// The record of a wildcard domain is the record of its base domain.
+		info := dns01.GetChallengeInfo(strings.TrimPrefix(identifier.Value, "*."), keyAuth)
+
+		m := new(dns.Msg)
+		m.SetQuestion(info.FQDN, dns.TypeTXT)
+
+		r, err := dns.Exchange(m, s.DNSAddr)
+		if err != nil {
+			return err
+		}
+
+		for _, rr := range r.Answer {
+			if txt, ok := rr.(*dns.TXT); ok && len(txt.Txt) > 0 && txt.Txt[0] == info.Value {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("no TXT record %q for %s", info.Value, info.FQDN)
+	}
+}
+
+func TestChallengeDNS_Client_Obtain_challtestsrv(t *testing.T) {
+	srv := startChallTestSrv(t)
+
+	runner := NewRunner(t, pebble.WithValidator(srv.Validator()))
+
+	client, _ := newClient(t, runner)
+
+	client.Challenge.Remove(challenge.HTTP01)
+	client.Challenge.Remove(challenge.TLSALPN01)
+
+	err := client.Challenge.SetDNS01Provider(challtestsrv.NewDNSProvider(srv.Client),
+		dns01.AddRecursiveNameservers([]string{srv.DNSAddr}),
+		dns01.DisableAuthoritativeNssPropagationRequirement(),
+	)
+	require.NoError(t, err)
+
+	resource, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{"*." + testDomain, testDomain},
+		Bundle:  true,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, "*."+testDomain, resource.Domain)
+	assert.NotEmpty(t, resource.Certificate)
+}
+
+func TestChallengeHTTP_Client_Obtain_challtestsrv(t *testing.T) {
+	srv := startChallTestSrv(t)
+
+	runner := NewRunner(t, pebble.WithValidator(srv.Validator()))
+
+	client, _ := newClient(t, runner)
+
+	client.Challenge.Remove(challenge.TLSALPN01)
+
+	err := client.Challenge.SetHTTP01Provider(challtestsrv.NewHTTPProvider(srv.Client))
+	require.NoError(t, err)
+
+	resource, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{testDomain},
+		Bundle:  true,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, testDomain, resource.Domain)
+	assert.NotEmpty(t, resource.Certificate)
+}
+
+func TestChallengeTLS_Client_Obtain_challtestsrv(t *testing.T) {
+	srv := startChallTestSrv(t)
+
+	runner := NewRunner(t, pebble.WithValidator(srv.Validator()))
+
+	client, _ := newClient(t, runner)
+
+	client.Challenge.Remove(challenge.HTTP01)
+
+	err := client.Challenge.SetTLSALPN01Provider(challtestsrv.NewTLSALPNProvider(srv.Client))
+	require.NoError(t, err)
+
+	resource, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{testDomain},
+		Bundle:  true,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, testDomain, resource.Domain)
+	assert.NotEmpty(t, resource.Certificate)
+}
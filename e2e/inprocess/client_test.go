@@ -0,0 +1,182 @@
+package inprocess
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge/http01"
+	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengeHTTP_Client_Obtain(t *testing.T) {
+	runner := NewRunner(t)
+
+	client, _ := newClient(t, runner)
+
+	resource, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{testDomain},
+		Bundle:  true,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, testDomain, resource.Domain)
+	assert.NotEmpty(t, resource.CertURL)
+	assert.NotEmpty(t, resource.Certificate)
+	assert.NotEmpty(t, resource.IssuerCertificate)
+	assert.Empty(t, resource.CSR)
+}
+
+func TestChallengeHTTP_Client_Obtain_profile(t *testing.T) {
+	runner := NewRunner(t, pebble.WithProfiles(map[string]string{"shortlived": "A short-lived certificate."}))
+
+	client, _ := newClient(t, runner)
+
+	resource, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{testDomain},
+		Bundle:  true,
+		Profile: "shortlived",
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, testDomain, resource.Domain)
+	assert.NotEmpty(t, resource.Certificate)
+}
+
+func TestChallengeHTTP_Client_Obtain_notBefore_notAfter(t *testing.T) {
+	runner := NewRunner(t)
+
+	client, _ := newClient(t, runner)
+
+	now := time.Now().UTC()
+
+	resource, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains:   []string{testDomain},
+		NotBefore: now.Add(1 * time.Hour),
+		NotAfter:  now.Add(2 * time.Hour),
+		Bundle:    true,
+	})
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(resource.Certificate)
+	require.NoError(t, err)
+
+	assert.WithinDuration(t, now.Add(1*time.Hour), cert.NotBefore, 1*time.Second)
+	assert.WithinDuration(t, now.Add(2*time.Hour), cert.NotAfter, 1*time.Second)
+}
+
+func TestChallengeTLS_Client_ObtainForCSR(t *testing.T) {
+	runner := NewRunner(t)
+
+	client, _ := newClient(t, runner)
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	csrRaw, err := certcrypto.CreateCSR(privateKey, certcrypto.CSROptions{
+		Domain: testDomain,
+		SAN:    []string{testDomain, testDomainSub},
+	})
+	require.NoError(t, err)
+
+	csr, err := x509.ParseCertificateRequest(csrRaw)
+	require.NoError(t, err)
+
+	resource, err := client.Certificate.ObtainForCSR(certificate.ObtainForCSRRequest{
+		CSR:    csr,
+		Bundle: true,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, testDomain, resource.Domain)
+	assert.NotEmpty(t, resource.Certificate)
+	assert.NotEmpty(t, resource.CSR)
+}
+
+func TestChallengeHTTP_Client_Registration_QueryRegistration(t *testing.T) {
+	runner := NewRunner(t)
+
+	client, user := newClient(t, runner)
+
+	resource, err := client.Registration.QueryRegistration()
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, "valid", resource.Body.Status)
+	assert.Equal(t, user.registration.URI, resource.URI)
+}
+
+func TestRegistrar_UpdateAccount(t *testing.T) {
+	runner := NewRunner(t)
+
+	client, user := newClient(t, runner)
+
+	require.Equal(t, []string{"mailto:" + runner.Email}, user.registration.Body.Contact)
+
+	user.email = "lego2@example.com"
+
+	resource, err := client.Registration.UpdateRegistration(registration.RegisterOptions{TermsOfServiceAgreed: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"mailto:lego2@example.com"}, resource.Body.Contact)
+	assert.Equal(t, user.registration.URI, resource.URI)
+}
+
+// newClient creates a lego client with a registered account,
+// and with the HTTP-01 and TLS-ALPN-01 solvers listening on the addresses of the runner.
+func newClient(t *testing.T, runner *Runner) (*lego.Client, *fakeUser) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	user := &fakeUser{email: runner.Email, privateKey: privateKey}
+
+	config := lego.NewConfig(user)
+	config.CADirURL = runner.Server.URL()
+
+	client, err := lego.NewClient(config)
+	require.NoError(t, err)
+
+	host, port, err := net.SplitHostPort(runner.HTTPAddr)
+	require.NoError(t, err)
+
+	err = client.Challenge.SetHTTP01Provider(http01.NewProviderServer(host, port))
+	require.NoError(t, err)
+
+	host, port, err = net.SplitHostPort(runner.TLSAddr)
+	require.NoError(t, err)
+
+	err = client.Challenge.SetTLSALPN01Provider(tlsalpn01.NewProviderServer(host, port))
+	require.NoError(t, err)
+
+	user.registration, err = client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
+	require.NoError(t, err)
+
+	return client, user
+}
+
+type fakeUser struct {
+	email        string
+	privateKey   crypto.PrivateKey
+	registration *registration.Resource
+}
+
+func (f *fakeUser) GetEmail() string                        { return f.email }
+func (f *fakeUser) GetRegistration() *registration.Resource { return f.registration }
+func (f *fakeUser) GetPrivateKey() crypto.PrivateKey        { return f.privateKey }
@@ -0,0 +1,235 @@
+package inprocess
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/idna"
+)
+
+const (
+	testDomain         = "acme.localhost"
+	testDomainSub      = "lego.localhost"
+	testDomainSubSub   = "acme.lego.localhost"
+	testDomainNonASCII = "légô.localhost"
+)
+
+func TestChallengeHTTP_Run_Renew_Revoke(t *testing.T) {
+	runner := NewRunner(t)
+
+	err := runner.Run(append(runner.HTTPArgs(), "-d", testDomain, "run")...)
+	require.NoError(t, err)
+
+	first := readCertificate(t, runner, testDomain)
+
+	assert.Equal(t, []string{testDomain}, first.DNSNames)
+
+	err = runner.Run(append(runner.HTTPArgs(), "-d", testDomain, "renew", "--days", "91", "--no-random-sleep")...)
+	require.NoError(t, err)
+
+	second := readCertificate(t, runner, testDomain)
+
+	assert.NotEqual(t, first.SerialNumber, second.SerialNumber)
+
+	err = runner.Run("-d", testDomain, "revoke")
+	require.NoError(t, err)
+
+	assert.True(t, runner.Server.IsRevoked(second))
+}
+
+func TestChallengeHTTP_Run_idempotent(t *testing.T) {
+	runner := NewRunner(t)
+
+	err := runner.Run(append(runner.HTTPArgs(), "-d", testDomain, "run")...)
+	require.NoError(t, err)
+
+	first := readCertificate(t, runner, testDomain)
+
+	err = runner.Run(append(runner.HTTPArgs(), "-d", testDomain, "run")...)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.SerialNumber, readCertificate(t, runner, testDomain).SerialNumber)
+
+	err = runner.Run(append(runner.HTTPArgs(), "-d", testDomain, "run", "--force")...)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.SerialNumber, readCertificate(t, runner, testDomain).SerialNumber)
+}
+
+func TestChallengeHTTP_Renew_summary(t *testing.T) {
+	runner := NewRunner(t)
+
+	err := runner.Run(append(runner.HTTPArgs(), "-d", testDomain, "run")...)
+	require.NoError(t, err)
+
+	summaryFile := filepath.Join(t.TempDir(), "summary.json")
+
+	err = runner.Run(append(runner.HTTPArgs(), "-d", testDomain, "renew", "--ari-disable", "--summary-file", summaryFile)...)
+	require.NoError(t, err)
+
+	assertSummary(t, summaryFile, "skipped", "the certificate is not due for renewal")
+
+	err = runner.Run(append(runner.HTTPArgs(), "-d", testDomain, "renew", "--days", "91", "--no-random-sleep", "--summary-file", summaryFile)...)
+	require.NoError(t, err)
+
+	assertSummary(t, summaryFile, "renewed", "the certificate is due for renewal")
+}
+
+func assertSummary(t *testing.T, filename, decision, reason string) {
+	t.Helper()
+
+	raw, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	var summary struct {
+		Certificates []struct {
+			Domain   string `json:"domain"`
+			Decision string `json:"decision"`
+			Reason   string `json:"reason"`
+		} `json:"certificates"`
+	}
+
+	err = json.Unmarshal(raw, &summary)
+	require.NoError(t, err)
+
+	require.Len(t, summary.Certificates, 1)
+	assert.Equal(t, testDomain, summary.Certificates[0].Domain)
+	assert.Equal(t, decision, summary.Certificates[0].Decision)
+	assert.Equal(t, reason, summary.Certificates[0].Reason)
+}
+
+func TestChallengeTLS_Run(t *testing.T) {
+	runner := NewRunner(t)
+
+	err := runner.Run(append(runner.TLSArgs(), "-d", testDomain, "run")...)
+	require.NoError(t, err)
+
+	cert := readCertificate(t, runner, testDomain)
+
+	assert.Equal(t, []string{testDomain}, cert.DNSNames)
+}
+
+func TestChallengeTLS_Run_IP(t *testing.T) {
+	runner := NewRunner(t)
+
+	err := runner.Run(append(runner.TLSArgs(), "-d", "127.0.0.1", "run")...)
+	require.NoError(t, err)
+
+	cert := readCertificate(t, runner, "127.0.0.1")
+
+	require.Len(t, cert.IPAddresses, 1)
+	assert.Equal(t, "127.0.0.1", cert.IPAddresses[0].String())
+}
+
+func TestChallengeTLS_Run_CSR(t *testing.T) {
+	testCases := []struct {
+		desc string
+		der  bool
+	}{
+		{desc: "DER", der: true},
+		{desc: "PEM", der: false},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			runner := NewRunner(t)
+
+			err := runner.Run(append(runner.TLSArgs(), "--csr", createTestCSRFile(t, test.der), "run")...)
+			require.NoError(t, err)
+
+			cert := readCertificate(t, runner, testDomain)
+
+			assert.ElementsMatch(t, []string{testDomain, testDomainSub}, cert.DNSNames)
+		})
+	}
+}
+
+func TestChallengeTLS_Run_Revoke(t *testing.T) {
+	runner := NewRunner(t)
+
+	err := runner.Run(append(runner.TLSArgs(), "-d", testDomainSub, "-d", testDomainSubSub, "run")...)
+	require.NoError(t, err)
+
+	cert := readCertificate(t, runner, testDomainSub)
+
+	assert.ElementsMatch(t, []string{testDomainSub, testDomainSubSub}, cert.DNSNames)
+
+	err = runner.Run("-d", testDomainSub, "revoke")
+	require.NoError(t, err)
+
+	assert.True(t, runner.Server.IsRevoked(cert))
+}
+
+func TestChallengeTLS_Run_Revoke_nonASCII(t *testing.T) {
+	runner := NewRunner(t)
+
+	err := runner.Run(append(runner.TLSArgs(), "-d", testDomainNonASCII, "run")...)
+	require.NoError(t, err)
+
+	cert := readCertificate(t, runner, testDomainNonASCII)
+
+	assert.Equal(t, []string{"xn--lg-bja9b.localhost"}, cert.DNSNames)
+
+	err = runner.Run("-d", testDomainNonASCII, "revoke")
+	require.NoError(t, err)
+
+	assert.True(t, runner.Server.IsRevoked(cert))
+}
+
+func readCertificate(t *testing.T, runner *Runner, domain string) *x509.Certificate {
+	t.Helper()
+
+	filename, err := idna.ToASCII(domain)
+	require.NoError(t, err)
+
+	raw, err := os.ReadFile(runner.CertificatePath(filename + ".crt"))
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(raw)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func TestSelfTest(t *testing.T) {
+	runner := NewRunner(t)
+
+	err := runner.Run(append(runner.HTTPArgs(), "-d", testDomain, "selftest")...)
+	require.NoError(t, err)
+}
+
+func createTestCSRFile(t *testing.T, der bool) string {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	csr, err := certcrypto.CreateCSR(privateKey, certcrypto.CSROptions{
+		Domain: testDomain,
+		SAN:    []string{testDomain, testDomainSub},
+	})
+	require.NoError(t, err)
+
+	filename := filepath.Join(t.TempDir(), "csr.pem")
+
+	if der {
+		filename = filepath.Join(t.TempDir(), "csr.der")
+	} else {
+		csr = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})
+	}
+
+	err = os.WriteFile(filename, csr, 0o600)
+	require.NoError(t, err)
+
+	return filename
+}
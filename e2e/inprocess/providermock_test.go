@@ -0,0 +1,102 @@
+package inprocess
+
+import (
+	"errors"
+	"net"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/challenge/dns01/dnsmock"
+	"github.com/go-acme/lego/v4/challenge/http01/httpmock"
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengeDNS_Client_Obtain_dnsmock(t *testing.T) {
+	t.Setenv("LEGO_DISABLE_CNAME_SUPPORT", "true")
+
+	provider := dnsmock.NewProvider()
+
+	// The server validates the challenges with the records presented by the mock.
+	runner := NewRunner(t, pebble.WithValidator(func(chlg acme.Challenge, identifier acme.Identifier, keyAuth string) error {
+		if chlg.Type != string(challenge.DNS01) {
+			return errors.New("unexpected challenge type: " + chlg.Type)
+		}
+
+		info := dns01.GetChallengeInfo(strings.TrimPrefix(identifier.Value, "*."), keyAuth)
+
+		if !slices.Contains(provider.Records()[info.EffectiveFQDN], info.Value) {
+			return errors.New("no TXT record for " + info.EffectiveFQDN)
+		}
+
+		return nil
+	}))
+
+	client, _ := newClient(t, runner)
+
+	client.Challenge.Remove(challenge.HTTP01)
+	client.Challenge.Remove(challenge.TLSALPN01)
+
+	// The records are not published to any nameserver.
+	err := client.Challenge.SetDNS01Provider(provider,
+		dns01.WrapPreCheck(func(_, _, _ string, _ dns01.PreCheckFunc) (bool, error) {
+			return true, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	resource, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{"*." + testDomain, testDomain},
+		Bundle:  true,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, "*."+testDomain, resource.Domain)
+
+	provider.AssertCalls(t, 2, 2)
+	provider.AssertAllCleanedUp(t)
+}
+
+func TestChallengeHTTP_Client_Obtain_httpmock(t *testing.T) {
+	runner := NewRunner(t)
+
+	provider := httpmock.NewProvider()
+
+	// The mock serves the challenge responses on the address contacted by the server.
+	listener, err := net.Listen("tcp", runner.HTTPAddr)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(provider)
+	_ = server.Listener.Close()
+	server.Listener = listener
+	server.Start()
+
+	t.Cleanup(server.Close)
+
+	client, _ := newClient(t, runner)
+
+	client.Challenge.Remove(challenge.TLSALPN01)
+
+	err = client.Challenge.SetHTTP01Provider(provider)
+	require.NoError(t, err)
+
+	resource, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{testDomain},
+		Bundle:  true,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, testDomain, resource.Domain)
+
+	provider.AssertCalls(t, 1, 1)
+	provider.AssertAllCleanedUp(t)
+}
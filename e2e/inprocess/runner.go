@@ -0,0 +1,117 @@
+// Package inprocess runs the lego CLI end-to-end without external binaries:
+// the ACME server is an in-process fake Pebble, the standalone solvers listen on ephemeral ports,
+// and the CLI commands are called through their urfave/cli entry points.
+package inprocess
+
+import (
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/cmd"
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/go-acme/lego/v4/platform/pebble/pebbletest"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+const caCertificatesEnvVar = "LEGO_CA_CERTIFICATES"
+
+// Runner runs the lego CLI against an in-process ACME server.
+type Runner struct {
+	Server *pebble.Server
+
+	// Path the directory used to store the lego data.
+	Path string
+
+	// HTTPAddr the address of the HTTP-01 standalone solver.
+	HTTPAddr string
+
+	// TLSAddr the address of the TLS-ALPN-01 standalone solver.
+	TLSAddr string
+
+	// Email the email of the account.
+	Email string
+}
+
+// NewRunner creates a Runner.
+// The server validates the challenges by contacting the standalone solvers.
+//
+// It modifies the environment variable LEGO_CA_CERTIFICATES,
+// so it cannot be used in parallel tests.
+func NewRunner(t *testing.T, options ...pebble.Option) *Runner {
+	t.Helper()
+
+	r := &Runner{
+		Path:     t.TempDir(),
+		HTTPAddr: freeAddr(t),
+		TLSAddr:  freeAddr(t),
+		Email:    "lego@example.com",
+	}
+
+	options = append([]pebble.Option{pebble.WithValidator(pebble.SolverValidator(r.HTTPAddr, r.TLSAddr))}, options...)
+
+	r.Server = pebbletest.NewServer(t, options...)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+
+	err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: r.Server.TLSCertificate().Raw}), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv(caCertificatesEnvVar, caFile)
+
+	return r
+}
+
+// Run runs the CLI with the given arguments.
+// The global flags related to the server, the account, and the storage are added by the runner.
+func (r *Runner) Run(args ...string) error {
+	app := cli.NewApp()
+	app.Name = "lego"
+	app.HelpName = "lego"
+
+	app.Flags = cmd.CreateFlags(r.Path)
+	app.Before = cmd.Before
+	app.Commands = cmd.CreateCommands()
+
+	globals := []string{
+		"lego",
+		"--server", r.Server.URL(),
+		"--path", r.Path,
+		"--email", r.Email,
+		"--accept-tos",
+	}
+
+	return app.Run(append(globals, args...))
+}
+
+// HTTPArgs returns the flags to use the HTTP-01 standalone solver.
+func (r *Runner) HTTPArgs() []string {
+	return []string{"--http", "--http.port", r.HTTPAddr}
+}
+
+// TLSArgs returns the flags to use the TLS-ALPN-01 standalone solver.
+func (r *Runner) TLSArgs() []string {
+	return []string{"--tls", "--tls.port", r.TLSAddr}
+}
+
+// CertificatePath returns the path of a certificate file managed by the CLI (e.g. "example.com.crt").
+func (r *Runner) CertificatePath(filename string) string {
+	return filepath.Join(r.Path, "certificates", filename)
+}
+
+// freeAddr returns a local address with a port available at the time of the call.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := listener.Addr().String()
+
+	require.NoError(t, listener.Close())
+
+	return addr
+}
@@ -13,6 +13,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 	"text/template"
@@ -122,6 +123,7 @@ func generateCLIHelp(models *descriptors.Providers) error {
 			"safe": func(src string) string {
 				return strings.ReplaceAll(src, "`", "'")
 			},
+			"defaultValue": defaultValue,
 		}).ParseFS(templateFS, cliTemplate),
 	).Execute(b, models)
 	if err != nil {
@@ -139,6 +141,19 @@ func generateCLIHelp(models *descriptors.Providers) error {
 	return err
 }
 
+// defaultValueRegexp matches the default value in the description of an environment variable (e.g. "(Default: 60)").
+var defaultValueRegexp = regexp.MustCompile(`\((?i:default):\s*([^)]+)\)`)
+
+// defaultValue extracts the default value from the description of an environment variable.
+func defaultValue(description string) string {
+	match := defaultValueRegexp.FindStringSubmatch(description)
+	if match == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(match[1])
+}
+
 func generateReadMe(models *descriptors.Providers) error {
 	tpl := html.Must(html.New(filepath.Base(readmeTemplate)).ParseFS(templateFS, readmeTemplate))
 	providers := orderProviders(models)
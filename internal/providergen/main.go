@@ -0,0 +1,230 @@
+// Scaffolds a new DNS provider from a short TOML spec.
+//
+//	go run ./internal/providergen -spec example.toml
+//
+// The spec contains the name, the code, the URLs, and the credentials of the provider:
+//
+//	Name = "Example"
+//	Code = "example"
+//	Since = "v4.31.0"
+//	URL = "https://example.com"
+//	APIURL = "https://api.example.com"
+//	APIDoc = "https://api.example.com/docs"
+//
+//	[Credentials]
+//	  API_KEY = "The API key"
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+)
+
+//go:embed templates
+var templates embed.FS
+
+const templatesRoot = "templates"
+
+var codePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// initialisms used to build the Go names of the credentials.
+var initialisms = []string{"API", "DNS", "HTTP", "ID", "IP", "TTL", "URL"}
+
+// Spec the description of the provider to scaffold.
+type Spec struct {
+	Name        string
+	Code        string
+	Since       string
+	URL         string
+	APIURL      string
+	APIDoc      string
+	Credentials map[string]string
+}
+
+// Credential a credential of the provider.
+type Credential struct {
+	Env         string // name of the environment variable without the namespace (e.g. API_KEY).
+	Field       string // name of the field of the Config struct (e.g. APIKey).
+	Description string
+}
+
+type data struct {
+	Spec
+
+	Package      string
+	EnvNamespace string
+	Credentials  []Credential
+}
+
+func main() {
+	specPath := flag.String("spec", "", "path to the TOML spec of the provider")
+	root := flag.String("root", ".", "root directory of the lego repository")
+
+	flag.Parse()
+
+	if *specPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir, err := generate(*root, *specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("The provider has been scaffolded in %s.\n", dir)
+	fmt.Println("Implement the API client, then run 'make generate-dns' to update the documentation and the providers list.")
+}
+
+func generate(root, specPath string) (string, error) {
+	var spec Spec
+
+	_, err := toml.DecodeFile(specPath, &spec)
+	if err != nil {
+		return "", fmt.Errorf("read spec: %w", err)
+	}
+
+	d, err := newData(spec)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(root, "providers", "dns", d.Package)
+
+	_, err = os.Stat(dir)
+	if err == nil {
+		return "", fmt.Errorf("the directory %s already exists", dir)
+	}
+
+	err = fs.WalkDir(templates, templatesRoot, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(templatesRoot, path)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, strings.ReplaceAll(strings.TrimSuffix(rel, ".tmpl"), "provider", d.Package))
+
+		return render(path, target, d)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func newData(spec Spec) (*data, error) {
+	if spec.Name == "" {
+		return nil, errors.New("the name is required")
+	}
+
+	if !codePattern.MatchString(spec.Code) {
+		return nil, fmt.Errorf("invalid code %q: must match %s", spec.Code, codePattern)
+	}
+
+	if len(spec.Credentials) == 0 {
+		return nil, errors.New("at least one credential is required")
+	}
+
+	d := &data{
+		Spec:         spec,
+		Package:      strings.ReplaceAll(spec.Code, "-", ""),
+		EnvNamespace: strings.ToUpper(strings.ReplaceAll(spec.Code, "-", "")) + "_",
+	}
+
+	if d.APIURL == "" {
+		d.APIURL = "https://api.example.com"
+	}
+
+	for env, desc := range spec.Credentials {
+		env = strings.ToUpper(env)
+
+		d.Credentials = append(d.Credentials, Credential{Env: env, Field: toGoName(env), Description: desc})
+	}
+
+	slices.SortFunc(d.Credentials, func(a, b Credential) int {
+		return strings.Compare(a.Env, b.Env)
+	})
+
+	return d, nil
+}
+
+func render(tmplPath, target string, d *data) error {
+	tmpl, err := template.New(filepath.Base(tmplPath)).Funcs(template.FuncMap{
+		"lowerFirst": lowerFirst,
+	}).ParseFS(templates, tmplPath)
+	if err != nil {
+		return err
+	}
+
+	b := &bytes.Buffer{}
+
+	err = tmpl.Execute(b, d)
+	if err != nil {
+		return fmt.Errorf("execute %s: %w", tmplPath, err)
+	}
+
+	content := b.Bytes()
+
+	if filepath.Ext(target) == ".go" {
+		content, err = format.Source(content)
+		if err != nil {
+			return fmt.Errorf("format %s: %w", target, err)
+		}
+	}
+
+	err = os.MkdirAll(filepath.Dir(target), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(target, content, 0o644)
+}
+
+// toGoName converts an environment variable name to a Go name (e.g. API_KEY to APIKey).
+func toGoName(env string) string {
+	var name strings.Builder
+
+	for part := range strings.SplitSeq(env, "_") {
+		if part == "" {
+			continue
+		}
+
+		if slices.Contains(initialisms, part) {
+			name.WriteString(part)
+			continue
+		}
+
+		name.WriteString(part[:1] + strings.ToLower(part[1:]))
+	}
+
+	return name.String()
+}
+
+func lowerFirst(s string) string {
+	for _, initialism := range initialisms {
+		if strings.HasPrefix(s, initialism) {
+			return strings.ToLower(initialism) + s[len(initialism):]
+		}
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
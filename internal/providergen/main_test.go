@@ -0,0 +1,129 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+Name = "Example"
+Code = "example-dns"
+Since = "v4.31.0"
+URL = "https://example.com"
+APIURL = "https://api.example.com"
+APIDoc = "https://api.example.com/docs"
+
+[Credentials]
+  API_KEY = "The API key"
+  SECRET = "The API secret"
+`
+
+func writeSpec(t *testing.T, content string) string {
+	t.Helper()
+
+	specPath := filepath.Join(t.TempDir(), "spec.toml")
+
+	err := os.WriteFile(specPath, []byte(content), 0o600)
+	require.NoError(t, err)
+
+	return specPath
+}
+
+func Test_generate(t *testing.T) {
+	root := t.TempDir()
+
+	dir, err := generate(root, writeSpec(t, testSpec))
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(root, "providers", "dns", "exampledns"), dir)
+
+	files := []string{
+		"exampledns.go",
+		"exampledns_test.go",
+		"exampledns.toml",
+		"internal/client.go",
+		"internal/client_test.go",
+		"internal/types.go",
+		"internal/fixtures/add_record.json",
+		"internal/fixtures/add_record-request.json",
+		"internal/fixtures/error.json",
+	}
+
+	for _, file := range files {
+		assert.FileExists(t, filepath.Join(dir, file))
+
+		if filepath.Ext(file) != ".go" {
+			continue
+		}
+
+		_, err = parser.ParseFile(token.NewFileSet(), filepath.Join(dir, file), nil, parser.AllErrors)
+		require.NoError(t, err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "exampledns.go"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(content), `EnvAPIKey = envNamespace + "API_KEY"`)
+	assert.Contains(t, string(content), `envNamespace = "EXAMPLEDNS_"`)
+	assert.Contains(t, string(content), `redact.Register(config.APIKey, config.Secret)`)
+
+	_, err = generate(root, writeSpec(t, testSpec))
+	require.ErrorContains(t, err, "already exists")
+}
+
+func Test_generate_invalid(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		spec     string
+		expected string
+	}{
+		{
+			desc:     "missing name",
+			spec:     `Code = "example"`,
+			expected: "the name is required",
+		},
+		{
+			desc: "invalid code",
+			spec: `Name = "Example"
+Code = "Example"`,
+			expected: `invalid code "Example": must match ^[a-z][a-z0-9-]*$`,
+		},
+		{
+			desc: "missing credentials",
+			spec: `Name = "Example"
+Code = "example"`,
+			expected: "at least one credential is required",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := generate(t.TempDir(), writeSpec(t, test.spec))
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func Test_toGoName(t *testing.T) {
+	testCases := []struct {
+		env      string
+		expected string
+	}{
+		{env: "API_KEY", expected: "APIKey"},
+		{env: "SECRET", expected: "Secret"},
+		{env: "CLIENT_ID", expected: "ClientID"},
+		{env: "API_URL", expected: "APIURL"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.env, func(t *testing.T) {
+			assert.Equal(t, test.expected, toGoName(test.env))
+		})
+	}
+}
@@ -50,15 +50,23 @@ func NewClient(config *Config) (*Client, error) {
 		return nil, err
 	}
 
+	if config.BadNonceRetries != 0 {
+		core.SetBadNonceRetries(config.BadNonceRetries)
+	}
+
+	core.SetLogger(config.Logger)
+
 	solversManager := resolver.NewSolversManager(core)
 
 	prober := resolver.NewProber(solversManager)
 
 	options := certificate.CertifierOptions{
-		KeyType:             config.Certificate.KeyType,
-		Timeout:             config.Certificate.Timeout,
-		OverallRequestLimit: config.Certificate.OverallRequestLimit,
-		DisableCommonName:   config.Certificate.DisableCommonName,
+		KeyType:                 config.Certificate.KeyType,
+		Timeout:                 config.Certificate.Timeout,
+		OrderPollInterval:       config.Certificate.OrderPollInterval,
+		OrderPollAttemptTimeout: config.Certificate.OrderPollAttemptTimeout,
+		OverallRequestLimit:     config.Certificate.OverallRequestLimit,
+		DisableCommonName:       config.Certificate.DisableCommonName,
 	}
 
 	certifier := certificate.NewCertifier(core, prober, options)
@@ -80,3 +88,15 @@ func (c *Client) GetToSURL() string {
 func (c *Client) GetExternalAccountRequired() bool {
 	return c.core.GetDirectory().Meta.ExternalAccountRequired
 }
+
+// GetProfiles returns the certificate profiles advertised by the ACME server (draft-ietf-acme-profiles):
+// the names of the profiles and their human-readable descriptions.
+func (c *Client) GetProfiles() map[string]string {
+	return c.core.GetDirectory().Meta.Profiles
+}
+
+// GetCAAIdentities returns the hostnames that the ACME server recognizes as referring to itself
+// for the purposes of CAA record validation.
+func (c *Client) GetCAAIdentities() []string {
+	return c.core.GetDirectory().Meta.CaaIdentities
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/registration"
 )
 
@@ -46,6 +47,13 @@ type Config struct {
 	UserAgent   string
 	HTTPClient  *http.Client
 	Certificate CertificateConfig
+	// BadNonceRetries the maximum number of retries of a request rejected with a badNonce error.
+	// api.DefaultBadNonceRetries if 0, no retry if negative.
+	BadNonceRetries int
+	// Logger the logger of the client (e.g. a *slog.Logger with the fields of a tenant).
+	// The default logger (log.Default) is used if nil.
+	// The DNS providers and the challenge servers always use the default logger.
+	Logger log.LeveledLogger
 }
 
 func NewConfig(user registration.User) *Config {
@@ -61,10 +69,15 @@ func NewConfig(user registration.User) *Config {
 }
 
 type CertificateConfig struct {
-	KeyType             certcrypto.KeyType
-	Timeout             time.Duration
-	OverallRequestLimit int
-	DisableCommonName   bool
+	KeyType certcrypto.KeyType
+	// Timeout the overall deadline to wait for the certificate, after the finalization of the order.
+	Timeout time.Duration
+	// OrderPollInterval the interval between the polls of the order (Timeout/60 if not defined).
+	OrderPollInterval time.Duration
+	// OrderPollAttemptTimeout the timeout of each poll of the order (no specific timeout if not defined).
+	OrderPollAttemptTimeout time.Duration
+	OverallRequestLimit     int
+	DisableCommonName       bool
 }
 
 // createDefaultHTTPClient Creates an HTTP client with a reasonable timeout value
@@ -0,0 +1,84 @@
+package tlsconfig
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrCacheMiss is returned by the caches when the certificate is not found.
+var ErrCacheMiss = errors.New("tlsconfig: certificate cache miss")
+
+// Cache stores the certificates: the PEM encoded private key followed by the certificate chain.
+type Cache interface {
+	// Get returns the certificate of the hostname, or ErrCacheMiss.
+	Get(ctx context.Context, hostname string) ([]byte, error)
+
+	// Put stores the certificate of the hostname.
+	Put(ctx context.Context, hostname string, data []byte) error
+}
+
+// DirCache stores the certificates in a directory (one file by hostname).
+type DirCache string
+
+// Get reads the certificate from the directory.
+func (d DirCache) Get(_ context.Context, hostname string) ([]byte, error) {
+	filename, err := d.filename(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrCacheMiss
+	}
+
+	return data, err
+}
+
+// Put writes the certificate in the directory.
+func (d DirCache) Put(_ context.Context, hostname string, data []byte) error {
+	filename, err := d.filename(hostname)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(string(d), 0o700)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(string(d), ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filename)
+}
+
+// filename returns the file of the hostname,
+// the hostname must be a DNS name: a path separator or ".." would allow to read or write outside the directory.
+func (d DirCache) filename(hostname string) (string, error) {
+	err := checkHostname(hostname)
+	if err != nil {
+		return "", err
+	}
+
+	// The wildcard character is not allowed in the file names on some systems.
+	return filepath.Join(string(d), strings.ReplaceAll(hostname, "*", "_")+".pem"), nil
+}
@@ -0,0 +1,392 @@
+// Package tlsconfig provides helpers to use the certificates obtained by lego in the TLS configurations (crypto/tls).
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"golang.org/x/time/rate"
+)
+
+// Default values of the policy.
+const (
+	DefaultNegativeCacheTTL = 10 * time.Minute
+	DefaultIssuanceInterval = 10 * time.Second
+	DefaultIssuanceBurst    = 5
+	DefaultRenewBefore      = 30 * 24 * time.Hour
+)
+
+// ErrRateLimited is returned when the issuance is refused by the rate limiting of the policy.
+var ErrRateLimited = errors.New("tlsconfig: issuance rate limit exceeded")
+
+// Obtainer obtains the certificates (e.g. lego.Client.Certificate).
+type Obtainer interface {
+	Obtain(request certificate.ObtainRequest) (*certificate.Resource, error)
+}
+
+// Policy the policy of the on-demand issuance.
+type Policy struct {
+	// HostPolicy decides if a certificate can be issued for the hostname: an error denies the issuance (required).
+	HostPolicy func(ctx context.Context, hostname string) error
+
+	// NegativeCacheTTL the duration during which a denied hostname, or a failed issuance, is not tried again.
+	// Default: DefaultNegativeCacheTTL.
+	NegativeCacheTTL time.Duration
+
+	// IssuanceInterval and IssuanceBurst limit the rate of the issuances (all the hostnames):
+	// one issuance by interval, with bursts of IssuanceBurst issuances.
+	// Default: DefaultIssuanceInterval and DefaultIssuanceBurst.
+	IssuanceInterval time.Duration
+	IssuanceBurst    int
+
+	// RenewBefore the certificates are renewed in the background when they expire in less than this duration.
+	// Default: DefaultRenewBefore.
+	RenewBefore time.Duration
+
+	// Cache stores the certificates, to reuse them after a restart (optional).
+	Cache Cache
+
+	// Profile the certificate profile (draft-ietf-acme-profiles) of the orders (optional).
+	Profile string
+}
+
+// AllowHosts returns a host policy allowing only the hostnames.
+func AllowHosts(hosts ...string) func(ctx context.Context, hostname string) error {
+	allowed := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		allowed = append(allowed, normalize(host))
+	}
+
+	return func(_ context.Context, hostname string) error {
+		if !slices.Contains(allowed, hostname) {
+			return fmt.Errorf("tlsconfig: the hostname %q is not allowed", hostname)
+		}
+
+		return nil
+	}
+}
+
+type call struct {
+	done chan struct{}
+	cert *tls.Certificate
+	err  error
+}
+
+type failure struct {
+	err   error
+	until time.Time
+}
+
+// Manager obtains the certificates at handshake time.
+// It also implements the challenge.Provider interface for the TLS-ALPN-01 challenge,
+// to solve the challenges with the listener using the manager.
+type Manager struct {
+	obtainer Obtainer
+	policy   Policy
+	limiter  *rate.Limiter
+
+	mu         sync.Mutex
+	certs      map[string]*tls.Certificate
+	pending    map[string]*call
+	failures   map[string]failure
+	renewing   map[string]bool
+	challenges map[string]*tls.Certificate
+
+	now func() time.Time
+}
+
+// NewManager creates a new Manager.
+func NewManager(obtainer Obtainer, policy Policy) *Manager {
+	if policy.NegativeCacheTTL == 0 {
+		policy.NegativeCacheTTL = DefaultNegativeCacheTTL
+	}
+
+	if policy.IssuanceInterval == 0 {
+		policy.IssuanceInterval = DefaultIssuanceInterval
+	}
+
+	if policy.IssuanceBurst == 0 {
+		policy.IssuanceBurst = DefaultIssuanceBurst
+	}
+
+	if policy.RenewBefore == 0 {
+		policy.RenewBefore = DefaultRenewBefore
+	}
+
+	return &Manager{
+		obtainer:   obtainer,
+		policy:     policy,
+		limiter:    rate.NewLimiter(rate.Every(policy.IssuanceInterval), policy.IssuanceBurst),
+		certs:      make(map[string]*tls.Certificate),
+		pending:    make(map[string]*call),
+		failures:   make(map[string]failure),
+		renewing:   make(map[string]bool),
+		challenges: make(map[string]*tls.Certificate),
+		now:        time.Now,
+	}
+}
+
+// OnDemand returns a tls.Config.GetCertificate function issuing the certificates lazily, at handshake time,
+// for the hostnames allowed by the policy.
+//
+// The challenges must be configured on the client (e.g. HTTP-01).
+// To solve the TLS-ALPN-01 challenges with the same listener, use a Manager as TLS-ALPN-01 provider:
+//
+//	manager := tlsconfig.NewManager(client.Certificate, policy)
+//	_ = client.Challenge.SetTLSALPN01Provider(manager)
+//	config := &tls.Config{GetCertificate: manager.GetCertificate, NextProtos: []string{"h2", "http/1.1", tlsalpn01.ACMETLS1Protocol}}
+func OnDemand(client *lego.Client, policy Policy) func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return NewManager(client.Certificate, policy).GetCertificate
+}
+
+// GetCertificate returns the certificate of the hostname (SNI), and obtains it if needed.
+func (m *Manager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	hostname := normalize(hello.ServerName)
+	if hostname == "" {
+		return nil, errors.New("tlsconfig: missing server name")
+	}
+
+	// The server name is controlled by the client, and it's used in the file names of the cache.
+	err := checkHostname(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hello.SupportedProtos) == 1 && hello.SupportedProtos[0] == tlsalpn01.ACMETLS1Protocol {
+		return m.challengeCertificate(hostname)
+	}
+
+	ctx := hello.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	m.mu.Lock()
+
+	cert, ok := m.certs[hostname]
+	if ok && m.dueForRenewal(cert) && !m.renewing[hostname] {
+		m.renewing[hostname] = true
+
+		go m.renew(hostname)
+	}
+
+	m.mu.Unlock()
+
+	if ok {
+		return cert, nil
+	}
+
+	return m.obtain(ctx, hostname)
+}
+
+// Present stores the certificate of the TLS-ALPN-01 challenge.
+func (m *Manager) Present(domain, _, keyAuth string) error {
+	cert, err := tlsalpn01.ChallengeCert(domain, keyAuth)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.challenges[normalize(domain)] = cert
+	m.mu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the certificate of the TLS-ALPN-01 challenge.
+func (m *Manager) CleanUp(domain, _, _ string) error {
+	m.mu.Lock()
+	delete(m.challenges, normalize(domain))
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *Manager) challengeCertificate(hostname string) (*tls.Certificate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cert, ok := m.challenges[hostname]
+	if !ok {
+		return nil, fmt.Errorf("tlsconfig: no TLS-ALPN-01 challenge for %q", hostname)
+	}
+
+	return cert, nil
+}
+
+// obtain returns the certificate from the cache or obtains it, with only one issuance at a time by hostname.
+func (m *Manager) obtain(ctx context.Context, hostname string) (*tls.Certificate, error) {
+	m.mu.Lock()
+
+	if f, ok := m.failures[hostname]; ok {
+		if m.now().Before(f.until) {
+			m.mu.Unlock()
+			return nil, f.err
+		}
+
+		delete(m.failures, hostname)
+	}
+
+	if c, ok := m.pending[hostname]; ok {
+		m.mu.Unlock()
+
+		select {
+		case <-c.done:
+			return c.cert, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	c := &call{done: make(chan struct{})}
+	m.pending[hostname] = c
+
+	m.mu.Unlock()
+
+	c.cert, c.err = m.issue(ctx, hostname)
+
+	m.mu.Lock()
+
+	delete(m.pending, hostname)
+
+	switch {
+	case c.err == nil:
+		m.certs[hostname] = c.cert
+
+	case !errors.Is(c.err, ErrRateLimited):
+		m.failures[hostname] = failure{err: c.err, until: m.now().Add(m.policy.NegativeCacheTTL)}
+	}
+
+	m.mu.Unlock()
+
+	close(c.done)
+
+	return c.cert, c.err
+}
+
+// renew renews the certificate in the background.
+// The current certificate is served until the new certificate is obtained.
+func (m *Manager) renew(hostname string) {
+	_, err := m.obtain(context.Background(), hostname)
+	if err != nil {
+		log.Warnf("[%s] tlsconfig: renewal: %v", hostname, err)
+	}
+
+	m.mu.Lock()
+	delete(m.renewing, hostname)
+	m.mu.Unlock()
+}
+
+// issue reads the certificate from the cache, or obtains a new certificate.
+// The host policy is checked first: a hostname removed from the policy is not served from the cache.
+func (m *Manager) issue(ctx context.Context, hostname string) (*tls.Certificate, error) {
+	if m.policy.HostPolicy == nil {
+		return nil, errors.New("tlsconfig: no host policy")
+	}
+
+	err := m.policy.HostPolicy(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.policy.Cache != nil {
+		cert, errC := m.fromCache(ctx, hostname)
+		if errC == nil && !m.dueForRenewal(cert) {
+			return cert, nil
+		}
+	}
+
+	if !m.limiter.Allow() {
+		return nil, ErrRateLimited
+	}
+
+	res, err := m.obtainer.Obtain(certificate.ObtainRequest{
+		Domains: []string{hostname},
+		Bundle:  true,
+		Profile: m.policy.Profile,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig: obtain certificate for %q: %w", hostname, err)
+	}
+
+	cert, err := tls.X509KeyPair(res.Certificate, res.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig: %w", err)
+	}
+
+	if m.policy.Cache != nil {
+		data := slices.Concat(res.PrivateKey, res.Certificate)
+
+		err = m.policy.Cache.Put(ctx, hostname, data)
+		if err != nil {
+			log.Warnf("[%s] tlsconfig: cache: %v", hostname, err)
+		}
+	}
+
+	return &cert, nil
+}
+
+func (m *Manager) fromCache(ctx context.Context, hostname string) (*tls.Certificate, error) {
+	data, err := m.policy.Cache.Get(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	// The private key and the certificates are in the same PEM data.
+	cert, err := tls.X509KeyPair(data, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+func (m *Manager) dueForRenewal(cert *tls.Certificate) bool {
+	if cert.Leaf == nil {
+		return false
+	}
+
+	return m.now().Add(m.policy.RenewBefore).After(cert.Leaf.NotAfter)
+}
+
+func normalize(hostname string) string {
+	return strings.ToLower(strings.TrimSuffix(hostname, "."))
+}
+
+// checkHostname checks that the hostname is a DNS name (letters, digits, hyphens, and underscores labels),
+// the first label can be a wildcard.
+func checkHostname(hostname string) error {
+	if hostname == "" || len(hostname) > 253 {
+		return fmt.Errorf("tlsconfig: invalid hostname %q", hostname)
+	}
+
+	for i, label := range strings.Split(hostname, ".") {
+		if i == 0 && label == "*" {
+			continue
+		}
+
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("tlsconfig: invalid hostname %q", hostname)
+		}
+
+		for _, r := range label {
+			switch {
+			case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '-', r == '_':
+			default:
+				return fmt.Errorf("tlsconfig: invalid hostname %q", hostname)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeObtainer struct {
+	calls    atomic.Int32
+	notAfter time.Time
+	release  chan struct{}
+	err      error
+}
+
+func (f *fakeObtainer) Obtain(request certificate.ObtainRequest) (*certificate.Resource, error) {
+	f.calls.Add(1)
+
+	if f.release != nil {
+		<-f.release
+	}
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	notAfter := f.notAfter
+	if notAfter.IsZero() {
+		notAfter = time.Now().Add(90 * 24 * time.Hour)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(int64(f.calls.Load())),
+		Subject:      pkix.Name{CommonName: request.Domains[0]},
+		DNSNames:     request.Domains,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificate.Resource{
+		Domain:      request.Domains[0],
+		Certificate: certcrypto.PEMEncode(certcrypto.DERCertificateBytes(der)),
+		PrivateKey:  certcrypto.PEMEncode(key),
+	}, nil
+}
+
+func hello(serverName string) *tls.ClientHelloInfo {
+	return &tls.ClientHelloInfo{ServerName: serverName}
+}
+
+func TestManager_GetCertificate(t *testing.T) {
+	obtainer := &fakeObtainer{}
+
+	manager := NewManager(obtainer, Policy{HostPolicy: AllowHosts("example.com")})
+
+	cert, err := manager.GetCertificate(hello("Example.com."))
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", cert.Leaf.Subject.CommonName)
+
+	again, err := manager.GetCertificate(hello("example.com"))
+	require.NoError(t, err)
+
+	assert.Same(t, cert, again)
+	assert.EqualValues(t, 1, obtainer.calls.Load())
+}
+
+func TestManager_GetCertificate_denied(t *testing.T) {
+	obtainer := &fakeObtainer{}
+
+	var policyCalls atomic.Int32
+
+	manager := NewManager(obtainer, Policy{HostPolicy: func(ctx context.Context, hostname string) error {
+		policyCalls.Add(1)
+		return AllowHosts("example.com")(ctx, hostname)
+	}})
+
+	_, err := manager.GetCertificate(hello("example.org"))
+	require.EqualError(t, err, `tlsconfig: the hostname "example.org" is not allowed`)
+
+	// negative cache
+	_, err = manager.GetCertificate(hello("example.org"))
+	require.EqualError(t, err, `tlsconfig: the hostname "example.org" is not allowed`)
+
+	assert.EqualValues(t, 1, policyCalls.Load())
+
+	manager.now = func() time.Time { return time.Now().Add(DefaultNegativeCacheTTL + time.Second) }
+
+	_, err = manager.GetCertificate(hello("example.org"))
+	require.Error(t, err)
+
+	assert.EqualValues(t, 2, policyCalls.Load())
+	assert.EqualValues(t, 0, obtainer.calls.Load())
+}
+
+func TestManager_GetCertificate_concurrent(t *testing.T) {
+	obtainer := &fakeObtainer{release: make(chan struct{})}
+
+	manager := NewManager(obtainer, Policy{HostPolicy: AllowHosts("example.com")})
+
+	var wg sync.WaitGroup
+
+	certs := make([]*tls.Certificate, 10)
+
+	for i := range certs {
+		wg.Go(func() {
+			cert, err := manager.GetCertificate(hello("example.com"))
+			assert.NoError(t, err)
+
+			certs[i] = cert
+		})
+	}
+
+	require.Eventually(t, func() bool { return obtainer.calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	close(obtainer.release)
+
+	wg.Wait()
+
+	assert.EqualValues(t, 1, obtainer.calls.Load())
+
+	for _, cert := range certs {
+		assert.Same(t, certs[0], cert)
+	}
+}
+
+func TestManager_GetCertificate_rateLimited(t *testing.T) {
+	obtainer := &fakeObtainer{}
+
+	manager := NewManager(obtainer, Policy{
+		HostPolicy:       func(context.Context, string) error { return nil },
+		IssuanceInterval: time.Hour,
+		IssuanceBurst:    1,
+	})
+
+	_, err := manager.GetCertificate(hello("a.example.com"))
+	require.NoError(t, err)
+
+	_, err = manager.GetCertificate(hello("b.example.com"))
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	assert.EqualValues(t, 1, obtainer.calls.Load())
+}
+
+func TestManager_GetCertificate_error(t *testing.T) {
+	obtainer := &fakeObtainer{err: errors.New("boom")}
+
+	manager := NewManager(obtainer, Policy{HostPolicy: AllowHosts("example.com")})
+
+	_, err := manager.GetCertificate(hello("example.com"))
+	require.EqualError(t, err, `tlsconfig: obtain certificate for "example.com": boom`)
+
+	_, err = manager.GetCertificate(hello("example.com"))
+	require.Error(t, err)
+
+	assert.EqualValues(t, 1, obtainer.calls.Load())
+}
+
+func TestManager_GetCertificate_renewal(t *testing.T) {
+	obtainer := &fakeObtainer{notAfter: time.Now().Add(24 * time.Hour)}
+
+	manager := NewManager(obtainer, Policy{HostPolicy: AllowHosts("example.com")})
+
+	first, err := manager.GetCertificate(hello("example.com"))
+	require.NoError(t, err)
+
+	obtainer.notAfter = time.Now().Add(90 * 24 * time.Hour)
+
+	// The current certificate is served during the renewal.
+	current, err := manager.GetCertificate(hello("example.com"))
+	require.NoError(t, err)
+
+	assert.Same(t, first, current)
+
+	require.Eventually(t, func() bool {
+		cert, errG := manager.GetCertificate(hello("example.com"))
+		return errG == nil && cert != first
+	}, time.Second, 10*time.Millisecond)
+
+	assert.EqualValues(t, 2, obtainer.calls.Load())
+}
+
+func TestManager_GetCertificate_cache(t *testing.T) {
+	cache := DirCache(t.TempDir())
+
+	obtainer := &fakeObtainer{}
+
+	policy := Policy{HostPolicy: AllowHosts("example.com"), Cache: cache}
+
+	_, err := NewManager(obtainer, policy).GetCertificate(hello("example.com"))
+	require.NoError(t, err)
+
+	// restart
+	cert, err := NewManager(obtainer, policy).GetCertificate(hello("example.com"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", cert.Leaf.Subject.CommonName)
+	assert.EqualValues(t, 1, obtainer.calls.Load())
+}
+
+func TestManager_GetCertificate_cacheDenied(t *testing.T) {
+	cache := DirCache(t.TempDir())
+
+	obtainer := &fakeObtainer{}
+
+	_, err := NewManager(obtainer, Policy{HostPolicy: AllowHosts("example.com"), Cache: cache}).GetCertificate(hello("example.com"))
+	require.NoError(t, err)
+
+	// restart, the hostname has been removed from the policy.
+	_, err = NewManager(obtainer, Policy{HostPolicy: AllowHosts("example.org"), Cache: cache}).GetCertificate(hello("example.com"))
+	require.EqualError(t, err, `tlsconfig: the hostname "example.com" is not allowed`)
+
+	assert.EqualValues(t, 1, obtainer.calls.Load())
+}
+
+func TestManager_GetCertificate_invalidServerName(t *testing.T) {
+	dir := t.TempDir()
+
+	var policyCalls atomic.Int32
+
+	manager := NewManager(&fakeObtainer{}, Policy{
+		HostPolicy: func(context.Context, string) error {
+			policyCalls.Add(1)
+			return nil
+		},
+		Cache: DirCache(filepath.Join(dir, "certs")),
+	})
+
+	testCases := []string{
+		"../../etc/passwd",
+		"..",
+		"example.com/../../secret",
+		`example.com\..\secret`,
+		"example.com\x00.pem",
+		"exa mple.com",
+	}
+
+	for _, serverName := range testCases {
+		t.Run(serverName, func(t *testing.T) {
+			_, err := manager.GetCertificate(hello(serverName))
+			require.ErrorContains(t, err, "tlsconfig: invalid hostname")
+		})
+	}
+
+	assert.EqualValues(t, 0, policyCalls.Load())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	assert.Empty(t, entries)
+}
+
+func TestManager_tlsALPN(t *testing.T) {
+	manager := NewManager(&fakeObtainer{}, Policy{HostPolicy: AllowHosts("example.com")})
+
+	info := &tls.ClientHelloInfo{ServerName: "example.com", SupportedProtos: []string{tlsalpn01.ACMETLS1Protocol}}
+
+	_, err := manager.GetCertificate(info)
+	require.EqualError(t, err, `tlsconfig: no TLS-ALPN-01 challenge for "example.com"`)
+
+	err = manager.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	cert, err := manager.GetCertificate(info)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com"}, cert.Leaf.DNSNames)
+
+	err = manager.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	_, err = manager.GetCertificate(info)
+	require.Error(t, err)
+}
+
+func TestDirCache(t *testing.T) {
+	cache := DirCache(t.TempDir())
+
+	_, err := cache.Get(t.Context(), "*.example.com")
+	require.ErrorIs(t, err, ErrCacheMiss)
+
+	err = cache.Put(t.Context(), "*.example.com", []byte("data"))
+	require.NoError(t, err)
+
+	data, err := cache.Get(t.Context(), "*.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("data"), data)
+
+	_, err = cache.Get(t.Context(), "../example.com")
+	require.EqualError(t, err, `tlsconfig: invalid hostname "../example.com"`)
+
+	err = cache.Put(t.Context(), "example.com/../../example.org", []byte("data"))
+	require.EqualError(t, err, `tlsconfig: invalid hostname "example.com/../../example.org"`)
+}
@@ -1,12 +1,27 @@
 package log
 
 import (
+	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-acme/lego/v4/platform/redact"
 )
 
-// Logger is an optional custom logger.
-var Logger StdLogger = log.New(os.Stderr, "", log.LstdFlags)
+// LeveledLogger the interface of the loggers.
+// It is satisfied by *slog.Logger:
+// a logger can be defined for each client (see lego.Config.Logger), to route the entries with their own fields.
+type LeveledLogger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+var _ LeveledLogger = (*slog.Logger)(nil)
 
 // StdLogger interface for Standard Logger.
 type StdLogger interface {
@@ -18,42 +33,252 @@ type StdLogger interface {
 	Printf(format string, args ...any)
 }
 
-// Fatal writes a log entry.
-// It uses Logger if not nil, otherwise it uses the default log.Logger.
+// Logger is an optional custom logger.
+// The entries written to Logger are forwarded to the default logger,
+// and a standard logger assigned to Logger is used as the default logger.
+//
+// Deprecated: use SetDefault and Default instead.
+var Logger StdLogger = defaultForwarder{}
+
+type loggerHolder struct {
+	LeveledLogger
+}
+
+var defaultLogger atomic.Pointer[loggerHolder]
+
+func init() {
+	SetDefault(NewStdLogger(log.New(os.Stderr, "", log.LstdFlags)))
+}
+
+// Default returns the default logger,
+// used by the package functions (Infof, Warnf, ...) and by the clients without a logger.
+func Default() LeveledLogger {
+	if _, ok := Logger.(defaultForwarder); !ok && Logger != nil {
+		// The deprecated Logger has been replaced by a standard logger.
+		return NewStdLogger(Logger)
+	}
+
+	return defaultLogger.Load().LeveledLogger
+}
+
+// SetDefault replaces the default logger (e.g. with a *slog.Logger).
+func SetDefault(logger LeveledLogger) {
+	if logger == nil {
+		panic("log: nil logger")
+	}
+
+	defaultLogger.Store(&loggerHolder{LeveledLogger: logger})
+}
+
+// Fatal writes an error entry, then exits.
+// The registered secrets (see the redact package) are scrubbed from the log entry.
 func Fatal(args ...any) {
-	Logger.Fatal(args...)
+	Exit(1, args...)
+}
+
+// Exit writes an error entry, then exits with the code.
+// The registered secrets (see the redact package) are scrubbed from the log entry.
+func Exit(code int, args ...any) {
+	Default().Error(redact.String(fmt.Sprint(args...)))
+	os.Exit(code)
 }
 
-// Fatalf writes a log entry.
-// It uses Logger if not nil, otherwise it uses the default log.Logger.
+// Fatalf writes an error entry, then exits.
+// The registered secrets (see the redact package) are scrubbed from the log entry.
 func Fatalf(format string, args ...any) {
-	Logger.Fatalf(format, args...)
+	Default().Error(redact.String(fmt.Sprintf(format, args...)))
+	os.Exit(1)
 }
 
 // Print writes a log entry.
-// It uses Logger if not nil, otherwise it uses the default log.Logger.
+// The registered secrets (see the redact package) are scrubbed from the log entry.
 func Print(args ...any) {
-	Logger.Print(args...)
+	Printer{}.Print(args...)
 }
 
 // Println writes a log entry.
-// It uses Logger if not nil, otherwise it uses the default log.Logger.
+// The registered secrets (see the redact package) are scrubbed from the log entry.
 func Println(args ...any) {
-	Logger.Println(args...)
+	Printer{}.Println(args...)
 }
 
 // Printf writes a log entry.
-// It uses Logger if not nil, otherwise it uses the default log.Logger.
+// The registered secrets (see the redact package) are scrubbed from the log entry.
 func Printf(format string, args ...any) {
-	Logger.Printf(format, args...)
+	Printer{}.Printf(format, args...)
+}
+
+// Errorf writes an error entry.
+func Errorf(format string, args ...any) {
+	Printer{}.Errorf(format, args...)
 }
 
 // Warnf writes a log entry.
 func Warnf(format string, args ...any) {
-	Printf("[WARN] "+format, args...)
+	Printer{}.Warnf(format, args...)
 }
 
 // Infof writes a log entry.
 func Infof(format string, args ...any) {
-	Printf("[INFO] "+format, args...)
+	Printer{}.Infof(format, args...)
+}
+
+// Debugf writes a debug entry.
+func Debugf(format string, args ...any) {
+	Printer{}.Debugf(format, args...)
+}
+
+// Printer writes formatted entries to a logger.
+// The registered secrets (see the redact package) are scrubbed from the log entries.
+// The zero value writes to the default logger.
+type Printer struct {
+	logger LeveledLogger
+}
+
+// NewPrinter creates a Printer writing to the logger, or to the default logger if nil.
+func NewPrinter(logger LeveledLogger) Printer {
+	return Printer{logger: logger}
+}
+
+func (p Printer) getLogger() LeveledLogger {
+	if p.logger == nil {
+		return Default()
+	}
+
+	return p.logger
+}
+
+// Print writes an info entry.
+func (p Printer) Print(args ...any) {
+	p.getLogger().Info(redact.String(fmt.Sprint(args...)))
+}
+
+// Println writes an info entry.
+func (p Printer) Println(args ...any) {
+	p.getLogger().Info(redact.String(strings.TrimSuffix(fmt.Sprintln(args...), "\n")))
+}
+
+// Printf writes an info entry.
+func (p Printer) Printf(format string, args ...any) {
+	p.getLogger().Info(redact.String(fmt.Sprintf(format, args...)))
+}
+
+// Infof writes an info entry.
+func (p Printer) Infof(format string, args ...any) {
+	p.getLogger().Info(redact.String(fmt.Sprintf(format, args...)))
+}
+
+// Debugf writes a debug entry.
+func (p Printer) Debugf(format string, args ...any) {
+	p.getLogger().Debug(redact.String(fmt.Sprintf(format, args...)))
+}
+
+// Warnf writes a warning entry.
+func (p Printer) Warnf(format string, args ...any) {
+	p.getLogger().Warn(redact.String(fmt.Sprintf(format, args...)))
+}
+
+// Errorf writes an error entry.
+func (p Printer) Errorf(format string, args ...any) {
+	p.getLogger().Error(redact.String(fmt.Sprintf(format, args...)))
+}
+
+// stdLogger a LeveledLogger writing to a standard logger.
+type stdLogger struct {
+	std   StdLogger
+	level slog.Leveler
+}
+
+// NewStdLogger creates a LeveledLogger writing to a standard logger (e.g. *log.Logger).
+// The level is the prefix of the entry ([DEBUG], [INFO], [WARN], [ERROR]),
+// and the attributes are appended to the message (key=value).
+// The debug entries are discarded (see NewLeveledStdLogger).
+func NewStdLogger(std StdLogger) LeveledLogger {
+	return NewLeveledStdLogger(std, slog.LevelInfo)
+}
+
+// NewLeveledStdLogger creates a LeveledLogger writing to a standard logger (see NewStdLogger),
+// the entries below the minimum level are discarded.
+func NewLeveledStdLogger(std StdLogger, level slog.Leveler) LeveledLogger {
+	return &stdLogger{std: std, level: level}
+}
+
+func (l *stdLogger) Debug(msg string, args ...any) {
+	l.print(slog.LevelDebug, "[DEBUG] ", msg, args)
+}
+
+func (l *stdLogger) Info(msg string, args ...any) {
+	l.print(slog.LevelInfo, "[INFO] ", msg, args)
+}
+
+func (l *stdLogger) Warn(msg string, args ...any) {
+	l.print(slog.LevelWarn, "[WARN] ", msg, args)
+}
+
+func (l *stdLogger) Error(msg string, args ...any) {
+	l.print(slog.LevelError, "[ERROR] ", msg, args)
+}
+
+func (l *stdLogger) print(level slog.Level, prefix, msg string, args []any) {
+	if level < l.level.Level() {
+		return
+	}
+
+	l.std.Print(formatEntry(prefix, msg, args))
+}
+
+// formatEntry formats an entry like the text handler of slog: the arguments are key/value pairs or slog.Attr.
+func formatEntry(level, msg string, args []any) string {
+	var b strings.Builder
+
+	b.WriteString(level)
+	b.WriteString(msg)
+
+	for len(args) > 0 {
+		var attr slog.Attr
+
+		switch x := args[0].(type) {
+		case slog.Attr:
+			attr, args = x, args[1:]
+		case string:
+			if len(args) == 1 {
+				attr, args = slog.Any("!BADKEY", x), nil
+			} else {
+				attr, args = slog.Any(x, args[1]), args[2:]
+			}
+		default:
+			attr, args = slog.Any("!BADKEY", x), args[1:]
+		}
+
+		b.WriteString(" " + redact.String(attr.String()))
+	}
+
+	return b.String()
+}
+
+// defaultForwarder the deprecated Logger: a StdLogger writing to the default logger.
+type defaultForwarder struct{}
+
+func (defaultForwarder) Fatal(args ...any) {
+	Fatal(args...)
+}
+
+func (defaultForwarder) Fatalln(args ...any) {
+	Fatal(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (defaultForwarder) Fatalf(format string, args ...any) {
+	Fatalf(format, args...)
+}
+
+func (defaultForwarder) Print(args ...any) {
+	Print(args...)
+}
+
+func (defaultForwarder) Println(args ...any) {
+	Println(args...)
+}
+
+func (defaultForwarder) Printf(format string, args ...any) {
+	Printf(format, args...)
 }
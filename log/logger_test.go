@@ -0,0 +1,103 @@
+package log
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStdLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	logger := NewStdLogger(log.New(buf, "", 0))
+
+	logger.Debug("debug")
+	logger.Info("message", "domain", "example.com", slog.Int("attempt", 2))
+	logger.Warn("warning", "orphan")
+	logger.Error("error")
+
+	expected := `[INFO] message domain=example.com attempt=2
+[WARN] warning !BADKEY=orphan
+[ERROR] error
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestNewLeveledStdLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	logger := NewLeveledStdLogger(log.New(buf, "", 0), slog.LevelInfo)
+
+	logger.Debug("debug")
+	logger.Info("message")
+
+	assert.Equal(t, "[INFO] message\n", buf.String())
+}
+
+func TestNewLeveledStdLogger_warn(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	logger := NewLeveledStdLogger(log.New(buf, "", 0), slog.LevelWarn)
+
+	logger.Debug("debug")
+	logger.Info("message")
+	logger.Warn("warning")
+	logger.Error("error")
+
+	assert.Equal(t, "[WARN] warning\n[ERROR] error\n", buf.String())
+}
+
+func TestPrinter(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	printer := NewPrinter(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	})).With("tenant", "foo"))
+
+	printer.Infof("[%s] acme: Obtaining SAN certificate", "example.com")
+	printer.Warnf("cleaning up failed: %v", "oops")
+
+	expected := `level=INFO msg="[example.com] acme: Obtaining SAN certificate" tenant=foo
+level=WARN msg="cleaning up failed: oops" tenant=foo
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestLogger_forwarder(t *testing.T) {
+	backup := Default()
+	t.Cleanup(func() { SetDefault(backup) })
+
+	buf := &bytes.Buffer{}
+
+	SetDefault(NewStdLogger(log.New(buf, "", 0)))
+
+	Logger.Printf("message %d", 1)
+	Logger.Println("message", 2)
+
+	assert.Equal(t, "[INFO] message 1\n[INFO] message 2\n", buf.String())
+}
+
+func TestLogger_replaced(t *testing.T) {
+	backup := Logger
+	t.Cleanup(func() { Logger = backup })
+
+	buf := &bytes.Buffer{}
+
+	Logger = log.New(buf, "", 0)
+
+	Debugf("debug")
+	Infof("message")
+
+	assert.Equal(t, "[INFO] message\n", buf.String())
+}
@@ -0,0 +1,149 @@
+// Package clock provides an abstraction of the time, to make the time-related logic testable without sleeping.
+package clock
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and the ability to wait.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Sleep pauses the current goroutine for at least the duration d.
+	Sleep(d time.Duration)
+	// SleepContext pauses the current goroutine for at least the duration d, or until the context is done.
+	// It returns the error of the context if the context is done before the end of the duration.
+	SleepContext(ctx context.Context, d time.Duration) error
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// Real returns a Clock based on the real time.
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+func (realClock) SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Fake a Clock controlled manually.
+// Sleep advances the time instantly.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+	slept   []time.Duration
+}
+
+type waiter struct {
+	until time.Time
+	ch    chan time.Time
+}
+
+// NewFake creates a Fake clock starting at the given time.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the current time of the clock.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+// Sleep records the duration and advances the time.
+func (f *Fake) Sleep(d time.Duration) {
+	f.mu.Lock()
+	f.slept = append(f.slept, d)
+	f.mu.Unlock()
+
+	f.Advance(d)
+}
+
+// SleepContext records the duration and advances the time, unless the context is already done.
+func (f *Fake) SleepContext(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.Sleep(d)
+
+	return nil
+}
+
+// After returns a channel receiving the time when the clock is advanced past the duration.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+
+	f.waiters = append(f.waiters, waiter{until: f.now.Add(d), ch: ch})
+
+	return ch
+}
+
+// Advance moves the time forward and fires the elapsed timers.
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set sets the current time and fires the elapsed timers.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = now
+
+	sort.Slice(f.waiters, func(i, j int) bool {
+		return f.waiters[i].until.Before(f.waiters[j].until)
+	})
+
+	var pending []waiter
+
+	for _, w := range f.waiters {
+		if w.until.After(now) {
+			pending = append(pending, w)
+			continue
+		}
+
+		w.ch <- now
+	}
+
+	f.waiters = pending
+}
+
+// Slept returns the durations of all the calls to Sleep.
+func (f *Fake) Slept() []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]time.Duration(nil), f.slept...)
+}
@@ -0,0 +1,77 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	clk := NewFake(start)
+
+	assert.Equal(t, start, clk.Now())
+
+	clk.Sleep(time.Hour)
+
+	assert.Equal(t, start.Add(time.Hour), clk.Now())
+	assert.Equal(t, []time.Duration{time.Hour}, clk.Slept())
+
+	after := clk.After(10 * time.Minute)
+
+	clk.Advance(5 * time.Minute)
+
+	select {
+	case <-after:
+		t.Fatal("the timer must not be fired")
+	default:
+	}
+
+	clk.Advance(5 * time.Minute)
+
+	select {
+	case now := <-after:
+		assert.Equal(t, start.Add(time.Hour+10*time.Minute), now)
+	default:
+		t.Fatal("the timer must be fired")
+	}
+}
+
+func TestFake_After_zero(t *testing.T) {
+	clk := NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	select {
+	case <-clk.After(0):
+	default:
+		t.Fatal("the timer must be fired")
+	}
+}
+
+func TestFake_SleepContext(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	clk := NewFake(start)
+
+	require.NoError(t, clk.SleepContext(t.Context(), time.Hour))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	require.ErrorIs(t, clk.SleepContext(ctx, time.Hour), context.Canceled)
+
+	assert.Equal(t, start.Add(time.Hour), clk.Now())
+	assert.Equal(t, []time.Duration{time.Hour}, clk.Slept())
+}
+
+func TestReal_SleepContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	require.ErrorIs(t, Real().SleepContext(ctx, time.Hour), context.Canceled)
+
+	require.NoError(t, Real().SleepContext(t.Context(), time.Millisecond))
+}
@@ -0,0 +1,65 @@
+package pebble
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-acme/lego/v4/acme"
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+// verifyEAB checks the External Account Binding of a new account request.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.3.4
+func (s *Server) verifyEAB(raw json.RawMessage, accountThumbprint string) *acme.ProblemDetails {
+	if len(raw) == 0 {
+		return problem(errExternalAccountReq, http.StatusForbidden, "an External Account Binding is required")
+	}
+
+	jws, err := jose.ParseSigned(string(raw), []jose.SignatureAlgorithm{jose.HS256, jose.HS384, jose.HS512})
+	if err != nil {
+		return malformed("unable to parse the External Account Binding: %v", err)
+	}
+
+	if len(jws.Signatures) != 1 {
+		return malformed("the External Account Binding must contain exactly one signature")
+	}
+
+	header := jws.Signatures[0].Protected
+
+	if header.Nonce != "" {
+		return malformed("the External Account Binding must not contain a nonce")
+	}
+
+	rawURL, _ := header.ExtraHeaders[jose.HeaderKey("url")].(string)
+	if rawURL != s.url(pathNewAccount) {
+		return unauthorized("the External Account Binding URL %q doesn't match the newAccount URL", rawURL)
+	}
+
+	hmac, ok := s.eabKeys[header.KeyID]
+	if !ok {
+		return unauthorized("unknown External Account Binding key identifier: %q", header.KeyID)
+	}
+
+	payload, err := jws.Verify(hmac)
+	if err != nil {
+		return unauthorized("invalid External Account Binding signature: %v", err)
+	}
+
+	var jwk jose.JSONWebKey
+
+	err = json.Unmarshal(payload, &jwk)
+	if err != nil {
+		return malformed("unable to parse the External Account Binding payload: %v", err)
+	}
+
+	thumb, err := thumbprint(&jwk)
+	if err != nil {
+		return malformed("%v", err)
+	}
+
+	if thumb != accountThumbprint {
+		return unauthorized("the External Account Binding key doesn't match the account key")
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package pebble
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+// Errors types.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-6.7
+const (
+	errNS                  = "urn:ietf:params:acme:error:"
+	errAccountDoesNotExist = errNS + "accountDoesNotExist"
+	errAlreadyRevoked      = errNS + "alreadyRevoked"
+	errBadCSR              = errNS + "badCSR"
+	errExternalAccountReq  = errNS + "externalAccountRequired"
+	errIncorrectResponse   = errNS + "incorrectResponse"
+	errInvalidProfile      = errNS + "invalidProfile"
+	errMalformed           = errNS + "malformed"
+	errOrderNotReady       = errNS + "orderNotReady"
+	errRejectedIdentifier  = errNS + "rejectedIdentifier"
+	errUnauthorized        = errNS + "unauthorized"
+	errUnsupportedIdent    = errNS + "unsupportedIdentifier"
+)
+
+func problem(typ string, status int, format string, a ...any) *acme.ProblemDetails {
+	return &acme.ProblemDetails{
+		Type:       typ,
+		Detail:     fmt.Sprintf(format, a...),
+		HTTPStatus: status,
+	}
+}
+
+func malformed(format string, a ...any) *acme.ProblemDetails {
+	return problem(errMalformed, http.StatusBadRequest, format, a...)
+}
+
+func unauthorized(format string, a ...any) *acme.ProblemDetails {
+	return problem(errUnauthorized, http.StatusForbidden, format, a...)
+}
+
+func notFound(resource, id string) *acme.ProblemDetails {
+	return problem(errMalformed, http.StatusNotFound, "%s not found: %s", resource, id)
+}
@@ -0,0 +1,710 @@
+package pebble
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+// Challenge types.
+const (
+	challengeHTTP01    = "http-01"
+	challengeDNS01     = "dns-01"
+	challengeTLSALPN01 = "tls-alpn-01"
+)
+
+func (s *Server) handleDirectory(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, acme.Directory{
+		NewNonceURL:   s.url(pathNonce),
+		NewAccountURL: s.url(pathNewAccount),
+		NewOrderURL:   s.url(pathNewOrder),
+		RevokeCertURL: s.url(pathRevokeCert),
+		RenewalInfo:   s.url(pathRenewal),
+		Meta: acme.Meta{
+			TermsOfService:          s.url("/terms"),
+			ExternalAccountRequired: len(s.eabKeys) > 0,
+			Profiles:                s.profiles,
+		},
+	})
+}
+
+func (s *Server) handleNonce(rw http.ResponseWriter, req *http.Request) {
+	rw.Header().Set("Replay-Nonce", s.newNonce())
+
+	if req.Method == http.MethodGet {
+		rw.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.3
+func (s *Server) handleNewAccount(rw http.ResponseWriter, req *http.Request) {
+	sr, prob := s.verify(req, true)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	var accReq acme.Account
+
+	err := json.Unmarshal(sr.payload, &accReq)
+	if err != nil {
+		writeProblem(rw, malformed("unable to parse the account: %v", err))
+		return
+	}
+
+	// A request signed with a key identifier refers to an existing account.
+	if sr.account != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		rw.Header().Set("Location", s.url(pathAccount+sr.account.id))
+		writeJSON(rw, http.StatusOK, sr.account.Account)
+
+		return
+	}
+
+	thumb, err := thumbprint(sr.jwk)
+	if err != nil {
+		writeProblem(rw, malformed("%v", err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, acc := range s.accounts {
+		if acc.thumbprint == thumb {
+			rw.Header().Set("Location", s.url(pathAccount+acc.id))
+			writeJSON(rw, http.StatusOK, acc.Account)
+
+			return
+		}
+	}
+
+	if accReq.OnlyReturnExisting {
+		writeProblem(rw, problem(errAccountDoesNotExist, http.StatusBadRequest, "no account exists with the provided key"))
+		return
+	}
+
+	if len(s.eabKeys) > 0 {
+		prob = s.verifyEAB(accReq.ExternalAccountBinding, thumb)
+		if prob != nil {
+			writeProblem(rw, prob)
+			return
+		}
+	}
+
+	id := s.nextID()
+
+	acc := &account{
+		Account: acme.Account{
+			Status:               acme.StatusValid,
+			Contact:              accReq.Contact,
+			TermsOfServiceAgreed: accReq.TermsOfServiceAgreed,
+			Orders:               s.url(pathAccount + id + "/orders"),
+		},
+		id:         id,
+		key:        sr.jwk,
+		thumbprint: thumb,
+	}
+
+	s.accounts[id] = acc
+
+	rw.Header().Set("Location", s.url(pathAccount+id))
+	writeJSON(rw, http.StatusCreated, acc.Account)
+}
+
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.3.2
+func (s *Server) handleAccount(rw http.ResponseWriter, req *http.Request) {
+	sr, prob := s.verify(req, false)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	if sr.account.id != req.PathValue("id") {
+		writeProblem(rw, unauthorized("the account doesn't match the key identifier"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acc := sr.account
+
+	if !sr.postAsGet() {
+		var update acme.Account
+
+		err := json.Unmarshal(sr.payload, &update)
+		if err != nil {
+			writeProblem(rw, malformed("unable to parse the account: %v", err))
+			return
+		}
+
+		switch update.Status {
+		case "":
+		case acme.StatusDeactivated:
+			acc.Status = acme.StatusDeactivated
+		default:
+			writeProblem(rw, malformed("invalid account status: %s", update.Status))
+			return
+		}
+
+		if update.Contact != nil {
+			acc.Contact = update.Contact
+		}
+	}
+
+	writeJSON(rw, http.StatusOK, acc.Account)
+}
+
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.4
+func (s *Server) handleNewOrder(rw http.ResponseWriter, req *http.Request) {
+	sr, prob := s.verify(req, false)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	var orderReq acme.Order
+
+	err := json.Unmarshal(sr.payload, &orderReq)
+	if err != nil {
+		writeProblem(rw, malformed("unable to parse the order: %v", err))
+		return
+	}
+
+	if len(orderReq.Identifiers) == 0 {
+		writeProblem(rw, malformed("the order doesn't contain identifiers"))
+		return
+	}
+
+	for _, ident := range orderReq.Identifiers {
+		prob = checkIdentifier(ident)
+		if prob != nil {
+			writeProblem(rw, prob)
+			return
+		}
+	}
+
+	prob = s.checkProfile(orderReq.Profile)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if orderReq.Replaces != "" {
+		prob = s.checkReplaces(orderReq.Replaces, sr.account.id)
+		if prob != nil {
+			writeProblem(rw, prob)
+			return
+		}
+	}
+
+	id := s.nextID()
+
+	o := &order{
+		Order: acme.Order{
+			Status:      acme.StatusPending,
+			Expires:     time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+			Identifiers: orderReq.Identifiers,
+			Profile:     orderReq.Profile,
+			NotBefore:   orderReq.NotBefore,
+			NotAfter:    orderReq.NotAfter,
+			Replaces:    orderReq.Replaces,
+			Finalize:    s.url(pathFinalize + id),
+		},
+		id:        id,
+		accountID: sr.account.id,
+	}
+
+	for _, ident := range orderReq.Identifiers {
+		authz := s.newAuthorization(sr.account.id, ident)
+
+		o.authzIDs = append(o.authzIDs, authz.id)
+		o.Authorizations = append(o.Authorizations, s.url(pathAuthz+authz.id))
+	}
+
+	s.orders[id] = o
+
+	rw.Header().Set("Location", s.url(pathOrder+id))
+	writeJSON(rw, http.StatusCreated, o.Order)
+}
+
+func (s *Server) handleOrder(rw http.ResponseWriter, req *http.Request) {
+	sr, prob := s.verify(req, false)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders[req.PathValue("id")]
+	if !ok || o.accountID != sr.account.id {
+		writeProblem(rw, notFound("order", req.PathValue("id")))
+		return
+	}
+
+	writeJSON(rw, http.StatusOK, o.Order)
+}
+
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.5
+func (s *Server) handleAuthorization(rw http.ResponseWriter, req *http.Request) {
+	sr, prob := s.verify(req, false)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	authz, ok := s.authzs[req.PathValue("id")]
+	if !ok || authz.accountID != sr.account.id {
+		writeProblem(rw, notFound("authorization", req.PathValue("id")))
+		return
+	}
+
+	if !sr.postAsGet() {
+		var update acme.Authorization
+
+		err := json.Unmarshal(sr.payload, &update)
+		if err != nil {
+			writeProblem(rw, malformed("unable to parse the authorization: %v", err))
+			return
+		}
+
+		// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.5.2
+		if update.Status != acme.StatusDeactivated {
+			writeProblem(rw, malformed("invalid authorization status: %s", update.Status))
+			return
+		}
+
+		authz.Status = acme.StatusDeactivated
+	}
+
+	writeJSON(rw, http.StatusOK, s.authorizationView(authz))
+}
+
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.5.1
+func (s *Server) handleChallenge(rw http.ResponseWriter, req *http.Request) {
+	sr, prob := s.verify(req, false)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chlg, ok := s.challenges[req.PathValue("id")]
+	if !ok {
+		writeProblem(rw, notFound("challenge", req.PathValue("id")))
+		return
+	}
+
+	authz := s.authzs[chlg.authzID]
+	if authz.accountID != sr.account.id {
+		writeProblem(rw, notFound("challenge", req.PathValue("id")))
+		return
+	}
+
+	if !sr.postAsGet() && chlg.Status == acme.StatusPending && authz.Status == acme.StatusPending {
+		s.validate(sr.account, authz, chlg)
+	}
+
+	rw.Header().Add("Link", fmt.Sprintf(`<%s>;rel="up"`, s.url(pathAuthz+authz.id)))
+	writeJSON(rw, http.StatusOK, chlg.Challenge)
+}
+
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.4
+func (s *Server) handleFinalize(rw http.ResponseWriter, req *http.Request) {
+	sr, prob := s.verify(req, false)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders[req.PathValue("id")]
+	if !ok || o.accountID != sr.account.id {
+		writeProblem(rw, notFound("order", req.PathValue("id")))
+		return
+	}
+
+	if o.Status != acme.StatusReady {
+		writeProblem(rw, problem(errOrderNotReady, http.StatusForbidden, "the order is not ready: %s", o.Status))
+		return
+	}
+
+	var msg acme.CSRMessage
+
+	err := json.Unmarshal(sr.payload, &msg)
+	if err != nil {
+		writeProblem(rw, malformed("unable to parse the finalize request: %v", err))
+		return
+	}
+
+	csr, prob := parseCSR(msg.Csr, o.Identifiers)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	notBefore, notAfter, err := s.validity(o.Order)
+	if err != nil {
+		writeProblem(rw, malformed("%v", err))
+		return
+	}
+
+	cert, chain, err := s.ca.issue(csr, notBefore, notAfter)
+	if err != nil {
+		writeProblem(rw, problem(errNS+"serverInternal", http.StatusInternalServerError, "unable to issue the certificate: %v", err))
+		return
+	}
+
+	certID := s.nextID()
+
+	s.certificates[certID] = &certificate{
+		accountID: sr.account.id,
+		cert:      cert,
+		chain:     chain,
+	}
+
+	if o.Replaces != "" {
+		if replaced := s.findCertificateByARICertID(o.Replaces); replaced != nil {
+			replaced.replaced = true
+		}
+	}
+
+	o.certID = certID
+	o.Status = acme.StatusValid
+	o.Certificate = s.url(pathCert + certID)
+
+	rw.Header().Set("Location", s.url(pathOrder+o.id))
+	writeJSON(rw, http.StatusOK, o.Order)
+}
+
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.4.2
+func (s *Server) handleCertificate(rw http.ResponseWriter, req *http.Request) {
+	sr, prob := s.verify(req, false)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.certificates[req.PathValue("id")]
+	if !ok || c.accountID != sr.account.id {
+		writeProblem(rw, notFound("certificate", req.PathValue("id")))
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/pem-certificate-chain")
+	_, _ = rw.Write(c.chain)
+}
+
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.6
+func (s *Server) handleRevokeCert(rw http.ResponseWriter, req *http.Request) {
+	sr, prob := s.verify(req, true)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	var msg acme.RevokeCertMessage
+
+	err := json.Unmarshal(sr.payload, &msg)
+	if err != nil {
+		writeProblem(rw, malformed("unable to parse the revocation request: %v", err))
+		return
+	}
+
+	der, err := base64.RawURLEncoding.DecodeString(msg.Certificate)
+	if err != nil {
+		writeProblem(rw, malformed("unable to decode the certificate: %v", err))
+		return
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		writeProblem(rw, malformed("unable to parse the certificate: %v", err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findCertificate(cert.SerialNumber)
+	if c == nil {
+		writeProblem(rw, notFound("certificate", cert.SerialNumber.String()))
+		return
+	}
+
+	// The request is signed either by the account that issued the certificate, or by the certificate key.
+	switch {
+	case sr.account != nil && sr.account.id == c.accountID:
+	case sr.jwk != nil && publicKeyEqual(sr.jwk.Key, c.cert.PublicKey):
+	default:
+		writeProblem(rw, unauthorized("the requester is not authorized to revoke this certificate"))
+		return
+	}
+
+	if c.revoked {
+		writeProblem(rw, problem(errAlreadyRevoked, http.StatusBadRequest, "the certificate is already revoked"))
+		return
+	}
+
+	c.revoked = true
+	c.reason = msg.Reason
+
+	rw.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) newAuthorization(accountID string, ident acme.Identifier) *authorization {
+	id := s.nextID()
+
+	authz := &authorization{
+		Authorization: acme.Authorization{
+			Status:     acme.StatusPending,
+			Expires:    time.Now().Add(24 * time.Hour).UTC(),
+			Identifier: ident,
+		},
+		id:        id,
+		accountID: accountID,
+	}
+
+	var types []string
+
+	switch {
+	case ident.Type == "dns" && strings.HasPrefix(ident.Value, "*."):
+		authz.Identifier.Value = strings.TrimPrefix(ident.Value, "*.")
+		authz.Wildcard = true
+
+		types = []string{challengeDNS01}
+
+	case ident.Type == "dns":
+		types = []string{challengeHTTP01, challengeDNS01, challengeTLSALPN01}
+
+	default:
+		types = []string{challengeHTTP01, challengeTLSALPN01}
+	}
+
+	for _, typ := range types {
+		chlgID := s.nextID()
+
+		s.challenges[chlgID] = &chall{
+			Challenge: acme.Challenge{
+				Type:   typ,
+				URL:    s.url(pathChallenge + chlgID),
+				Status: acme.StatusPending,
+				Token:  randomToken(),
+			},
+			id:      chlgID,
+			authzID: id,
+		}
+
+		authz.chlgIDs = append(authz.chlgIDs, chlgID)
+	}
+
+	s.authzs[id] = authz
+
+	return authz
+}
+
+// authorizationView returns the authorization object with its challenges.
+func (s *Server) authorizationView(authz *authorization) acme.Authorization {
+	view := authz.Authorization
+	view.Challenges = nil
+
+	for _, id := range authz.chlgIDs {
+		chlg := s.challenges[id]
+
+		// For valid authorizations, the challenge that was validated.
+		if authz.Status == acme.StatusValid && chlg.Status != acme.StatusValid {
+			continue
+		}
+
+		view.Challenges = append(view.Challenges, chlg.Challenge)
+	}
+
+	return view
+}
+
+func (s *Server) validate(acc *account, authz *authorization, chlg *chall) {
+	keyAuth := chlg.Token + "." + acc.thumbprint
+
+	ident := authz.Identifier
+	if authz.Wildcard {
+		ident.Value = "*." + ident.Value
+	}
+
+	err := s.validator(chlg.Challenge, ident, keyAuth)
+	if err != nil {
+		chlg.Status = acme.StatusInvalid
+		chlg.Error = problem(errIncorrectResponse, http.StatusForbidden, "%v", err)
+		authz.Status = acme.StatusInvalid
+	} else {
+		chlg.Status = acme.StatusValid
+		chlg.Validated = time.Now().UTC()
+		authz.Status = acme.StatusValid
+	}
+
+	s.updateOrders()
+}
+
+// updateOrders updates the status of the pending orders according to the status of their authorizations.
+func (s *Server) updateOrders() {
+	for _, o := range s.orders {
+		if o.Status != acme.StatusPending {
+			continue
+		}
+
+		ready := true
+
+		for _, id := range o.authzIDs {
+			switch s.authzs[id].Status {
+			case acme.StatusValid:
+			case acme.StatusPending:
+				ready = false
+			default:
+				o.Status = acme.StatusInvalid
+				o.Error = problem(errUnauthorized, http.StatusForbidden, "the authorization %s is %s", id, s.authzs[id].Status)
+			}
+		}
+
+		if ready && o.Status == acme.StatusPending {
+			o.Status = acme.StatusReady
+		}
+	}
+}
+
+func (s *Server) validity(o acme.Order) (time.Time, time.Time, error) {
+	notBefore := time.Now().Add(-time.Minute).UTC()
+
+	if o.NotBefore != "" {
+		var err error
+
+		notBefore, err = time.Parse(time.RFC3339, o.NotBefore)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid notBefore: %w", err)
+		}
+	}
+
+	notAfter := notBefore.Add(s.lifetime)
+
+	if o.NotAfter != "" {
+		var err error
+
+		notAfter, err = time.Parse(time.RFC3339, o.NotAfter)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid notAfter: %w", err)
+		}
+	}
+
+	return notBefore, notAfter, nil
+}
+
+func (s *Server) checkProfile(profile string) *acme.ProblemDetails {
+	if profile == "" {
+		return nil
+	}
+
+	if _, ok := s.profiles[profile]; !ok {
+		return problem(errInvalidProfile, http.StatusBadRequest, "unknown profile: %s", profile)
+	}
+
+	return nil
+}
+
+func checkIdentifier(ident acme.Identifier) *acme.ProblemDetails {
+	switch ident.Type {
+	case "dns":
+		if ident.Value == "" || strings.Contains(strings.TrimPrefix(ident.Value, "*."), "*") {
+			return problem(errRejectedIdentifier, http.StatusBadRequest, "invalid DNS identifier: %q", ident.Value)
+		}
+
+	case "ip":
+		if net.ParseIP(ident.Value) == nil {
+			return problem(errRejectedIdentifier, http.StatusBadRequest, "invalid IP identifier: %q", ident.Value)
+		}
+
+	default:
+		return problem(errUnsupportedIdent, http.StatusBadRequest, "unsupported identifier type: %q", ident.Type)
+	}
+
+	return nil
+}
+
+// parseCSR decodes the CSR and checks that it matches the identifiers of the order.
+func parseCSR(raw string, identifiers []acme.Identifier) (*x509.CertificateRequest, *acme.ProblemDetails) {
+	der, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, problem(errBadCSR, http.StatusBadRequest, "unable to decode the CSR: %v", err)
+	}
+
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return nil, problem(errBadCSR, http.StatusBadRequest, "unable to parse the CSR: %v", err)
+	}
+
+	err = csr.CheckSignature()
+	if err != nil {
+		return nil, problem(errBadCSR, http.StatusBadRequest, "invalid CSR signature: %v", err)
+	}
+
+	var expected []string
+	for _, ident := range identifiers {
+		expected = append(expected, strings.ToLower(ident.Value))
+	}
+
+	var names []string
+	for _, name := range csr.DNSNames {
+		names = append(names, strings.ToLower(name))
+	}
+
+	for _, ip := range csr.IPAddresses {
+		names = append(names, ip.String())
+	}
+
+	slices.Sort(expected)
+	slices.Sort(names)
+
+	if !slices.Equal(slices.Compact(expected), slices.Compact(names)) {
+		return nil, problem(errBadCSR, http.StatusBadRequest, "the CSR identifiers %v don't match the order identifiers %v", names, expected)
+	}
+
+	return csr, nil
+}
+
+func publicKeyEqual(a, b any) bool {
+	type equaler interface {
+		Equal(x any) bool
+	}
+
+	if jwk, ok := a.(*jose.JSONWebKey); ok {
+		a = jwk.Key
+	}
+
+	key, ok := a.(equaler)
+
+	return ok && key.Equal(b)
+}
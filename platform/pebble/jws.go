@@ -0,0 +1,106 @@
+package pebble
+
+import (
+	"crypto"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-acme/lego/v4/acme"
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+const maxBodySize = 1024 * 1024
+
+var supportedAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.ES256, jose.ES384, jose.ES512,
+}
+
+// signedRequest a verified JWS request.
+type signedRequest struct {
+	payload []byte
+
+	// jwk is defined when the request is signed with an embedded key.
+	jwk *jose.JSONWebKey
+
+	// account is defined when the request is signed with a key identifier.
+	account *account
+}
+
+// postAsGet reports whether the request is a POST-as-GET request.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-6.3
+func (r *signedRequest) postAsGet() bool {
+	return len(r.payload) == 0
+}
+
+// verify checks the JWS signature, the nonce and the URL of the request.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-6.2
+func (s *Server) verify(req *http.Request, embeddedKey bool) (*signedRequest, *acme.ProblemDetails) {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, req.Body, maxBodySize))
+	if err != nil {
+		return nil, malformed("unable to read the request body: %v", err)
+	}
+
+	jws, err := jose.ParseSigned(string(body), supportedAlgorithms)
+	if err != nil {
+		return nil, malformed("unable to parse the JWS: %v", err)
+	}
+
+	if len(jws.Signatures) != 1 {
+		return nil, malformed("the JWS must contain exactly one signature")
+	}
+
+	header := jws.Signatures[0].Protected
+
+	if !s.useNonce(header.Nonce) {
+		return nil, problem(acme.BadNonceErr, http.StatusBadRequest, "JWS has an invalid anti-replay nonce: %q", header.Nonce)
+	}
+
+	rawURL, _ := header.ExtraHeaders[jose.HeaderKey("url")].(string)
+	if rawURL != s.url(req.URL.Path) {
+		return nil, unauthorized("the JWS header URL %q doesn't match the request URL", rawURL)
+	}
+
+	result := &signedRequest{}
+
+	var key any
+
+	switch {
+	case embeddedKey && header.JSONWebKey != nil && header.KeyID == "":
+		result.jwk = header.JSONWebKey
+		key = header.JSONWebKey
+
+	case header.KeyID != "" && header.JSONWebKey == nil:
+		acc, prob := s.lookupAccount(header.KeyID)
+		if prob != nil {
+			return nil, prob
+		}
+
+		result.account = acc
+		key = acc.key
+
+	default:
+		return nil, malformed("the JWS must contain exactly one of the 'jwk' or 'kid' header fields")
+	}
+
+	payload, err := jws.Verify(key)
+	if err != nil {
+		return nil, malformed("JWS verification error: %v", err)
+	}
+
+	result.payload = payload
+
+	return result, nil
+}
+
+// thumbprint returns the base64url encoded SHA-256 thumbprint of the key.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-8.1
+func thumbprint(key *jose.JSONWebKey) (string, error) {
+	raw, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("compute thumbprint: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
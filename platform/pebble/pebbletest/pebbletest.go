@@ -0,0 +1,22 @@
+// Package pebbletest provides the test helpers of the in-process ACME server.
+package pebbletest
+
+import (
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/stretchr/testify/require"
+)
+
+// NewServer creates and starts a new ACME server.
+// The server is stopped at the end of the test.
+func NewServer(t *testing.T, options ...pebble.Option) *pebble.Server {
+	t.Helper()
+
+	s, err := pebble.New(options...)
+	require.NoError(t, err)
+
+	t.Cleanup(s.Close)
+
+	return s
+}
@@ -0,0 +1,113 @@
+package pebble
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+// https://www.rfc-editor.org/rfc/rfc9773.html#section-4.1
+func (s *Server) handleGetRenewalInfo(rw http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	c := s.findCertificateByARICertID(req.PathValue("id"))
+	s.mu.Unlock()
+
+	if c == nil {
+		writeProblem(rw, notFound("certificate", req.PathValue("id")))
+		return
+	}
+
+	rw.Header().Set("Retry-After", strconv.Itoa(int(s.renewalRetryAfter.Seconds())))
+	writeJSON(rw, http.StatusOK, s.renewalInfo(c.cert))
+}
+
+// handleUpdateRenewalInfo marks a certificate as replaced.
+// This endpoint has been removed from the final version of the RFC, but some servers still support it.
+func (s *Server) handleUpdateRenewalInfo(rw http.ResponseWriter, req *http.Request) {
+	sr, prob := s.verify(req, false)
+	if prob != nil {
+		writeProblem(rw, prob)
+		return
+	}
+
+	var msg acme.RenewalInfoUpdateRequest
+
+	err := json.Unmarshal(sr.payload, &msg)
+	if err != nil {
+		writeProblem(rw, malformed("unable to parse the renewal info update: %v", err))
+		return
+	}
+
+	if !msg.Replaced {
+		writeProblem(rw, malformed("the 'replaced' field must be true"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findCertificateByARICertID(msg.CertID)
+	if c == nil || c.accountID != sr.account.id {
+		writeProblem(rw, notFound("certificate", msg.CertID))
+		return
+	}
+
+	c.replaced = true
+
+	rw.WriteHeader(http.StatusOK)
+}
+
+// checkReplaces checks the "replaces" field of a new order.
+// https://www.rfc-editor.org/rfc/rfc9773.html#section-5
+func (s *Server) checkReplaces(certID, accountID string) *acme.ProblemDetails {
+	c := s.findCertificateByARICertID(certID)
+	if c == nil || c.accountID != accountID {
+		return malformed("unknown certificate to replace: %s", certID)
+	}
+
+	if c.replaced {
+		return problem(acme.AlreadyReplacedErr, http.StatusConflict, "the certificate has already been replaced: %s", certID)
+	}
+
+	return nil
+}
+
+func (s *Server) findCertificateByARICertID(certID string) *certificate {
+	for _, c := range s.certificates {
+		if ariCertID(c.cert) == certID {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// ariCertID returns the unique identifier of a certificate: base64url(AKI) || '.' || base64url(Serial).
+// https://www.rfc-editor.org/rfc/rfc9773.html#section-4.1
+func ariCertID(cert *x509.Certificate) string {
+	der, err := asn1.Marshal(cert.SerialNumber)
+	if err != nil || len(der) < 3 {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cert.AuthorityKeyId) + "." + base64.RawURLEncoding.EncodeToString(der[2:])
+}
+
+// defaultRenewalInfo suggests to renew the certificate during the first half of the last third of its lifetime.
+func defaultRenewalInfo(cert *x509.Certificate) acme.RenewalInfoResponse {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+
+	start := cert.NotAfter.Add(-lifetime / 3)
+
+	return acme.RenewalInfoResponse{
+		SuggestedWindow: acme.Window{
+			Start: start.UTC(),
+			End:   start.Add(lifetime / 6).UTC(),
+		},
+	}
+}
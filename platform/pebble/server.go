@@ -0,0 +1,474 @@
+// Package pebble provides a minimal in-process ACME server (RFC 8555) for integration tests and self-tests.
+//
+// The server verifies the JWS of the requests, manages the anti-replay nonces,
+// and follows the lifecycle of the accounts, orders, authorizations, and challenges.
+// The certificates are issued by an in-memory CA.
+//
+// The package doesn't depend on the testing package: see [pebbletest.NewServer] for the tests.
+package pebble
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+const (
+	pathDirectory  = "/dir"
+	pathNonce      = "/nonce"
+	pathNewAccount = "/new-account"
+	pathAccount    = "/account/"
+	pathNewOrder   = "/new-order"
+	pathOrder      = "/order/"
+	pathAuthz      = "/authz/"
+	pathChallenge  = "/chall/"
+	pathFinalize   = "/finalize/"
+	pathCert       = "/cert/"
+	pathRevokeCert = "/revoke-cert"
+	pathRenewal    = "/renewal-info"
+)
+
+// Endpoint identifies an endpoint of the server.
+type Endpoint string
+
+// Endpoints of the server.
+const (
+	EndpointDirectory   Endpoint = "directory"
+	EndpointNonce       Endpoint = "nonce"
+	EndpointNewAccount  Endpoint = "newAccount"
+	EndpointAccount     Endpoint = "account"
+	EndpointNewOrder    Endpoint = "newOrder"
+	EndpointOrder       Endpoint = "order"
+	EndpointAuthz       Endpoint = "authz"
+	EndpointChallenge   Endpoint = "challenge"
+	EndpointFinalize    Endpoint = "finalize"
+	EndpointCertificate Endpoint = "certificate"
+	EndpointRevokeCert  Endpoint = "revokeCert"
+	EndpointRenewalInfo Endpoint = "renewalInfo"
+)
+
+// Validator validates a challenge.
+// keyAuth is the expected key authorization for the challenge.
+// Returning an error makes the challenge, the authorization and the order invalid.
+type Validator func(chlg acme.Challenge, identifier acme.Identifier, keyAuth string) error
+
+// Option configures a Server.
+type Option func(*Server) error
+
+// WithValidator defines the function used to validate the challenges.
+// By default, all the challenges are considered valid.
+func WithValidator(validator Validator) Option {
+	return func(s *Server) error {
+		s.validator = validator
+		return nil
+	}
+}
+
+// WithCertificateLifetime defines the lifetime of the issued certificates when the order doesn't define it.
+// The default is 90 days.
+func WithCertificateLifetime(lifetime time.Duration) Option {
+	return func(s *Server) error {
+		s.lifetime = lifetime
+		return nil
+	}
+}
+
+// WithProfiles defines the certificate profiles advertised by the directory (draft-ietf-acme-profiles).
+// The keys are the names of the profiles, and the values are their descriptions.
+// An order with a profile not advertised by the directory is rejected.
+func WithProfiles(profiles map[string]string) Option {
+	return func(s *Server) error {
+		s.profiles = profiles
+		return nil
+	}
+}
+
+// WithRenewalInfo defines the function used to compute the renewal information (RFC 9773) of a certificate.
+// By default, the suggested window is the first half of the last third of the certificate lifetime.
+func WithRenewalInfo(fn RenewalInfoFunc) Option {
+	return func(s *Server) error {
+		s.renewalInfo = fn
+		return nil
+	}
+}
+
+// WithRenewalInfoRetryAfter defines the Retry-After header of the renewal information responses.
+// The default is 6 hours.
+func WithRenewalInfoRetryAfter(retryAfter time.Duration) Option {
+	return func(s *Server) error {
+		s.renewalRetryAfter = retryAfter
+		return nil
+	}
+}
+
+// WithExternalAccountBinding makes the External Account Binding required,
+// and registers an external account key.
+// The option can be used several times to register several keys.
+// hmacEncoded is the MAC key in base64url encoding without padding.
+func WithExternalAccountBinding(kid, hmacEncoded string) Option {
+	return func(s *Server) error {
+		hmac, err := base64.RawURLEncoding.DecodeString(hmacEncoded)
+		if err != nil {
+			return fmt.Errorf("decode EAB HMAC: %w", err)
+		}
+
+		if s.eabKeys == nil {
+			s.eabKeys = make(map[string][]byte)
+		}
+
+		s.eabKeys[kid] = hmac
+
+		return nil
+	}
+}
+
+// RenewalInfoFunc computes the renewal information of a certificate.
+type RenewalInfoFunc func(cert *x509.Certificate) acme.RenewalInfoResponse
+
+// Server a minimal in-process ACME server.
+type Server struct {
+	server   *http.Server
+	listener net.Listener
+	baseURL  string
+	tlsCert  *x509.Certificate
+	ca       *certificateAuthority
+
+	validator         Validator
+	lifetime          time.Duration
+	profiles          map[string]string
+	renewalInfo       RenewalInfoFunc
+	renewalRetryAfter time.Duration
+	eabKeys           map[string][]byte
+
+	mu           sync.Mutex
+	lastID       int
+	errors       map[Endpoint][]*acme.ProblemDetails
+	nonces       map[string]struct{}
+	accounts     map[string]*account
+	orders       map[string]*order
+	authzs       map[string]*authorization
+	challenges   map[string]*chall
+	certificates map[string]*certificate
+}
+
+type account struct {
+	acme.Account
+
+	id         string
+	key        *jose.JSONWebKey
+	thumbprint string
+}
+
+type order struct {
+	acme.Order
+
+	id        string
+	accountID string
+	authzIDs  []string
+	certID    string
+}
+
+type authorization struct {
+	acme.Authorization
+
+	id        string
+	accountID string
+	chlgIDs   []string
+}
+
+type chall struct {
+	acme.Challenge
+
+	id      string
+	authzID string
+}
+
+type certificate struct {
+	accountID string
+	cert      *x509.Certificate
+	chain     []byte
+	revoked   bool
+	reason    *uint
+	replaced  bool
+}
+
+// New creates and starts a new ACME server.
+// The server must be stopped with [Server.Close].
+func New(options ...Option) (*Server, error) {
+	ca, err := newCertificateAuthority()
+	if err != nil {
+		return nil, fmt.Errorf("create the certificate authority: %w", err)
+	}
+
+	s := &Server{
+		ca:                ca,
+		validator:         func(acme.Challenge, acme.Identifier, string) error { return nil },
+		lifetime:          90 * 24 * time.Hour,
+		renewalInfo:       defaultRenewalInfo,
+		renewalRetryAfter: 6 * time.Hour,
+		errors:            make(map[Endpoint][]*acme.ProblemDetails),
+		nonces:            make(map[string]struct{}),
+		accounts:          make(map[string]*account),
+		orders:            make(map[string]*order),
+		authzs:            make(map[string]*authorization),
+		challenges:        make(map[string]*chall),
+		certificates:      make(map[string]*certificate),
+	}
+
+	for _, option := range options {
+		err = option(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tlsCert, err := newTLSCertificate()
+	if err != nil {
+		return nil, fmt.Errorf("create the TLS certificate: %w", err)
+	}
+
+	s.tlsCert = tlsCert.Leaf
+
+	s.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+
+	s.baseURL = "https://" + s.listener.Addr().String()
+
+	s.server = &http.Server{
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{tlsCert},
+		},
+	}
+
+	go func() { _ = s.server.ServeTLS(s.listener, "", "") }()
+
+	return s, nil
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	_ = s.server.Close()
+}
+
+// URL returns the URL of the ACME directory.
+func (s *Server) URL() string {
+	return s.url(pathDirectory)
+}
+
+// Client returns an HTTP client configured to trust the TLS certificate of the server.
+func (s *Server) Client() *http.Client {
+	pool := x509.NewCertPool()
+	pool.AddCert(s.tlsCert)
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool},
+		},
+	}
+}
+
+// TLSCertificate returns the TLS certificate of the server.
+// It must be trusted by the clients which don't use [Server.Client].
+func (s *Server) TLSCertificate() *x509.Certificate {
+	return s.tlsCert
+}
+
+// Root returns the root certificate of the CA.
+func (s *Server) Root() *x509.Certificate {
+	return s.ca.root
+}
+
+// Roots returns a pool containing the root certificate of the CA.
+func (s *Server) Roots() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(s.ca.root)
+
+	return pool
+}
+
+// InjectError makes the next request to the endpoint fail with the problem.
+// Each call queues a new error: the errors are returned in the order of the calls, one per request.
+func (s *Server) InjectError(endpoint Endpoint, prob *acme.ProblemDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors[endpoint] = append(s.errors[endpoint], prob)
+}
+
+// IsRevoked reports whether the certificate has been revoked through the server.
+func (s *Server) IsRevoked(cert *x509.Certificate) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findCertificate(cert.SerialNumber)
+
+	return c != nil && c.revoked
+}
+
+// IsReplaced reports whether the certificate has been marked as replaced,
+// either by a finalized order or through the renewalInfo endpoint.
+func (s *Server) IsReplaced(cert *x509.Certificate) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findCertificate(cert.SerialNumber)
+
+	return c != nil && c.replaced
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	s.handle(mux, EndpointDirectory, "GET "+pathDirectory, s.handleDirectory)
+	s.handle(mux, EndpointNonce, "HEAD "+pathNonce, s.handleNonce)
+	s.handle(mux, EndpointNonce, "GET "+pathNonce, s.handleNonce)
+	s.handle(mux, EndpointNewAccount, "POST "+pathNewAccount, s.handleNewAccount)
+	s.handle(mux, EndpointAccount, "POST "+pathAccount+"{id}", s.handleAccount)
+	s.handle(mux, EndpointNewOrder, "POST "+pathNewOrder, s.handleNewOrder)
+	s.handle(mux, EndpointOrder, "POST "+pathOrder+"{id}", s.handleOrder)
+	s.handle(mux, EndpointAuthz, "POST "+pathAuthz+"{id}", s.handleAuthorization)
+	s.handle(mux, EndpointChallenge, "POST "+pathChallenge+"{id}", s.handleChallenge)
+	s.handle(mux, EndpointFinalize, "POST "+pathFinalize+"{id}", s.handleFinalize)
+	s.handle(mux, EndpointCertificate, "POST "+pathCert+"{id}", s.handleCertificate)
+	s.handle(mux, EndpointRevokeCert, "POST "+pathRevokeCert, s.handleRevokeCert)
+	s.handle(mux, EndpointRenewalInfo, "GET "+pathRenewal+"/{id}", s.handleGetRenewalInfo)
+	s.handle(mux, EndpointRenewalInfo, "POST "+pathRenewal, s.handleUpdateRenewalInfo)
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Cache-Control", "no-store")
+
+		if req.Method == http.MethodPost {
+			rw.Header().Set("Replay-Nonce", s.newNonce())
+		}
+
+		mux.ServeHTTP(rw, req)
+	})
+}
+
+// handle registers the handler, preceded by the errors injected for the endpoint.
+func (s *Server) handle(mux *http.ServeMux, endpoint Endpoint, pattern string, handler http.HandlerFunc) {
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		prob := s.popError(endpoint)
+		if prob != nil {
+			writeProblem(rw, prob)
+			return
+		}
+
+		handler(rw, req)
+	})
+}
+
+func (s *Server) popError(endpoint Endpoint) *acme.ProblemDetails {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue := s.errors[endpoint]
+	if len(queue) == 0 {
+		return nil
+	}
+
+	s.errors[endpoint] = queue[1:]
+
+	return queue[0]
+}
+
+func (s *Server) url(path string) string {
+	return s.baseURL + path
+}
+
+func (s *Server) nextID() string {
+	s.lastID++
+
+	return strconv.Itoa(s.lastID)
+}
+
+func (s *Server) newNonce() string {
+	nonce := randomToken()
+
+	s.mu.Lock()
+	s.nonces[nonce] = struct{}{}
+	s.mu.Unlock()
+
+	return nonce
+}
+
+func (s *Server) useNonce(nonce string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.nonces[nonce]; !ok {
+		return false
+	}
+
+	delete(s.nonces, nonce)
+
+	return true
+}
+
+// lookupAccount returns the valid account related to the key identifier.
+func (s *Server) lookupAccount(kid string) (*account, *acme.ProblemDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, _ := strings.CutPrefix(kid, s.url(pathAccount))
+
+	acc, ok := s.accounts[id]
+	if !ok {
+		return nil, problem(errAccountDoesNotExist, http.StatusBadRequest, "unknown account: %s", kid)
+	}
+
+	if acc.Status != acme.StatusValid {
+		return nil, unauthorized("the account is not valid: %s", acc.Status)
+	}
+
+	return acc, nil
+}
+
+func (s *Server) findCertificate(serial *big.Int) *certificate {
+	for _, c := range s.certificates {
+		if c.cert.SerialNumber.Cmp(serial) == 0 {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func randomToken() string {
+	raw := make([]byte, 16)
+	_, _ = rand.Read(raw)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func writeJSON(rw http.ResponseWriter, status int, body any) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+
+	_ = json.NewEncoder(rw).Encode(body)
+}
+
+func writeProblem(rw http.ResponseWriter, prob *acme.ProblemDetails) {
+	rw.Header().Set("Content-Type", "application/problem+json")
+	rw.WriteHeader(prob.HTTPStatus)
+
+	_ = json.NewEncoder(rw).Encode(prob)
+}
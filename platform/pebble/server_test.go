@@ -0,0 +1,286 @@
+package pebble_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/go-acme/lego/v4/platform/pebble/pebbletest"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUser struct {
+	registration *registration.Resource
+	key          crypto.PrivateKey
+}
+
+func (u *fakeUser) GetEmail() string                        { return "test@example.com" }
+func (u *fakeUser) GetRegistration() *registration.Resource { return u.registration }
+func (u *fakeUser) GetPrivateKey() crypto.PrivateKey        { return u.key }
+
+// fakeProvider records the key authorizations presented by lego.
+type fakeProvider struct {
+	mu       sync.Mutex
+	keyAuths map[string]string
+}
+
+func (p *fakeProvider) Present(domain, _, keyAuth string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.keyAuths[domain] = keyAuth
+
+	return nil
+}
+
+func (p *fakeProvider) CleanUp(_, _, _ string) error {
+	return nil
+}
+
+func (p *fakeProvider) validate(_ acme.Challenge, identifier acme.Identifier, keyAuth string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.keyAuths[identifier.Value] != keyAuth {
+		return errors.New("unexpected key authorization")
+	}
+
+	return nil
+}
+
+func setupClient(t *testing.T, server *pebble.Server) *lego.Client {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	user := &fakeUser{key: key}
+
+	config := lego.NewConfig(user)
+	config.CADirURL = server.URL()
+	config.HTTPClient = server.Client()
+	config.Certificate.KeyType = certcrypto.EC256
+
+	client, err := lego.NewClient(config)
+	require.NoError(t, err)
+
+	user.registration, err = client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestServer_lifecycle(t *testing.T) {
+	provider := &fakeProvider{keyAuths: map[string]string{}}
+
+	server := pebbletest.NewServer(t, pebble.WithValidator(provider.validate))
+
+	client := setupClient(t, server)
+
+	require.NoError(t, client.Challenge.SetHTTP01Provider(provider))
+
+	res, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{"example.com", "www.example.com"},
+		Bundle:  true,
+	})
+	require.NoError(t, err)
+
+	certs, err := certcrypto.ParsePEMBundle(res.Certificate)
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(certs[1])
+
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		DNSName:       "www.example.com",
+		Roots:         server.Roots(),
+		Intermediates: intermediates,
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"example.com", "www.example.com"}, certs[0].DNSNames)
+
+	require.NoError(t, client.Certificate.Revoke(res.Certificate))
+
+	assert.True(t, server.IsRevoked(certs[0]))
+
+	err = client.Certificate.Revoke(res.Certificate)
+	require.ErrorContains(t, err, "urn:ietf:params:acme:error:alreadyRevoked")
+}
+
+func TestServer_invalidChallenge(t *testing.T) {
+	provider := &fakeProvider{keyAuths: map[string]string{}}
+
+	server := pebbletest.NewServer(t, pebble.WithValidator(func(acme.Challenge, acme.Identifier, string) error {
+		return errors.New("boom")
+	}))
+
+	client := setupClient(t, server)
+
+	require.NoError(t, client.Challenge.SetHTTP01Provider(provider))
+
+	_, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{"example.com"},
+	})
+	require.ErrorContains(t, err, "urn:ietf:params:acme:error:incorrectResponse :: boom")
+}
+
+func TestServer_existingAccount(t *testing.T) {
+	server := pebbletest.NewServer(t)
+
+	client := setupClient(t, server)
+
+	reg, err := client.Registration.ResolveAccountByKey()
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusValid, reg.Body.Status)
+}
+
+func TestServer_profiles(t *testing.T) {
+	provider := &fakeProvider{keyAuths: map[string]string{}}
+
+	server := pebbletest.NewServer(t,
+		pebble.WithValidator(provider.validate),
+		pebble.WithProfiles(map[string]string{"shortlived": "6 days"}),
+	)
+
+	client := setupClient(t, server)
+
+	require.NoError(t, client.Challenge.SetHTTP01Provider(provider))
+
+	_, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{"example.com"},
+		Profile: "shortlived",
+	})
+	require.NoError(t, err)
+
+	_, err = client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{"example.com"},
+		Profile: "unknown",
+	})
+	require.ErrorContains(t, err, "urn:ietf:params:acme:error:invalidProfile")
+}
+
+func TestServer_renewalInfo(t *testing.T) {
+	provider := &fakeProvider{keyAuths: map[string]string{}}
+
+	server := pebbletest.NewServer(t,
+		pebble.WithValidator(provider.validate),
+		pebble.WithRenewalInfoRetryAfter(time.Hour),
+	)
+
+	client := setupClient(t, server)
+
+	require.NoError(t, client.Challenge.SetHTTP01Provider(provider))
+
+	res, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{"example.com"},
+	})
+	require.NoError(t, err)
+
+	leaf, err := certcrypto.ParsePEMCertificate(res.Certificate)
+	require.NoError(t, err)
+
+	info, err := client.Certificate.GetRenewalInfo(certificate.RenewalInfoRequest{Cert: leaf})
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Hour, info.RetryAfter)
+	assert.True(t, info.SuggestedWindow.Start.Before(info.SuggestedWindow.End))
+	assert.True(t, info.SuggestedWindow.End.Before(leaf.NotAfter))
+
+	certID, err := certificate.MakeARICertID(leaf)
+	require.NoError(t, err)
+
+	_, err = client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains:        []string{"example.com"},
+		ReplacesCertID: certID,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, server.IsReplaced(leaf))
+
+	// lego retries without the "replaces" field when the certificate has already been replaced.
+	_, err = client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains:        []string{"example.com"},
+		ReplacesCertID: certID,
+	})
+	require.NoError(t, err)
+}
+
+func TestServer_externalAccountBinding(t *testing.T) {
+	hmacEncoded := base64.RawURLEncoding.EncodeToString([]byte("secret-secret-secret-secret-secr"))
+
+	server := pebbletest.NewServer(t, pebble.WithExternalAccountBinding("kid-1", hmacEncoded))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	config := lego.NewConfig(&fakeUser{key: key})
+	config.CADirURL = server.URL()
+	config.HTTPClient = server.Client()
+
+	client, err := lego.NewClient(config)
+	require.NoError(t, err)
+
+	_, err = client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
+	require.ErrorContains(t, err, "urn:ietf:params:acme:error:externalAccountRequired")
+
+	_, err = client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
+		TermsOfServiceAgreed: true,
+		Kid:                  "kid-2",
+		HmacEncoded:          hmacEncoded,
+	})
+	require.ErrorContains(t, err, "urn:ietf:params:acme:error:unauthorized")
+
+	reg, err := client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
+		TermsOfServiceAgreed: true,
+		Kid:                  "kid-1",
+		HmacEncoded:          hmacEncoded,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusValid, reg.Body.Status)
+}
+
+func TestServer_InjectError(t *testing.T) {
+	provider := &fakeProvider{keyAuths: map[string]string{}}
+
+	server := pebbletest.NewServer(t, pebble.WithValidator(provider.validate))
+
+	client := setupClient(t, server)
+
+	require.NoError(t, client.Challenge.SetHTTP01Provider(provider))
+
+	server.InjectError(pebble.EndpointFinalize, &acme.ProblemDetails{
+		Type:       "urn:ietf:params:acme:error:serverInternal",
+		Detail:     "injected",
+		HTTPStatus: http.StatusInternalServerError,
+	})
+
+	_, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{"example.com"},
+	})
+	require.ErrorContains(t, err, "urn:ietf:params:acme:error:serverInternal :: injected")
+
+	// The injected error is consumed.
+	_, err = client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains: []string{"example.com"},
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,124 @@
+package pebble
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+// idPeAcmeIdentifier is the OID for the ACME extension for the TLS-ALPN challenge.
+// https://www.rfc-editor.org/rfc/rfc8737.html#section-6.1
+var idPeAcmeIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+
+const validationTimeout = 10 * time.Second
+
+// SolverValidator validates the http-01 and tls-alpn-01 challenges
+// by contacting the solvers listening on the given addresses (host:port).
+// The challenges of the other types, or without address, are rejected.
+func SolverValidator(httpAddr, tlsAddr string) Validator {
+	return func(chlg acme.Challenge, identifier acme.Identifier, keyAuth string) error {
+		switch {
+		case chlg.Type == challengeHTTP01 && httpAddr != "":
+			return validateHTTP01(httpAddr, identifier.Value, chlg.Token, keyAuth)
+
+		case chlg.Type == challengeTLSALPN01 && tlsAddr != "":
+			return validateTLSALPN01(tlsAddr, identifier.Value, keyAuth)
+
+		default:
+			return fmt.Errorf("unsupported challenge type: %s", chlg.Type)
+		}
+	}
+}
+
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-8.3
+func validateHTTP01(addr, host, token, keyAuth string) error {
+	client := &http.Client{Timeout: validationTimeout}
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+addr+"/.well-known/acme-challenge/"+token, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	req.Host = host
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if got := strings.TrimSpace(string(body)); got != keyAuth {
+		return fmt.Errorf("the key authorization %q doesn't match %q", got, keyAuth)
+	}
+
+	return nil
+}
+
+// https://www.rfc-editor.org/rfc/rfc8737.html#section-3
+func validateTLSALPN01(addr, host, keyAuth string) error {
+	dialer := &net.Dialer{Timeout: validationTimeout}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{
+		ServerName:         host,
+		NextProtos:         []string{"acme-tls/1"},
+		InsecureSkipVerify: true, //nolint:gosec // the certificate is self-signed by design.
+	})
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	state := conn.ConnectionState()
+
+	if state.NegotiatedProtocol != "acme-tls/1" {
+		return fmt.Errorf("unexpected negotiated protocol: %q", state.NegotiatedProtocol)
+	}
+
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("no certificate")
+	}
+
+	expected := sha256.Sum256([]byte(keyAuth))
+
+	for _, ext := range state.PeerCertificates[0].Extensions {
+		if !ext.Id.Equal(idPeAcmeIdentifier) {
+			continue
+		}
+
+		var value []byte
+
+		_, err = asn1.Unmarshal(ext.Value, &value)
+		if err != nil {
+			return fmt.Errorf("parse the acmeIdentifier extension: %w", err)
+		}
+
+		if !bytes.Equal(value, expected[:]) {
+			return errors.New("the acmeIdentifier extension doesn't match the key authorization")
+		}
+
+		return nil
+	}
+
+	return errors.New("missing acmeIdentifier extension")
+}
@@ -0,0 +1,115 @@
+// Package redact is a registry of the secrets (API keys, tokens, passwords, etc.)
+// used to scrub them from the logs, the errors, and the HTTP dumps.
+package redact
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Replacement the value used to replace a secret.
+const Replacement = "***"
+
+// The values shorter than minLength are not registered to avoid scrubbing common words.
+const minLength = 4
+
+var registry = &secrets{}
+
+type secrets struct {
+	mu       sync.RWMutex
+	values   []string
+	replacer *strings.Replacer
+}
+
+// Register registers secrets.
+// The empty values and the values shorter than 4 characters are ignored.
+func Register(values ...string) {
+	registry.register(values...)
+}
+
+// String replaces the registered secrets with [Replacement].
+func String(s string) string {
+	return registry.redact(s)
+}
+
+// Error returns an error that replaces the registered secrets with [Replacement] in the error message.
+// The original error is still available with [errors.Unwrap], [errors.Is], and [errors.As].
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var redacted *redactedError
+	if errors.As(err, &redacted) && redacted == err {
+		return err
+	}
+
+	return &redactedError{err: err}
+}
+
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return String(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+func (s *secrets) register(values ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changed bool
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+
+		if len(value) < minLength || slices.Contains(s.values, value) {
+			continue
+		}
+
+		s.values = append(s.values, value)
+		changed = true
+	}
+
+	if !changed {
+		return
+	}
+
+	// The longest values first: a secret can contain another secret.
+	slices.SortFunc(s.values, func(a, b string) int {
+		return len(b) - len(a)
+	})
+
+	var oldnew []string
+	for _, value := range s.values {
+		oldnew = append(oldnew, value, Replacement)
+	}
+
+	s.replacer = strings.NewReplacer(oldnew...)
+}
+
+func (s *secrets) redact(value string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.replacer == nil {
+		return value
+	}
+
+	return s.replacer.Replace(value)
+}
+
+func (s *secrets) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values = nil
+	s.replacer = nil
+}
@@ -0,0 +1,70 @@
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRegistry(t *testing.T, values ...string) {
+	t.Helper()
+
+	registry.reset()
+	t.Cleanup(registry.reset)
+
+	Register(values...)
+}
+
+func TestString(t *testing.T) {
+	setupRegistry(t, "secret-token", "token", "", "abc")
+
+	testCases := []struct {
+		desc     string
+		value    string
+		expected string
+	}{
+		{
+			desc:     "no secret",
+			value:    "hello world",
+			expected: "hello world",
+		},
+		{
+			desc:     "secret",
+			value:    "https://api.example.com/?key=secret-token",
+			expected: "https://api.example.com/?key=***",
+		},
+		{
+			desc:     "secret contained in another secret",
+			value:    "token secret-token",
+			expected: "*** ***",
+		},
+		{
+			desc:     "value too short",
+			value:    "abc",
+			expected: "abc",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, String(test.value))
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	setupRegistry(t, "secret-token")
+
+	errBase := errors.New("base")
+
+	err := Error(fmt.Errorf("request https://api.example.com/?key=secret-token: %w", errBase))
+
+	require.EqualError(t, err, "request https://api.example.com/?key=***: base")
+	require.ErrorIs(t, err, errBase)
+
+	assert.Same(t, err, Error(err))
+	assert.NoError(t, Error(nil))
+}
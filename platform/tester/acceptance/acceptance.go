@@ -0,0 +1,166 @@
+// Package acceptance provides a shared acceptance test harness for the DNS providers.
+//
+// The tests run against the real API when the live test requirements are met and the environment variable
+// LEGO_ACCEPTANCE_RECORD is set to true: the HTTP interactions are recorded, sanitized, and saved as a cassette.
+// Otherwise, the recorded interactions are replayed without network access.
+package acceptance
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/vcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// EnvRecord the environment variable used to enable the recording of the cassettes.
+const EnvRecord = "LEGO_ACCEPTANCE_RECORD"
+
+const (
+	defaultToken   = "acceptance-token"
+	defaultKeyAuth = "acceptance-token.key-authorization"
+)
+
+// ProviderFactory creates a DNS provider using the given HTTP client.
+type ProviderFactory func(client *http.Client) (challenge.Provider, error)
+
+// Config the configuration of the acceptance tests of a DNS provider.
+type Config struct {
+	// EnvTest is the environment variables manager of the provider tests.
+	EnvTest *tester.EnvTest
+
+	// Cassette is the path of the cassette file.
+	// Defaults to "fixtures/acceptance.json".
+	Cassette string
+
+	// Secrets are the names of the environment variables containing secrets.
+	// Their values are replaced by [vcr.Redacted] inside the cassette,
+	// and the variables are set to [vcr.Redacted] during the replay.
+	Secrets []string
+
+	// NewProvider creates the provider.
+	// The provider must use the given HTTP client to be recorded.
+	NewProvider ProviderFactory
+
+	// Wait is the time to wait between Present and CleanUp during the recording.
+	Wait time.Duration
+
+	// SkipIdempotentCleanUp disables the check of a second CleanUp call.
+	SkipIdempotentCleanUp bool
+}
+
+// Run runs the acceptance tests of a DNS provider.
+//
+// The tests check that:
+//   - Present creates the record.
+//   - CleanUp removes the record.
+//   - a second CleanUp doesn't fail (idempotent clean up).
+//   - the timeout and interval, if defined, are positive.
+func Run(t *testing.T, cfg Config) {
+	t.Helper()
+
+	require.NotNil(t, cfg.EnvTest, "EnvTest is required")
+	require.NotNil(t, cfg.NewProvider, "NewProvider is required")
+
+	filename := cfg.Cassette
+	if filename == "" {
+		filename = filepath.Join("fixtures", "acceptance.json")
+	}
+
+	defer func() {
+		cfg.EnvTest.ClearEnv()
+		cfg.EnvTest.RestoreEnv()
+	}()
+
+	if isRecording() && cfg.EnvTest.IsLiveTest() {
+		record(t, cfg, filename)
+		return
+	}
+
+	replay(t, cfg, filename)
+}
+
+func record(t *testing.T, cfg Config, filename string) {
+	t.Helper()
+
+	cfg.EnvTest.RestoreEnv()
+
+	recorder := vcr.NewRecorder(nil)
+
+	runScenario(t, cfg, recorder.Client(), cfg.EnvTest.GetDomain(), cfg.Wait)
+
+	var secrets []string
+	for _, key := range cfg.Secrets {
+		secrets = append(secrets, cfg.EnvTest.GetValue(key))
+	}
+
+	cassette := recorder.Cassette()
+	cassette.Domain = cfg.EnvTest.GetDomain()
+	cassette.Sanitize(secrets...)
+
+	require.NoError(t, cassette.Save(filename))
+}
+
+func replay(t *testing.T, cfg Config, filename string) {
+	t.Helper()
+
+	cassette, err := vcr.LoadCassette(filename)
+	if os.IsNotExist(err) {
+		t.Skipf("skipping acceptance test: no cassette %s", filename)
+	}
+
+	require.NoError(t, err)
+
+	envVars := make(map[string]string)
+	for _, key := range cfg.Secrets {
+		envVars[key] = vcr.Redacted
+	}
+
+	cfg.EnvTest.Apply(envVars)
+
+	replayer := vcr.NewReplayer(cassette)
+
+	runScenario(t, cfg, replayer.Client(), cassette.Domain, 0)
+
+	assert.Zero(t, replayer.Remaining(), "some recorded interactions have not been replayed")
+}
+
+func runScenario(t *testing.T, cfg Config, client *http.Client, domain string, wait time.Duration) {
+	t.Helper()
+
+	provider, err := cfg.NewProvider(client)
+	require.NoError(t, err)
+
+	if p, ok := provider.(challenge.ProviderTimeout); ok {
+		timeout, interval := p.Timeout()
+		assert.Positive(t, timeout, "timeout")
+		assert.Positive(t, interval, "interval")
+	}
+
+	err = provider.Present(domain, defaultToken, defaultKeyAuth)
+	require.NoError(t, err, "Present")
+
+	time.Sleep(wait)
+
+	err = provider.CleanUp(domain, defaultToken, defaultKeyAuth)
+	require.NoError(t, err, "CleanUp")
+
+	if cfg.SkipIdempotentCleanUp {
+		return
+	}
+
+	err = provider.CleanUp(domain, defaultToken, defaultKeyAuth)
+	require.NoError(t, err, "idempotent CleanUp")
+}
+
+func isRecording() bool {
+	ok, _ := strconv.ParseBool(os.Getenv(EnvRecord))
+	return ok
+}
@@ -0,0 +1,146 @@
+package acceptance
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/vcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	envAPIKey = "FAKE_API_KEY"
+	envDomain = "FAKE_DOMAIN"
+)
+
+type fakeProvider struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func (p *fakeProvider) Present(domain, _, _ string) error {
+	return p.do(http.MethodPut, domain)
+}
+
+func (p *fakeProvider) CleanUp(domain, _, _ string) error {
+	return p.do(http.MethodDelete, domain)
+}
+
+func (p *fakeProvider) Timeout() (timeout, interval time.Duration) {
+	return time.Minute, time.Second
+}
+
+func (p *fakeProvider) do(method, domain string) error {
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/records/%s?key=%s", p.baseURL, domain, p.apiKey), http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Auth-Key", p.apiKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func setupAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+
+	records := map[string]bool{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Auth-Key") != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch req.Method {
+		case http.MethodPut:
+			records[req.URL.Path] = true
+		case http.MethodDelete:
+			delete(records, req.URL.Path)
+		}
+
+		_, _ = fmt.Fprintf(rw, `{"path":%q}`, req.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRun(t *testing.T) {
+	server := setupAPI(t)
+
+	cassette := filepath.Join(t.TempDir(), "fixtures", "acceptance.json")
+
+	newConfig := func() Config {
+		return Config{
+			EnvTest:  tester.NewEnvTest(envAPIKey, envDomain).WithDomain(envDomain),
+			Cassette: cassette,
+			Secrets:  []string{envAPIKey},
+			NewProvider: func(client *http.Client) (challenge.Provider, error) {
+				apiKey := os.Getenv(envAPIKey)
+				if apiKey == "" {
+					return nil, errors.New("missing API key")
+				}
+
+				return &fakeProvider{baseURL: server.URL, apiKey: apiKey, client: client}, nil
+			},
+		}
+	}
+
+	// Record.
+	t.Setenv(envAPIKey, "secret")
+	t.Setenv(envDomain, "example.com")
+	t.Setenv(EnvRecord, "true")
+
+	Run(t, newConfig())
+
+	raw, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(raw), "secret")
+
+	recorded, err := vcr.LoadCassette(cassette)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", recorded.Domain)
+	require.Len(t, recorded.Interactions, 3)
+	assert.Equal(t, []string{vcr.Redacted}, recorded.Interactions[0].Request.Header.Values("X-Auth-Key"))
+
+	// Replay without credentials and without the API.
+	server.Close()
+
+	t.Setenv(envAPIKey, "")
+	t.Setenv(envDomain, "")
+	t.Setenv(EnvRecord, "")
+
+	Run(t, newConfig())
+
+	assert.Empty(t, os.Getenv(envAPIKey))
+}
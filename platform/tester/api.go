@@ -11,6 +11,8 @@ import (
 )
 
 // MockACMEServer Minimal stub ACME server for validation.
+// The protocol flows (profiles, renewal information, External Account Binding, error injection)
+// are provided by the in-process ACME server of the package platform/pebble.
 func MockACMEServer() *servermock.Builder[*httptest.Server] {
 	return servermock.NewBuilder(
 		func(server *httptest.Server) (*httptest.Server, error) {
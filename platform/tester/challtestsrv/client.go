@@ -0,0 +1,141 @@
+// Package challtestsrv provides a client for the management API of Pebble's challenge test server (pebble-challtestsrv),
+// and challenge providers backed by it.
+// https://github.com/letsencrypt/pebble/tree/main/cmd/pebble-challtestsrv
+package challtestsrv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultManagementURL the default URL of the management API.
+const DefaultManagementURL = "http://localhost:8055"
+
+// Client a client for the management API of challtestsrv.
+type Client struct {
+	baseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(managementURL string) (*Client, error) {
+	if managementURL == "" {
+		managementURL = DefaultManagementURL
+	}
+
+	baseURL, err := url.Parse(managementURL)
+	if err != nil {
+		return nil, fmt.Errorf("challtestsrv: %w", err)
+	}
+
+	return &Client{
+		baseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// SetDefaultIPv4 sets the IPv4 address returned for A queries of hosts without explicit A records.
+func (c *Client) SetDefaultIPv4(ctx context.Context, ip string) error {
+	return c.post(ctx, "/set-default-ipv4", map[string]string{"ip": ip})
+}
+
+// AddARecord adds A records for a host.
+func (c *Client) AddARecord(ctx context.Context, host string, addresses ...string) error {
+	return c.post(ctx, "/add-a", hostAddresses{Host: host, Addresses: addresses})
+}
+
+// ClearARecord removes the A records of a host.
+func (c *Client) ClearARecord(ctx context.Context, host string) error {
+	return c.post(ctx, "/clear-a", hostAddresses{Host: host})
+}
+
+// SetTXT adds a TXT record for a host.
+func (c *Client) SetTXT(ctx context.Context, host, value string) error {
+	return c.post(ctx, "/set-txt", hostValue{Host: host, Value: value})
+}
+
+// ClearTXT removes the TXT records of a host.
+func (c *Client) ClearTXT(ctx context.Context, host string) error {
+	return c.post(ctx, "/clear-txt", hostValue{Host: host})
+}
+
+// AddHTTP01 adds an HTTP-01 challenge response.
+func (c *Client) AddHTTP01(ctx context.Context, token, content string) error {
+	return c.post(ctx, "/add-http01", tokenContent{Token: token, Content: content})
+}
+
+// DelHTTP01 removes an HTTP-01 challenge response.
+func (c *Client) DelHTTP01(ctx context.Context, token string) error {
+	return c.post(ctx, "/del-http01", tokenContent{Token: token})
+}
+
+// AddTLSALPN01 adds a TLS-ALPN-01 challenge response.
+func (c *Client) AddTLSALPN01(ctx context.Context, host, content string) error {
+	return c.post(ctx, "/add-tlsalpn01", hostContent{Host: host, Content: content})
+}
+
+// DelTLSALPN01 removes a TLS-ALPN-01 challenge response.
+func (c *Client) DelTLSALPN01(ctx context.Context, host string) error {
+	return c.post(ctx, "/del-tlsalpn01", hostContent{Host: host})
+}
+
+func (c *Client) post(ctx context.Context, uri string, payload any) error {
+	body := new(bytes.Buffer)
+
+	err := json.NewEncoder(body).Encode(payload)
+	if err != nil {
+		return fmt.Errorf("challtestsrv: failed to create request JSON body: %w", err)
+	}
+
+	endpoint := c.baseURL.JoinPath(uri)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), body)
+	if err != nil {
+		return fmt.Errorf("challtestsrv: unable to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("challtestsrv: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(resp.Body)
+
+		return fmt.Errorf("challtestsrv: %s %s: unexpected status code: [status code: %d] body: %s",
+			req.Method, uri, resp.StatusCode, string(raw))
+	}
+
+	return nil
+}
+
+type hostValue struct {
+	Host  string `json:"host"`
+	Value string `json:"value,omitempty"`
+}
+
+type hostContent struct {
+	Host    string `json:"host"`
+	Content string `json:"content,omitempty"`
+}
+
+type hostAddresses struct {
+	Host      string   `json:"host"`
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+type tokenContent struct {
+	Token   string `json:"token"`
+	Content string `json:"content,omitempty"`
+}
@@ -0,0 +1,85 @@
+package challtestsrv
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+)
+
+var (
+	_ challenge.ProviderTimeout = (*DNSProvider)(nil)
+	_ challenge.Provider        = (*HTTPProvider)(nil)
+	_ challenge.Provider        = (*TLSALPNProvider)(nil)
+)
+
+// DNSProvider a dns-01 challenge provider backed by challtestsrv.
+type DNSProvider struct {
+	client *Client
+}
+
+// NewDNSProvider creates a new DNSProvider.
+func NewDNSProvider(client *Client) *DNSProvider {
+	return &DNSProvider{client: client}
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, _, keyAuth string) error {
+	info := dns01.GetChallengeInfo(domain, keyAuth)
+
+	return d.client.SetTXT(context.Background(), info.EffectiveFQDN, info.Value)
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, _, keyAuth string) error {
+	info := dns01.GetChallengeInfo(domain, keyAuth)
+
+	return d.client.ClearTXT(context.Background(), info.EffectiveFQDN)
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// The records are served immediately by challtestsrv.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return 10 * time.Second, 500 * time.Millisecond
+}
+
+// HTTPProvider an http-01 challenge provider backed by challtestsrv.
+type HTTPProvider struct {
+	client *Client
+}
+
+// NewHTTPProvider creates a new HTTPProvider.
+func NewHTTPProvider(client *Client) *HTTPProvider {
+	return &HTTPProvider{client: client}
+}
+
+// Present registers the challenge response.
+func (h *HTTPProvider) Present(_, token, keyAuth string) error {
+	return h.client.AddHTTP01(context.Background(), token, keyAuth)
+}
+
+// CleanUp removes the challenge response.
+func (h *HTTPProvider) CleanUp(_, token, _ string) error {
+	return h.client.DelHTTP01(context.Background(), token)
+}
+
+// TLSALPNProvider a tls-alpn-01 challenge provider backed by challtestsrv.
+type TLSALPNProvider struct {
+	client *Client
+}
+
+// NewTLSALPNProvider creates a new TLSALPNProvider.
+func NewTLSALPNProvider(client *Client) *TLSALPNProvider {
+	return &TLSALPNProvider{client: client}
+}
+
+// Present registers the challenge response.
+func (t *TLSALPNProvider) Present(domain, _, keyAuth string) error {
+	return t.client.AddTLSALPN01(context.Background(), domain, keyAuth)
+}
+
+// CleanUp removes the challenge response.
+func (t *TLSALPNProvider) CleanUp(domain, _, _ string) error {
+	return t.client.DelTLSALPN01(context.Background(), domain)
+}
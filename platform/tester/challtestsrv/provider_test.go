@@ -0,0 +1,76 @@
+package challtestsrv
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/require"
+)
+
+func mockBuilder() *servermock.Builder[*Client] {
+	return servermock.NewBuilder(
+		func(server *httptest.Server) (*Client, error) {
+			client, err := NewClient(server.URL)
+			if err != nil {
+				return nil, err
+			}
+
+			client.HTTPClient = server.Client()
+
+			return client, nil
+		},
+		servermock.CheckHeader().WithJSONHeaders(),
+	)
+}
+
+func TestDNSProvider(t *testing.T) {
+	client := mockBuilder().
+		Route("POST /set-txt", nil,
+			servermock.CheckRequestJSONBody(`{"host":"_acme-challenge.domain.","value":"LHDhK3oGRvkiefQnx7OOczTY5Tic_xZ6HcMOc_gmtoM"}`)).
+		Route("POST /clear-txt", nil,
+			servermock.CheckRequestJSONBody(`{"host":"_acme-challenge.domain."}`)).
+		Build(t)
+
+	provider := NewDNSProvider(client)
+
+	require.NoError(t, provider.Present("domain", "token", "key"))
+	require.NoError(t, provider.CleanUp("domain", "token", "key"))
+}
+
+func TestHTTPProvider(t *testing.T) {
+	client := mockBuilder().
+		Route("POST /add-http01", nil,
+			servermock.CheckRequestJSONBody(`{"token":"token","content":"key"}`)).
+		Route("POST /del-http01", nil,
+			servermock.CheckRequestJSONBody(`{"token":"token"}`)).
+		Build(t)
+
+	provider := NewHTTPProvider(client)
+
+	require.NoError(t, provider.Present("domain", "token", "key"))
+	require.NoError(t, provider.CleanUp("domain", "token", "key"))
+}
+
+func TestTLSALPNProvider(t *testing.T) {
+	client := mockBuilder().
+		Route("POST /add-tlsalpn01", nil,
+			servermock.CheckRequestJSONBody(`{"host":"domain","content":"key"}`)).
+		Route("POST /del-tlsalpn01", nil,
+			servermock.CheckRequestJSONBody(`{"host":"domain"}`)).
+		Build(t)
+
+	provider := NewTLSALPNProvider(client)
+
+	require.NoError(t, provider.Present("domain", "token", "key"))
+	require.NoError(t, provider.CleanUp("domain", "token", "key"))
+}
+
+func TestDNSProvider_error(t *testing.T) {
+	client := mockBuilder().Build(t)
+
+	provider := NewDNSProvider(client)
+
+	err := provider.Present("domain", "token", "key")
+	require.EqualError(t, err, "challtestsrv: POST /set-txt: unexpected status code: [status code: 404] body: 404 page not found\n")
+}
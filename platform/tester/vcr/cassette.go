@@ -0,0 +1,146 @@
+package vcr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Redacted is the placeholder used to replace secrets inside cassettes.
+const Redacted = "REDACTED"
+
+// sensitiveHeaders the headers always removed from cassettes.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Api-Key",
+	"X-Auth-Key",
+	"X-Auth-Token",
+}
+
+// Cassette a set of recorded HTTP interactions.
+type Cassette struct {
+	// Domain is the domain used during the recording.
+	Domain string `json:"domain"`
+
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction a recorded HTTP request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest a recorded HTTP request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse a recorded HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// LoadCassette reads a cassette from a file.
+func LoadCassette(filename string) (*Cassette, error) {
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+
+	err = json.Unmarshal(raw, &cassette)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal cassette %s: %w", filename, err)
+	}
+
+	return &cassette, nil
+}
+
+// Save writes the cassette to a file.
+func (c *Cassette) Save(filename string) error {
+	raw, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cassette: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(filename), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, append(raw, '\n'), 0o644)
+}
+
+// Sanitize removes the sensitive headers and replaces the secrets by [Redacted].
+func (c *Cassette) Sanitize(secrets ...string) {
+	replacer := newSecretReplacer(secrets)
+
+	for i := range c.Interactions {
+		it := &c.Interactions[i]
+
+		it.Request.URL = replacer.Replace(it.Request.URL)
+		it.Request.Body = replacer.Replace(it.Request.Body)
+		it.Request.Header = sanitizeHeader(it.Request.Header, replacer)
+
+		it.Response.Body = replacer.Replace(it.Response.Body)
+		it.Response.Header = sanitizeHeader(it.Response.Header, replacer)
+	}
+}
+
+func sanitizeHeader(header http.Header, replacer *strings.Replacer) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	result := make(http.Header, len(header))
+
+	for key, values := range header {
+		if isSensitiveHeader(key) {
+			result[key] = []string{Redacted}
+			continue
+		}
+
+		for _, value := range values {
+			result[key] = append(result[key], replacer.Replace(value))
+		}
+	}
+
+	return result
+}
+
+func isSensitiveHeader(key string) bool {
+	for _, h := range sensitiveHeaders {
+		if strings.EqualFold(h, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newSecretReplacer(secrets []string) *strings.Replacer {
+	var oldnew []string
+
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+
+		oldnew = append(oldnew, secret, Redacted)
+	}
+
+	return strings.NewReplacer(oldnew...)
+}
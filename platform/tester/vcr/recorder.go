@@ -0,0 +1,166 @@
+package vcr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Mode the mode of a [Recorder].
+type Mode int
+
+const (
+	// ModeReplay serves the responses from a cassette, without network access.
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests to the real API and records the interactions.
+	ModeRecord
+)
+
+// Recorder an [http.RoundTripper] that records or replays HTTP interactions.
+type Recorder struct {
+	mode Mode
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+	position int
+}
+
+// NewRecorder creates a [Recorder] that records the interactions into the cassette.
+func NewRecorder(next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Recorder{
+		mode:     ModeRecord,
+		next:     next,
+		cassette: &Cassette{},
+	}
+}
+
+// NewReplayer creates a [Recorder] that replays the interactions of the cassette.
+// The requests must be sent in the same order as during the recording.
+func NewReplayer(cassette *Cassette) *Recorder {
+	return &Recorder{
+		mode:     ModeReplay,
+		cassette: cassette,
+	}
+}
+
+// Client returns an HTTP client using the recorder as transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Cassette returns the cassette of the recorder.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cassette
+}
+
+// Remaining returns the number of interactions not yet replayed.
+func (r *Recorder) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode != ModeReplay {
+		return 0
+	}
+
+	return len(r.cassette.Interactions) - r.position
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		var err error
+
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+
+		_ = req.Body.Close()
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	})
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.position >= len(r.cassette.Interactions) {
+		return nil, fmt.Errorf("unexpected request %s %s: no more recorded interactions", req.Method, req.URL)
+	}
+
+	it := r.cassette.Interactions[r.position]
+
+	if it.Request.Method != req.Method || it.Request.URL != req.URL.String() {
+		return nil, fmt.Errorf("unexpected request %s %s: expected %s %s (interaction %d)",
+			req.Method, req.URL, it.Request.Method, it.Request.URL, r.position)
+	}
+
+	r.position++
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", it.Response.StatusCode, http.StatusText(it.Response.StatusCode)),
+		StatusCode:    it.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        it.Response.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewBufferString(it.Response.Body)),
+		ContentLength: int64(len(it.Response.Body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,72 @@
+// Package vcr records and replays the HTTP interactions of API clients.
+//
+// The interactions are stored, without secrets, inside cassettes (JSON files).
+// The tests use the cassettes to exercise the parsing of real API responses without network access.
+package vcr
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// EnvRecord the environment variable used to enable the recording of the cassettes.
+const EnvRecord = "LEGO_VCR_RECORD"
+
+// New returns an HTTP client that replays the interactions of the cassette "fixtures/cassettes/<name>.json".
+//
+// When the environment variable LEGO_VCR_RECORD is set to true,
+// the client sends the requests to the real API, and the cassette is (re)written at the end of the test.
+// The secrets are replaced by [Redacted] inside the cassette.
+func New(t *testing.T, name string, secrets ...string) *http.Client {
+	t.Helper()
+
+	client, _ := NewForDomain(t, name, "", secrets...)
+
+	return client
+}
+
+// NewForDomain is like [New], and stores the domain used during the recording inside the cassette.
+// It returns the domain to use: the given domain during the recording, the recorded domain during the replay.
+func NewForDomain(t *testing.T, name, domain string, secrets ...string) (*http.Client, string) {
+	t.Helper()
+
+	filename := filepath.Join("fixtures", "cassettes", name+".json")
+
+	if IsRecording() {
+		recorder := NewRecorder(nil)
+
+		t.Cleanup(func() {
+			cassette := recorder.Cassette()
+			cassette.Domain = domain
+			cassette.Sanitize(secrets...)
+
+			require.NoError(t, cassette.Save(filename))
+		})
+
+		return recorder.Client(), domain
+	}
+
+	cassette, err := LoadCassette(filename)
+	require.NoError(t, err, "use %s=true to record the cassette", EnvRecord)
+
+	replayer := NewReplayer(cassette)
+
+	t.Cleanup(func() {
+		assert.Zero(t, replayer.Remaining(), "some recorded interactions have not been replayed")
+	})
+
+	return replayer.Client(), cassette.Domain
+}
+
+// IsRecording reports whether the cassettes are recorded (LEGO_VCR_RECORD=true).
+// During the replay, the secrets must be set to [Redacted].
+func IsRecording() bool {
+	ok, _ := strconv.ParseBool(os.Getenv(EnvRecord))
+	return ok
+}
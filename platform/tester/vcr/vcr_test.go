@@ -0,0 +1,150 @@
+package vcr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_replay_unexpectedRequest(t *testing.T) {
+	replayer := NewReplayer(&Cassette{
+		Interactions: []Interaction{{
+			Request:  RecordedRequest{Method: http.MethodGet, URL: "https://example.com/a"},
+			Response: RecordedResponse{StatusCode: http.StatusOK, Body: "ok"},
+		}},
+	})
+
+	client := replayer.Client()
+
+	_, err := client.Get("https://example.com/b")
+	require.ErrorContains(t, err, "unexpected request GET https://example.com/b: expected GET https://example.com/a (interaction 0)")
+
+	resp, err := client.Get("https://example.com/a")
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Zero(t, replayer.Remaining())
+
+	_, err = client.Get("https://example.com/a")
+	require.ErrorContains(t, err, "no more recorded interactions")
+}
+
+func TestCassette_Sanitize(t *testing.T) {
+	cassette := &Cassette{
+		Interactions: []Interaction{{
+			Request: RecordedRequest{
+				Method: http.MethodPost,
+				URL:    "https://example.com/api?token=s3cr3t",
+				Header: http.Header{"Authorization": {"Bearer s3cr3t"}, "X-Custom": {"s3cr3t-value"}},
+				Body:   `{"password":"s3cr3t"}`,
+			},
+			Response: RecordedResponse{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Set-Cookie": {"session=abc"}},
+				Body:       `{"echo":"s3cr3t"}`,
+			},
+		}},
+	}
+
+	cassette.Sanitize("s3cr3t", "")
+
+	expected := Interaction{
+		Request: RecordedRequest{
+			Method: http.MethodPost,
+			URL:    "https://example.com/api?token=REDACTED",
+			Header: http.Header{"Authorization": {Redacted}, "X-Custom": {"REDACTED-value"}},
+			Body:   `{"password":"REDACTED"}`,
+		},
+		Response: RecordedResponse{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Set-Cookie": {Redacted}},
+			Body:       `{"echo":"REDACTED"}`,
+		},
+	}
+
+	assert.Equal(t, expected, cassette.Interactions[0])
+}
+
+func TestNew(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprintf(rw, `{"token":%q}`, req.Header.Get("Authorization"))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Chdir(t.TempDir())
+
+	call := func(t *testing.T, client *http.Client) string {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/zones", http.NoBody)
+		require.NoError(t, err)
+
+		req.Header.Set("Authorization", "s3cr3t")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return string(body)
+	}
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(EnvRecord, "true")
+
+		assert.JSONEq(t, `{"token":"s3cr3t"}`, call(t, New(t, "zones", "s3cr3t")))
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		assert.JSONEq(t, `{"token":"REDACTED"}`, call(t, New(t, "zones")))
+	})
+}
+
+func TestNewForDomain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, req.URL.Query().Get("domain"))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Chdir(t.TempDir())
+
+	call := func(t *testing.T, client *http.Client, domain string) string {
+		t.Helper()
+
+		resp, err := client.Get(server.URL + "/update?domain=" + domain)
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return string(body)
+	}
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(EnvRecord, "true")
+
+		client, domain := NewForDomain(t, "update", "recorded.example.com")
+		assert.Equal(t, "recorded.example.com", domain)
+
+		assert.Equal(t, "recorded.example.com", call(t, client, domain))
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		client, domain := NewForDomain(t, "update", "ignored.example.com")
+		assert.Equal(t, "recorded.example.com", domain)
+
+		assert.Equal(t, "recorded.example.com", call(t, client, domain))
+	})
+}
@@ -11,6 +11,11 @@ import (
 
 // For polls the given function 'f', once every 'interval', up to 'timeout'.
 func For(msg string, timeout, interval time.Duration, f func() (bool, error)) error {
+	return ForWithContext(context.Background(), msg, timeout, interval, f)
+}
+
+// ForWithContext is like For, but the polling stops when the context is done.
+func ForWithContext(ctx context.Context, msg string, timeout, interval time.Duration, f func() (bool, error)) error {
 	log.Infof("Wait for %s [timeout: %s, interval: %s]", msg, timeout, interval)
 
 	var lastErr error
@@ -37,7 +42,11 @@ func For(msg string, timeout, interval time.Duration, f func() (bool, error)) er
 			lastErr = err
 		}
 
-		time.Sleep(interval)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", msg, ctx.Err())
+		case <-time.After(interval):
+		}
 	}
 }
 
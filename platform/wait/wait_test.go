@@ -1,6 +1,7 @@
 package wait
 
 import (
+	"context"
 	"errors"
 	"sync/atomic"
 	"testing"
@@ -119,3 +120,20 @@ func TestFor_stop_with_error(t *testing.T) {
 
 	require.EqualValues(t, 1, io.Load())
 }
+
+func TestForWithContext_canceled(t *testing.T) {
+	var io atomic.Int64
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	err := ForWithContext(ctx, "test", 10*time.Second, 1*time.Second, func() (bool, error) {
+		io.Add(1)
+
+		cancel()
+
+		return false, nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.EqualValues(t, 1, io.Load())
+}
@@ -0,0 +1,200 @@
+// Package acm implements a deployer importing the certificates in AWS Certificate Manager (ACM).
+package acm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "AWS_ACM_"
+
+	EnvRegion          = envNamespace + "REGION"
+	EnvCertificateARNs = envNamespace + "CERTIFICATE_ARNS"
+)
+
+// The tag of the certificates imported by the deployer.
+const tagDomain = "lego:domain"
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	// Region the default region, used when the ARN of the certificate is unknown.
+	Region string
+
+	// CertificateARNs the ARN of the ACM certificate of each domain (main domain of the certificate).
+	// The region of the ARN is used.
+	CertificateARNs map[string]string
+
+	Client *acm.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Region: env.GetOrFile(EnvRegion),
+	}
+}
+
+// Deployer imports the certificates in ACM.
+// A certificate is re-imported with the same ARN, so the resources using it (ALB, CloudFront, etc.) use the new certificate.
+type Deployer struct {
+	config *Config
+	client *acm.Client
+}
+
+// NewDeployer returns a Deployer instance configured for ACM.
+// The credentials are read from the default locations of the AWS SDK (environment, shared files, IAM role).
+func NewDeployer() (*Deployer, error) {
+	config := NewDefaultConfig()
+
+	arns, err := parseCertificateARNs(env.GetOrFile(EnvCertificateARNs))
+	if err != nil {
+		return nil, fmt.Errorf("acm: %w", err)
+	}
+
+	config.CertificateARNs = arns
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for ACM.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("acm: the configuration of the deployer is nil")
+	}
+
+	if config.Client != nil {
+		return &Deployer{config: config, client: config.Client}, nil
+	}
+
+	var optFns []func(options *awsconfig.LoadOptions) error
+
+	if config.Region != "" {
+		optFns = append(optFns, awsconfig.WithRegion(config.Region))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("acm: %w", err)
+	}
+
+	return &Deployer{
+		config: config,
+		client: acm.NewFromConfig(cfg),
+	}, nil
+}
+
+// Deploy imports the certificate: re-imports it in the existing ACM certificate, or imports it as a new ACM certificate.
+// Without a configured ARN, the existing ACM certificate is the certificate imported for the same domain.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("acm: %w", err)
+	}
+
+	var optFns []func(*acm.Options)
+
+	certificateARN := d.config.CertificateARNs[res.Domain]
+	if certificateARN != "" {
+		parsed, errP := arn.Parse(certificateARN)
+		if errP != nil {
+			return fmt.Errorf("acm: certificate ARN: %w", errP)
+		}
+
+		optFns = append(optFns, func(options *acm.Options) { options.Region = parsed.Region })
+	} else {
+		certificateARN, err = d.findCertificate(ctx, res.Domain)
+		if err != nil {
+			return fmt.Errorf("acm: find certificate: %w", err)
+		}
+	}
+
+	input := &acm.ImportCertificateInput{
+		Certificate: m.Certificate,
+		PrivateKey:  m.PrivateKey,
+	}
+
+	if len(m.Chain) > 0 {
+		input.CertificateChain = m.Chain
+	}
+
+	if certificateARN != "" {
+		input.CertificateArn = aws.String(certificateARN)
+	} else {
+		// The tags can only be defined by the first import.
+		input.Tags = []awstypes.Tag{{Key: aws.String(tagDomain), Value: aws.String(res.Domain)}}
+	}
+
+	output, err := d.client.ImportCertificate(ctx, input, optFns...)
+	if err != nil {
+		return fmt.Errorf("acm: import certificate: %w", err)
+	}
+
+	if certificateARN == "" {
+		log.Infof("[%s] acm: the certificate has been imported: %s", res.Domain, aws.ToString(output.CertificateArn))
+	}
+
+	return nil
+}
+
+// findCertificate returns the ARN of the imported ACM certificate of the domain, if any.
+func (d *Deployer) findCertificate(ctx context.Context, domain string) (string, error) {
+	input := &acm.ListCertificatesInput{
+		// By default, only the RSA 2048 certificates are listed.
+		Includes: &awstypes.Filters{KeyTypes: awstypes.KeyAlgorithm("").Values()},
+	}
+
+	paginator := acm.NewListCertificatesPaginator(d.client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		for _, summary := range page.CertificateSummaryList {
+			if summary.Type == awstypes.CertificateTypeImported && aws.ToString(summary.DomainName) == domain {
+				return aws.ToString(summary.CertificateArn), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// parseCertificateARNs parses the ARNs of the certificates (e.g. "example.com=arn:...,*.example.org=arn:...").
+func parseCertificateARNs(raw string) (map[string]string, error) {
+	arns := make(map[string]string)
+
+	for entry := range strings.SplitSeq(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		domain, value, ok := strings.Cut(entry, "=")
+
+		domain, value = strings.TrimSpace(domain), strings.TrimSpace(value)
+
+		if !ok || domain == "" || !arn.IsARN(value) {
+			return nil, fmt.Errorf("invalid certificate ARN: %q (expected: domain=arn)", entry)
+		}
+
+		arns[domain] = value
+	}
+
+	return arns, nil
+}
@@ -0,0 +1,197 @@
+package acm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	exampleARN = "arn:aws:acm:us-west-2:123456789012:certificate/11111111-2222-3333-4444-555555555555"
+	newARN     = "arn:aws:acm:mock-region:123456789012:certificate/66666666-7777-8888-9999-000000000000"
+)
+
+var envTest = tester.NewEnvTest(EnvRegion, EnvCertificateARNs)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvRegion:          "us-east-1",
+				EnvCertificateARNs: "example.com=" + exampleARN,
+			},
+		},
+		{
+			desc: "invalid ARN",
+			envVars: map[string]string{
+				EnvCertificateARNs: "example.com=foo",
+			},
+			expected: `acm: invalid certificate ARN: "example.com=foo" (expected: domain=arn)`,
+		},
+		{
+			desc: "missing domain",
+			envVars: map[string]string{
+				EnvCertificateARNs: exampleARN,
+			},
+			expected: `acm: invalid certificate ARN: "` + exampleARN + `" (expected: domain=arn)`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_parseCertificateARNs(t *testing.T) {
+	arns, err := parseCertificateARNs(" example.com=" + exampleARN + ", *.example.org = " + newARN + ",")
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		"example.com":   exampleARN,
+		"*.example.org": newARN,
+	}
+
+	assert.Equal(t, expected, arns)
+}
+
+// mockACM the ACM API (JSON 1.1 protocol): the operations are identified by the X-Amz-Target header.
+type mockACM struct {
+	summaries []map[string]string
+	imported  map[string]any
+	region    string
+}
+
+func (m *mockACM) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	// The signing region is in the credential scope of the Authorization header (Credential=<key>/<date>/<region>/acm/aws4_request).
+	if scope := strings.Split(req.Header.Get("Authorization"), "/"); len(scope) > 2 {
+		m.region = scope[2]
+	}
+
+	raw, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/x-amz-json-1.1")
+
+	switch req.Header.Get("X-Amz-Target") {
+	case "CertificateManager.ListCertificates":
+		_ = json.NewEncoder(rw).Encode(map[string]any{"CertificateSummaryList": m.summaries})
+
+	case "CertificateManager.ImportCertificate":
+		err = json.Unmarshal(raw, &m.imported)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		certificateARN, ok := m.imported["CertificateArn"].(string)
+		if !ok {
+			certificateARN = newARN
+		}
+
+		_ = json.NewEncoder(rw).Encode(map[string]any{"CertificateArn": certificateARN})
+
+	default:
+		http.Error(rw, "unexpected target: "+req.Header.Get("X-Amz-Target"), http.StatusBadRequest)
+	}
+}
+
+func setupDeployer(arns map[string]string) func(server *httptest.Server) (*Deployer, error) {
+	return func(server *httptest.Server) (*Deployer, error) {
+		cfg := aws.Config{
+			HTTPClient:       server.Client(),
+			Credentials:      credentials.NewStaticCredentialsProvider("abc", "123", " "),
+			Region:           "mock-region",
+			BaseEndpoint:     aws.String(server.URL),
+			RetryMaxAttempts: 1,
+		}
+
+		config := NewDefaultConfig()
+		config.CertificateARNs = arns
+		config.Client = acm.NewFromConfig(cfg)
+
+		return NewDeployerConfig(config)
+	}
+}
+
+func TestDeployer_Deploy_configuredARN(t *testing.T) {
+	mock := &mockACM{}
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(map[string]string{"example.com": exampleARN})).
+		Route("POST /", mock).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+
+	assert.Equal(t, exampleARN, mock.imported["CertificateArn"])
+	assert.Equal(t, "us-west-2", mock.region)
+	assert.NotContains(t, mock.imported, "Tags")
+	assert.NotEmpty(t, mock.imported["CertificateChain"])
+}
+
+func TestDeployer_Deploy_existingCertificate(t *testing.T) {
+	mock := &mockACM{summaries: []map[string]string{
+		{"CertificateArn": "arn:aws:acm:mock-region:123456789012:certificate/other", "DomainName": "example.org", "Type": "IMPORTED"},
+		{"CertificateArn": "arn:aws:acm:mock-region:123456789012:certificate/issued", "DomainName": "example.com", "Type": "AMAZON_ISSUED"},
+		{"CertificateArn": exampleARN, "DomainName": "example.com", "Type": "IMPORTED"},
+	}}
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(nil)).
+		Route("POST /", mock).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+
+	assert.Equal(t, exampleARN, mock.imported["CertificateArn"])
+}
+
+func TestDeployer_Deploy_newCertificate(t *testing.T) {
+	mock := &mockACM{}
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(nil)).
+		Route("POST /", mock).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+
+	assert.NotContains(t, mock.imported, "CertificateArn")
+	assert.Equal(t, []any{map[string]any{"Key": tagDomain, "Value": "example.com"}}, mock.imported["Tags"])
+}
@@ -0,0 +1,229 @@
+// Package azurekeyvault implements a deployer importing the certificates in Azure Key Vault.
+package azurekeyvault
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/deploy/azurekeyvault/internal"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "AZURE_KEYVAULT_"
+
+	EnvVaultURL        = envNamespace + "URL"
+	EnvCertificateName = envNamespace + "CERTIFICATE_NAME"
+	EnvAuthMethod      = envNamespace + "AUTH_METHOD"
+	EnvClientID        = envNamespace + "CLIENT_ID"
+
+	EnvHTTPTimeout = envNamespace + "HTTP_TIMEOUT"
+)
+
+const (
+	authMethodMSI     = "msi"
+	authMethodEnv     = "env"
+	authMethodWLI     = "wli"
+	authMethodCLI     = "cli"
+	authMethodDefault = "default"
+)
+
+const defaultCertificateName = "{{ .Name }}"
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	VaultURL string
+
+	// CertificateName the template of the name of the certificate object.
+	CertificateName string
+
+	// AuthMethod the authentication method: msi (managed identity), env, wli (workload identity), cli, or default.
+	AuthMethod string
+	// ClientID the client ID of a user-assigned managed identity.
+	ClientID string
+
+	// Credential the credential used instead of the authentication method (optional).
+	Credential azcore.TokenCredential
+
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		CertificateName: env.GetOrDefaultString(EnvCertificateName, defaultCertificateName),
+		AuthMethod:      env.GetOrDefaultString(EnvAuthMethod, authMethodMSI),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		},
+	}
+}
+
+// TemplateData the data of the template of the name of the certificate object.
+type TemplateData struct {
+	// Domain the main domain of the certificate.
+	Domain string
+	// Name the main domain as a valid name of certificate object (e.g. "*.example.com" -> "wildcard-example-com").
+	Name string
+}
+
+// Deployer imports the certificates as new versions of Key Vault certificate objects.
+// The resources referencing the certificate object without version (Application Gateway, App Service, etc.) use the new version.
+type Deployer struct {
+	config *Config
+	client *internal.Client
+
+	certificateName *template.Template
+}
+
+// NewDeployer returns a Deployer instance configured for Azure Key Vault.
+func NewDeployer() (*Deployer, error) {
+	config := NewDefaultConfig()
+	config.VaultURL = env.GetOrFile(EnvVaultURL)
+	config.ClientID = env.GetOrFile(EnvClientID)
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for Azure Key Vault.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("azurekeyvault: the configuration of the deployer is nil")
+	}
+
+	if config.VaultURL == "" {
+		return nil, errors.New("azurekeyvault: the vault URL is missing")
+	}
+
+	certificateName, err := template.New("certificateName").Parse(config.CertificateName)
+	if err != nil {
+		return nil, fmt.Errorf("azurekeyvault: certificate name: %w", err)
+	}
+
+	credential := config.Credential
+	if credential == nil {
+		credential, err = getCredential(config)
+		if err != nil {
+			return nil, fmt.Errorf("azurekeyvault: credentials: %w", err)
+		}
+	}
+
+	client, err := internal.NewClient(config.VaultURL, credential)
+	if err != nil {
+		return nil, fmt.Errorf("azurekeyvault: %w", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &Deployer{
+		config:          config,
+		client:          client,
+		certificateName: certificateName,
+	}, nil
+}
+
+// Deploy imports the certificate as a new version of the certificate object.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("azurekeyvault: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = d.certificateName.Execute(buf, TemplateData{Domain: res.Domain, Name: CertificateName(res.Domain)})
+	if err != nil {
+		return fmt.Errorf("azurekeyvault: certificate name: %w", err)
+	}
+
+	// Key Vault only supports the unencrypted PKCS#8 private keys.
+	key, err := pkcs8(m.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("azurekeyvault: private key: %w", err)
+	}
+
+	request := internal.ImportRequest{
+		Value: string(key) + string(m.FullChain),
+		Policy: &internal.Policy{
+			SecretProperties: &internal.SecretProperties{ContentType: "application/x-pem-file"},
+		},
+		Tags: map[string]string{"domain": res.Domain},
+	}
+
+	cert, err := d.client.ImportCertificate(ctx, buf.String(), request)
+	if err != nil {
+		return fmt.Errorf("azurekeyvault: import certificate %s: %w", buf.String(), err)
+	}
+
+	log.Infof("[%s] azurekeyvault: new version of the certificate: %s", res.Domain, cert.ID)
+
+	return nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^0-9a-zA-Z-]+`)
+
+// CertificateName returns a valid name of certificate object (alphanumeric characters and dashes) from a domain.
+func CertificateName(domain string) string {
+	name := strings.ReplaceAll(strings.ToLower(domain), "*", "wildcard")
+
+	return strings.Trim(invalidNameChars.ReplaceAllString(name, "-"), "-")
+}
+
+func pkcs8(raw []byte) ([]byte, error) {
+	key, err := certcrypto.ParsePEMPrivateKey(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func getCredential(config *Config) (azcore.TokenCredential, error) {
+	switch strings.ToLower(config.AuthMethod) {
+	case authMethodMSI:
+		var options *azidentity.ManagedIdentityCredentialOptions
+		if config.ClientID != "" {
+			options = &azidentity.ManagedIdentityCredentialOptions{ID: azidentity.ClientID(config.ClientID)}
+		}
+
+		return azidentity.NewManagedIdentityCredential(options)
+
+	case authMethodEnv:
+		return azidentity.NewEnvironmentCredential(nil)
+
+	case authMethodWLI:
+		return azidentity.NewWorkloadIdentityCredential(nil)
+
+	case authMethodCLI:
+		return azidentity.NewAzureCLICredential(nil)
+
+	case authMethodDefault:
+		return azidentity.NewDefaultAzureCredential(nil)
+
+	default:
+		return nil, fmt.Errorf("unsupported authentication method: %q", config.AuthMethod)
+	}
+}
@@ -0,0 +1,171 @@
+package azurekeyvault
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/deploy/azurekeyvault/internal"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvVaultURL, EnvCertificateName, EnvAuthMethod, EnvClientID)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvVaultURL: "https://example.vault.azure.net",
+			},
+		},
+		{
+			desc: "success with user-assigned managed identity",
+			envVars: map[string]string{
+				EnvVaultURL: "https://example.vault.azure.net",
+				EnvClientID: "00000000-0000-0000-0000-000000000000",
+			},
+		},
+		{
+			desc:     "missing vault URL",
+			envVars:  map[string]string{},
+			expected: "azurekeyvault: the vault URL is missing",
+		},
+		{
+			desc: "unsupported authentication method",
+			envVars: map[string]string{
+				EnvVaultURL:   "https://example.vault.azure.net",
+				EnvAuthMethod: "foo",
+			},
+			expected: `azurekeyvault: credentials: unsupported authentication method: "foo"`,
+		},
+		{
+			desc: "invalid template",
+			envVars: map[string]string{
+				EnvVaultURL:        "https://example.vault.azure.net",
+				EnvCertificateName: "{{ .Name",
+			},
+			expected: "azurekeyvault: certificate name: template: certificateName:1: unclosed action",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(_ context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "secret"}, nil
+}
+
+func setupDeployer(server *httptest.Server) (*Deployer, error) {
+	config := NewDefaultConfig()
+	config.VaultURL = server.URL
+	config.CertificateName = defaultCertificateName
+	config.Credential = fakeCredential{}
+	config.HTTPClient = server.Client()
+
+	return NewDeployerConfig(config)
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	res := deploytest.NewResource(t, "*.example.com")
+
+	var request internal.ImportRequest
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer,
+		servermock.CheckHeader().
+			WithAuthorization("Bearer secret"),
+	).
+		Route("POST /certificates/wildcard-example-com/import",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				raw, err := io.ReadAll(req.Body)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				err = json.Unmarshal(raw, &request)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				_, _ = rw.Write([]byte(`{"id":"https://example.vault.azure.net/certificates/wildcard-example-com/abc"}`))
+			}),
+			servermock.CheckQueryParameter().Strict().
+				With("api-version", internal.APIVersion),
+		).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), res)
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/x-pem-file", request.Policy.SecretProperties.ContentType)
+	assert.Equal(t, map[string]string{"domain": "*.example.com"}, request.Tags)
+
+	var types []string
+
+	rest := []byte(request.Value)
+	for {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		types = append(types, block.Type)
+	}
+
+	assert.Equal(t, []string{"PRIVATE KEY", "CERTIFICATE", "CERTIFICATE"}, types)
+}
+
+func TestDeployer_Deploy_error(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer).
+		Route("POST /certificates/example-com/import",
+			servermock.RawStringResponse(`{"error":{"code":"Forbidden","message":"The user does not have certificates import permission"}}`).
+				WithStatusCode(http.StatusForbidden)).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, "azurekeyvault: import certificate example-com: 403: Forbidden: The user does not have certificates import permission")
+}
+
+func TestCertificateName(t *testing.T) {
+	assert.Equal(t, "example-com", CertificateName("example.com"))
+	assert.Equal(t, "wildcard-example-com", CertificateName("*.example.com"))
+	assert.Equal(t, "xn--bcher-kva-example-com", CertificateName("xn--bcher-kva.Example.com"))
+}
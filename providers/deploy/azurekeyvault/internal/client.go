@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// APIVersion the version of the Key Vault API.
+const APIVersion = "7.4"
+
+// Client the Key Vault API client.
+type Client struct {
+	credential azcore.TokenCredential
+	scope      string
+
+	baseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(vaultURL string, credential azcore.TokenCredential) (*Client, error) {
+	baseURL, err := url.Parse(vaultURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse vault URL: %w", err)
+	}
+
+	return &Client{
+		credential: credential,
+		scope:      scope(baseURL.Hostname()),
+		baseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// ImportCertificate imports the certificate as a new version of the certificate object (created if needed).
+func (c *Client) ImportCertificate(ctx context.Context, name string, request ImportRequest) (*Certificate, error) {
+	endpoint := c.baseURL.JoinPath("certificates", name, "import")
+
+	query := endpoint.Query()
+	query.Set("api-version", APIVersion)
+	endpoint.RawQuery = query.Encode()
+
+	buf := new(bytes.Buffer)
+
+	err := json.NewEncoder(buf).Encode(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request JSON body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), buf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	token, err := c.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{c.scope}})
+	if err != nil {
+		return nil, fmt.Errorf("get token: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to communicate with Key Vault: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+
+		err = json.Unmarshal(raw, apiErr)
+		if err != nil || apiErr.Detail.Message == "" {
+			apiErr.Detail.Message = strings.TrimSpace(string(raw))
+		}
+
+		return nil, apiErr
+	}
+
+	var result Certificate
+
+	err = json.Unmarshal(raw, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response: %w: %s", err, string(raw))
+	}
+
+	return &result, nil
+}
+
+// scope returns the OAuth scope of the Key Vault from its hostname (e.g. myvault.vault.azure.net -> https://vault.azure.net/.default).
+func scope(hostname string) string {
+	_, suffix, found := strings.Cut(hostname, ".")
+	if !found || !strings.HasPrefix(suffix, "vault.") {
+		suffix = "vault.azure.net"
+	}
+
+	return "https://" + suffix + "/.default"
+}
@@ -0,0 +1,42 @@
+package internal
+
+import "fmt"
+
+// APIError the Key Vault API errors.
+type APIError struct {
+	StatusCode int         `json:"-"`
+	Detail     ErrorDetail `json:"error"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d: %s: %s", e.StatusCode, e.Detail.Code, e.Detail.Message)
+}
+
+// ErrorDetail the detail of an error.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ImportRequest the request of the import of a certificate.
+type ImportRequest struct {
+	// Value the certificate and the private key (PEM with a PKCS#8 key, or base64 encoded PKCS#12).
+	Value  string            `json:"value"`
+	Policy *Policy           `json:"policy,omitempty"`
+	Tags   map[string]string `json:"tags,omitempty"`
+}
+
+// Policy the management policy of a certificate.
+type Policy struct {
+	SecretProperties *SecretProperties `json:"secret_props,omitempty"`
+}
+
+// SecretProperties the properties of the secret backing a certificate.
+type SecretProperties struct {
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// Certificate a certificate (version).
+type Certificate struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,134 @@
+// Package cpanel implements a deployer installing the certificates in a cPanel account (UAPI).
+package cpanel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
+	"github.com/go-acme/lego/v4/providers/deploy/cpanel/internal"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "CPANEL_"
+
+	EnvUsername   = envNamespace + "USERNAME"
+	EnvToken      = envNamespace + "TOKEN"
+	EnvBaseURL    = envNamespace + "BASE_URL"
+	EnvSSLDomains = envNamespace + "SSL_DOMAINS"
+
+	EnvHTTPTimeout = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	Username string
+	Token    string
+	BaseURL  string
+
+	// Domains the domains (virtual hosts) on which the certificate is installed.
+	// Default: the main domain of the certificate (without the wildcard label).
+	Domains []string
+
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		},
+	}
+}
+
+// Deployer installs the certificates in a cPanel account.
+type Deployer struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDeployer returns a Deployer instance configured for cPanel.
+func NewDeployer() (*Deployer, error) {
+	values, err := env.Get(EnvUsername, EnvToken, EnvBaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("cpanel: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Username = values[EnvUsername]
+	config.Token = values[EnvToken]
+	config.BaseURL = values[EnvBaseURL]
+
+	if domains := env.GetOrFile(EnvSSLDomains); domains != "" {
+		config.Domains = strings.Split(domains, ",")
+	}
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for cPanel.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("cpanel: the configuration of the deployer is nil")
+	}
+
+	redact.Register(config.Token)
+
+	if config.Username == "" || config.Token == "" {
+		return nil, errors.New("cpanel: some credentials information are missing")
+	}
+
+	if config.BaseURL == "" {
+		return nil, errors.New("cpanel: server information are missing")
+	}
+
+	client, err := internal.NewClient(config.BaseURL, config.Username, config.Token)
+	if err != nil {
+		return nil, fmt.Errorf("cpanel: %w", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &Deployer{config: config, client: client}, nil
+}
+
+// Deploy installs the certificate on the domains (SSL::install_ssl).
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("cpanel: %w", err)
+	}
+
+	domains := d.config.Domains
+	if len(domains) == 0 {
+		domains = []string{strings.TrimPrefix(res.Domain, "*.")}
+	}
+
+	for _, domain := range domains {
+		domain = strings.TrimSpace(domain)
+
+		result, err := d.client.InstallSSL(ctx, domain, m.Certificate, m.PrivateKey, m.Chain)
+		if err != nil {
+			return fmt.Errorf("cpanel: install certificate on %s: %w", domain, err)
+		}
+
+		if len(result.WarningDomains) > 0 {
+			log.Warnf("[%s] cpanel: the certificate doesn't cover some domains of the virtual host %s: %s",
+				res.Domain, domain, strings.Join(result.WarningDomains, ", "))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package cpanel
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvUsername, EnvToken, EnvBaseURL, EnvSSLDomains)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvUsername:   "user",
+				EnvToken:      "secret",
+				EnvBaseURL:    "https://example.com:2083",
+				EnvSSLDomains: "example.com,example.org",
+			},
+		},
+		{
+			desc: "missing token",
+			envVars: map[string]string{
+				EnvUsername: "user",
+				EnvBaseURL:  "https://example.com:2083",
+			},
+			expected: "cpanel: some credentials information are missing: CPANEL_TOKEN",
+		},
+		{
+			desc:     "missing all",
+			envVars:  map[string]string{},
+			expected: "cpanel: some credentials information are missing: CPANEL_USERNAME,CPANEL_TOKEN,CPANEL_BASE_URL",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupDeployer(domains ...string) func(server *httptest.Server) (*Deployer, error) {
+	return func(server *httptest.Server) (*Deployer, error) {
+		config := NewDefaultConfig()
+		config.Username = "user"
+		config.Token = "secret"
+		config.BaseURL = server.URL
+		config.Domains = domains
+		config.HTTPClient = server.Client()
+
+		return NewDeployerConfig(config)
+	}
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	res := deploytest.NewResource(t, "*.example.com")
+
+	m, err := material.FromResource(res)
+	require.NoError(t, err)
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(),
+		servermock.CheckHeader().
+			WithAuthorization("cpanel user:secret"),
+	).
+		Route("POST /execute/SSL/install_ssl",
+			servermock.RawStringResponse(`{"status":1,"data":{"domain":"example.com","action":"install","working_domains":["example.com"],"warning_domains":["www.example.com"]}}`),
+			servermock.CheckForm().UsePostForm().Strict().
+				With("domain", "example.com").
+				With("cert", string(m.Certificate)).
+				With("key", string(m.PrivateKey)).
+				With("cabundle", string(m.Chain)),
+		).
+		Build(t)
+
+	err = deployer.Deploy(t.Context(), res)
+	require.NoError(t, err)
+}
+
+func TestDeployer_Deploy_domains(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer("example.com", "example.org")).
+		Route("POST /execute/SSL/install_ssl",
+			servermock.RawStringResponse(`{"status":1,"data":{}}`),
+			servermock.CheckForm().UsePostForm().
+				WithRegexp("domain", `^example\.(com|org)$`),
+		).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+}
+
+func TestDeployer_Deploy_error(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer()).
+		Route("POST /execute/SSL/install_ssl",
+			servermock.RawStringResponse(`{"status":0,"errors":["The certificate does not match the key."]}`)).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, "cpanel: install certificate on example.com: error: The certificate does not match the key.")
+}
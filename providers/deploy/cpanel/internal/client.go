@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const statusFailed = 0
+
+// Client the cPanel UAPI client.
+type Client struct {
+	username string
+	token    string
+
+	baseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(baseURL, username, token string) (*Client, error) {
+	apiEndpoint, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse URL: %w", err)
+	}
+
+	return &Client{
+		username:   username,
+		token:      token,
+		baseURL:    apiEndpoint.JoinPath("execute"),
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// InstallSSL installs the certificate on a domain.
+// https://api.docs.cpanel.net/openapi/cpanel/operation/install_ssl/
+func (c *Client) InstallSSL(ctx context.Context, domain string, cert, key, caBundle []byte) (*InstallResult, error) {
+	form := url.Values{}
+	form.Set("domain", domain)
+	form.Set("cert", string(cert))
+	form.Set("key", string(key))
+
+	if len(caBundle) > 0 {
+		form.Set("cabundle", string(caBundle))
+	}
+
+	var result APIResponse[InstallResult]
+
+	err := c.post(ctx, c.baseURL.JoinPath("SSL", "install_ssl"), form, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Status == statusFailed {
+		return nil, &APIError{Errors: result.Errors, Messages: result.Messages}
+	}
+
+	return &result.Data, nil
+}
+
+func (c *Client) post(ctx context.Context, endpoint *url.URL, form url.Values, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("cpanel %s:%s", c.username, c.token))
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to communicate with cPanel: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal response: %w: %s", err, string(raw))
+	}
+
+	return nil
+}
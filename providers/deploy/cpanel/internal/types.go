@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIResponse the UAPI response.
+type APIResponse[T any] struct {
+	Data T `json:"data,omitempty"`
+
+	Status   int      `json:"status"`
+	Messages []string `json:"messages,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// InstallResult the result of SSL::install_ssl.
+type InstallResult struct {
+	Domain         string   `json:"domain"`
+	Action         string   `json:"action"`
+	WorkingDomains []string `json:"working_domains"`
+	WarningDomains []string `json:"warning_domains"`
+}
+
+// APIError the UAPI errors.
+type APIError struct {
+	Errors   []string
+	Messages []string
+}
+
+func (e *APIError) Error() string {
+	msg := strings.Join(e.Errors, ", ")
+
+	if len(e.Messages) > 0 {
+		msg += ": " + strings.Join(e.Messages, ", ")
+	}
+
+	return fmt.Sprintf("error: %s", msg)
+}
@@ -0,0 +1,65 @@
+// Package deploy implements the deployers: the installation of the certificates in external services after their issuance or renewal.
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/providers/deploy/acm"
+	"github.com/go-acme/lego/v4/providers/deploy/azurekeyvault"
+	"github.com/go-acme/lego/v4/providers/deploy/cpanel"
+	"github.com/go-acme/lego/v4/providers/deploy/dockerswarm"
+	"github.com/go-acme/lego/v4/providers/deploy/fastly"
+	"github.com/go-acme/lego/v4/providers/deploy/gcloud"
+	"github.com/go-acme/lego/v4/providers/deploy/iis"
+	"github.com/go-acme/lego/v4/providers/deploy/keychain"
+	"github.com/go-acme/lego/v4/providers/deploy/kubernetes"
+	"github.com/go-acme/lego/v4/providers/deploy/plesk"
+	"github.com/go-acme/lego/v4/providers/deploy/pubsub"
+	"github.com/go-acme/lego/v4/providers/deploy/synology"
+	"github.com/go-acme/lego/v4/providers/deploy/vault"
+	"github.com/go-acme/lego/v4/providers/deploy/wincertstore"
+)
+
+// Deployer installs a certificate in an external service.
+type Deployer interface {
+	Deploy(ctx context.Context, res *certificate.Resource) error
+}
+
+// NewDeployerByName creates a deployer by its name.
+// The deployers are configured with environment variables.
+func NewDeployerByName(name string) (Deployer, error) {
+	switch name {
+	case "acm":
+		return acm.NewDeployer()
+	case "azurekeyvault":
+		return azurekeyvault.NewDeployer()
+	case "cpanel":
+		return cpanel.NewDeployer()
+	case "dockerswarm":
+		return dockerswarm.NewDeployer()
+	case "fastly":
+		return fastly.NewDeployer()
+	case "gcloud":
+		return gcloud.NewDeployer()
+	case "iis":
+		return iis.NewDeployer()
+	case "keychain":
+		return keychain.NewDeployer()
+	case "kubernetes":
+		return kubernetes.NewDeployer()
+	case "plesk":
+		return plesk.NewDeployer()
+	case "pubsub":
+		return pubsub.NewDeployer()
+	case "synology":
+		return synology.NewDeployer()
+	case "vault":
+		return vault.NewDeployer()
+	case "wincertstore":
+		return wincertstore.NewDeployer()
+	default:
+		return nil, fmt.Errorf("unrecognized deployer: %s", name)
+	}
+}
@@ -0,0 +1,314 @@
+// Package dockerswarm implements a deployer creating Docker Swarm secrets and updating the services using them.
+package dockerswarm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/deploy/dockerswarm/internal"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "DOCKER_SWARM_"
+
+	EnvHost       = envNamespace + "HOST"
+	EnvSecretName = envNamespace + "SECRET_NAME"
+	EnvServices   = envNamespace + "SERVICES"
+	EnvCertTarget = envNamespace + "CERT_TARGET"
+	EnvKeyTarget  = envNamespace + "KEY_TARGET"
+	EnvPrune      = envNamespace + "PRUNE"
+
+	EnvHTTPTimeout = envNamespace + "HTTP_TIMEOUT"
+)
+
+// The labels of the secrets created by the deployer.
+const (
+	labelSecret = "lego.secret"
+	labelDomain = "lego.domain"
+)
+
+const (
+	defaultHost       = "unix:///var/run/docker.sock"
+	defaultSecretName = "{{ .Name }}"
+	defaultCertTarget = "tls.crt"
+	defaultKeyTarget  = "tls.key"
+)
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	Host string
+
+	// SecretName the template of the base name of the secrets.
+	// The secrets are named `<base name>-crt-<version>` and `<base name>-key-<version>`.
+	SecretName string
+
+	// Services the services to update.
+	Services []string
+
+	// CertTarget and KeyTarget the files of the secrets inside the containers (when the service doesn't use them yet).
+	CertTarget string
+	KeyTarget  string
+
+	// Prune removes the previous versions of the secrets.
+	Prune bool
+
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Host:       env.GetOrDefaultString(EnvHost, env.GetOrDefaultString("DOCKER_HOST", defaultHost)),
+		SecretName: env.GetOrDefaultString(EnvSecretName, defaultSecretName),
+		CertTarget: env.GetOrDefaultString(EnvCertTarget, defaultCertTarget),
+		KeyTarget:  env.GetOrDefaultString(EnvKeyTarget, defaultKeyTarget),
+		Prune:      env.GetOrDefaultBool(EnvPrune, true),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		},
+	}
+}
+
+// TemplateData the data of the template of the name of the secrets.
+type TemplateData struct {
+	// Domain the main domain of the certificate.
+	Domain string
+	// Name the main domain as a valid secret name (e.g. "*.example.com" -> "wildcard.example.com").
+	Name string
+}
+
+// Deployer creates Docker Swarm secrets and updates the services using them.
+// The secrets of Swarm are immutable: each deployment creates a new version of the secrets.
+type Deployer struct {
+	config *Config
+	client *internal.Client
+
+	secretName *template.Template
+}
+
+// NewDeployer returns a Deployer instance configured for the Docker Engine.
+func NewDeployer() (*Deployer, error) {
+	config := NewDefaultConfig()
+
+	if services := env.GetOrFile(EnvServices); services != "" {
+		config.Services = strings.Split(services, ",")
+	}
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for the Docker Engine.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("dockerswarm: the configuration of the deployer is nil")
+	}
+
+	secretName, err := template.New("secretName").Parse(config.SecretName)
+	if err != nil {
+		return nil, fmt.Errorf("dockerswarm: secret name: %w", err)
+	}
+
+	client, err := internal.NewClient(config.Host)
+	if err != nil {
+		return nil, fmt.Errorf("dockerswarm: %w", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient.Timeout = config.HTTPClient.Timeout
+
+		if config.HTTPClient.Transport != nil {
+			client.HTTPClient.Transport = config.HTTPClient.Transport
+		}
+	}
+
+	return &Deployer{
+		config:     config,
+		client:     client,
+		secretName: secretName,
+	}, nil
+}
+
+// Deploy creates the new versions of the secrets, and updates the services to use them.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("dockerswarm: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = d.secretName.Execute(buf, TemplateData{Domain: res.Domain, Name: SecretName(res.Domain)})
+	if err != nil {
+		return fmt.Errorf("dockerswarm: secret name: %w", err)
+	}
+
+	base := buf.String()
+	version := m.Leaf.NotBefore.UTC().Format("20060102150405")
+
+	labels := map[string]string{labelSecret: base, labelDomain: res.Domain}
+
+	certRef, err := d.createSecret(ctx, internal.SecretSpec{Name: base + "-crt-" + version, Labels: labels, Data: m.FullChain})
+	if err != nil {
+		return fmt.Errorf("dockerswarm: create certificate secret: %w", err)
+	}
+
+	keyRef, err := d.createSecret(ctx, internal.SecretSpec{Name: base + "-key-" + version, Labels: labels, Data: m.PrivateKey})
+	if err != nil {
+		return fmt.Errorf("dockerswarm: create key secret: %w", err)
+	}
+
+	certRef.File = &internal.SecretFile{Name: d.config.CertTarget, UID: "0", GID: "0", Mode: 0o444}
+	keyRef.File = &internal.SecretFile{Name: d.config.KeyTarget, UID: "0", GID: "0", Mode: 0o400}
+
+	for _, name := range d.config.Services {
+		err = d.updateService(ctx, strings.TrimSpace(name), base, certRef, keyRef)
+		if err != nil {
+			return fmt.Errorf("dockerswarm: update service %s: %w", name, err)
+		}
+	}
+
+	if d.config.Prune {
+		d.prune(ctx, base, certRef.SecretID, keyRef.SecretID)
+	}
+
+	return nil
+}
+
+// createSecret creates the secret, or reuses the existing secret with the same name (same version).
+func (d *Deployer) createSecret(ctx context.Context, spec internal.SecretSpec) (internal.SecretReference, error) {
+	id, err := d.client.CreateSecret(ctx, spec)
+	if err == nil {
+		return internal.SecretReference{SecretID: id, SecretName: spec.Name}, nil
+	}
+
+	apiErr := &internal.APIError{}
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		return internal.SecretReference{}, err
+	}
+
+	secrets, errL := d.client.ListSecrets(ctx, labelSecret+"="+spec.Labels[labelSecret])
+	if errL != nil {
+		return internal.SecretReference{}, errL
+	}
+
+	for _, secret := range secrets {
+		if secret.Spec.Name == spec.Name {
+			return internal.SecretReference{SecretID: secret.ID, SecretName: spec.Name}, nil
+		}
+	}
+
+	return internal.SecretReference{}, err
+}
+
+// updateService replaces the previous versions of the secrets by the new versions, keeping their files.
+// The secrets are added if the service doesn't use them yet.
+func (d *Deployer) updateService(ctx context.Context, name, base string, certRef, keyRef internal.SecretReference) error {
+	service, err := d.client.InspectService(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	containerSpec, ok := nested(service.Spec, "TaskTemplate", "ContainerSpec")
+	if !ok {
+		return errors.New("the service has no container specification")
+	}
+
+	var refs []internal.SecretReference
+
+	if raw, found := containerSpec["Secrets"]; found {
+		err = remarshal(raw, &refs)
+		if err != nil {
+			return fmt.Errorf("secrets of the service: %w", err)
+		}
+	}
+
+	refs = replaceSecret(refs, base+"-crt-", certRef)
+	refs = replaceSecret(refs, base+"-key-", keyRef)
+
+	containerSpec["Secrets"] = refs
+
+	return d.client.UpdateService(ctx, service)
+}
+
+// prune removes the previous versions of the secrets (best effort: the secrets still used by other services are kept).
+func (d *Deployer) prune(ctx context.Context, base string, keep ...string) {
+	secrets, err := d.client.ListSecrets(ctx, labelSecret+"="+base)
+	if err != nil {
+		log.Warnf("dockerswarm: list secrets: %v", err)
+		return
+	}
+
+	for _, secret := range secrets {
+		if slices.Contains(keep, secret.ID) {
+			continue
+		}
+
+		err = d.client.RemoveSecret(ctx, secret.ID)
+		if err != nil {
+			log.Warnf("dockerswarm: remove secret %s: %v", secret.Spec.Name, err)
+		}
+	}
+}
+
+func replaceSecret(refs []internal.SecretReference, prefix string, ref internal.SecretReference) []internal.SecretReference {
+	var found bool
+
+	for i, r := range refs {
+		if !strings.HasPrefix(r.SecretName, prefix) {
+			continue
+		}
+
+		found = true
+
+		refs[i].SecretID = ref.SecretID
+		refs[i].SecretName = ref.SecretName
+	}
+
+	if !found {
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// SecretName returns a valid secret name from a domain.
+func SecretName(domain string) string {
+	return strings.ReplaceAll(strings.ToLower(domain), "*", "wildcard")
+}
+
+func nested(spec map[string]any, keys ...string) (map[string]any, bool) {
+	current := spec
+
+	for _, key := range keys {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		current = next
+	}
+
+	return current, true
+}
+
+func remarshal(in, out any) error {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, out)
+}
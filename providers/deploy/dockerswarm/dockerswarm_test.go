@@ -0,0 +1,177 @@
+package dockerswarm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/deploy/dockerswarm/internal"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvHost, EnvSecretName, EnvServices, EnvCertTarget, EnvKeyTarget, EnvPrune, "DOCKER_HOST")
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvServices: "web,api",
+			},
+		},
+		{
+			desc: "TCP host",
+			envVars: map[string]string{
+				"DOCKER_HOST": "tcp://127.0.0.1:2375",
+			},
+		},
+		{
+			desc: "unsupported host",
+			envVars: map[string]string{
+				EnvHost: "ssh://user@example.com",
+			},
+			expected: `dockerswarm: unsupported scheme: "ssh"`,
+		},
+		{
+			desc: "invalid template",
+			envVars: map[string]string{
+				EnvSecretName: "{{ .Name",
+			},
+			expected: "dockerswarm: secret name: template: secretName:1: unclosed action",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupDeployer(server *httptest.Server) (*Deployer, error) {
+	config := NewDefaultConfig()
+	config.Host = server.URL
+	config.SecretName = defaultSecretName
+	config.CertTarget = defaultCertTarget
+	config.KeyTarget = defaultKeyTarget
+	config.Prune = true
+	config.Services = []string{"web"}
+	config.HTTPClient = server.Client()
+
+	return NewDeployerConfig(config)
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	var spec map[string]any
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer).
+		Route("POST /v1.41/secrets/create", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			var secret internal.SecretSpec
+
+			err := json.NewDecoder(req.Body).Decode(&secret)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			switch secret.Name {
+			case "example.com-crt-20291003000000":
+				_, _ = rw.Write([]byte(`{"ID":"crt2"}`))
+			case "example.com-key-20291003000000":
+				_, _ = rw.Write([]byte(`{"ID":"key2"}`))
+			default:
+				http.Error(rw, "unexpected secret: "+secret.Name, http.StatusBadRequest)
+			}
+		})).
+		Route("GET /v1.41/services/web", servermock.RawStringResponse(`{
+  "ID": "svc1",
+  "Version": {"Index": 42},
+  "Spec": {
+    "Name": "web",
+    "TaskTemplate": {
+      "ContainerSpec": {
+        "Image": "nginx",
+        "Secrets": [
+          {"File": {"Name": "site.crt", "UID": "0", "GID": "0", "Mode": 292}, "SecretID": "crt1", "SecretName": "example.com-crt-20290101000000"},
+          {"File": {"Name": "other", "UID": "0", "GID": "0", "Mode": 292}, "SecretID": "other", "SecretName": "other"}
+        ]
+      }
+    }
+  }
+}`)).
+		Route("POST /v1.41/services/svc1/update", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			raw, err := io.ReadAll(req.Body)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			err = json.Unmarshal(raw, &spec)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_, _ = rw.Write([]byte(`{}`))
+		}), servermock.CheckQueryParameter().Strict().With("version", "42")).
+		Route("GET /v1.41/secrets", servermock.RawStringResponse(`[
+  {"ID": "crt1", "Spec": {"Name": "example.com-crt-20290101000000"}},
+  {"ID": "crt2", "Spec": {"Name": "example.com-crt-20291003000000"}},
+  {"ID": "key2", "Spec": {"Name": "example.com-key-20291003000000"}}
+]`), servermock.CheckQueryParameter().Strict().With("filters", `{"label":["lego.secret=example.com"]}`)).
+		Route("DELETE /v1.41/secrets/crt1", nil).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+
+	expected := []any{
+		map[string]any{
+			"File":     map[string]any{"Name": "site.crt", "UID": "0", "GID": "0", "Mode": float64(0o444)},
+			"SecretID": "crt2", "SecretName": "example.com-crt-20291003000000",
+		},
+		map[string]any{
+			"File":     map[string]any{"Name": "other", "UID": "0", "GID": "0", "Mode": float64(0o444)},
+			"SecretID": "other", "SecretName": "other",
+		},
+		map[string]any{
+			"File":     map[string]any{"Name": "tls.key", "UID": "0", "GID": "0", "Mode": float64(0o400)},
+			"SecretID": "key2", "SecretName": "example.com-key-20291003000000",
+		},
+	}
+
+	containerSpec, ok := nested(spec, "TaskTemplate", "ContainerSpec")
+	require.True(t, ok)
+
+	assert.Equal(t, "nginx", containerSpec["Image"])
+	assert.Equal(t, expected, containerSpec["Secrets"])
+}
+
+func TestSecretName(t *testing.T) {
+	assert.Equal(t, "example.com", SecretName("example.com"))
+	assert.Equal(t, "wildcard.example.com", SecretName("*.Example.com"))
+}
@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIVersion the version of the Docker Engine API.
+const APIVersion = "v1.41"
+
+// Client the Docker Engine API client.
+type Client struct {
+	baseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client for the Docker host (unix:///var/run/docker.sock, tcp://host:2375).
+func NewClient(host string) (*Client, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("parse host: %w", err)
+	}
+
+	client := &Client{HTTPClient: &http.Client{Timeout: 30 * time.Second}}
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+
+		client.baseURL = &url.URL{Scheme: "http", Host: "docker"}
+		client.HTTPClient.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socket)
+			},
+		}
+
+	case "tcp", "http":
+		client.baseURL = &url.URL{Scheme: "http", Host: u.Host}
+
+	case "https":
+		client.baseURL = &url.URL{Scheme: "https", Host: u.Host}
+
+	default:
+		return nil, fmt.Errorf("unsupported scheme: %q", u.Scheme)
+	}
+
+	client.baseURL = client.baseURL.JoinPath(APIVersion)
+
+	return client, nil
+}
+
+// CreateSecret creates a secret, and returns its ID.
+func (c *Client) CreateSecret(ctx context.Context, spec SecretSpec) (string, error) {
+	var result idResponse
+
+	err := c.do(ctx, http.MethodPost, c.baseURL.JoinPath("secrets", "create"), spec, &result)
+	if err != nil {
+		return "", err
+	}
+
+	return result.ID, nil
+}
+
+// ListSecrets lists the secrets with the label (`key=value`).
+func (c *Client) ListSecrets(ctx context.Context, label string) ([]Secret, error) {
+	endpoint := c.baseURL.JoinPath("secrets")
+
+	filters, err := json.Marshal(map[string][]string{"label": {label}})
+	if err != nil {
+		return nil, err
+	}
+
+	query := endpoint.Query()
+	query.Set("filters", string(filters))
+	endpoint.RawQuery = query.Encode()
+
+	var secrets []Secret
+
+	err = c.do(ctx, http.MethodGet, endpoint, nil, &secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// RemoveSecret removes a secret.
+func (c *Client) RemoveSecret(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, c.baseURL.JoinPath("secrets", id), nil, nil)
+}
+
+// InspectService returns a service by its ID or name.
+func (c *Client) InspectService(ctx context.Context, id string) (*Service, error) {
+	var service Service
+
+	err := c.do(ctx, http.MethodGet, c.baseURL.JoinPath("services", id), nil, &service)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service, nil
+}
+
+// UpdateService updates the specification of a service.
+func (c *Client) UpdateService(ctx context.Context, service *Service) error {
+	endpoint := c.baseURL.JoinPath("services", service.ID, "update")
+
+	query := endpoint.Query()
+	query.Set("version", strconv.Itoa(service.Version.Index))
+	endpoint.RawQuery = query.Encode()
+
+	return c.do(ctx, http.MethodPost, endpoint, service.Spec, nil)
+}
+
+func (c *Client) do(ctx context.Context, method string, endpoint *url.URL, payload, result any) error {
+	var body io.Reader
+
+	if payload != nil {
+		buf := new(bytes.Buffer)
+
+		err := json.NewEncoder(buf).Encode(payload)
+		if err != nil {
+			return fmt.Errorf("failed to create request JSON body: %w", err)
+		}
+
+		body = buf
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to communicate with the Docker Engine: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+
+		err = json.Unmarshal(raw, apiErr)
+		if err != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal response: %w: %s", err, string(raw))
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package internal
+
+import "fmt"
+
+// SecretSpec the specification of a Swarm secret.
+// The data is base64 encoded by the JSON encoding.
+type SecretSpec struct {
+	Name   string            `json:"Name"`
+	Labels map[string]string `json:"Labels,omitempty"`
+	Data   []byte            `json:"Data"`
+}
+
+// Secret a Swarm secret.
+type Secret struct {
+	ID   string     `json:"ID"`
+	Spec SecretSpec `json:"Spec"`
+}
+
+// Service a Swarm service.
+// The specification is kept as-is (map) to be sent back without losing the unknown fields.
+type Service struct {
+	ID      string         `json:"ID"`
+	Version Version        `json:"Version"`
+	Spec    map[string]any `json:"Spec"`
+}
+
+// Version the version of an object, required by the updates.
+type Version struct {
+	Index int `json:"Index"`
+}
+
+// SecretReference a secret of a service.
+type SecretReference struct {
+	File       *SecretFile `json:"File,omitempty"`
+	SecretID   string      `json:"SecretID"`
+	SecretName string      `json:"SecretName"`
+}
+
+// SecretFile the file of a secret inside the containers.
+type SecretFile struct {
+	Name string `json:"Name"`
+	UID  string `json:"UID"`
+	GID  string `json:"GID"`
+	Mode uint32 `json:"Mode"`
+}
+
+type idResponse struct {
+	ID string `json:"ID"`
+}
+
+// APIError the Docker Engine API errors.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
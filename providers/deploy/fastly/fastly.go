@@ -0,0 +1,145 @@
+// Package fastly implements a deployer uploading the certificates to the Fastly TLS platform.
+package fastly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
+	"github.com/go-acme/lego/v4/providers/deploy/fastly/internal"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "FASTLY_"
+
+	EnvAPIToken        = envNamespace + "API_TOKEN"
+	EnvConfigurationID = envNamespace + "TLS_CONFIGURATION_ID"
+
+	EnvHTTPTimeout = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	APIToken string
+
+	// ConfigurationID the TLS configuration of the activations (optional: the default TLS configuration).
+	ConfigurationID string
+
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		ConfigurationID: env.GetOrFile(EnvConfigurationID),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		},
+	}
+}
+
+// Deployer uploads the certificates to the Fastly TLS platform (TLS subscriptions with your own certificates).
+type Deployer struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDeployer returns a Deployer instance configured for Fastly.
+func NewDeployer() (*Deployer, error) {
+	values, err := env.Get(EnvAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("fastly: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIToken = values[EnvAPIToken]
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for Fastly.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("fastly: the configuration of the deployer is nil")
+	}
+
+	redact.Register(config.APIToken)
+
+	if config.APIToken == "" {
+		return nil, errors.New("fastly: missing credentials")
+	}
+
+	client := internal.NewClient(config.APIToken)
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &Deployer{config: config, client: client}, nil
+}
+
+// Deploy uploads the private key and the certificate, and activates TLS for the domains of the certificate.
+// The certificate of the main domain is replaced if it exists, the existing activations are kept.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("fastly: %w", err)
+	}
+
+	name := fmt.Sprintf("%s (lego %s)", res.Domain, m.Leaf.NotBefore.UTC().Format(time.DateOnly))
+
+	// The private key is reused by the renewals with --reuse-key.
+	_, err = d.client.CreatePrivateKey(ctx, name, m.PrivateKey)
+	if err != nil && !isConflict(err) {
+		return fmt.Errorf("fastly: upload private key: %w", err)
+	}
+
+	certificates, err := d.client.FindCertificates(ctx, res.Domain)
+	if err != nil {
+		return fmt.Errorf("fastly: find certificate: %w", err)
+	}
+
+	var certificateID string
+
+	if len(certificates) > 0 {
+		certificateID = certificates[0].ID
+
+		err = d.client.UpdateCertificate(ctx, certificateID, name, m.FullChain)
+		if err != nil {
+			return fmt.Errorf("fastly: update certificate %s: %w", certificateID, err)
+		}
+	} else {
+		cert, errC := d.client.CreateCertificate(ctx, name, m.FullChain)
+		if errC != nil {
+			return fmt.Errorf("fastly: upload certificate: %w", errC)
+		}
+
+		certificateID = cert.ID
+
+		log.Infof("[%s] fastly: the certificate %s has been uploaded.", res.Domain, certificateID)
+	}
+
+	for _, domain := range m.Leaf.DNSNames {
+		err = d.client.CreateActivation(ctx, certificateID, d.config.ConfigurationID, domain)
+		if err != nil && !isConflict(err) {
+			return fmt.Errorf("fastly: activate %s: %w", domain, err)
+		}
+	}
+
+	return nil
+}
+
+// isConflict checks if the resource already exists (private key already uploaded, domain already activated).
+func isConflict(err error) bool {
+	apiErr := &internal.APIError{}
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
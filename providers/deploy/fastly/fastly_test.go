@@ -0,0 +1,133 @@
+package fastly
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvAPIToken, EnvConfigurationID)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvAPIToken: "secret",
+			},
+		},
+		{
+			desc:     "missing credentials",
+			envVars:  map[string]string{},
+			expected: "fastly: some credentials information are missing: FASTLY_API_TOKEN",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupDeployer(server *httptest.Server) (*Deployer, error) {
+	config := NewDefaultConfig()
+	config.APIToken = "secret"
+	config.ConfigurationID = "conf1"
+	config.HTTPClient = server.Client()
+
+	d, err := NewDeployerConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	d.client.BaseURL, _ = url.Parse(server.URL)
+
+	return d, nil
+}
+
+func TestDeployer_Deploy_new(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer,
+		servermock.CheckHeader().
+			With("Fastly-Key", "secret"),
+	).
+		Route("POST /tls/private_keys",
+			servermock.RawStringResponse(`{"data":{"id":"key1","type":"tls_private_key"}}`).
+				WithStatusCode(http.StatusCreated),
+			servermock.CheckHeader().
+				WithContentType("application/vnd.api+json"),
+		).
+		Route("GET /tls/certificates",
+			servermock.RawStringResponse(`{"data":[]}`),
+			servermock.CheckQueryParameter().Strict().
+				With("filter[tls_domains.id]", "example.com"),
+		).
+		Route("POST /tls/certificates",
+			servermock.RawStringResponse(`{"data":{"id":"cert1","type":"tls_certificate"}}`).
+				WithStatusCode(http.StatusCreated)).
+		Route("POST /tls/activations",
+			servermock.Noop().
+				WithStatusCode(http.StatusCreated),
+			servermock.CheckRequestJSONBody(`{"data":{"type":"tls_activation","relationships":{
+				"tls_certificate":{"data":{"id":"cert1","type":"tls_certificate"}},
+				"tls_configuration":{"data":{"id":"conf1","type":"tls_configuration"}},
+				"tls_domain":{"data":{"id":"example.com","type":"tls_domain"}}
+			}}}`),
+		).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+}
+
+func TestDeployer_Deploy_update(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer).
+		Route("POST /tls/private_keys",
+			servermock.RawStringResponse(`{"errors":[{"title":"Conflict","detail":"Key already exists"}]}`).
+				WithStatusCode(http.StatusConflict)).
+		Route("GET /tls/certificates",
+			servermock.RawStringResponse(`{"data":[{"id":"cert1","type":"tls_certificate","relationships":{"tls_domains":{"data":[{"id":"example.com","type":"tls_domain"}]}}}]}`)).
+		Route("PATCH /tls/certificates/cert1", servermock.Noop()).
+		Route("POST /tls/activations",
+			servermock.RawStringResponse(`{"errors":[{"title":"Conflict","detail":"Domain already activated"}]}`).
+				WithStatusCode(http.StatusConflict)).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+}
+
+func TestDeployer_Deploy_error(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer).
+		Route("POST /tls/private_keys",
+			servermock.RawStringResponse(`{"errors":[{"title":"Unauthorized","detail":"Insufficient permissions"}]}`).
+				WithStatusCode(http.StatusForbidden)).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, "fastly: upload private key: 403: Unauthorized: Insufficient permissions")
+}
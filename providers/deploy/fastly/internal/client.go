@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL the default API endpoint.
+const DefaultBaseURL = "https://api.fastly.com"
+
+const contentType = "application/vnd.api+json"
+
+// Resource types.
+const (
+	TypePrivateKey    = "tls_private_key"
+	TypeCertificate   = "tls_certificate"
+	TypeActivation    = "tls_activation"
+	TypeConfiguration = "tls_configuration"
+	TypeDomain        = "tls_domain"
+)
+
+// Client the Fastly TLS API client.
+type Client struct {
+	token string
+
+	BaseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(token string) *Client {
+	baseURL, _ := url.Parse(DefaultBaseURL)
+
+	return &Client{
+		token:      token,
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// CreatePrivateKey uploads a private key.
+// https://www.fastly.com/documentation/reference/api/tls/platform-tls/private-keys/
+func (c *Client) CreatePrivateKey(ctx context.Context, name string, key []byte) (*Resource, error) {
+	payload := Document{Data: Resource{
+		Type:       TypePrivateKey,
+		Attributes: map[string]any{"name": name, "key": string(key)},
+	}}
+
+	var result Document
+
+	err := c.do(ctx, http.MethodPost, c.BaseURL.JoinPath("tls", "private_keys"), payload, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// FindCertificates returns the certificates of a TLS domain.
+// https://www.fastly.com/documentation/reference/api/tls/platform-tls/certificates/
+func (c *Client) FindCertificates(ctx context.Context, domain string) ([]Resource, error) {
+	endpoint := c.BaseURL.JoinPath("tls", "certificates")
+
+	query := endpoint.Query()
+	query.Set("filter[tls_domains.id]", domain)
+	endpoint.RawQuery = query.Encode()
+
+	var result Collection
+
+	err := c.do(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// CreateCertificate uploads a certificate (the private key must be uploaded before).
+func (c *Client) CreateCertificate(ctx context.Context, name string, cert []byte) (*Resource, error) {
+	payload := Document{Data: Resource{
+		Type:       TypeCertificate,
+		Attributes: map[string]any{"name": name, "cert_blob": string(cert)},
+	}}
+
+	var result Document
+
+	err := c.do(ctx, http.MethodPost, c.BaseURL.JoinPath("tls", "certificates"), payload, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateCertificate replaces a certificate, the activations are kept.
+func (c *Client) UpdateCertificate(ctx context.Context, id, name string, cert []byte) error {
+	payload := Document{Data: Resource{
+		ID:         id,
+		Type:       TypeCertificate,
+		Attributes: map[string]any{"name": name, "cert_blob": string(cert)},
+	}}
+
+	return c.do(ctx, http.MethodPatch, c.BaseURL.JoinPath("tls", "certificates", id), payload, nil)
+}
+
+// CreateActivation enables TLS for a domain with a certificate.
+// The default TLS configuration is used if the configuration ID is empty.
+// https://www.fastly.com/documentation/reference/api/tls/platform-tls/activations/
+func (c *Client) CreateActivation(ctx context.Context, certificateID, configurationID, domain string) error {
+	relationships := map[string]Relationship{
+		"tls_certificate": {Data: Identifier{ID: certificateID, Type: TypeCertificate}},
+		"tls_domain":      {Data: Identifier{ID: domain, Type: TypeDomain}},
+	}
+
+	if configurationID != "" {
+		relationships["tls_configuration"] = Relationship{Data: Identifier{ID: configurationID, Type: TypeConfiguration}}
+	}
+
+	payload := Document{Data: Resource{Type: TypeActivation, Relationships: relationships}}
+
+	return c.do(ctx, http.MethodPost, c.BaseURL.JoinPath("tls", "activations"), payload, nil)
+}
+
+func (c *Client) do(ctx context.Context, method string, endpoint *url.URL, payload, result any) error {
+	var body io.Reader
+
+	if payload != nil {
+		buf := new(bytes.Buffer)
+
+		err := json.NewEncoder(buf).Encode(payload)
+		if err != nil {
+			return fmt.Errorf("failed to create request JSON body: %w", err)
+		}
+
+		body = buf
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", contentType)
+	req.Header.Set("Fastly-Key", c.token)
+
+	if payload != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to communicate with Fastly: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+
+		err = json.Unmarshal(raw, apiErr)
+		if err != nil || len(apiErr.Errors) == 0 {
+			apiErr.Errors = []ErrorEntry{{Title: strings.TrimSpace(string(raw))}}
+		}
+
+		return apiErr
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal response: %w: %s", err, string(raw))
+	}
+
+	return nil
+}
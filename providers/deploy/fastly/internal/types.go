@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIError the Fastly API errors (JSON:API).
+type APIError struct {
+	StatusCode int          `json:"-"`
+	Errors     []ErrorEntry `json:"errors"`
+}
+
+func (e *APIError) Error() string {
+	var msg []string
+
+	for _, entry := range e.Errors {
+		if entry.Detail != "" {
+			msg = append(msg, entry.Title+": "+entry.Detail)
+		} else {
+			msg = append(msg, entry.Title)
+		}
+	}
+
+	return fmt.Sprintf("%d: %s", e.StatusCode, strings.Join(msg, ", "))
+}
+
+// ErrorEntry an error.
+type ErrorEntry struct {
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+// Document a JSON:API document with one resource.
+type Document struct {
+	Data Resource `json:"data"`
+}
+
+// Collection a JSON:API document with several resources.
+type Collection struct {
+	Data []Resource `json:"data"`
+}
+
+// Resource a JSON:API resource.
+type Resource struct {
+	ID            string                  `json:"id,omitempty"`
+	Type          string                  `json:"type"`
+	Attributes    map[string]any          `json:"attributes,omitempty"`
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+}
+
+// Relationship a JSON:API relationship: an Identifier, or a list of identifiers.
+type Relationship struct {
+	Data any `json:"data"`
+}
+
+// Identifier a JSON:API resource identifier.
+type Identifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
@@ -0,0 +1,331 @@
+// Package gcloud implements a deployer uploading the certificates to Google Cloud (Certificate Manager or classic SSL certificates).
+package gcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"cloud.google.com/go/compute/metadata"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/deploy/gcloud/internal"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+	"golang.org/x/oauth2/google"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "GCE_"
+
+	EnvServiceAccount = envNamespace + "SERVICE_ACCOUNT"
+	EnvProject        = envNamespace + "PROJECT"
+
+	EnvMode             = envNamespace + "CERTIFICATE_MODE"
+	EnvLocation         = envNamespace + "CERTIFICATE_LOCATION"
+	EnvCertificateName  = envNamespace + "CERTIFICATE_NAME"
+	EnvTargetHTTPSProxy = envNamespace + "CERTIFICATE_TARGET_HTTPS_PROXY"
+
+	EnvHTTPTimeout = envNamespace + "CERTIFICATE_HTTP_TIMEOUT"
+)
+
+// Modes.
+const (
+	// ModeCertificateManager uses Certificate Manager: the certificate is updated in place.
+	ModeCertificateManager = "certificatemanager"
+	// ModeCompute uses the classic SSL certificates of the load balancers: a new certificate is created by deployment.
+	ModeCompute = "compute"
+)
+
+const (
+	defaultLocation        = "global"
+	defaultCertificateName = "{{ .Name }}"
+)
+
+const scopeCloudPlatform = "https://www.googleapis.com/auth/cloud-platform"
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	Project string
+
+	// Mode the API used: ModeCertificateManager or ModeCompute.
+	Mode string
+	// Location the location of the Certificate Manager certificates.
+	Location string
+	// CertificateName the template of the name of the certificate.
+	// With the classic SSL certificates, the name is suffixed by the version of the certificate.
+	CertificateName string
+	// TargetHTTPSProxy the target HTTPS proxy to update with the new classic SSL certificate (optional).
+	TargetHTTPSProxy string
+
+	// HTTPClient an authorized (OAuth2) HTTP client.
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Mode:             env.GetOrDefaultString(EnvMode, ModeCertificateManager),
+		Location:         env.GetOrDefaultString(EnvLocation, defaultLocation),
+		CertificateName:  env.GetOrDefaultString(EnvCertificateName, defaultCertificateName),
+		TargetHTTPSProxy: env.GetOrFile(EnvTargetHTTPSProxy),
+	}
+}
+
+// TemplateData the data of the template of the name of the certificate.
+type TemplateData struct {
+	// Domain the main domain of the certificate.
+	Domain string
+	// Name the main domain as a valid resource name (e.g. "*.example.com" -> "wildcard-example-com").
+	Name string
+}
+
+// Deployer uploads the certificates to Google Cloud.
+type Deployer struct {
+	config *Config
+	client *internal.Client
+
+	certificateName *template.Template
+}
+
+// NewDeployer returns a Deployer instance configured for Google Cloud.
+// A service account can be passed with GCE_SERVICE_ACCOUNT (or GCE_SERVICE_ACCOUNT_FILE),
+// otherwise the default credentials are used.
+// The project is GCE_PROJECT, or the project of the service account, or the project of the metadata server.
+func NewDeployer() (*Deployer, error) {
+	ctx := context.Background()
+
+	config := NewDefaultConfig()
+	config.Project = env.GetOrFile(EnvProject)
+
+	timeout := env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second)
+
+	if saKey := env.GetOrFile(EnvServiceAccount); saKey != "" {
+		if config.Project == "" {
+			var datJSON struct {
+				ProjectID string `json:"project_id"`
+			}
+
+			err := json.Unmarshal([]byte(saKey), &datJSON)
+			if err != nil || datJSON.ProjectID == "" {
+				return nil, errors.New("gcloud: project ID not found in Google Cloud Service Account file")
+			}
+
+			config.Project = datJSON.ProjectID
+		}
+
+		conf, err := google.JWTConfigFromJSON([]byte(saKey), scopeCloudPlatform)
+		if err != nil {
+			return nil, fmt.Errorf("gcloud: unable to acquire config: %w", err)
+		}
+
+		config.HTTPClient = conf.Client(ctx)
+	} else {
+		if config.Project == "" {
+			config.Project, _ = metadata.ProjectIDWithContext(ctx)
+		}
+
+		client, err := google.DefaultClient(ctx, scopeCloudPlatform)
+		if err != nil {
+			return nil, fmt.Errorf("gcloud: unable to get Google Cloud client: %w", err)
+		}
+
+		config.HTTPClient = client
+	}
+
+	config.HTTPClient.Timeout = timeout
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for Google Cloud.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("gcloud: the configuration of the deployer is nil")
+	}
+
+	if config.Project == "" {
+		return nil, errors.New("gcloud: project name missing")
+	}
+
+	if config.HTTPClient == nil {
+		return nil, errors.New("gcloud: client is nil")
+	}
+
+	switch config.Mode {
+	case ModeCertificateManager:
+	case ModeCompute:
+		if config.Location != defaultLocation {
+			return nil, errors.New("gcloud: only the global classic SSL certificates are supported")
+		}
+	default:
+		return nil, fmt.Errorf("gcloud: unsupported mode: %q", config.Mode)
+	}
+
+	certificateName, err := template.New("certificateName").Parse(config.CertificateName)
+	if err != nil {
+		return nil, fmt.Errorf("gcloud: certificate name: %w", err)
+	}
+
+	return &Deployer{
+		config:          config,
+		client:          internal.NewClient(config.Project, config.HTTPClient),
+		certificateName: certificateName,
+	}, nil
+}
+
+// Deploy uploads the certificate.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("gcloud: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = d.certificateName.Execute(buf, TemplateData{Domain: res.Domain, Name: ResourceName(res.Domain)})
+	if err != nil {
+		return fmt.Errorf("gcloud: certificate name: %w", err)
+	}
+
+	if d.config.Mode == ModeCompute {
+		err = d.deployCompute(ctx, buf.String(), m)
+	} else {
+		err = d.deployCertificateManager(ctx, buf.String(), m)
+	}
+
+	if err != nil {
+		return fmt.Errorf("gcloud: %w", err)
+	}
+
+	return nil
+}
+
+// deployCertificateManager updates the certificate, or creates it.
+// The certificate maps referencing the certificate use the new certificate.
+func (d *Deployer) deployCertificateManager(ctx context.Context, name string, m *material.Material) error {
+	cert := &internal.Certificate{
+		Description: "lego: " + m.Domain,
+		Labels:      map[string]string{"managed-by": "lego"},
+		SelfManaged: &internal.SelfManagedCertificate{
+			PemCertificate: string(m.FullChain),
+			PemPrivateKey:  string(m.PrivateKey),
+		},
+	}
+
+	err := d.client.UpdateCertificate(ctx, d.config.Location, name, cert)
+	if err == nil {
+		return nil
+	}
+
+	if !internal.IsNotFound(err) {
+		return fmt.Errorf("update certificate %s: %w", name, err)
+	}
+
+	err = d.client.CreateCertificate(ctx, d.config.Location, name, cert)
+	if err != nil {
+		return fmt.Errorf("create certificate %s: %w", name, err)
+	}
+
+	log.Infof("[%s] gcloud: the certificate %s has been created.", m.Domain, name)
+
+	return nil
+}
+
+// deployCompute creates a new classic SSL certificate (immutable),
+// and replaces the previous versions by the new certificate in the target HTTPS proxy.
+func (d *Deployer) deployCompute(ctx context.Context, base string, m *material.Material) error {
+	name := base + "-" + m.Leaf.NotBefore.UTC().Format("20060102150405")
+
+	err := d.client.InsertSslCertificate(ctx, &internal.SslCertificate{
+		Name:        name,
+		Description: "lego: " + m.Domain,
+		Certificate: string(m.FullChain),
+		PrivateKey:  string(m.PrivateKey),
+	})
+	if err != nil && !isAlreadyExists(err) {
+		return fmt.Errorf("insert SSL certificate %s: %w", name, err)
+	}
+
+	if d.config.TargetHTTPSProxy == "" {
+		log.Infof("[%s] gcloud: the SSL certificate %s has been created.", m.Domain, name)
+		return nil
+	}
+
+	proxy, err := d.client.GetTargetHTTPSProxy(ctx, d.config.TargetHTTPSProxy)
+	if err != nil {
+		return fmt.Errorf("get target HTTPS proxy %s: %w", d.config.TargetHTTPSProxy, err)
+	}
+
+	certificates, replaced := replaceCertificate(proxy.SslCertificates, base, name,
+		d.client.ComputeURL.JoinPath("projects", d.config.Project, "global", "sslCertificates", name).String())
+
+	err = d.client.SetSslCertificates(ctx, d.config.TargetHTTPSProxy, certificates)
+	if err != nil {
+		return fmt.Errorf("set SSL certificates of target HTTPS proxy %s: %w", d.config.TargetHTTPSProxy, err)
+	}
+
+	// Best effort: the certificates can be used by other proxies.
+	for _, old := range replaced {
+		err = d.client.DeleteSslCertificate(ctx, old)
+		if err != nil {
+			log.Warnf("[%s] gcloud: delete SSL certificate %s: %v", m.Domain, old, err)
+		}
+	}
+
+	return nil
+}
+
+// replaceCertificate replaces the previous versions of the certificate (same base name), or adds the certificate.
+// It returns the certificates of the proxy, and the names of the replaced certificates.
+func replaceCertificate(certificates []string, base, name, link string) ([]string, []string) {
+	var (
+		result   []string
+		replaced []string
+	)
+
+	version := regexp.MustCompile("^" + regexp.QuoteMeta(base) + `-\d{14}$`)
+
+	for _, cert := range certificates {
+		certName := cert[strings.LastIndex(cert, "/")+1:]
+
+		switch {
+		case certName == name:
+			// Already used by the proxy.
+		case version.MatchString(certName):
+			replaced = append(replaced, certName)
+		default:
+			result = append(result, cert)
+		}
+	}
+
+	return append(result, link), replaced
+}
+
+func isAlreadyExists(err error) bool {
+	apiErr := &internal.APIError{}
+
+	return errors.As(err, &apiErr) && apiErr.Detail.Code == http.StatusConflict
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ResourceName returns a valid resource name (lowercase letters, digits, and dashes) from a domain.
+func ResourceName(domain string) string {
+	name := strings.ReplaceAll(strings.ToLower(domain), "*", "wildcard")
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "-"), "-")
+
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "cert-" + name
+	}
+
+	return name
+}
@@ -0,0 +1,216 @@
+package gcloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/deploy/gcloud/internal"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvServiceAccount, EnvProject, EnvMode, EnvLocation, EnvCertificateName, EnvTargetHTTPSProxy)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvServiceAccount: `{"type":"service_account","project_id":"my-project","private_key":"x","client_email":"lego@my-project.iam.gserviceaccount.com"}`,
+			},
+		},
+		{
+			desc: "missing project",
+			envVars: map[string]string{
+				EnvServiceAccount: `{"type":"service_account","private_key":"x","client_email":"lego@my-project.iam.gserviceaccount.com"}`,
+			},
+			expected: "gcloud: project ID not found in Google Cloud Service Account file",
+		},
+		{
+			desc: "unsupported mode",
+			envVars: map[string]string{
+				EnvServiceAccount: `{"type":"service_account","project_id":"my-project","private_key":"x","client_email":"lego@my-project.iam.gserviceaccount.com"}`,
+				EnvMode:           "foo",
+			},
+			expected: `gcloud: unsupported mode: "foo"`,
+		},
+		{
+			desc: "regional classic SSL certificates",
+			envVars: map[string]string{
+				EnvServiceAccount: `{"type":"service_account","project_id":"my-project","private_key":"x","client_email":"lego@my-project.iam.gserviceaccount.com"}`,
+				EnvMode:           ModeCompute,
+				EnvLocation:       "europe-west1",
+			},
+			expected: "gcloud: only the global classic SSL certificates are supported",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupDeployer(mode, proxy string) func(server *httptest.Server) (*Deployer, error) {
+	return func(server *httptest.Server) (*Deployer, error) {
+		config := NewDefaultConfig()
+		config.Project = "my-project"
+		config.Mode = mode
+		config.Location = defaultLocation
+		config.CertificateName = defaultCertificateName
+		config.TargetHTTPSProxy = proxy
+		config.HTTPClient = server.Client()
+
+		d, err := NewDeployerConfig(config)
+		if err != nil {
+			return nil, err
+		}
+
+		serverURL, _ := url.Parse(server.URL)
+
+		d.client.CertificateManagerURL = serverURL.JoinPath("v1")
+		d.client.ComputeURL = serverURL.JoinPath("compute", "v1")
+
+		return d, nil
+	}
+}
+
+func TestDeployer_Deploy_certificateManager(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(ModeCertificateManager, "")).
+		Route("PATCH /v1/projects/my-project/locations/global/certificates/wildcard-example-com",
+			servermock.RawStringResponse(`{"name":"projects/my-project/locations/global/operations/op1","done":true}`),
+			servermock.CheckQueryParameter().Strict().
+				With("updateMask", "selfManaged,labels"),
+			servermock.CheckHeader().
+				WithJSONHeaders(),
+		).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "*.example.com"))
+	require.NoError(t, err)
+}
+
+func TestDeployer_Deploy_certificateManager_create(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(ModeCertificateManager, "")).
+		Route("PATCH /v1/projects/my-project/locations/global/certificates/example-com",
+			servermock.RawStringResponse(`{"error":{"code":404,"message":"Resource not found","status":"NOT_FOUND"}}`).
+				WithStatusCode(http.StatusNotFound)).
+		Route("POST /v1/projects/my-project/locations/global/certificates",
+			servermock.RawStringResponse(`{"name":"projects/my-project/locations/global/operations/op1","done":true}`),
+			servermock.CheckQueryParameter().Strict().
+				With("certificateId", "example-com"),
+		).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+}
+
+func TestDeployer_Deploy_certificateManager_error(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(ModeCertificateManager, "")).
+		Route("PATCH /v1/projects/my-project/locations/global/certificates/example-com",
+			servermock.RawStringResponse(`{"name":"projects/my-project/locations/global/operations/op1","done":true,"error":{"code":3,"message":"invalid certificate"}}`)).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, "gcloud: update certificate example-com: 3: invalid certificate")
+}
+
+func TestDeployer_Deploy_compute(t *testing.T) {
+	var (
+		inserted internal.SslCertificate
+		proxy    map[string][]string
+	)
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(ModeCompute, "web-proxy")).
+		Route("POST /compute/v1/projects/my-project/global/sslCertificates",
+			decodeBody(&inserted, `{"name":"op1","status":"RUNNING"}`)).
+		Route("POST /compute/v1/projects/my-project/global/operations/op1/wait",
+			servermock.RawStringResponse(`{"name":"op1","status":"DONE"}`)).
+		Route("GET /compute/v1/projects/my-project/global/targetHttpsProxies/web-proxy",
+			servermock.RawStringResponse(`{"name":"web-proxy","sslCertificates":[
+				"https://compute.googleapis.com/compute/v1/projects/my-project/global/sslCertificates/example-com-20250101000000",
+				"https://compute.googleapis.com/compute/v1/projects/my-project/global/sslCertificates/example-org"
+			]}`)).
+		Route("POST /compute/v1/projects/my-project/global/targetHttpsProxies/web-proxy/setSslCertificates",
+			decodeBody(&proxy, `{"name":"op2","status":"DONE"}`)).
+		Route("DELETE /compute/v1/projects/my-project/global/sslCertificates/example-com-20250101000000",
+			servermock.RawStringResponse(`{"name":"op3","status":"DONE"}`)).
+		Build(t)
+
+	res := deploytest.NewResource(t, "example.com")
+
+	err := deployer.Deploy(t.Context(), res)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example-com-20291003000000", inserted.Name)
+	assert.Equal(t, string(res.PrivateKey), inserted.PrivateKey)
+
+	require.Len(t, proxy["sslCertificates"], 2)
+	assert.Equal(t, "https://compute.googleapis.com/compute/v1/projects/my-project/global/sslCertificates/example-org", proxy["sslCertificates"][0])
+	assert.True(t, strings.HasSuffix(proxy["sslCertificates"][1], "/compute/v1/projects/my-project/global/sslCertificates/example-com-20291003000000"))
+}
+
+func decodeBody(body any, response string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(response))
+	}
+}
+
+func Test_replaceCertificate(t *testing.T) {
+	certificates := []string{
+		"https://compute.googleapis.com/compute/v1/projects/p/global/sslCertificates/example-com-20250101000000",
+		"https://compute.googleapis.com/compute/v1/projects/p/global/sslCertificates/example-com-www",
+		"https://compute.googleapis.com/compute/v1/projects/p/global/sslCertificates/example-org-20250101000000",
+	}
+
+	result, replaced := replaceCertificate(certificates, "example-com", "example-com-20260101000000",
+		"https://compute.googleapis.com/compute/v1/projects/p/global/sslCertificates/example-com-20260101000000")
+
+	expected := []string{
+		"https://compute.googleapis.com/compute/v1/projects/p/global/sslCertificates/example-com-www",
+		"https://compute.googleapis.com/compute/v1/projects/p/global/sslCertificates/example-org-20250101000000",
+		"https://compute.googleapis.com/compute/v1/projects/p/global/sslCertificates/example-com-20260101000000",
+	}
+
+	assert.Equal(t, expected, result)
+	assert.Equal(t, []string{"example-com-20250101000000"}, replaced)
+}
+
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "example-com", ResourceName("example.com"))
+	assert.Equal(t, "wildcard-example-com", ResourceName("*.example.com"))
+	assert.Equal(t, "cert-1-example-com", ResourceName("1.example.com"))
+}
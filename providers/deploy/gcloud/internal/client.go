@@ -0,0 +1,225 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Default base URLs.
+const (
+	DefaultCertificateManagerURL = "https://certificatemanager.googleapis.com/v1"
+	DefaultComputeURL            = "https://compute.googleapis.com/compute/v1"
+)
+
+// The interval between two checks of the status of the operations.
+const operationInterval = 2 * time.Second
+
+// Client the Certificate Manager and Compute Engine API client.
+type Client struct {
+	project string
+
+	CertificateManagerURL *url.URL
+	ComputeURL            *url.URL
+	HTTPClient            *http.Client
+}
+
+// NewClient creates a new Client.
+// The HTTP client must be authorized (OAuth2).
+func NewClient(project string, httpClient *http.Client) *Client {
+	certificateManagerURL, _ := url.Parse(DefaultCertificateManagerURL)
+	computeURL, _ := url.Parse(DefaultComputeURL)
+
+	return &Client{
+		project:               project,
+		CertificateManagerURL: certificateManagerURL,
+		ComputeURL:            computeURL,
+		HTTPClient:            httpClient,
+	}
+}
+
+// CreateCertificate creates a certificate of Certificate Manager.
+func (c *Client) CreateCertificate(ctx context.Context, location, name string, cert *Certificate) error {
+	endpoint := c.CertificateManagerURL.JoinPath("projects", c.project, "locations", location, "certificates")
+
+	query := endpoint.Query()
+	query.Set("certificateId", name)
+	endpoint.RawQuery = query.Encode()
+
+	return c.doOperation(ctx, http.MethodPost, endpoint, cert)
+}
+
+// UpdateCertificate replaces the certificate and the private key of a self-managed certificate of Certificate Manager.
+func (c *Client) UpdateCertificate(ctx context.Context, location, name string, cert *Certificate) error {
+	endpoint := c.CertificateManagerURL.JoinPath("projects", c.project, "locations", location, "certificates", name)
+
+	query := endpoint.Query()
+	query.Set("updateMask", "selfManaged,labels")
+	endpoint.RawQuery = query.Encode()
+
+	return c.doOperation(ctx, http.MethodPatch, endpoint, cert)
+}
+
+// InsertSslCertificate creates a classic (global) SSL certificate.
+func (c *Client) InsertSslCertificate(ctx context.Context, cert *SslCertificate) error {
+	endpoint := c.ComputeURL.JoinPath("projects", c.project, "global", "sslCertificates")
+
+	return c.doComputeOperation(ctx, http.MethodPost, endpoint, cert)
+}
+
+// DeleteSslCertificate deletes a classic (global) SSL certificate.
+func (c *Client) DeleteSslCertificate(ctx context.Context, name string) error {
+	endpoint := c.ComputeURL.JoinPath("projects", c.project, "global", "sslCertificates", name)
+
+	return c.doComputeOperation(ctx, http.MethodDelete, endpoint, nil)
+}
+
+// GetTargetHTTPSProxy returns a target HTTPS proxy.
+func (c *Client) GetTargetHTTPSProxy(ctx context.Context, name string) (*TargetHTTPSProxy, error) {
+	endpoint := c.ComputeURL.JoinPath("projects", c.project, "global", "targetHttpsProxies", name)
+
+	var proxy TargetHTTPSProxy
+
+	err := c.do(ctx, http.MethodGet, endpoint, nil, &proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proxy, nil
+}
+
+// SetSslCertificates replaces the SSL certificates of a target HTTPS proxy.
+func (c *Client) SetSslCertificates(ctx context.Context, proxy string, certificates []string) error {
+	endpoint := c.ComputeURL.JoinPath("projects", c.project, "global", "targetHttpsProxies", proxy, "setSslCertificates")
+
+	return c.doComputeOperation(ctx, http.MethodPost, endpoint, map[string][]string{"sslCertificates": certificates})
+}
+
+// doOperation calls the API, and waits for the completion of the long-running operation (Certificate Manager).
+func (c *Client) doOperation(ctx context.Context, method string, endpoint *url.URL, payload any) error {
+	var op Operation
+
+	err := c.do(ctx, method, endpoint, payload, &op)
+	if err != nil {
+		return err
+	}
+
+	for !op.Done {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("operation %s: %w", op.Name, ctx.Err())
+		case <-time.After(operationInterval):
+		}
+
+		err = c.do(ctx, http.MethodGet, c.CertificateManagerURL.JoinPath(op.Name), nil, &op)
+		if err != nil {
+			return fmt.Errorf("operation %s: %w", op.Name, err)
+		}
+	}
+
+	if op.Error != nil {
+		return &APIError{Detail: *op.Error}
+	}
+
+	return nil
+}
+
+// doComputeOperation calls the API, and waits for the completion of the global operation (Compute Engine).
+func (c *Client) doComputeOperation(ctx context.Context, method string, endpoint *url.URL, payload any) error {
+	var op ComputeOperation
+
+	err := c.do(ctx, method, endpoint, payload, &op)
+	if err != nil {
+		return err
+	}
+
+	for op.Status != "DONE" {
+		// The wait method returns when the operation is done, or after 2 minutes.
+		err = c.do(ctx, http.MethodPost, c.ComputeURL.JoinPath("projects", c.project, "global", "operations", op.Name, "wait"), nil, &op)
+		if err != nil {
+			return fmt.Errorf("operation %s: %w", op.Name, err)
+		}
+	}
+
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return op.Error
+	}
+
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method string, endpoint *url.URL, payload, result any) error {
+	var body io.Reader
+
+	if payload != nil {
+		buf := new(bytes.Buffer)
+
+		err := json.NewEncoder(buf).Encode(payload)
+		if err != nil {
+			return fmt.Errorf("failed to create request JSON body: %w", err)
+		}
+
+		body = buf
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to communicate with Google Cloud: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{}
+
+		err = json.Unmarshal(raw, apiErr)
+		if err != nil || apiErr.Detail.Message == "" {
+			apiErr.Detail = ErrorDetail{Message: strings.TrimSpace(string(raw))}
+		}
+
+		apiErr.Detail.Code = resp.StatusCode
+
+		return apiErr
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal response: %w: %s", err, string(raw))
+	}
+
+	return nil
+}
+
+// IsNotFound checks if the error is a "not found" error.
+func IsNotFound(err error) bool {
+	apiErr := &APIError{}
+
+	return errors.As(err, &apiErr) && apiErr.Detail.Code == http.StatusNotFound
+}
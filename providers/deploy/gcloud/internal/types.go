@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIError the Google Cloud API errors.
+type APIError struct {
+	Detail ErrorDetail `json:"error"`
+}
+
+func (e *APIError) Error() string {
+	if e.Detail.Status == "" {
+		return fmt.Sprintf("%d: %s", e.Detail.Code, e.Detail.Message)
+	}
+
+	return fmt.Sprintf("%d: %s: %s", e.Detail.Code, e.Detail.Status, e.Detail.Message)
+}
+
+// ErrorDetail the detail of an error.
+type ErrorDetail struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// Certificate a certificate of Certificate Manager.
+type Certificate struct {
+	Name        string                  `json:"name,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	Labels      map[string]string       `json:"labels,omitempty"`
+	SelfManaged *SelfManagedCertificate `json:"selfManaged,omitempty"`
+}
+
+// SelfManagedCertificate the certificate and the private key of a self-managed certificate.
+type SelfManagedCertificate struct {
+	PemCertificate string `json:"pemCertificate"`
+	PemPrivateKey  string `json:"pemPrivateKey"`
+}
+
+// Operation a long-running operation of Certificate Manager.
+type Operation struct {
+	Name  string       `json:"name"`
+	Done  bool         `json:"done"`
+	Error *ErrorDetail `json:"error,omitempty"`
+}
+
+// SslCertificate a classic SSL certificate (Compute Engine).
+type SslCertificate struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"privateKey"`
+	SelfLink    string `json:"selfLink,omitempty"`
+}
+
+// TargetHTTPSProxy a target HTTPS proxy (Compute Engine).
+type TargetHTTPSProxy struct {
+	Name            string   `json:"name"`
+	SslCertificates []string `json:"sslCertificates"`
+}
+
+// ComputeOperation an operation of Compute Engine.
+type ComputeOperation struct {
+	Name   string         `json:"name"`
+	Status string         `json:"status"`
+	Error  *ComputeErrors `json:"error,omitempty"`
+}
+
+// ComputeErrors the errors of an operation of Compute Engine.
+type ComputeErrors struct {
+	Errors []ComputeError `json:"errors"`
+}
+
+// ComputeError an error of an operation of Compute Engine.
+type ComputeError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ComputeErrors) Error() string {
+	var msg []string
+	for _, err := range e.Errors {
+		msg = append(msg, err.Code+": "+err.Message)
+	}
+
+	return strings.Join(msg, ", ")
+}
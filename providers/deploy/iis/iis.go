@@ -0,0 +1,264 @@
+// Package iis implements a deployer importing the certificates in the certificate store of Windows, and binding them to IIS sites.
+package iis
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/certstore"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/httpsys"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "IIS_"
+
+	EnvBindings     = envNamespace + "BINDINGS"
+	EnvStore        = envNamespace + "STORE"
+	EnvFriendlyName = envNamespace + "FRIENDLY_NAME"
+	EnvAppCmd       = envNamespace + "APPCMD"
+)
+
+const (
+	defaultStore        = "WebHosting"
+	defaultFriendlyName = "{{ .Domain }} ({{ .NotAfter.Format \"2006-01-02\" }})"
+)
+
+// SSL flags of the IIS bindings.
+const sslFlagSNI = "1"
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	// Bindings the HTTPS bindings of the sites: `<site name>=<ip>:<port>:<hostname>` (IIS binding information).
+	// The hostname is optional (binding without SNI), `*` is all the addresses.
+	Bindings []string
+
+	// Store the store of the local machine (e.g. `WebHosting`, `My`).
+	Store string
+
+	// FriendlyName the template of the friendly name of the certificate.
+	FriendlyName string
+
+	// AppCmd the path of appcmd.exe (IIS administration).
+	AppCmd string
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Store:        env.GetOrDefaultString(EnvStore, defaultStore),
+		FriendlyName: env.GetOrDefaultString(EnvFriendlyName, defaultFriendlyName),
+		AppCmd:       env.GetOrDefaultString(EnvAppCmd, filepath.Join(os.Getenv("windir"), "system32", "inetsrv", "appcmd.exe")),
+	}
+}
+
+// TemplateData the data of the template of the friendly name.
+type TemplateData struct {
+	// Domain the main domain of the certificate.
+	Domain string
+	// NotAfter the expiration date of the certificate.
+	NotAfter time.Time
+}
+
+// siteBinding a HTTPS binding of an IIS site.
+type siteBinding struct {
+	site string
+	// info the binding information (`<ip>:<port>:<hostname>`).
+	info    string
+	binding httpsys.Binding
+}
+
+// Deployer imports the certificates in the certificate store of the local machine,
+// and binds them to IIS sites.
+type Deployer struct {
+	config *Config
+
+	friendlyName *template.Template
+	bindings     []siteBinding
+
+	importCertificate func(pfx []byte, password string, opts certstore.Options) ([]byte, error)
+	updateBinding     func(binding httpsys.Binding, hash []byte, storeName string) error
+	run               func(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// NewDeployer returns a Deployer instance configured for IIS.
+func NewDeployer() (*Deployer, error) {
+	values, err := env.Get(EnvBindings)
+	if err != nil {
+		return nil, fmt.Errorf("iis: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Bindings = strings.Split(values[EnvBindings], ",")
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for IIS.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("iis: the configuration of the deployer is nil")
+	}
+
+	if len(config.Bindings) == 0 {
+		return nil, errors.New("iis: the bindings are missing")
+	}
+
+	if config.Store == "" {
+		return nil, errors.New("iis: the store is missing")
+	}
+
+	friendlyName, err := template.New("friendlyName").Parse(config.FriendlyName)
+	if err != nil {
+		return nil, fmt.Errorf("iis: friendly name: %w", err)
+	}
+
+	var bindings []siteBinding
+
+	for _, raw := range config.Bindings {
+		binding, errP := parseSiteBinding(raw)
+		if errP != nil {
+			return nil, fmt.Errorf("iis: %w", errP)
+		}
+
+		bindings = append(bindings, binding)
+	}
+
+	return &Deployer{
+		config:            config,
+		friendlyName:      friendlyName,
+		bindings:          bindings,
+		importCertificate: certstore.Import,
+		updateBinding:     httpsys.Update,
+		run:               run,
+	}, nil
+}
+
+// Deploy imports the certificate in the store, adds the HTTPS bindings to the sites if needed,
+// then binds the certificate (thumbprint) to them.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("iis: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = d.friendlyName.Execute(buf, TemplateData{Domain: res.Domain, NotAfter: m.Leaf.NotAfter})
+	if err != nil {
+		return fmt.Errorf("iis: friendly name: %w", err)
+	}
+
+	// The PKCS#12 archive is only used to transfer the private key to the store.
+	password := rand.Text()
+
+	pfx, err := m.PKCS12(password)
+	if err != nil {
+		return fmt.Errorf("iis: %w", err)
+	}
+
+	thumbprint, err := d.importCertificate(pfx, password, certstore.Options{
+		StoreName:    d.config.Store,
+		FriendlyName: buf.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("iis: import certificate: %w", err)
+	}
+
+	for _, b := range d.bindings {
+		err = d.ensureSiteBinding(ctx, b)
+		if err != nil {
+			return fmt.Errorf("iis: site %q: binding %s: %w", b.site, b.info, err)
+		}
+
+		err = d.updateBinding(b.binding, thumbprint, d.config.Store)
+		if err != nil {
+			return fmt.Errorf("iis: site %q: binding %s: %w", b.site, b.info, err)
+		}
+	}
+
+	return nil
+}
+
+// ensureSiteBinding adds the HTTPS binding to the site, if the site doesn't have it yet.
+func (d *Deployer) ensureSiteBinding(ctx context.Context, b siteBinding) error {
+	output, err := d.run(ctx, d.config.AppCmd, "list", "site", b.site, "/text:bindings")
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(strings.Split(strings.TrimSpace(string(output)), ","), "https/"+b.info) {
+		return nil
+	}
+
+	binding := fmt.Sprintf("protocol='https',bindingInformation='%s'", b.info)
+	if b.binding.Host != "" {
+		binding += fmt.Sprintf(",sslFlags='%s'", sslFlagSNI)
+	}
+
+	_, err = d.run(ctx, d.config.AppCmd, "set", "site", b.site, "/+bindings.["+binding+"]")
+
+	return err
+}
+
+// parseSiteBinding parses a binding: `<site name>=<ip>:<port>:<hostname>`.
+func parseSiteBinding(raw string) (siteBinding, error) {
+	site, info, ok := strings.Cut(strings.TrimSpace(raw), "=")
+	if !ok || strings.TrimSpace(site) == "" {
+		return siteBinding{}, fmt.Errorf("binding %q: the site name is missing", raw)
+	}
+
+	info = strings.TrimSpace(info)
+
+	i := strings.LastIndex(info, ":")
+	if i < 0 {
+		return siteBinding{}, fmt.Errorf("binding %q: the format is <ip>:<port>:<hostname>", raw)
+	}
+
+	ipPort, hostname := info[:i], info[i+1:]
+
+	if hostname == "" {
+		binding, err := httpsys.ParseBinding(ipPort)
+		if err != nil {
+			return siteBinding{}, err
+		}
+
+		return siteBinding{site: strings.TrimSpace(site), info: info, binding: binding}, nil
+	}
+
+	// The bindings with a hostname use SNI: the certificate is bound to the hostname and the port.
+	_, port, err := net.SplitHostPort(ipPort)
+	if err != nil {
+		return siteBinding{}, fmt.Errorf("binding %q: %w", raw, err)
+	}
+
+	binding, err := httpsys.ParseBinding(net.JoinHostPort(hostname, port))
+	if err != nil {
+		return siteBinding{}, err
+	}
+
+	return siteBinding{site: strings.TrimSpace(site), info: info, binding: binding}, nil
+}
+
+func run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("appcmd %s: %w: %s", args[0], err, bytes.TrimSpace(output))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,223 @@
+package iis
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/certstore"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/httpsys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var envTest = tester.NewEnvTest(EnvBindings, EnvStore, EnvFriendlyName, EnvAppCmd)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvBindings: "Default Web Site=*:443:www.example.com,API=192.0.2.1:8443:",
+			},
+		},
+		{
+			desc:     "missing bindings",
+			envVars:  map[string]string{},
+			expected: "iis: some credentials information are missing: IIS_BINDINGS",
+		},
+		{
+			desc: "missing site name",
+			envVars: map[string]string{
+				EnvBindings: "*:443:www.example.com",
+			},
+			expected: `iis: binding "*:443:www.example.com": the site name is missing`,
+		},
+		{
+			desc: "invalid binding",
+			envVars: map[string]string{
+				EnvBindings: "Default Web Site=www.example.com",
+			},
+			expected: `iis: binding "Default Web Site=www.example.com": the format is <ip>:<port>:<hostname>`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_parseSiteBinding(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		raw      string
+		expected siteBinding
+	}{
+		{
+			desc: "SNI",
+			raw:  "Default Web Site=*:443:www.example.com",
+			expected: siteBinding{
+				site:    "Default Web Site",
+				info:    "*:443:www.example.com",
+				binding: httpsys.Binding{Host: "www.example.com", Addr: netip.MustParseAddrPort("0.0.0.0:443")},
+			},
+		},
+		{
+			desc: "IP",
+			raw:  " API = 192.0.2.1:8443:",
+			expected: siteBinding{
+				site:    "API",
+				info:    "192.0.2.1:8443:",
+				binding: httpsys.Binding{Addr: netip.MustParseAddrPort("192.0.2.1:8443")},
+			},
+		},
+		{
+			desc: "IPv6",
+			raw:  "API=[2001:db8::1]:443:",
+			expected: siteBinding{
+				site:    "API",
+				info:    "[2001:db8::1]:443:",
+				binding: httpsys.Binding{Addr: netip.MustParseAddrPort("[2001:db8::1]:443")},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			binding, err := parseSiteBinding(test.raw)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, binding)
+		})
+	}
+}
+
+type fakeIIS struct {
+	t *testing.T
+
+	// bindings the output of `appcmd list site <site> /text:bindings`.
+	bindings map[string]string
+
+	commands []string
+	updated  []string
+}
+
+func (f *fakeIIS) importCertificate(pfx []byte, password string, opts certstore.Options) ([]byte, error) {
+	_, leaf, _, err := pkcs12.DecodeChain(pfx, password)
+	require.NoError(f.t, err)
+
+	assert.Equal(f.t, "example.com", leaf.Subject.CommonName)
+	assert.Equal(f.t, certstore.Options{StoreName: "WebHosting", FriendlyName: "example.com (2030-01-01)"}, opts)
+
+	return []byte{0xca, 0xfe}, nil
+}
+
+func (f *fakeIIS) updateBinding(binding httpsys.Binding, hash []byte, storeName string) error {
+	assert.Equal(f.t, []byte{0xca, 0xfe}, hash)
+	assert.Equal(f.t, "WebHosting", storeName)
+
+	f.updated = append(f.updated, binding.String())
+
+	return nil
+}
+
+func (f *fakeIIS) run(_ context.Context, name string, args ...string) ([]byte, error) {
+	assert.Equal(f.t, `C:\Windows\system32\inetsrv\appcmd.exe`, name)
+
+	f.commands = append(f.commands, strings.Join(args, " "))
+
+	if args[0] == "list" {
+		bindings, ok := f.bindings[args[2]]
+		if !ok {
+			return nil, errors.New(`appcmd list: exit status 1: ERROR ( message:Cannot find SITE object with identifier "` + args[2] + `". )`)
+		}
+
+		return []byte(bindings + "\r\n"), nil
+	}
+
+	return nil, nil
+}
+
+func setupDeployer(t *testing.T, iis *fakeIIS, bindings ...string) *Deployer {
+	t.Helper()
+
+	config := &Config{
+		Bindings:     bindings,
+		Store:        "WebHosting",
+		FriendlyName: defaultFriendlyName,
+		AppCmd:       `C:\Windows\system32\inetsrv\appcmd.exe`,
+	}
+
+	d, err := NewDeployerConfig(config)
+	require.NoError(t, err)
+
+	d.importCertificate = iis.importCertificate
+	d.updateBinding = iis.updateBinding
+	d.run = iis.run
+
+	return d
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	iis := &fakeIIS{
+		t: t,
+		bindings: map[string]string{
+			"Default Web Site": "http/*:80:,https/*:443:www.example.com",
+			"API":              "http/*:80:api.example.com",
+		},
+	}
+
+	d := setupDeployer(t, iis, "Default Web Site=*:443:www.example.com", "API=*:443:api.example.com", "API=192.0.2.1:8443:")
+
+	err := d.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+
+	expected := []string{
+		"list site Default Web Site /text:bindings",
+		"list site API /text:bindings",
+		"set site API /+bindings.[protocol='https',bindingInformation='*:443:api.example.com',sslFlags='1']",
+		"list site API /text:bindings",
+		"set site API /+bindings.[protocol='https',bindingInformation='192.0.2.1:8443:']",
+	}
+	assert.Equal(t, expected, iis.commands)
+
+	assert.Equal(t, []string{"www.example.com:443", "api.example.com:443", "192.0.2.1:8443"}, iis.updated)
+}
+
+func TestDeployer_Deploy_unknownSite(t *testing.T) {
+	iis := &fakeIIS{t: t}
+
+	d := setupDeployer(t, iis, "Unknown=*:443:www.example.com")
+
+	err := d.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, `iis: site "Unknown": binding *:443:www.example.com: appcmd list: exit status 1: ERROR ( message:Cannot find SITE object with identifier "Unknown". )`)
+
+	assert.Empty(t, iis.updated)
+}
@@ -0,0 +1,19 @@
+// Package certstore imports the certificates in the certificate stores of the local machine (Windows).
+package certstore
+
+import "errors"
+
+// ErrNotSupported is returned on the platforms without certificate stores.
+var ErrNotSupported = errors.New("the certificate stores are only supported on Windows")
+
+// Options the options of the import.
+type Options struct {
+	// StoreName the store of the certificate (e.g. "My").
+	StoreName string
+
+	// FriendlyName the friendly name of the certificate (optional).
+	FriendlyName string
+
+	// GrantRead the accounts allowed to read the private key (e.g. `IIS AppPool\DefaultAppPool`).
+	GrantRead []string
+}
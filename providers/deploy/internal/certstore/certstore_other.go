@@ -0,0 +1,8 @@
+//go:build !windows
+
+package certstore
+
+// Import imports the certificate and its private key from a PKCS#12 archive.
+func Import(_ []byte, _ string, _ Options) ([]byte, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,301 @@
+//go:build windows
+
+package certstore
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	modcrypt32 = windows.NewLazySystemDLL("crypt32.dll")
+	modncrypt  = windows.NewLazySystemDLL("ncrypt.dll")
+
+	procCertSetCertificateContextProperty = modcrypt32.NewProc("CertSetCertificateContextProperty")
+	procNCryptGetProperty                 = modncrypt.NewProc("NCryptGetProperty")
+	procNCryptSetProperty                 = modncrypt.NewProc("NCryptSetProperty")
+	procNCryptFreeObject                  = modncrypt.NewProc("NCryptFreeObject")
+)
+
+const (
+	encodingType = windows.X509_ASN_ENCODING | windows.PKCS_7_ASN_ENCODING
+
+	certFriendlyNamePropID = 11
+
+	ncryptSecurityDescrProperty = "Security Descr"
+
+	// The store of the intermediate certificates.
+	intermediateStoreName = "CA"
+)
+
+// Import imports the certificate and its private key (CNG, machine key) from a PKCS#12 archive,
+// in a store of the local machine.
+// The issuers are added to the store of the intermediate certification authorities.
+// Returns the thumbprint (SHA-1) of the certificate.
+func Import(pfx []byte, password string, opts Options) ([]byte, error) {
+	if len(pfx) == 0 {
+		return nil, errors.New("empty PKCS#12 archive")
+	}
+
+	pwd, err := windows.UTF16PtrFromString(password)
+	if err != nil {
+		return nil, err
+	}
+
+	blob := windows.CryptDataBlob{Size: uint32(len(pfx)), Data: &pfx[0]}
+
+	flags := uint32(windows.CRYPT_MACHINE_KEYSET | windows.PKCS12_ALWAYS_CNG_KSP | windows.PKCS12_ALLOW_OVERWRITE_KEY)
+
+	temp, err := windows.PFXImportCertStore(&blob, pwd, flags)
+	if err != nil {
+		return nil, fmt.Errorf("PFXImportCertStore: %w", err)
+	}
+
+	defer func() { _ = windows.CertCloseStore(temp, 0) }()
+
+	var (
+		thumbprint []byte
+		leaf       *windows.CertContext
+	)
+
+	for ctx := (*windows.CertContext)(nil); ; {
+		ctx, err = windows.CertEnumCertificatesInStore(temp, ctx)
+		if err != nil {
+			break
+		}
+
+		if hasPrivateKey(ctx) {
+			leaf = windows.CertDuplicateCertificateContext(ctx)
+			sum := sha1.Sum(encoded(ctx))
+			thumbprint = sum[:]
+
+			continue
+		}
+
+		err = addToStore(intermediateStoreName, ctx, windows.CERT_STORE_ADD_USE_EXISTING, nil)
+		if err != nil {
+			return nil, fmt.Errorf("add issuer: %w", err)
+		}
+	}
+
+	if leaf == nil {
+		return nil, errors.New("no certificate with a private key in the PKCS#12 archive")
+	}
+
+	defer func() { _ = windows.CertFreeCertificateContext(leaf) }()
+
+	err = addToStore(opts.StoreName, leaf, windows.CERT_STORE_ADD_REPLACE_EXISTING, func(ctx *windows.CertContext) error {
+		if opts.FriendlyName != "" {
+			errP := setFriendlyName(ctx, opts.FriendlyName)
+			if errP != nil {
+				return fmt.Errorf("friendly name: %w", errP)
+			}
+		}
+
+		if len(opts.GrantRead) > 0 {
+			errP := grantRead(ctx, opts.GrantRead)
+			if errP != nil {
+				return fmt.Errorf("private key access: %w", errP)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return thumbprint, nil
+}
+
+// addToStore adds the certificate to a store of the local machine, and calls fn with the certificate of the store.
+func addToStore(storeName string, ctx *windows.CertContext, disposition uint32, fn func(ctx *windows.CertContext) error) error {
+	name, err := windows.UTF16PtrFromString(storeName)
+	if err != nil {
+		return err
+	}
+
+	store, err := windows.CertOpenStore(windows.CERT_STORE_PROV_SYSTEM, 0, 0,
+		windows.CERT_SYSTEM_STORE_LOCAL_MACHINE, uintptr(unsafe.Pointer(name)))
+	if err != nil {
+		return fmt.Errorf("open store %s: %w", storeName, err)
+	}
+
+	defer func() { _ = windows.CertCloseStore(store, 0) }()
+
+	var added *windows.CertContext
+
+	err = windows.CertAddCertificateContextToStore(store, ctx, disposition, &added)
+	if err != nil {
+		return fmt.Errorf("add certificate to store %s: %w", storeName, err)
+	}
+
+	defer func() { _ = windows.CertFreeCertificateContext(added) }()
+
+	if fn == nil {
+		return nil
+	}
+
+	return fn(added)
+}
+
+func hasPrivateKey(ctx *windows.CertContext) bool {
+	var key windows.Handle
+
+	var (
+		keySpec    uint32
+		callerFree bool
+	)
+
+	flags := uint32(windows.CRYPT_ACQUIRE_SILENT_FLAG | windows.CRYPT_ACQUIRE_ONLY_NCRYPT_KEY_FLAG)
+
+	err := windows.CryptAcquireCertificatePrivateKey(ctx, flags, nil, &key, &keySpec, &callerFree)
+	if err != nil {
+		return false
+	}
+
+	if callerFree {
+		_, _, _ = procNCryptFreeObject.Call(uintptr(key))
+	}
+
+	return true
+}
+
+func setFriendlyName(ctx *windows.CertContext, friendlyName string) error {
+	name, err := windows.UTF16FromString(friendlyName)
+	if err != nil {
+		return err
+	}
+
+	blob := windows.CryptDataBlob{Size: uint32(len(name) * 2), Data: (*byte)(unsafe.Pointer(&name[0]))}
+
+	r, _, err := procCertSetCertificateContextProperty.Call(
+		uintptr(unsafe.Pointer(ctx)), certFriendlyNamePropID, 0, uintptr(unsafe.Pointer(&blob)))
+	if r == 0 {
+		return err
+	}
+
+	return nil
+}
+
+// grantRead adds the accounts to the DACL of the private key (CNG).
+func grantRead(ctx *windows.CertContext, accounts []string) error {
+	var key windows.Handle
+
+	var (
+		keySpec    uint32
+		callerFree bool
+	)
+
+	flags := uint32(windows.CRYPT_ACQUIRE_SILENT_FLAG | windows.CRYPT_ACQUIRE_ONLY_NCRYPT_KEY_FLAG)
+
+	err := windows.CryptAcquireCertificatePrivateKey(ctx, flags, nil, &key, &keySpec, &callerFree)
+	if err != nil {
+		return fmt.Errorf("CryptAcquireCertificatePrivateKey: %w", err)
+	}
+
+	if callerFree {
+		defer func() { _, _, _ = procNCryptFreeObject.Call(uintptr(key)) }()
+	}
+
+	sd, err := keySecurityDescriptor(key)
+	if err != nil {
+		return err
+	}
+
+	dacl, _, err := sd.DACL()
+	if err != nil && !errors.Is(err, windows.ERROR_OBJECT_NOT_FOUND) {
+		return fmt.Errorf("DACL: %w", err)
+	}
+
+	var entries []windows.EXPLICIT_ACCESS
+
+	for _, account := range accounts {
+		sid, _, _, errL := windows.LookupSID("", account)
+		if errL != nil {
+			return fmt.Errorf("account %q: %w", account, errL)
+		}
+
+		entries = append(entries, windows.EXPLICIT_ACCESS{
+			AccessPermissions: windows.GENERIC_READ,
+			AccessMode:        windows.GRANT_ACCESS,
+			Inheritance:       windows.NO_INHERITANCE,
+			Trustee: windows.TRUSTEE{
+				TrusteeForm:  windows.TRUSTEE_IS_SID,
+				TrusteeType:  windows.TRUSTEE_IS_UNKNOWN,
+				TrusteeValue: windows.TrusteeValueFromSID(sid),
+			},
+		})
+	}
+
+	acl, err := windows.ACLFromEntries(entries, dacl)
+	if err != nil {
+		return fmt.Errorf("ACL: %w", err)
+	}
+
+	absolute, err := windows.NewSecurityDescriptor()
+	if err != nil {
+		return err
+	}
+
+	err = absolute.SetDACL(acl, true, false)
+	if err != nil {
+		return err
+	}
+
+	relative, err := absolute.ToSelfRelative()
+	if err != nil {
+		return err
+	}
+
+	return setKeySecurityDescriptor(key, relative)
+}
+
+func keySecurityDescriptor(key windows.Handle) (*windows.SECURITY_DESCRIPTOR, error) {
+	property, err := windows.UTF16PtrFromString(ncryptSecurityDescrProperty)
+	if err != nil {
+		return nil, err
+	}
+
+	var size uint32
+
+	r, _, _ := procNCryptGetProperty.Call(uintptr(key), uintptr(unsafe.Pointer(property)), 0, 0,
+		uintptr(unsafe.Pointer(&size)), windows.DACL_SECURITY_INFORMATION)
+	if r != 0 {
+		return nil, fmt.Errorf("NCryptGetProperty: %w", windows.Errno(r))
+	}
+
+	buf := make([]byte, size)
+
+	r, _, _ = procNCryptGetProperty.Call(uintptr(key), uintptr(unsafe.Pointer(property)), uintptr(unsafe.Pointer(&buf[0])), uintptr(size),
+		uintptr(unsafe.Pointer(&size)), windows.DACL_SECURITY_INFORMATION)
+	if r != 0 {
+		return nil, fmt.Errorf("NCryptGetProperty: %w", windows.Errno(r))
+	}
+
+	return (*windows.SECURITY_DESCRIPTOR)(unsafe.Pointer(&buf[0])), nil
+}
+
+func setKeySecurityDescriptor(key windows.Handle, sd *windows.SECURITY_DESCRIPTOR) error {
+	property, err := windows.UTF16PtrFromString(ncryptSecurityDescrProperty)
+	if err != nil {
+		return err
+	}
+
+	r, _, _ := procNCryptSetProperty.Call(uintptr(key), uintptr(unsafe.Pointer(property)),
+		uintptr(unsafe.Pointer(sd)), uintptr(sd.Length()), windows.DACL_SECURITY_INFORMATION)
+	if r != 0 {
+		return fmt.Errorf("NCryptSetProperty: %w", windows.Errno(r))
+	}
+
+	return nil
+}
+
+func encoded(ctx *windows.CertContext) []byte {
+	return bytes.Clone(unsafe.Slice(ctx.EncodedCert, ctx.Length))
+}
@@ -0,0 +1,67 @@
+// Package deploytest provides the helpers of the tests of the deployers.
+package deploytest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/stretchr/testify/require"
+)
+
+// NotAfter the expiration date of the certificates created by NewResource.
+var NotAfter = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// NewResource creates a certificate resource: a certificate for the domain (bundled with the issuer), the issuer, and the private key.
+func NewResource(t *testing.T, domain string) *certificate.Resource {
+	t.Helper()
+
+	issuerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	issuerTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             NotAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              NotAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	issuerDER, err := x509.CreateCertificate(rand.Reader, issuerTemplate, issuerTemplate, issuerKey.Public(), issuerKey)
+	require.NoError(t, err)
+
+	issuer, err := x509.ParseCertificate(issuerDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: domain},
+		DNSNames:     []string{domain},
+		NotBefore:    NotAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     NotAfter,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, issuer, key.Public(), issuerKey)
+	require.NoError(t, err)
+
+	issuerPEM := certcrypto.PEMEncode(certcrypto.DERCertificateBytes(issuerDER))
+
+	return &certificate.Resource{
+		Domain:            domain,
+		Certificate:       append(certcrypto.PEMEncode(certcrypto.DERCertificateBytes(certDER)), issuerPEM...),
+		IssuerCertificate: issuerPEM,
+		PrivateKey:        certcrypto.PEMEncode(key),
+	}
+}
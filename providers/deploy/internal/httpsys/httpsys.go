@@ -0,0 +1,57 @@
+// Package httpsys updates the SSL bindings of HTTP.sys (used by IIS) on Windows.
+package httpsys
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// ErrNotSupported is returned on the platforms without HTTP.sys.
+var ErrNotSupported = errors.New("the HTTP.sys bindings are only supported on Windows")
+
+// Binding a SSL binding of HTTP.sys.
+type Binding struct {
+	// Host the hostname of a SNI binding (empty for an IP binding).
+	Host string
+
+	// Addr the IP address and the port of an IP binding, only the port is used by a SNI binding.
+	Addr netip.AddrPort
+}
+
+// ParseBinding parses a binding: `ip:port` (`*:port` for all the addresses) or `hostname:port` (SNI).
+func ParseBinding(raw string) (Binding, error) {
+	host, rawPort, err := net.SplitHostPort(strings.TrimSpace(raw))
+	if err != nil {
+		return Binding{}, fmt.Errorf("binding %q: %w", raw, err)
+	}
+
+	port, err := strconv.ParseUint(rawPort, 10, 16)
+	if err != nil || port == 0 {
+		return Binding{}, fmt.Errorf("binding %q: invalid port", raw)
+	}
+
+	if host == "" || host == "*" {
+		return Binding{Addr: netip.AddrPortFrom(netip.IPv4Unspecified(), uint16(port))}, nil
+	}
+
+	if addr, errP := netip.ParseAddr(host); errP == nil {
+		return Binding{Addr: netip.AddrPortFrom(addr, uint16(port))}, nil
+	}
+
+	return Binding{
+		Host: strings.ToLower(host),
+		Addr: netip.AddrPortFrom(netip.IPv4Unspecified(), uint16(port)),
+	}, nil
+}
+
+func (b Binding) String() string {
+	if b.Host != "" {
+		return net.JoinHostPort(b.Host, strconv.Itoa(int(b.Addr.Port())))
+	}
+
+	return b.Addr.String()
+}
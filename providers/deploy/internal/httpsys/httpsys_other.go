@@ -0,0 +1,8 @@
+//go:build !windows
+
+package httpsys
+
+// Update sets the certificate of the binding, the binding is created if needed.
+func Update(_ Binding, _ []byte, _ string) error {
+	return ErrNotSupported
+}
@@ -0,0 +1,87 @@
+package httpsys
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBinding(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		raw      string
+		expected Binding
+	}{
+		{
+			desc:     "IPv4",
+			raw:      "192.0.2.1:443",
+			expected: Binding{Addr: netip.MustParseAddrPort("192.0.2.1:443")},
+		},
+		{
+			desc:     "IPv6",
+			raw:      "[2001:db8::1]:8443",
+			expected: Binding{Addr: netip.MustParseAddrPort("[2001:db8::1]:8443")},
+		},
+		{
+			desc:     "all the addresses",
+			raw:      "*:443",
+			expected: Binding{Addr: netip.MustParseAddrPort("0.0.0.0:443")},
+		},
+		{
+			desc:     "SNI",
+			raw:      " WWW.example.com:443",
+			expected: Binding{Host: "www.example.com", Addr: netip.MustParseAddrPort("0.0.0.0:443")},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			binding, err := ParseBinding(test.raw)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, binding)
+		})
+	}
+}
+
+func TestParseBinding_error(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		raw      string
+		expected string
+	}{
+		{
+			desc:     "missing port",
+			raw:      "example.com",
+			expected: `binding "example.com": address example.com: missing port in address`,
+		},
+		{
+			desc:     "invalid port",
+			raw:      "example.com:https",
+			expected: `binding "example.com:https": invalid port`,
+		},
+		{
+			desc:     "port zero",
+			raw:      "*:0",
+			expected: `binding "*:0": invalid port`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseBinding(test.raw)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestBinding_String(t *testing.T) {
+	assert.Equal(t, "www.example.com:443", Binding{Host: "www.example.com", Addr: netip.MustParseAddrPort("0.0.0.0:443")}.String())
+	assert.Equal(t, "[2001:db8::1]:443", Binding{Addr: netip.MustParseAddrPort("[2001:db8::1]:443")}.String())
+}
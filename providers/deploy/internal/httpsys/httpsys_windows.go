@@ -0,0 +1,243 @@
+//go:build windows
+
+package httpsys
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	modhttpapi = windows.NewLazySystemDLL("httpapi.dll")
+
+	procHTTPInitialize                 = modhttpapi.NewProc("HttpInitialize")
+	procHTTPTerminate                  = modhttpapi.NewProc("HttpTerminate")
+	procHTTPQueryServiceConfiguration  = modhttpapi.NewProc("HttpQueryServiceConfiguration")
+	procHTTPSetServiceConfiguration    = modhttpapi.NewProc("HttpSetServiceConfiguration")
+	procHTTPDeleteServiceConfiguration = modhttpapi.NewProc("HttpDeleteServiceConfiguration")
+)
+
+const (
+	// HTTPAPI_VERSION 1.0, passed by value.
+	httpAPIVersion1 = 1
+
+	httpInitializeConfig = 0x2
+
+	httpServiceConfigSSLCertInfo    = 1
+	httpServiceConfigSslSniCertInfo = 7
+
+	httpServiceConfigQueryExact = 0
+)
+
+// The application ID of IIS, used by the new bindings.
+var iisAppID = windows.GUID{Data1: 0x4dc3e181, Data2: 0xe14b, Data3: 0x4a21, Data4: [8]byte{0xb0, 0x22, 0x59, 0xfc, 0x66, 0x9b, 0x09, 0x14}}
+
+// sockaddrStorage SOCKADDR_STORAGE.
+type sockaddrStorage struct {
+	_   [0]uint64
+	raw [128]byte
+}
+
+// sslParam HTTP_SERVICE_CONFIG_SSL_PARAM.
+type sslParam struct {
+	SslHashLength                        uint32
+	SslHash                              *byte
+	AppID                                windows.GUID
+	SslCertStoreName                     *uint16
+	DefaultCertCheckMode                 uint32
+	DefaultRevocationFreshnessTime       uint32
+	DefaultRevocationURLRetrievalTimeout uint32
+	DefaultSslCtlIdentifier              *uint16
+	DefaultSslCtlStoreName               *uint16
+	DefaultFlags                         uint32
+}
+
+// sslSet HTTP_SERVICE_CONFIG_SSL_SET.
+type sslSet struct {
+	IPPort *sockaddrStorage
+	Param  sslParam
+}
+
+// sslQuery HTTP_SERVICE_CONFIG_SSL_QUERY.
+type sslQuery struct {
+	QueryDesc uint32
+	IPPort    *sockaddrStorage
+	Token     uint32
+}
+
+// sniKey HTTP_SERVICE_CONFIG_SSL_SNI_KEY.
+type sniKey struct {
+	IPPort sockaddrStorage
+	Host   *uint16
+}
+
+// sniSet HTTP_SERVICE_CONFIG_SSL_SNI_SET.
+type sniSet struct {
+	Key   sniKey
+	Param sslParam
+}
+
+// sniQuery HTTP_SERVICE_CONFIG_SSL_SNI_QUERY.
+type sniQuery struct {
+	QueryDesc uint32
+	Key       sniKey
+	Token     uint32
+}
+
+// Update sets the certificate of the binding, the binding is created if needed.
+// The other parameters of an existing binding (application ID, client certificates, etc.) are kept.
+func Update(b Binding, hash []byte, storeName string) error {
+	if len(hash) == 0 {
+		return errors.New("empty certificate hash")
+	}
+
+	r, _, _ := procHTTPInitialize.Call(httpAPIVersion1, httpInitializeConfig, 0)
+	if r != 0 {
+		return fmt.Errorf("HttpInitialize: %w", windows.Errno(r))
+	}
+
+	defer func() { _, _, _ = procHTTPTerminate.Call(httpInitializeConfig, 0) }()
+
+	store, err := windows.UTF16PtrFromString(storeName)
+	if err != nil {
+		return err
+	}
+
+	addr := sockaddr(b)
+
+	if b.Host != "" {
+		host, errH := windows.UTF16PtrFromString(b.Host)
+		if errH != nil {
+			return errH
+		}
+
+		key := sniKey{IPPort: *addr, Host: host}
+
+		query := sniQuery{QueryDesc: httpServiceConfigQueryExact, Key: key}
+
+		buf, errQ := queryConfiguration(httpServiceConfigSslSniCertInfo, unsafe.Pointer(&query), unsafe.Sizeof(query))
+		if errQ != nil {
+			return errQ
+		}
+
+		set := &sniSet{Key: key}
+
+		var previous *sniSet
+		if buf != nil {
+			previous = (*sniSet)(unsafe.Pointer(&buf[0]))
+			set.Param = previous.Param
+		}
+
+		return replace(httpServiceConfigSslSniCertInfo, unsafe.Pointer(set), unsafe.Pointer(previous), unsafe.Sizeof(*set),
+			&set.Param, hash, store)
+	}
+
+	query := sslQuery{QueryDesc: httpServiceConfigQueryExact, IPPort: addr}
+
+	buf, err := queryConfiguration(httpServiceConfigSSLCertInfo, unsafe.Pointer(&query), unsafe.Sizeof(query))
+	if err != nil {
+		return err
+	}
+
+	set := &sslSet{IPPort: addr}
+
+	var previous *sslSet
+	if buf != nil {
+		previous = (*sslSet)(unsafe.Pointer(&buf[0]))
+		set.Param = previous.Param
+	}
+
+	return replace(httpServiceConfigSSLCertInfo, unsafe.Pointer(set), unsafe.Pointer(previous), unsafe.Sizeof(*set),
+		&set.Param, hash, store)
+}
+
+// replace deletes the previous binding (if any), and creates the binding with the new certificate.
+// The previous binding is restored if the creation fails.
+func replace(configID uintptr, set, previous unsafe.Pointer, size uintptr, param *sslParam, hash []byte, store *uint16) error {
+	if previous == nil {
+		param.AppID = iisAppID
+	}
+
+	param.SslHashLength = uint32(len(hash))
+	param.SslHash = &hash[0]
+	param.SslCertStoreName = store
+
+	if previous != nil {
+		r, _, _ := procHTTPDeleteServiceConfiguration.Call(0, configID, uintptr(previous), size, 0)
+		if r != 0 {
+			return fmt.Errorf("HttpDeleteServiceConfiguration: %w", windows.Errno(r))
+		}
+	}
+
+	r, _, _ := procHTTPSetServiceConfiguration.Call(0, configID, uintptr(set), size, 0)
+	if r == 0 {
+		return nil
+	}
+
+	err := fmt.Errorf("HttpSetServiceConfiguration: %w", windows.Errno(r))
+
+	if previous != nil {
+		r, _, _ = procHTTPSetServiceConfiguration.Call(0, configID, uintptr(previous), size, 0)
+		if r != 0 {
+			return errors.Join(err, fmt.Errorf("restore the previous binding: %w", windows.Errno(r)))
+		}
+	}
+
+	return err
+}
+
+// queryConfiguration returns the raw configuration of a binding, or nil if the binding doesn't exist.
+func queryConfiguration(configID uintptr, input unsafe.Pointer, inputSize uintptr) ([]byte, error) {
+	var size uint32
+
+	r, _, _ := procHTTPQueryServiceConfiguration.Call(0, configID, uintptr(input), inputSize, 0, 0, uintptr(unsafe.Pointer(&size)), 0)
+
+	switch windows.Errno(r) {
+	case windows.ERROR_FILE_NOT_FOUND:
+		return nil, nil
+	case windows.ERROR_INSUFFICIENT_BUFFER:
+	default:
+		return nil, fmt.Errorf("HttpQueryServiceConfiguration: %w", windows.Errno(r))
+	}
+
+	// The buffer is aligned on 8 bytes, like the structures it contains.
+	aligned := make([]uint64, (size+7)/8)
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(&aligned[0])), size)
+
+	r, _, _ = procHTTPQueryServiceConfiguration.Call(0, configID, uintptr(input), inputSize,
+		uintptr(unsafe.Pointer(&buf[0])), uintptr(size), uintptr(unsafe.Pointer(&size)), 0)
+	if r != 0 {
+		return nil, fmt.Errorf("HttpQueryServiceConfiguration: %w", windows.Errno(r))
+	}
+
+	return buf, nil
+}
+
+func sockaddr(b Binding) *sockaddrStorage {
+	sa := &sockaddrStorage{}
+
+	addr := b.Addr.Addr().Unmap()
+	port := b.Addr.Port()
+
+	// The port is in network byte order.
+	port = port<<8 | port>>8
+
+	if addr.Is4() {
+		raw := (*windows.RawSockaddrInet4)(unsafe.Pointer(&sa.raw[0]))
+		raw.Family = windows.AF_INET
+		raw.Port = port
+		raw.Addr = addr.As4()
+
+		return sa
+	}
+
+	raw := (*windows.RawSockaddrInet6)(unsafe.Pointer(&sa.raw[0]))
+	raw.Family = windows.AF_INET6
+	raw.Port = port
+	raw.Addr = addr.As16()
+
+	return sa
+}
@@ -0,0 +1,88 @@
+// Package material extracts the PEM encoded certificate material of a certificate resource.
+package material
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Material the PEM encoded certificate material.
+type Material struct {
+	Domain string
+
+	// Leaf the certificate.
+	Leaf *x509.Certificate
+
+	// Certificate the certificate only.
+	Certificate []byte
+	// Chain the issuers.
+	Chain []byte
+	// FullChain the certificate followed by the issuers.
+	FullChain []byte
+
+	PrivateKey []byte
+}
+
+// FromResource extracts the material from the resource.
+// The certificate of the resource can be bundled with the issuers or not.
+func FromResource(res *certificate.Resource) (*Material, error) {
+	if len(res.PrivateKey) == 0 {
+		return nil, errors.New("the private key is missing (certificate obtained from a CSR)")
+	}
+
+	certs, err := certcrypto.ParsePEMBundle(res.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate: %w", err)
+	}
+
+	leaf := certcrypto.PEMEncode(certcrypto.DERCertificateBytes(certs[0].Raw))
+
+	var chain []byte
+
+	if len(certs) > 1 {
+		for _, cert := range certs[1:] {
+			chain = append(chain, certcrypto.PEMEncode(certcrypto.DERCertificateBytes(cert.Raw))...)
+		}
+	} else {
+		chain = res.IssuerCertificate
+	}
+
+	return &Material{
+		Domain:      res.Domain,
+		Leaf:        certs[0],
+		Certificate: leaf,
+		Chain:       chain,
+		FullChain:   append(append([]byte{}, leaf...), chain...),
+		PrivateKey:  res.PrivateKey,
+	}, nil
+}
+
+// PKCS12 returns the private key, the certificate, and the issuers as a PKCS#12 (PFX) archive.
+// The legacy encryption (3DES) is used: it's supported by all the versions of Windows and macOS.
+func (m *Material) PKCS12(password string) ([]byte, error) {
+	key, err := certcrypto.ParsePEMPrivateKey(m.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	var issuers []*x509.Certificate
+
+	if len(m.Chain) > 0 {
+		issuers, err = certcrypto.ParsePEMBundle(m.Chain)
+		if err != nil {
+			return nil, fmt.Errorf("parse issuers: %w", err)
+		}
+	}
+
+	pfx, err := pkcs12.LegacyDES.Encode(key, m.Leaf, issuers, password)
+	if err != nil {
+		return nil, fmt.Errorf("encode PKCS#12: %w", err)
+	}
+
+	return pfx, nil
+}
@@ -0,0 +1,67 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestFromResource(t *testing.T) {
+	res := deploytest.NewResource(t, "example.com")
+
+	m, err := FromResource(res)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", m.Leaf.Subject.CommonName)
+	assert.Equal(t, res.IssuerCertificate, m.Chain)
+	assert.Equal(t, res.Certificate, m.FullChain)
+	assert.Equal(t, res.PrivateKey, m.PrivateKey)
+}
+
+func TestFromResource_notBundled(t *testing.T) {
+	res := deploytest.NewResource(t, "example.com")
+
+	bundled, err := FromResource(res)
+	require.NoError(t, err)
+
+	res.Certificate = bundled.Certificate
+
+	m, err := FromResource(res)
+	require.NoError(t, err)
+
+	assert.Equal(t, res.IssuerCertificate, m.Chain)
+	assert.Equal(t, bundled.FullChain, m.FullChain)
+}
+
+func TestFromResource_noPrivateKey(t *testing.T) {
+	res := deploytest.NewResource(t, "example.com")
+	res.PrivateKey = nil
+
+	_, err := FromResource(res)
+	require.EqualError(t, err, "the private key is missing (certificate obtained from a CSR)")
+
+	_, err = FromResource(&certificate.Resource{PrivateKey: []byte("key")})
+	require.Error(t, err)
+}
+
+func TestMaterial_PKCS12(t *testing.T) {
+	res := deploytest.NewResource(t, "example.com")
+
+	m, err := FromResource(res)
+	require.NoError(t, err)
+
+	pfx, err := m.PKCS12("secret")
+	require.NoError(t, err)
+
+	key, leaf, issuers, err := pkcs12.DecodeChain(pfx, "secret")
+	require.NoError(t, err)
+
+	assert.NotNil(t, key)
+	assert.Equal(t, m.Leaf.Raw, leaf.Raw)
+	require.Len(t, issuers, 1)
+	assert.Equal(t, "Test CA", issuers[0].Subject.CommonName)
+}
@@ -0,0 +1,44 @@
+// Package tlsutil provides the TLS helpers of the deployers.
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// NewConfig returns a TLS configuration trusting only the CA certificates of the file (PEM).
+func NewConfig(caFile string) (*tls.Config, error) {
+	raw, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(raw) {
+		return nil, fmt.Errorf("CA file: no certificate found in %s", caFile)
+	}
+
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, nil
+}
+
+// WithCA returns a copy of the HTTP client trusting only the CA certificates of the file (PEM).
+func WithCA(client *http.Client, caFile string) (*http.Client, error) {
+	config, err := NewConfig(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("unsupported default transport")
+	}
+
+	transport = transport.Clone()
+	transport.TLSClientConfig = config
+
+	return &http.Client{Timeout: client.Timeout, Transport: transport}, nil
+}
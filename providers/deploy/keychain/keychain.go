@@ -0,0 +1,203 @@
+// Package keychain implements a deployer importing the certificates in a macOS keychain.
+package keychain
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "KEYCHAIN_"
+
+	EnvPath           = envNamespace + "PATH"
+	EnvTrustedApps    = envNamespace + "TRUSTED_APPS"
+	EnvAllowAllApps   = envNamespace + "ALLOW_ALL_APPS"
+	EnvNonExtractable = envNamespace + "NON_EXTRACTABLE"
+	EnvTrustIssuer    = envNamespace + "TRUST_ISSUER"
+)
+
+const defaultPath = "/Library/Keychains/System.keychain"
+
+// Trust settings domains of the issuer.
+const (
+	// TrustUser adds the issuer to the trust settings of the user.
+	TrustUser = "user"
+	// TrustAdmin adds the issuer to the trust settings of the administrator (all the users).
+	TrustAdmin = "admin"
+)
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	// Path the keychain.
+	Path string
+
+	// TrustedApps the applications allowed to use the private key without confirmation (access control list).
+	TrustedApps []string
+	// AllowAllApps allows all the applications to use the private key without confirmation.
+	AllowAllApps bool
+
+	// NonExtractable prevents the export of the private key from the keychain.
+	NonExtractable bool
+
+	// TrustIssuer trusts the root issuer of the certificate (e.g. a development CA): TrustUser or TrustAdmin (optional).
+	TrustIssuer string
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Path:           env.GetOrDefaultString(EnvPath, defaultPath),
+		AllowAllApps:   env.GetOrDefaultBool(EnvAllowAllApps, false),
+		NonExtractable: env.GetOrDefaultBool(EnvNonExtractable, true),
+		TrustIssuer:    env.GetOrFile(EnvTrustIssuer),
+	}
+}
+
+// Deployer imports the certificates in a macOS keychain (`security import`).
+type Deployer struct {
+	config *Config
+
+	run func(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// NewDeployer returns a Deployer instance configured for a macOS keychain.
+func NewDeployer() (*Deployer, error) {
+	config := NewDefaultConfig()
+
+	if apps := env.GetOrFile(EnvTrustedApps); apps != "" {
+		config.TrustedApps = strings.Split(apps, ",")
+	}
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for a macOS keychain.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("keychain: the configuration of the deployer is nil")
+	}
+
+	if config.Path == "" {
+		return nil, errors.New("keychain: the path of the keychain is missing")
+	}
+
+	switch config.TrustIssuer {
+	case "", TrustUser, TrustAdmin:
+	default:
+		return nil, fmt.Errorf("keychain: invalid trust settings domain: %q", config.TrustIssuer)
+	}
+
+	return &Deployer{config: config, run: run}, nil
+}
+
+// Deploy imports the identity (the certificate, the issuers, and the private key) in the keychain,
+// then trusts the root issuer if needed.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("keychain: %w", err)
+	}
+
+	// The PKCS#12 archive is only used to transfer the private key to the keychain.
+	password := rand.Text()
+
+	pfx, err := m.PKCS12(password)
+	if err != nil {
+		return fmt.Errorf("keychain: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "lego-keychain")
+	if err != nil {
+		return fmt.Errorf("keychain: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	pfxFile := filepath.Join(dir, "identity.p12")
+
+	err = os.WriteFile(pfxFile, pfx, 0o600)
+	if err != nil {
+		return fmt.Errorf("keychain: %w", err)
+	}
+
+	args := []string{"import", pfxFile, "-k", d.config.Path, "-f", "pkcs12", "-P", password}
+
+	if d.config.NonExtractable {
+		args = append(args, "-x")
+	}
+
+	if d.config.AllowAllApps {
+		args = append(args, "-A")
+	}
+
+	for _, app := range d.config.TrustedApps {
+		args = append(args, "-T", strings.TrimSpace(app))
+	}
+
+	_, err = d.run(ctx, "security", args...)
+	if err != nil {
+		return fmt.Errorf("keychain: import identity: %w", err)
+	}
+
+	if d.config.TrustIssuer == "" || len(m.Chain) == 0 {
+		return nil
+	}
+
+	err = d.trustIssuer(ctx, dir, m.Chain)
+	if err != nil {
+		return fmt.Errorf("keychain: trust issuer: %w", err)
+	}
+
+	return nil
+}
+
+// trustIssuer adds the root issuer (the last certificate of the chain) to the trust settings.
+func (d *Deployer) trustIssuer(ctx context.Context, dir string, chain []byte) error {
+	issuers, err := certcrypto.ParsePEMBundle(chain)
+	if err != nil {
+		return err
+	}
+
+	root := issuers[len(issuers)-1]
+
+	rootFile := filepath.Join(dir, "issuer.pem")
+
+	err = os.WriteFile(rootFile, certcrypto.PEMEncode(certcrypto.DERCertificateBytes(root.Raw)), 0o600)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"add-trusted-cert"}
+
+	if d.config.TrustIssuer == TrustAdmin {
+		args = append(args, "-d")
+	}
+
+	args = append(args, "-r", "trustRoot", "-k", d.config.Path, rootFile)
+
+	_, err = d.run(ctx, "security", args...)
+
+	return err
+}
+
+func run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w: %s", name, args[0], err, bytes.TrimSpace(output))
+	}
+
+	return output, nil
+}
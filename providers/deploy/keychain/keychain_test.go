@@ -0,0 +1,160 @@
+package keychain
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var envTest = tester.NewEnvTest(EnvPath, EnvTrustedApps, EnvAllowAllApps, EnvNonExtractable, EnvTrustIssuer)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvPath:        "/Users/me/Library/Keychains/login.keychain-db",
+				EnvTrustedApps: "/usr/sbin/httpd,/usr/local/bin/nginx",
+				EnvTrustIssuer: TrustUser,
+			},
+		},
+		{
+			desc:    "default values",
+			envVars: map[string]string{},
+		},
+		{
+			desc: "invalid trust settings domain",
+			envVars: map[string]string{
+				EnvTrustIssuer: "system",
+			},
+			expected: `keychain: invalid trust settings domain: "system"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+type call struct {
+	name string
+	args []string
+}
+
+// fakeRunner records the commands, and checks the files of the commands.
+type fakeRunner struct {
+	t     *testing.T
+	calls []call
+	err   error
+}
+
+func (f *fakeRunner) run(_ context.Context, name string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, call{name: name, args: args})
+
+	switch args[0] {
+	case "import":
+		raw, err := os.ReadFile(args[1])
+		require.NoError(f.t, err)
+
+		// The password follows -P.
+		_, leaf, _, err := pkcs12.DecodeChain(raw, args[7])
+		require.NoError(f.t, err)
+
+		assert.Equal(f.t, "example.com", leaf.Subject.CommonName)
+
+	case "add-trusted-cert":
+		raw, err := os.ReadFile(args[len(args)-1])
+		require.NoError(f.t, err)
+
+		issuer, err := certcrypto.ParsePEMCertificate(raw)
+		require.NoError(f.t, err)
+
+		assert.Equal(f.t, "Test CA", issuer.Subject.CommonName)
+	}
+
+	return nil, f.err
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	d, err := NewDeployerConfig(&Config{Path: "/Library/Keychains/System.keychain", NonExtractable: true})
+	require.NoError(t, err)
+
+	runner := &fakeRunner{t: t}
+	d.run = runner.run
+
+	err = d.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+
+	require.Len(t, runner.calls, 1)
+
+	args := runner.calls[0].args
+	assert.Equal(t, "security", runner.calls[0].name)
+	assert.Equal(t, []string{"import", "-k", "/Library/Keychains/System.keychain", "-f", "pkcs12", "-P"},
+		[]string{args[0], args[2], args[3], args[4], args[5], args[6]})
+	assert.Equal(t, []string{"-x"}, args[8:])
+
+	// The temporary files are removed.
+	assert.NoFileExists(t, args[1])
+}
+
+func TestDeployer_Deploy_trust(t *testing.T) {
+	d, err := NewDeployerConfig(&Config{
+		Path:         "/Users/me/Library/Keychains/login.keychain-db",
+		TrustedApps:  []string{"/usr/sbin/httpd", " /usr/local/bin/nginx"},
+		AllowAllApps: false,
+		TrustIssuer:  TrustAdmin,
+	})
+	require.NoError(t, err)
+
+	runner := &fakeRunner{t: t}
+	d.run = runner.run
+
+	err = d.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+
+	require.Len(t, runner.calls, 2)
+
+	assert.Equal(t, []string{"-T", "/usr/sbin/httpd", "-T", "/usr/local/bin/nginx"}, runner.calls[0].args[8:])
+
+	args := runner.calls[1].args
+	assert.Equal(t, []string{"add-trusted-cert", "-d", "-r", "trustRoot", "-k", "/Users/me/Library/Keychains/login.keychain-db"}, args[:len(args)-1])
+}
+
+func TestDeployer_Deploy_error(t *testing.T) {
+	d, err := NewDeployerConfig(&Config{Path: "/Library/Keychains/System.keychain"})
+	require.NoError(t, err)
+
+	runner := &fakeRunner{t: t, err: errors.New("security import: exit status 1: The specified keychain could not be found.")}
+	d.run = runner.run
+
+	err = d.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, "keychain: import identity: security import: exit status 1: The specified keychain could not be found.")
+}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// FieldManager the field manager of the server-side apply.
+const FieldManager = "lego"
+
+// RestartedAtAnnotation the annotation of the pod template used by `kubectl rollout restart`.
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// Client the Kubernetes API client.
+type Client struct {
+	token string
+
+	baseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(baseURL, token string) (*Client, error) {
+	endpoint, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse URL: %w", err)
+	}
+
+	return &Client{
+		token:      token,
+		baseURL:    endpoint,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// ApplySecret creates or updates the Secret (server-side apply).
+func (c *Client) ApplySecret(ctx context.Context, secret *Secret) error {
+	endpoint := c.baseURL.JoinPath("api", "v1", "namespaces", secret.Metadata.Namespace, "secrets", secret.Metadata.Name)
+
+	query := endpoint.Query()
+	query.Set("fieldManager", FieldManager)
+	query.Set("force", "true")
+	endpoint.RawQuery = query.Encode()
+
+	return c.patch(ctx, endpoint, "application/apply-patch+yaml", secret)
+}
+
+// RestartDeployment triggers the rollout of the Deployment, like `kubectl rollout restart`.
+func (c *Client) RestartDeployment(ctx context.Context, namespace, name string, at time.Time) error {
+	endpoint := c.baseURL.JoinPath("apis", "apps", "v1", "namespaces", namespace, "deployments", name)
+
+	patch := map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]string{
+						RestartedAtAnnotation: at.Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+
+	return c.patch(ctx, endpoint, "application/strategic-merge-patch+json", patch)
+}
+
+func (c *Client) patch(ctx context.Context, endpoint *url.URL, contentType string, payload any) error {
+	buf := new(bytes.Buffer)
+
+	err := json.NewEncoder(buf).Encode(payload)
+	if err != nil {
+		return fmt.Errorf("failed to create request JSON body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, endpoint.String(), buf)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", contentType)
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to communicate with the API server: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		return parseError(resp)
+	}
+
+	return nil
+}
+
+func parseError(resp *http.Response) error {
+	raw, _ := io.ReadAll(resp.Body)
+
+	var status Status
+
+	err := json.Unmarshal(raw, &status)
+	if err != nil || status.Message == "" {
+		return fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, string(raw))
+	}
+
+	return &status
+}
@@ -0,0 +1,33 @@
+package internal
+
+import "fmt"
+
+// ObjectMeta the metadata of a Kubernetes object.
+type ObjectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Secret a Kubernetes Secret.
+// The values of the data are base64 encoded by the JSON encoding.
+type Secret struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   ObjectMeta        `json:"metadata"`
+	Type       string            `json:"type"`
+	Data       map[string][]byte `json:"data"`
+}
+
+// Status the Kubernetes API errors.
+type Status struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+	Code    int    `json:"code"`
+}
+
+func (s *Status) Error() string {
+	return fmt.Sprintf("%d: %s: %s", s.Code, s.Reason, s.Message)
+}
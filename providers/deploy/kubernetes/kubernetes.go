@@ -0,0 +1,263 @@
+// Package kubernetes implements a deployer writing the certificates in Kubernetes TLS Secrets.
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/tlsutil"
+	"github.com/go-acme/lego/v4/providers/deploy/kubernetes/internal"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "K8S_"
+
+	EnvAPIURL      = envNamespace + "API_URL"
+	EnvToken       = envNamespace + "TOKEN"
+	EnvCAFile      = envNamespace + "CA_FILE"
+	EnvNamespace   = envNamespace + "NAMESPACE"
+	EnvSecretName  = envNamespace + "SECRET_NAME"
+	EnvDeployments = envNamespace + "DEPLOYMENTS"
+
+	EnvHTTPTimeout = envNamespace + "HTTP_TIMEOUT"
+)
+
+// The files of the service account, used inside a cluster.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+const (
+	defaultNamespace  = "default"
+	defaultSecretName = "{{ .Name }}-tls"
+)
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	APIURL string
+	Token  string
+	CAFile string
+
+	// Namespace the template of the namespace of the Secret.
+	Namespace string
+	// SecretName the template of the name of the Secret.
+	SecretName string
+
+	// Deployments the Deployments (in the namespace of the Secret) to roll out after the update of the Secret.
+	Deployments []string
+
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Namespace:  env.GetOrDefaultString(EnvNamespace, defaultNamespace),
+		SecretName: env.GetOrDefaultString(EnvSecretName, defaultSecretName),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		},
+	}
+}
+
+// TemplateData the data of the templates of the namespace and the name of the Secret.
+type TemplateData struct {
+	// Domain the main domain of the certificate.
+	Domain string
+	// Name the main domain as a valid name of Kubernetes object (e.g. "*.example.com" -> "wildcard-example-com").
+	Name string
+}
+
+// Deployer writes the certificates in Kubernetes TLS Secrets.
+type Deployer struct {
+	config *Config
+	client *internal.Client
+
+	namespace  *template.Template
+	secretName *template.Template
+}
+
+// NewDeployer returns a Deployer instance configured for the Kubernetes API server.
+// Inside a cluster, the API server and the credentials of the service account are used by default.
+func NewDeployer() (*Deployer, error) {
+	config := NewDefaultConfig()
+	config.APIURL = env.GetOrFile(EnvAPIURL)
+	config.Token = env.GetOrFile(EnvToken)
+	config.CAFile = env.GetOrFile(EnvCAFile)
+
+	if deployments := env.GetOrFile(EnvDeployments); deployments != "" {
+		config.Deployments = strings.Split(deployments, ",")
+	}
+
+	if config.APIURL == "" {
+		err := inClusterConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes: %w", err)
+		}
+	}
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for the Kubernetes API server.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("kubernetes: the configuration of the deployer is nil")
+	}
+
+	redact.Register(config.Token)
+
+	if config.APIURL == "" {
+		return nil, errors.New("kubernetes: the API server URL is missing")
+	}
+
+	namespace, err := template.New("namespace").Parse(config.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: namespace: %w", err)
+	}
+
+	secretName, err := template.New("secretName").Parse(config.SecretName)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: secret name: %w", err)
+	}
+
+	client, err := internal.NewClient(config.APIURL, config.Token)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: %w", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.CAFile != "" {
+		client.HTTPClient, err = tlsutil.WithCA(client.HTTPClient, config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes: %w", err)
+		}
+	}
+
+	return &Deployer{
+		config:     config,
+		client:     client,
+		namespace:  namespace,
+		secretName: secretName,
+	}, nil
+}
+
+// Deploy creates or updates the Secret, and rolls out the Deployments.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("kubernetes: %w", err)
+	}
+
+	data := TemplateData{Domain: res.Domain, Name: ObjectName(res.Domain)}
+
+	namespace, err := execute(d.namespace, data)
+	if err != nil {
+		return fmt.Errorf("kubernetes: namespace: %w", err)
+	}
+
+	name, err := execute(d.secretName, data)
+	if err != nil {
+		return fmt.Errorf("kubernetes: secret name: %w", err)
+	}
+
+	secret := &internal.Secret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata: internal.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				"lego.go-acme.github.io/domain":    res.Domain,
+				"lego.go-acme.github.io/not-after": m.Leaf.NotAfter.UTC().Format(time.RFC3339),
+			},
+		},
+		Type: "kubernetes.io/tls",
+		Data: map[string][]byte{
+			"tls.crt": m.FullChain,
+			"tls.key": m.PrivateKey,
+		},
+	}
+
+	err = d.client.ApplySecret(ctx, secret)
+	if err != nil {
+		return fmt.Errorf("kubernetes: apply secret %s/%s: %w", namespace, name, err)
+	}
+
+	now := time.Now()
+
+	for _, deployment := range d.config.Deployments {
+		err = d.client.RestartDeployment(ctx, namespace, strings.TrimSpace(deployment), now)
+		if err != nil {
+			return fmt.Errorf("kubernetes: restart deployment %s/%s: %w", namespace, deployment, err)
+		}
+	}
+
+	return nil
+}
+
+// ObjectName returns a valid name of Kubernetes object (RFC 1123 subdomain) from a domain.
+func ObjectName(domain string) string {
+	name := strings.ToLower(domain)
+	name = strings.ReplaceAll(name, "*", "wildcard")
+	name = strings.ReplaceAll(name, ".", "-")
+
+	return strings.Trim(name, "-")
+}
+
+func execute(tmpl *template.Template, data TemplateData) (string, error) {
+	buf := new(bytes.Buffer)
+
+	err := tmpl.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// inClusterConfig defines the API server and the credentials from the environment of a pod.
+func inClusterConfig(config *Config) error {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return fmt.Errorf("not running inside a cluster, the API server URL must be defined (%s)", EnvAPIURL)
+	}
+
+	config.APIURL = "https://" + net.JoinHostPort(host, port)
+
+	if config.Token == "" {
+		token, err := os.ReadFile(serviceAccountDir + "/token")
+		if err != nil {
+			return fmt.Errorf("service account token: %w", err)
+		}
+
+		config.Token = strings.TrimSpace(string(token))
+	}
+
+	if config.CAFile == "" {
+		config.CAFile = serviceAccountDir + "/ca.crt"
+	}
+
+	if os.Getenv(EnvNamespace) == "" {
+		namespace, err := os.ReadFile(serviceAccountDir + "/namespace")
+		if err == nil {
+			config.Namespace = strings.TrimSpace(string(namespace))
+		}
+	}
+
+	return nil
+}
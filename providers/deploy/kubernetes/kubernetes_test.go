@@ -0,0 +1,156 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvAPIURL, EnvToken, EnvCAFile, EnvNamespace, EnvSecretName, EnvDeployments)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvAPIURL:      "https://k8s.example.com:6443",
+				EnvToken:       "secret",
+				EnvDeployments: "web,api",
+			},
+		},
+		{
+			desc: "invalid template",
+			envVars: map[string]string{
+				EnvAPIURL:     "https://k8s.example.com:6443",
+				EnvSecretName: "{{ .Name",
+			},
+			expected: "kubernetes: secret name: template: secretName:1: unclosed action",
+		},
+		{
+			desc: "missing CA file",
+			envVars: map[string]string{
+				EnvAPIURL: "https://k8s.example.com:6443",
+				EnvCAFile: "/does/not/exist.crt",
+			},
+			expected: "kubernetes: CA file: open /does/not/exist.crt: no such file or directory",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDeployerConfig(t *testing.T) {
+	config := NewDefaultConfig()
+
+	_, err := NewDeployerConfig(config)
+	require.EqualError(t, err, "kubernetes: the API server URL is missing")
+}
+
+func setupDeployer(namespace, secretName string, deployments ...string) func(server *httptest.Server) (*Deployer, error) {
+	return func(server *httptest.Server) (*Deployer, error) {
+		config := NewDefaultConfig()
+		config.APIURL = server.URL
+		config.Token = "secret"
+		config.Namespace = namespace
+		config.SecretName = secretName
+		config.Deployments = deployments
+		config.HTTPClient = server.Client()
+
+		return NewDeployerConfig(config)
+	}
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	res := deploytest.NewResource(t, "*.example.com")
+
+	var secret map[string]any
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer("{{ .Name }}", "{{ .Name }}-cert", "web"),
+		servermock.CheckHeader().
+			WithAuthorization("Bearer secret"),
+	).
+		Route("PATCH /api/v1/namespaces/wildcard-example-com/secrets/wildcard-example-com-cert",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				raw, err := io.ReadAll(req.Body)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				err = json.Unmarshal(raw, &secret)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}),
+			servermock.CheckHeader().
+				WithContentType("application/apply-patch+yaml"),
+			servermock.CheckQueryParameter().Strict().
+				With("fieldManager", "lego").
+				With("force", "true"),
+		).
+		Route("PATCH /apis/apps/v1/namespaces/wildcard-example-com/deployments/web", nil,
+			servermock.CheckHeader().
+				WithContentType("application/strategic-merge-patch+json"),
+		).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), res)
+	require.NoError(t, err)
+
+	assert.Equal(t, "kubernetes.io/tls", secret["type"])
+
+	data, ok := secret["data"].(map[string]any)
+	require.True(t, ok)
+
+	assert.Equal(t, base64.StdEncoding.EncodeToString(res.Certificate), data["tls.crt"])
+	assert.Equal(t, base64.StdEncoding.EncodeToString(res.PrivateKey), data["tls.key"])
+}
+
+func TestDeployer_Deploy_error(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer("default", defaultSecretName)).
+		Route("PATCH /api/v1/namespaces/default/secrets/example-com-tls",
+			servermock.RawStringResponse(`{"kind":"Status","status":"Failure","message":"secrets is forbidden","reason":"Forbidden","code":403}`).
+				WithStatusCode(http.StatusForbidden)).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, "kubernetes: apply secret default/example-com-tls: 403: Forbidden: secrets is forbidden")
+}
+
+func TestObjectName(t *testing.T) {
+	assert.Equal(t, "example-com", ObjectName("example.com"))
+	assert.Equal(t, "wildcard-example-com", ObjectName("*.example.com"))
+	assert.Equal(t, "www-example-com", ObjectName("WWW.Example.com"))
+}
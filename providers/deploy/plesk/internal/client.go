@@ -0,0 +1,180 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PropertyCertificateName the hosting property of the certificate of a site.
+const PropertyCertificateName = "certificate_name"
+
+// Client the Plesk API client.
+type Client struct {
+	apiKey string
+
+	baseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(baseURL *url.URL, apiKey string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		baseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// InstallCertificate adds the certificate to the pool of the site.
+// https://docs.plesk.com/en-US/obsidian/api-rpc/about-xml-api/reference/managing-ssltls-certificates/installing-certificates.36218/
+func (c *Client) InstallCertificate(ctx context.Context, site, name string, key, cert, ca []byte) error {
+	payload := RequestPacket{Certificate: &CertificateRequest{Install: &CertificateInstall{
+		Name: name,
+		Site: site,
+		Content: CertificateContent{
+			Key:  string(key),
+			Cert: string(cert),
+			CA:   string(ca),
+		},
+	}}}
+
+	response, err := c.doRequest(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	return checkResult(response.Certificate.Install)
+}
+
+// RemoveCertificate removes the certificate from the pool of the site.
+// https://docs.plesk.com/en-US/obsidian/api-rpc/about-xml-api/reference/managing-ssltls-certificates/removing-certificates.36220/
+func (c *Client) RemoveCertificate(ctx context.Context, site, name string) error {
+	payload := RequestPacket{Certificate: &CertificateRequest{Remove: &CertificateRemove{
+		Filter: CertificateFilter{Name: name},
+		Site:   site,
+	}}}
+
+	response, err := c.doRequest(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	return checkResult(response.Certificate.Remove)
+}
+
+// GetCertificateName returns the name of the certificate used by the site.
+// https://docs.plesk.com/en-US/obsidian/api-rpc/about-xml-api/reference/managing-sites-domains/getting-information-about-sites.66583/
+func (c *Client) GetCertificateName(ctx context.Context, site string) (string, error) {
+	payload := RequestPacket{Site: &SiteRequest{Get: &SiteGet{
+		Filter:  SiteFilter{Name: site},
+		Dataset: SiteDataset{Hosting: &struct{}{}},
+	}}}
+
+	response, err := c.doRequest(ctx, payload)
+	if err != nil {
+		return "", err
+	}
+
+	err = checkResult(response.Site.Get)
+	if err != nil {
+		return "", err
+	}
+
+	if response.Site.Get.Hosting == nil {
+		return "", nil
+	}
+
+	for _, property := range response.Site.Get.Hosting.Properties {
+		if property.Name == PropertyCertificateName {
+			return property.Value, nil
+		}
+	}
+
+	return "", nil
+}
+
+// SetCertificate sets the certificate used by the site.
+// https://docs.plesk.com/en-US/obsidian/api-rpc/about-xml-api/reference/managing-sites-domains/changing-site-settings.66591/
+func (c *Client) SetCertificate(ctx context.Context, site, name string) error {
+	payload := RequestPacket{Site: &SiteRequest{Set: &SiteSet{
+		Filter: SiteFilter{Name: site},
+		Values: SiteValues{Hosting: Hosting{Properties: []Property{{Name: PropertyCertificateName, Value: name}}}},
+	}}}
+
+	response, err := c.doRequest(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	return checkResult(response.Site.Set)
+}
+
+func (c *Client) doRequest(ctx context.Context, payload RequestPacket) (*ResponsePacket, error) {
+	endpoint := c.baseURL.JoinPath("/enterprise/control/agent.php")
+
+	body := new(bytes.Buffer)
+
+	err := xml.NewEncoder(body).Encode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "text/xml")
+
+	// The secret keys of the REST API (/api/v2/auth/keys) are accepted by the XML API.
+	req.Header.Set("Key", c.apiKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to communicate with Plesk: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	var response ResponsePacket
+
+	err = xml.Unmarshal(raw, &response)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response: %w: %s", err, string(raw))
+	}
+
+	if response.System != nil {
+		return nil, response.System
+	}
+
+	return &response, nil
+}
+
+func checkResult(result *Result) error {
+	if result == nil {
+		return errors.New("unexpected empty result")
+	}
+
+	if result.Status != StatusOK {
+		return result
+	}
+
+	return nil
+}
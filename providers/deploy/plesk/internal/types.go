@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Response status.
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// Request.
+
+type RequestPacket struct {
+	XMLName xml.Name `xml:"packet"`
+
+	Certificate *CertificateRequest `xml:"certificate,omitempty"`
+	Site        *SiteRequest        `xml:"site,omitempty"`
+}
+
+type CertificateRequest struct {
+	Install *CertificateInstall `xml:"install,omitempty"`
+	Remove  *CertificateRemove  `xml:"remove,omitempty"`
+}
+
+type CertificateInstall struct {
+	Name    string             `xml:"name"`
+	Site    string             `xml:"site"`
+	Content CertificateContent `xml:"content"`
+}
+
+type CertificateContent struct {
+	CSR  string `xml:"csr"`
+	Key  string `xml:"pvt"`
+	Cert string `xml:"cert,omitempty"`
+	CA   string `xml:"ca,omitempty"`
+}
+
+type CertificateRemove struct {
+	Filter CertificateFilter `xml:"filter"`
+	Site   string            `xml:"site"`
+}
+
+type CertificateFilter struct {
+	Name string `xml:"name"`
+}
+
+type SiteRequest struct {
+	Get *SiteGet `xml:"get,omitempty"`
+	Set *SiteSet `xml:"set,omitempty"`
+}
+
+type SiteFilter struct {
+	Name string `xml:"name"`
+}
+
+type SiteGet struct {
+	Filter  SiteFilter  `xml:"filter"`
+	Dataset SiteDataset `xml:"dataset"`
+}
+
+type SiteDataset struct {
+	Hosting *struct{} `xml:"hosting"`
+}
+
+type SiteSet struct {
+	Filter SiteFilter `xml:"filter"`
+	Values SiteValues `xml:"values"`
+}
+
+type SiteValues struct {
+	Hosting Hosting `xml:"hosting"`
+}
+
+type Hosting struct {
+	Properties []Property `xml:"vrt_hst>property"`
+}
+
+type Property struct {
+	Name  string `xml:"name"`
+	Value string `xml:"value"`
+}
+
+// Response.
+
+type ResponsePacket struct {
+	XMLName xml.Name `xml:"packet"`
+
+	Certificate struct {
+		Install *Result `xml:"install>result"`
+		Remove  *Result `xml:"remove>result"`
+	} `xml:"certificate"`
+
+	Site struct {
+		Get *Result `xml:"get>result"`
+		Set *Result `xml:"set>result"`
+	} `xml:"site"`
+
+	System *Result `xml:"system"`
+}
+
+type Result struct {
+	Status  string `xml:"status"`
+	ErrCode string `xml:"errcode"`
+	ErrText string `xml:"errtext"`
+
+	Hosting *Hosting `xml:"data>hosting"`
+}
+
+func (r Result) Error() string {
+	return fmt.Sprintf("%s: %s - %s", r.Status, r.ErrCode, r.ErrText)
+}
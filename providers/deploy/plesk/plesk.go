@@ -0,0 +1,170 @@
+// Package plesk implements a deployer installing the certificates on the sites of Plesk.
+package plesk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+	"github.com/go-acme/lego/v4/providers/deploy/plesk/internal"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "PLESK_"
+
+	EnvServerBaseURL = envNamespace + "SERVER_BASE_URL"
+	EnvAPIKey        = envNamespace + "API_KEY"
+	EnvSites         = envNamespace + "SITES"
+	EnvPrune         = envNamespace + "PRUNE"
+
+	EnvHTTPTimeout = envNamespace + "HTTP_TIMEOUT"
+)
+
+// The prefix of the names of the certificates installed by the deployer.
+const namePrefix = "lego "
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	BaseURL string
+	APIKey  string
+
+	// Sites the sites on which the certificate is installed.
+	// Default: the main domain of the certificate (without the wildcard label).
+	Sites []string
+
+	// Prune removes the certificate previously installed by the deployer.
+	Prune bool
+
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Prune: env.GetOrDefaultBool(EnvPrune, true),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		},
+	}
+}
+
+// Deployer installs the certificates on the sites of Plesk.
+// The certificates are managed with the XML API (the REST API has no certificate endpoints),
+// authenticated with a secret key of the REST API.
+type Deployer struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDeployer returns a Deployer instance configured for Plesk.
+func NewDeployer() (*Deployer, error) {
+	values, err := env.Get(EnvServerBaseURL, EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("plesk: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.BaseURL = values[EnvServerBaseURL]
+	config.APIKey = values[EnvAPIKey]
+
+	if sites := env.GetOrFile(EnvSites); sites != "" {
+		config.Sites = strings.Split(sites, ",")
+	}
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for Plesk.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("plesk: the configuration of the deployer is nil")
+	}
+
+	redact.Register(config.APIKey)
+
+	if config.BaseURL == "" {
+		return nil, errors.New("plesk: missing server base URL")
+	}
+
+	baseURL, err := url.Parse(config.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("plesk: failed to parse base URL (%s): %w", config.BaseURL, err)
+	}
+
+	if config.APIKey == "" {
+		return nil, errors.New("plesk: missing API key")
+	}
+
+	client := internal.NewClient(baseURL, config.APIKey)
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &Deployer{config: config, client: client}, nil
+}
+
+// Deploy adds the certificate to the pool of the sites, and uses it for the sites.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("plesk: %w", err)
+	}
+
+	name := namePrefix + res.Domain + " " + m.Leaf.NotBefore.UTC().Format("20060102150405")
+
+	sites := d.config.Sites
+	if len(sites) == 0 {
+		sites = []string{strings.TrimPrefix(res.Domain, "*.")}
+	}
+
+	for _, site := range sites {
+		err = d.deploy(ctx, strings.TrimSpace(site), name, m)
+		if err != nil {
+			return fmt.Errorf("plesk: site %s: %w", site, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Deployer) deploy(ctx context.Context, site, name string, m *material.Material) error {
+	previous, err := d.client.GetCertificateName(ctx, site)
+	if err != nil {
+		return fmt.Errorf("get certificate: %w", err)
+	}
+
+	if previous == name {
+		return nil
+	}
+
+	err = d.client.InstallCertificate(ctx, site, name, m.PrivateKey, m.Certificate, m.Chain)
+	if err != nil {
+		return fmt.Errorf("install certificate: %w", err)
+	}
+
+	err = d.client.SetCertificate(ctx, site, name)
+	if err != nil {
+		return fmt.Errorf("set certificate: %w", err)
+	}
+
+	// Only the certificates installed by the deployer are removed.
+	if d.config.Prune && strings.HasPrefix(previous, namePrefix) {
+		err = d.client.RemoveCertificate(ctx, site, previous)
+		if err != nil {
+			log.Warnf("[%s] plesk: remove certificate %q: %v", m.Domain, previous, err)
+		}
+	}
+
+	return nil
+}
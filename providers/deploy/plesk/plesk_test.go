@@ -0,0 +1,180 @@
+package plesk
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvServerBaseURL, EnvAPIKey, EnvSites, EnvPrune)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvServerBaseURL: "https://plesk.example.com:8443",
+				EnvAPIKey:        "secret",
+			},
+		},
+		{
+			desc: "missing API key",
+			envVars: map[string]string{
+				EnvServerBaseURL: "https://plesk.example.com:8443",
+			},
+			expected: "plesk: some credentials information are missing: PLESK_API_KEY",
+		},
+		{
+			desc: "invalid URL",
+			envVars: map[string]string{
+				EnvServerBaseURL: ":",
+				EnvAPIKey:        "secret",
+			},
+			expected: `plesk: failed to parse base URL (:): parse ":": missing protocol scheme`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupDeployer(server *httptest.Server) (*Deployer, error) {
+	config := NewDefaultConfig()
+	config.BaseURL = server.URL
+	config.APIKey = "secret"
+	config.Prune = true
+	config.HTTPClient = server.Client()
+
+	return NewDeployerConfig(config)
+}
+
+// mockPlesk the XML API: the operations are identified by the content of the packets.
+type mockPlesk struct {
+	current  string
+	requests []string
+	errors   map[string]string
+}
+
+func (m *mockPlesk) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	raw, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	body := string(raw)
+
+	var operation, response string
+
+	switch {
+	case strings.Contains(body, "<certificate><install>"):
+		operation = "install"
+		response = `<packet><certificate><install><result><status>ok</status></result></install></certificate></packet>`
+	case strings.Contains(body, "<certificate><remove>"):
+		operation = "remove"
+		response = `<packet><certificate><remove><result><status>ok</status></result></remove></certificate></packet>`
+	case strings.Contains(body, "<site><get>"):
+		operation = "get"
+		response = `<packet><site><get><result><status>ok</status><data><hosting><vrt_hst>
+<property><name>ssl</name><value>true</value></property>
+<property><name>certificate_name</name><value>` + m.current + `</value></property>
+</vrt_hst></hosting></data></result></get></site></packet>`
+	case strings.Contains(body, "<site><set>"):
+		operation = "set"
+		response = `<packet><site><set><result><status>ok</status></result></set></site></packet>`
+	default:
+		http.Error(rw, "unexpected packet: "+body, http.StatusBadRequest)
+		return
+	}
+
+	m.requests = append(m.requests, operation)
+
+	if errText, ok := m.errors[operation]; ok {
+		response = `<packet><certificate><` + operation + `><result><status>error</status><errcode>1023</errcode><errtext>` + errText + `</errtext></result></` + operation + `></certificate></packet>`
+	}
+
+	_, _ = rw.Write([]byte(response))
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	mock := &mockPlesk{current: "lego example.com 20250101000000"}
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer,
+		servermock.CheckHeader().
+			With("Key", "secret").
+			WithContentType("text/xml"),
+	).
+		Route("POST /enterprise/control/agent.php", mock).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"get", "install", "set", "remove"}, mock.requests)
+}
+
+func TestDeployer_Deploy_notManaged(t *testing.T) {
+	mock := &mockPlesk{current: "Default certificate"}
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer).
+		Route("POST /enterprise/control/agent.php", mock).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "*.example.com"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"get", "install", "set"}, mock.requests)
+}
+
+func TestDeployer_Deploy_alreadyDeployed(t *testing.T) {
+	mock := &mockPlesk{current: "lego example.com 20291003000000"}
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer).
+		Route("POST /enterprise/control/agent.php", mock).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"get"}, mock.requests)
+}
+
+func TestDeployer_Deploy_error(t *testing.T) {
+	mock := &mockPlesk{errors: map[string]string{"install": "Invalid certificate"}}
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer).
+		Route("POST /enterprise/control/agent.php", mock).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, "plesk: site example.com: install certificate: error: 1023 - Invalid certificate")
+}
@@ -0,0 +1,268 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// MQTT control packet types.
+const (
+	mqttConnect    = 0x10
+	mqttConnAck    = 0x20
+	mqttPublish    = 0x30
+	mqttPubAck     = 0x40
+	mqttDisconnect = 0xe0
+)
+
+const mqttKeepAlive = 60
+
+// The return codes of CONNACK.
+var mqttConnectErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// MQTTClient publishes the messages to a MQTT broker (MQTT 3.1.1, QoS 1).
+// https://docs.oasis-open.org/mqtt/mqtt/v3.1.1/mqtt-v3.1.1.html
+type MQTTClient struct {
+	// Address the address of the broker (host:port).
+	Address string
+	// TLSConfig the TLS configuration, nil without TLS.
+	TLSConfig *tls.Config
+
+	ClientID string
+	Username string
+	Password string
+
+	// Retain the broker keeps the last message of the topic for the future subscribers.
+	Retain bool
+
+	Timeout time.Duration
+}
+
+// Publish connects to the broker, publishes the message, and disconnects.
+func (c *MQTTClient) Publish(ctx context.Context, topic string, payload []byte) error {
+	conn, err := dial(ctx, c.Address, c.TLSConfig, c.Timeout)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	reader := bufio.NewReader(conn)
+
+	_, err = conn.Write(c.connectPacket())
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+
+	packetType, body, err := readMQTTPacket(reader)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+
+	if packetType != mqttConnAck || len(body) != 2 {
+		return fmt.Errorf("connect: unexpected packet: 0x%x", packetType)
+	}
+
+	if body[1] != 0 {
+		msg, ok := mqttConnectErrors[body[1]]
+		if !ok {
+			msg = fmt.Sprintf("return code %d", body[1])
+		}
+
+		return fmt.Errorf("connect: connection refused: %s", msg)
+	}
+
+	const packetID = 1
+
+	_, err = conn.Write(c.publishPacket(topic, packetID, payload))
+	if err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+
+	packetType, body, err = readMQTTPacket(reader)
+	if err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+
+	if packetType != mqttPubAck || len(body) != 2 || binary.BigEndian.Uint16(body) != packetID {
+		return fmt.Errorf("publish: unexpected packet: 0x%x", packetType)
+	}
+
+	_, err = conn.Write([]byte{mqttDisconnect, 0})
+	if err != nil {
+		return fmt.Errorf("disconnect: %w", err)
+	}
+
+	return nil
+}
+
+func (c *MQTTClient) connectPacket() []byte {
+	// Clean session.
+	var flags byte = 0x02
+
+	body := appendMQTTString(nil, "MQTT")
+	body = append(body, 4) // Protocol level: 3.1.1
+
+	payload := appendMQTTString(nil, c.ClientID)
+
+	if c.Username != "" {
+		flags |= 0x80
+
+		payload = appendMQTTString(payload, c.Username)
+	}
+
+	if c.Password != "" {
+		flags |= 0x40
+
+		payload = appendMQTTString(payload, c.Password)
+	}
+
+	body = append(body, flags)
+	body = binary.BigEndian.AppendUint16(body, mqttKeepAlive)
+	body = append(body, payload...)
+
+	return appendMQTTPacket(mqttConnect, body)
+}
+
+func (c *MQTTClient) publishPacket(topic string, packetID uint16, payload []byte) []byte {
+	// QoS 1.
+	var header byte = mqttPublish | 0x02
+
+	if c.Retain {
+		header |= 0x01
+	}
+
+	body := appendMQTTString(nil, topic)
+	body = binary.BigEndian.AppendUint16(body, packetID)
+	body = append(body, payload...)
+
+	return appendMQTTPacket(header, body)
+}
+
+func appendMQTTPacket(header byte, body []byte) []byte {
+	packet := []byte{header}
+
+	// Remaining length (variable byte integer).
+	length := len(body)
+
+	for {
+		b := byte(length % 128)
+		length /= 128
+
+		if length > 0 {
+			b |= 0x80
+		}
+
+		packet = append(packet, b)
+
+		if length == 0 {
+			break
+		}
+	}
+
+	return append(packet, body...)
+}
+
+func appendMQTTString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+func readMQTTPacket(reader *bufio.Reader) (byte, []byte, error) {
+	header, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var length, multiplier int = 0, 1
+
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+
+		b, errR := reader.ReadByte()
+		if errR != nil {
+			return 0, nil, errR
+		}
+
+		length += int(b&0x7f) * multiplier
+		multiplier *= 128
+
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	body := make([]byte, length)
+
+	_, err = io.ReadFull(reader, body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return header & 0xf0, body, nil
+}
+
+// dial opens a connection (TLS if the configuration is not nil), with a deadline for all the exchanges.
+func dial(ctx context.Context, address string, tlsConfig *tls.Config, timeout time.Duration) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	if tlsConfig == nil {
+		return conn, nil
+	}
+
+	return startTLS(ctx, conn, address, tlsConfig)
+}
+
+func startTLS(ctx context.Context, conn net.Conn, address string, tlsConfig *tls.Config) (net.Conn, error) {
+	config := tlsConfig.Clone()
+
+	if config.ServerName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+
+		config.ServerName = host
+	}
+
+	tlsConn := tls.Client(conn, config)
+
+	err := tlsConn.HandshakeContext(ctx)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("TLS handshake: %w", err)
+	}
+
+	return tlsConn, nil
+}
@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mqttMessage struct {
+	connect []byte
+	header  byte
+	topic   string
+	payload []byte
+}
+
+// fakeBroker accepts one connection, and answers CONNACK with the return code.
+func fakeBroker(t *testing.T, returnCode byte) (string, <-chan mqttMessage) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	messages := make(chan mqttMessage, 1)
+
+	go func() {
+		defer close(messages)
+
+		conn, errA := listener.Accept()
+		if errA != nil {
+			return
+		}
+
+		defer func() { _ = conn.Close() }()
+
+		reader := bufio.NewReader(conn)
+
+		var msg mqttMessage
+
+		_, msg.connect, errA = readMQTTPacket(reader)
+		if errA != nil {
+			return
+		}
+
+		_, _ = conn.Write([]byte{mqttConnAck, 2, 0, returnCode})
+
+		if returnCode != 0 {
+			return
+		}
+
+		header, errA := reader.Peek(1)
+		if errA != nil {
+			return
+		}
+
+		msg.header = header[0]
+
+		_, body, errA := readMQTTPacket(reader)
+		if errA != nil {
+			return
+		}
+
+		topicLength := binary.BigEndian.Uint16(body)
+		msg.topic = string(body[2 : 2+topicLength])
+
+		packetID := body[2+topicLength : 4+topicLength]
+		msg.payload = body[4+topicLength:]
+
+		_, _ = conn.Write(append([]byte{mqttPubAck, 2}, packetID...))
+
+		packetType, _, errA := readMQTTPacket(reader)
+		if errA != nil || packetType != mqttDisconnect {
+			return
+		}
+
+		messages <- msg
+	}()
+
+	return listener.Addr().String(), messages
+}
+
+func TestMQTTClient_Publish(t *testing.T) {
+	address, messages := fakeBroker(t, 0)
+
+	client := &MQTTClient{
+		Address:  address,
+		ClientID: "lego-test",
+		Username: "user",
+		Password: "secret",
+		Retain:   true,
+		Timeout:  5 * time.Second,
+	}
+
+	// The payload is larger than 128 bytes: the remaining length uses several bytes.
+	payload := make([]byte, 300)
+
+	err := client.Publish(t.Context(), "lego/certificates/example.com", payload)
+	require.NoError(t, err)
+
+	msg := <-messages
+
+	expectedConnect := []byte{0, 4, 'M', 'Q', 'T', 'T', 4, 0xc2, 0, 60}
+	expectedConnect = append(expectedConnect, 0, 9)
+	expectedConnect = append(expectedConnect, "lego-test"...)
+	expectedConnect = append(expectedConnect, 0, 4)
+	expectedConnect = append(expectedConnect, "user"...)
+	expectedConnect = append(expectedConnect, 0, 6)
+	expectedConnect = append(expectedConnect, "secret"...)
+
+	assert.Equal(t, expectedConnect, msg.connect)
+
+	// PUBLISH, QoS 1, retain.
+	assert.Equal(t, byte(0x33), msg.header)
+	assert.Equal(t, "lego/certificates/example.com", msg.topic)
+	assert.Equal(t, payload, msg.payload)
+}
+
+func TestMQTTClient_Publish_refused(t *testing.T) {
+	address, _ := fakeBroker(t, 5)
+
+	client := &MQTTClient{Address: address, ClientID: "lego-test", Timeout: 5 * time.Second}
+
+	err := client.Publish(t.Context(), "lego/certificates/example.com", []byte("{}"))
+	require.EqualError(t, err, "connect: connection refused: not authorized")
+}
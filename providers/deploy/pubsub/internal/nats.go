@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// NATSClient publishes the messages to a NATS server.
+// https://docs.nats.io/reference/reference-protocols/nats-protocol
+type NATSClient struct {
+	// Address the address of the server (host:port).
+	Address string
+	// TLSConfig the TLS configuration: TLS is used if the configuration is not nil, or if the server requires it.
+	TLSConfig *tls.Config
+
+	Username string
+	Password string
+	Token    string
+
+	Timeout time.Duration
+}
+
+type natsInfo struct {
+	TLSRequired bool  `json:"tls_required"`
+	MaxPayload  int64 `json:"max_payload"`
+}
+
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Protocol int    `json:"protocol"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+// Publish connects to the server, publishes the message, and disconnects.
+func (c *NATSClient) Publish(ctx context.Context, subject string, payload []byte) error {
+	conn, err := dial(ctx, c.Address, nil, c.Timeout)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("read INFO: %w", err)
+	}
+
+	rawInfo, ok := strings.CutPrefix(strings.TrimSpace(line), "INFO ")
+	if !ok {
+		return fmt.Errorf("unexpected message: %s", strings.TrimSpace(line))
+	}
+
+	var info natsInfo
+
+	err = json.Unmarshal([]byte(rawInfo), &info)
+	if err != nil {
+		return fmt.Errorf("read INFO: %w", err)
+	}
+
+	if info.MaxPayload > 0 && int64(len(payload)) > info.MaxPayload {
+		return fmt.Errorf("the message (%d bytes) exceeds the maximum payload of the server (%d bytes)", len(payload), info.MaxPayload)
+	}
+
+	if c.TLSConfig != nil || info.TLSRequired {
+		tlsConfig := c.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+
+		conn, err = startTLS(ctx, conn, c.Address, tlsConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	connect, err := json.Marshal(natsConnect{
+		Name:     "lego",
+		Lang:     "go",
+		Protocol: 1,
+		User:     c.Username,
+		Pass:     c.Password,
+		Token:    c.Token,
+	})
+	if err != nil {
+		return err
+	}
+
+	var msg []byte
+	msg = fmt.Appendf(msg, "CONNECT %s\r\n", connect)
+	msg = fmt.Appendf(msg, "PUB %s %d\r\n", subject, len(payload))
+	msg = append(msg, payload...)
+	msg = append(msg, "\r\nPING\r\n"...)
+
+	_, err = conn.Write(msg)
+	if err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+
+	// The server answers PONG after the processing of the previous messages, or an error.
+	return waitPong(conn)
+}
+
+func waitPong(conn net.Conn) error {
+	reader := bufio.NewReader(conn)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("wait PONG: %w", err)
+		}
+
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "PONG":
+			return nil
+
+		case line == "PING":
+			_, err = conn.Write([]byte("PONG\r\n"))
+			if err != nil {
+				return err
+			}
+
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("server error: %s", strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'"))
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type natsMessage struct {
+	connect natsConnect
+	subject string
+	payload []byte
+}
+
+// fakeNATS accepts one connection, and answers the PING with the response.
+func fakeNATS(t *testing.T, info, response string) (string, <-chan natsMessage) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	messages := make(chan natsMessage, 1)
+
+	go func() {
+		defer close(messages)
+
+		conn, errA := listener.Accept()
+		if errA != nil {
+			return
+		}
+
+		defer func() { _ = conn.Close() }()
+
+		_, _ = fmt.Fprintf(conn, "INFO %s\r\n", info)
+
+		reader := bufio.NewReader(conn)
+
+		var msg natsMessage
+
+		line, errA := reader.ReadString('\n')
+		if errA != nil {
+			return
+		}
+
+		_ = json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), &msg.connect)
+
+		line, errA = reader.ReadString('\n')
+		if errA != nil {
+			return
+		}
+
+		var size int
+
+		_, errA = fmt.Sscanf(strings.TrimSpace(line), "PUB %s %d", &msg.subject, &size)
+		if errA != nil {
+			return
+		}
+
+		msg.payload = make([]byte, size+2)
+
+		_, errA = io.ReadFull(reader, msg.payload)
+		if errA != nil {
+			return
+		}
+
+		msg.payload = msg.payload[:size]
+
+		line, errA = reader.ReadString('\n')
+		if errA != nil || strings.TrimSpace(line) != "PING" {
+			return
+		}
+
+		_, _ = fmt.Fprintf(conn, "%s\r\n", response)
+
+		messages <- msg
+	}()
+
+	return listener.Addr().String(), messages
+}
+
+func TestNATSClient_Publish(t *testing.T) {
+	address, messages := fakeNATS(t, `{"server_id":"test","max_payload":1048576}`, "PONG")
+
+	client := &NATSClient{
+		Address:  address,
+		Username: "user",
+		Password: "secret",
+		Timeout:  5 * time.Second,
+	}
+
+	err := client.Publish(t.Context(), "lego.certificates.example.com", []byte(`{"domain":"example.com"}`))
+	require.NoError(t, err)
+
+	msg := <-messages
+
+	expectedConnect := natsConnect{Name: "lego", Lang: "go", Protocol: 1, User: "user", Pass: "secret"}
+	assert.Equal(t, expectedConnect, msg.connect)
+
+	assert.Equal(t, "lego.certificates.example.com", msg.subject)
+	assert.JSONEq(t, `{"domain":"example.com"}`, string(msg.payload))
+}
+
+func TestNATSClient_Publish_error(t *testing.T) {
+	address, _ := fakeNATS(t, `{"server_id":"test","auth_required":true}`, "-ERR 'Authorization Violation'")
+
+	client := &NATSClient{Address: address, Token: "invalid", Timeout: 5 * time.Second}
+
+	err := client.Publish(t.Context(), "lego.certificates.example.com", []byte("{}"))
+	require.EqualError(t, err, "server error: Authorization Violation")
+}
+
+func TestNATSClient_Publish_maxPayload(t *testing.T) {
+	address, _ := fakeNATS(t, `{"server_id":"test","max_payload":10}`, "PONG")
+
+	client := &NATSClient{Address: address, Timeout: 5 * time.Second}
+
+	err := client.Publish(t.Context(), "lego.certificates.example.com", make([]byte, 20))
+	require.EqualError(t, err, "the message (20 bytes) exceeds the maximum payload of the server (10 bytes)")
+}
@@ -0,0 +1,378 @@
+// Package pubsub implements a deployer publishing the certificates to a MQTT broker or a NATS server.
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/tlsutil"
+	"github.com/go-acme/lego/v4/providers/deploy/pubsub/internal"
+	"github.com/go-jose/go-jose/v4"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "PUBSUB_"
+
+	EnvURL        = envNamespace + "URL"
+	EnvTopic      = envNamespace + "TOPIC"
+	EnvUsername   = envNamespace + "USERNAME"
+	EnvPassword   = envNamespace + "PASSWORD"
+	EnvToken      = envNamespace + "TOKEN"
+	EnvClientID   = envNamespace + "CLIENT_ID"
+	EnvRetain     = envNamespace + "RETAIN"
+	EnvCAFile     = envNamespace + "CA_FILE"
+	EnvRecipients = envNamespace + "RECIPIENTS"
+	EnvPlaintext  = envNamespace + "PLAINTEXT"
+
+	EnvTimeout = envNamespace + "TIMEOUT"
+)
+
+// The default topics (MQTT) and subjects (NATS).
+const (
+	defaultMQTTTopic   = "lego/certificates/{{ .Name }}"
+	defaultNATSSubject = "lego.certificates.{{ .Name }}"
+)
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	// URL the broker: `mqtt://host:1883`, `mqtts://host:8883` (TLS), or `nats://host:4222`.
+	URL string
+
+	// Topic the template of the topic (MQTT) or the subject (NATS).
+	// Default: `lego/certificates/{{ .Name }}` (MQTT), `lego.certificates.{{ .Name }}` (NATS).
+	Topic string
+
+	Username string
+	Password string
+	// Token the authentication token (NATS).
+	Token string
+
+	// ClientID the client identifier (MQTT). Default: a random identifier.
+	ClientID string
+	// Retain the broker keeps the last message for the future subscribers (MQTT).
+	Retain bool
+
+	CAFile string
+
+	// Recipients the files of the public keys (RSA or ECDSA, PEM) of the recipients:
+	// if defined, the messages are encrypted to the recipients (JWE).
+	Recipients []string
+
+	// Plaintext allows to publish the private key without TLS and without encryption.
+	Plaintext bool
+
+	Timeout time.Duration
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Topic:   env.GetOrFile(EnvTopic),
+		Retain:  env.GetOrDefaultBool(EnvRetain, true),
+		Timeout: env.GetOrDefaultSecond(EnvTimeout, 30*time.Second),
+	}
+}
+
+// TemplateData the data of the template of the topic.
+type TemplateData struct {
+	// Domain the main domain of the certificate.
+	Domain string
+	// Name the main domain without wildcard (e.g. "*.example.com" -> "wildcard.example.com").
+	Name string
+}
+
+// Bundle the content of the messages (JSON).
+type Bundle struct {
+	Domain      string    `json:"domain"`
+	Certificate string    `json:"certificate"`
+	Chain       string    `json:"chain"`
+	FullChain   string    `json:"fullchain"`
+	PrivateKey  string    `json:"private_key"`
+	NotAfter    time.Time `json:"not_after"`
+}
+
+type publisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
+
+// Deployer publishes the certificates to a MQTT broker or a NATS server.
+type Deployer struct {
+	config *Config
+
+	publisher publisher
+	topic     *template.Template
+
+	encrypter jose.Encrypter
+}
+
+// NewDeployer returns a Deployer instance configured for a MQTT broker or a NATS server.
+func NewDeployer() (*Deployer, error) {
+	values, err := env.Get(EnvURL)
+	if err != nil {
+		return nil, fmt.Errorf("pubsub: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.URL = values[EnvURL]
+	config.Username = env.GetOrFile(EnvUsername)
+	config.Password = env.GetOrFile(EnvPassword)
+	config.Token = env.GetOrFile(EnvToken)
+	config.ClientID = env.GetOrFile(EnvClientID)
+	config.CAFile = env.GetOrFile(EnvCAFile)
+	config.Plaintext = env.GetOrDefaultBool(EnvPlaintext, false)
+
+	if recipients := env.GetOrFile(EnvRecipients); recipients != "" {
+		config.Recipients = strings.Split(recipients, ",")
+	}
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for a MQTT broker or a NATS server.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("pubsub: the configuration of the deployer is nil")
+	}
+
+	redact.Register(config.Password, config.Token)
+
+	endpoint, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("pubsub: URL: %w", err)
+	}
+
+	var tlsConfig *tls.Config
+
+	if config.CAFile != "" {
+		tlsConfig, err = tlsutil.NewConfig(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("pubsub: %w", err)
+		}
+	}
+
+	d := &Deployer{config: config}
+
+	topic := config.Topic
+
+	switch endpoint.Scheme {
+	case "mqtt", "mqtts":
+		port := "1883"
+
+		if endpoint.Scheme == "mqtts" {
+			port = "8883"
+
+			if tlsConfig == nil {
+				tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+			}
+		}
+
+		clientID := config.ClientID
+		if clientID == "" {
+			clientID = "lego-" + strings.ToLower(rand.Text()[:12])
+		}
+
+		d.publisher = &internal.MQTTClient{
+			Address:   hostPort(endpoint, port),
+			TLSConfig: tlsConfig,
+			ClientID:  clientID,
+			Username:  config.Username,
+			Password:  config.Password,
+			Retain:    config.Retain,
+			Timeout:   config.Timeout,
+		}
+
+		if topic == "" {
+			topic = defaultMQTTTopic
+		}
+
+	case "nats":
+		d.publisher = &internal.NATSClient{
+			Address:   hostPort(endpoint, "4222"),
+			TLSConfig: tlsConfig,
+			Username:  config.Username,
+			Password:  config.Password,
+			Token:     config.Token,
+			Timeout:   config.Timeout,
+		}
+
+		if topic == "" {
+			topic = defaultNATSSubject
+		}
+
+	default:
+		return nil, fmt.Errorf("pubsub: unsupported URL scheme: %q (mqtt, mqtts, nats)", endpoint.Scheme)
+	}
+
+	d.topic, err = template.New("topic").Parse(topic)
+	if err != nil {
+		return nil, fmt.Errorf("pubsub: topic: %w", err)
+	}
+
+	if len(config.Recipients) > 0 {
+		d.encrypter, err = newEncrypter(config.Recipients)
+		if err != nil {
+			return nil, fmt.Errorf("pubsub: recipients: %w", err)
+		}
+	}
+
+	// The messages contain the private key.
+	if d.encrypter == nil && tlsConfig == nil {
+		if !config.Plaintext {
+			return nil, fmt.Errorf("pubsub: the private key would be published in plaintext over %s: "+
+				"use TLS (mqtts, %s), encrypt the messages (%s), or allow it explicitly (%s)",
+				endpoint.Scheme, EnvCAFile, EnvRecipients, EnvPlaintext)
+		}
+
+		log.Warnf("pubsub: the private key is published in plaintext over %s", endpoint.Scheme)
+	}
+
+	return d, nil
+}
+
+// Deploy publishes the certificate, the issuers, and the private key (JSON), encrypted to the recipients if needed.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("pubsub: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = d.topic.Execute(buf, TemplateData{Domain: res.Domain, Name: strings.ReplaceAll(res.Domain, "*", "wildcard")})
+	if err != nil {
+		return fmt.Errorf("pubsub: topic: %w", err)
+	}
+
+	payload, err := json.Marshal(Bundle{
+		Domain:      res.Domain,
+		Certificate: string(m.Certificate),
+		Chain:       string(m.Chain),
+		FullChain:   string(m.FullChain),
+		PrivateKey:  string(m.PrivateKey),
+		NotAfter:    m.Leaf.NotAfter.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("pubsub: %w", err)
+	}
+
+	if d.encrypter != nil {
+		obj, errE := d.encrypter.Encrypt(payload)
+		if errE != nil {
+			return fmt.Errorf("pubsub: encrypt: %w", errE)
+		}
+
+		payload = []byte(obj.FullSerialize())
+	}
+
+	err = d.publisher.Publish(ctx, buf.String(), payload)
+	if err != nil {
+		return fmt.Errorf("pubsub: publish to %s: %w", buf.String(), err)
+	}
+
+	return nil
+}
+
+// newEncrypter creates a JWE encrypter (A256GCM) for the public keys of the recipients:
+// RSA-OAEP-256 for the RSA keys, ECDH-ES+A256KW for the ECDSA keys.
+// The key ID of each recipient is the JWK thumbprint (SHA-256) of its public key.
+func newEncrypter(files []string) (jose.Encrypter, error) {
+	var recipients []jose.Recipient
+
+	for _, file := range files {
+		key, err := readPublicKey(strings.TrimSpace(file))
+		if err != nil {
+			return nil, err
+		}
+
+		thumbprint, err := (&jose.JSONWebKey{Key: key}).Thumbprint(crypto.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		recipient := jose.Recipient{Key: key, KeyID: base64.RawURLEncoding.EncodeToString(thumbprint)}
+
+		switch key.(type) {
+		case *rsa.PublicKey:
+			recipient.Algorithm = jose.RSA_OAEP_256
+		case *ecdsa.PublicKey:
+			recipient.Algorithm = jose.ECDH_ES_A256KW
+		default:
+			return nil, fmt.Errorf("%s: unsupported key type %T", file, key)
+		}
+
+		recipients = append(recipients, recipient)
+	}
+
+	opts := (&jose.EncrypterOptions{}).WithContentType("application/json")
+
+	return jose.NewMultiEncrypter(jose.A256GCM, recipients, opts)
+}
+
+// readPublicKey reads a public key (PEM): a public key (PKIX or PKCS#1), or the public key of a certificate.
+func readPublicKey(file string) (crypto.PublicKey, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data", file)
+	}
+
+	var key crypto.PublicKey
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			key = cert.PublicKey
+		}
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM type: %s", file, block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	return key, nil
+}
+
+func hostPort(endpoint *url.URL, defaultPort string) string {
+	port := endpoint.Port()
+	if port == "" {
+		port = defaultPort
+	}
+
+	return net.JoinHostPort(endpoint.Hostname(), port)
+}
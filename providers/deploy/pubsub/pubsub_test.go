@@ -0,0 +1,233 @@
+package pubsub
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+	"github.com/go-acme/lego/v4/providers/deploy/pubsub/internal"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvURL, EnvTopic, EnvUsername, EnvPassword, EnvToken, EnvClientID, EnvRetain, EnvCAFile, EnvRecipients, EnvPlaintext)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "MQTT",
+			envVars: map[string]string{
+				EnvURL:      "mqtts://broker.example.com",
+				EnvUsername: "lego",
+				EnvPassword: "secret",
+			},
+		},
+		{
+			desc: "NATS",
+			envVars: map[string]string{
+				EnvURL:       "nats://nats.example.com:4222",
+				EnvToken:     "secret",
+				EnvPlaintext: "true",
+			},
+		},
+		{
+			desc: "plaintext",
+			envVars: map[string]string{
+				EnvURL: "mqtt://broker.example.com",
+			},
+			expected: "pubsub: the private key would be published in plaintext over mqtt: " +
+				"use TLS (mqtts, PUBSUB_CA_FILE), encrypt the messages (PUBSUB_RECIPIENTS), or allow it explicitly (PUBSUB_PLAINTEXT)",
+		},
+		{
+			desc:     "missing URL",
+			envVars:  map[string]string{},
+			expected: "pubsub: some credentials information are missing: PUBSUB_URL",
+		},
+		{
+			desc: "unsupported scheme",
+			envVars: map[string]string{
+				EnvURL: "amqp://broker.example.com",
+			},
+			expected: `pubsub: unsupported URL scheme: "amqp" (mqtt, mqtts, nats)`,
+		},
+		{
+			desc: "missing recipient",
+			envVars: map[string]string{
+				EnvURL:        "nats://nats.example.com",
+				EnvRecipients: "./fixtures/missing.pem",
+			},
+			expected: "pubsub: recipients: open ./fixtures/missing.pem: no such file or directory",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDeployerConfig_publisher(t *testing.T) {
+	d, err := NewDeployerConfig(&Config{URL: "mqtts://broker.example.com", ClientID: "device-1"})
+	require.NoError(t, err)
+
+	client, ok := d.publisher.(*internal.MQTTClient)
+	require.True(t, ok)
+
+	assert.Equal(t, "broker.example.com:8883", client.Address)
+	assert.Equal(t, "device-1", client.ClientID)
+	assert.NotNil(t, client.TLSConfig)
+
+	d, err = NewDeployerConfig(&Config{URL: "nats://nats.example.com", Plaintext: true})
+	require.NoError(t, err)
+
+	natsClient, ok := d.publisher.(*internal.NATSClient)
+	require.True(t, ok)
+
+	assert.Equal(t, "nats.example.com:4222", natsClient.Address)
+	assert.Nil(t, natsClient.TLSConfig)
+}
+
+type fakePublisher struct {
+	topic   string
+	payload []byte
+	err     error
+}
+
+func (f *fakePublisher) Publish(_ context.Context, topic string, payload []byte) error {
+	f.topic = topic
+	f.payload = payload
+
+	return f.err
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	d, err := NewDeployerConfig(&Config{URL: "mqtt://broker.example.com", Plaintext: true})
+	require.NoError(t, err)
+
+	publisher := &fakePublisher{}
+	d.publisher = publisher
+
+	res := deploytest.NewResource(t, "*.example.com")
+
+	err = d.Deploy(t.Context(), res)
+	require.NoError(t, err)
+
+	assert.Equal(t, "lego/certificates/wildcard.example.com", publisher.topic)
+
+	m, err := material.FromResource(res)
+	require.NoError(t, err)
+
+	var bundle Bundle
+
+	err = json.Unmarshal(publisher.payload, &bundle)
+	require.NoError(t, err)
+
+	expected := Bundle{
+		Domain:      "*.example.com",
+		Certificate: string(m.Certificate),
+		Chain:       string(m.Chain),
+		FullChain:   string(m.FullChain),
+		PrivateKey:  string(m.PrivateKey),
+		NotAfter:    deploytest.NotAfter,
+	}
+
+	assert.Equal(t, expected, bundle)
+}
+
+func TestDeployer_Deploy_encrypted(t *testing.T) {
+	dir := t.TempDir()
+
+	var (
+		keys  []*ecdsa.PrivateKey
+		files []string
+	)
+
+	for _, name := range []string{"device-1.pem", "device-2.pem"} {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		der, err := x509.MarshalPKIXPublicKey(key.Public())
+		require.NoError(t, err)
+
+		file := filepath.Join(dir, name)
+
+		err = os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600)
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+		files = append(files, file)
+	}
+
+	d, err := NewDeployerConfig(&Config{
+		URL:        "nats://nats.example.com",
+		Topic:      "devices.{{ .Name }}",
+		Recipients: files,
+	})
+	require.NoError(t, err)
+
+	publisher := &fakePublisher{}
+	d.publisher = publisher
+
+	err = d.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "devices.example.com", publisher.topic)
+
+	// Each recipient can decrypt the message.
+	for _, key := range keys {
+		obj, err := jose.ParseEncryptedJSON(string(publisher.payload),
+			[]jose.KeyAlgorithm{jose.ECDH_ES_A256KW}, []jose.ContentEncryption{jose.A256GCM})
+		require.NoError(t, err)
+
+		_, _, plaintext, err := obj.DecryptMulti(key)
+		require.NoError(t, err)
+
+		var bundle Bundle
+
+		err = json.Unmarshal(plaintext, &bundle)
+		require.NoError(t, err)
+
+		assert.Equal(t, "example.com", bundle.Domain)
+	}
+}
+
+func TestDeployer_Deploy_error(t *testing.T) {
+	d, err := NewDeployerConfig(&Config{URL: "nats://nats.example.com", Plaintext: true})
+	require.NoError(t, err)
+
+	d.publisher = &fakePublisher{err: errors.New("server error: Authorization Violation")}
+
+	err = d.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, "pubsub: publish to lego.certificates.example.com: server error: Authorization Violation")
+}
@@ -0,0 +1,258 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type sessionKey string
+
+const sessionContextKey sessionKey = "session"
+
+// Client the Synology DSM API client.
+type Client struct {
+	username string
+	password string
+
+	baseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(baseURL, username, password string) (*Client, error) {
+	apiEndpoint, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse URL: %w", err)
+	}
+
+	return &Client{
+		username:   username,
+		password:   password,
+		baseURL:    apiEndpoint.JoinPath("webapi", "entry.cgi"),
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// CreateAuthenticatedContext logs in, and returns a context containing the session.
+// https://global.download.synology.com/download/Document/Software/DeveloperGuide/Os/DSM/All/enu/DSM_Login_Web_API_Guide_enu.pdf
+func (c *Client) CreateAuthenticatedContext(ctx context.Context) (context.Context, error) {
+	form := url.Values{}
+	form.Set("api", "SYNO.API.Auth")
+	form.Set("version", "6")
+	form.Set("method", "login")
+	form.Set("account", c.username)
+	form.Set("passwd", c.password)
+	form.Set("session", "lego")
+	form.Set("format", "sid")
+	form.Set("enable_syno_token", "yes")
+
+	var session Session
+
+	err := c.do(ctx, form, nil, &session)
+	if err != nil {
+		return nil, fmt.Errorf("login: %w", err)
+	}
+
+	return context.WithValue(ctx, sessionContextKey, &session), nil
+}
+
+// Logout ends the session of the context.
+func (c *Client) Logout(ctx context.Context) error {
+	form := url.Values{}
+	form.Set("api", "SYNO.API.Auth")
+	form.Set("version", "6")
+	form.Set("method", "logout")
+	form.Set("session", "lego")
+
+	return c.do(ctx, form, nil, nil)
+}
+
+// ListCertificates lists the certificates, and the services using them.
+func (c *Client) ListCertificates(ctx context.Context) ([]Certificate, error) {
+	form := url.Values{}
+	form.Set("api", "SYNO.Core.Certificate.CRT")
+	form.Set("version", "1")
+	form.Set("method", "list")
+
+	var result Certificates
+
+	err := c.do(ctx, form, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Certificates, nil
+}
+
+// ImportCertificate imports a certificate.
+// The certificate with the ID is replaced (the services using it use the new certificate), a new certificate is created if the ID is empty.
+func (c *Client) ImportCertificate(ctx context.Context, id, desc string, asDefault bool, key, cert, interCert []byte) (string, error) {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+
+	files := []struct {
+		field   string
+		name    string
+		content []byte
+	}{
+		{field: "key", name: "privkey.pem", content: key},
+		{field: "cert", name: "cert.pem", content: cert},
+		{field: "inter_cert", name: "chain.pem", content: interCert},
+	}
+
+	for _, file := range files {
+		if len(file.content) == 0 {
+			continue
+		}
+
+		part, err := writer.CreateFormFile(file.field, file.name)
+		if err != nil {
+			return "", err
+		}
+
+		_, err = part.Write(file.content)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var defaultValue string
+	if asDefault {
+		defaultValue = "true"
+	}
+
+	fields := [][2]string{{"id", id}, {"desc", desc}, {"as_default", defaultValue}}
+
+	for _, field := range fields {
+		err := writer.WriteField(field[0], field[1])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("api", "SYNO.Core.Certificate")
+	query.Set("version", "1")
+	query.Set("method", "import")
+
+	var result ImportResult
+
+	err = c.do(ctx, query, &multipartBody{contentType: writer.FormDataContentType(), body: buf}, &result)
+	if err != nil {
+		return "", err
+	}
+
+	return result.ID, nil
+}
+
+// SetServices assigns the certificates to the services.
+func (c *Client) SetServices(ctx context.Context, settings []ServiceSetting) error {
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("api", "SYNO.Core.Certificate.Service")
+	form.Set("version", "1")
+	form.Set("method", "set")
+	form.Set("settings", string(raw))
+
+	return c.do(ctx, form, nil, nil)
+}
+
+type multipartBody struct {
+	contentType string
+	body        io.Reader
+}
+
+// do sends the request: the parameters are sent as form, or in the query with a multipart body.
+func (c *Client) do(ctx context.Context, params url.Values, mp *multipartBody, result any) error {
+	endpoint := *c.baseURL
+
+	var (
+		body        io.Reader
+		contentType string
+	)
+
+	if mp != nil {
+		endpoint.RawQuery = params.Encode()
+		body = mp.body
+		contentType = mp.contentType
+	} else {
+		body = strings.NewReader(params.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), body)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	if session, ok := ctx.Value(sessionContextKey).(*Session); ok {
+		query := req.URL.Query()
+		query.Set("_sid", session.SID)
+		req.URL.RawQuery = query.Encode()
+
+		req.Header.Set("X-SYNO-TOKEN", session.SynoToken)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to communicate with DSM: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	response := APIResponse[json.RawMessage]{}
+
+	err = json.Unmarshal(raw, &response)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal response: %w: %s", err, string(raw))
+	}
+
+	if !response.Success {
+		if response.Error != nil {
+			return response.Error
+		}
+
+		return errors.New("unsuccessful request")
+	}
+
+	if result == nil || len(response.Data) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(response.Data, result)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal response: %w: %s", err, string(raw))
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"fmt"
+)
+
+// APIResponse the response of the DSM API.
+type APIResponse[T any] struct {
+	Data    T         `json:"data"`
+	Success bool      `json:"success"`
+	Error   *APIError `json:"error,omitempty"`
+}
+
+// Session the session of the DSM API.
+type Session struct {
+	SID       string `json:"sid"`
+	SynoToken string `json:"synotoken"`
+}
+
+// Certificates the certificates of DSM.
+type Certificates struct {
+	Certificates []Certificate `json:"certificates"`
+}
+
+// Certificate a certificate of DSM.
+type Certificate struct {
+	ID        string    `json:"id"`
+	Desc      string    `json:"desc"`
+	IsDefault bool      `json:"is_default"`
+	Services  []Service `json:"services"`
+}
+
+// Service a service using a certificate (DSM services, packages, and reverse proxy entries).
+type Service struct {
+	DisplayName     string `json:"display_name"`
+	DisplayNameI18N string `json:"display_name_i18n,omitempty"`
+	IsPkg           bool   `json:"isPkg"`
+	MultipleCert    bool   `json:"multiple_cert,omitempty"`
+	Owner           string `json:"owner"`
+	Service         string `json:"service"`
+	Subscriber      string `json:"subscriber"`
+	UserSetable     bool   `json:"user_setable,omitempty"`
+}
+
+// ServiceSetting the assignation of a certificate to a service.
+type ServiceSetting struct {
+	Service Service `json:"service"`
+	OldID   string  `json:"old_id"`
+	ID      string  `json:"id"`
+}
+
+// ImportResult the result of the import of a certificate.
+type ImportResult struct {
+	ID string `json:"id"`
+}
+
+// APIError the error of the DSM API.
+type APIError struct {
+	Code int `json:"code"`
+}
+
+func (e *APIError) Error() string {
+	msg, ok := errorMessages[e.Code]
+	if !ok {
+		return fmt.Sprintf("error %d", e.Code)
+	}
+
+	return fmt.Sprintf("error %d: %s", e.Code, msg)
+}
+
+// https://global.download.synology.com/download/Document/Software/DeveloperGuide/Os/DSM/All/enu/DSM_Login_Web_API_Guide_enu.pdf
+var errorMessages = map[int]string{
+	100: "unknown error",
+	101: "invalid parameter",
+	102: "the requested API does not exist",
+	103: "the requested method does not exist",
+	104: "the requested version does not support the functionality",
+	105: "the logged in session does not have permission",
+	106: "session timeout",
+	107: "session interrupted by duplicated login",
+	119: "invalid session",
+	400: "no such account or incorrect password",
+	401: "disabled account",
+	402: "denied permission",
+	403: "2-factor authentication code required",
+	404: "failed to authenticate 2-factor authentication code",
+	406: "enforce to authenticate with 2-factor authentication code",
+	407: "blocked IP source",
+	408: "expired password cannot change",
+	409: "expired password",
+	410: "password must be changed",
+}
@@ -0,0 +1,249 @@
+// Package synology implements a deployer replacing the certificates of Synology DSM.
+package synology
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/tlsutil"
+	"github.com/go-acme/lego/v4/providers/deploy/synology/internal"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "SYNOLOGY_DSM_"
+
+	EnvBaseURL     = envNamespace + "BASE_URL"
+	EnvUsername    = envNamespace + "USERNAME"
+	EnvPassword    = envNamespace + "PASSWORD"
+	EnvCAFile      = envNamespace + "CA_FILE"
+	EnvDescription = envNamespace + "CERTIFICATE_DESCRIPTION"
+	EnvAsDefault   = envNamespace + "AS_DEFAULT"
+	EnvServices    = envNamespace + "SERVICES"
+
+	EnvHTTPTimeout = envNamespace + "HTTP_TIMEOUT"
+)
+
+const defaultDescription = "{{ .Domain }}"
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	BaseURL  string
+	Username string
+	Password string
+	CAFile   string
+
+	// Description the template of the description of the certificate: the certificate with this description is replaced.
+	Description string
+
+	// AsDefault sets the certificate as the default certificate.
+	AsDefault bool
+
+	// Services the services (display names of the DSM services, packages, and reverse proxy entries) to assign to the certificate.
+	Services []string
+
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Description: env.GetOrDefaultString(EnvDescription, defaultDescription),
+		AsDefault:   env.GetOrDefaultBool(EnvAsDefault, false),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		},
+	}
+}
+
+// TemplateData the data of the template of the description.
+type TemplateData struct {
+	// Domain the main domain of the certificate.
+	Domain string
+}
+
+// Deployer replaces the certificates of Synology DSM.
+type Deployer struct {
+	config *Config
+	client *internal.Client
+
+	description *template.Template
+}
+
+// NewDeployer returns a Deployer instance configured for Synology DSM.
+func NewDeployer() (*Deployer, error) {
+	values, err := env.Get(EnvBaseURL, EnvUsername, EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("synology: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.BaseURL = values[EnvBaseURL]
+	config.Username = values[EnvUsername]
+	config.Password = values[EnvPassword]
+	config.CAFile = env.GetOrFile(EnvCAFile)
+
+	if services := env.GetOrFile(EnvServices); services != "" {
+		config.Services = strings.Split(services, ",")
+	}
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for Synology DSM.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("synology: the configuration of the deployer is nil")
+	}
+
+	redact.Register(config.Password)
+
+	if config.Username == "" || config.Password == "" {
+		return nil, errors.New("synology: some credentials information are missing")
+	}
+
+	if config.BaseURL == "" {
+		return nil, errors.New("synology: server information are missing")
+	}
+
+	description, err := template.New("description").Parse(config.Description)
+	if err != nil {
+		return nil, fmt.Errorf("synology: description: %w", err)
+	}
+
+	client, err := internal.NewClient(config.BaseURL, config.Username, config.Password)
+	if err != nil {
+		return nil, fmt.Errorf("synology: %w", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.CAFile != "" {
+		client.HTTPClient, err = tlsutil.WithCA(client.HTTPClient, config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("synology: %w", err)
+		}
+	}
+
+	return &Deployer{config: config, client: client, description: description}, nil
+}
+
+// Deploy replaces the certificate with the same description (created if needed),
+// then assigns the certificate to the services.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("synology: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = d.description.Execute(buf, TemplateData{Domain: res.Domain})
+	if err != nil {
+		return fmt.Errorf("synology: description: %w", err)
+	}
+
+	desc := buf.String()
+
+	ctxAuth, err := d.client.CreateAuthenticatedContext(ctx)
+	if err != nil {
+		return fmt.Errorf("synology: %w", err)
+	}
+
+	defer func() {
+		errL := d.client.Logout(ctxAuth)
+		if errL != nil {
+			log.Warnf("[%s] synology: logout: %v", res.Domain, errL)
+		}
+	}()
+
+	certs, err := d.client.ListCertificates(ctxAuth)
+	if err != nil {
+		return fmt.Errorf("synology: list certificates: %w", err)
+	}
+
+	var currentID string
+
+	for _, cert := range certs {
+		if cert.Desc == desc {
+			currentID = cert.ID
+			break
+		}
+	}
+
+	// The services are checked before the import.
+	settings, err := d.serviceSettings(certs, currentID)
+	if err != nil {
+		return fmt.Errorf("synology: %w", err)
+	}
+
+	id, err := d.client.ImportCertificate(ctxAuth, currentID, desc, d.config.AsDefault, m.PrivateKey, m.Certificate, m.Chain)
+	if err != nil {
+		return fmt.Errorf("synology: import certificate %q: %w", desc, err)
+	}
+
+	if id == "" {
+		id = currentID
+	}
+
+	if len(settings) == 0 {
+		return nil
+	}
+
+	for i := range settings {
+		settings[i].ID = id
+	}
+
+	err = d.client.SetServices(ctxAuth, settings)
+	if err != nil {
+		return fmt.Errorf("synology: assign services: %w", err)
+	}
+
+	return nil
+}
+
+// serviceSettings returns the assignations of the services not using the certificate (id) yet.
+func (d *Deployer) serviceSettings(certs []internal.Certificate, id string) ([]internal.ServiceSetting, error) {
+	var settings []internal.ServiceSetting
+
+	for _, name := range d.config.Services {
+		name = strings.TrimSpace(name)
+
+		idx := slices.IndexFunc(certs, func(cert internal.Certificate) bool {
+			return slices.ContainsFunc(cert.Services, func(service internal.Service) bool {
+				return service.DisplayName == name
+			})
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("service %q not found", name)
+		}
+
+		cert := certs[idx]
+
+		if id != "" && cert.ID == id {
+			continue
+		}
+
+		for _, service := range cert.Services {
+			if service.DisplayName == name {
+				settings = append(settings, internal.ServiceSetting{Service: service, OldID: cert.ID})
+			}
+		}
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,247 @@
+package synology
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvBaseURL, EnvUsername, EnvPassword, EnvCAFile, EnvDescription, EnvAsDefault, EnvServices)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvBaseURL:  "https://nas.example.com:5001",
+				EnvUsername: "admin",
+				EnvPassword: "secret",
+				EnvServices: "DSM Desktop Service,www.example.com",
+			},
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				EnvBaseURL:  "https://nas.example.com:5001",
+				EnvUsername: "admin",
+			},
+			expected: "synology: some credentials information are missing: SYNOLOGY_DSM_PASSWORD",
+		},
+		{
+			desc: "invalid CA file",
+			envVars: map[string]string{
+				EnvBaseURL:  "https://nas.example.com:5001",
+				EnvUsername: "admin",
+				EnvPassword: "secret",
+				EnvCAFile:   "./fixtures/missing.pem",
+			},
+			expected: "synology: CA file: open ./fixtures/missing.pem: no such file or directory",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupDeployer(services ...string) func(server *httptest.Server) (*Deployer, error) {
+	return func(server *httptest.Server) (*Deployer, error) {
+		config := NewDefaultConfig()
+		config.BaseURL = server.URL
+		config.Username = "admin"
+		config.Password = "secret"
+		config.Description = defaultDescription
+		config.AsDefault = false
+		config.Services = services
+		config.HTTPClient = server.Client()
+
+		return NewDeployerConfig(config)
+	}
+}
+
+const listResponse = `{
+  "success": true,
+  "data": {
+    "certificates": [
+      {
+        "id": "abc123",
+        "desc": "synology.me",
+        "is_default": true,
+        "services": [
+          {"display_name": "DSM Desktop Service", "isPkg": false, "owner": "root", "service": "default", "subscriber": "system"},
+          {"display_name": "www.example.com", "isPkg": false, "owner": "root", "service": "0a1b2c", "subscriber": "ReverseProxy"}
+        ]
+      },
+      {
+        "id": "def456",
+        "desc": "example.com",
+        "is_default": false,
+        "services": [
+          {"display_name": "FTPS", "isPkg": false, "owner": "root", "service": "ftpd", "subscriber": "smbftpd"}
+        ]
+      }
+    ]
+  }
+}`
+
+// mockDSM the DSM API: all the requests use the same endpoint, the API and the method are parameters.
+type mockDSM struct {
+	t *testing.T
+
+	material *material.Material
+
+	calls    []string
+	settings string
+}
+
+func (m *mockDSM) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	api := req.FormValue("api") + ":" + req.FormValue("method")
+
+	m.calls = append(m.calls, api)
+
+	if api != "SYNO.API.Auth:login" {
+		assert.Equal(m.t, "session-id", req.URL.Query().Get("_sid"))
+		assert.Equal(m.t, "syno-token", req.Header.Get("X-SYNO-TOKEN"))
+	}
+
+	switch api {
+	case "SYNO.API.Auth:login":
+		assert.Equal(m.t, "admin", req.PostFormValue("account"))
+		assert.Equal(m.t, "secret", req.PostFormValue("passwd"))
+
+		_, _ = io.WriteString(rw, `{"success":true,"data":{"sid":"session-id","synotoken":"syno-token"}}`)
+
+	case "SYNO.API.Auth:logout":
+		_, _ = io.WriteString(rw, `{"success":true}`)
+
+	case "SYNO.Core.Certificate.CRT:list":
+		_, _ = io.WriteString(rw, listResponse)
+
+	case "SYNO.Core.Certificate:import":
+		assert.Equal(m.t, "def456", req.FormValue("id"))
+		assert.Equal(m.t, "example.com", req.FormValue("desc"))
+		assert.Empty(m.t, req.FormValue("as_default"))
+
+		for field, expected := range map[string][]byte{"key": m.material.PrivateKey, "cert": m.material.Certificate, "inter_cert": m.material.Chain} {
+			file, _, err := req.FormFile(field)
+			require.NoError(m.t, err)
+
+			content, err := io.ReadAll(file)
+			require.NoError(m.t, err)
+
+			assert.Equal(m.t, expected, content, field)
+		}
+
+		_, _ = io.WriteString(rw, `{"success":true,"data":{"id":"def456","restart_httpd":false}}`)
+
+	case "SYNO.Core.Certificate.Service:set":
+		m.settings = req.PostFormValue("settings")
+
+		_, _ = io.WriteString(rw, `{"success":true}`)
+
+	default:
+		http.Error(rw, "unexpected API: "+api, http.StatusBadRequest)
+	}
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	res := deploytest.NewResource(t, "example.com")
+
+	m, err := material.FromResource(res)
+	require.NoError(t, err)
+
+	mock := &mockDSM{t: t, material: m}
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer("www.example.com", "FTPS")).
+		Route("POST /webapi/entry.cgi", mock).
+		Build(t)
+
+	err = deployer.Deploy(t.Context(), res)
+	require.NoError(t, err)
+
+	expectedCalls := []string{
+		"SYNO.API.Auth:login",
+		"SYNO.Core.Certificate.CRT:list",
+		"SYNO.Core.Certificate:import",
+		"SYNO.Core.Certificate.Service:set",
+		"SYNO.API.Auth:logout",
+	}
+	assert.Equal(t, expectedCalls, mock.calls)
+
+	// FTPS already uses the certificate.
+	expectedSettings := []map[string]any{
+		{
+			"service": map[string]any{"display_name": "www.example.com", "isPkg": false, "owner": "root", "service": "0a1b2c", "subscriber": "ReverseProxy"},
+			"old_id":  "abc123",
+			"id":      "def456",
+		},
+	}
+
+	var settings []map[string]any
+
+	err = json.Unmarshal([]byte(mock.settings), &settings)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedSettings, settings)
+}
+
+func TestDeployer_Deploy_unknownService(t *testing.T) {
+	res := deploytest.NewResource(t, "example.com")
+
+	m, err := material.FromResource(res)
+	require.NoError(t, err)
+
+	mock := &mockDSM{t: t, material: m}
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer("WebDAV")).
+		Route("POST /webapi/entry.cgi", mock).
+		Build(t)
+
+	err = deployer.Deploy(t.Context(), res)
+	require.EqualError(t, err, `synology: service "WebDAV" not found`)
+
+	assert.Equal(t, []string{"SYNO.API.Auth:login", "SYNO.Core.Certificate.CRT:list", "SYNO.API.Auth:logout"}, mock.calls)
+}
+
+func TestDeployer_Deploy_loginError(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer()).
+		Route("POST /webapi/entry.cgi",
+			servermock.RawStringResponse(`{"success":false,"error":{"code":400}}`),
+			servermock.CheckForm().UsePostForm().
+				With("api", "SYNO.API.Auth").
+				With("method", "login"),
+		).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, "synology: login: error 400: no such account or incorrect password")
+}
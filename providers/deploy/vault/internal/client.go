@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client the Vault API client.
+type Client struct {
+	token     string
+	namespace string
+
+	baseURL    *url.URL
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(addr, token, namespace string) (*Client, error) {
+	baseURL, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("parse address: %w", err)
+	}
+
+	return &Client{
+		token:      token,
+		namespace:  namespace,
+		baseURL:    baseURL.JoinPath("v1"),
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// LoginAppRole gets a token with the AppRole auth method.
+func (c *Client) LoginAppRole(ctx context.Context, mount, roleID, secretID string) error {
+	var result authResponse
+
+	err := c.do(ctx, c.baseURL.JoinPath("auth", mount, "login"), appRoleLogin{RoleID: roleID, SecretID: secretID}, &result)
+	if err != nil {
+		return fmt.Errorf("approle login: %w", err)
+	}
+
+	if result.Auth.ClientToken == "" {
+		return errors.New("approle login: no token in the response")
+	}
+
+	c.token = result.Auth.ClientToken
+
+	return nil
+}
+
+// WriteKV writes the data in the KV secrets engine (version 1 or 2).
+func (c *Client) WriteKV(ctx context.Context, mount, path string, version int, data map[string]string) error {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if version == 1 {
+		return c.do(ctx, c.baseURL.JoinPath(append([]string{mount}, segments...)...), data, nil)
+	}
+
+	payload := map[string]any{"data": data}
+
+	return c.do(ctx, c.baseURL.JoinPath(append([]string{mount, "data"}, segments...)...), payload, nil)
+}
+
+func (c *Client) do(ctx context.Context, endpoint *url.URL, payload, result any) error {
+	buf := new(bytes.Buffer)
+
+	err := json.NewEncoder(buf).Encode(payload)
+	if err != nil {
+		return fmt.Errorf("failed to create request JSON body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), buf)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to communicate with Vault: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+
+		err = json.Unmarshal(raw, apiErr)
+		if err != nil || len(apiErr.Errors) == 0 {
+			apiErr.Errors = []string{strings.TrimSpace(string(raw))}
+		}
+
+		return apiErr
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal response: %w: %s", err, string(raw))
+	}
+
+	return nil
+}
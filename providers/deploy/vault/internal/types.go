@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIError the Vault API errors.
+type APIError struct {
+	StatusCode int      `json:"-"`
+	Errors     []string `json:"errors"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, strings.Join(e.Errors, ", "))
+}
+
+type appRoleLogin struct {
+	RoleID   string `json:"role_id"`
+	SecretID string `json:"secret_id"`
+}
+
+type authResponse struct {
+	Auth struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
@@ -0,0 +1,197 @@
+// Package vault implements a deployer writing the certificates in the KV secrets engine of HashiCorp Vault.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/tlsutil"
+	"github.com/go-acme/lego/v4/providers/deploy/vault/internal"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "VAULT_"
+
+	EnvAddr           = envNamespace + "ADDR"
+	EnvToken          = envNamespace + "TOKEN"
+	EnvVaultNamespace = envNamespace + "NAMESPACE"
+	EnvCACert         = envNamespace + "CACERT"
+
+	EnvRoleID       = envNamespace + "ROLE_ID"
+	EnvSecretID     = envNamespace + "SECRET_ID"
+	EnvAppRoleMount = envNamespace + "APPROLE_MOUNT"
+
+	EnvKVMount   = envNamespace + "KV_MOUNT"
+	EnvKVPath    = envNamespace + "KV_PATH"
+	EnvKVVersion = envNamespace + "KV_VERSION"
+
+	EnvHTTPTimeout = envNamespace + "HTTP_TIMEOUT"
+)
+
+const (
+	defaultAppRoleMount = "approle"
+	defaultKVMount      = "secret"
+	defaultKVPath       = "lego/{{ .Domain }}"
+	defaultKVVersion    = 2
+)
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	Addr      string
+	Token     string
+	Namespace string
+	CACert    string
+
+	// RoleID and SecretID the credentials of the AppRole auth method, used instead of the token if defined.
+	RoleID       string
+	SecretID     string
+	AppRoleMount string
+
+	// KVMount the mount path of the KV secrets engine.
+	KVMount string
+	// KVPath the template of the path of the secret.
+	KVPath string
+	// KVVersion the version of the KV secrets engine (1 or 2).
+	KVVersion int
+
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		AppRoleMount: env.GetOrDefaultString(EnvAppRoleMount, defaultAppRoleMount),
+		KVMount:      env.GetOrDefaultString(EnvKVMount, defaultKVMount),
+		KVPath:       env.GetOrDefaultString(EnvKVPath, defaultKVPath),
+		KVVersion:    env.GetOrDefaultInt(EnvKVVersion, defaultKVVersion),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		},
+	}
+}
+
+// TemplateData the data of the template of the path of the secret.
+type TemplateData struct {
+	// Domain the main domain of the certificate.
+	Domain string
+}
+
+// Deployer writes the certificates in the KV secrets engine of Vault.
+// The secret contains the keys `certificate`, `chain`, `fullchain`, `private_key`, `domain`, and `not_after`,
+// to be consumed by Vault Agent templates.
+type Deployer struct {
+	config *Config
+	client *internal.Client
+
+	path *template.Template
+}
+
+// NewDeployer returns a Deployer instance configured for Vault.
+// The environment variables of the Vault CLI (VAULT_ADDR, VAULT_TOKEN, VAULT_NAMESPACE, VAULT_CACERT) are used.
+func NewDeployer() (*Deployer, error) {
+	config := NewDefaultConfig()
+	config.Addr = env.GetOrFile(EnvAddr)
+	config.Token = env.GetOrFile(EnvToken)
+	config.Namespace = env.GetOrFile(EnvVaultNamespace)
+	config.CACert = env.GetOrFile(EnvCACert)
+	config.RoleID = env.GetOrFile(EnvRoleID)
+	config.SecretID = env.GetOrFile(EnvSecretID)
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for Vault.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("vault: the configuration of the deployer is nil")
+	}
+
+	redact.Register(config.Token, config.SecretID)
+
+	if config.Addr == "" {
+		return nil, errors.New("vault: the address is missing")
+	}
+
+	if config.Token == "" && config.RoleID == "" {
+		return nil, errors.New("vault: missing credentials: a token or an AppRole role ID is required")
+	}
+
+	if config.KVVersion != 1 && config.KVVersion != 2 {
+		return nil, fmt.Errorf("vault: unsupported KV version: %d", config.KVVersion)
+	}
+
+	path, err := template.New("path").Parse(config.KVPath)
+	if err != nil {
+		return nil, fmt.Errorf("vault: path: %w", err)
+	}
+
+	client, err := internal.NewClient(config.Addr, config.Token, config.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("vault: %w", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.CACert != "" {
+		client.HTTPClient, err = tlsutil.WithCA(client.HTTPClient, config.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("vault: %w", err)
+		}
+	}
+
+	return &Deployer{
+		config: config,
+		client: client,
+		path:   path,
+	}, nil
+}
+
+// Deploy writes the certificate, the chain, and the private key in the secret.
+func (d *Deployer) Deploy(ctx context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("vault: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = d.path.Execute(buf, TemplateData{Domain: res.Domain})
+	if err != nil {
+		return fmt.Errorf("vault: path: %w", err)
+	}
+
+	if d.config.RoleID != "" {
+		err = d.client.LoginAppRole(ctx, d.config.AppRoleMount, d.config.RoleID, d.config.SecretID)
+		if err != nil {
+			return fmt.Errorf("vault: %w", err)
+		}
+	}
+
+	data := map[string]string{
+		"certificate": string(m.Certificate),
+		"chain":       string(m.Chain),
+		"fullchain":   string(m.FullChain),
+		"private_key": string(m.PrivateKey),
+		"domain":      res.Domain,
+		"not_after":   m.Leaf.NotAfter.UTC().Format(time.RFC3339),
+	}
+
+	err = d.client.WriteKV(ctx, d.config.KVMount, buf.String(), d.config.KVVersion, data)
+	if err != nil {
+		return fmt.Errorf("vault: write secret %s/%s: %w", d.config.KVMount, buf.String(), err)
+	}
+
+	return nil
+}
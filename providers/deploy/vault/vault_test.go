@@ -0,0 +1,187 @@
+package vault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/deploytest"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvAddr, EnvToken, EnvVaultNamespace, EnvCACert, EnvRoleID, EnvSecretID, EnvKVPath, EnvKVVersion)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvAddr:  "https://vault.example.com:8200",
+				EnvToken: "secret",
+			},
+		},
+		{
+			desc: "success with AppRole",
+			envVars: map[string]string{
+				EnvAddr:     "https://vault.example.com:8200",
+				EnvRoleID:   "role",
+				EnvSecretID: "secret",
+			},
+		},
+		{
+			desc: "missing address",
+			envVars: map[string]string{
+				EnvToken: "secret",
+			},
+			expected: "vault: the address is missing",
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				EnvAddr: "https://vault.example.com:8200",
+			},
+			expected: "vault: missing credentials: a token or an AppRole role ID is required",
+		},
+		{
+			desc: "unsupported KV version",
+			envVars: map[string]string{
+				EnvAddr:      "https://vault.example.com:8200",
+				EnvToken:     "secret",
+				EnvKVVersion: "3",
+			},
+			expected: "vault: unsupported KV version: 3",
+		},
+		{
+			desc: "invalid template",
+			envVars: map[string]string{
+				EnvAddr:   "https://vault.example.com:8200",
+				EnvToken:  "secret",
+				EnvKVPath: "{{ .Domain",
+			},
+			expected: "vault: path: template: path:1: unclosed action",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupDeployer(version int, roleID string) func(server *httptest.Server) (*Deployer, error) {
+	return func(server *httptest.Server) (*Deployer, error) {
+		config := NewDefaultConfig()
+		config.Addr = server.URL
+		config.Namespace = "team"
+		config.KVMount = defaultKVMount
+		config.KVPath = defaultKVPath
+		config.KVVersion = version
+		config.HTTPClient = server.Client()
+
+		if roleID != "" {
+			config.AppRoleMount = defaultAppRoleMount
+			config.RoleID = roleID
+			config.SecretID = "secret-id"
+		} else {
+			config.Token = "secret"
+		}
+
+		return NewDeployerConfig(config)
+	}
+}
+
+func expectedData(res *material.Material) map[string]string {
+	return map[string]string{
+		"certificate": string(res.Certificate),
+		"chain":       string(res.Chain),
+		"fullchain":   string(res.FullChain),
+		"private_key": string(res.PrivateKey),
+		"domain":      res.Domain,
+		"not_after":   "2030-01-01T00:00:00Z",
+	}
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	res := deploytest.NewResource(t, "example.com")
+
+	m, err := material.FromResource(res)
+	require.NoError(t, err)
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(2, ""),
+		servermock.CheckHeader().
+			With("X-Vault-Token", "secret").
+			With("X-Vault-Namespace", "team"),
+	).
+		Route("POST /v1/secret/data/lego/example.com", nil,
+			servermock.CheckRequestJSONBodyFromStruct(map[string]any{"data": expectedData(m)}),
+		).
+		Build(t)
+
+	err = deployer.Deploy(t.Context(), res)
+	require.NoError(t, err)
+}
+
+func TestDeployer_Deploy_kv1(t *testing.T) {
+	res := deploytest.NewResource(t, "example.com")
+
+	m, err := material.FromResource(res)
+	require.NoError(t, err)
+
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(1, "")).
+		Route("POST /v1/secret/lego/example.com", nil,
+			servermock.CheckRequestJSONBodyFromStruct(expectedData(m)),
+		).
+		Build(t)
+
+	err = deployer.Deploy(t.Context(), res)
+	require.NoError(t, err)
+}
+
+func TestDeployer_Deploy_appRole(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(2, "role-id")).
+		Route("POST /v1/auth/approle/login",
+			servermock.RawStringResponse(`{"auth":{"client_token":"token-from-approle"}}`),
+			servermock.CheckRequestJSONBody(`{"role_id":"role-id","secret_id":"secret-id"}`),
+		).
+		Route("POST /v1/secret/data/lego/example.com", nil,
+			servermock.CheckHeader().
+				With("X-Vault-Token", "token-from-approle"),
+		).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.NoError(t, err)
+}
+
+func TestDeployer_Deploy_error(t *testing.T) {
+	deployer := servermock.NewBuilder[*Deployer](setupDeployer(2, "")).
+		Route("POST /v1/secret/data/lego/example.com",
+			servermock.RawStringResponse(`{"errors":["permission denied"]}`).
+				WithStatusCode(http.StatusForbidden)).
+		Build(t)
+
+	err := deployer.Deploy(t.Context(), deploytest.NewResource(t, "example.com"))
+	require.EqualError(t, err, "vault: write secret secret/lego/example.com: 403: permission denied")
+}
@@ -0,0 +1,165 @@
+// Package wincertstore implements a deployer importing the certificates in the certificate store of the local machine (Windows).
+package wincertstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/certstore"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/httpsys"
+	"github.com/go-acme/lego/v4/providers/deploy/internal/material"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "WINCERTSTORE_"
+
+	EnvStore        = envNamespace + "STORE"
+	EnvFriendlyName = envNamespace + "FRIENDLY_NAME"
+	EnvGrantRead    = envNamespace + "GRANT_READ"
+	EnvBindings     = envNamespace + "BINDINGS"
+)
+
+const defaultStore = "My"
+
+// Config is used to configure the creation of the Deployer.
+type Config struct {
+	// Store the store of the local machine (e.g. `My` for LocalMachine\My, `WebHosting`).
+	Store string
+
+	// FriendlyName the template of the friendly name of the certificate (optional).
+	FriendlyName string
+
+	// GrantRead the accounts allowed to read the private key (e.g. `IIS AppPool\DefaultAppPool`, `NT SERVICE\MyService`).
+	GrantRead []string
+
+	// Bindings the SSL bindings of HTTP.sys (used by IIS) to update: `ip:port`, `*:port`, or `hostname:port` (SNI).
+	Bindings []string
+}
+
+// NewDefaultConfig returns a default configuration for the Deployer.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Store:        env.GetOrDefaultString(EnvStore, defaultStore),
+		FriendlyName: env.GetOrFile(EnvFriendlyName),
+	}
+}
+
+// TemplateData the data of the template of the friendly name.
+type TemplateData struct {
+	// Domain the main domain of the certificate.
+	Domain string
+	// NotAfter the expiration date of the certificate.
+	NotAfter time.Time
+}
+
+// Deployer imports the certificates in the certificate store of the local machine,
+// and updates the SSL bindings using them.
+type Deployer struct {
+	config *Config
+
+	friendlyName *template.Template
+	bindings     []httpsys.Binding
+}
+
+// NewDeployer returns a Deployer instance configured for the certificate store of the local machine.
+func NewDeployer() (*Deployer, error) {
+	config := NewDefaultConfig()
+
+	if accounts := env.GetOrFile(EnvGrantRead); accounts != "" {
+		config.GrantRead = strings.Split(accounts, ",")
+	}
+
+	if bindings := env.GetOrFile(EnvBindings); bindings != "" {
+		config.Bindings = strings.Split(bindings, ",")
+	}
+
+	return NewDeployerConfig(config)
+}
+
+// NewDeployerConfig return a Deployer instance configured for the certificate store of the local machine.
+func NewDeployerConfig(config *Config) (*Deployer, error) {
+	if config == nil {
+		return nil, errors.New("wincertstore: the configuration of the deployer is nil")
+	}
+
+	if config.Store == "" {
+		return nil, errors.New("wincertstore: the store is missing")
+	}
+
+	friendlyName, err := template.New("friendlyName").Parse(config.FriendlyName)
+	if err != nil {
+		return nil, fmt.Errorf("wincertstore: friendly name: %w", err)
+	}
+
+	var bindings []httpsys.Binding
+
+	for _, raw := range config.Bindings {
+		binding, errP := httpsys.ParseBinding(raw)
+		if errP != nil {
+			return nil, fmt.Errorf("wincertstore: %w", errP)
+		}
+
+		bindings = append(bindings, binding)
+	}
+
+	return &Deployer{
+		config:       config,
+		friendlyName: friendlyName,
+		bindings:     bindings,
+	}, nil
+}
+
+// Deploy imports the certificate and its private key (CNG) in the store, then updates the bindings with the thumbprint of the certificate.
+func (d *Deployer) Deploy(_ context.Context, res *certificate.Resource) error {
+	m, err := material.FromResource(res)
+	if err != nil {
+		return fmt.Errorf("wincertstore: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = d.friendlyName.Execute(buf, TemplateData{Domain: res.Domain, NotAfter: m.Leaf.NotAfter})
+	if err != nil {
+		return fmt.Errorf("wincertstore: friendly name: %w", err)
+	}
+
+	// The PKCS#12 archive is only used to transfer the private key to the store.
+	password := rand.Text()
+
+	pfx, err := m.PKCS12(password)
+	if err != nil {
+		return fmt.Errorf("wincertstore: %w", err)
+	}
+
+	var accounts []string
+	for _, account := range d.config.GrantRead {
+		accounts = append(accounts, strings.TrimSpace(account))
+	}
+
+	thumbprint, err := certstore.Import(pfx, password, certstore.Options{
+		StoreName:    d.config.Store,
+		FriendlyName: buf.String(),
+		GrantRead:    accounts,
+	})
+	if err != nil {
+		return fmt.Errorf("wincertstore: import certificate: %w", err)
+	}
+
+	for _, binding := range d.bindings {
+		err = httpsys.Update(binding, thumbprint, d.config.Store)
+		if err != nil {
+			return fmt.Errorf("wincertstore: update binding %s: %w", binding, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package wincertstore
+
+import (
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvStore, EnvFriendlyName, EnvGrantRead, EnvBindings)
+
+func TestNewDeployer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvFriendlyName: "{{ .Domain }} ({{ .NotAfter.Format \"2006-01-02\" }})",
+				EnvGrantRead:    `IIS AppPool\DefaultAppPool`,
+				EnvBindings:     "*:443,www.example.com:443",
+			},
+		},
+		{
+			desc:    "default values",
+			envVars: map[string]string{},
+		},
+		{
+			desc: "invalid binding",
+			envVars: map[string]string{
+				EnvBindings: "example.com",
+			},
+			expected: `wincertstore: binding "example.com": address example.com: missing port in address`,
+		},
+		{
+			desc: "invalid friendly name",
+			envVars: map[string]string{
+				EnvFriendlyName: "{{ .Domain",
+			},
+			expected: "wincertstore: friendly name: template: friendlyName:1: unclosed action",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			d, err := NewDeployer()
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				require.NotNil(t, d.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDeployerConfig(t *testing.T) {
+	config := &Config{
+		Store:    "My",
+		Bindings: []string{"192.0.2.1:443", " www.example.com:8443"},
+	}
+
+	d, err := NewDeployerConfig(config)
+	require.NoError(t, err)
+
+	require.Len(t, d.bindings, 2)
+	assert.Equal(t, "192.0.2.1:443", d.bindings[0].String())
+	assert.Equal(t, "www.example.com:8443", d.bindings[1].String())
+
+	config.Store = ""
+
+	_, err = NewDeployerConfig(config)
+	require.EqualError(t, err, "wincertstore: the store is missing")
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
 	"golang.org/x/net/idna"
 )
@@ -102,6 +103,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("alicloud: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.SecretKey, config.SecurityToken)
+
 	if config.RegionID == "" {
 		config.RegionID = defaultRegionID
 	}
@@ -14,6 +14,7 @@ import (
 	esa "github.com/go-acme/esa-20240910/v2/client"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
 )
 
@@ -97,6 +98,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("aliesa: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.SecretKey, config.SecurityToken)
+
 	if config.RegionID == "" {
 		config.RegionID = defaultRegionID
 	}
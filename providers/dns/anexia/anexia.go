@@ -14,6 +14,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/anexia/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -85,6 +86,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("anexia: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Token)
+
 	if config.Token == "" {
 		return nil, errors.New("anexia: incomplete credentials, missing token")
 	}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/artfiles/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -75,6 +76,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("artfiles: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password)
+
 	client, err := internal.NewClient(config.Username, config.Password)
 	if err != nil {
 		return nil, fmt.Errorf("artfiles: %w", err)
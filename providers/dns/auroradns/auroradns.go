@@ -10,6 +10,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/miekg/dns"
 	"github.com/nrdcg/auroradns"
@@ -83,6 +84,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("aurora: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Secret)
+
 	if config.APIKey == "" || config.Secret == "" {
 		return nil, errors.New("aurora: some credentials information are missing")
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/aziontech/azionapi-go-sdk/idns"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
 
@@ -77,6 +78,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("azion: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.PersonalToken)
+
 	if config.PersonalToken == "" {
 		return nil, errors.New("azion: missing credentials")
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -136,6 +137,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("azure: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.ClientSecret)
+
 	if !env.GetOrDefaultBool(EnvLegoAzureBypassDeprecation, false) {
 		var msg strings.Builder
 
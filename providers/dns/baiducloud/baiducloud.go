@@ -9,6 +9,7 @@ import (
 	baidudns "github.com/baidubce/bce-sdk-go/services/dns"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
 )
 
@@ -72,6 +73,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("baiducloud: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.SecretAccessKey)
+
 	if config.AccessKeyID == "" && config.SecretAccessKey == "" {
 		return nil, errors.New("baiducloud: credentials missing")
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/beget/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -81,6 +82,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("beget: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password)
+
 	if config.Username == "" || config.Password == "" {
 		return nil, errors.New("beget: incomplete credentials, missing username and/or password")
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/brandit/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -84,6 +85,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("brandit: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey)
+
 	client, err := internal.NewClient(config.APIUsername, config.APIKey)
 	if err != nil {
 		return nil, fmt.Errorf("brandit: %w", err)
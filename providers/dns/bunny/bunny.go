@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
 	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
@@ -83,6 +84,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("bunny: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey)
+
 	if config.APIKey == "" {
 		return nil, errors.New("bunny: credentials missing")
 	}
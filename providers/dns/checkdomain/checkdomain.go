@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/checkdomain/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -80,6 +81,8 @@ func NewDNSProvider() (*DNSProvider, error) {
 }
 
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	redact.Register(config.Token)
+
 	if config.Endpoint == nil {
 		return nil, errors.New("checkdomain: invalid endpoint")
 	}
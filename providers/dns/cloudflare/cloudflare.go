@@ -15,6 +15,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/cloudflare/internal"
 )
 
@@ -46,7 +47,10 @@ const (
 	minTTL = 120
 )
 
-var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
+var (
+	_ challenge.ProviderTimeout = (*DNSProvider)(nil)
+	_ dns01.MultiTXTProvider    = (*DNSProvider)(nil)
+)
 
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
@@ -131,6 +135,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("cloudflare: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.AuthKey, config.AuthToken, config.ZoneToken)
+
 	if config.TTL < minTTL {
 		return nil, fmt.Errorf("cloudflare: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
 	}
@@ -153,6 +159,11 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
+// MultipleTXTValues a TXT record is created for each value.
+func (d *DNSProvider) MultipleTXTValues() bool {
+	return true
+}
+
 // Present creates a TXT record to fulfill the dns-01 challenge.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	ctx := context.Background()
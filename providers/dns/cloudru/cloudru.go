@@ -13,6 +13,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/cloudru/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -91,6 +92,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("cloudru: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Secret)
+
 	if config.ServiceInstanceID == "" || config.KeyID == "" || config.Secret == "" {
 		return nil, errors.New("cloudru: some credentials information are missing")
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/conohav3/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -86,6 +87,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("conohav3: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password)
+
 	if config.TenantID == "" || config.UserID == "" || config.Password == "" {
 		return nil, errors.New("conohav3: some credentials information are missing")
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/constellix/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/hashicorp/go-retryablehttp"
@@ -83,6 +84,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("constellix: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey, config.SecretKey)
+
 	if config.SecretKey == "" || config.APIKey == "" {
 		return nil, errors.New("constellix: incomplete credentials, missing secret key and/or API key")
 	}
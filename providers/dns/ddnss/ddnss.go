@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/ddnss/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -76,6 +77,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("ddnss: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Key)
+
 	client, err := internal.NewClient(&internal.Authentication{Key: config.Key})
 	if err != nil {
 		return nil, fmt.Errorf("ddnss: %w", err)
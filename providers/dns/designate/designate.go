@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"os"
 	"slices"
 	"sync"
 	"time"
@@ -13,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/recordsets"
@@ -109,13 +109,15 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("designate: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.opts.Password, config.opts.ApplicationCredentialSecret, config.opts.TokenID)
+
 	provider, err := openstack.AuthenticatedClient(config.opts)
 	if err != nil {
 		return nil, fmt.Errorf("designate: failed to authenticate: %w", err)
 	}
 
 	dnsClient, err := openstack.NewDNSV2(provider, gophercloud.EndpointOpts{
-		Region: os.Getenv("OS_REGION_NAME"),
+		Region: env.GetOrFile("OS_REGION_NAME"),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("designate: failed to get DNS provider: %w", err)
@@ -10,6 +10,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/directadmin/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -83,6 +84,8 @@ func NewDNSProvider() (*DNSProvider, error) {
 
 // NewDNSProviderConfig return a DNSProvider instance configured for DirectAdmin.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	redact.Register(config.Password)
+
 	if config.BaseURL == "" {
 		return nil, errors.New("directadmin: missing API URL")
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/nrdcg/dnspod-go"
 )
@@ -76,6 +77,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("dnspod: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.LoginToken)
+
 	if config.LoginToken == "" {
 		return nil, errors.New("dnspod: credentials missing")
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/domeneshop/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -77,6 +78,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("domeneshop: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIToken, config.APISecret)
+
 	if config.APIToken == "" || config.APISecret == "" {
 		return nil, errors.New("domeneshop: credentials missing")
 	}
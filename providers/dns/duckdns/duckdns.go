@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/duckdns/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -28,7 +29,10 @@ const (
 	EnvSequenceInterval   = envNamespace + "SEQUENCE_INTERVAL"
 )
 
-var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
+var (
+	_ challenge.ProviderTimeout = (*DNSProvider)(nil)
+	_ dns01.MultiTXTProvider    = (*DNSProvider)(nil)
+)
 
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
@@ -77,6 +81,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("duckdns: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Token)
+
 	if config.Token == "" {
 		return nil, errors.New("duckdns: credentials missing")
 	}
@@ -92,6 +98,11 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	return &DNSProvider{config: config, client: client}, nil
 }
 
+// MultipleTXTValues DuckDNS manages a single TXT value per domain.
+func (d *DNSProvider) MultipleTXTValues() bool {
+	return false
+}
+
 // Present creates a TXT record to fulfill the dns-01 challenge.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	info := dns01.GetChallengeInfo(domain, keyAuth)
@@ -1,10 +1,15 @@
 package duckdns
 
 import (
+	"net/http"
+	"os"
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/acceptance"
+	"github.com/go-acme/lego/v4/platform/tester/vcr"
 	"github.com/stretchr/testify/require"
 )
 
@@ -89,6 +94,43 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+// The cassette is recorded with LEGO_VCR_RECORD=true, DUCKDNS_TOKEN, and DUCKDNS_DOMAIN.
+func TestDNSProvider_PresentCleanUp_vcr(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Token = vcr.Redacted
+
+	if vcr.IsRecording() {
+		config.Token = envTest.GetValue(EnvToken)
+	}
+
+	var domain string
+
+	config.HTTPClient, domain = vcr.NewForDomain(t, "present_cleanup", envTest.GetDomain(), config.Token)
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.Present(domain, "", "123d==")
+	require.NoError(t, err)
+
+	err = provider.CleanUp(domain, "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestAcceptance(t *testing.T) {
+	acceptance.Run(t, acceptance.Config{
+		EnvTest: envTest,
+		Secrets: []string{EnvToken},
+		NewProvider: func(client *http.Client) (challenge.Provider, error) {
+			config := NewDefaultConfig()
+			config.Token = os.Getenv(EnvToken)
+			config.HTTPClient = client
+
+			return NewDNSProviderConfig(config)
+		},
+	})
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
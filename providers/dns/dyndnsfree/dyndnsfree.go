@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/dyndnsfree/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -73,6 +74,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("dyndnsfree: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password)
+
 	client, err := internal.NewClient(config.Username, config.Password)
 	if err != nil {
 		return nil, fmt.Errorf("dyndnsfree: new client: %w", err)
@@ -17,6 +17,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
 )
 
@@ -113,6 +114,10 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("edgedns: the configuration of the DNS provider is nil")
 	}
 
+	if config.Config != nil {
+		redact.Register(config.ClientToken, config.ClientSecret, config.AccessToken)
+	}
+
 	err := config.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("edgedns: %w", err)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
 	teo "github.com/go-acme/tencentedgdeone/v20220901"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
@@ -98,6 +99,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("edgeone: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.SecretKey, config.SessionToken)
+
 	var credential *common.Credential
 
 	switch {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/eurodns/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -76,6 +77,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("eurodns: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey)
+
 	client, err := internal.NewClient(config.ApplicationID, config.APIKey)
 	if err != nil {
 		return nil, fmt.Errorf("eurodns: %w", err)
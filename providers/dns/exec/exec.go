@@ -6,7 +6,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
 	"time"
 
@@ -63,7 +62,7 @@ func NewDNSProvider() (*DNSProvider, error) {
 
 	config := NewDefaultConfig()
 	config.Program = values[EnvPath]
-	config.Mode = os.Getenv(EnvMode)
+	config.Mode = env.GetOrFile(EnvMode)
 
 	return NewDNSProviderConfig(config)
 }
@@ -13,14 +13,14 @@ import (
 )
 
 func TestDNSProvider_Present(t *testing.T) {
-	backupLogger := log.Logger
+	backupLogger := log.Default()
 
 	defer func() {
-		log.Logger = backupLogger
+		log.SetDefault(backupLogger)
 	}()
 
 	logRecorder := &LogRecorder{}
-	log.Logger = logRecorder
+	log.SetDefault(logRecorder)
 
 	type expected struct {
 		args  string
@@ -64,7 +64,7 @@ func TestDNSProvider_Present(t *testing.T) {
 
 	var message string
 
-	logRecorder.On("Println", mock.Anything).Run(func(args mock.Arguments) {
+	logRecorder.On("Info", mock.Anything).Run(func(args mock.Arguments) {
 		message = args.String(0)
 		fmt.Fprintln(os.Stdout, "XXX", message)
 	})
@@ -88,14 +88,14 @@ func TestDNSProvider_Present(t *testing.T) {
 }
 
 func TestDNSProvider_CleanUp(t *testing.T) {
-	backupLogger := log.Logger
+	backupLogger := log.Default()
 
 	defer func() {
-		log.Logger = backupLogger
+		log.SetDefault(backupLogger)
 	}()
 
 	logRecorder := &LogRecorder{}
-	log.Logger = logRecorder
+	log.SetDefault(logRecorder)
 
 	type expected struct {
 		args  string
@@ -139,7 +139,7 @@ func TestDNSProvider_CleanUp(t *testing.T) {
 
 	var message string
 
-	logRecorder.On("Println", mock.Anything).Run(func(args mock.Arguments) {
+	logRecorder.On("Info", mock.Anything).Run(func(args mock.Arguments) {
 		message = args.String(0)
 		fmt.Fprintln(os.Stdout, "XXX", message)
 	})
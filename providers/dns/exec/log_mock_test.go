@@ -6,26 +6,18 @@ type LogRecorder struct {
 	mock.Mock
 }
 
-func (*LogRecorder) Fatal(args ...any) {
+func (*LogRecorder) Debug(msg string, args ...any) {
 	panic("implement me")
 }
 
-func (*LogRecorder) Fatalln(args ...any) {
-	panic("implement me")
-}
-
-func (*LogRecorder) Fatalf(format string, args ...any) {
-	panic("implement me")
+func (l *LogRecorder) Info(msg string, args ...any) {
+	l.Called(msg)
 }
 
-func (*LogRecorder) Print(args ...any) {
+func (*LogRecorder) Warn(msg string, args ...any) {
 	panic("implement me")
 }
 
-func (l *LogRecorder) Println(args ...any) {
-	l.Called(args...)
-}
-
-func (*LogRecorder) Printf(format string, args ...any) {
+func (*LogRecorder) Error(msg string, args ...any) {
 	panic("implement me")
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/cenkalti/backoff/v5"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/platform/wait"
 	"github.com/go-acme/lego/v4/providers/dns/f5xc/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
@@ -84,6 +85,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("f5xc: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIToken)
+
 	if config.GroupName == "" {
 		return nil, errors.New("f5xc: missing group name")
 	}
@@ -1,10 +1,14 @@
 package freemyip
 
 import (
+	"net/http"
+	"os"
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/acceptance"
 	"github.com/stretchr/testify/require"
 )
 
@@ -89,6 +93,20 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestAcceptance(t *testing.T) {
+	acceptance.Run(t, acceptance.Config{
+		EnvTest: envTest,
+		Secrets: []string{EnvToken},
+		NewProvider: func(client *http.Client) (challenge.Provider, error) {
+			config := NewDefaultConfig()
+			config.Token = os.Getenv(EnvToken)
+			config.HTTPClient = client
+
+			return NewDNSProviderConfig(config)
+		},
+	})
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -14,6 +14,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/gandiv5/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -95,6 +96,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("gandiv5: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey, config.PersonalAccessToken)
+
 	if config.APIKey != "" {
 		log.Print("gandiv5: API Key is deprecated, use Personal Access Token instead")
 	}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/gigahostno/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -85,6 +86,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("gigahostno: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password, config.Secret)
+
 	identifier, err := internal.NewIdentifier(config.Username, config.Password, config.Secret)
 	if err != nil {
 		return nil, fmt.Errorf("gigahostno: %w", err)
@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/godaddy/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -82,6 +83,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("godaddy: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey, config.APISecret)
+
 	if config.APIKey == "" || config.APISecret == "" {
 		return nil, errors.New("godaddy: credentials missing")
 	}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/gravity/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/google/uuid"
@@ -84,6 +85,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("gravity: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password)
+
 	client, err := internal.NewClient(config.ServerURL, config.Username, config.Password)
 	if err != nil {
 		return nil, fmt.Errorf("gravity: %w", err)
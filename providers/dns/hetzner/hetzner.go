@@ -10,6 +10,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/hetzner/internal/hetznerv1"
 	"github.com/go-acme/lego/v4/providers/dns/hetzner/internal/legacy"
 )
@@ -100,6 +101,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("hetzner: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey, config.APIToken)
+
 	switch {
 	case config.APIToken != "":
 		cfg := &hetznerv1.Config{
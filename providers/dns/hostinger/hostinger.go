@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/hostinger/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -74,6 +75,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("hostinger: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIToken)
+
 	client, err := internal.NewClient(config.APIToken)
 	if err != nil {
 		return nil, fmt.Errorf("hostinger: %w", err)
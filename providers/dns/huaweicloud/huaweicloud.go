@@ -14,6 +14,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/platform/wait"
 	"github.com/go-acme/lego/v4/providers/dns/huaweicloud/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
@@ -94,6 +95,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("huaweicloud: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.SecretAccessKey)
+
 	if config.AccessKeyID == "" || config.SecretAccessKey == "" || config.Region == "" {
 		return nil, errors.New("huaweicloud: credentials missing")
 	}
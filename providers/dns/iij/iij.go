@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/iij/doapi"
 	"github.com/iij/doapi/protocol"
 	"github.com/miekg/dns"
@@ -74,6 +75,8 @@ func NewDNSProvider() (*DNSProvider, error) {
 // NewDNSProviderConfig takes a given config
 // and returns a custom configured DNSProvider instance.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	redact.Register(config.SecretKey)
+
 	if config.SecretKey == "" || config.AccessKey == "" || config.DoServiceCode == "" {
 		return nil, errors.New("iij: credentials missing")
 	}
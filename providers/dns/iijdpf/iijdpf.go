@@ -10,6 +10,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/miekg/dns"
 	dpfapi "github.com/mimuret/golang-iij-dpf/pkg/api"
 	dpfapiutils "github.com/mimuret/golang-iij-dpf/pkg/apiutils"
@@ -74,6 +75,8 @@ func NewDNSProvider() (*DNSProvider, error) {
 // NewDNSProviderConfig takes a given config
 // and returns a custom configured DNSProvider instance.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	redact.Register(config.Token)
+
 	if config.Token == "" {
 		return nil, errors.New("iijdpf: API token missing")
 	}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/active24/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
@@ -37,6 +38,8 @@ func NewDNSProviderConfig(config *Config, baseAPIDomain string) (*DNSProvider, e
 		return nil, errors.New("the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey, config.Secret)
+
 	client, err := internal.NewClient(baseAPIDomain, config.APIKey, config.Secret)
 	if err != nil {
 		return nil, err
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 )
 
 const replacement = "***"
@@ -104,7 +105,7 @@ func (d *DumpTransport) RoundTrip(h *http.Request) (*http.Response, error) {
 }
 
 func (d *DumpTransport) redact(content []byte) string {
-	data := string(content)
+	data := redact.String(string(content))
 
 	for _, r := range d.regexps {
 		data = r.ReplaceAllString(data, "$1: "+replacement)
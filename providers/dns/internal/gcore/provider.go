@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/internal/gcore/internal"
 )
@@ -43,6 +44,8 @@ func NewDNSProviderConfig(config *Config, baseURL string) (*DNSProvider, error)
 		return nil, errors.New("the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIToken)
+
 	if config.APIToken == "" {
 		return nil, errors.New("incomplete credentials provided")
 	}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	ionos "github.com/go-acme/lego/v4/providers/dns/internal/ionos/internal"
 )
@@ -41,6 +42,8 @@ func NewDNSProviderConfig(config *Config, baseURL string) (*DNSProvider, error)
 		return nil, errors.New("the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey)
+
 	if config.APIKey == "" {
 		return nil, errors.New("credentials missing")
 	}
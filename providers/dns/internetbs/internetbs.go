@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/internetbs/internal"
 )
@@ -79,6 +80,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("internetbs: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey, config.Password)
+
 	if config.APIKey == "" || config.Password == "" {
 		return nil, errors.New("internetbs: missing credentials")
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/nrdcg/goinwx"
 	"github.com/pquerna/otp/totp"
 )
@@ -82,6 +83,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("inwx: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password, config.SharedSecret)
+
 	if config.Username == "" || config.Password == "" {
 		return nil, errors.New("inwx: credentials missing")
 	}
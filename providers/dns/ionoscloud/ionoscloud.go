@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/ionoscloud/internal"
 )
@@ -78,6 +79,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("ionoscloud: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIToken)
+
 	client, err := internal.NewClient(config.APIToken)
 	if err != nil {
 		return nil, fmt.Errorf("ionoscloud: %w", err)
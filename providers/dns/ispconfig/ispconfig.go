@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/ispconfig/internal"
 )
@@ -88,6 +89,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("ispconfig: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password)
+
 	if config.ServerURL == "" {
 		return nil, errors.New("ispconfig: missing server URL")
 	}
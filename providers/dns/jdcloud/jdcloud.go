@@ -14,6 +14,7 @@ import (
 	domainservice "github.com/go-acme/jdcloud-sdk-go/services/domainservice/models"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 )
 
 // Environment variables names.
@@ -85,6 +86,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("jdcloud: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.AccessKeySecret)
+
 	if config.AccessKeyID == "" || config.AccessKeySecret == "" {
 		return nil, errors.New("jdcloud: missing credentials")
 	}
@@ -3,12 +3,12 @@ package joker
 
 import (
 	"net/http"
-	"os"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 )
 
 // Environment variables names.
@@ -65,7 +65,7 @@ func NewDefaultConfig() *Config {
 // NewDNSProvider returns a DNSProvider instance configured for Joker.
 // Credentials must be passed in the environment variable JOKER_API_KEY.
 func NewDNSProvider() (challenge.ProviderTimeout, error) {
-	if os.Getenv(EnvMode) == modeSVC {
+	if env.GetOrFile(EnvMode) == modeSVC {
 		return newSvcProvider()
 	}
 
@@ -74,6 +74,8 @@ func NewDNSProvider() (challenge.ProviderTimeout, error) {
 
 // NewDNSProviderConfig return a DNSProvider instance configured for Joker.
 func NewDNSProviderConfig(config *Config) (challenge.ProviderTimeout, error) {
+	redact.Register(config.Password, config.APIKey)
+
 	if config.APIMode == modeSVC {
 		return newSvcProviderConfig(config)
 	}
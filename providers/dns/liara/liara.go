@@ -13,6 +13,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/liara/internal"
 	"github.com/hashicorp/go-retryablehttp"
@@ -91,6 +92,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("liara: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey)
+
 	if config.APIKey == "" {
 		return nil, errors.New("liara: APIKey is missing")
 	}
@@ -110,7 +113,7 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		retryClient.HTTPClient = config.HTTPClient
 	}
 
-	retryClient.Logger = log.Logger
+	retryClient.Logger = log.Default()
 
 	client := internal.NewClient(
 		clientdebug.Wrap(
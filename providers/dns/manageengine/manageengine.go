@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/manageengine/internal"
 )
@@ -72,6 +73,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("manageengine: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.ClientSecret)
+
 	if config.ClientID == "" || config.ClientSecret == "" {
 		return nil, errors.New("manageengine: credentials missing")
 	}
@@ -5,12 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/myaddr/internal"
 )
@@ -83,6 +86,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("myaddr: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(slices.Collect(maps.Values(config.Credentials))...)
+
 	client, err := internal.NewClient(config.Credentials)
 	if err != nil {
 		return nil, fmt.Errorf("myaddr: %w", err)
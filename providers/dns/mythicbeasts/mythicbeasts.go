@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/mythicbeasts/internal"
 )
@@ -101,6 +102,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("mythicbeasts: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password)
+
 	if config.UserName == "" || config.Password == "" {
 		return nil, errors.New("mythicbeasts: incomplete credentials, missing username and/or password")
 	}
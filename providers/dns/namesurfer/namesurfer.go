@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/namesurfer/internal"
 )
@@ -95,6 +96,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("namesurfer: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APISecret)
+
 	client, err := internal.NewClient(config.BaseURL, config.APIKey, config.APISecret)
 	if err != nil {
 		return nil, fmt.Errorf("namesurfer: %w", err)
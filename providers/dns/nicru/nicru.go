@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/nicru/internal"
 )
@@ -79,6 +80,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("nicru: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password, config.Secret)
+
 	clientCfg := &internal.OauthConfiguration{
 		OAuth2ClientID: config.ServiceID,
 		OAuth2SecretID: config.Secret,
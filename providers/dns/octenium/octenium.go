@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/octenium/internal"
 	"github.com/hashicorp/go-retryablehttp"
@@ -79,6 +80,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("octenium: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey)
+
 	client, err := internal.NewClient(config.APIKey)
 	if err != nil {
 		return nil, fmt.Errorf("octenium: %w", err)
@@ -91,7 +94,7 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	retryClient := retryablehttp.NewClient()
 	retryClient.RetryMax = 5
 	retryClient.HTTPClient = client.HTTPClient
-	retryClient.Logger = log.Logger
+	retryClient.Logger = log.Default()
 
 	client.HTTPClient = clientdebug.Wrap(retryClient.StandardClient())
 
@@ -13,6 +13,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/onecloudru/internal"
 )
@@ -84,6 +85,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("onecloudru: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Token)
+
 	client, err := internal.NewClient(config.Token)
 	if err != nil {
 		return nil, fmt.Errorf("onecloudru: %w", err)
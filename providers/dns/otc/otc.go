@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/otc/internal"
 )
@@ -113,6 +114,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("otc: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password)
+
 	if config.DomainName == "" || config.UserName == "" || config.Password == "" || config.ProjectName == "" {
 		return nil, errors.New("otc: credentials missing")
 	}
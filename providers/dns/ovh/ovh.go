@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/ovh/go-ovh/ovh"
@@ -143,6 +144,12 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("ovh: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.ApplicationSecret, config.ConsumerKey, config.AccessToken)
+
+	if config.OAuth2Config != nil {
+		redact.Register(config.OAuth2Config.ClientSecret)
+	}
+
 	if config.OAuth2Config != nil && config.hasAppKeyAuth() && config.AccessToken != "" {
 		return nil, errors.New("ovh: can't use multiple authentication systems (ApplicationKey, OAuth2, Access Token)")
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/nrdcg/porkbun"
 )
@@ -87,6 +88,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("porkbun: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey, config.SecretAPIKey)
+
 	if config.SecretAPIKey == "" || config.APIKey == "" {
 		return nil, errors.New("porkbun: some credentials information are missing")
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/regru/internal"
 )
@@ -88,6 +89,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("regru: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.Password, config.TLSKey)
+
 	if config.Username == "" || config.Password == "" {
 		return nil, errors.New("regru: incomplete credentials, missing username and/or password")
 	}
@@ -15,6 +15,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/rfc2136/internal"
 	"github.com/miekg/dns"
 )
@@ -66,7 +67,10 @@ const (
 	actionInsert = "INSERT"
 )
 
-var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
+var (
+	_ challenge.ProviderTimeout = (*DNSProvider)(nil)
+	_ dns01.MultiTXTProvider    = (*DNSProvider)(nil)
+)
 
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
@@ -173,6 +177,9 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("dnsupdate: %w", err)
 	}
 
+	// The secret can be read from the TSIG file.
+	redact.Register(config.TSIGSecret, config.TSIGGSSPassword)
+
 	slices.SortFunc(config.Zones, func(a, b string) int {
 		return cmp.Compare(len(dns.Split(b)), len(dns.Split(a)))
 	})
@@ -192,6 +199,11 @@ func (d *DNSProvider) Sequential() time.Duration {
 	return d.config.SequenceInterval
 }
 
+// MultipleTXTValues the insertion of a value replaces the TXT record set of the FQDN.
+func (d *DNSProvider) MultipleTXTValues() bool {
+	return false
+}
+
 // Present creates a TXT record using the specified parameters.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	info := dns01.GetChallengeInfo(domain, keyAuth)
@@ -243,6 +255,75 @@ func (d *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 		return fmt.Errorf("unexpected action: %s", action)
 	}
 
+	return d.exchange(m)
+}
+
+// SetCAA replaces the CAA records of the domain.
+func (d *DNSProvider) SetCAA(domain string, records []dns01.CAARecord) error {
+	fqdn := dns.Fqdn(domain)
+
+	var rrs []dns.RR
+
+	for _, record := range records {
+		rrs = append(rrs, &dns.CAA{
+			Hdr:   dns.RR_Header{Name: fqdn, Rrtype: dns.TypeCAA, Class: dns.ClassINET, Ttl: uint32(d.config.TTL)},
+			Flag:  record.Flag,
+			Tag:   record.Tag,
+			Value: record.Value,
+		})
+	}
+
+	err := d.setRRset(fqdn, dns.TypeCAA, rrs)
+	if err != nil {
+		return fmt.Errorf("dnsupdate: failed to set CAA records: %w", err)
+	}
+
+	return nil
+}
+
+// SetTLSA replaces the TLSA records of the fqdn.
+func (d *DNSProvider) SetTLSA(fqdn string, records []dns01.TLSARecord) error {
+	fqdn = dns.Fqdn(fqdn)
+
+	var rrs []dns.RR
+
+	for _, record := range records {
+		rrs = append(rrs, &dns.TLSA{
+			Hdr:          dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTLSA, Class: dns.ClassINET, Ttl: uint32(d.config.TTL)},
+			Usage:        record.Usage,
+			Selector:     record.Selector,
+			MatchingType: record.MatchingType,
+			Certificate:  record.Certificate,
+		})
+	}
+
+	err := d.setRRset(fqdn, dns.TypeTLSA, rrs)
+	if err != nil {
+		return fmt.Errorf("dnsupdate: failed to set TLSA records: %w", err)
+	}
+
+	return nil
+}
+
+// setRRset replaces the RRset of the given type, in a single update.
+func (d *DNSProvider) setRRset(fqdn string, rrType uint16, rrs []dns.RR) error {
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	m := new(dns.Msg).SetUpdate(zone)
+
+	m.RemoveRRset([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: fqdn, Rrtype: rrType, Class: dns.ClassINET}}})
+
+	if len(rrs) > 0 {
+		m.Insert(rrs)
+	}
+
+	return d.exchange(m)
+}
+
+func (d *DNSProvider) exchange(m *dns.Msg) error {
 	// Setup client
 	c := &dns.Client{Timeout: d.config.DNSTimeout}
 
@@ -250,18 +331,14 @@ func (d *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 	if d.config.TSIGAlgorithm == tsig.GSS {
 		c.Net = "tcp"
 
-		var gssClient *gss.Client
-
-		gssClient, err = gss.NewClient(c)
+		gssClient, err := gss.NewClient(c)
 		if err != nil {
 			return fmt.Errorf("create GSS client: %w", err)
 		}
 
 		defer func() { _ = gssClient.Close() }()
 
-		var keyName string
-
-		keyName, err = d.negotiate(gssClient)
+		keyName, err := d.negotiate(gssClient)
 		if err != nil {
 			return err
 		}
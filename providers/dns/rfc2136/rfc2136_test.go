@@ -2,11 +2,15 @@ package rfc2136
 
 import (
 	"bytes"
+	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
 	"github.com/miekg/dns"
@@ -236,6 +240,23 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestNewDNSProviderConfig_redact(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Nameserver = "127.0.0.1:53"
+	config.TSIGAlgorithm = dns.HmacSHA256
+	config.TSIGKey = "lego-redact.example.com."
+	config.TSIGSecret = "bGVnby1yZWRhY3Qtc2VjcmV0"
+
+	_, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = redact.Error(fmt.Errorf("dnsupdate: %w",
+		fmt.Errorf("key %s refused (%s)", config.TSIGKey, config.TSIGSecret)))
+
+	// The TSIG key is a name, only the secret is scrubbed.
+	require.EqualError(t, err, "dnsupdate: key lego-redact.example.com. refused (***)")
+}
+
 func TestDNSProvider_Present_success(t *testing.T) {
 	dns01.ClearFqdnCache()
 
@@ -319,6 +340,165 @@ func TestDNSProvider_Present_success_updatePacket(t *testing.T) {
 	}
 }
 
+// The TXT values of the FQDN after the presentation of two challenges must match the declared capability.
+func TestDNSProvider_MultipleTXTValues(t *testing.T) {
+	dns01.ClearFqdnCache()
+
+	defer envTest.RestoreEnv()
+
+	envTest.ClearEnv()
+
+	var (
+		mu     sync.Mutex
+		values []string
+	)
+
+	// Applies the updates of the TXT records of the FQDN.
+	addr := dnsmock.NewServer().
+		Query(fakeFqdn+" SOA", dnsmock.SOA(fakeZone)).
+		Update(fakeZone+" SOA", func(w dns.ResponseWriter, req *dns.Msg) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, rr := range req.Ns {
+				if rr.Header().Name != fakeFqdn || rr.Header().Rrtype != dns.TypeTXT {
+					continue
+				}
+
+				switch rr.Header().Class {
+				case dns.ClassANY:
+					values = nil
+				case dns.ClassNONE:
+					values = slices.DeleteFunc(values, func(v string) bool { return v == rr.(*dns.TXT).Txt[0] })
+				default:
+					values = append(values, rr.(*dns.TXT).Txt[0])
+				}
+			}
+
+			dnsmock.Noop(w, req)
+		}).
+		Build(t)
+
+	config := NewDefaultConfig()
+	config.Nameserver = addr.String()
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	// A domain and its wildcard use the same FQDN.
+	err = provider.Present(fakeDomain, "token1", "keyAuth1")
+	require.NoError(t, err)
+
+	err = provider.Present(fakeDomain, "token2", "keyAuth2")
+	require.NoError(t, err)
+
+	expected := []string{dns01.GetChallengeInfo(fakeDomain, "keyAuth2").Value}
+	if provider.MultipleTXTValues() {
+		expected = append([]string{dns01.GetChallengeInfo(fakeDomain, "keyAuth1").Value}, expected...)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, expected, values)
+}
+
+func TestDNSProvider_SetCAA(t *testing.T) {
+	dns01.ClearFqdnCache()
+
+	defer envTest.RestoreEnv()
+
+	envTest.ClearEnv()
+
+	reqChan := make(chan *dns.Msg, 1)
+
+	addr := dnsmock.NewServer().
+		Query("www.example.com. SOA", dnsmock.SOA(fakeZone)).
+		Update(fakeZone+" SOA", func(w dns.ResponseWriter, req *dns.Msg) {
+			dnsmock.Noop(w, req)
+
+			reqChan <- req
+		}).
+		Build(t)
+
+	config := NewDefaultConfig()
+	config.Nameserver = addr.String()
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.SetCAA("www.example.com", []dns01.CAARecord{{Tag: dns01.CAATagIssue, Value: "letsencrypt.org"}})
+	require.NoError(t, err)
+
+	select {
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for request")
+
+	case rcvMsg := <-reqChan:
+		require.Len(t, rcvMsg.Ns, 2)
+
+		assert.Equal(t, uint16(dns.ClassANY), rcvMsg.Ns[0].Header().Class)
+		assert.Equal(t, dns.TypeCAA, rcvMsg.Ns[0].Header().Rrtype)
+
+		caa, ok := rcvMsg.Ns[1].(*dns.CAA)
+		require.True(t, ok)
+
+		assert.Equal(t, "www.example.com.", caa.Hdr.Name)
+		assert.Equal(t, "issue", caa.Tag)
+		assert.Equal(t, "letsencrypt.org", caa.Value)
+	}
+}
+
+func TestDNSProvider_SetTLSA(t *testing.T) {
+	dns01.ClearFqdnCache()
+
+	defer envTest.RestoreEnv()
+
+	envTest.ClearEnv()
+
+	reqChan := make(chan *dns.Msg, 1)
+
+	addr := dnsmock.NewServer().
+		Query("_25._tcp.mail.example.com. SOA", dnsmock.SOA(fakeZone)).
+		Update(fakeZone+" SOA", func(w dns.ResponseWriter, req *dns.Msg) {
+			dnsmock.Noop(w, req)
+
+			reqChan <- req
+		}).
+		Build(t)
+
+	config := NewDefaultConfig()
+	config.Nameserver = addr.String()
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	records := []dns01.TLSARecord{
+		{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "aa"},
+		{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "bb"},
+	}
+
+	err = provider.SetTLSA("_25._tcp.mail.example.com.", records)
+	require.NoError(t, err)
+
+	select {
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for request")
+
+	case rcvMsg := <-reqChan:
+		require.Len(t, rcvMsg.Ns, 3)
+
+		assert.Equal(t, uint16(dns.ClassANY), rcvMsg.Ns[0].Header().Class)
+		assert.Equal(t, dns.TypeTLSA, rcvMsg.Ns[0].Header().Rrtype)
+
+		tlsa, ok := rcvMsg.Ns[2].(*dns.TLSA)
+		require.True(t, ok)
+
+		assert.Equal(t, "_25._tcp.mail.example.com.", tlsa.Hdr.Name)
+		assert.Equal(t, "bb", tlsa.Certificate)
+	}
+}
+
 func TestDNSProvider_Present_error(t *testing.T) {
 	dns01.ClearFqdnCache()
 
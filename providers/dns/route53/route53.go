@@ -21,6 +21,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/platform/wait"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
 )
@@ -45,7 +46,10 @@ const (
 	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
 )
 
-var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
+var (
+	_ challenge.ProviderTimeout = (*DNSProvider)(nil)
+	_ dns01.MultiTXTProvider    = (*DNSProvider)(nil)
+)
 
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
@@ -115,6 +119,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("route53: the configuration of the Route53 DNS provider is nil")
 	}
 
+	redact.Register(config.SecretAccessKey, config.SessionToken)
+
 	if config.Client != nil {
 		return &DNSProvider{client: config.Client, config: config}, nil
 	}
@@ -137,6 +143,11 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
+// MultipleTXTValues the values are appended to the TXT record set of the FQDN.
+func (d *DNSProvider) MultipleTXTValues() bool {
+	return true
+}
+
 // Present creates a TXT record using the specified parameters.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	ctx := context.Background()
@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/safedns/internal"
 	"github.com/miekg/dns"
@@ -81,6 +82,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("safedns: supplied configuration was nil")
 	}
 
+	redact.Register(config.AuthToken)
+
 	if config.AuthToken == "" {
 		return nil, errors.New("safedns: credentials missing")
 	}
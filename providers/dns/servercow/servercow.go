@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/servercow/internal"
 )
@@ -76,6 +77,8 @@ func NewDNSProvider() (*DNSProvider, error) {
 
 // NewDNSProviderConfig return a DNSProvider instance configured for Servercow.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	redact.Register(config.Password)
+
 	if config.Username == "" || config.Password == "" {
 		return nil, errors.New("servercow: incomplete credentials, missing username and/or password")
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/spaceship/internal"
 )
@@ -76,6 +77,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("spaceship: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey, config.APISecret)
+
 	client, err := internal.NewClient(config.APIKey, config.APISecret)
 	if err != nil {
 		return nil, fmt.Errorf("spaceship: %w", err)
@@ -5,12 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/syse/internal"
 )
@@ -83,6 +86,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("syse: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(slices.Collect(maps.Values(config.Credentials))...)
+
 	if len(config.Credentials) == 0 {
 		return nil, errors.New("syse: missing credentials")
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/timewebcloud/internal"
 )
@@ -78,6 +79,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("timewebcloud: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.AuthToken)
+
 	if config.AuthToken == "" {
 		return nil, errors.New("timewebcloud: authentication token is missing")
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/go-acme/lego/v4/providers/dns/ucloud/internal"
 	"github.com/ucloud/ucloud-sdk-go/ucloud"
@@ -91,6 +92,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("ucloud: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.PrivateKey)
+
 	if config.PublicKey == "" || config.PrivateKey == "" {
 		return nil, errors.New("ucloud: credentials missing")
 	}
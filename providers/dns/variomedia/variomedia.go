@@ -15,6 +15,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/platform/wait"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/variomedia/internal"
@@ -83,6 +84,8 @@ func NewDNSProvider() (*DNSProvider, error) {
 
 // NewDNSProviderConfig return a DNSProvider instance configured for Variomedia.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	redact.Register(config.APIToken)
+
 	if config.APIToken == "" {
 		return nil, errors.New("variomedia: missing credentials")
 	}
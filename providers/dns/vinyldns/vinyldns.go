@@ -12,6 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/vinyldns/go-vinyldns/vinyldns"
@@ -89,6 +90,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("vinyldns: the configuration of the VinylDNS DNS provider is nil")
 	}
 
+	redact.Register(config.AccessKey, config.SecretKey)
+
 	if config.AccessKey == "" || config.SecretKey == "" {
 		return nil, errors.New("vinyldns: credentials are missing")
 	}
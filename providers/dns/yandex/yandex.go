@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/yandex/internal"
 	"github.com/miekg/dns"
@@ -76,6 +77,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("yandex: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.PddToken)
+
 	if config.PddToken == "" {
 		return nil, errors.New("yandex: credentials missing")
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/yandex360/internal"
 	"github.com/miekg/dns"
@@ -90,6 +91,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("yandex360: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.OAuthToken)
+
 	client, err := internal.NewClient(config.OAuthToken, config.OrgID)
 	if err != nil {
 		return nil, fmt.Errorf("yandex360: %w", err)
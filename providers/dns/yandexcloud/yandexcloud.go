@@ -14,6 +14,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	ycdnsproto "github.com/yandex-cloud/go-genproto/yandex/cloud/dns/v1"
 	ycdns "github.com/yandex-cloud/go-sdk/services/dns/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk/v2"
@@ -81,6 +82,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("yandexcloud: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.IamToken)
+
 	if config.IamToken == "" {
 		return nil, errors.New("yandexcloud: some credentials information are missing IAM token")
 	}
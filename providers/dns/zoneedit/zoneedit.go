@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/zoneedit/internal"
 )
@@ -72,6 +73,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("zoneedit: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.AuthToken)
+
 	client, err := internal.NewClient(config.User, config.AuthToken)
 	if err != nil {
 		return nil, fmt.Errorf("zoneedit: %w", err)
@@ -10,6 +10,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/platform/redact"
 	"github.com/go-acme/lego/v4/providers/dns/internal/rimuhosting"
 )
 
@@ -69,6 +70,8 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("zonomi: the configuration of the DNS provider is nil")
 	}
 
+	redact.Register(config.APIKey)
+
 	provider, err := rimuhosting.NewDNSProviderConfig(config, defaultBaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("zonomi: %w", err)
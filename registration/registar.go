@@ -1,12 +1,12 @@
 package registration
 
 import (
+	"crypto"
 	"errors"
 	"net/http"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
-	"github.com/go-acme/lego/v4/log"
 )
 
 const mailTo = "mailto:"
@@ -53,7 +53,7 @@ func (r *Registrar) Register(options RegisterOptions) (*Resource, error) {
 	}
 
 	if r.user.GetEmail() != "" {
-		log.Infof("acme: Registering account for %s", r.user.GetEmail())
+		r.core.Logger().Infof("acme: Registering account for %s", r.user.GetEmail())
 		accMsg.Contact = []string{mailTo + r.user.GetEmail()}
 	}
 
@@ -77,7 +77,7 @@ func (r *Registrar) RegisterWithExternalAccountBinding(options RegisterEABOption
 	}
 
 	if r.user.GetEmail() != "" {
-		log.Infof("acme: Registering account for %s", r.user.GetEmail())
+		r.core.Logger().Infof("acme: Registering account for %s", r.user.GetEmail())
 		accMsg.Contact = []string{mailTo + r.user.GetEmail()}
 	}
 
@@ -103,7 +103,7 @@ func (r *Registrar) QueryRegistration() (*Resource, error) {
 	}
 
 	// Log the URL here instead of the email as the email may not be set
-	log.Infof("acme: Querying account for %s", r.user.GetRegistration().URI)
+	r.core.Logger().Infof("acme: Querying account for %s", r.user.GetRegistration().URI)
 
 	account, err := r.core.Accounts.Get(r.user.GetRegistration().URI)
 	if err != nil {
@@ -117,6 +117,39 @@ func (r *Registrar) QueryRegistration() (*Resource, error) {
 	}, nil
 }
 
+// ListOrders lists the orders of the account, the most recent orders are the first ones if the server sorts them.
+// The limit is the maximum number of orders (no limit if the value is 0 or negative).
+func (r *Registrar) ListOrders(limit int) ([]acme.ExtendedOrder, error) {
+	reg, err := r.QueryRegistration()
+	if err != nil {
+		return nil, err
+	}
+
+	if reg.Body.Orders == "" {
+		return nil, errors.New("acme: the server doesn't provide the list of the orders of the account")
+	}
+
+	urls, err := r.core.Orders.List(reg.Body.Orders, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]acme.ExtendedOrder, 0, len(urls))
+
+	for _, orderURL := range urls {
+		order, err := r.core.Orders.Get(orderURL)
+		if err != nil {
+			return nil, err
+		}
+
+		order.Location = orderURL
+
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
 // UpdateRegistration update the user registration on the ACME server.
 func (r *Registrar) UpdateRegistration(options RegisterOptions) (*Resource, error) {
 	if r == nil || r.user == nil {
@@ -129,7 +162,7 @@ func (r *Registrar) UpdateRegistration(options RegisterOptions) (*Resource, erro
 	}
 
 	if r.user.GetEmail() != "" {
-		log.Infof("acme: Registering account for %s", r.user.GetEmail())
+		r.core.Logger().Infof("acme: Registering account for %s", r.user.GetEmail())
 		accMsg.Contact = []string{mailTo + r.user.GetEmail()}
 	}
 
@@ -143,13 +176,60 @@ func (r *Registrar) UpdateRegistration(options RegisterOptions) (*Resource, erro
 	return &Resource{URI: accountURL, Body: account}, nil
 }
 
+// UpdateContacts replaces the contacts of the account on the ACME server by the email addresses.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.3.2
+func (r *Registrar) UpdateContacts(emails ...string) (*Resource, error) {
+	if r == nil || r.user == nil || r.user.GetRegistration() == nil {
+		return nil, errors.New("acme: cannot update the contacts of a nil client or user")
+	}
+
+	if len(emails) == 0 {
+		return nil, errors.New("acme: at least one contact is required")
+	}
+
+	accMsg := acme.Account{}
+
+	for _, email := range emails {
+		accMsg.Contact = append(accMsg.Contact, mailTo+email)
+	}
+
+	accountURL := r.user.GetRegistration().URI
+
+	r.core.Logger().Infof("acme: Updating the contacts of the account %s", accountURL)
+
+	account, err := r.core.Accounts.Update(accountURL, accMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Resource{URI: accountURL, Body: account}, nil
+}
+
+// RolloverKey replaces the key of the account on the ACME server by the new key.
+// The next requests of the client are signed with the new key:
+// the new key must be stored and returned by the User (GetPrivateKey) for the next clients.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.3.5
+func (r *Registrar) RolloverKey(newKey crypto.PrivateKey) error {
+	if r == nil || r.user == nil || r.user.GetRegistration() == nil {
+		return errors.New("acme: cannot roll over the key of a nil client or user")
+	}
+
+	if newKey == nil {
+		return errors.New("acme: the new key is required")
+	}
+
+	r.core.Logger().Infof("acme: Rolling over the key of the account %s", r.user.GetRegistration().URI)
+
+	return r.core.Accounts.KeyChange(newKey)
+}
+
 // DeleteRegistration deletes the client's user registration from the ACME server.
 func (r *Registrar) DeleteRegistration() error {
 	if r == nil || r.user == nil {
 		return errors.New("acme: cannot unregister a nil client or user")
 	}
 
-	log.Infof("acme: Deleting account for %s", r.user.GetEmail())
+	r.core.Logger().Infof("acme: Deleting account for %s", r.user.GetEmail())
 
 	return r.core.Accounts.Deactivate(r.user.GetRegistration().URI)
 }
@@ -157,7 +237,7 @@ func (r *Registrar) DeleteRegistration() error {
 // ResolveAccountByKey will attempt to look up an account using the given account key
 // and return its registration resource.
 func (r *Registrar) ResolveAccountByKey() (*Resource, error) {
-	log.Infof("acme: Trying to resolve account by key")
+	r.core.Logger().Infof("acme: Trying to resolve account by key")
 
 	accMsg := acme.Account{OnlyReturnExisting: true}
 
@@ -3,14 +3,20 @@ package registration
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"testing"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/platform/pebble"
+	"github.com/go-acme/lego/v4/platform/pebble/pebbletest"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-jose/go-jose/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -45,3 +51,226 @@ func TestRegistrar_ResolveAccountByKey(t *testing.T) {
 
 	assert.Equal(t, "valid", res.Body.Status, "Unexpected account status")
 }
+
+func TestRegistrar_RegisterWithExternalAccountBinding(t *testing.T) {
+	hmacEncoded := base64.RawURLEncoding.EncodeToString([]byte("a-secret-hmac-key-of-32-bytes..."))
+
+	testCases := []struct {
+		desc     string
+		kid      string
+		hmac     string
+		expected string
+	}{
+		{
+			desc: "valid binding",
+			kid:  "kid-1",
+			hmac: hmacEncoded,
+		},
+		{
+			desc:     "unknown key identifier",
+			kid:      "kid-2",
+			hmac:     hmacEncoded,
+			expected: "urn:ietf:params:acme:error:unauthorized",
+		},
+		{
+			desc:     "invalid MAC key",
+			kid:      "kid-1",
+			hmac:     base64.RawURLEncoding.EncodeToString([]byte("another-hmac-key-of-32-bytes....")),
+			expected: "urn:ietf:params:acme:error:unauthorized",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			server := pebbletest.NewServer(t, pebble.WithExternalAccountBinding("kid-1", hmacEncoded))
+
+			registrar := newPebbleRegistrar(t, server)
+
+			res, err := registrar.RegisterWithExternalAccountBinding(RegisterEABOptions{
+				TermsOfServiceAgreed: true,
+				Kid:                  test.kid,
+				HmacEncoded:          test.hmac,
+			})
+
+			if test.expected != "" {
+				require.ErrorContains(t, err, test.expected)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, acme.StatusValid, res.Body.Status)
+			assert.NotEmpty(t, res.URI)
+		})
+	}
+}
+
+func TestRegistrar_Register_externalAccountRequired(t *testing.T) {
+	server := pebbletest.NewServer(t, pebble.WithExternalAccountBinding("kid-1", "c2VjcmV0"))
+
+	registrar := newPebbleRegistrar(t, server)
+
+	_, err := registrar.Register(RegisterOptions{TermsOfServiceAgreed: true})
+	require.ErrorContains(t, err, "urn:ietf:params:acme:error:externalAccountRequired")
+}
+
+func newPebbleRegistrar(t *testing.T, server *pebble.Server) *Registrar {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL(), "", key)
+	require.NoError(t, err)
+
+	return NewRegistrar(core, mockUser{email: "test@test.com", regres: &Resource{}, privatekey: key})
+}
+
+func TestRegistrar_ListOrders(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /account/1",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+				servermock.JSONEncode(acme.Account{Status: "valid", Orders: serverURL + "/orders"}).ServeHTTP(rw, req)
+			})).
+		Route("POST /orders",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+				servermock.JSONEncode(acme.OrdersList{Orders: []string{serverURL + "/order/1"}}).ServeHTTP(rw, req)
+			})).
+		Route("POST /order/1",
+			servermock.JSONEncode(acme.Order{
+				Status:      acme.StatusPending,
+				Expires:     "2025-01-08T00:00:00Z",
+				Identifiers: []acme.Identifier{{Type: "dns", Value: "example.com"}},
+			})).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     &Resource{URI: server.URL + "/account/1"},
+		privatekey: key,
+	}
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", server.URL+"/account/1", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	orders, err := registrar.ListOrders(0)
+	require.NoError(t, err)
+
+	expected := []acme.ExtendedOrder{{
+		Order: acme.Order{
+			Status:      acme.StatusPending,
+			Expires:     "2025-01-08T00:00:00Z",
+			Identifiers: []acme.Identifier{{Type: "dns", Value: "example.com"}},
+		},
+		Location: server.URL + "/order/1",
+	}}
+
+	assert.Equal(t, expected, orders)
+}
+
+func TestRegistrar_UpdateContacts(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Could not generate test key")
+
+	server := tester.MockACMEServer().
+		Route("POST /account/1",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := io.ReadAll(req.Body)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				jws, err := jose.ParseSigned(string(body), []jose.SignatureAlgorithm{jose.RS256})
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				payload, err := jws.Verify(key.Public())
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				var account acme.Account
+
+				err = json.Unmarshal(payload, &account)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				account.Status = acme.StatusValid
+
+				servermock.JSONEncode(account).ServeHTTP(rw, req)
+			})).
+		BuildHTTPS(t)
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     &Resource{URI: server.URL + "/account/1"},
+		privatekey: key,
+	}
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", server.URL+"/account/1", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	res, err := registrar.UpdateContacts("new@example.com")
+	require.NoError(t, err)
+
+	expected := &Resource{
+		Body: acme.Account{
+			Status:  acme.StatusValid,
+			Contact: []string{"mailto:new@example.com"},
+		},
+		URI: server.URL + "/account/1",
+	}
+
+	assert.Equal(t, expected, res)
+
+	_, err = registrar.UpdateContacts()
+	require.EqualError(t, err, "acme: at least one contact is required")
+}
+
+func TestRegistrar_RolloverKey(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /keyChange", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Could not generate test key")
+
+	newKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     &Resource{URI: server.URL + "/account/1"},
+		privatekey: key,
+	}
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", server.URL+"/account/1", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	err = registrar.RolloverKey(nil)
+	require.EqualError(t, err, "acme: the new key is required")
+
+	err = registrar.RolloverKey(newKey)
+	require.NoError(t, err)
+}